@@ -0,0 +1,189 @@
+package orgdatacore
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// backstageGroup is a team, org, pillar or team group on its way to a
+// Backstage Group entity.
+type backstageGroup struct {
+	name, typ, description string
+	parent                 *ParentInfo
+	entityName             string
+	children               []string
+}
+
+// ExportBackstageCatalog writes the loaded teams, orgs, pillars, team groups
+// and employees as a Backstage catalog: a multi-document YAML stream of Group
+// and User entities, as read from catalog-info.yaml files. Groups carry their
+// type, parent and children, so the portal shows the same hierarchy;
+// users carry their name, email and the teams they belong to. Groups are
+// sorted by name and users by UID.
+//
+// Backstage names allow only letters, digits and the separators "-", "_" and
+// ".", so other characters become "-". Names shared by groups of different
+// types get the type appended, such as "platform-org", to keep entity
+// references unique. Without loaded data the export fails with ErrNoData.
+func (s *Service) ExportBackstageCatalog(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return ErrNoData
+	}
+	lookups := &s.data.Lookups
+
+	var groups []*backstageGroup
+	for _, t := range lookups.Teams {
+		groups = append(groups, &backstageGroup{name: t.Name, typ: "team", description: t.Description, parent: t.Parent})
+	}
+	for _, o := range lookups.Orgs {
+		groups = append(groups, &backstageGroup{name: o.Name, typ: "org", description: o.Description, parent: o.Parent})
+	}
+	for _, p := range lookups.Pillars {
+		groups = append(groups, &backstageGroup{name: p.Name, typ: "pillar", description: p.Description, parent: p.Parent})
+	}
+	for _, tg := range lookups.TeamGroups {
+		groups = append(groups, &backstageGroup{name: tg.Name, typ: "team_group", description: tg.Description, parent: tg.Parent})
+	}
+	slices.SortFunc(groups, func(a, b *backstageGroup) int {
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.typ, b.typ))
+	})
+
+	// Qualify names used by several groups, then resolve parents to the
+	// qualified names.
+	uses := make(map[string]int)
+	for _, g := range groups {
+		uses[backstageName(g.name)]++
+	}
+	byRef := make(map[string]*backstageGroup, len(groups))
+	for _, g := range groups {
+		g.entityName = backstageName(g.name)
+		if uses[g.entityName] > 1 {
+			g.entityName = backstageName(g.name + "-" + g.typ)
+		}
+		byRef[g.typ+":"+g.name] = g
+	}
+	for _, g := range groups {
+		if g.parent == nil {
+			continue
+		}
+		if parent, ok := byRef[g.parent.Type+":"+g.parent.Name]; ok {
+			parent.children = append(parent.children, g.entityName)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	first := true
+	document := func() {
+		if !first {
+			bw.WriteString("---\n")
+		}
+		first = false
+	}
+
+	for _, g := range groups {
+		document()
+		fmt.Fprintf(bw, "apiVersion: backstage.io/v1alpha1\nkind: Group\nmetadata:\n  name: %s\n", yamlQuote(g.entityName))
+		if g.entityName != g.name {
+			fmt.Fprintf(bw, "  title: %s\n", yamlQuote(g.name))
+		}
+		if g.description != "" {
+			fmt.Fprintf(bw, "  description: %s\n", yamlQuote(g.description))
+		}
+		fmt.Fprintf(bw, "spec:\n  type: %s\n  profile:\n    displayName: %s\n", yamlQuote(g.typ), yamlQuote(g.name))
+		if g.parent != nil {
+			if parent, ok := byRef[g.parent.Type+":"+g.parent.Name]; ok {
+				fmt.Fprintf(bw, "  parent: %s\n", yamlQuote(parent.entityName))
+			}
+		}
+		slices.Sort(g.children)
+		writeYAMLList(bw, "  children", g.children)
+	}
+
+	uids := make([]string, 0, len(lookups.Employees))
+	for uid := range lookups.Employees {
+		uids = append(uids, uid)
+	}
+	slices.Sort(uids)
+	for _, uid := range uids {
+		emp := lookups.Employees[uid]
+		var memberOf []string
+		for _, m := range s.data.Indexes.Membership.MembershipIndex[uid] {
+			if g, ok := byRef[m.Type+":"+m.Name]; ok && m.Type == "team" {
+				memberOf = append(memberOf, g.entityName)
+			}
+		}
+		slices.Sort(memberOf)
+		memberOf = slices.Compact(memberOf)
+
+		document()
+		fmt.Fprintf(bw, "apiVersion: backstage.io/v1alpha1\nkind: User\nmetadata:\n  name: %s\n", yamlQuote(backstageName(uid)))
+		bw.WriteString("spec:\n  profile:\n")
+		if emp.FullName != "" {
+			fmt.Fprintf(bw, "    displayName: %s\n", yamlQuote(emp.FullName))
+		}
+		if emp.Email != "" {
+			fmt.Fprintf(bw, "    email: %s\n", yamlQuote(emp.Email))
+		}
+		writeYAMLList(bw, "  memberOf", memberOf)
+	}
+	return bw.Flush()
+}
+
+// backstageName maps name to a valid Backstage entity name: at most 63
+// letters, digits and single separators, starting and ending with a letter
+// or digit.
+func backstageName(name string) string {
+	var b strings.Builder
+	// pending is the separator to write before the next letter or digit.
+	var pending byte
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9':
+			if pending != 0 && b.Len() > 0 {
+				b.WriteByte(pending)
+			}
+			pending = 0
+			b.WriteByte(c)
+		case c == '-' || c == '_' || c == '.':
+			if pending == 0 {
+				pending = c
+			}
+		default:
+			if pending == 0 {
+				pending = '-'
+			}
+		}
+	}
+	out := b.String()
+	if len(out) > 63 {
+		out = strings.TrimRight(out[:63], "-_.")
+	}
+	return out
+}
+
+// yamlQuote returns s as a YAML double-quoted scalar.
+func yamlQuote(s string) string {
+	return strconv.Quote(s)
+}
+
+// writeYAMLList writes key with items as a block sequence, or as [] when
+// there are none.
+func writeYAMLList(w *bufio.Writer, key string, items []string) {
+	if len(items) == 0 {
+		fmt.Fprintf(w, "%s: []\n", key)
+		return
+	}
+	fmt.Fprintf(w, "%s:\n", key)
+	indent := strings.Repeat(" ", len(key)-len(strings.TrimLeft(key, " ")))
+	for _, item := range items {
+		fmt.Fprintf(w, "%s  - %s\n", indent, yamlQuote(item))
+	}
+}
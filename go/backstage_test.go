@@ -0,0 +1,107 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExportBackstageCatalog(t *testing.T) {
+	var buf bytes.Buffer
+	if err := setupTestService(t).ExportBackstageCatalog(&buf); err != nil {
+		t.Fatalf("ExportBackstageCatalog: %v", err)
+	}
+	docs := strings.Split(buf.String(), "---\n")
+	// 6 groups and 3 users.
+	if len(docs) != 9 {
+		t.Fatalf("got %d documents, want 9:\n%s", len(docs), buf.String())
+	}
+
+	wantGroup := `apiVersion: backstage.io/v1alpha1
+kind: Group
+metadata:
+  name: "backend-teams"
+  description: "Backend engineering teams"
+spec:
+  type: "team_group"
+  profile:
+    displayName: "backend-teams"
+  parent: "engineering"
+  children:
+    - "platform-team"
+`
+	if docs[0] != wantGroup {
+		t.Errorf("first document =\n%s\nwant\n%s", docs[0], wantGroup)
+	}
+
+	wantUser := `apiVersion: backstage.io/v1alpha1
+kind: User
+metadata:
+  name: "jsmith"
+spec:
+  profile:
+    displayName: "John Smith"
+    email: "jsmith@example.com"
+  memberOf:
+    - "test-team"
+`
+	if docs[8] != wantUser {
+		t.Errorf("last document =\n%s\nwant\n%s", docs[8], wantUser)
+	}
+	if !strings.Contains(buf.String(), "displayName: \"test-org\"\n  children:\n    - \"platform-org\"\n    - \"test-team\"\n") {
+		t.Errorf("test-org is not the root of its children:\n%s", buf.String())
+	}
+}
+
+func TestExportBackstageCatalogNameCollision(t *testing.T) {
+	data := CreateTestData()
+	data.Lookups.Orgs["test-squad"] = Org{Name: "test-squad", Type: "org"}
+	squad := data.Lookups.Teams["test-squad"]
+	squad.Parent = &ParentInfo{Name: "test-squad", Type: "org"}
+	data.Lookups.Teams["test-squad"] = squad
+
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "collision", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := service.ExportBackstageCatalog(&buf); err != nil {
+		t.Fatalf("ExportBackstageCatalog: %v", err)
+	}
+	for _, want := range []string{
+		"name: \"test-squad-org\"\n  title: \"test-squad\"\n",
+		"name: \"test-squad-team\"\n  title: \"test-squad\"\n",
+		"parent: \"test-squad-org\"\n",
+		"memberOf:\n    - \"test-squad-team\"\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("catalog lacks %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestBackstageName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"test-team", "test-team"},
+		{"Platform & Tools", "Platform-Tools"},
+		{"--odd__name..", "odd_name"},
+		{"ÜberTeam", "berTeam"},
+		{strings.Repeat("a", 62) + "-b", strings.Repeat("a", 62)},
+	}
+	for _, tt := range tests {
+		if got := backstageName(tt.in); got != tt.want {
+			t.Errorf("backstageName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportBackstageCatalogNoData(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewService().ExportBackstageCatalog(&buf); !errors.Is(err, ErrNoData) {
+		t.Errorf("ExportBackstageCatalog without data = %v, want ErrNoData", err)
+	}
+}
@@ -2,7 +2,9 @@ package orgdatacore
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
@@ -67,22 +69,46 @@ func BenchmarkGetTeamByName(b *testing.B) {
 func BenchmarkIsEmployeeInTeam(b *testing.B) {
 	service := setupBenchmarkService(b)
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		service.IsEmployeeInTeam("jsmith", "test-team")
 	}
 }
 
+// BenchmarkIsSlackUserInTeam benchmarks team membership checks by Slack ID
+func BenchmarkIsSlackUserInTeam(b *testing.B) {
+	service := setupBenchmarkService(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		service.IsSlackUserInTeam("U12345678", "test-team")
+	}
+}
+
 // BenchmarkIsEmployeeInOrg benchmarks organization membership checks
 func BenchmarkIsEmployeeInOrg(b *testing.B) {
 	service := setupBenchmarkService(b)
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		service.IsEmployeeInOrg("jsmith", "test-org")
 	}
 }
 
+// BenchmarkIsSlackUserInOrg benchmarks inherited organization membership checks by Slack ID
+func BenchmarkIsSlackUserInOrg(b *testing.B) {
+	service := setupBenchmarkService(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		service.IsSlackUserInOrg("U98765432", "test-org")
+	}
+}
+
 // BenchmarkGetTeamsForUID benchmarks team list retrieval
 func BenchmarkGetTeamsForUID(b *testing.B) {
 	service := setupBenchmarkService(b)
@@ -124,3 +150,170 @@ func BenchmarkConcurrentReads(b *testing.B) {
 		}
 	})
 }
+
+// largeDatasetSizes are the employee counts used by the large-dataset benchmarks.
+var largeDatasetSizes = []int{10_000, 50_000, 200_000}
+
+var (
+	largeDatasetMu    sync.Mutex
+	largeDatasetCache = map[int][]byte{}
+)
+
+// largeDataset returns a generated dataset with n employees, caching the JSON
+// across benchmarks. Team count scales with n (roughly 10 people per team).
+func largeDataset(n int) []byte {
+	largeDatasetMu.Lock()
+	defer largeDatasetMu.Unlock()
+	if data, ok := largeDatasetCache[n]; ok {
+		return data
+	}
+	data := testingsupport.GenerateDataset(n, n/10, 5)
+	largeDatasetCache[n] = data
+	return data
+}
+
+// setupLargeBenchmarkService creates a service loaded with a generated dataset of n employees
+func setupLargeBenchmarkService(b *testing.B, n int) *Service {
+	b.Helper()
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(string(largeDataset(n)))); err != nil {
+		b.Fatalf("Failed to load generated data: %v", err)
+	}
+	return service
+}
+
+// BenchmarkLargeLoad benchmarks a full load of generated datasets
+func BenchmarkLargeLoad(b *testing.B) {
+	for _, n := range largeDatasetSizes {
+		b.Run(fmt.Sprintf("employees=%d", n), func(b *testing.B) {
+			source := NewFakeDataSource(string(largeDataset(n)))
+			b.SetBytes(int64(len(source.Data)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := NewService().LoadFromDataSource(context.Background(), source); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLargeLoadWithoutConsistencyChecks benchmarks a full load of
+// generated datasets under WithoutConsistencyChecks, for comparison with
+// BenchmarkLargeLoad
+func BenchmarkLargeLoadWithoutConsistencyChecks(b *testing.B) {
+	for _, n := range largeDatasetSizes {
+		b.Run(fmt.Sprintf("employees=%d", n), func(b *testing.B) {
+			source := NewFakeDataSource(string(largeDataset(n)))
+			b.SetBytes(int64(len(source.Data)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := NewService(WithoutConsistencyChecks()).LoadFromDataSource(context.Background(), source); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLargeQueries benchmarks hot-path queries against generated datasets
+func BenchmarkLargeQueries(b *testing.B) {
+	for _, n := range largeDatasetSizes {
+		service := setupLargeBenchmarkService(b, n)
+		uid := fmt.Sprintf("user%d", n/2)
+		slackID := fmt.Sprintf("U%d", n/2)
+		team := service.GetTeamsForUID(uid)[0]
+
+		b.Run(fmt.Sprintf("GetEmployeeByUID/employees=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.GetEmployeeByUID(uid)
+			}
+		})
+		b.Run(fmt.Sprintf("GetEmployeeByEmail/employees=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.GetEmployeeByEmail(uid + "@example.com")
+			}
+		})
+		b.Run(fmt.Sprintf("IsEmployeeInTeam/employees=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.IsEmployeeInTeam(uid, team)
+			}
+		})
+		b.Run(fmt.Sprintf("IsEmployeeInOrg/employees=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.IsEmployeeInOrg(uid, "org-0-0")
+			}
+		})
+		b.Run(fmt.Sprintf("GetUserOrganizations/employees=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.GetUserOrganizations(slackID)
+			}
+		})
+		b.Run(fmt.Sprintf("GetTeamMembers/employees=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				service.GetTeamMembers(team)
+			}
+		})
+	}
+}
+
+// BenchmarkLargeConcurrentReads benchmarks mixed parallel reads against generated datasets
+func BenchmarkLargeConcurrentReads(b *testing.B) {
+	for _, n := range largeDatasetSizes {
+		service := setupLargeBenchmarkService(b, n)
+		b.Run(fmt.Sprintf("employees=%d", n), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					uid := fmt.Sprintf("user%d", i%n)
+					switch i % 3 {
+					case 0:
+						service.GetEmployeeByUID(uid)
+					case 1:
+						service.GetTeamsForUID(uid)
+					case 2:
+						service.IsEmployeeInOrg(uid, "org-0-0")
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkLargeLoadEagerIndexes benchmarks loads that build every secondary index up front
+func BenchmarkLargeLoadEagerIndexes(b *testing.B) {
+	all := []IndexKind{IndexEmail, IndexSlackChannel, IndexManager, IndexRepo}
+	for _, n := range largeDatasetSizes {
+		b.Run(fmt.Sprintf("employees=%d", n), func(b *testing.B) {
+			source := NewFakeDataSource(string(largeDataset(n)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := NewService(WithEagerIndexes(all...)).LoadFromDataSource(context.Background(), source); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkLargeLoadSections benchmarks a load that keeps only employee and Slack lookups
+func BenchmarkLargeLoadSections(b *testing.B) {
+	for _, n := range largeDatasetSizes {
+		b.Run(fmt.Sprintf("employees=%d", n), func(b *testing.B) {
+			source := NewFakeDataSource(string(largeDataset(n)))
+			b.SetBytes(int64(len(source.Data)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				service := NewService(WithSections(SectionEmployees, SectionSlackIDs))
+				if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
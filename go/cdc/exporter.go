@@ -0,0 +1,175 @@
+// Package cdc exports a change-data-capture feed of org data. An Exporter
+// turns every reload of a service into a Record holding the ChangeSet
+// between the previous and the new data, and appends it as one line of
+// JSON to a Sink, giving data warehouses an incremental history of the org
+// over time:
+//
+//	exporter := cdc.New(service, cdc.NewFileSink("/var/lib/orgdata/changes.jsonl"))
+//	go exporter.Run(ctx)
+//	err := service.StartDataSourceWatcher(ctx, source)
+//
+// Sinks are provided for local files, Pub/Sub topics and, with the gcs
+// build tag, GCS objects.
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// Record is one line of the feed. The ChangeSet fields are inlined, so each
+// line also carries base_version, data_version and the upserted and removed
+// entities.
+type Record struct {
+	// Sequence numbers the records written by one Exporter, starting at 1.
+	// It restarts with the process; use Checksum to deduplicate.
+	Sequence uint64 `json:"sequence"`
+	// Time is when the reload happened.
+	Time time.Time `json:"time"`
+	// PreviousChecksum and Checksum identify the data before and after the
+	// reload, as reported by Service.GetVersion.
+	PreviousChecksum string `json:"previous_checksum"`
+	Checksum         string `json:"checksum"`
+	*orgdatacore.ChangeSet
+}
+
+// Sink receives the feed.
+type Sink interface {
+	// Append durably appends lines, one or more newline-terminated JSON
+	// records, to the feed. On error the same lines are appended again
+	// later, so a Sink should not write them partially.
+	Append(ctx context.Context, lines []byte) error
+	String() string
+}
+
+// Exporter appends the changes of every reload of a service to a Sink.
+type Exporter struct {
+	svc        *orgdatacore.Service
+	sink       Sink
+	logger     *slog.Logger
+	retryDelay time.Duration
+
+	mu      sync.Mutex
+	pending []Record
+	seq     uint64
+	ready   chan struct{}
+}
+
+// New returns an Exporter writing the changes of svc to sink.
+func New(svc *orgdatacore.Service, sink Sink) *Exporter {
+	return &Exporter{
+		svc:        svc,
+		sink:       sink,
+		logger:     slog.Default(),
+		retryDelay: 10 * time.Second,
+		ready:      make(chan struct{}, 1),
+	}
+}
+
+// WithRetryDelay sets how long Run waits before retrying a failed append,
+// 10 seconds by default, and returns e.
+func (e *Exporter) WithRetryDelay(d time.Duration) *Exporter {
+	if d > 0 {
+		e.retryDelay = d
+	}
+	return e
+}
+
+// WithLogger sets the logger used by Run, slog.Default by default, and
+// returns e.
+func (e *Exporter) WithLogger(logger *slog.Logger) *Exporter {
+	if logger != nil {
+		e.logger = logger
+	}
+	return e
+}
+
+// Run records every reload of the service until ctx is cancelled. Start it
+// before the service loads data: the first load and reloads that change
+// nothing produce no record.
+//
+// Reload callbacks only queue their record, so a slow sink does not delay
+// reloads. Records queued while an append is in progress are written
+// together in the next one. Failed appends are logged and retried, in
+// order, after the retry delay. Records still queued when ctx is cancelled
+// are lost.
+func (e *Exporter) Run(ctx context.Context) {
+	unsubscribe := e.svc.OnReloadChanges(e.enqueue)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.ready:
+		}
+		for !e.flush(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(e.retryDelay):
+			}
+		}
+	}
+}
+
+// enqueue queues the record of one reload for the next flush.
+func (e *Exporter) enqueue(old, new orgdatacore.DataVersion, changes *orgdatacore.ChangeSet) {
+	if changes == nil || changes.IsEmpty() {
+		return
+	}
+	e.mu.Lock()
+	e.seq++
+	e.pending = append(e.pending, Record{
+		Sequence:         e.seq,
+		Time:             new.LoadTime.UTC(),
+		PreviousChecksum: old.Checksum,
+		Checksum:         new.Checksum,
+		ChangeSet:        changes,
+	})
+	e.mu.Unlock()
+	select {
+	case e.ready <- struct{}{}:
+	default:
+	}
+}
+
+// flush appends the queued records to the sink and reports whether it
+// succeeded. Records are only dropped from the queue once appended.
+func (e *Exporter) flush(ctx context.Context) bool {
+	e.mu.Lock()
+	records := e.pending
+	e.mu.Unlock()
+	if len(records) == 0 {
+		return true
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			// Data that was loaded always encodes; drop the record rather
+			// than block the feed on it.
+			e.logger.Error("failed to encode change record", "sequence", r.Sequence, "error", err)
+		}
+	}
+	if err := e.sink.Append(ctx, buf.Bytes()); err != nil {
+		if ctx.Err() == nil {
+			e.logger.Error("failed to append changes", "sink", e.sink.String(), "records", len(records), "error", err)
+		}
+		return false
+	}
+
+	e.mu.Lock()
+	e.pending = append([]Record{}, e.pending[len(records):]...)
+	e.mu.Unlock()
+	e.logger.Info("appended changes", "sink", e.sink.String(), "records", len(records),
+		"last_sequence", records[len(records)-1].Sequence)
+	return true
+}
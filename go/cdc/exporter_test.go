@@ -0,0 +1,248 @@
+package cdc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// memorySink collects appended lines, failing the first failures appends.
+type memorySink struct {
+	mu       sync.Mutex
+	failures int
+	appends  int
+	lines    []Record
+}
+
+func (m *memorySink) Append(_ context.Context, lines []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.appends++
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("sink unavailable")
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(lines))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return err
+		}
+		m.lines = append(m.lines, r)
+	}
+	return nil
+}
+
+func (m *memorySink) String() string { return "memory" }
+
+func (m *memorySink) records() []Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Record{}, m.lines...)
+}
+
+func loadedService(t *testing.T) *orgdatacore.Service {
+	t.Helper()
+	service := orgdatacore.NewService()
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	return service
+}
+
+func hire(t *testing.T, service *orgdatacore.Service, uid string) {
+	t.Helper()
+	if err := service.ApplyChangeSet(orgdatacore.ChangeSet{
+		DataVersion:       "v-" + uid,
+		EmployeesUpserted: map[string]orgdatacore.Employee{uid: {UID: uid, ManagerUID: "testuser1"}},
+	}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+}
+
+func TestExporterRecords(t *testing.T) {
+	ctx := context.Background()
+	service := loadedService(t)
+	sink := &memorySink{failures: 1}
+	exporter := New(service, sink)
+	unsubscribe := service.OnReloadChanges(exporter.enqueue)
+	defer unsubscribe()
+
+	before := service.GetVersion()
+	hire(t, service, "newhire1")
+	middle := service.GetVersion()
+	// Reloading the same data produces no record.
+	if err := service.LoadFromDataSource(ctx, orgdatacore.NewFakeDataSource(mustDump(t, service))); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	reloaded := service.GetVersion()
+	hire(t, service, "newhire2")
+	after := service.GetVersion()
+
+	if exporter.flush(ctx) {
+		t.Fatal("flush succeeded although the sink failed")
+	}
+	if !exporter.flush(ctx) {
+		t.Fatal("retried flush failed")
+	}
+	if !exporter.flush(ctx) || sink.appends != 2 {
+		t.Errorf("appends = %d, want 2: an empty queue must not append", sink.appends)
+	}
+
+	records := sink.records()
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	tests := []struct {
+		sequence       uint64
+		previous, next orgdatacore.DataVersion
+		added          string
+		dataVersion    string
+	}{
+		{1, before, middle, "newhire1", "v-newhire1"},
+		{2, reloaded, after, "newhire2", "v-newhire2"},
+	}
+	for i, tt := range tests {
+		r := records[i]
+		if r.Sequence != tt.sequence || r.PreviousChecksum != tt.previous.Checksum || r.Checksum != tt.next.Checksum {
+			t.Errorf("record %d = sequence %d, %s -> %s; want %d, %s -> %s", i,
+				r.Sequence, r.PreviousChecksum, r.Checksum, tt.sequence, tt.previous.Checksum, tt.next.Checksum)
+		}
+		if r.ChangeSet == nil || r.DataVersion != tt.dataVersion || len(r.EmployeesAdded) != 1 || r.EmployeesAdded[0] != tt.added {
+			t.Errorf("record %d changes = %+v, want %s added in %s", i, r.ChangeSet, tt.added, tt.dataVersion)
+		}
+		if !r.Time.Equal(tt.next.LoadTime) {
+			t.Errorf("record %d time = %v, want %v", i, r.Time, tt.next.LoadTime)
+		}
+	}
+}
+
+func mustDump(t *testing.T, service *orgdatacore.Service) string {
+	t.Helper()
+	dump, _, err := service.ExportDump()
+	if err != nil {
+		t.Fatalf("ExportDump: %v", err)
+	}
+	return string(dump)
+}
+
+func TestExporterRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service := loadedService(t)
+	sink := &memorySink{failures: 1}
+	exporter := New(service, sink).WithRetryDelay(10 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		exporter.Run(ctx)
+		close(done)
+	}()
+
+	// Run subscribes asynchronously, so keep changing the data until the
+	// first change is recorded.
+	deadline := time.Now().Add(5 * time.Second)
+	for i := 0; len(sink.records()) == 0; i++ {
+		if time.Now().After(deadline) {
+			t.Fatal("no record was appended")
+		}
+		hire(t, service, fmt.Sprintf("newhire%d", i))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+	if r := sink.records()[0]; r.Sequence != 1 {
+		t.Errorf("first record sequence = %d, want 1", r.Sequence)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "changes.jsonl")
+	sink := NewFileSink(path)
+	for _, lines := range []string{"{\"sequence\":1}\n", "{\"sequence\":2}\n{\"sequence\":3}\n"} {
+		if err := sink.Append(ctx, []byte(lines)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"sequence\":1}\n{\"sequence\":2}\n{\"sequence\":3}\n"; string(got) != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+	if sink.String() != "file:"+path {
+		t.Errorf("String = %q", sink.String())
+	}
+}
+
+func TestPubSubSink(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		lines    string
+		wantErr  bool
+		wantMsgs []string
+	}{
+		{"publishes each line", http.StatusOK, "{\"sequence\":1}\n{\"sequence\":2}\n", false, []string{`{"sequence":1}`, `{"sequence":2}`}},
+		{"nothing to publish", http.StatusOK, "", false, nil},
+		{"server error", http.StatusForbidden, "{\"sequence\":1}\n", true, []string{`{"sequence":1}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			var got []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.Method + " " + r.URL.Path
+				body, _ := io.ReadAll(r.Body)
+				var request struct {
+					Messages []struct {
+						Data []byte `json:"data"`
+					} `json:"messages"`
+				}
+				if err := json.Unmarshal(body, &request); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				for _, m := range request.Messages {
+					got = append(got, string(m.Data))
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer ts.Close()
+
+			sink := NewPubSubSink("proj", "org-changes", ts.Client()).WithEndpoint(ts.URL)
+			err := sink.Append(context.Background(), []byte(tt.lines))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Append error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.wantMsgs) {
+				t.Fatalf("messages = %q, want %q", got, tt.wantMsgs)
+			}
+			for i := range got {
+				if got[i] != tt.wantMsgs[i] {
+					t.Errorf("message %d = %s, want %s", i, got[i], tt.wantMsgs[i])
+				}
+			}
+			if tt.wantMsgs != nil && path != "POST /v1/projects/proj/topics/org-changes:publish" {
+				t.Errorf("request = %s", path)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//go:build gcs
+
+package cdc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// GCSSink writes the feed to GCS. GCS objects cannot be appended to, so
+// every append creates a new object under a prefix, named after the time of
+// the append so that listing the prefix returns the feed in order. Tools
+// such as BigQuery external tables read the prefix as one JSON Lines table.
+type GCSSink struct {
+	client *storage.Client
+	bucket string
+	prefix string
+}
+
+// NewGCSSink returns a GCSSink creating objects in bucket whose names start
+// with prefix, for example "orgdata/changes/".
+func NewGCSSink(client *storage.Client, bucket, prefix string) *GCSSink {
+	return &GCSSink{client: client, bucket: bucket, prefix: prefix}
+}
+
+// Append writes lines to a new object. The object only becomes visible once
+// fully written, and is never overwritten.
+func (g *GCSSink) Append(ctx context.Context, lines []byte) error {
+	name := g.prefix + time.Now().UTC().Format("20060102T150405.000000000Z") + ".jsonl"
+	w := g.client.Bucket(g.bucket).Object(name).If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	w.ContentType = "application/x-ndjson"
+	if _, err := w.Write(lines); err != nil {
+		w.Close()
+		return fmt.Errorf("cdc: write gs://%s/%s: %w", g.bucket, name, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("cdc: write gs://%s/%s: %w", g.bucket, name, err)
+	}
+	return nil
+}
+
+func (g *GCSSink) String() string {
+	return fmt.Sprintf("gs://%s/%s", g.bucket, g.prefix)
+}
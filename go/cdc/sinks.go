@@ -0,0 +1,133 @@
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FileSink appends the feed to a local file, creating it if needed.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink returns a FileSink appending to path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Append writes lines to the end of the file and syncs it. If the write
+// fails part way, the file is truncated back to its previous size.
+func (f *FileSink) Append(_ context.Context, lines []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(lines); err != nil {
+		_ = file.Truncate(info.Size())
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *FileSink) String() string {
+	return "file:" + f.path
+}
+
+// defaultPubSubEndpoint is the Pub/Sub REST API.
+const defaultPubSubEndpoint = "https://pubsub.googleapis.com"
+
+// PubSubSink publishes each record as one message to a Pub/Sub topic, using
+// the Pub/Sub REST API. Messages are limited to 10 MB, so a reload changing
+// most of a large org may not fit.
+type PubSubSink struct {
+	project  string
+	topic    string
+	endpoint string
+	http     *http.Client
+}
+
+// NewPubSubSink returns a PubSubSink publishing to topic in project. hc must
+// authenticate its requests, for example one returned by
+// golang.org/x/oauth2/google.DefaultClient with the Pub/Sub scope.
+func NewPubSubSink(project, topic string, hc *http.Client) *PubSubSink {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &PubSubSink{project: project, topic: topic, endpoint: defaultPubSubEndpoint, http: hc}
+}
+
+// WithEndpoint sets the Pub/Sub API endpoint, for example the emulator's,
+// and returns p.
+func (p *PubSubSink) WithEndpoint(endpoint string) *PubSubSink {
+	if endpoint != "" {
+		p.endpoint = strings.TrimRight(endpoint, "/")
+	}
+	return p
+}
+
+// Append publishes every line as a message in a single request, so either
+// all of them are published or none are.
+func (p *PubSubSink) Append(ctx context.Context, lines []byte) error {
+	type message struct {
+		Data []byte `json:"data"`
+	}
+	var request struct {
+		Messages []message `json:"messages"`
+	}
+	request.Messages = []message{}
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if line = bytes.TrimSuffix(line, []byte("\n")); len(line) > 0 {
+			request.Messages = append(request.Messages, message{Data: line})
+		}
+	}
+	if len(request.Messages) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish", p.endpoint, url.PathEscape(p.project), url.PathEscape(p.topic))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("cdc: publish to %s: %d %s", p, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+func (p *PubSubSink) String() string {
+	return fmt.Sprintf("pubsub:projects/%s/topics/%s", p.project, p.topic)
+}
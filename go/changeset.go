@@ -0,0 +1,339 @@
+package orgdatacore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ChangeSet describes an incremental update to the loaded organizational data.
+//
+// Upserted entities replace any existing entity with the same key; removed keys
+// are deleted. Removals are applied before upserts, so a key present in both
+// ends up with the upserted value.
+type ChangeSet struct {
+	// BaseVersion, if set, must equal the data_version of the currently loaded
+	// data. A mismatch means the change set was computed against other data
+	// and is rejected with ErrChangeSetConflict.
+	BaseVersion string `json:"base_version,omitempty"`
+	// DataVersion and GeneratedAt, if set, replace the corresponding metadata
+	// fields once the change set is applied.
+	DataVersion string `json:"data_version,omitempty"`
+	GeneratedAt string `json:"generated_at,omitempty"`
+
+	EmployeesUpserted  map[string]Employee  `json:"employees_upserted,omitempty"`
+	EmployeesRemoved   []string             `json:"employees_removed,omitempty"`
+	TeamsUpserted      map[string]Team      `json:"teams_upserted,omitempty"`
+	TeamsRemoved       []string             `json:"teams_removed,omitempty"`
+	OrgsUpserted       map[string]Org       `json:"orgs_upserted,omitempty"`
+	OrgsRemoved        []string             `json:"orgs_removed,omitempty"`
+	PillarsUpserted    map[string]Pillar    `json:"pillars_upserted,omitempty"`
+	PillarsRemoved     []string             `json:"pillars_removed,omitempty"`
+	TeamGroupsUpserted map[string]TeamGroup `json:"team_groups_upserted,omitempty"`
+	TeamGroupsRemoved  []string             `json:"team_groups_removed,omitempty"`
+
+	// MembershipUpserted replaces the full membership list for each UID.
+	// Memberships of removed employees are dropped automatically.
+	MembershipUpserted map[string][]MembershipInfo `json:"membership_upserted,omitempty"`
+
+	// The remaining fields summarize the change in domain terms. They are
+	// filled in by DiffSnapshots and ignored by ApplyChangeSet.
+
+	// EmployeesAdded lists the UIDs in EmployeesUpserted that are new.
+	EmployeesAdded    []string           `json:"employees_added,omitempty"`
+	MembershipChanges []MembershipChange `json:"membership_changes,omitempty"`
+	ManagerChanges    []ManagerChange    `json:"manager_changes,omitempty"`
+	HierarchyMoves    []HierarchyMove    `json:"hierarchy_moves,omitempty"`
+}
+
+// MembershipChange records the teams an employee joined or left.
+type MembershipChange struct {
+	UID          string   `json:"uid"`
+	TeamsAdded   []string `json:"teams_added,omitempty"`
+	TeamsRemoved []string `json:"teams_removed,omitempty"`
+}
+
+// ManagerChange records an employee whose manager changed.
+type ManagerChange struct {
+	UID           string `json:"uid"`
+	OldManagerUID string `json:"old_manager_uid"`
+	NewManagerUID string `json:"new_manager_uid"`
+}
+
+// HierarchyMove records a team, org, pillar, or team group whose parent changed.
+// A nil parent means the entity was a root.
+type HierarchyMove struct {
+	Name      string      `json:"name"`
+	Type      EntityType  `json:"type"`
+	OldParent *ParentInfo `json:"old_parent,omitempty"`
+	NewParent *ParentInfo `json:"new_parent,omitempty"`
+}
+
+// IsEmpty reports whether the change set contains no entity or membership changes.
+func (c *ChangeSet) IsEmpty() bool {
+	return len(c.EmployeesUpserted) == 0 && len(c.EmployeesRemoved) == 0 &&
+		len(c.TeamsUpserted) == 0 && len(c.TeamsRemoved) == 0 &&
+		len(c.OrgsUpserted) == 0 && len(c.OrgsRemoved) == 0 &&
+		len(c.PillarsUpserted) == 0 && len(c.PillarsRemoved) == 0 &&
+		len(c.TeamGroupsUpserted) == 0 && len(c.TeamGroupsRemoved) == 0 &&
+		len(c.MembershipUpserted) == 0
+}
+
+// changeSetSource is the source reported in events and load stats for
+// change sets applied directly with ApplyChangeSet.
+const changeSetSource = "changeset"
+
+// ApplyChangeSet applies an incremental update to the loaded data.
+//
+// The current snapshot is never mutated: a new snapshot is built that shares
+// every unchanged map with the old one and copies only the maps the change set
+// touches, so small updates avoid a full re-decode of the dump. Concurrent
+// readers keep seeing the old snapshot until the new one is swapped in.
+func (s *Service) ApplyChangeSet(cs ChangeSet) error {
+	attempt := s.startLoad(changeSetSource)
+	err := s.applyChanges(cs, attempt)
+	s.finishLoad(attempt, err)
+	return err
+}
+
+func (s *Service) applyChanges(cs ChangeSet, attempt *loadAttempt) error {
+	s.mu.RLock()
+	base, baseChecksum := s.data, s.version.Checksum
+	s.mu.RUnlock()
+
+	if base == nil {
+		return ErrNoData
+	}
+	if cs.BaseVersion != "" && cs.BaseVersion != base.Metadata.DataVersion {
+		return fmt.Errorf("%w: change set is based on %q but %q is loaded",
+			ErrChangeSetConflict, cs.BaseVersion, base.Metadata.DataVersion)
+	}
+
+	start := time.Now()
+	next := applyChangeSet(base, &cs)
+	reapplyOverlays(next, &cs)
+	start = timePhase(&attempt.phases.Decode, start)
+	if _, err := s.validate(next, attempt); err != nil {
+		return err
+	}
+	start = timePhase(&attempt.phases.Validate, start)
+	indexes := s.prepareIndexes(next, changeSetChecksum(baseChecksum, &cs))
+	start = timePhase(&attempt.phases.Index, start)
+
+	s.mu.Lock()
+	if s.data != base {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: data was reloaded while the change set was being applied", ErrChangeSetConflict)
+	}
+	ev := s.installData(next, indexes)
+	s.mu.Unlock()
+	timePhase(&attempt.phases.Swap, start)
+	attempt.install(ev, true)
+
+	s.loggerFor(LogLoader).Info("change set applied", "data_version", next.Metadata.DataVersion,
+		"employees", ev.newVersion.EmployeeCount, "orgs", ev.newVersion.OrgCount)
+	s.publishReload(ev)
+	s.persistLastKnownGood(ev)
+	return nil
+}
+
+// changeSetChecksum returns the checksum of the data reached by applying cs
+// to data whose checksum is base: the SHA-256 of base followed by cs encoded
+// as JSON, so replicas applying the same change sets to the same dump agree.
+func changeSetChecksum(base string, cs *ChangeSet) string {
+	h := sha256.New()
+	h.Write([]byte(base))
+	if err := json.NewEncoder(h).Encode(cs); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LoadChangesFromDataSource fetches the changes since the currently loaded
+// data version from source and applies them. If no data is loaded yet, or the
+// source reports ErrFullReloadRequired or a conflicting change set, it falls
+// back to a full LoadFromDataSource.
+func (s *Service) LoadChangesFromDataSource(ctx context.Context, source DeltaDataSource) error {
+	s.setLastSource(source)
+	attempt := s.startLoad(source.String())
+	err := s.loadChangesFromDataSource(ctx, source, attempt)
+	s.finishLoad(attempt, err)
+	return err
+}
+
+func (s *Service) loadChangesFromDataSource(ctx context.Context, source DeltaDataSource, attempt *loadAttempt) error {
+	s.mu.RLock()
+	loaded := s.data != nil
+	var since string
+	if loaded {
+		since = s.data.Metadata.DataVersion
+	}
+	s.mu.RUnlock()
+
+	if !loaded {
+		return s.loadFromDataSource(ctx, source, attempt)
+	}
+
+	start := time.Now()
+	cs, err := source.LoadChanges(ctx, since)
+	timePhase(&attempt.phases.Download, start)
+	if errors.Is(err, ErrFullReloadRequired) {
+		s.loggerFor(LogLoader).Info("delta unavailable, performing full reload", "source", source.String(), "since", since)
+		return s.loadFromDataSource(ctx, source, attempt)
+	}
+	if err != nil {
+		return NewLoadError(source.String(), err)
+	}
+	if cs == nil || cs.IsEmpty() && cs.DataVersion == "" {
+		return nil
+	}
+
+	err = s.applyChanges(*cs, attempt)
+	if errors.Is(err, ErrChangeSetConflict) {
+		s.loggerFor(LogLoader).Warn("change set conflict, performing full reload", "source", source.String(), "error", err)
+		return s.loadFromDataSource(ctx, source, attempt)
+	}
+	if err != nil {
+		return NewLoadError(source.String(), err)
+	}
+	return nil
+}
+
+// refreshFromDataSource reloads from source, using incremental changes when
+// the source supports them.
+func (s *Service) refreshFromDataSource(ctx context.Context, source DataSource) error {
+	if delta, ok := source.(DeltaDataSource); ok {
+		return s.LoadChangesFromDataSource(ctx, delta)
+	}
+	return s.LoadFromDataSource(ctx, source)
+}
+
+// applyChangeSet returns a new Data with cs applied to base. base is not
+// modified; maps untouched by cs are shared between base and the result.
+func applyChangeSet(base *Data, cs *ChangeSet) *Data {
+	next := *base
+
+	next.Lookups.Employees = cowApply(base.Lookups.Employees, cs.EmployeesUpserted, cs.EmployeesRemoved)
+	next.Lookups.Teams = cowApply(base.Lookups.Teams, cs.TeamsUpserted, cs.TeamsRemoved)
+	next.Lookups.Orgs = cowApply(base.Lookups.Orgs, cs.OrgsUpserted, cs.OrgsRemoved)
+	next.Lookups.Pillars = cowApply(base.Lookups.Pillars, cs.PillarsUpserted, cs.PillarsRemoved)
+	next.Lookups.TeamGroups = cowApply(base.Lookups.TeamGroups, cs.TeamGroupsUpserted, cs.TeamGroupsRemoved)
+	next.Indexes.Membership.MembershipIndex = pruneMemberships(
+		cowApply(base.Indexes.Membership.MembershipIndex, cs.MembershipUpserted, cs.EmployeesRemoved), cs)
+
+	// Keep the Slack and GitHub ID mappings consistent with employee changes.
+	if len(cs.EmployeesUpserted) > 0 || len(cs.EmployeesRemoved) > 0 {
+		slack := cloneMap(base.Indexes.SlackIDMappings.SlackUIDToUID)
+		github := cloneMap(base.Indexes.GitHubIDMappings.GitHubIDToUID)
+
+		unmap := func(uid string) {
+			old, ok := base.Lookups.Employees[uid]
+			if !ok {
+				return
+			}
+			if old.SlackUID != "" && slack[old.SlackUID] == uid {
+				delete(slack, old.SlackUID)
+			}
+			if old.GitHubID != "" && github[old.GitHubID] == uid {
+				delete(github, old.GitHubID)
+			}
+		}
+		for _, uid := range cs.EmployeesRemoved {
+			unmap(uid)
+		}
+		for uid, emp := range cs.EmployeesUpserted {
+			unmap(uid)
+			if emp.SlackUID != "" {
+				slack[emp.SlackUID] = uid
+			}
+			if emp.GitHubID != "" {
+				github[emp.GitHubID] = uid
+			}
+		}
+		next.Indexes.SlackIDMappings.SlackUIDToUID = slack
+		next.Indexes.GitHubIDMappings.GitHubIDToUID = github
+	}
+
+	if cs.DataVersion != "" {
+		next.Metadata.DataVersion = cs.DataVersion
+	}
+	if cs.GeneratedAt != "" {
+		next.Metadata.GeneratedAt = cs.GeneratedAt
+	}
+	next.Metadata.TotalEmployees = len(next.Lookups.Employees)
+	next.Metadata.TotalOrgs = len(next.Lookups.Orgs)
+	next.Metadata.TotalTeams = len(next.Lookups.Teams)
+
+	return &next
+}
+
+// pruneMemberships returns the membership index m without the memberships
+// of the teams, orgs, pillars and team groups cs removes and does not upsert
+// again. m is returned as is if nothing is pruned; otherwise it is copied.
+func pruneMemberships(m map[string][]MembershipInfo, cs *ChangeSet) map[string][]MembershipInfo {
+	removed := make(map[MembershipInfo]bool)
+	mark := func(typ MembershipType, names []string, upserted func(string) bool) {
+		for _, name := range names {
+			if !upserted(name) {
+				removed[MembershipInfo{Name: name, Type: string(typ)}] = true
+			}
+		}
+	}
+	mark(MembershipTeam, cs.TeamsRemoved, func(n string) bool { _, ok := cs.TeamsUpserted[n]; return ok })
+	mark(MembershipOrg, cs.OrgsRemoved, func(n string) bool { _, ok := cs.OrgsUpserted[n]; return ok })
+	mark(MembershipPillar, cs.PillarsRemoved, func(n string) bool { _, ok := cs.PillarsUpserted[n]; return ok })
+	mark(MembershipTeamGroup, cs.TeamGroupsRemoved, func(n string) bool { _, ok := cs.TeamGroupsUpserted[n]; return ok })
+	if len(removed) == 0 {
+		return m
+	}
+
+	var out map[string][]MembershipInfo
+	for uid, memberships := range m {
+		if !slices.ContainsFunc(memberships, func(mi MembershipInfo) bool { return removed[mi] }) {
+			continue
+		}
+		if out == nil {
+			out = cloneMap(m)
+		}
+		kept := slices.DeleteFunc(slices.Clone(memberships), func(mi MembershipInfo) bool { return removed[mi] })
+		if len(kept) == 0 {
+			delete(out, uid)
+		} else {
+			out[uid] = kept
+		}
+	}
+	if out == nil {
+		return m
+	}
+	return out
+}
+
+// cowApply returns m with removals and upserts applied. When there is nothing
+// to change, m itself is returned; otherwise a modified copy is returned and m
+// is left untouched.
+func cowApply[V any](m map[string]V, upserts map[string]V, removals []string) map[string]V {
+	if len(upserts) == 0 && len(removals) == 0 {
+		return m
+	}
+	out := cloneMap(m)
+	for _, k := range removals {
+		delete(out, k)
+	}
+	for k, v := range upserts {
+		out[k] = v
+	}
+	return out
+}
+
+func cloneMap[V any](m map[string]V) map[string]V {
+	out := make(map[string]V, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,257 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+// fakeDeltaSource serves a fixed change set on top of a file data source.
+type fakeDeltaSource struct {
+	*testingsupport.FileDataSource
+	changes   *ChangeSet
+	err       error
+	fullLoads int
+	sinces    []string
+}
+
+func (f *fakeDeltaSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	f.fullLoads++
+	return f.FileDataSource.Load(ctx)
+}
+
+func (f *fakeDeltaSource) LoadChanges(ctx context.Context, sinceVersion string) (*ChangeSet, error) {
+	f.sinces = append(f.sinces, sinceVersion)
+	return f.changes, f.err
+}
+
+func TestApplyChangeSet(t *testing.T) {
+	service := setupTestService(t)
+
+	service.mu.RLock()
+	before := service.data
+	service.mu.RUnlock()
+
+	err := service.ApplyChangeSet(ChangeSet{
+		BaseVersion: "test-abc123",
+		DataVersion: "test-abc124",
+		EmployeesUpserted: map[string]Employee{
+			"jsmith": {UID: "jsmith", FullName: "John Smith", Email: "john.smith@example.com", SlackUID: "U00000001", GitHubID: "jsmith-dev", ManagerUID: "adoe"},
+			"cnew":   {UID: "cnew", FullName: "Carol New", Email: "cnew@example.com", SlackUID: "U00000002", ManagerUID: "adoe"},
+		},
+		EmployeesRemoved: []string{"bwilson"},
+		MembershipUpserted: map[string][]MembershipInfo{
+			"cnew": {{Name: "test-team", Type: "team"}, {Name: "test-org", Type: "org"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplyChangeSet failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  *Employee
+		want string
+	}{
+		{"upserted by email", service.GetEmployeeByEmail("john.smith@example.com"), "jsmith"},
+		{"new slack ID", service.GetEmployeeBySlackID("U00000001"), "jsmith"},
+		{"added employee", service.GetEmployeeByUID("cnew"), "cnew"},
+		{"added by slack ID", service.GetEmployeeBySlackID("U00000002"), "cnew"},
+		{"old slack ID", service.GetEmployeeBySlackID("U12345678"), ""},
+		{"old email", service.GetEmployeeByEmail("jsmith@example.com"), ""},
+		{"removed employee", service.GetEmployeeByUID("bwilson"), ""},
+		{"removed by github ID", service.GetEmployeeByGitHubID("bobw"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if tt.got != nil {
+				got = tt.got.UID
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if !service.IsEmployeeInTeam("cnew", "test-team") {
+		t.Error("cnew should be a member of test-team")
+	}
+	if orgs := service.GetUserOrganizations("bwilson"); len(orgs) != 0 {
+		t.Errorf("removed employee should have no memberships, got %v", orgs)
+	}
+	service.mu.RLock()
+	reports := service.derived().managerIndex()["adoe"]
+	service.mu.RUnlock()
+	if len(reports) != 2 {
+		t.Errorf("reports of adoe = %v, want 2", reports)
+	}
+	if v := service.GetVersion(); v.EmployeeCount != 3 {
+		t.Errorf("EmployeeCount = %d, want 3", v.EmployeeCount)
+	}
+
+	// The previous snapshot must be untouched.
+	if _, ok := before.Lookups.Employees["bwilson"]; !ok {
+		t.Error("previous snapshot lost bwilson")
+	}
+	if _, ok := before.Lookups.Employees["cnew"]; ok {
+		t.Error("previous snapshot gained cnew")
+	}
+	if before.Indexes.SlackIDMappings.SlackUIDToUID["U12345678"] != "jsmith" {
+		t.Error("previous snapshot slack mapping was modified")
+	}
+	if before.Metadata.DataVersion != "test-abc123" {
+		t.Errorf("previous snapshot data version = %q", before.Metadata.DataVersion)
+	}
+
+	service.mu.RLock()
+	after := service.data
+	service.mu.RUnlock()
+	if after.Metadata.DataVersion != "test-abc124" {
+		t.Errorf("DataVersion = %q, want test-abc124", after.Metadata.DataVersion)
+	}
+	// Maps untouched by the change set are shared, not copied.
+	if after.Lookups.Teams == nil || len(after.Lookups.Teams) != len(before.Lookups.Teams) {
+		t.Error("teams should be carried over unchanged")
+	}
+}
+
+func TestApplyChangeSetRemovesTeam(t *testing.T) {
+	service := setupTestService(t)
+
+	if err := service.ApplyChangeSet(ChangeSet{TeamsRemoved: []string{"test-team"}}); err != nil {
+		t.Fatalf("ApplyChangeSet failed: %v", err)
+	}
+
+	if teams := service.GetTeamsForUID("jsmith"); len(teams) != 0 {
+		t.Errorf("GetTeamsForUID(jsmith) = %v, want the removed team pruned", teams)
+	}
+	if service.IsEmployeeInTeam("adoe", "test-team") {
+		t.Error("adoe should no longer be a member of the removed test-team")
+	}
+	if members := service.GetTeamMembers("test-team"); len(members) != 0 {
+		t.Errorf("GetTeamMembers(test-team) = %v, want none", members)
+	}
+	if !service.IsEmployeeInOrg("jsmith", "test-org") {
+		t.Error("jsmith's other memberships should be kept")
+	}
+	if teams := service.GetTeamsForUID("bwilson"); len(teams) != 1 || teams[0] != "platform-team" {
+		t.Errorf("GetTeamsForUID(bwilson) = %v, want platform-team", teams)
+	}
+	if report := service.LastLoadReport(); report == nil || report.Source != "changeset" {
+		t.Errorf("LastLoadReport() = %+v, want source changeset", report)
+	}
+}
+
+func TestApplyChangeSetErrors(t *testing.T) {
+	t.Run("no data", func(t *testing.T) {
+		if err := NewService().ApplyChangeSet(ChangeSet{}); !errors.Is(err, ErrNoData) {
+			t.Errorf("expected ErrNoData, got %v", err)
+		}
+	})
+
+	t.Run("base version mismatch", func(t *testing.T) {
+		service := setupTestService(t)
+		err := service.ApplyChangeSet(ChangeSet{BaseVersion: "other", EmployeesRemoved: []string{"jsmith"}})
+		if !errors.Is(err, ErrChangeSetConflict) {
+			t.Errorf("expected ErrChangeSetConflict, got %v", err)
+		}
+		if service.GetEmployeeByUID("jsmith") == nil {
+			t.Error("rejected change set should not be applied")
+		}
+	})
+
+	t.Run("result fails validation", func(t *testing.T) {
+		service := setupTestService(t)
+		err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"jsmith", "adoe", "bwilson"}})
+		if !errors.Is(err, ErrInvalidData) {
+			t.Errorf("expected ErrInvalidData, got %v", err)
+		}
+		if service.GetEmployeeByUID("jsmith") == nil {
+			t.Error("invalid change set should not be applied")
+		}
+	})
+}
+
+func TestLoadChangesFromDataSource(t *testing.T) {
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	ctx := context.Background()
+
+	t.Run("initial load is full", func(t *testing.T) {
+		source := &fakeDeltaSource{FileDataSource: testingsupport.NewFileDataSource(testDataPath)}
+		service := NewService()
+		if err := service.LoadChangesFromDataSource(ctx, source); err != nil {
+			t.Fatalf("LoadChangesFromDataSource failed: %v", err)
+		}
+		if source.fullLoads != 1 || len(source.sinces) != 0 {
+			t.Errorf("fullLoads = %d, delta calls = %d; want 1, 0", source.fullLoads, len(source.sinces))
+		}
+	})
+
+	t.Run("delta applied", func(t *testing.T) {
+		source := &fakeDeltaSource{
+			FileDataSource: testingsupport.NewFileDataSource(testDataPath),
+			changes:        &ChangeSet{BaseVersion: "test-abc123", EmployeesRemoved: []string{"bwilson"}},
+		}
+		service := NewService()
+		if err := service.LoadFromDataSource(ctx, source); err != nil {
+			t.Fatalf("LoadFromDataSource failed: %v", err)
+		}
+		if err := service.LoadChangesFromDataSource(ctx, source); err != nil {
+			t.Fatalf("LoadChangesFromDataSource failed: %v", err)
+		}
+		if source.fullLoads != 1 {
+			t.Errorf("fullLoads = %d, want 1", source.fullLoads)
+		}
+		if len(source.sinces) != 1 || source.sinces[0] != "test-abc123" {
+			t.Errorf("LoadChanges since = %v, want [test-abc123]", source.sinces)
+		}
+		if service.GetEmployeeByUID("bwilson") != nil {
+			t.Error("bwilson should have been removed by the delta")
+		}
+	})
+
+	t.Run("falls back to full reload", func(t *testing.T) {
+		for _, err := range []error{ErrFullReloadRequired, nil} {
+			source := &fakeDeltaSource{
+				FileDataSource: testingsupport.NewFileDataSource(testDataPath),
+				err:            err,
+			}
+			if err == nil {
+				source.changes = &ChangeSet{BaseVersion: "stale", EmployeesRemoved: []string{"bwilson"}}
+			}
+			service := NewService()
+			if err := service.LoadFromDataSource(ctx, source); err != nil {
+				t.Fatalf("LoadFromDataSource failed: %v", err)
+			}
+			if err := service.LoadChangesFromDataSource(ctx, source); err != nil {
+				t.Fatalf("LoadChangesFromDataSource failed: %v", err)
+			}
+			if source.fullLoads != 2 {
+				t.Errorf("fullLoads = %d, want 2", source.fullLoads)
+			}
+			if service.GetEmployeeByUID("bwilson") == nil {
+				t.Error("full reload should restore the source data")
+			}
+		}
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		source := &fakeDeltaSource{
+			FileDataSource: testingsupport.NewFileDataSource(testDataPath),
+			err:            errors.New("boom"),
+		}
+		service := NewService()
+		if err := service.LoadFromDataSource(ctx, source); err != nil {
+			t.Fatalf("LoadFromDataSource failed: %v", err)
+		}
+		var loadErr *LoadError
+		if err := service.LoadChangesFromDataSource(ctx, source); !errors.As(err, &loadErr) {
+			t.Errorf("expected LoadError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package orgdatacore
+
+import "time"
+
+// Clock tells the time. A Service reads it for load times, data age and
+// freshness, watcher status and event times, and data sources that poll
+// wait on it between checks. Tests substitute a fake, such as
+// orgdatacoretest.FakeClock, to exercise polling and staleness without
+// sleeping. Durations of loads are measured on the real clock regardless.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d has
+	// passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock of the system time.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,71 @@
+// Command genorgdata writes a synthetic comprehensive-index dump, for load
+// testing consumers and the library itself. The shape is set by flags:
+//
+//	genorgdata -employees 50000 -team-size 8 -depth 4 -jira-density 0.3 -out synthetic.json
+//
+// Entities follow a fixed naming scheme (user0…, team-0…, org-0-0…), so load
+// tests can query known names, and the same flags always give the same dump.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func main() {
+	var cfg testingsupport.GeneratorConfig
+	flag.IntVar(&cfg.Employees, "employees", 1000, "number of employees")
+	flag.IntVar(&cfg.Teams, "teams", 0, "number of teams (default: employees / team-size)")
+	flag.IntVar(&cfg.TeamSize, "team-size", 10, "employees per team, used when -teams is 0")
+	flag.IntVar(&cfg.Depth, "depth", 3, "org levels above the teams")
+	flag.IntVar(&cfg.OrgFanout, "fanout", 4, "child orgs under each org")
+	flag.Float64Var(&cfg.SlackDensity, "slack-density", 1, "fraction of teams with a Slack channel, 0 to 1")
+	flag.Float64Var(&cfg.JiraDensity, "jira-density", 0.5, "fraction of teams owning a Jira component, 0 to 1")
+	flag.IntVar(&cfg.JiraProjects, "jira-projects", 10, "Jira projects the components are spread over")
+	out := flag.String("out", "-", "file to write, - for stdout")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	if err := validate(cfg); err != nil {
+		logger.Error("invalid flags", "error", err)
+		os.Exit(2)
+	}
+
+	data := testingsupport.Generate(cfg)
+	var err error
+	if *out == "-" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*out, data, 0o644)
+	}
+	if err != nil {
+		logger.Error("failed to write dump", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("generated dump", "employees", cfg.Employees, "bytes", len(data), "out", *out)
+}
+
+func validate(cfg testingsupport.GeneratorConfig) error {
+	var errs []error
+	if cfg.Employees < 1 {
+		errs = append(errs, errors.New("-employees must be at least 1"))
+	}
+	if cfg.Teams < 0 || cfg.TeamSize < 0 || cfg.Depth < 1 || cfg.OrgFanout < 1 || cfg.JiraProjects < 1 {
+		errs = append(errs, errors.New("-teams and -team-size must not be negative, and -depth, -fanout and -jira-projects must be at least 1"))
+	}
+	densities := []struct {
+		flag  string
+		value float64
+	}{{"-slack-density", cfg.SlackDensity}, {"-jira-density", cfg.JiraDensity}}
+	for _, d := range densities {
+		if d.value < 0 || d.value > 1 {
+			errs = append(errs, fmt.Errorf("%s must be between 0 and 1, got %g", d.flag, d.value))
+		}
+	}
+	return errors.Join(errs...)
+}
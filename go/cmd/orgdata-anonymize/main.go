@@ -0,0 +1,72 @@
+// Command orgdata-anonymize turns a production data dump into a PII-free
+// test fixture with orgdatacore.AnonymizeFixture: employee names, UIDs,
+// emails, Slack and GitHub IDs and cost centers become realistic fakes, while
+// teams, orgs, memberships and indexes keep their shape.
+//
+//	orgdata-anonymize -seed "$FIXTURE_SEED" -in comprehensive_index_dump.json -out testdata/fixture.json
+//
+// The same dump and seed always give the same fixture. Keep the seed out of
+// the repository the fixture is committed to.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func main() {
+	in := flag.String("in", "-", "data dump to read, - for stdin")
+	out := flag.String("out", "-", "fixture to write, - for stdout")
+	seed := flag.String("seed", os.Getenv("FIXTURE_SEED"), "secret the fakes are derived from (default $FIXTURE_SEED)")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	if err := run(*in, *out, *seed, logger); err != nil {
+		logger.Error("anonymization failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(in, out, seed string, logger *slog.Logger) error {
+	if seed == "" {
+		return errors.New("a seed is required: set -seed or FIXTURE_SEED")
+	}
+
+	var r io.Reader = os.Stdin
+	if in != "-" {
+		f, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var data orgdatacore.Data
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return fmt.Errorf("decode %s: %w", in, err)
+	}
+
+	orgdatacore.AnonymizeFixture(&data, seed)
+	report := orgdatacore.ValidateData(&data)
+	for _, issue := range report.Issues {
+		logger.Warn("fixture validation issue", "check", issue.Check, "severity", issue.Severity, "message", issue.Message)
+	}
+
+	encoded, err := json.MarshalIndent(&data, "", "  ")
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+	if out == "-" {
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+	return os.WriteFile(out, encoded, 0o644)
+}
@@ -0,0 +1,71 @@
+// Command orgdata-mcp serves organizational data to LLM assistants as Model
+// Context Protocol tools over stdio. It loads the dump from GCS, keeps it
+// current with a watcher, and answers on stdin/stdout; logs go to stderr.
+//
+// Build with -tags gcs for GCS support:
+//
+//	go build -tags gcs ./cmd/orgdata-mcp
+//	orgdata-mcp -bucket resolved-org -object orgdata/comprehensive_index_dump.json
+//
+// and register the binary as a stdio MCP server in the assistant's
+// configuration.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/mcpserver"
+)
+
+func main() {
+	bucket := flag.String("bucket", getEnvOrDefault("GCS_BUCKET", "resolved-org"), "GCS bucket holding the data dump")
+	object := flag.String("object", getEnvOrDefault("GCS_OBJECT_PATH", "orgdata/comprehensive_index_dump.json"), "object path of the data dump")
+	project := flag.String("project", getEnvOrDefault("GCS_PROJECT_ID", "openshift-crt"), "GCS project ID")
+	interval := flag.Duration("check-interval", 5*time.Minute, "how often to check GCS for new data")
+	flag.Parse()
+
+	// stdout carries the protocol, so everything else goes to stderr.
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	orgdatacore.SetLogger(logger)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	source, err := newDataSource(ctx, *bucket, *object, *project, *interval, logger)
+	if err != nil {
+		logger.Error("failed to create data source", "error", err)
+		os.Exit(1)
+	}
+	defer source.Close()
+
+	service := orgdatacore.NewService(orgdatacore.WithLogger(logger))
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		logger.Error("failed to load data", "source", source.String(), "error", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := service.StartDataSourceWatcher(ctx, source); err != nil {
+			logger.Error("watcher stopped", "error", err)
+		}
+	}()
+	defer service.StopWatcher()
+
+	if err := mcpserver.New(service).WithLogger(logger).Serve(ctx, os.Stdin, os.Stdout); err != nil && ctx.Err() == nil {
+		logger.Error("serve failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,19 @@
+//go:build gcs
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func newDataSource(ctx context.Context, bucket, object, project string, interval time.Duration, logger *slog.Logger) (orgdatacore.DataSource, error) {
+	return orgdatacore.NewGCSDataSourceWithSDK(ctx, bucket, object,
+		orgdatacore.WithCheckInterval(interval),
+		orgdatacore.WithProjectID(project),
+		orgdatacore.WithGCSLogger(logger),
+	)
+}
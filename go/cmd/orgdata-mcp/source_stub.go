@@ -0,0 +1,22 @@
+//go:build !gcs
+
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// newDataSource returns the GCS stub, whose Load fails explaining that the
+// binary must be built with -tags gcs.
+func newDataSource(_ context.Context, bucket, object, project string, interval time.Duration, _ *slog.Logger) (orgdatacore.DataSource, error) {
+	return orgdatacore.NewGCSDataSource(orgdatacore.GCSConfig{
+		Bucket:        bucket,
+		ObjectPath:    object,
+		ProjectID:     project,
+		CheckInterval: interval,
+	}), nil
+}
@@ -0,0 +1,117 @@
+package orgdatacore
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// CodeownersRule assigns a path pattern of a repository to a team, for
+// ownership the teams' repo entries do not record.
+type CodeownersRule struct {
+	// Pattern is a CODEOWNERS path pattern, such as "/docs/" or "*.proto".
+	Pattern string
+	// Team is the name of the owning team.
+	Team string
+}
+
+// GenerateCODEOWNERS writes a GitHub CODEOWNERS file for repo. Each team
+// listing the repository owns the path of its repo entry, or the whole
+// repository if the entry has no path; rules add further patterns. Owners
+// are the GitHub IDs of the team members, as @handles. Teams sharing a
+// pattern share its line, and lines are ordered from the whole repository
+// down to deeper paths, since GitHub applies the last matching line.
+//
+// Repository references are compared case-insensitively, ignoring any
+// "https://github.com/" prefix and ".git" suffix. A repository no team
+// lists, with no rules, or a rule naming an unknown team fails with
+// ErrNotFound, and without loaded data GenerateCODEOWNERS fails with
+// ErrNoData.
+func (s *Service) GenerateCODEOWNERS(w io.Writer, repo string, rules ...CodeownersRule) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return ErrNoData
+	}
+
+	// pattern -> owning team names
+	owners := make(map[string][]string)
+	key := normalizeRepo(repo)
+	for _, name := range s.derived().repoIndex()[key] {
+		for _, r := range s.data.Lookups.Teams[name].Group.Repos {
+			if normalizeRepo(r.Repo) != key {
+				continue
+			}
+			pattern := codeownersPattern(r.Path)
+			if !slices.Contains(owners[pattern], name) {
+				owners[pattern] = append(owners[pattern], name)
+			}
+		}
+	}
+	for _, rule := range rules {
+		if _, ok := s.data.Lookups.Teams[rule.Team]; !ok {
+			return fmt.Errorf("%w: team %q in CODEOWNERS rule for %q", ErrNotFound, rule.Team, rule.Pattern)
+		}
+		if !slices.Contains(owners[rule.Pattern], rule.Team) {
+			owners[rule.Pattern] = append(owners[rule.Pattern], rule.Team)
+		}
+	}
+	if len(owners) == 0 {
+		return fmt.Errorf("%w: no team owns repository %q", ErrNotFound, repo)
+	}
+
+	patterns := make([]string, 0, len(owners))
+	for p := range owners {
+		patterns = append(patterns, p)
+	}
+	slices.SortFunc(patterns, func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.Count(a, "/"), strings.Count(b, "/")), cmp.Compare(a, b))
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Generated from cyborg-data for %s. Do not edit by hand.\n", key)
+	for _, p := range patterns {
+		teams := owners[p]
+		slices.Sort(teams)
+		handles := s.githubHandles(teams)
+		fmt.Fprintf(bw, "\n# %s\n", strings.Join(teams, ", "))
+		if len(handles) == 0 {
+			// A pattern with no owners would clear the ownership an
+			// earlier line gives it, so leave it out.
+			fmt.Fprintf(bw, "# %s: no members with GitHub IDs\n", p)
+			continue
+		}
+		fmt.Fprintf(bw, "%s %s\n", p, strings.Join(handles, " "))
+	}
+	return bw.Flush()
+}
+
+// codeownersPattern turns a repo entry's path into a CODEOWNERS pattern
+// anchored at the repository root.
+func codeownersPattern(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return "*"
+	}
+	return "/" + path + "/"
+}
+
+// githubHandles returns the sorted, deduplicated @handles of the members of
+// teams that have GitHub IDs.
+// Must be called with s.mu held.
+func (s *Service) githubHandles(teams []string) []string {
+	handles := []string{}
+	for _, name := range teams {
+		for _, uid := range s.data.Lookups.Teams[name].Group.ResolvedPeopleUIDList {
+			if emp, ok := s.data.Lookups.Employees[uid]; ok && emp.GitHubID != "" {
+				handles = append(handles, "@"+emp.GitHubID)
+			}
+		}
+	}
+	slices.Sort(handles)
+	return slices.Compact(handles)
+}
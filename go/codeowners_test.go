@@ -0,0 +1,94 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGenerateCODEOWNERS(t *testing.T) {
+	service := setupTestService(t)
+
+	var buf bytes.Buffer
+	if err := service.GenerateCODEOWNERS(&buf, "https://github.com/example/test-repo.git"); err != nil {
+		t.Fatalf("GenerateCODEOWNERS: %v", err)
+	}
+	want := "# Generated from cyborg-data for example/test-repo. Do not edit by hand.\n" +
+		"\n# test-team\n" +
+		"* @alice-codes @jsmith-dev\n"
+	if buf.String() != want {
+		t.Errorf("GenerateCODEOWNERS =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestGenerateCODEOWNERSPaths(t *testing.T) {
+	data := CreateTestData()
+	data.Lookups.Employees["testuser1"] = Employee{UID: "testuser1", GitHubID: "ghuser1"}
+	data.Lookups.Employees["testuser2"] = Employee{UID: "testuser2", GitHubID: "ghuser2"}
+	data.Lookups.Employees["testuser3"] = Employee{UID: "testuser3"}
+	squad := data.Lookups.Teams["test-squad"]
+	squad.Group.Repos = []RepoInfo{
+		{Repo: "org/mono", Path: "services/api/"},
+		{Repo: "org/mono"},
+		{Repo: "org/other"},
+	}
+	data.Lookups.Teams["test-squad"] = squad
+	data.Lookups.Teams["docs-team"] = Team{Name: "docs-team", Type: "team", Group: Group{
+		ResolvedPeopleUIDList: []string{"testuser2"},
+		Repos:                 []RepoInfo{{Repo: "https://github.com/org/mono", Path: "/services/api"}},
+	}}
+	data.Lookups.Teams["new-team"] = Team{Name: "new-team", Type: "team", Group: Group{
+		ResolvedPeopleUIDList: []string{"testuser3"},
+	}}
+
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "paths", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err := service.GenerateCODEOWNERS(&buf, "org/mono",
+		CodeownersRule{Pattern: "/docs/", Team: "docs-team"},
+		CodeownersRule{Pattern: "/experimental/", Team: "new-team"},
+	)
+	if err != nil {
+		t.Fatalf("GenerateCODEOWNERS: %v", err)
+	}
+	want := "# Generated from cyborg-data for org/mono. Do not edit by hand.\n" +
+		"\n# test-squad\n" +
+		"* @ghuser1 @ghuser2\n" +
+		"\n# docs-team\n" +
+		"/docs/ @ghuser2\n" +
+		"\n# new-team\n" +
+		"# /experimental/: no members with GitHub IDs\n" +
+		"\n# docs-team, test-squad\n" +
+		"/services/api/ @ghuser1 @ghuser2\n"
+	if buf.String() != want {
+		t.Errorf("GenerateCODEOWNERS =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestGenerateCODEOWNERSErrors(t *testing.T) {
+	service := setupTestService(t)
+	var buf bytes.Buffer
+
+	tests := []struct {
+		name    string
+		service *Service
+		repo    string
+		rules   []CodeownersRule
+		want    error
+	}{
+		{"unowned repo", service, "example/unknown", nil, ErrNotFound},
+		{"unknown rule team", service, "example/test-repo", []CodeownersRule{{Pattern: "*", Team: "ghost-team"}}, ErrNotFound},
+		{"no data", NewService(), "example/test-repo", nil, ErrNoData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.service.GenerateCODEOWNERS(&buf, tt.repo, tt.rules...); !errors.Is(err, tt.want) {
+				t.Errorf("GenerateCODEOWNERS = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
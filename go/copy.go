@@ -0,0 +1,160 @@
+package orgdatacore
+
+import "slices"
+
+// CopyPolicy controls how much of the loaded dataset the entities returned by
+// a Service share with it.
+type CopyPolicy int
+
+const (
+	// SharedReadOnly returns a copy of each entity struct, but its slices,
+	// maps and pointers, such as Group.ResolvedPeopleUIDList or Parent, are
+	// those of the loaded dataset. Callers must treat them as read-only:
+	// modifying them changes what later queries return, and races with
+	// other readers. It is the default, and allocates the least.
+	SharedReadOnly CopyPolicy = iota
+	// DeepCopy returns entities that share nothing with the loaded dataset,
+	// so callers may modify them freely, at the cost of copying every nested
+	// slice on each call.
+	DeepCopy
+)
+
+// String returns the name of the policy.
+func (p CopyPolicy) String() string {
+	switch p {
+	case SharedReadOnly:
+		return "SharedReadOnly"
+	case DeepCopy:
+		return "DeepCopy"
+	}
+	return "CopyPolicy(unknown)"
+}
+
+// WithCopyPolicy sets how teams, orgs, pillars, team groups and components
+// returned by lookups, GetAll* methods and enumerations share data with the
+// loaded dataset. Employees hold no nested data, so they are unaffected.
+func WithCopyPolicy(p CopyPolicy) ServiceOption {
+	return func(c *serviceConfig) {
+		c.copyPolicy = p
+	}
+}
+
+// deepCopier is implemented by the entity types that hold nested data.
+type deepCopier[T any] interface {
+	deepCopy() T
+}
+
+// owned returns v as the copy policy of s requires.
+func owned[T deepCopier[T]](s *Service, v T) T {
+	if s.copyPolicy == DeepCopy {
+		return v.deepCopy()
+	}
+	return v
+}
+
+// ownedAll applies owned to every element of vs in place and returns it.
+func ownedAll[T deepCopier[T]](s *Service, vs []T) []T {
+	if s.copyPolicy == DeepCopy {
+		for i := range vs {
+			vs[i] = vs[i].deepCopy()
+		}
+	}
+	return vs
+}
+
+func (t Team) deepCopy() Team {
+	t.Parent = cloneParent(t.Parent)
+	t.Group = t.Group.deepCopy()
+	return t
+}
+
+func (o Org) deepCopy() Org {
+	o.Parent = cloneParent(o.Parent)
+	o.Group = o.Group.deepCopy()
+	return o
+}
+
+func (p Pillar) deepCopy() Pillar {
+	p.Parent = cloneParent(p.Parent)
+	p.Group = p.Group.deepCopy()
+	return p
+}
+
+func (tg TeamGroup) deepCopy() TeamGroup {
+	tg.Parent = cloneParent(tg.Parent)
+	tg.Group = tg.Group.deepCopy()
+	return tg
+}
+
+func (c Component) deepCopy() Component {
+	c.Parent = cloneParent(c.Parent)
+	c.Repos = cloneRepos(c.Repos)
+	c.Jiras = cloneJiras(c.Jiras)
+	c.ReposList = slices.Clone(c.ReposList)
+	c.Context = cloneContext(c.Context)
+	c.ResolvedContext = cloneContext(c.ResolvedContext)
+	return c
+}
+
+func (g Group) deepCopy() Group {
+	g.ResolvedPeopleUIDList = slices.Clone(g.ResolvedPeopleUIDList)
+	if g.Slack != nil {
+		slack := SlackConfig{
+			Channels: slices.Clone(g.Slack.Channels),
+			Aliases:  slices.Clone(g.Slack.Aliases),
+		}
+		for i := range slack.Channels {
+			slack.Channels[i].Types = slices.Clone(slack.Channels[i].Types)
+		}
+		g.Slack = &slack
+	}
+	g.Roles = slices.Clone(g.Roles)
+	for i := range g.Roles {
+		g.Roles[i].People = slices.Clone(g.Roles[i].People)
+		g.Roles[i].Roles = slices.Clone(g.Roles[i].Roles)
+	}
+	g.Jiras = cloneJiras(g.Jiras)
+	g.Repos = cloneRepos(g.Repos)
+	g.Keywords = slices.Clone(g.Keywords)
+	g.Emails = slices.Clone(g.Emails)
+	g.Resources = slices.Clone(g.Resources)
+	g.Escalation = slices.Clone(g.Escalation)
+	g.ComponentRoles = slices.Clone(g.ComponentRoles)
+	g.Context = cloneContext(g.Context)
+	g.ResolvedContext = cloneContext(g.ResolvedContext)
+	return g
+}
+
+func cloneParent(p *ParentInfo) *ParentInfo {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+func cloneJiras(jiras []JiraInfo) []JiraInfo {
+	jiras = slices.Clone(jiras)
+	for i := range jiras {
+		jiras[i].Types = slices.Clone(jiras[i].Types)
+	}
+	return jiras
+}
+
+func cloneRepos(repos []RepoInfo) []RepoInfo {
+	repos = slices.Clone(repos)
+	for i := range repos {
+		repos[i].Tags = slices.Clone(repos[i].Tags)
+		repos[i].Roles = slices.Clone(repos[i].Roles)
+		repos[i].Types = slices.Clone(repos[i].Types)
+	}
+	return repos
+}
+
+func cloneContext(items []ContextItemInfo) []ContextItemInfo {
+	items = slices.Clone(items)
+	for i := range items {
+		items[i].Types = slices.Clone(items[i].Types)
+	}
+	return items
+}
@@ -0,0 +1,66 @@
+package orgdatacore
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestWithCopyPolicyDeepCopy(t *testing.T) {
+	service := NewService(WithCopyPolicy(DeepCopy))
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	want := service.GetTeamByName("test-team")
+
+	// Modify everything a caller can reach through each kind of result.
+	corrupt := func(team *Team) {
+		team.Parent.Name = "corrupted"
+		team.Group.ResolvedPeopleUIDList[0] = "corrupted"
+		if team.Group.Slack != nil && len(team.Group.Slack.Channels) > 0 {
+			team.Group.Slack.Channels[0].Channel = "corrupted"
+		}
+		for i := range team.Group.Roles {
+			team.Group.Roles[i].People = append(team.Group.Roles[i].People[:0], "corrupted")
+		}
+	}
+	corrupt(service.GetTeamByName("test-team"))
+	for _, team := range service.GetAllTeams() {
+		corrupt(&team)
+	}
+	for _, team := range service.AllTeams() {
+		corrupt(team)
+	}
+	for _, component := range service.GetAllComponents() {
+		for i := range component.Repos {
+			component.Repos[i].Repo = "corrupted"
+		}
+	}
+
+	got := service.GetTeamByName("test-team")
+	if got.Parent.Name != want.Parent.Name ||
+		!slices.Equal(got.Group.ResolvedPeopleUIDList, want.Group.ResolvedPeopleUIDList) ||
+		got.Group.Slack.Channels[0].Channel != want.Group.Slack.Channels[0].Channel ||
+		!slices.Equal(got.Group.Roles[0].People, want.Group.Roles[0].People) {
+		t.Errorf("modifying returned teams changed the dataset: got %+v, want %+v", got, want)
+	}
+	if component := service.GetComponentByName("platform-api"); component.Repos[0].Repo == "corrupted" {
+		t.Error("modifying a returned component changed the dataset")
+	}
+	if !service.IsEmployeeInTeam("jsmith", "test-team") {
+		t.Error("IsEmployeeInTeam(jsmith, test-team) = false after modifying returned teams")
+	}
+}
+
+func TestCopyPolicyString(t *testing.T) {
+	if got := SharedReadOnly.String(); got != "SharedReadOnly" {
+		t.Errorf("SharedReadOnly.String() = %q", got)
+	}
+	if got := DeepCopy.String(); got != "DeepCopy" {
+		t.Errorf("DeepCopy.String() = %q", got)
+	}
+}
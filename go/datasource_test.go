@@ -1,7 +1,9 @@
 package orgdatacore
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -229,3 +231,96 @@ func TestDataSourceIntegrationWithService(t *testing.T) {
 		t.Error("Employee lookup failed after loading from data source")
 	}
 }
+
+// TestGenerateDataset verifies the synthetic generator produces loadable, consistent data
+func TestGenerateDataset(t *testing.T) {
+	service := NewService()
+	data := testingsupport.GenerateDataset(100, 10, 3)
+	if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(string(data))); err != nil {
+		t.Fatalf("Failed to load generated data: %v", err)
+	}
+
+	if got := service.GetVersion().EmployeeCount; got != 100 {
+		t.Errorf("EmployeeCount = %d, want 100", got)
+	}
+	if got := len(service.GetAllTeamNames()); got != 10 {
+		t.Errorf("team count = %d, want 10", got)
+	}
+	if !service.IsEmployeeInTeam("user15", "team-5") {
+		t.Error("user15 should be in team-5")
+	}
+	if !service.IsEmployeeInOrg("user15", "org-0-0") {
+		t.Error("every employee should be in the root org")
+	}
+	if mgr := service.GetManagerForEmployee("user15"); mgr == nil || mgr.UID != "user5" {
+		t.Errorf("manager of user15 = %+v, want user5", mgr)
+	}
+	if path := service.GetHierarchyPath("team-5", "team"); len(path) != 4 {
+		t.Errorf("hierarchy path for team-5 = %v, want team plus 3 org levels", path)
+	}
+	if emp := service.GetEmployeeBySlackID("U42"); emp == nil || emp.UID != "user42" {
+		t.Errorf("GetEmployeeBySlackID(U42) = %+v, want user42", emp)
+	}
+
+	if again := testingsupport.GenerateDataset(100, 10, 3); string(again) != string(data) {
+		t.Error("GenerateDataset should be deterministic")
+	}
+}
+
+// TestGenerateConfig verifies generated dumps of every shape conform to the
+// schema and pass validation.
+func TestGenerateConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           testingsupport.GeneratorConfig
+		wantTeams     int
+		wantChannels  int
+		wantJiraTeams int
+	}{
+		{"team size", testingsupport.GeneratorConfig{Employees: 95, TeamSize: 10, Depth: 2, SlackDensity: 1}, 10, 10, 0},
+		{"default team size", testingsupport.GeneratorConfig{Employees: 40, Depth: 1}, 4, 0, 0},
+		{"half jira", testingsupport.GeneratorConfig{Employees: 60, Teams: 6, Depth: 3, OrgFanout: 2, JiraDensity: 0.5, JiraProjects: 2}, 6, 0, 3},
+		{"sparse slack", testingsupport.GeneratorConfig{Employees: 100, Teams: 20, Depth: 2, SlackDensity: 0.25, JiraDensity: 1}, 20, 5, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := testingsupport.Generate(tt.cfg)
+			if err := ValidateAgainstSchema(bytes.NewReader(raw)); err != nil {
+				t.Fatalf("ValidateAgainstSchema: %v", err)
+			}
+			var data Data
+			if err := json.Unmarshal(raw, &data); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if issues := ValidateData(&data).Issues; len(issues) != 0 {
+				t.Errorf("validation issues: %+v", issues)
+			}
+			if got := len(data.Lookups.Teams); got != tt.wantTeams {
+				t.Errorf("teams = %d, want %d", got, tt.wantTeams)
+			}
+			channels, jiraTeams := 0, 0
+			for _, team := range data.Lookups.Teams {
+				if team.Group.Slack != nil {
+					channels += len(team.Group.Slack.Channels)
+				}
+				jiraTeams += len(team.Group.Jiras)
+			}
+			if channels != tt.wantChannels {
+				t.Errorf("Slack channels = %d, want %d", channels, tt.wantChannels)
+			}
+			if jiraTeams != tt.wantJiraTeams {
+				t.Errorf("teams owning Jira components = %d, want %d", jiraTeams, tt.wantJiraTeams)
+			}
+			owners := 0
+			for _, components := range data.Indexes.Jira {
+				for _, o := range components {
+					owners += len(o)
+				}
+			}
+			if owners != tt.wantJiraTeams {
+				t.Errorf("Jira index owners = %d, want %d", owners, tt.wantJiraTeams)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package orgdatacore
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// DiffSnapshots computes the changes that turn snapshot a into snapshot b.
+// A nil snapshot is treated as empty.
+//
+// The entity and membership fields of the result are such that applying it to
+// a with ApplyChangeSet yields b's employees and hierarchy, and the memberships
+// of every employee in b. The summary fields describe the same change in
+// domain terms. All slices are sorted so results are deterministic.
+func DiffSnapshots(a, b *Data) *ChangeSet {
+	if a == nil {
+		a = &Data{}
+	}
+	if b == nil {
+		b = &Data{}
+	}
+
+	cs := &ChangeSet{
+		BaseVersion: a.Metadata.DataVersion,
+		DataVersion: b.Metadata.DataVersion,
+		GeneratedAt: b.Metadata.GeneratedAt,
+	}
+
+	cs.EmployeesUpserted, cs.EmployeesRemoved = diffEntities(a.Lookups.Employees, b.Lookups.Employees)
+	for uid, emp := range cs.EmployeesUpserted {
+		old, existed := a.Lookups.Employees[uid]
+		if !existed {
+			cs.EmployeesAdded = append(cs.EmployeesAdded, uid)
+			continue
+		}
+		if old.ManagerUID != emp.ManagerUID {
+			cs.ManagerChanges = append(cs.ManagerChanges, ManagerChange{
+				UID:           uid,
+				OldManagerUID: old.ManagerUID,
+				NewManagerUID: emp.ManagerUID,
+			})
+		}
+	}
+	sort.Strings(cs.EmployeesAdded)
+	sort.Slice(cs.ManagerChanges, func(i, j int) bool { return cs.ManagerChanges[i].UID < cs.ManagerChanges[j].UID })
+
+	cs.TeamsUpserted, cs.TeamsRemoved = diffEntities(a.Lookups.Teams, b.Lookups.Teams)
+	cs.OrgsUpserted, cs.OrgsRemoved = diffEntities(a.Lookups.Orgs, b.Lookups.Orgs)
+	cs.PillarsUpserted, cs.PillarsRemoved = diffEntities(a.Lookups.Pillars, b.Lookups.Pillars)
+	cs.TeamGroupsUpserted, cs.TeamGroupsRemoved = diffEntities(a.Lookups.TeamGroups, b.Lookups.TeamGroups)
+
+	cs.HierarchyMoves = appendMoves(cs.HierarchyMoves, EntityTeam, a.Lookups.Teams, cs.TeamsUpserted, func(t Team) *ParentInfo { return t.Parent })
+	cs.HierarchyMoves = appendMoves(cs.HierarchyMoves, EntityOrg, a.Lookups.Orgs, cs.OrgsUpserted, func(o Org) *ParentInfo { return o.Parent })
+	cs.HierarchyMoves = appendMoves(cs.HierarchyMoves, EntityPillar, a.Lookups.Pillars, cs.PillarsUpserted, func(p Pillar) *ParentInfo { return p.Parent })
+	cs.HierarchyMoves = appendMoves(cs.HierarchyMoves, EntityTeamGroup, a.Lookups.TeamGroups, cs.TeamGroupsUpserted, func(g TeamGroup) *ParentInfo { return g.Parent })
+	sort.Slice(cs.HierarchyMoves, func(i, j int) bool {
+		mi, mj := cs.HierarchyMoves[i], cs.HierarchyMoves[j]
+		if mi.Type != mj.Type {
+			return mi.Type < mj.Type
+		}
+		return mi.Name < mj.Name
+	})
+
+	diffMemberships(cs, a.Indexes.Membership.MembershipIndex, b.Indexes.Membership.MembershipIndex)
+
+	return cs
+}
+
+// diffEntities returns the entries of b that are new or differ from a, and
+// the sorted keys of a that are missing from b. It returns nil for either
+// result when there is nothing to report.
+func diffEntities[V any](a, b map[string]V) (upserted map[string]V, removed []string) {
+	for k, v := range b {
+		if old, ok := a[k]; ok && reflect.DeepEqual(old, v) {
+			continue
+		}
+		if upserted == nil {
+			upserted = make(map[string]V)
+		}
+		upserted[k] = v
+	}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	return upserted, removed
+}
+
+// appendMoves records entities in upserted whose parent differs from before.
+// Newly added entities are not moves.
+func appendMoves[V any](moves []HierarchyMove, typ EntityType, before, upserted map[string]V, parent func(V) *ParentInfo) []HierarchyMove {
+	for name, v := range upserted {
+		old, existed := before[name]
+		if !existed {
+			continue
+		}
+		oldParent, newParent := parent(old), parent(v)
+		if sameParent(oldParent, newParent) {
+			continue
+		}
+		moves = append(moves, HierarchyMove{Name: name, Type: typ, OldParent: oldParent, NewParent: newParent})
+	}
+	return moves
+}
+
+func sameParent(a, b *ParentInfo) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// diffMemberships fills in MembershipUpserted for UIDs whose membership list
+// changed and MembershipChanges for UIDs whose set of teams changed.
+func diffMemberships(cs *ChangeSet, a, b map[string][]MembershipInfo) {
+	for uid, memberships := range b {
+		old := a[uid]
+		if slices.Equal(old, memberships) {
+			continue
+		}
+		if cs.MembershipUpserted == nil {
+			cs.MembershipUpserted = make(map[string][]MembershipInfo)
+		}
+		cs.MembershipUpserted[uid] = memberships
+		if change, ok := teamChange(uid, old, memberships); ok {
+			cs.MembershipChanges = append(cs.MembershipChanges, change)
+		}
+	}
+	for uid, memberships := range a {
+		if _, ok := b[uid]; ok {
+			continue
+		}
+		if change, ok := teamChange(uid, memberships, nil); ok {
+			cs.MembershipChanges = append(cs.MembershipChanges, change)
+		}
+	}
+	sort.Slice(cs.MembershipChanges, func(i, j int) bool { return cs.MembershipChanges[i].UID < cs.MembershipChanges[j].UID })
+}
+
+func teamChange(uid string, before, after []MembershipInfo) (MembershipChange, bool) {
+	oldTeams := teamSet(before)
+	newTeams := teamSet(after)
+	change := MembershipChange{UID: uid}
+	for team := range newTeams {
+		if !oldTeams[team] {
+			change.TeamsAdded = append(change.TeamsAdded, team)
+		}
+	}
+	for team := range oldTeams {
+		if !newTeams[team] {
+			change.TeamsRemoved = append(change.TeamsRemoved, team)
+		}
+	}
+	if len(change.TeamsAdded) == 0 && len(change.TeamsRemoved) == 0 {
+		return change, false
+	}
+	sort.Strings(change.TeamsAdded)
+	sort.Strings(change.TeamsRemoved)
+	return change, true
+}
+
+func teamSet(memberships []MembershipInfo) map[string]bool {
+	teams := make(map[string]bool)
+	for _, m := range memberships {
+		if m.Type == string(MembershipTeam) {
+			teams[m.Name] = true
+		}
+	}
+	return teams
+}
@@ -0,0 +1,129 @@
+package orgdatacore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func diffTestData() *Data {
+	return &Data{
+		Metadata: Metadata{DataVersion: "v1"},
+		Lookups: Lookups{
+			Employees: map[string]Employee{
+				"alice": {UID: "alice", FullName: "Alice"},
+				"bob":   {UID: "bob", FullName: "Bob", ManagerUID: "alice"},
+				"carol": {UID: "carol", FullName: "Carol", ManagerUID: "alice"},
+			},
+			Teams: map[string]Team{
+				"team-a": {Name: "team-a", Parent: &ParentInfo{Name: "org-1", Type: "org"}},
+				"team-b": {Name: "team-b", Parent: &ParentInfo{Name: "org-1", Type: "org"}},
+			},
+			Orgs: map[string]Org{
+				"org-1": {Name: "org-1"},
+				"org-2": {Name: "org-2"},
+			},
+		},
+		Indexes: Indexes{
+			Membership: MembershipIndex{MembershipIndex: map[string][]MembershipInfo{
+				"alice": {{Name: "team-a", Type: "team"}, {Name: "org-1", Type: "org"}},
+				"bob":   {{Name: "team-a", Type: "team"}, {Name: "org-1", Type: "org"}},
+				"carol": {{Name: "team-b", Type: "team"}, {Name: "org-1", Type: "org"}},
+			}},
+		},
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	a := diffTestData()
+	b := applyChangeSet(a, &ChangeSet{
+		DataVersion: "v2",
+		EmployeesUpserted: map[string]Employee{
+			"bob":  {UID: "bob", FullName: "Bob", ManagerUID: "carol"},
+			"dave": {UID: "dave", FullName: "Dave", ManagerUID: "alice"},
+		},
+		EmployeesRemoved: []string{"carol"},
+		TeamsUpserted: map[string]Team{
+			"team-b": {Name: "team-b", Parent: &ParentInfo{Name: "org-2", Type: "org"}},
+		},
+		MembershipUpserted: map[string][]MembershipInfo{
+			"bob":  {{Name: "team-b", Type: "team"}, {Name: "org-2", Type: "org"}},
+			"dave": {{Name: "team-a", Type: "team"}, {Name: "org-1", Type: "org"}},
+		},
+	})
+
+	cs := DiffSnapshots(a, b)
+
+	if cs.BaseVersion != "v1" || cs.DataVersion != "v2" {
+		t.Errorf("versions = %q -> %q, want v1 -> v2", cs.BaseVersion, cs.DataVersion)
+	}
+	if !reflect.DeepEqual(cs.EmployeesAdded, []string{"dave"}) {
+		t.Errorf("EmployeesAdded = %v, want [dave]", cs.EmployeesAdded)
+	}
+	if !reflect.DeepEqual(cs.EmployeesRemoved, []string{"carol"}) {
+		t.Errorf("EmployeesRemoved = %v, want [carol]", cs.EmployeesRemoved)
+	}
+	if len(cs.EmployeesUpserted) != 2 {
+		t.Errorf("EmployeesUpserted has %d entries, want 2 (bob, dave)", len(cs.EmployeesUpserted))
+	}
+
+	wantManagers := []ManagerChange{{UID: "bob", OldManagerUID: "alice", NewManagerUID: "carol"}}
+	if !reflect.DeepEqual(cs.ManagerChanges, wantManagers) {
+		t.Errorf("ManagerChanges = %+v, want %+v", cs.ManagerChanges, wantManagers)
+	}
+
+	wantMoves := []HierarchyMove{{
+		Name:      "team-b",
+		Type:      EntityTeam,
+		OldParent: &ParentInfo{Name: "org-1", Type: "org"},
+		NewParent: &ParentInfo{Name: "org-2", Type: "org"},
+	}}
+	if !reflect.DeepEqual(cs.HierarchyMoves, wantMoves) {
+		t.Errorf("HierarchyMoves = %+v, want %+v", cs.HierarchyMoves, wantMoves)
+	}
+
+	wantMembership := []MembershipChange{
+		{UID: "bob", TeamsAdded: []string{"team-b"}, TeamsRemoved: []string{"team-a"}},
+		{UID: "carol", TeamsRemoved: []string{"team-b"}},
+		{UID: "dave", TeamsAdded: []string{"team-a"}},
+	}
+	if !reflect.DeepEqual(cs.MembershipChanges, wantMembership) {
+		t.Errorf("MembershipChanges = %+v, want %+v", cs.MembershipChanges, wantMembership)
+	}
+
+	// Applying the diff to a reproduces b.
+	roundTrip := applyChangeSet(a, cs)
+	if !reflect.DeepEqual(roundTrip.Lookups.Employees, b.Lookups.Employees) {
+		t.Error("applying the diff should reproduce b's employees")
+	}
+	if !reflect.DeepEqual(roundTrip.Lookups.Teams, b.Lookups.Teams) {
+		t.Error("applying the diff should reproduce b's teams")
+	}
+	if !reflect.DeepEqual(roundTrip.Indexes.Membership.MembershipIndex, b.Indexes.Membership.MembershipIndex) {
+		t.Error("applying the diff should reproduce b's memberships")
+	}
+}
+
+func TestDiffSnapshotsIdentical(t *testing.T) {
+	cs := DiffSnapshots(diffTestData(), diffTestData())
+	if !cs.IsEmpty() {
+		t.Errorf("diff of identical snapshots should be empty, got %+v", cs)
+	}
+	if len(cs.ManagerChanges) != 0 || len(cs.HierarchyMoves) != 0 || len(cs.MembershipChanges) != 0 {
+		t.Error("diff of identical snapshots should have no summary entries")
+	}
+}
+
+func TestDiffSnapshotsNil(t *testing.T) {
+	cs := DiffSnapshots(nil, diffTestData())
+	if !reflect.DeepEqual(cs.EmployeesAdded, []string{"alice", "bob", "carol"}) {
+		t.Errorf("diff from nil should add every employee, got %v", cs.EmployeesAdded)
+	}
+	if len(cs.ManagerChanges) != 0 || len(cs.HierarchyMoves) != 0 {
+		t.Error("new entities are not manager changes or moves")
+	}
+
+	cs = DiffSnapshots(diffTestData(), nil)
+	if !reflect.DeepEqual(cs.EmployeesRemoved, []string{"alice", "bob", "carol"}) {
+		t.Errorf("diff to nil should remove every employee, got %v", cs.EmployeesRemoved)
+	}
+}
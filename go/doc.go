@@ -14,6 +14,26 @@
 //	emp := service.GetEmployeeByUID("jsmith")
 //	teams := service.GetTeamsForUID("jsmith")
 //
+// # Configuration
+//
+// A Service is configured once, by the options passed to [NewService]:
+//
+//   - logging and time: [WithLogger], [WithQueryLogging], [WithLogLevels],
+//     [WithClock];
+//   - metrics: [WithMetrics];
+//   - validation of loaded data: [WithLoadValidator], [WithCheckSeverity],
+//     [WithRequiredSections], [WithValidationThreshold], [WithStrictSchema],
+//     [WithUnknownFieldCheck], [WithoutConsistencyChecks], [WithRepair],
+//     [WithMaxShrink];
+//   - local patches to loaded data: [WithOverlay];
+//   - what is kept in memory: [WithSections], [WithEagerIndexes],
+//     [WithQueryCache];
+//   - lookups and their results: [WithLookupNormalization], [WithCopyPolicy];
+//   - freshness and recovery: [WithMaxDataAge], [WithSnapshotHistory],
+//     [WithLastKnownGoodPath].
+//
+// [WithOptions] bundles several options into one.
+//
 // # Build Tags
 //
 // GCS support requires the "gcs" build tag to avoid forcing heavy cloud dependencies on all consumers:
@@ -0,0 +1,183 @@
+package orgdatacore
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// dotShapes gives each entity type its own node shape in hierarchy charts.
+var dotShapes = map[string]string{
+	"pillar":     "box3d",
+	"org":        "folder",
+	"team_group": "component",
+	"team":       "box",
+}
+
+// ExportHierarchyDOT writes the team/org hierarchy below root as a Graphviz
+// DOT digraph, with an edge from each parent to its children. root may be any
+// team, org, pillar or team group; an empty root charts the whole hierarchy,
+// starting from every entity without a parent. Render the output with
+// RenderSVG or any Graphviz tool. An unknown root fails with ErrNotFound, and
+// without loaded data the export fails with ErrNoData.
+func (s *Service) ExportHierarchyDOT(w io.Writer, root string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return ErrNoData
+	}
+
+	var roots []*HierarchyNode
+	if root != "" {
+		typ := s.getEntityType(root)
+		if typ == "" {
+			return fmt.Errorf("%w: entity %q", ErrNotFound, root)
+		}
+		roots = append(roots, s.computeDescendantsTree(root, typ))
+	} else {
+		for _, r := range s.hierarchyRoots() {
+			roots = append(roots, s.computeDescendantsTree(r.Name, r.Type))
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph hierarchy {")
+	fmt.Fprintln(bw, "\trankdir=TB;")
+	fmt.Fprintln(bw, "\tnode [fontname=\"Helvetica\"];")
+	seen := make(map[string]bool)
+	var writeNode func(n *HierarchyNode)
+	writeNode = func(n *HierarchyNode) {
+		id := n.Type + ":" + n.Name
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		fmt.Fprintf(bw, "\t%s [label=%s, shape=%s];\n", dotQuote(id), dotQuote(n.Name+"\n"+n.Type), dotShapes[n.Type])
+		children := slices.Clone(n.Children)
+		slices.SortFunc(children, func(a, b HierarchyNode) int {
+			return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Type, b.Type))
+		})
+		for i := range children {
+			fmt.Fprintf(bw, "\t%s -> %s;\n", dotQuote(id), dotQuote(children[i].Type+":"+children[i].Name))
+			writeNode(&children[i])
+		}
+	}
+	for _, r := range roots {
+		writeNode(r)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// hierarchyRoots returns the entities without a parent, or whose parent is
+// not a known entity, sorted by name.
+// Must be called with s.mu held.
+func (s *Service) hierarchyRoots() []HierarchyPathEntry {
+	var roots []HierarchyPathEntry
+	add := func(name, typ string, parent *ParentInfo) {
+		if parent == nil || s.getEntityType(parent.Name) == "" {
+			roots = append(roots, HierarchyPathEntry{Name: name, Type: typ})
+		}
+	}
+	for name, t := range s.data.Lookups.Teams {
+		add(name, "team", t.Parent)
+	}
+	for name, o := range s.data.Lookups.Orgs {
+		add(name, "org", o.Parent)
+	}
+	for name, p := range s.data.Lookups.Pillars {
+		add(name, "pillar", p.Parent)
+	}
+	for name, tg := range s.data.Lookups.TeamGroups {
+		add(name, "team_group", tg.Parent)
+	}
+	slices.SortFunc(roots, func(a, b HierarchyPathEntry) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Type, b.Type))
+	})
+	return roots
+}
+
+// ExportReportingDOT writes the reporting subtree of managerUID as a
+// Graphviz DOT digraph: the manager, their direct reports, and so on down,
+// with an edge from each manager to each report. Nodes are labelled with the
+// employee's name and job title. An unknown UID fails with ErrNotFound, and
+// without loaded data the export fails with ErrNoData.
+func (s *Service) ExportReportingDOT(w io.Writer, managerUID string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return ErrNoData
+	}
+	if _, ok := s.data.Lookups.Employees[managerUID]; !ok {
+		return fmt.Errorf("%w: employee %q", ErrNotFound, managerUID)
+	}
+
+	reports := s.derived().managerIndex()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph reporting {")
+	fmt.Fprintln(bw, "\trankdir=TB;")
+	fmt.Fprintln(bw, "\tnode [shape=box, fontname=\"Helvetica\"];")
+	// Breadth-first, so each level of the chart is written together; seen
+	// guards against manager cycles in bad data.
+	seen := map[string]bool{managerUID: true}
+	queue := []string{managerUID}
+	for len(queue) > 0 {
+		uid := queue[0]
+		queue = queue[1:]
+		emp := s.data.Lookups.Employees[uid]
+		label := emp.FullName
+		if label == "" {
+			label = uid
+		}
+		if emp.JobTitle != "" {
+			label += "\n" + emp.JobTitle
+		}
+		fmt.Fprintf(bw, "\t%s [label=%s];\n", dotQuote(uid), dotQuote(label))
+
+		direct := slices.Clone(reports[uid])
+		slices.Sort(direct)
+		for _, r := range direct {
+			if _, ok := s.data.Lookups.Employees[r]; !ok {
+				continue
+			}
+			fmt.Fprintf(bw, "\t%s -> %s;\n", dotQuote(uid), dotQuote(r))
+			if !seen[r] {
+				seen[r] = true
+				queue = append(queue, r)
+			}
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote returns s as a DOT double-quoted string.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// RenderSVG renders a DOT graph, such as the output of ExportHierarchyDOT,
+// to SVG by running Graphviz's dot command, which must be on the PATH.
+func RenderSVG(ctx context.Context, dot io.Reader, w io.Writer) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "dot", "-Tsvg")
+	cmd.Stdin = dot
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("render svg: %w: %s", err, msg)
+		}
+		return fmt.Errorf("render svg: %w", err)
+	}
+	return nil
+}
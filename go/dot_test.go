@@ -0,0 +1,120 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestExportHierarchyDOT(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name    string
+		root    string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "whole hierarchy",
+			root: "",
+			want: []string{
+				`"org:test-org" [label="test-org\norg", shape=folder];`,
+				`"org:test-org" -> "org:platform-org";`,
+				`"org:test-org" -> "team:test-team";`,
+				`"team_group:backend-teams" -> "team:platform-team";`,
+			},
+		},
+		{
+			name: "subtree",
+			root: "engineering",
+			want: []string{
+				`"pillar:engineering" [label="engineering\npillar", shape=box3d];`,
+				`"pillar:engineering" -> "team_group:backend-teams";`,
+			},
+			notWant: []string{`test-org`, `test-team`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := service.ExportHierarchyDOT(&buf, tt.root); err != nil {
+				t.Fatalf("ExportHierarchyDOT: %v", err)
+			}
+			out := buf.String()
+			if !strings.HasPrefix(out, "digraph hierarchy {\n") || !strings.HasSuffix(out, "}\n") {
+				t.Errorf("output is not a digraph:\n%s", out)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("output lacks %s:\n%s", w, out)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(out, w) {
+					t.Errorf("output contains %s:\n%s", w, out)
+				}
+			}
+		})
+	}
+}
+
+func TestExportReportingDOT(t *testing.T) {
+	service := setupTestService(t)
+
+	var buf bytes.Buffer
+	if err := service.ExportReportingDOT(&buf, "adoe"); err != nil {
+		t.Fatalf("ExportReportingDOT: %v", err)
+	}
+	want := "digraph reporting {\n" +
+		"\trankdir=TB;\n" +
+		"\tnode [shape=box, fontname=\"Helvetica\"];\n" +
+		"\t\"adoe\" [label=\"Alice Doe\\nTeam Lead\"];\n" +
+		"\t\"adoe\" -> \"jsmith\";\n" +
+		"\t\"jsmith\" [label=\"John Smith\\nSoftware Engineer\"];\n" +
+		"}\n"
+	if buf.String() != want {
+		t.Errorf("ExportReportingDOT =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestExportDOTErrors(t *testing.T) {
+	service := setupTestService(t)
+	var buf bytes.Buffer
+
+	if err := service.ExportHierarchyDOT(&buf, "nonexistent"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ExportHierarchyDOT(unknown) = %v, want ErrNotFound", err)
+	}
+	if err := service.ExportReportingDOT(&buf, "nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ExportReportingDOT(unknown) = %v, want ErrNotFound", err)
+	}
+	if err := NewService().ExportHierarchyDOT(&buf, ""); !errors.Is(err, ErrNoData) {
+		t.Errorf("ExportHierarchyDOT without data = %v, want ErrNoData", err)
+	}
+}
+
+func TestDOTQuote(t *testing.T) {
+	if got, want := dotQuote("a \"b\"\\c\nd"), `"a \"b\"\\c\nd"`; got != want {
+		t.Errorf("dotQuote = %s, want %s", got, want)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	if _, err := exec.LookPath("dot"); err != nil {
+		t.Skip("Graphviz dot is not installed")
+	}
+	var dot, svg bytes.Buffer
+	if err := setupTestService(t).ExportHierarchyDOT(&dot, ""); err != nil {
+		t.Fatalf("ExportHierarchyDOT: %v", err)
+	}
+	if err := RenderSVG(context.Background(), &dot, &svg); err != nil {
+		t.Fatalf("RenderSVG: %v", err)
+	}
+	if !strings.Contains(svg.String(), "<svg") {
+		t.Errorf("RenderSVG output is not SVG: %.200s", svg.String())
+	}
+}
@@ -463,3 +463,30 @@ func TestGetEmployeeByEmail(t *testing.T) {
 		})
 	}
 }
+
+// TestManagerIndex tests the lazily-built manager index
+func TestManagerIndex(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name         string
+		uid          string
+		expectedUIDs []string
+	}{
+		{name: "manager with one report", uid: "adoe", expectedUIDs: []string{"jsmith"}},
+		{name: "employee without reports", uid: "jsmith", expectedUIDs: []string{}},
+		{name: "nonexistent employee", uid: "nonexistent", expectedUIDs: []string{}},
+		{name: "empty UID", uid: "", expectedUIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service.mu.RLock()
+			uids := append([]string{}, service.derived().managerIndex()[tt.uid]...)
+			service.mu.RUnlock()
+			if !reflect.DeepEqual(uids, tt.expectedUIDs) {
+				t.Errorf("reports of %q = %v, expected %v", tt.uid, uids, tt.expectedUIDs)
+			}
+		})
+	}
+}
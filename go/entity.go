@@ -0,0 +1,32 @@
+package orgdatacore
+
+// Entity is a kind of named entity: a team, org, pillar, team group or
+// component.
+type Entity interface {
+	Team | Org | Pillar | TeamGroup | Component
+}
+
+// Get looks up the entity of kind T named name, for code that handles
+// references to several kinds of entity alike:
+//
+//	team, ok := orgdatacore.Get[orgdatacore.Team](svc, "platform-team")
+//
+// It calls the GetXByName method of s for T, and reports whether it found
+// the entity.
+func Get[T Entity](s ServiceInterface, name string) (*T, bool) {
+	var found any
+	switch any((*T)(nil)).(type) {
+	case *Team:
+		found = s.GetTeamByName(name)
+	case *Org:
+		found = s.GetOrgByName(name)
+	case *Pillar:
+		found = s.GetPillarByName(name)
+	case *TeamGroup:
+		found = s.GetTeamGroupByName(name)
+	case *Component:
+		found = s.GetComponentByName(name)
+	}
+	entity, _ := found.(*T)
+	return entity, entity != nil
+}
@@ -0,0 +1,31 @@
+package orgdatacore
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	service := setupTestService(t)
+
+	if team, ok := Get[Team](service, "test-team"); !ok || team.Name != "test-team" {
+		t.Errorf("Get[Team](test-team) = %v, %v", team, ok)
+	}
+	if org, ok := Get[Org](service, "platform-org"); !ok || org.Name != "platform-org" {
+		t.Errorf("Get[Org](platform-org) = %v, %v", org, ok)
+	}
+	if pillar, ok := Get[Pillar](service, "engineering"); !ok || pillar.Name != "engineering" {
+		t.Errorf("Get[Pillar](engineering) = %v, %v", pillar, ok)
+	}
+	if tg, ok := Get[TeamGroup](service, "backend-teams"); !ok || tg.Name != "backend-teams" {
+		t.Errorf("Get[TeamGroup](backend-teams) = %v, %v", tg, ok)
+	}
+	if component, ok := Get[Component](service, "platform-api"); !ok || component.Name != "platform-api" {
+		t.Errorf("Get[Component](platform-api) = %v, %v", component, ok)
+	}
+
+	// A name of another kind of entity is not found.
+	if org, ok := Get[Org](service, "test-team"); ok || org != nil {
+		t.Errorf("Get[Org](test-team) = %v, %v, want nil, false", org, ok)
+	}
+	if team, ok := Get[Team](NewService(), "test-team"); ok || team != nil {
+		t.Errorf("Get[Team] on an empty service = %v, %v, want nil, false", team, ok)
+	}
+}
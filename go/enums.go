@@ -47,13 +47,16 @@ const (
 	EntityOrg       EntityType = "org"
 	EntityPillar    EntityType = "pillar"
 	EntityTeamGroup EntityType = "team_group"
+	// EntityMembership is not an entity: it selects the employee-to-group
+	// edges of the membership index, for exports.
+	EntityMembership EntityType = "membership"
 )
 
 func (e EntityType) String() string { return string(e) }
 
 func (e EntityType) IsValid() bool {
 	switch e {
-	case EntityEmployee, EntityTeam, EntityOrg, EntityPillar, EntityTeamGroup:
+	case EntityEmployee, EntityTeam, EntityOrg, EntityPillar, EntityTeamGroup, EntityMembership:
 		return true
 	}
 	return false
@@ -76,3 +79,243 @@ func (p PIIMode) IsValid() bool {
 	}
 	return false
 }
+
+// IndexKind identifies a secondary index derived from the loaded data.
+type IndexKind string
+
+const (
+	IndexEmail        IndexKind = "email"
+	IndexSlackChannel IndexKind = "slack_channel"
+	IndexManager      IndexKind = "manager"
+	IndexRepo         IndexKind = "repo"
+	IndexGraph        IndexKind = "graph"
+	IndexSearch       IndexKind = "search"
+)
+
+func (i IndexKind) String() string { return string(i) }
+
+func (i IndexKind) IsValid() bool {
+	switch i {
+	case IndexEmail, IndexSlackChannel, IndexManager, IndexRepo, IndexGraph, IndexSearch:
+		return true
+	}
+	return false
+}
+
+// GraphEdgeKind is the relationship a GraphEdge stands for.
+type GraphEdgeKind string
+
+const (
+	// EdgeMemberOf links an employee to a group they belong to.
+	EdgeMemberOf GraphEdgeKind = "member_of"
+	// EdgeReportsTo links an employee to their manager.
+	EdgeReportsTo GraphEdgeKind = "reports_to"
+	// EdgeChildOf links a group to its parent in the hierarchy.
+	EdgeChildOf GraphEdgeKind = "child_of"
+)
+
+func (k GraphEdgeKind) String() string { return string(k) }
+
+func (k GraphEdgeKind) IsValid() bool {
+	switch k {
+	case EdgeMemberOf, EdgeReportsTo, EdgeChildOf:
+		return true
+	}
+	return false
+}
+
+// Section identifies a top-level part of the organizational data document
+// that can be skipped at load time with WithSections.
+type Section string
+
+const (
+	SectionEmployees          Section = "employees"
+	SectionTeams              Section = "teams"
+	SectionOrgs               Section = "orgs"
+	SectionPillars            Section = "pillars"
+	SectionTeamGroups         Section = "team_groups"
+	SectionComponents         Section = "components"
+	SectionMembership         Section = "membership"
+	SectionSlackIDs           Section = "slack_id_mappings"
+	SectionGitHubIDs          Section = "github_id_mappings"
+	SectionJira               Section = "jira"
+	SectionComponentOwnership Section = "component_ownership"
+)
+
+func (s Section) String() string { return string(s) }
+
+func (s Section) IsValid() bool {
+	switch s {
+	case SectionEmployees, SectionTeams, SectionOrgs, SectionPillars, SectionTeamGroups,
+		SectionComponents, SectionMembership, SectionSlackIDs, SectionGitHubIDs,
+		SectionJira, SectionComponentOwnership:
+		return true
+	}
+	return false
+}
+
+// EventType identifies a service lifecycle event delivered by SubscribeEvents.
+type EventType string
+
+const (
+	EventLoadStarted    EventType = "load_started"
+	EventLoadSucceeded  EventType = "load_succeeded"
+	EventLoadFailed     EventType = "load_failed"
+	EventWatcherStopped EventType = "watcher_stopped"
+	EventDataStale      EventType = "data_stale"
+)
+
+func (e EventType) String() string { return string(e) }
+
+func (e EventType) IsValid() bool {
+	switch e {
+	case EventLoadStarted, EventLoadSucceeded, EventLoadFailed, EventWatcherStopped, EventDataStale:
+		return true
+	}
+	return false
+}
+
+// MembershipEventType identifies an org change delivered by
+// OnMembershipEvents.
+type MembershipEventType string
+
+const (
+	EmployeeJoinedTeam MembershipEventType = "employee_joined_team"
+	EmployeeLeftTeam   MembershipEventType = "employee_left_team"
+	EmployeeJoinedOrg  MembershipEventType = "employee_joined_org"
+	EmployeeLeftOrg    MembershipEventType = "employee_left_org"
+	ManagerChanged     MembershipEventType = "manager_changed"
+	TeamMoved          MembershipEventType = "team_moved"
+)
+
+func (e MembershipEventType) String() string { return string(e) }
+
+func (e MembershipEventType) IsValid() bool {
+	switch e {
+	case EmployeeJoinedTeam, EmployeeLeftTeam, EmployeeJoinedOrg, EmployeeLeftOrg, ManagerChanged, TeamMoved:
+		return true
+	}
+	return false
+}
+
+// Severity grades a validation issue. Issues at or above the service's
+// validation threshold reject the load.
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+func (s Severity) String() string { return string(s) }
+
+func (s Severity) IsValid() bool {
+	switch s {
+	case SeverityInfo, SeverityWarning, SeverityError:
+		return true
+	}
+	return false
+}
+
+// AtLeast reports whether s is as severe as min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.rank() >= min.rank()
+}
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	}
+	return 0
+}
+
+// ValidationCheck names one of the checks run on every new dataset. Use it
+// with WithCheckSeverity to change how seriously the check's issues are taken.
+type ValidationCheck string
+
+const (
+	// CheckRequiredSections reports sections that must not be empty.
+	CheckRequiredSections ValidationCheck = "required_sections"
+	// CheckPIIFree reports personal data in a dump marked pii_free.
+	CheckPIIFree ValidationCheck = "pii_free"
+	// CheckDanglingManager reports employees whose manager is not an employee.
+	CheckDanglingManager ValidationCheck = "dangling_manager"
+	// CheckUnknownMember reports members of teams, orgs, pillars and team
+	// groups that are not employees.
+	CheckUnknownMember ValidationCheck = "unknown_member"
+	// CheckMissingTeam reports teams in the membership index that are not in
+	// the team lookup.
+	CheckMissingTeam ValidationCheck = "missing_team"
+	// CheckMembershipMismatch reports team memberships that the membership
+	// index and the team's member list disagree on.
+	CheckMembershipMismatch ValidationCheck = "membership_mismatch"
+	// CheckUnknownUID reports UIDs in the membership index and the Slack and
+	// GitHub ID mappings that are not employees.
+	CheckUnknownUID ValidationCheck = "unknown_uid"
+	// CheckDanglingParent reports parents that are not in the lookups.
+	CheckDanglingParent ValidationCheck = "dangling_parent"
+	// CheckUnknownEntity reports orgs, pillars and team groups in the
+	// membership index, and Jira and component owners, that are not in the
+	// lookups.
+	CheckUnknownEntity ValidationCheck = "unknown_entity"
+	// CheckManagerCycle reports employees whose manager chain loops back on
+	// itself.
+	CheckManagerCycle ValidationCheck = "manager_cycle"
+	// CheckHierarchyCycle reports loops in the parent links between teams,
+	// orgs, pillars and team groups.
+	CheckHierarchyCycle ValidationCheck = "hierarchy_cycle"
+	// CheckDuplicateID reports Slack IDs, GitHub IDs and emails shared by
+	// several employees, which lookups can only resolve to one of them.
+	CheckDuplicateID ValidationCheck = "duplicate_id"
+	// CheckNameCollision reports names shared by entities of different
+	// types, which name-only lookups such as GetDescendantsTree cannot tell
+	// apart.
+	CheckNameCollision ValidationCheck = "name_collision"
+	// CheckUnknownField reports dump fields the Go types do not model. It
+	// only runs under WithUnknownFieldCheck.
+	CheckUnknownField ValidationCheck = "unknown_field"
+)
+
+func (c ValidationCheck) String() string { return string(c) }
+
+func (c ValidationCheck) IsValid() bool {
+	switch c {
+	case CheckRequiredSections, CheckPIIFree, CheckDanglingManager, CheckUnknownMember, CheckMissingTeam,
+		CheckMembershipMismatch, CheckUnknownUID, CheckDanglingParent, CheckUnknownEntity, CheckManagerCycle,
+		CheckHierarchyCycle, CheckDuplicateID, CheckNameCollision, CheckUnknownField:
+		return true
+	}
+	return false
+}
+
+// RepairKind names a kind of fix applied to a dataset under WithRepair.
+type RepairKind string
+
+const (
+	// RepairTrimWhitespace trims whitespace around employee fields and
+	// member UIDs.
+	RepairTrimWhitespace RepairKind = "trim_whitespace"
+	// RepairLowercaseEmail lowercases employee emails.
+	RepairLowercaseEmail RepairKind = "lowercase_email"
+	// RepairSelfManager removes managers that are the employee themselves.
+	RepairSelfManager RepairKind = "self_manager"
+	// RepairDuplicateMembership removes repeated entries from the membership
+	// index and from group member lists.
+	RepairDuplicateMembership RepairKind = "duplicate_membership"
+)
+
+func (k RepairKind) String() string { return string(k) }
+
+func (k RepairKind) IsValid() bool {
+	switch k {
+	case RepairTrimWhitespace, RepairLowercaseEmail, RepairSelfManager, RepairDuplicateMembership:
+		return true
+	}
+	return false
+}
@@ -66,6 +66,7 @@ func TestEntityType(t *testing.T) {
 		{EntityOrg, "org", true},
 		{EntityPillar, "pillar", true},
 		{EntityTeamGroup, "team_group", true},
+		{EntityMembership, "membership", true},
 		{EntityType("invalid"), "invalid", false},
 		{EntityType(""), "", false},
 	}
@@ -81,3 +82,235 @@ func TestEntityType(t *testing.T) {
 		})
 	}
 }
+
+func TestIndexKind(t *testing.T) {
+	tests := []struct {
+		ik      IndexKind
+		str     string
+		isValid bool
+	}{
+		{IndexEmail, "email", true},
+		{IndexSlackChannel, "slack_channel", true},
+		{IndexManager, "manager", true},
+		{IndexRepo, "repo", true},
+		{IndexGraph, "graph", true},
+		{IndexSearch, "search", true},
+		{IndexKind("invalid"), "invalid", false},
+		{IndexKind(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.ik), func(t *testing.T) {
+			if got := tt.ik.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.ik.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
+
+func TestGraphEdgeKind(t *testing.T) {
+	tests := []struct {
+		k       GraphEdgeKind
+		str     string
+		isValid bool
+	}{
+		{EdgeMemberOf, "member_of", true},
+		{EdgeReportsTo, "reports_to", true},
+		{EdgeChildOf, "child_of", true},
+		{GraphEdgeKind("invalid"), "invalid", false},
+		{GraphEdgeKind(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.k), func(t *testing.T) {
+			if got := tt.k.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.k.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
+
+func TestSection(t *testing.T) {
+	tests := []struct {
+		s       Section
+		str     string
+		isValid bool
+	}{
+		{SectionEmployees, "employees", true},
+		{SectionTeams, "teams", true},
+		{SectionOrgs, "orgs", true},
+		{SectionPillars, "pillars", true},
+		{SectionTeamGroups, "team_groups", true},
+		{SectionComponents, "components", true},
+		{SectionMembership, "membership", true},
+		{SectionSlackIDs, "slack_id_mappings", true},
+		{SectionGitHubIDs, "github_id_mappings", true},
+		{SectionJira, "jira", true},
+		{SectionComponentOwnership, "component_ownership", true},
+		{Section("metadata"), "metadata", false},
+		{Section(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.s), func(t *testing.T) {
+			if got := tt.s.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.s.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
+
+func TestEventType(t *testing.T) {
+	tests := []struct {
+		e       EventType
+		str     string
+		isValid bool
+	}{
+		{EventLoadStarted, "load_started", true},
+		{EventLoadSucceeded, "load_succeeded", true},
+		{EventLoadFailed, "load_failed", true},
+		{EventWatcherStopped, "watcher_stopped", true},
+		{EventDataStale, "data_stale", true},
+		{EventType("reloaded"), "reloaded", false},
+		{EventType(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.e), func(t *testing.T) {
+			if got := tt.e.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.e.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
+
+func TestMembershipEventType(t *testing.T) {
+	tests := []struct {
+		e       MembershipEventType
+		str     string
+		isValid bool
+	}{
+		{EmployeeJoinedTeam, "employee_joined_team", true},
+		{EmployeeLeftTeam, "employee_left_team", true},
+		{EmployeeJoinedOrg, "employee_joined_org", true},
+		{EmployeeLeftOrg, "employee_left_org", true},
+		{ManagerChanged, "manager_changed", true},
+		{TeamMoved, "team_moved", true},
+		{MembershipEventType("org_moved"), "org_moved", false},
+		{MembershipEventType(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.e), func(t *testing.T) {
+			if got := tt.e.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.e.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		s       Severity
+		str     string
+		isValid bool
+		warning bool // AtLeast(SeverityWarning)
+	}{
+		{SeverityInfo, "info", true, false},
+		{SeverityWarning, "warning", true, true},
+		{SeverityError, "error", true, true},
+		{Severity("fatal"), "fatal", false, false},
+		{Severity(""), "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.s), func(t *testing.T) {
+			if got := tt.s.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.s.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+			if got := tt.s.AtLeast(SeverityWarning); got != tt.warning {
+				t.Errorf("AtLeast(SeverityWarning) = %v, want %v", got, tt.warning)
+			}
+		})
+	}
+}
+
+func TestValidationCheck(t *testing.T) {
+	tests := []struct {
+		c       ValidationCheck
+		str     string
+		isValid bool
+	}{
+		{CheckRequiredSections, "required_sections", true},
+		{CheckPIIFree, "pii_free", true},
+		{CheckDanglingManager, "dangling_manager", true},
+		{CheckUnknownMember, "unknown_member", true},
+		{CheckMissingTeam, "missing_team", true},
+		{CheckMembershipMismatch, "membership_mismatch", true},
+		{CheckUnknownUID, "unknown_uid", true},
+		{CheckDanglingParent, "dangling_parent", true},
+		{CheckUnknownEntity, "unknown_entity", true},
+		{CheckManagerCycle, "manager_cycle", true},
+		{CheckHierarchyCycle, "hierarchy_cycle", true},
+		{CheckDuplicateID, "duplicate_id", true},
+		{CheckNameCollision, "name_collision", true},
+		{CheckUnknownField, "unknown_field", true},
+		{ValidationCheck("spelling"), "spelling", false},
+		{ValidationCheck(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.c), func(t *testing.T) {
+			if got := tt.c.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.c.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
+
+func TestRepairKind(t *testing.T) {
+	tests := []struct {
+		k       RepairKind
+		str     string
+		isValid bool
+	}{
+		{RepairTrimWhitespace, "trim_whitespace", true},
+		{RepairLowercaseEmail, "lowercase_email", true},
+		{RepairSelfManager, "self_manager", true},
+		{RepairDuplicateMembership, "duplicate_membership", true},
+		{RepairKind("rewrite"), "rewrite", false},
+		{RepairKind(""), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.k), func(t *testing.T) {
+			if got := tt.k.String(); got != tt.str {
+				t.Errorf("String() = %q, want %q", got, tt.str)
+			}
+			if got := tt.k.IsValid(); got != tt.isValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.isValid)
+			}
+		})
+	}
+}
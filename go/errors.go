@@ -12,6 +12,21 @@ var (
 	ErrInvalidConfig         = errors.New("orgdatacore: invalid configuration")
 	ErrWatcherAlreadyRunning = errors.New("orgdatacore: watcher already running")
 	ErrInvalidData           = errors.New("orgdatacore: invalid data structure")
+	ErrChangeSetConflict     = errors.New("orgdatacore: change set does not apply to loaded data")
+	ErrFullReloadRequired    = errors.New("orgdatacore: full reload required")
+	ErrLoadRejected          = errors.New("orgdatacore: data rejected by load validator")
+	ErrNoDataSource          = errors.New("orgdatacore: no data source has been used")
+	ErrStaleData             = errors.New("orgdatacore: data is older than the maximum age")
+	ErrNoSnapshot            = errors.New("orgdatacore: no snapshot to restore")
+	ErrSchemaMismatch        = errors.New("orgdatacore: data does not match schema")
+	ErrDataShrunk            = errors.New("orgdatacore: new data is much smaller than the loaded data")
+	ErrInvalidExport         = errors.New("orgdatacore: invalid export request")
+	ErrInvalidImport         = errors.New("orgdatacore: invalid import")
+	ErrSnapshotReadOnly      = errors.New("orgdatacore: snapshots cannot load data")
+	ErrTenantExists          = errors.New("orgdatacore: tenant already registered")
+	ErrFederatedLoad         = errors.New("orgdatacore: federated services load through their members")
+	ErrVisibleLoad           = errors.New("orgdatacore: visible services load through the wrapped service")
+	ErrInvalidOverlay        = errors.New("orgdatacore: invalid overlay")
 )
 
 // NotFoundError wraps ErrNotFound with details about what wasn't found.
@@ -75,3 +90,28 @@ func (e *LoadError) Unwrap() error {
 func NewLoadError(source string, err error) *LoadError {
 	return &LoadError{Source: source, Err: err}
 }
+
+// ValidationError wraps ErrInvalidData with the validation issues that
+// rejected a dataset.
+type ValidationError struct {
+	// Issues are the issues at or above the validation threshold.
+	Issues []ValidationIssue
+	// Report is the full report, including issues below the threshold.
+	Report *ValidationReport
+}
+
+func (e *ValidationError) Error() string {
+	msg := fmt.Sprintf("%v: %s", ErrInvalidData, e.Issues[0].Message)
+	if n := len(e.Issues) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return msg
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidData
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidData
+}
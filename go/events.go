@@ -0,0 +1,98 @@
+package orgdatacore
+
+import (
+	"sync"
+	"time"
+)
+
+// Event describes a service lifecycle change delivered by SubscribeEvents.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Source is the data source being loaded, "changeset" for
+	// ApplyChangeSet.
+	Source string
+	// Watcher is the watcher name for EventWatcherStopped.
+	Watcher string
+	// DataVersion is the data_version loaded, for EventLoadSucceeded and
+	// EventDataStale.
+	DataVersion string
+	// Duration is how long the load took, for EventLoadSucceeded and
+	// EventLoadFailed.
+	Duration time.Duration
+	// Err is the failure for EventLoadFailed, the staleness for
+	// EventDataStale, and the source error for EventWatcherStopped when the
+	// watcher stopped on its own; nil when it was stopped or cancelled.
+	Err error
+}
+
+// eventSubscribers is the set of SubscribeEvents channels. It has its own
+// lock so events can be published while s.mu is held or not.
+type eventSubscribers struct {
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+// SubscribeEvents returns a channel receiving the service's lifecycle events:
+// loads starting, succeeding and failing (including watcher reloads and change
+// sets), watchers stopping, and loads of data that is already stale under
+// WithMaxDataAge. Use it to react to the service programmatically rather than
+// by scraping logs.
+//
+// Events are sent without blocking: when the channel's buffer is full, the
+// event is dropped for that subscriber, so size buffer for how quickly the
+// channel is drained. Call unsubscribe to stop delivery and close the channel.
+func (s *Service) SubscribeEvents(buffer int) (events <-chan Event, unsubscribe func()) {
+	ch := make(chan Event, max(buffer, 0))
+
+	s.eventSubs.mu.Lock()
+	if s.eventSubs.subs == nil {
+		s.eventSubs.subs = make(map[chan Event]struct{})
+	}
+	s.eventSubs.subs[ch] = struct{}{}
+	s.eventSubs.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.eventSubs.mu.Lock()
+			delete(s.eventSubs.subs, ch)
+			s.eventSubs.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// emit sends ev to every subscriber that has room for it.
+func (s *Service) emit(ev Event) {
+	ev.Time = s.clock.Now()
+
+	s.eventSubs.mu.Lock()
+	defer s.eventSubs.mu.Unlock()
+	for ch := range s.eventSubs.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// startLoad begins tracking a load attempt from source.
+func (s *Service) startLoad(source string) *loadAttempt {
+	s.emit(Event{Type: EventLoadStarted, Source: source})
+	return newLoadAttempt(source, s.clock)
+}
+
+// finishLoad records the outcome of attempt in the load stats and events.
+func (s *Service) finishLoad(attempt *loadAttempt, err error) {
+	s.loadStats.record(attempt, err)
+
+	ev := Event{Type: EventLoadSucceeded, Source: attempt.source, Duration: time.Since(attempt.start)}
+	if err != nil {
+		ev.Type, ev.Err = EventLoadFailed, err
+	} else if attempt.installed != nil {
+		ev.Duration = attempt.installed.Duration
+		ev.DataVersion = attempt.installed.DataVersion
+	}
+	s.emit(ev)
+}
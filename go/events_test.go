@@ -0,0 +1,145 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func drainEvents(events <-chan Event) []Event {
+	var got []Event
+	for {
+		select {
+		case ev := <-events:
+			got = append(got, ev)
+		default:
+			return got
+		}
+	}
+}
+
+func eventTypes(events []Event) []EventType {
+	types := make([]EventType, 0, len(events))
+	for _, ev := range events {
+		types = append(types, ev.Type)
+	}
+	return types
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	ctx := context.Background()
+	stale := CreateTestData()
+	stale.Metadata.GeneratedAt = time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	raw, err := json.Marshal(stale)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		opts      []ServiceOption
+		load      func(*Service) error
+		wantTypes []EventType
+		wantErr   bool
+	}{
+		{
+			name:      "load succeeded",
+			load:      func(s *Service) error { return s.LoadFromDataSource(ctx, jsonSource(t, "primary", CreateTestData())) },
+			wantTypes: []EventType{EventLoadStarted, EventLoadSucceeded},
+		},
+		{
+			name:      "load failed",
+			load:      func(s *Service) error { return s.LoadFromDataSource(ctx, NewFakeDataSource("{not json")) },
+			wantTypes: []EventType{EventLoadStarted, EventLoadFailed},
+			wantErr:   true,
+		},
+		{
+			name:      "stale data",
+			opts:      []ServiceOption{WithMaxDataAge(time.Hour)},
+			load:      func(s *Service) error { return s.LoadFromDataSource(ctx, NewFakeDataSource(string(raw))) },
+			wantTypes: []EventType{EventLoadStarted, EventDataStale, EventLoadSucceeded},
+		},
+		{
+			name:      "change set",
+			load:      func(s *Service) error { return s.ApplyChangeSet(ChangeSet{}) },
+			wantTypes: []EventType{EventLoadStarted, EventLoadFailed},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(tt.opts...)
+			events, unsubscribe := service.SubscribeEvents(8)
+			defer unsubscribe()
+
+			err := tt.load(service)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("load error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			got := drainEvents(events)
+			types := eventTypes(got)
+			if len(types) != len(tt.wantTypes) {
+				t.Fatalf("events = %v, want %v", types, tt.wantTypes)
+			}
+			for i := range types {
+				if types[i] != tt.wantTypes[i] {
+					t.Fatalf("events = %v, want %v", types, tt.wantTypes)
+				}
+			}
+			for _, ev := range got {
+				if ev.Time.IsZero() {
+					t.Errorf("%s event has no time", ev.Type)
+				}
+				if ev.Type == EventLoadFailed && ev.Err == nil {
+					t.Error("load_failed event has no error")
+				}
+				if ev.Type == EventDataStale && !errors.Is(ev.Err, ErrStaleData) {
+					t.Errorf("data_stale error = %v, want ErrStaleData", ev.Err)
+				}
+			}
+			last := got[len(got)-1]
+			if last.Type == EventLoadSucceeded && last.DataVersion == "" {
+				t.Error("load_succeeded event has no data version")
+			}
+		})
+	}
+}
+
+func TestSubscribeEventsWatcherStopped(t *testing.T) {
+	service := NewService()
+	events, unsubscribe := service.SubscribeEvents(8)
+	defer unsubscribe()
+
+	done := startWatcher(t, service, "primary", newContextDataSource("primary-source"))
+	service.StopNamedWatcher("primary")
+	waitStopped(t, "primary", done)
+
+	got := drainEvents(events)
+	if len(got) == 0 || got[len(got)-1].Type != EventWatcherStopped {
+		t.Fatalf("events = %v, want watcher_stopped last", eventTypes(got))
+	}
+	stopped := got[len(got)-1]
+	if stopped.Watcher != "primary" || stopped.Source != "primary-source" || stopped.Err != nil {
+		t.Errorf("watcher_stopped = %+v, want watcher primary from primary-source without error", stopped)
+	}
+}
+
+func TestSubscribeEventsUnsubscribe(t *testing.T) {
+	service := NewService()
+	events, unsubscribe := service.SubscribeEvents(0)
+
+	// An unbuffered subscriber nobody reads from must not block loads.
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "primary", CreateTestData())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("channel still open after unsubscribe")
+	}
+}
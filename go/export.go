@@ -0,0 +1,206 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// exportColumn is one column of a flat export.
+type exportColumn[T any] struct {
+	name  string
+	value func(T) string
+}
+
+var employeeColumns = []exportColumn[*Employee]{
+	{"uid", func(e *Employee) string { return e.UID }},
+	{"full_name", func(e *Employee) string { return e.FullName }},
+	{"email", func(e *Employee) string { return e.Email }},
+	{"job_title", func(e *Employee) string { return e.JobTitle }},
+	{"slack_uid", func(e *Employee) string { return e.SlackUID }},
+	{"github_id", func(e *Employee) string { return e.GitHubID }},
+	{"rhat_geo", func(e *Employee) string { return e.RhatGeo }},
+	{"cost_center", func(e *Employee) string { return strconv.Itoa(e.CostCenter) }},
+	{"manager_uid", func(e *Employee) string { return e.ManagerUID }},
+	{"is_people_manager", func(e *Employee) string { return strconv.FormatBool(e.IsPeopleManager) }},
+	{"timezone", func(e *Employee) string { return e.Timezone }},
+}
+
+// groupRow holds the fields teams, orgs, pillars and team groups share.
+type groupRow struct {
+	UID, Name, TabName, Description, Type string
+	Parent                                *ParentInfo
+	Group                                 *Group
+}
+
+var groupColumns = []exportColumn[*groupRow]{
+	{"uid", func(g *groupRow) string { return g.UID }},
+	{"name", func(g *groupRow) string { return g.Name }},
+	{"tab_name", func(g *groupRow) string { return g.TabName }},
+	{"description", func(g *groupRow) string { return g.Description }},
+	{"type", func(g *groupRow) string { return g.Type }},
+	{"parent_name", func(g *groupRow) string {
+		if g.Parent == nil {
+			return ""
+		}
+		return g.Parent.Name
+	}},
+	{"parent_type", func(g *groupRow) string {
+		if g.Parent == nil {
+			return ""
+		}
+		return g.Parent.Type
+	}},
+	{"member_count", func(g *groupRow) string { return strconv.Itoa(len(g.Group.ResolvedPeopleUIDList)) }},
+	{"members", func(g *groupRow) string {
+		members := slices.Clone(g.Group.ResolvedPeopleUIDList)
+		slices.Sort(members)
+		return strings.Join(members, ";")
+	}},
+	{"slack_channels", func(g *groupRow) string {
+		if g.Group.Slack == nil {
+			return ""
+		}
+		channels := make([]string, 0, len(g.Group.Slack.Channels))
+		for _, ch := range g.Group.Slack.Channels {
+			channels = append(channels, ch.Channel)
+		}
+		return strings.Join(channels, ";")
+	}},
+}
+
+// membershipEdge is one employee-to-group entry of the membership index.
+type membershipEdge struct {
+	UID string
+	MembershipInfo
+}
+
+var membershipColumns = []exportColumn[*membershipEdge]{
+	{"uid", func(m *membershipEdge) string { return m.UID }},
+	{"name", func(m *membershipEdge) string { return m.Name }},
+	{"type", func(m *membershipEdge) string { return m.Type }},
+}
+
+// ExportCSV writes the loaded employees, teams, orgs, pillars or team groups,
+// or with EntityMembership the membership index as one uid,name,type row per
+// employee-to-group edge, as CSV with a header row. Rows are sorted by UID
+// or name.
+//
+// fields selects and orders the columns by their names, which are the JSON
+// field names for employees, plus parent_name, parent_type, member_count,
+// members and slack_channels for groups; an empty list exports every column.
+// List values are joined with ";". An unknown entity type or field fails with
+// ErrInvalidExport, and without loaded data ExportCSV fails with ErrNoData.
+func (s *Service) ExportCSV(w io.Writer, entity EntityType, fields []string) error {
+	return s.export(w, entity, fields, ',')
+}
+
+// ExportTSV is ExportCSV with tab-separated columns.
+func (s *Service) ExportTSV(w io.Writer, entity EntityType, fields []string) error {
+	return s.export(w, entity, fields, '\t')
+}
+
+func (s *Service) export(w io.Writer, entity EntityType, fields []string, comma rune) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return ErrNoData
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	lookups := &s.data.Lookups
+	var err error
+	switch entity {
+	case EntityEmployee:
+		rows := make([]*Employee, 0, len(lookups.Employees))
+		for uid := range lookups.Employees {
+			emp := lookups.Employees[uid]
+			rows = append(rows, &emp)
+		}
+		slices.SortFunc(rows, func(a, b *Employee) int { return cmp.Compare(a.UID, b.UID) })
+		err = writeExport(cw, entity, employeeColumns, fields, rows)
+	case EntityTeam:
+		err = writeExport(cw, entity, groupColumns, fields, groupRows(lookups.Teams, func(t *Team) groupRow {
+			return groupRow{t.UID, t.Name, t.TabName, t.Description, t.Type, t.Parent, &t.Group}
+		}))
+	case EntityOrg:
+		err = writeExport(cw, entity, groupColumns, fields, groupRows(lookups.Orgs, func(o *Org) groupRow {
+			return groupRow{o.UID, o.Name, o.TabName, o.Description, o.Type, o.Parent, &o.Group}
+		}))
+	case EntityPillar:
+		err = writeExport(cw, entity, groupColumns, fields, groupRows(lookups.Pillars, func(p *Pillar) groupRow {
+			return groupRow{p.UID, p.Name, p.TabName, p.Description, p.Type, p.Parent, &p.Group}
+		}))
+	case EntityTeamGroup:
+		err = writeExport(cw, entity, groupColumns, fields, groupRows(lookups.TeamGroups, func(tg *TeamGroup) groupRow {
+			return groupRow{tg.UID, tg.Name, tg.TabName, tg.Description, tg.Type, tg.Parent, &tg.Group}
+		}))
+	case EntityMembership:
+		var rows []*membershipEdge
+		for uid, memberships := range s.data.Indexes.Membership.MembershipIndex {
+			for _, m := range memberships {
+				rows = append(rows, &membershipEdge{UID: uid, MembershipInfo: m})
+			}
+		}
+		slices.SortFunc(rows, func(a, b *membershipEdge) int {
+			return cmp.Or(cmp.Compare(a.UID, b.UID), cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name))
+		})
+		err = writeExport(cw, entity, membershipColumns, fields, rows)
+	default:
+		return fmt.Errorf("%w: cannot export entity type %q", ErrInvalidExport, entity)
+	}
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// groupRows converts a lookup map to rows sorted by name.
+func groupRows[T any](groups map[string]T, row func(*T) groupRow) []*groupRow {
+	rows := make([]*groupRow, 0, len(groups))
+	for name := range groups {
+		g := groups[name]
+		r := row(&g)
+		rows = append(rows, &r)
+	}
+	slices.SortFunc(rows, func(a, b *groupRow) int { return cmp.Compare(a.Name, b.Name) })
+	return rows
+}
+
+func writeExport[T any](cw *csv.Writer, entity EntityType, columns []exportColumn[T], fields []string, rows []T) error {
+	selected := columns
+	if len(fields) > 0 {
+		selected = make([]exportColumn[T], 0, len(fields))
+		for _, f := range fields {
+			i := slices.IndexFunc(columns, func(c exportColumn[T]) bool { return c.name == f })
+			if i < 0 {
+				return fmt.Errorf("%w: unknown %s field %q", ErrInvalidExport, entity, f)
+			}
+			selected = append(selected, columns[i])
+		}
+	}
+
+	record := make([]string, len(selected))
+	for i, c := range selected {
+		record[i] = c.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, c := range selected {
+			record[i] = c.value(row)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name   string
+		entity EntityType
+		fields []string
+		want   string
+	}{
+		{
+			name:   "employee fields",
+			entity: EntityEmployee,
+			fields: []string{"uid", "manager_uid", "is_people_manager"},
+			want: "uid,manager_uid,is_people_manager\n" +
+				"adoe,,true\n" +
+				"bwilson,,false\n" +
+				"jsmith,adoe,false\n",
+		},
+		{
+			name:   "team members",
+			entity: EntityTeam,
+			fields: []string{"name", "parent_name", "members"},
+			want: "name,parent_name,members\n" +
+				"platform-team,backend-teams,bwilson\n" +
+				"test-team,test-org,adoe;jsmith\n",
+		},
+		{
+			name:   "pillars",
+			entity: EntityPillar,
+			fields: []string{"name", "type"},
+			want:   "name,type\nengineering,pillar\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := service.ExportCSV(&buf, tt.entity, tt.fields); err != nil {
+				t.Fatalf("ExportCSV: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("ExportCSV =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExportCSVAllColumns(t *testing.T) {
+	service := setupTestService(t)
+
+	var buf bytes.Buffer
+	if err := service.ExportCSV(&buf, EntityEmployee, []string{}); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "uid,full_name,email,job_title,slack_uid,github_id,rhat_geo,cost_center,manager_uid,is_people_manager,timezone" {
+		t.Errorf("header = %s", lines[0])
+	}
+	if len(lines) != 4 || !strings.HasPrefix(lines[3], "jsmith,John Smith,jsmith@example.com,") {
+		t.Errorf("rows = %q, want three employees sorted by UID", lines[1:])
+	}
+
+	buf.Reset()
+	if err := service.ExportCSV(&buf, EntityMembership, nil); err != nil {
+		t.Fatalf("ExportCSV(membership): %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	// One row per membership index edge.
+	if lines[0] != "uid,name,type" || len(lines) != 1+service.GetStatistics().MembershipEdges {
+		t.Errorf("membership export = %q", lines)
+	}
+	if !strings.Contains(buf.String(), "jsmith,test-team,team\n") {
+		t.Errorf("membership export lacks jsmith's team edge:\n%s", buf.String())
+	}
+}
+
+func TestExportTSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := setupTestService(t).ExportTSV(&buf, EntityTeam, []string{"name", "member_count"}); err != nil {
+		t.Fatalf("ExportTSV: %v", err)
+	}
+	if want := "name\tmember_count\nplatform-team\t1\ntest-team\t2\n"; buf.String() != want {
+		t.Errorf("ExportTSV = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestExportCSVErrors(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name    string
+		service *Service
+		entity  EntityType
+		fields  []string
+		want    error
+	}{
+		{"unknown field", service, EntityEmployee, []string{"uid", "salary"}, ErrInvalidExport},
+		{"group field on employees", service, EntityEmployee, []string{"members"}, ErrInvalidExport},
+		{"unknown entity", service, EntityType("component"), nil, ErrInvalidExport},
+		{"no data", NewService(), EntityEmployee, nil, ErrNoData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.service.ExportCSV(&buf, tt.entity, tt.fields)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ExportCSV error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,520 @@
+package orgdatacore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FederatedService is a ServiceInterface that answers from several services,
+// for orgs whose data is split between producers: each member can be a
+// local Service, an httpclient.Client or any other implementation.
+//
+//	fed := orgdatacore.NewFederatedService(localService, remoteClient)
+//
+// Members are listed in order of precedence, and results merge by these
+// rules:
+//
+//   - lookups of one entity return the answer of the first member that has
+//     it;
+//   - queries about a team, org or component, such as GetTeamMembers, are
+//     answered by the first member that has that entity, so the members and
+//     metadata of one team never mix producers;
+//   - lists, such as GetAllTeams or GetTeamsForUID, are the union of the
+//     members' lists, where an entity listed by several members appears once,
+//     as the first of them lists it;
+//   - membership checks are answered by the member owning the team or org,
+//     like GetTeamMembers and GetOrgMembers; a Slack ID is first resolved to
+//     an employee as GetEmployeeBySlackID does;
+//   - graph queries stay within one member: Neighborhood is answered by the
+//     member that has the node, and FindPath by the first member connecting
+//     both nodes;
+//   - Search results are merged as lists are, then ranked and limited
+//     together.
+//
+// GetVersion describes the federated view as a whole, and GetDataAge and
+// IsDataStale follow the stalest member.
+//
+// Members load and watch their own data: LoadFromDataSource and
+// StartDataSourceWatcher fail with ErrFederatedLoad, and StopWatcher does
+// nothing.
+type FederatedService struct {
+	members []ServiceInterface
+}
+
+var _ ServiceInterface = (*FederatedService)(nil)
+
+// NewFederatedService returns a FederatedService over members, highest
+// precedence first.
+func NewFederatedService(members ...ServiceInterface) *FederatedService {
+	return &FederatedService{members: slices.Clone(members)}
+}
+
+// Members returns the federated services, highest precedence first.
+func (f *FederatedService) Members() []ServiceInterface {
+	return slices.Clone(f.members)
+}
+
+// firstOf returns the first non-nil answer of the members.
+func firstOf[T any](f *FederatedService, query func(ServiceInterface) *T) *T {
+	for _, m := range f.members {
+		if v := query(m); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// firstNonEmpty returns the first non-empty answer of the members.
+func firstNonEmpty[T any](f *FederatedService, query func(ServiceInterface) []T) []T {
+	for _, m := range f.members {
+		if v := query(m); len(v) > 0 {
+			return v
+		}
+	}
+	return []T{}
+}
+
+// unionOf concatenates the answers of the members, keeping the first of the
+// elements that have the same key.
+func unionOf[T any, K comparable](f *FederatedService, query func(ServiceInterface) []T, key func(T) K) []T {
+	out := []T{}
+	seen := make(map[K]bool)
+	for _, m := range f.members {
+		for _, v := range query(m) {
+			if k := key(v); !seen[k] {
+				seen[k] = true
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+func unionStrings(f *FederatedService, query func(ServiceInterface) []string) []string {
+	return unionOf(f, query, func(s string) string { return s })
+}
+
+// owner returns the first member that has the entity, or nil.
+func (f *FederatedService) owner(has func(ServiceInterface) bool) ServiceInterface {
+	for _, m := range f.members {
+		if has(m) {
+			return m
+		}
+	}
+	return nil
+}
+
+func (f *FederatedService) teamOwner(teamName string) ServiceInterface {
+	return f.owner(func(m ServiceInterface) bool { return m.GetTeamByName(teamName) != nil })
+}
+
+// entityOwner returns the first member that has the team, org, pillar or
+// team group. An empty entityType matches any of them.
+func (f *FederatedService) entityOwner(name, entityType string) ServiceInterface {
+	return f.owner(func(m ServiceInterface) bool {
+		switch strings.ToLower(entityType) {
+		case "team":
+			return m.GetTeamByName(name) != nil
+		case "org":
+			return m.GetOrgByName(name) != nil
+		case "pillar":
+			return m.GetPillarByName(name) != nil
+		case "team_group":
+			return m.GetTeamGroupByName(name) != nil
+		case "":
+			return m.GetTeamByName(name) != nil || m.GetOrgByName(name) != nil ||
+				m.GetPillarByName(name) != nil || m.GetTeamGroupByName(name) != nil
+		}
+		return false
+	})
+}
+
+func employeeUID(e Employee) string { return e.UID }
+
+func (f *FederatedService) GetEmployeeByUID(uid string) *Employee {
+	return firstOf(f, func(m ServiceInterface) *Employee { return m.GetEmployeeByUID(uid) })
+}
+
+func (f *FederatedService) GetEmployeeBySlackID(slackID string) *Employee {
+	return firstOf(f, func(m ServiceInterface) *Employee { return m.GetEmployeeBySlackID(slackID) })
+}
+
+func (f *FederatedService) GetEmployeeByGitHubID(githubID string) *Employee {
+	return firstOf(f, func(m ServiceInterface) *Employee { return m.GetEmployeeByGitHubID(githubID) })
+}
+
+func (f *FederatedService) GetEmployeeByEmail(email string) *Employee {
+	return firstOf(f, func(m ServiceInterface) *Employee { return m.GetEmployeeByEmail(email) })
+}
+
+// GetManagerForEmployee asks the first member that has the employee, then
+// looks the manager up across all members, since a manager can belong to
+// another producer.
+func (f *FederatedService) GetManagerForEmployee(uid string) *Employee {
+	emp := f.GetEmployeeByUID(uid)
+	if emp == nil || emp.ManagerUID == "" {
+		return nil
+	}
+	return f.GetEmployeeByUID(emp.ManagerUID)
+}
+
+func (f *FederatedService) GetTeamByName(teamName string) *Team {
+	return firstOf(f, func(m ServiceInterface) *Team { return m.GetTeamByName(teamName) })
+}
+
+func (f *FederatedService) GetTeamsBySlackChannel(channel string) []Team {
+	return unionOf(f, func(m ServiceInterface) []Team { return m.GetTeamsBySlackChannel(channel) },
+		func(t Team) string { return t.Name })
+}
+
+func (f *FederatedService) GetOrgByName(orgName string) *Org {
+	return firstOf(f, func(m ServiceInterface) *Org { return m.GetOrgByName(orgName) })
+}
+
+func (f *FederatedService) GetPillarByName(pillarName string) *Pillar {
+	return firstOf(f, func(m ServiceInterface) *Pillar { return m.GetPillarByName(pillarName) })
+}
+
+func (f *FederatedService) GetTeamGroupByName(teamGroupName string) *TeamGroup {
+	return firstOf(f, func(m ServiceInterface) *TeamGroup { return m.GetTeamGroupByName(teamGroupName) })
+}
+
+func (f *FederatedService) GetUserMemberships(uid string) []MembershipInfo {
+	return unionOf(f, func(m ServiceInterface) []MembershipInfo { return m.GetUserMemberships(uid) },
+		func(mi MembershipInfo) MembershipInfo { return mi })
+}
+
+func (f *FederatedService) GetUserTeams(uid string) []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetUserTeams(uid) })
+}
+
+func (f *FederatedService) GetTeamsForUID(uid string) []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetTeamsForUID(uid) })
+}
+
+func (f *FederatedService) GetTeamsForSlackID(slackID string) []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetTeamsForSlackID(slackID) })
+}
+
+func (f *FederatedService) GetTeamMembers(teamName string) []Employee {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.GetTeamMembers(teamName)
+	}
+	return []Employee{}
+}
+
+func (f *FederatedService) GetOrgMembers(orgName string) []Employee {
+	if m := f.entityOwner(orgName, "org"); m != nil {
+		return m.GetOrgMembers(orgName)
+	}
+	return []Employee{}
+}
+
+func (f *FederatedService) IsEmployeeInTeam(uid string, teamName string) bool {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.IsEmployeeInTeam(uid, teamName)
+	}
+	return false
+}
+
+func (f *FederatedService) IsSlackUserInTeam(slackID string, teamName string) bool {
+	if emp := f.GetEmployeeBySlackID(slackID); emp != nil {
+		return f.IsEmployeeInTeam(emp.UID, teamName)
+	}
+	return false
+}
+
+func (f *FederatedService) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.AreEmployeesInTeam(uids, teamName)
+	}
+	out := make(map[string]bool, len(uids))
+	for _, uid := range uids {
+		out[uid] = false
+	}
+	return out
+}
+
+func (f *FederatedService) IsEmployeeInAnyTeam(uid string, teams []string) bool {
+	return slices.ContainsFunc(teams, func(team string) bool { return f.IsEmployeeInTeam(uid, team) })
+}
+
+func (f *FederatedService) IsEmployeeInOrg(uid string, orgName string) bool {
+	if m := f.entityOwner(orgName, "org"); m != nil {
+		return m.IsEmployeeInOrg(uid, orgName)
+	}
+	return false
+}
+
+func (f *FederatedService) IsSlackUserInOrg(slackID string, orgName string) bool {
+	if emp := f.GetEmployeeBySlackID(slackID); emp != nil {
+		return f.IsEmployeeInOrg(emp.UID, orgName)
+	}
+	return false
+}
+
+func (f *FederatedService) GetUserOrganizations(slackUserID string) []OrgInfo {
+	return unionOf(f, func(m ServiceInterface) []OrgInfo { return m.GetUserOrganizations(slackUserID) },
+		func(o OrgInfo) OrgInfo { return o })
+}
+
+func (f *FederatedService) GetTeamEscalation(teamName string) []EscalationContactInfo {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.GetTeamEscalation(teamName)
+	}
+	return []EscalationContactInfo{}
+}
+
+// GetVersion describes the federated view: the counts are of the distinct
+// orgs and employees across members, LoadTime is the oldest member's, and
+// ConfigMaps merge as lookups do. Checksum is the hex SHA-256 of the
+// members' checksums in order, or empty if any member has none.
+func (f *FederatedService) GetVersion() DataVersion {
+	if len(f.members) == 0 {
+		return DataVersion{}
+	}
+	v := DataVersion{
+		OrgCount:      len(f.GetAllOrgNames()),
+		EmployeeCount: len(f.GetAllEmployeeUIDs()),
+	}
+	h := sha256.New()
+	for i, m := range f.members {
+		mv := m.GetVersion()
+		if i == 0 || mv.LoadTime.Before(v.LoadTime) {
+			v.LoadTime = mv.LoadTime
+		}
+		for name, version := range mv.ConfigMaps {
+			if _, ok := v.ConfigMaps[name]; !ok {
+				if v.ConfigMaps == nil {
+					v.ConfigMaps = make(map[string]string)
+				}
+				v.ConfigMaps[name] = version
+			}
+		}
+		if mv.Checksum == "" {
+			h = nil
+		} else if h != nil {
+			h.Write([]byte(mv.Checksum + "\n"))
+		}
+	}
+	if h != nil {
+		v.Checksum = hex.EncodeToString(h.Sum(nil))
+	}
+	return v
+}
+
+// GetDataAge returns the age of the stalest member's data.
+func (f *FederatedService) GetDataAge() time.Duration {
+	var age time.Duration
+	for _, m := range f.members {
+		age = max(age, m.GetDataAge())
+	}
+	return age
+}
+
+// IsDataStale reports whether any member's data is stale.
+func (f *FederatedService) IsDataStale(maxAge time.Duration) bool {
+	return slices.ContainsFunc(f.members, func(m ServiceInterface) bool { return m.IsDataStale(maxAge) })
+}
+
+func (f *FederatedService) LoadFromDataSource(context.Context, DataSource) error {
+	return ErrFederatedLoad
+}
+
+func (f *FederatedService) StartDataSourceWatcher(context.Context, DataSource) error {
+	return ErrFederatedLoad
+}
+
+func (f *FederatedService) StopWatcher() {}
+
+func (f *FederatedService) GetAllEmployeeUIDs() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetAllEmployeeUIDs() })
+}
+
+func (f *FederatedService) GetAllEmployees() []Employee {
+	return unionOf(f, func(m ServiceInterface) []Employee { return m.GetAllEmployees() }, employeeUID)
+}
+
+func (f *FederatedService) GetAllTeamNames() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetAllTeamNames() })
+}
+
+func (f *FederatedService) GetAllTeams() []Team {
+	return unionOf(f, func(m ServiceInterface) []Team { return m.GetAllTeams() }, func(t Team) string { return t.Name })
+}
+
+func (f *FederatedService) GetAllOrgNames() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetAllOrgNames() })
+}
+
+func (f *FederatedService) GetAllOrgs() []Org {
+	return unionOf(f, func(m ServiceInterface) []Org { return m.GetAllOrgs() }, func(o Org) string { return o.Name })
+}
+
+func (f *FederatedService) GetAllPillarNames() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetAllPillarNames() })
+}
+
+func (f *FederatedService) GetAllPillars() []Pillar {
+	return unionOf(f, func(m ServiceInterface) []Pillar { return m.GetAllPillars() }, func(p Pillar) string { return p.Name })
+}
+
+func (f *FederatedService) GetAllTeamGroupNames() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetAllTeamGroupNames() })
+}
+
+func (f *FederatedService) GetAllTeamGroups() []TeamGroup {
+	return unionOf(f, func(m ServiceInterface) []TeamGroup { return m.GetAllTeamGroups() },
+		func(tg TeamGroup) string { return tg.Name })
+}
+
+// GetHierarchyPath returns the path of the first member that has the
+// entity. A path does not continue into another member's hierarchy.
+func (f *FederatedService) GetHierarchyPath(entityName string, entityType string) []HierarchyPathEntry {
+	return firstNonEmpty(f, func(m ServiceInterface) []HierarchyPathEntry { return m.GetHierarchyPath(entityName, entityType) })
+}
+
+func (f *FederatedService) GetDescendantsTree(entityName string) *HierarchyNode {
+	if m := f.entityOwner(entityName, ""); m != nil {
+		return m.GetDescendantsTree(entityName)
+	}
+	return nil
+}
+
+func (f *FederatedService) FindPath(from, to GraphNode) []GraphEdge {
+	for _, m := range f.members {
+		if path := m.FindPath(from, to); path != nil {
+			return path
+		}
+	}
+	return nil
+}
+
+func (f *FederatedService) Neighborhood(node GraphNode, hops int) []GraphEdge {
+	var m ServiceInterface
+	if node.Type == EntityEmployee {
+		m = f.owner(func(m ServiceInterface) bool { return m.GetEmployeeByUID(node.Name) != nil })
+	} else {
+		m = f.entityOwner(node.Name, string(node.Type))
+	}
+	if m == nil {
+		return nil
+	}
+	return m.Neighborhood(node, hops)
+}
+
+func (f *FederatedService) Search(query string, opts ...SearchOption) []SearchResult {
+	q := NewSearchOptions(opts...)
+	all := append(slices.Clone(opts), SearchLimit(0))
+	var results []SearchResult
+	seen := make(map[GraphNode]bool)
+	for _, m := range f.members {
+		for _, r := range m.Search(query, all...) {
+			if key := (GraphNode{Type: r.Type, Name: r.Name}); !seen[key] {
+				seen[key] = true
+				results = append(results, r)
+			}
+		}
+	}
+	slices.SortFunc(results, compareSearchResults)
+	return q.limited(results)
+}
+
+func (f *FederatedService) GetComponentByName(name string) *Component {
+	return firstOf(f, func(m ServiceInterface) *Component { return m.GetComponentByName(name) })
+}
+
+func (f *FederatedService) GetAllComponents() []Component {
+	return unionOf(f, func(m ServiceInterface) []Component { return m.GetAllComponents() },
+		func(c Component) string { return c.Name })
+}
+
+func (f *FederatedService) GetAllComponentNames() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetAllComponentNames() })
+}
+
+func (f *FederatedService) GetTeamsForComponent(componentName string) []ComponentOwnerInfo {
+	m := f.owner(func(m ServiceInterface) bool { return m.GetComponentByName(componentName) != nil })
+	if m == nil {
+		return []ComponentOwnerInfo{}
+	}
+	return m.GetTeamsForComponent(componentName)
+}
+
+func (f *FederatedService) GetComponentsForTeam(teamName string) []ComponentOwnership {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.GetComponentsForTeam(teamName)
+	}
+	return []ComponentOwnership{}
+}
+
+func (f *FederatedService) GetJiraProjects() []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetJiraProjects() })
+}
+
+func (f *FederatedService) GetJiraComponents(project string) []string {
+	return unionStrings(f, func(m ServiceInterface) []string { return m.GetJiraComponents(project) })
+}
+
+func (f *FederatedService) GetTeamsByJiraProject(project string) []JiraOwnerInfo {
+	return unionOf(f, func(m ServiceInterface) []JiraOwnerInfo { return m.GetTeamsByJiraProject(project) },
+		func(o JiraOwnerInfo) JiraOwnerInfo { return o })
+}
+
+func (f *FederatedService) GetTeamsByJiraComponent(project, component string) []JiraOwnerInfo {
+	return unionOf(f, func(m ServiceInterface) []JiraOwnerInfo { return m.GetTeamsByJiraComponent(project, component) },
+		func(o JiraOwnerInfo) JiraOwnerInfo { return o })
+}
+
+func (f *FederatedService) GetJiraOwnershipForTeam(teamName string) []JiraOwnership {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.GetJiraOwnershipForTeam(teamName)
+	}
+	return []JiraOwnership{}
+}
+
+func (f *FederatedService) GetContextForTeam(teamName string) []ContextItemInfo {
+	if m := f.teamOwner(teamName); m != nil {
+		return m.GetContextForTeam(teamName)
+	}
+	return []ContextItemInfo{}
+}
+
+func (f *FederatedService) GetContextForEntity(entityName string, entityType string) []ContextItemInfo {
+	if m := f.entityOwner(entityName, entityType); m != nil {
+		return m.GetContextForEntity(entityName, entityType)
+	}
+	return []ContextItemInfo{}
+}
+
+func (f *FederatedService) GetContextByType(entityName string, contextType string, entityType string) []ContextItemInfo {
+	if m := f.entityOwner(entityName, entityType); m != nil {
+		return m.GetContextByType(entityName, contextType, entityType)
+	}
+	return []ContextItemInfo{}
+}
+
+func (f *FederatedService) GetAllContextTypesForEntity(entityName string, entityType string) []string {
+	if m := f.entityOwner(entityName, entityType); m != nil {
+		return m.GetAllContextTypesForEntity(entityName, entityType)
+	}
+	return []string{}
+}
+
+// GetContextTypeDescriptions merges the members' descriptions, the first
+// member describing a type winning.
+func (f *FederatedService) GetContextTypeDescriptions() map[string]string {
+	out := make(map[string]string)
+	for _, m := range f.members {
+		for k, v := range m.GetContextTypeDescriptions() {
+			if _, ok := out[k]; !ok {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
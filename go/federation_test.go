@@ -0,0 +1,168 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func loadServiceFromData(t *testing.T, data *Data) *Service {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(string(raw))); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+	return service
+}
+
+func TestFederatedService(t *testing.T) {
+	primary := loadServiceFromData(t, CreateTestData())
+
+	// A lower-precedence producer with its own view of testuser1 and
+	// test-squad.
+	shadowData := CreateTestData()
+	shadowData.Lookups.Employees["testuser1"] = Employee{UID: "testuser1", FullName: "Shadow User"}
+	squad := shadowData.Lookups.Teams["test-squad"]
+	squad.Description = "shadow squad"
+	squad.Group.ResolvedPeopleUIDList = []string{"testuser1"}
+	shadowData.Lookups.Teams["test-squad"] = squad
+	shadowData.Lookups.Teams["shadow-team"] = Team{Name: "shadow-team", Type: "team"}
+	// The shadow member also disagrees about testuser2's memberships.
+	shadowData.Indexes.Membership.MembershipIndex["testuser2"] = []MembershipInfo{
+		{Name: "test-team", Type: "team"}, {Name: "test-division", Type: "org"}}
+	shadowData.Lookups.Employees["bwilson"] = Employee{UID: "bwilson", SlackUID: "U98765432"}
+	shadowData.Indexes.SlackIDMappings.SlackUIDToUID["U98765432"] = "bwilson"
+	shadowData.Indexes.Membership.MembershipIndex["bwilson"] = []MembershipInfo{{Name: "test-squad", Type: "team"}}
+	shadow := loadServiceFromData(t, shadowData)
+
+	fed := NewFederatedService(primary, setupTestService(t), shadow)
+
+	if emp := fed.GetEmployeeByUID("testuser1"); emp == nil || emp.FullName != "Test User One" {
+		t.Errorf("GetEmployeeByUID(testuser1) = %v, want the primary's employee", emp)
+	}
+	if emp := fed.GetEmployeeByUID("jsmith"); emp == nil || emp.FullName != "John Smith" {
+		t.Errorf("GetEmployeeByUID(jsmith) = %v, want the second member's employee", emp)
+	}
+	if mgr := fed.GetManagerForEmployee("jsmith"); mgr == nil || mgr.UID != "adoe" {
+		t.Errorf("GetManagerForEmployee(jsmith) = %v, want adoe", mgr)
+	}
+	if team := fed.GetTeamByName("test-squad"); team == nil || team.Description == "shadow squad" {
+		t.Errorf("GetTeamByName(test-squad) = %v, want the primary's team", team)
+	}
+
+	uids := fed.GetAllEmployeeUIDs()
+	slices.Sort(uids)
+	if want := []string{"adoe", "bwilson", "jsmith", "testuser1", "testuser2"}; !slices.Equal(uids, want) {
+		t.Errorf("GetAllEmployeeUIDs() = %v, want %v", uids, want)
+	}
+	teams := fed.GetAllTeams()
+	names := make([]string, len(teams))
+	for i, team := range teams {
+		names[i] = team.Name
+		if team.Name == "test-squad" && team.Description == "shadow squad" {
+			t.Error("GetAllTeams() lists test-squad as the shadow member does")
+		}
+	}
+	slices.Sort(names)
+	if want := []string{"platform-team", "shadow-team", "test-squad", "test-team"}; !slices.Equal(names, want) {
+		t.Errorf("GetAllTeams() names = %v, want %v", names, want)
+	}
+
+	// Team queries are answered by the member owning the team, without
+	// mixing in other members' members.
+	if members := fed.GetTeamMembers("test-squad"); len(members) != 2 {
+		t.Errorf("GetTeamMembers(test-squad) = %v, want the primary's 2 members", members)
+	}
+	if members := fed.GetTeamMembers("test-team"); len(members) != 2 {
+		t.Errorf("GetTeamMembers(test-team) = %v, want 2 members", members)
+	}
+	if members := fed.GetTeamMembers("nonexistent"); members == nil || len(members) != 0 {
+		t.Errorf("GetTeamMembers(nonexistent) = %v, want an empty list", members)
+	}
+	if path := fed.GetHierarchyPath("platform-team", "team"); len(path) == 0 || path[len(path)-1].Name != "test-org" {
+		t.Errorf("GetHierarchyPath(platform-team) = %v, want a path to test-org", path)
+	}
+
+	// Membership checks are answered by the member owning the team or org,
+	// as GetTeamMembers is, whatever lower-precedence members say.
+	membership := []struct {
+		name  string
+		check func() bool
+		want  bool
+	}{
+		{"bwilson in platform-team", func() bool { return fed.IsEmployeeInTeam("bwilson", "platform-team") }, true},
+		{"testuser2 in test-division", func() bool { return fed.IsEmployeeInOrg("testuser2", "test-division") }, true},
+		{"bwilson in test-squad per the shadow", func() bool { return fed.IsEmployeeInTeam("bwilson", "test-squad") }, false},
+		{"testuser2 in test-team per the shadow", func() bool { return fed.IsEmployeeInTeam("testuser2", "test-team") }, false},
+		{"bwilson by Slack ID in test-squad", func() bool { return fed.IsSlackUserInTeam("U98765432", "test-squad") }, false},
+		{"testuser1 by Slack ID in test-squad", func() bool { return fed.IsSlackUserInTeam("U111111", "test-squad") }, true},
+		{"testuser1 by Slack ID in test-division", func() bool { return fed.IsSlackUserInOrg("U111111", "test-division") }, true},
+		{"unknown Slack ID", func() bool { return fed.IsSlackUserInTeam("UNKNOWN", "test-squad") }, false},
+		{"unknown team", func() bool { return fed.IsEmployeeInTeam("testuser1", "nonexistent") }, false},
+		{"bwilson in any team, asking each owner", func() bool {
+			return fed.IsEmployeeInAnyTeam("bwilson", []string{"test-squad", "platform-team"})
+		}, true},
+		{"bwilson in any team per the shadow", func() bool { return fed.IsEmployeeInAnyTeam("bwilson", []string{"test-squad"}) }, false},
+	}
+	for _, tt := range membership {
+		if got := tt.check(); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	want := map[string]bool{"testuser1": true, "bwilson": false}
+	if got := fed.AreEmployeesInTeam([]string{"testuser1", "bwilson"}, "test-squad"); !maps.Equal(got, want) {
+		t.Errorf("AreEmployeesInTeam(test-squad) = %v, want %v", got, want)
+	}
+
+	// Graph queries stay within the member that has the nodes.
+	if path := fed.FindPath(employeeNode("bwilson"), teamNode("platform-team")); len(path) != 1 {
+		t.Errorf("FindPath(bwilson, platform-team) = %v, want the second member's membership", path)
+	}
+	if path := fed.FindPath(employeeNode("testuser1"), teamNode("platform-team")); path != nil {
+		t.Errorf("FindPath across members = %v, want nil", path)
+	}
+	if edges := fed.Neighborhood(teamNode("shadow-team"), 1); edges == nil || len(edges) != 0 {
+		t.Errorf("Neighborhood(shadow-team) = %v, want the shadow's empty neighborhood", edges)
+	}
+	if edges := fed.Neighborhood(employeeNode("nobody"), 1); edges != nil {
+		t.Errorf("Neighborhood(nobody) = %v, want nil", edges)
+	}
+	if got, want := resultNames(fed.Search("plat")), []string{"org:platform-org", "team:platform-team"}; !slices.Equal(got, want) {
+		t.Errorf("Search(plat) = %v, want %v", got, want)
+	}
+	squads := 0
+	for _, r := range fed.Search("squad", SearchLimit(0)) {
+		if r.Name == "test-squad" {
+			squads++
+		}
+	}
+	if squads != 1 {
+		t.Errorf("Search(squad) lists test-squad %d times, want once", squads)
+	}
+	if teams := fed.GetTeamsForUID("testuser1"); !slices.Equal(teams, []string{"test-squad"}) {
+		t.Errorf("GetTeamsForUID(testuser1) = %v, want test-squad once", teams)
+	}
+
+	if err := fed.LoadFromDataSource(context.Background(), NewFakeDataSource("")); !errors.Is(err, ErrFederatedLoad) {
+		t.Errorf("LoadFromDataSource: err = %v, want ErrFederatedLoad", err)
+	}
+	version := fed.GetVersion()
+	if version.EmployeeCount != 5 || version.OrgCount != len(fed.GetAllOrgNames()) || version.Checksum == "" || version.Checksum == primary.GetVersion().Checksum {
+		t.Errorf("GetVersion() = %+v, want the counts and a checksum of the federated view", version)
+	}
+	for _, m := range fed.Members() {
+		if m.GetVersion().LoadTime.Before(version.LoadTime) {
+			t.Errorf("GetVersion().LoadTime = %v, want the oldest member's", version.LoadTime)
+		}
+	}
+	if got := fed.Members(); len(got) != 3 || got[0] != primary {
+		t.Errorf("Members() = %v", got)
+	}
+}
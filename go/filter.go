@@ -0,0 +1,132 @@
+package orgdatacore
+
+import (
+	"slices"
+	"strings"
+)
+
+// EnumerationOption narrows what an enumeration method such as AllTeamNames
+// yields. Options combine: an entity must pass all of them.
+//
+//	for name := range service.AllTeamNames(orgdatacore.WithinOrg("platform-org"), orgdatacore.OfType("team")) {
+//		...
+//	}
+//
+// Filtering happens while the enumeration copies the data, so entities that
+// do not pass are never copied.
+type EnumerationOption func(*enumeration)
+
+type enumeration struct {
+	within []ParentInfo
+	types  []string
+}
+
+// WithinOrg keeps entities that have the organization among their ancestors,
+// and employees who are members of it or of an entity under it.
+func WithinOrg(name string) EnumerationOption {
+	return within(name, "org")
+}
+
+// WithinPillar keeps entities that have the pillar among their ancestors, and
+// employees who are members of it or of an entity under it.
+func WithinPillar(name string) EnumerationOption {
+	return within(name, "pillar")
+}
+
+// WithinTeamGroup keeps entities that have the team group among their
+// ancestors, and employees who are members of it or of an entity under it.
+func WithinTeamGroup(name string) EnumerationOption {
+	return within(name, "team_group")
+}
+
+func within(name, kind string) EnumerationOption {
+	return func(e *enumeration) {
+		e.within = append(e.within, ParentInfo{Name: name, Type: kind})
+	}
+}
+
+// OfType keeps entities whose type field is one of types. It has no effect
+// on employee enumerations.
+func OfType(types ...string) EnumerationOption {
+	return func(e *enumeration) {
+		e.types = append(e.types, types...)
+	}
+}
+
+// newEnumeration applies opts, resolving the names of Within options the way
+// lookups by name are resolved. It returns nil, which passes everything, when
+// there are no options. Must be called with s.mu held.
+func (s *Service) newEnumeration(opts []EnumerationOption) *enumeration {
+	if len(opts) == 0 {
+		return nil
+	}
+	e := &enumeration{}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if s.data == nil {
+		return e
+	}
+	for i, a := range e.within {
+		switch a.Type {
+		case "org":
+			e.within[i].Name = resolveKey(s, s.data.Lookups.Orgs, "org", a.Name)
+		case "pillar":
+			e.within[i].Name = resolveKey(s, s.data.Lookups.Pillars, "pillar", a.Name)
+		case "team_group":
+			e.within[i].Name = resolveKey(s, s.data.Lookups.TeamGroups, "team_group", a.Name)
+		}
+	}
+	return e
+}
+
+// matchEntity reports whether the entity passes e. Must be called with s.mu
+// held.
+func (s *Service) matchEntity(e *enumeration, name, entityType, typeField string) bool {
+	if e == nil {
+		return true
+	}
+	if len(e.types) > 0 && !slices.Contains(e.types, typeField) {
+		return false
+	}
+	for _, ancestor := range e.within {
+		if !s.hasAncestor(name, entityType, ancestor) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchEmployee reports whether the employee passes e. Must be called with
+// s.mu held.
+func (s *Service) matchEmployee(e *enumeration, uid string) bool {
+	if e == nil || len(e.within) == 0 {
+		return true
+	}
+	memberships := s.data.Indexes.Membership.MembershipIndex[uid]
+	for _, ancestor := range e.within {
+		if !slices.ContainsFunc(memberships, func(m MembershipInfo) bool {
+			return (m.Name == ancestor.Name && strings.EqualFold(m.Type, ancestor.Type)) ||
+				s.hasAncestor(m.Name, m.Type, ancestor)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAncestor reports whether ancestor appears among the ancestors of the
+// entity. Like hasOrgAncestor, the walk is bounded by the number of entities
+// so it terminates on cyclic data. Must be called with s.mu held.
+func (s *Service) hasAncestor(name, entityType string, ancestor ParentInfo) bool {
+	lookups := &s.data.Lookups
+	limit := len(lookups.Teams) + len(lookups.Orgs) + len(lookups.Pillars) + len(lookups.TeamGroups)
+	parent := s.getEntityParent(name, entityType)
+	for i := 0; parent != nil && i < limit; i++ {
+		if parent.Name == ancestor.Name && strings.EqualFold(parent.Type, ancestor.Type) {
+			return true
+		}
+		parent = s.getEntityParent(parent.Name, parent.Type)
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package orgdatacore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Name parts for fake employees. Enough combinations that fake names in a
+// fixture rarely repeat, while still reading like names.
+var (
+	fakeFirstNames = []string{
+		"Alex", "Blake", "Casey", "Dana", "Eli", "Frankie", "Gale", "Harper",
+		"Indy", "Jordan", "Kai", "Logan", "Morgan", "Noel", "Oakley", "Parker",
+		"Quinn", "Riley", "Sage", "Taylor", "Uma", "Val", "Wren", "Yael",
+		"Avery", "Bailey", "Cameron", "Drew", "Emery", "Finley", "Hayden", "Jamie",
+	}
+	fakeLastNames = []string{
+		"Abbott", "Barnes", "Carver", "Dalton", "Ellison", "Foster", "Garner", "Hale",
+		"Ingram", "Jensen", "Keller", "Lowry", "Mercer", "Nolan", "Ortega", "Porter",
+		"Quincy", "Ramsey", "Sutton", "Thorne", "Underwood", "Vance", "Whitaker", "Yates",
+		"Archer", "Brooks", "Collins", "Dixon", "Everett", "Fletcher", "Griffin", "Hayes",
+	}
+)
+
+// AnonymizeFixture rewrites data in place into a PII-free fixture that keeps
+// the shape of the original: every UID, name, email, Slack ID, GitHub ID and
+// cost center is replaced by a realistic fake, and every reference to them
+// (managers, member and role lists, the membership, Slack and GitHub indexes)
+// is rewritten to match, so lookups and memberships behave as before. Team,
+// org and other entity names are kept.
+//
+// Fakes are derived from seed with HMAC-SHA256, so the same dump and seed
+// always give the same fixture and regenerated fixtures diff cleanly. Keep the
+// seed private: anyone holding it and a list of real UIDs can recompute the
+// mapping. Unlike AnonymizingDataSource, which hides identities from a running
+// service, this produces data meant to be written out and committed.
+func AnonymizeFixture(data *Data, seed string) {
+	f := fixtureFaker{key: []byte(seed)}
+
+	// Assign fakes in UID order, so collisions resolve the same way on
+	// every run.
+	uids := make([]string, 0, len(data.Lookups.Employees))
+	for uid := range data.Lookups.Employees {
+		uids = append(uids, uid)
+	}
+	slices.Sort(uids)
+
+	uidMap := make(map[string]string, len(uids))
+	used := make(map[string]bool, len(uids))
+	names := make(map[string]string, len(uids))
+	for _, uid := range uids {
+		first := fakeFirstNames[f.pick("first", uid, len(fakeFirstNames))]
+		last := fakeLastNames[f.pick("last", uid, len(fakeLastNames))]
+		base := strings.ToLower(first[:1] + last)
+		fake := base
+		for n := 2; used[fake]; n++ {
+			fake = fmt.Sprintf("%s%d", base, n)
+		}
+		used[fake] = true
+		uidMap[uid] = fake
+		names[uid] = first + " " + last
+	}
+	mapUID := func(uid string) string {
+		if fake, ok := uidMap[uid]; ok {
+			return fake
+		}
+		// A reference to someone who is not an employee still must not
+		// leak, so it gets a fake too, keyed by the same HMAC.
+		return "unknown-" + f.code("uid", uid, 8)
+	}
+
+	costCenters := make(map[int]int)
+	usedCostCenters := make(map[int]bool)
+	for _, uid := range uids {
+		cc := data.Lookups.Employees[uid].CostCenter
+		if cc == 0 {
+			continue
+		}
+		if _, ok := costCenters[cc]; ok {
+			continue
+		}
+		fake := 10000 + f.pick("cost_center", fmt.Sprint(cc), 90000)
+		for usedCostCenters[fake] {
+			fake = 10000 + (fake-10000+1)%90000
+		}
+		usedCostCenters[fake] = true
+		costCenters[cc] = fake
+	}
+
+	slackIDs := make(map[string]string)
+	mapSlackID := func(id string) string {
+		if id == "" {
+			return ""
+		}
+		if fake, ok := slackIDs[id]; ok {
+			return fake
+		}
+		fake := "U" + strings.ToUpper(f.code("slack", id, 10))
+		slackIDs[id] = fake
+		return fake
+	}
+	githubIDs := make(map[string]string)
+	mapGitHubID := func(id string) string {
+		if id == "" {
+			return ""
+		}
+		if fake, ok := githubIDs[id]; ok {
+			return fake
+		}
+		fake := "gh-" + f.code("github", id, 8)
+		githubIDs[id] = fake
+		return fake
+	}
+
+	employees := make(map[string]Employee, len(uids))
+	for _, uid := range uids {
+		emp := data.Lookups.Employees[uid]
+		emp.UID = uidMap[uid]
+		emp.FullName = names[uid]
+		emp.Email = emp.UID + "@example.com"
+		emp.SlackUID = mapSlackID(emp.SlackUID)
+		emp.GitHubID = mapGitHubID(emp.GitHubID)
+		emp.CostCenter = costCenters[emp.CostCenter]
+		if emp.ManagerUID != "" {
+			emp.ManagerUID = mapUID(emp.ManagerUID)
+		}
+		employees[emp.UID] = emp
+	}
+	data.Lookups.Employees = employees
+
+	if old := data.Indexes.Membership.MembershipIndex; old != nil {
+		index := make(map[string][]MembershipInfo, len(old))
+		for uid, memberships := range old {
+			index[mapUID(uid)] = memberships
+		}
+		data.Indexes.Membership.MembershipIndex = index
+	}
+	if old := data.Indexes.SlackIDMappings.SlackUIDToUID; old != nil {
+		index := make(map[string]string, len(old))
+		for id, uid := range old {
+			index[mapSlackID(id)] = mapUID(uid)
+		}
+		data.Indexes.SlackIDMappings.SlackUIDToUID = index
+	}
+	if old := data.Indexes.GitHubIDMappings.GitHubIDToUID; old != nil {
+		index := make(map[string]string, len(old))
+		for id, uid := range old {
+			index[mapGitHubID(id)] = mapUID(uid)
+		}
+		data.Indexes.GitHubIDMappings.GitHubIDToUID = index
+	}
+
+	remap := func(g *Group) {
+		people := make([]string, len(g.ResolvedPeopleUIDList))
+		for i, uid := range g.ResolvedPeopleUIDList {
+			people[i] = mapUID(uid)
+		}
+		g.ResolvedPeopleUIDList = people
+		roles := slices.Clone(g.Roles)
+		for i := range roles {
+			people := make([]string, len(roles[i].People))
+			for j, uid := range roles[i].People {
+				people[j] = mapUID(uid)
+			}
+			roles[i].People = people
+		}
+		g.Roles = roles
+	}
+	for name, team := range data.Lookups.Teams {
+		remap(&team.Group)
+		data.Lookups.Teams[name] = team
+	}
+	for name, org := range data.Lookups.Orgs {
+		remap(&org.Group)
+		data.Lookups.Orgs[name] = org
+	}
+	for name, pillar := range data.Lookups.Pillars {
+		remap(&pillar.Group)
+		data.Lookups.Pillars[name] = pillar
+	}
+	for name, tg := range data.Lookups.TeamGroups {
+		remap(&tg.Group)
+		data.Lookups.TeamGroups[name] = tg
+	}
+}
+
+// fixtureFaker derives fakes from a keyed hash of the real value.
+type fixtureFaker struct {
+	key []byte
+}
+
+func (f fixtureFaker) sum(kind, value string) []byte {
+	mac := hmac.New(sha256.New, f.key)
+	mac.Write([]byte(kind))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
+
+// pick returns an index below n for value.
+func (f fixtureFaker) pick(kind, value string, n int) int {
+	return int(binary.BigEndian.Uint64(f.sum(kind, value)) % uint64(n))
+}
+
+// code returns n lowercase letters and digits for value.
+func (f fixtureFaker) code(kind, value string, n int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	sum := f.sum(kind, value)
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = alphabet[int(sum[i])%len(alphabet)]
+	}
+	return string(out)
+}
@@ -0,0 +1,126 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func loadTestData(t *testing.T) *Data {
+	t.Helper()
+	raw, err := json.Marshal(setupTestService(t).data)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var data Data
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return &data
+}
+
+func TestAnonymizeFixture(t *testing.T) {
+	data := loadTestData(t)
+	AnonymizeFixture(data, "test-seed")
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, pii := range []string{"jsmith", "John Smith", "adoe@example.com", "U12345678", "jsmith-dev", "alice-codes", "bobw"} {
+		if bytes.Contains(raw, []byte(pii)) {
+			t.Errorf("fixture still contains %q", pii)
+		}
+	}
+	if issues := ValidateData(data).Issues; len(issues) != 0 {
+		t.Errorf("fixture has validation issues: %+v", issues)
+	}
+
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "fixture", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	members := service.GetTeamMembers("test-team")
+	if len(members) != 2 {
+		t.Fatalf("test-team has %d members, want 2", len(members))
+	}
+	for _, emp := range members {
+		if !slices.Equal(service.GetTeamsForUID(emp.UID), []string{"test-team"}) {
+			t.Errorf("GetTeamsForUID(%s) = %v", emp.UID, service.GetTeamsForUID(emp.UID))
+		}
+		if got := service.GetEmployeeBySlackID(emp.SlackUID); got == nil || got.UID != emp.UID {
+			t.Errorf("GetEmployeeBySlackID(%s) = %+v, want %s", emp.SlackUID, got, emp.UID)
+		}
+		if got := service.GetEmployeeByGitHubID(emp.GitHubID); got == nil || got.UID != emp.UID {
+			t.Errorf("GetEmployeeByGitHubID(%s) = %+v, want %s", emp.GitHubID, got, emp.UID)
+		}
+		if emp.Email != emp.UID+"@example.com" || len(strings.Fields(emp.FullName)) != 2 {
+			t.Errorf("fake employee = %+v", emp)
+		}
+	}
+	// jsmith reports to adoe, who is the only one of the pair with no
+	// manager.
+	var report, manager Employee
+	for _, emp := range members {
+		if emp.ManagerUID != "" {
+			report = emp
+		} else {
+			manager = emp
+		}
+	}
+	if report.ManagerUID != manager.UID || manager.UID == "" {
+		t.Errorf("manager link lost: report %+v, manager %+v", report, manager)
+	}
+}
+
+func TestAnonymizeFixtureDeterministic(t *testing.T) {
+	encode := func(seed string) string {
+		data := loadTestData(t)
+		AnonymizeFixture(data, seed)
+		raw, err := json.Marshal(data)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return string(raw)
+	}
+
+	if encode("a") != encode("a") {
+		t.Error("the same seed gave different fixtures")
+	}
+	if encode("a") == encode("b") {
+		t.Error("different seeds gave the same fixture")
+	}
+}
+
+func TestAnonymizeFixtureCostCenters(t *testing.T) {
+	data := CreateTestData()
+	data.Lookups.Employees["testuser1"] = Employee{UID: "testuser1", CostCenter: 4242}
+	data.Lookups.Employees["testuser2"] = Employee{UID: "testuser2", CostCenter: 4242}
+	data.Lookups.Employees["testuser3"] = Employee{UID: "testuser3", CostCenter: 7777}
+	data.Lookups.Employees["testuser4"] = Employee{UID: "testuser4"}
+	AnonymizeFixture(data, "seed")
+
+	byCostCenter := make(map[int]int)
+	for _, emp := range data.Lookups.Employees {
+		if emp.CostCenter == 4242 || emp.CostCenter == 7777 {
+			t.Errorf("real cost center %d kept", emp.CostCenter)
+		}
+		byCostCenter[emp.CostCenter]++
+	}
+	// Employees sharing a cost center still share one, and those without
+	// still have none.
+	counts := []int{}
+	for _, n := range byCostCenter {
+		counts = append(counts, n)
+	}
+	slices.Sort(counts)
+	if !slices.Equal(counts, []int{1, 1, 2}) {
+		t.Errorf("cost center group sizes = %v, want [1 1 2]", counts)
+	}
+	if byCostCenter[0] != 1 {
+		t.Errorf("%d employees without a cost center, want 1", byCostCenter[0])
+	}
+}
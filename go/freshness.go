@@ -0,0 +1,72 @@
+package orgdatacore
+
+import (
+	"fmt"
+	"time"
+)
+
+// generatedAtLayouts are the formats accepted for the generated_at metadata.
+// Timestamps without a zone, as found in some dumps, are taken as UTC.
+var generatedAtLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// parseGeneratedAt parses the generated_at metadata of a data file.
+func parseGeneratedAt(s string) (time.Time, bool) {
+	for _, layout := range generatedAtLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CheckFreshness reports whether the loaded data is within the age set with
+// WithMaxDataAge. It returns ErrNoData if nothing is loaded, and an error
+// wrapping ErrStaleData if the data was generated longer ago than allowed.
+// When generated_at is missing or unparseable the load time is used instead.
+// It always returns nil for loaded data if no maximum age is configured.
+//
+// Queries keep answering from stale data; ServiceInterface methods have no
+// error return, so call CheckFreshness from readiness probes or before
+// decisions that must not act on an outdated org chart.
+func (s *Service) CheckFreshness() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return ErrNoData
+	}
+	return s.checkFreshness(s.data, s.version.LoadTime)
+}
+
+// IsDegraded reports whether the service has no data or its data is stale
+// according to WithMaxDataAge.
+func (s *Service) IsDegraded() bool {
+	return s.CheckFreshness() != nil
+}
+
+func (s *Service) checkFreshness(data *Data, loadTime time.Time) error {
+	if s.maxDataAge <= 0 {
+		return nil
+	}
+	generated, ok := parseGeneratedAt(data.Metadata.GeneratedAt)
+	if !ok {
+		generated = loadTime
+	}
+	if age := s.clock.Now().Sub(generated); age > s.maxDataAge {
+		return fmt.Errorf("%w: generated at %s, %s ago (max %s)",
+			ErrStaleData, generated.Format(time.RFC3339), age.Round(time.Second), s.maxDataAge)
+	}
+	return nil
+}
+
+// warnIfStale logs a warning when newly loaded data is already stale, which
+// usually means the upstream pipeline has stopped publishing.
+func (s *Service) warnIfStale(data *Data) {
+	if err := s.checkFreshness(data, s.clock.Now()); err != nil {
+		s.loggerFor(LogLoader).Warn("loaded data is stale", "data_version", data.Metadata.DataVersion, "error", err)
+		s.emit(Event{Type: EventDataStale, DataVersion: data.Metadata.DataVersion, Err: err})
+	}
+}
@@ -0,0 +1,71 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseGeneratedAt(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"2024-01-01T00:00:00Z", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2024-01-01T02:00:00+02:00", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"2024-01-15T12:00:00.000000", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseGeneratedAt(tt.in)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseGeneratedAt(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCheckFreshness(t *testing.T) {
+	load := func(t *testing.T, generatedAt string, opts ...ServiceOption) *Service {
+		t.Helper()
+		data := CreateTestData()
+		data.Metadata.GeneratedAt = generatedAt
+		raw, err := json.Marshal(data)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		service := NewService(opts...)
+		if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(string(raw))); err != nil {
+			t.Fatalf("LoadFromDataSource: %v", err)
+		}
+		return service
+	}
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name        string
+		service     func(t *testing.T) *Service
+		wantErr     error
+		wantDegrade bool
+	}{
+		{"no data", func(*testing.T) *Service { return NewService(WithMaxDataAge(time.Hour)) }, ErrNoData, true},
+		{"no max age", func(t *testing.T) *Service { return load(t, "2024-01-01T00:00:00Z") }, nil, false},
+		{"fresh", func(t *testing.T) *Service { return load(t, recent, WithMaxDataAge(2*time.Hour)) }, nil, false},
+		{"stale", func(t *testing.T) *Service { return load(t, recent, WithMaxDataAge(30*time.Minute)) }, ErrStaleData, true},
+		{"unparseable uses load time", func(t *testing.T) *Service { return load(t, "unknown", WithMaxDataAge(time.Hour)) }, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := tt.service(t)
+			if err := service.CheckFreshness(); !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Errorf("CheckFreshness() = %v, want %v", err, tt.wantErr)
+			}
+			if got := service.IsDegraded(); got != tt.wantDegrade {
+				t.Errorf("IsDegraded() = %v, want %v", got, tt.wantDegrade)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+// knownStrings returns every key and string value in the test data,
+// lowercased and trimmed, so fuzz inputs that may name real entities can be
+// told apart from inputs that must not be found.
+func knownStrings(tb testing.TB) map[string]bool {
+	tb.Helper()
+	raw, err := os.ReadFile(filepath.Join("..", "testdata", "test_org_data.json"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		tb.Fatal(err)
+	}
+	known := map[string]bool{}
+	var walk func(v any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			for k, child := range v {
+				known[strings.ToLower(strings.TrimSpace(k))] = true
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		case string:
+			known[strings.ToLower(strings.TrimSpace(v))] = true
+		}
+	}
+	walk(doc)
+	return known
+}
+
+// isEmptyResult reports whether v is what a query returns when nothing
+// matches: nil, false, or an empty string, slice or map.
+func isEmptyResult(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	default:
+		return false
+	}
+}
+
+// FuzzQueries feeds arbitrary strings to every ServiceInterface query
+// taking only string parameters. No query may panic, and queries whose
+// arguments name nothing in the data must return an empty result.
+func FuzzQueries(f *testing.F) {
+	seeds := [][3]string{
+		{"jsmith", "test-team", "team"},
+		{"", "", ""},
+		{"JSMITH", " test-team ", "TEAM"},
+		{"../../etc/passwd", "' OR 1=1 --", "%s%n%x"},
+		{"日本語", "🙂", "‮\u0000"},
+		{"\xff\xfe", "\t\n", "team_group"},
+		{strings.Repeat("a", 1<<12), strings.Repeat("🙂", 1<<10), "org"},
+	}
+	for _, s := range seeds {
+		f.Add(s[0], s[1], s[2])
+	}
+
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))); err != nil {
+		f.Fatalf("LoadFromDataSource: %v", err)
+	}
+	known := knownStrings(f)
+	serviceValue := reflect.ValueOf(service)
+	iface := reflect.TypeOf((*ServiceInterface)(nil)).Elem()
+
+	f.Fuzz(func(t *testing.T, a, b, c string) {
+		inputs := []string{a, b, c}
+		for i := 0; i < iface.NumMethod(); i++ {
+			method := iface.Method(i)
+			if method.Type.NumIn() == 0 || method.Type.NumIn() > len(inputs) {
+				continue
+			}
+			args := make([]reflect.Value, 0, method.Type.NumIn())
+			anyKnown := false
+			for j := 0; j < method.Type.NumIn(); j++ {
+				if method.Type.In(j).Kind() != reflect.String {
+					args = nil
+					break
+				}
+				args = append(args, reflect.ValueOf(inputs[j]).Convert(method.Type.In(j)))
+				anyKnown = anyKnown || known[strings.ToLower(strings.TrimSpace(inputs[j]))]
+			}
+			if args == nil {
+				continue
+			}
+
+			out := callWithoutPanic(t, serviceValue.MethodByName(method.Name), args, method.Name)
+			if !anyKnown && len(out) > 0 && !isEmptyResult(out[0]) {
+				t.Errorf("%s%q = %v, want an empty result for unknown inputs",
+					method.Name, inputs[:len(args)], out[0].Interface())
+			}
+		}
+	})
+}
+
+func callWithoutPanic(t *testing.T, fn reflect.Value, args []reflect.Value, name string) (out []reflect.Value) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("%s panicked: %v", name, fmt.Sprint(r))
+		}
+	}()
+	return fn.Call(args)
+}
@@ -20,6 +20,7 @@ type GCSDataSourceImpl struct {
 	lastModTime time.Time
 	interval    time.Duration
 	logger      *slog.Logger
+	clock       Clock
 }
 
 func NewGCSDataSourceWithSDK(ctx context.Context, bucket, objectPath string, opts ...GCSOption) (*GCSDataSourceImpl, error) {
@@ -41,6 +42,9 @@ func NewGCSDataSourceWithSDK(ctx context.Context, bucket, objectPath string, opt
 	if cfg.credentialsJSON != "" {
 		clientOpts = append(clientOpts, option.WithCredentialsJSON([]byte(cfg.credentialsJSON)))
 	}
+	if cfg.endpoint != "" {
+		clientOpts = append(clientOpts, option.WithEndpoint(cfg.endpoint))
+	}
 
 	client, err := storage.NewClient(ctx, clientOpts...)
 	if err != nil {
@@ -53,9 +57,20 @@ func NewGCSDataSourceWithSDK(ctx context.Context, bucket, objectPath string, opt
 		client:     client,
 		interval:   cfg.checkInterval,
 		logger:     cfg.logger,
+		clock:      cfg.clock,
 	}, nil
 }
 
+// MustNewGCSDataSourceWithSDK is like NewGCSDataSourceWithSDK but panics on
+// error, for CLIs and init-time wiring.
+func MustNewGCSDataSourceWithSDK(ctx context.Context, bucket, objectPath string, opts ...GCSOption) *GCSDataSourceImpl {
+	source, err := NewGCSDataSourceWithSDK(ctx, bucket, objectPath, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return source
+}
+
 func (g *GCSDataSourceImpl) Load(ctx context.Context) (io.ReadCloser, error) {
 	bucket := g.client.Bucket(g.bucket)
 	object := bucket.Object(g.objectPath)
@@ -75,16 +90,13 @@ func (g *GCSDataSourceImpl) Load(ctx context.Context) (io.ReadCloser, error) {
 }
 
 func (g *GCSDataSourceImpl) Watch(ctx context.Context, callback func() error) error {
-	ticker := time.NewTicker(g.interval)
-
 	go func() {
-		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				g.logger.Debug("GCS watcher stopped", "source", g.String())
 				return
-			case <-ticker.C:
+			case <-g.clock.After(g.interval):
 				g.checkAndReload(ctx, callback)
 			}
 		}
@@ -96,6 +108,7 @@ func (g *GCSDataSourceImpl) Watch(ctx context.Context, callback func() error) er
 
 func (g *GCSDataSourceImpl) checkAndReload(ctx context.Context, callback func() error) {
 	attrs, err := g.client.Bucket(g.bucket).Object(g.objectPath).Attrs(ctx)
+	ReportWatchCheck(ctx, g.clock.Now().Add(g.interval), err)
 	if err != nil {
 		g.logger.Error("failed to check object metadata", "source", g.String(), "error", err)
 		return
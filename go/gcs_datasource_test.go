@@ -0,0 +1,129 @@
+//go:build gcs
+
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func newFakeGCSServer(t *testing.T) (*testingsupport.FakeGCSServer, *testingsupport.FakeBucket) {
+	t.Helper()
+	client := testingsupport.NewFakeGCSClient()
+	bucket := client.AddBucket("orgdata")
+	raw, err := json.Marshal(CreateTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket.AddBlob("path/to/data.json", raw)
+	server := testingsupport.NewFakeGCSServer(client)
+	t.Cleanup(server.Close)
+	return server, bucket
+}
+
+func TestGCSDataSourceEmulatorHost(t *testing.T) {
+	server, _ := newFakeGCSServer(t)
+	t.Setenv("STORAGE_EMULATOR_HOST", server.EmulatorHost())
+	ctx := context.Background()
+
+	source, err := NewGCSDataSourceWithSDK(ctx, "orgdata", "path/to/data.json")
+	if err != nil {
+		t.Fatalf("NewGCSDataSourceWithSDK: %v", err)
+	}
+	defer source.Close()
+
+	service := NewService()
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if service.GetEmployeeByUID("testuser1") == nil {
+		t.Error("testuser1 not loaded")
+	}
+
+	missing, err := NewGCSDataSourceWithSDK(ctx, "orgdata", "missing.json")
+	if err != nil {
+		t.Fatalf("NewGCSDataSourceWithSDK: %v", err)
+	}
+	defer missing.Close()
+	if _, err := missing.Load(ctx); err == nil {
+		t.Error("Load of a missing object succeeded, want an error")
+	}
+}
+
+func TestGCSDataSourceCredentials(t *testing.T) {
+	server, _ := newFakeGCSServer(t)
+	ctx := context.Background()
+
+	source, err := NewGCSDataSourceWithSDK(ctx, "orgdata", "path/to/data.json",
+		WithEndpoint(server.Endpoint()),
+		WithCredentialsJSON(server.CredentialsJSON()))
+	if err != nil {
+		t.Fatalf("NewGCSDataSourceWithSDK: %v", err)
+	}
+	defer source.Close()
+
+	reader, err := source.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	reader.Close()
+	if server.ObjectRequests() == 0 {
+		t.Error("fake server saw no object requests")
+	}
+}
+
+func TestGCSDataSourceWatch(t *testing.T) {
+	server, bucket := newFakeGCSServer(t)
+	t.Setenv("STORAGE_EMULATOR_HOST", server.EmulatorHost())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source, err := NewGCSDataSourceWithSDK(ctx, "orgdata", "path/to/data.json",
+		WithCheckInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewGCSDataSourceWithSDK: %v", err)
+	}
+	defer source.Close()
+
+	service := NewService()
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if err := service.StartDataSourceWatcher(ctx, source); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+	defer service.StopWatcher()
+
+	updated := CreateTestData()
+	delete(updated.Lookups.Employees, "testuser2")
+	raw, err := json.Marshal(updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bucket.UpdateBlob("path/to/data.json", raw); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for service.GetEmployeeByUID("testuser2") != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("service did not reload after the object changed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMustNewGCSDataSourceWithSDK(t *testing.T) {
+	defer func() {
+		var cfgErr *ConfigError
+		if err, _ := recover().(error); !errors.As(err, &cfgErr) {
+			t.Errorf("MustNewGCSDataSourceWithSDK panicked with %v, want a ConfigError", err)
+		}
+	}()
+	MustNewGCSDataSourceWithSDK(context.Background(), "", "path/to/data.json")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
 )
@@ -364,3 +365,98 @@ func TestFakeGCSDataSourceHotReload(t *testing.T) {
 		t.Errorf("Expected 'User Two', got %q", user2.FullName)
 	}
 }
+
+// TestFakeGCSDataSourceTriggerChange tests that a watched service reloads on
+// TriggerChange.
+func TestFakeGCSDataSourceTriggerChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	initial := CreateTestData()
+	source := testingsupport.NewFakeGCSDataSource("bucket", "data.json", mustJSON(t, initial))
+	if err := source.TriggerChange(ctx); err == nil {
+		t.Error("TriggerChange before Watch succeeded, want an error")
+	}
+
+	service := NewService()
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if err := service.StartDataSourceWatcher(ctx, source); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+	defer service.StopWatcher()
+
+	updated := CreateTestData()
+	updated.Metadata.DataVersion = "test-v2.0"
+	delete(updated.Lookups.Employees, "testuser2")
+	if err := source.UpdateContent(mustJSON(t, updated)); err != nil {
+		t.Fatalf("UpdateContent: %v", err)
+	}
+	if service.GetEmployeeByUID("testuser2") == nil {
+		t.Fatal("service reloaded before TriggerChange")
+	}
+	if err := source.TriggerChange(ctx); err != nil {
+		t.Fatalf("TriggerChange: %v", err)
+	}
+	if service.GetEmployeeByUID("testuser2") != nil {
+		t.Error("testuser2 still present after TriggerChange")
+	}
+
+	if err := source.Watch(ctx, func() error { return nil }); err == nil {
+		t.Error("second Watch succeeded, want an error")
+	}
+}
+
+// TestFakeGCSDataSourcePolling tests that Watch calls back when the
+// generation changes if a poll interval is set.
+func TestFakeGCSDataSourcePolling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := testingsupport.NewFakeGCSDataSource("bucket", "data.json", []byte(`{}`))
+	source.SetPollInterval(time.Millisecond)
+	calls := make(chan struct{}, 10)
+	if err := source.Watch(ctx, func() error {
+		select {
+		case calls <- struct{}{}:
+		default:
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+
+	select {
+	case <-calls:
+		t.Fatal("callback ran before the generation changed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if err := source.UpdateContent([]byte(`{"v": 2}`)); err != nil {
+		t.Fatalf("UpdateContent: %v", err)
+	}
+	select {
+	case <-calls:
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback did not run after the generation changed")
+	}
+
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for source.TriggerChange(context.Background()) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Watch still running after its context was cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
@@ -0,0 +1,136 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// GitHubTeamMapping binds a team in a GitHub organization to the team whose
+// members it should hold.
+type GitHubTeamMapping struct {
+	// Slug is the GitHub team's slug, such as "platform-team".
+	Slug string
+	// Team is the name of the team in the org data.
+	Team string
+}
+
+// GitHubTeamChange is what a sync job must do to one GitHub team. Members are
+// lowercase GitHub logins, sorted.
+type GitHubTeamChange struct {
+	Slug string
+	// Create is set when the GitHub team does not exist yet; Add then holds
+	// all of its members.
+	Create bool
+	Add    []string
+	Remove []string
+}
+
+// GitHubTeamPlan lists the changes that bring GitHub teams in line with the
+// org data.
+type GitHubTeamPlan struct {
+	// Changes holds one entry per GitHub team that needs changing, sorted by
+	// slug. Teams already in sync are left out.
+	Changes []GitHubTeamChange
+	// MissingGitHubIDs lists, by slug, the UIDs of members who cannot be
+	// added because they have no GitHub ID.
+	MissingGitHubIDs map[string][]string
+}
+
+// GitHubTeamMappings maps every team to a GitHub team of the same name, for
+// organizations whose GitHub teams mirror the org data one to one.
+func (s *Service) GitHubTeamMappings() []GitHubTeamMapping {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mappings := []GitHubTeamMapping{}
+	if s.data == nil {
+		return mappings
+	}
+	for name := range s.data.Lookups.Teams {
+		mappings = append(mappings, GitHubTeamMapping{Slug: name, Team: name})
+	}
+	slices.SortFunc(mappings, func(a, b GitHubTeamMapping) int { return cmp.Compare(a.Slug, b.Slug) })
+	return mappings
+}
+
+// DesiredGitHubTeams returns the GitHub logins each mapped GitHub team should
+// hold: the members of its team that have GitHub IDs, lowercased and sorted.
+// UIDs of members without a GitHub ID are returned by slug in missing. An
+// unknown team fails with ErrNotFound, and without loaded data
+// DesiredGitHubTeams fails with ErrNoData.
+func (s *Service) DesiredGitHubTeams(mappings []GitHubTeamMapping) (desired, missing map[string][]string, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil, nil, ErrNoData
+	}
+	desired = make(map[string][]string, len(mappings))
+	missing = make(map[string][]string)
+	for _, m := range mappings {
+		team, ok := s.data.Lookups.Teams[m.Team]
+		if !ok {
+			return nil, nil, NewNotFoundError("team", m.Team)
+		}
+		logins := desired[m.Slug]
+		if logins == nil {
+			logins = []string{}
+		}
+		for _, uid := range team.Group.ResolvedPeopleUIDList {
+			if emp, ok := s.data.Lookups.Employees[uid]; ok && emp.GitHubID != "" {
+				logins = append(logins, strings.ToLower(emp.GitHubID))
+			} else {
+				missing[m.Slug] = append(missing[m.Slug], uid)
+			}
+		}
+		slices.Sort(logins)
+		desired[m.Slug] = slices.Compact(logins)
+	}
+	for slug, uids := range missing {
+		slices.Sort(uids)
+		missing[slug] = slices.Compact(uids)
+	}
+	return desired, missing, nil
+}
+
+// DiffGitHubTeams compares the desired members of each GitHub team, by slug,
+// with its current members as reported by the GitHub API, and returns the
+// changes sorted by slug. Logins are compared case-insensitively, as GitHub
+// does. Teams in current but not in desired are not managed by the mapping
+// and are left alone.
+func DiffGitHubTeams(desired, current map[string][]string) []GitHubTeamChange {
+	changes := []GitHubTeamChange{}
+	for slug, want := range desired {
+		have, exists := current[slug]
+		change := GitHubTeamChange{Slug: slug, Create: !exists}
+		change.Add, change.Remove = diffMembers(lowerAll(want), lowerAll(have))
+		if change.Create || len(change.Add) > 0 || len(change.Remove) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b GitHubTeamChange) int { return cmp.Compare(a.Slug, b.Slug) })
+	return changes
+}
+
+// PlanGitHubTeams combines DesiredGitHubTeams and DiffGitHubTeams: given each
+// GitHub team's current members by slug, it returns the changes a sync job
+// should apply.
+func (s *Service) PlanGitHubTeams(mappings []GitHubTeamMapping, current map[string][]string) (GitHubTeamPlan, error) {
+	desired, missing, err := s.DesiredGitHubTeams(mappings)
+	if err != nil {
+		return GitHubTeamPlan{}, err
+	}
+	return GitHubTeamPlan{
+		Changes:          DiffGitHubTeams(desired, current),
+		MissingGitHubIDs: missing,
+	}, nil
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	return lowered
+}
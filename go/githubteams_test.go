@@ -0,0 +1,126 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGitHubTeamMappings(t *testing.T) {
+	service := setupTestService(t)
+
+	want := []GitHubTeamMapping{
+		{Slug: "platform-team", Team: "platform-team"},
+		{Slug: "test-team", Team: "test-team"},
+	}
+	if got := service.GitHubTeamMappings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GitHubTeamMappings = %+v, want %+v", got, want)
+	}
+	if got := NewService().GitHubTeamMappings(); len(got) != 0 {
+		t.Errorf("GitHubTeamMappings without data = %+v, want empty", got)
+	}
+}
+
+func TestDesiredGitHubTeams(t *testing.T) {
+	service := setupTestService(t)
+
+	desired, missing, err := service.DesiredGitHubTeams(service.GitHubTeamMappings())
+	if err != nil {
+		t.Fatalf("DesiredGitHubTeams: %v", err)
+	}
+	want := map[string][]string{
+		"test-team":     {"alice-codes", "jsmith-dev"},
+		"platform-team": {"bobw"},
+	}
+	if !reflect.DeepEqual(desired, want) {
+		t.Errorf("desired = %v, want %v", desired, want)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing = %v, want none", missing)
+	}
+}
+
+func TestDesiredGitHubTeamsErrors(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name    string
+		service *Service
+		want    error
+	}{
+		{"no data", NewService(), ErrNoData},
+		{"unknown team", service, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.service.DesiredGitHubTeams([]GitHubTeamMapping{{Slug: "x", Team: "nope"}})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("DesiredGitHubTeams = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffGitHubTeams(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string][]string
+		current map[string][]string
+		want    []GitHubTeamChange
+	}{
+		{
+			name:    "in sync ignoring case",
+			desired: map[string][]string{"a": {"alice", "bob"}},
+			current: map[string][]string{"a": {"Bob", "ALICE"}},
+			want:    []GitHubTeamChange{},
+		},
+		{
+			name:    "add and remove",
+			desired: map[string][]string{"a": {"alice", "carol"}},
+			current: map[string][]string{"a": {"alice", "Bob"}},
+			want:    []GitHubTeamChange{{Slug: "a", Add: []string{"carol"}, Remove: []string{"bob"}}},
+		},
+		{
+			name:    "create",
+			desired: map[string][]string{"b": {"bob", "alice"}},
+			current: map[string][]string{"other": {"dave"}},
+			want:    []GitHubTeamChange{{Slug: "b", Create: true, Add: []string{"alice", "bob"}, Remove: []string{}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffGitHubTeams(tt.desired, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffGitHubTeams = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanGitHubTeams(t *testing.T) {
+	data := CreateTestData()
+	emp := data.Lookups.Employees["testuser1"]
+	emp.GitHubID = "GHUser1"
+	data.Lookups.Employees["testuser1"] = emp
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "github", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	plan, err := service.PlanGitHubTeams(
+		[]GitHubTeamMapping{{Slug: "squad", Team: "test-squad"}},
+		map[string][]string{"squad": {"ghuser1", "ghost"}},
+	)
+	if err != nil {
+		t.Fatalf("PlanGitHubTeams: %v", err)
+	}
+	want := GitHubTeamPlan{
+		Changes:          []GitHubTeamChange{{Slug: "squad", Add: []string{}, Remove: []string{"ghost"}}},
+		MissingGitHubIDs: map[string][]string{"squad": {"testuser2"}},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("plan = %+v, want %+v", plan, want)
+	}
+}
@@ -0,0 +1,250 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"slices"
+)
+
+// GraphNode is an employee, identified by UID, or a team, organization,
+// pillar or team group, identified by name, in the org graph.
+type GraphNode struct {
+	Type EntityType `json:"type"`
+	Name string     `json:"name"`
+}
+
+// GraphEdge is a relationship between two nodes of the org graph. Edges are
+// directed the way the data states them: an employee is a member of a team,
+// reports to a manager, and a team is a child of its parent.
+type GraphEdge struct {
+	From GraphNode     `json:"from"`
+	To   GraphNode     `json:"to"`
+	Kind GraphEdgeKind `json:"kind"`
+}
+
+// FindPath returns the shortest chain of relationships connecting from and
+// to, such as the edges from one team up to the organization it shares with
+// another and back down. Edges are followed in either direction, but each
+// keeps the direction the data states, so the From of an edge is not
+// necessarily the node reached before it. Of several shortest paths, the
+// same one is returned every time.
+//
+// Group names are resolved like lookups by name. FindPath returns nil when
+// either node is unknown or they are not connected, and an empty path when
+// they are the same node.
+//
+//	path := service.FindPath(
+//		orgdatacore.GraphNode{Type: orgdatacore.EntityTeam, Name: "team-a"},
+//		orgdatacore.GraphNode{Type: orgdatacore.EntityTeam, Name: "team-b"},
+//	)
+func (s *Service) FindPath(from, to GraphNode) []GraphEdge {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil
+	}
+	graph := s.derived().graphIndex()
+	from, to = s.resolveNode(from), s.resolveNode(to)
+	if _, ok := graph[from]; !ok {
+		return nil
+	}
+	if _, ok := graph[to]; !ok {
+		return nil
+	}
+	if from == to {
+		return []GraphEdge{}
+	}
+
+	// Breadth-first search, remembering the edge each node was reached by.
+	via := map[GraphNode]GraphEdge{from: {}}
+	queue := []GraphNode{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range graph[node] {
+			next := edge.other(node)
+			if _, seen := via[next]; seen {
+				continue
+			}
+			via[next] = edge
+			if next == to {
+				var path []GraphEdge
+				for n := to; n != from; {
+					e := via[n]
+					path = append(path, e)
+					n = e.other(n)
+				}
+				slices.Reverse(path)
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// Neighborhood returns the edges of every relationship within hops steps of
+// node: its direct relationships for one hop, those of the nodes they lead
+// to for two, and so on. Edges are ordered by distance from node. It returns
+// nil when node is unknown or hops is less than one, and no edges when node
+// has no relationships.
+func (s *Service) Neighborhood(node GraphNode, hops int) []GraphEdge {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil || hops < 1 {
+		return nil
+	}
+	graph := s.derived().graphIndex()
+	node = s.resolveNode(node)
+	if _, ok := graph[node]; !ok {
+		return nil
+	}
+
+	edges := []GraphEdge{}
+	seenEdges := make(map[GraphEdge]bool)
+	seen := map[GraphNode]bool{node: true}
+	frontier := []GraphNode{node}
+	for range hops {
+		var next []GraphNode
+		for _, n := range frontier {
+			for _, edge := range graph[n] {
+				if !seenEdges[edge] {
+					seenEdges[edge] = true
+					edges = append(edges, edge)
+				}
+				if other := edge.other(n); !seen[other] {
+					seen[other] = true
+					next = append(next, other)
+				}
+			}
+		}
+		frontier = next
+	}
+	return edges
+}
+
+// other returns the endpoint of e that is not n.
+func (e GraphEdge) other(n GraphNode) GraphNode {
+	if e.From == n {
+		return e.To
+	}
+	return e.From
+}
+
+// resolveNode resolves the name of a group node like lookups by name.
+// Must be called with s.mu held.
+func (s *Service) resolveNode(n GraphNode) GraphNode {
+	lookups := &s.data.Lookups
+	switch n.Type {
+	case EntityTeam:
+		n.Name = resolveKey(s, lookups.Teams, "team", n.Name)
+	case EntityOrg:
+		n.Name = resolveKey(s, lookups.Orgs, "org", n.Name)
+	case EntityPillar:
+		n.Name = resolveKey(s, lookups.Pillars, "pillar", n.Name)
+	case EntityTeamGroup:
+		n.Name = resolveKey(s, lookups.TeamGroups, "team_group", n.Name)
+	}
+	return n
+}
+
+// graphIndex returns the edges touching each node of the org graph, with
+// every node present even when it has no edges. An employee is linked to the
+// groups of their membership index entries, except for the ancestors of
+// another of those groups, which the hierarchy already connects them to.
+func (d *derivedIndexes) graphIndex() map[GraphNode][]GraphEdge {
+	d.graphOnce.Do(func() {
+		d.graph = make(map[GraphNode][]GraphEdge)
+		if d.data == nil {
+			return
+		}
+		lookups := &d.data.Lookups
+		add := func(edge GraphEdge) {
+			if _, ok := d.graph[edge.To]; !ok {
+				return // dangling reference
+			}
+			d.graph[edge.From] = append(d.graph[edge.From], edge)
+			d.graph[edge.To] = append(d.graph[edge.To], edge)
+		}
+
+		for uid := range lookups.Employees {
+			d.graph[GraphNode{Type: EntityEmployee, Name: uid}] = nil
+		}
+		addGroups(d.graph, EntityTeam, lookups.Teams)
+		addGroups(d.graph, EntityOrg, lookups.Orgs)
+		addGroups(d.graph, EntityPillar, lookups.Pillars)
+		addGroups(d.graph, EntityTeamGroup, lookups.TeamGroups)
+
+		parents := make(map[GraphNode]GraphNode)
+		for node := range d.graph {
+			if node.Type == EntityEmployee {
+				continue
+			}
+			if p := groupParent(lookups, node); p != nil {
+				parent := GraphNode{Type: EntityType(p.Type), Name: p.Name}
+				parents[node] = parent
+				add(GraphEdge{From: node, To: parent, Kind: EdgeChildOf})
+			}
+		}
+
+		for uid, emp := range lookups.Employees {
+			node := GraphNode{Type: EntityEmployee, Name: uid}
+			if emp.ManagerUID != "" && emp.ManagerUID != uid {
+				add(GraphEdge{From: node, To: GraphNode{Type: EntityEmployee, Name: emp.ManagerUID}, Kind: EdgeReportsTo})
+			}
+			memberships := d.data.Indexes.Membership.MembershipIndex[uid]
+			implied := make(map[GraphNode]bool)
+			for _, m := range memberships {
+				// Bounded by the number of groups so it terminates on
+				// cyclic data.
+				group := GraphNode{Type: EntityType(m.Type), Name: m.Name}
+				for i, p := 0, parents[group]; p != (GraphNode{}) && i < len(parents); i, p = i+1, parents[p] {
+					implied[p] = true
+				}
+			}
+			for _, m := range memberships {
+				group := GraphNode{Type: EntityType(m.Type), Name: m.Name}
+				if !implied[group] {
+					add(GraphEdge{From: node, To: group, Kind: EdgeMemberOf})
+				}
+			}
+		}
+
+		for node, edges := range d.graph {
+			slices.SortFunc(edges, compareEdges)
+			d.graph[node] = slices.CompactFunc(edges, func(a, b GraphEdge) bool { return a == b })
+		}
+	})
+	return d.graph
+}
+
+func addGroups[T any](graph map[GraphNode][]GraphEdge, kind EntityType, m map[string]T) {
+	for name := range m {
+		graph[GraphNode{Type: kind, Name: name}] = nil
+	}
+}
+
+func groupParent(lookups *Lookups, n GraphNode) *ParentInfo {
+	switch n.Type {
+	case EntityTeam:
+		return lookups.Teams[n.Name].Parent
+	case EntityOrg:
+		return lookups.Orgs[n.Name].Parent
+	case EntityPillar:
+		return lookups.Pillars[n.Name].Parent
+	case EntityTeamGroup:
+		return lookups.TeamGroups[n.Name].Parent
+	}
+	return nil
+}
+
+func compareEdges(a, b GraphEdge) int {
+	return cmp.Or(
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.From.Type, b.From.Type),
+		cmp.Compare(a.From.Name, b.From.Name),
+		cmp.Compare(a.To.Type, b.To.Type),
+		cmp.Compare(a.To.Name, b.To.Name),
+	)
+}
@@ -0,0 +1,91 @@
+package orgdatacore
+
+import (
+	"slices"
+	"testing"
+)
+
+func teamNode(name string) GraphNode    { return GraphNode{Type: EntityTeam, Name: name} }
+func orgNode(name string) GraphNode     { return GraphNode{Type: EntityOrg, Name: name} }
+func employeeNode(uid string) GraphNode { return GraphNode{Type: EntityEmployee, Name: uid} }
+
+func TestFindPath(t *testing.T) {
+	service := setupTestService(t)
+
+	path := service.FindPath(teamNode("test-team"), teamNode("platform-team"))
+	want := []GraphEdge{
+		{From: teamNode("test-team"), To: orgNode("test-org"), Kind: EdgeChildOf},
+		{From: orgNode("platform-org"), To: orgNode("test-org"), Kind: EdgeChildOf},
+		{From: GraphNode{Type: EntityPillar, Name: "engineering"}, To: orgNode("platform-org"), Kind: EdgeChildOf},
+		{From: GraphNode{Type: EntityTeamGroup, Name: "backend-teams"}, To: GraphNode{Type: EntityPillar, Name: "engineering"}, Kind: EdgeChildOf},
+		{From: teamNode("platform-team"), To: GraphNode{Type: EntityTeamGroup, Name: "backend-teams"}, Kind: EdgeChildOf},
+	}
+	if !slices.Equal(path, want) {
+		t.Errorf("FindPath(test-team, platform-team) = %v, want %v", path, want)
+	}
+
+	// Employees are linked to their team rather than to the orgs above it.
+	path = service.FindPath(employeeNode("bwilson"), orgNode("platform-org"))
+	if len(path) != 4 || path[0] != (GraphEdge{From: employeeNode("bwilson"), To: teamNode("platform-team"), Kind: EdgeMemberOf}) {
+		t.Errorf("FindPath(bwilson, platform-org) = %v, want 4 edges through platform-team", path)
+	}
+
+	path = service.FindPath(employeeNode("jsmith"), employeeNode("adoe"))
+	if want := []GraphEdge{{From: employeeNode("jsmith"), To: employeeNode("adoe"), Kind: EdgeReportsTo}}; !slices.Equal(path, want) {
+		t.Errorf("FindPath(jsmith, adoe) = %v, want %v", path, want)
+	}
+
+	if path := service.FindPath(teamNode("test-team"), teamNode("test-team")); path == nil || len(path) != 0 {
+		t.Errorf("FindPath to itself = %v, want an empty path", path)
+	}
+	if path := service.FindPath(teamNode("test-team"), teamNode("nonexistent")); path != nil {
+		t.Errorf("FindPath to an unknown team = %v, want nil", path)
+	}
+	if path := service.FindPath(orgNode("test-team"), teamNode("platform-team")); path != nil {
+		t.Errorf("FindPath from a team named as an org = %v, want nil", path)
+	}
+	if path := NewService().FindPath(teamNode("test-team"), teamNode("platform-team")); path != nil {
+		t.Errorf("FindPath on an empty service = %v, want nil", path)
+	}
+}
+
+func TestNeighborhood(t *testing.T) {
+	service := setupTestService(t)
+
+	edges := service.Neighborhood(teamNode("test-team"), 1)
+	want := []GraphEdge{
+		{From: teamNode("test-team"), To: orgNode("test-org"), Kind: EdgeChildOf},
+		{From: employeeNode("adoe"), To: teamNode("test-team"), Kind: EdgeMemberOf},
+		{From: employeeNode("jsmith"), To: teamNode("test-team"), Kind: EdgeMemberOf},
+	}
+	if !slices.Equal(edges, want) {
+		t.Errorf("Neighborhood(test-team, 1) = %v, want %v", edges, want)
+	}
+
+	edges = service.Neighborhood(teamNode("test-team"), 2)
+	if !slices.Equal(edges[:len(want)], want) {
+		t.Errorf("Neighborhood(test-team, 2) = %v, want it to start with the 1-hop edges", edges)
+	}
+	for _, extra := range []GraphEdge{
+		{From: orgNode("platform-org"), To: orgNode("test-org"), Kind: EdgeChildOf},
+		{From: employeeNode("jsmith"), To: employeeNode("adoe"), Kind: EdgeReportsTo},
+	} {
+		if !slices.Contains(edges, extra) {
+			t.Errorf("Neighborhood(test-team, 2) = %v, missing %v", edges, extra)
+		}
+	}
+
+	if edges := service.Neighborhood(teamNode("test-team"), 0); edges != nil {
+		t.Errorf("Neighborhood with 0 hops = %v, want nil", edges)
+	}
+	if edges := service.Neighborhood(teamNode("nonexistent"), 1); edges != nil {
+		t.Errorf("Neighborhood of an unknown team = %v, want nil", edges)
+	}
+
+	if err := service.ApplyChangeSet(ChangeSet{EmployeesUpserted: map[string]Employee{"loner": {UID: "loner"}}}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if edges := service.Neighborhood(employeeNode("loner"), 1); edges == nil || len(edges) != 0 {
+		t.Errorf("Neighborhood of an employee without relationships = %v, want no edges", edges)
+	}
+}
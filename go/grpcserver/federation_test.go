@@ -0,0 +1,130 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// grpcMember is a federation member backed by the OrgData RPCs, covering the
+// queries the test makes. The rest are answered by the embedded service,
+// which has no data.
+type grpcMember struct {
+	orgdatacore.ServiceInterface
+	t      *testing.T
+	client OrgDataClient
+}
+
+func newGRPCMember(t *testing.T) *grpcMember {
+	return &grpcMember{ServiceInterface: orgdatacore.NewService(), t: t, client: newTestClient(t)}
+}
+
+// call runs fn with a deadline, reporting whether it succeeded. Errors other
+// than codes.NotFound fail the test.
+func (g *grpcMember) call(method string, fn func(context.Context) error) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := fn(ctx)
+	if err != nil && status.Code(err) != codes.NotFound {
+		g.t.Errorf("%s: %v", method, err)
+	}
+	return err == nil
+}
+
+func (g *grpcMember) getEmployee(req *GetEmployeeRequest) *orgdatacore.Employee {
+	var resp *Employee
+	if !g.call("GetEmployee", func(ctx context.Context) (err error) {
+		resp, err = g.client.GetEmployee(ctx, req)
+		return err
+	}) {
+		return nil
+	}
+	return &orgdatacore.Employee{UID: resp.Uid, FullName: resp.FullName, Email: resp.Email, SlackUID: resp.SlackUid, ManagerUID: resp.ManagerUid}
+}
+
+func (g *grpcMember) GetEmployeeByUID(uid string) *orgdatacore.Employee {
+	return g.getEmployee(&GetEmployeeRequest{Key: &GetEmployeeRequest_Uid{Uid: uid}})
+}
+
+func (g *grpcMember) GetEmployeeBySlackID(slackID string) *orgdatacore.Employee {
+	return g.getEmployee(&GetEmployeeRequest{Key: &GetEmployeeRequest_SlackId{SlackId: slackID}})
+}
+
+func (g *grpcMember) GetTeamByName(teamName string) *orgdatacore.Team {
+	var resp *Entity
+	if !g.call("GetEntity", func(ctx context.Context) (err error) {
+		resp, err = g.client.GetEntity(ctx, &GetEntityRequest{Name: teamName, Type: "team"})
+		return err
+	}) {
+		return nil
+	}
+	return &orgdatacore.Team{UID: resp.Uid, Name: resp.Name, Description: resp.Description,
+		Group: orgdatacore.Group{ResolvedPeopleUIDList: resp.MemberUids}}
+}
+
+func (g *grpcMember) GetTeamMembers(teamName string) []orgdatacore.Employee {
+	var resp *EmployeeList
+	if !g.call("GetMembers", func(ctx context.Context) (err error) {
+		resp, err = g.client.GetMembers(ctx, &GetEntityRequest{Name: teamName, Type: "team"})
+		return err
+	}) {
+		return []orgdatacore.Employee{}
+	}
+	out := make([]orgdatacore.Employee, 0, len(resp.Employees))
+	for _, e := range resp.Employees {
+		out = append(out, orgdatacore.Employee{UID: e.Uid, FullName: e.FullName})
+	}
+	return out
+}
+
+func (g *grpcMember) IsEmployeeInTeam(uid string, teamName string) bool {
+	var resp *IsMemberResponse
+	req := &IsMemberRequest{Member: &IsMemberRequest_Uid{Uid: uid}, Name: teamName, Type: "team"}
+	return g.call("IsMember", func(ctx context.Context) (err error) {
+		resp, err = g.client.IsMember(ctx, req)
+		return err
+	}) && resp.Member
+}
+
+func TestFederatedGRPCMember(t *testing.T) {
+	local := orgdatacore.NewService()
+	if err := local.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	fed := orgdatacore.NewFederatedService(local, newGRPCMember(t))
+
+	if emp := fed.GetEmployeeByUID("testuser1"); emp == nil || emp.FullName != "Test User One" {
+		t.Errorf("GetEmployeeByUID(testuser1) = %v, want the local employee", emp)
+	}
+	if emp := fed.GetEmployeeByUID("jsmith"); emp == nil || emp.FullName != "John Smith" {
+		t.Errorf("GetEmployeeByUID(jsmith) = %v, want the remote employee", emp)
+	}
+	if members := fed.GetTeamMembers("test-team"); len(members) != 2 {
+		t.Errorf("GetTeamMembers(test-team) = %v, want the remote team's 2 members", members)
+	}
+	if members := fed.GetTeamMembers("test-squad"); len(members) != 2 {
+		t.Errorf("GetTeamMembers(test-squad) = %v, want the local team's 2 members", members)
+	}
+
+	membership := []struct {
+		name  string
+		check func() bool
+		want  bool
+	}{
+		{"jsmith in test-team", func() bool { return fed.IsEmployeeInTeam("jsmith", "test-team") }, true},
+		{"jsmith by Slack ID in test-team", func() bool { return fed.IsSlackUserInTeam("U12345678", "test-team") }, true},
+		{"testuser1 in test-team", func() bool { return fed.IsEmployeeInTeam("testuser1", "test-team") }, false},
+		{"testuser1 by Slack ID in test-squad", func() bool { return fed.IsSlackUserInTeam("U111111", "test-squad") }, true},
+		{"jsmith in test-squad", func() bool { return fed.IsEmployeeInTeam("jsmith", "test-squad") }, false},
+	}
+	for _, tt := range membership {
+		if got := tt.check(); got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
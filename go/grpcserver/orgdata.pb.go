@@ -0,0 +1,1141 @@
+// OrgData serves queries against one shared in-memory copy of the
+// organizational data, so bots in any language can use it without each
+// loading the full dump. The Go implementation is in go/grpcserver.
+//
+// Generate a Python client with:
+//
+//   python -m grpc_tools.protoc -I proto --python_out=. --grpc_python_out=. \
+//       proto/orgdata/v1/orgdata.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        (unknown)
+// source: orgdata/v1/orgdata.proto
+
+package grpcserver
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetEmployeeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*GetEmployeeRequest_Uid
+	//	*GetEmployeeRequest_SlackId
+	//	*GetEmployeeRequest_GithubId
+	//	*GetEmployeeRequest_Email
+	Key           isGetEmployeeRequest_Key `protobuf_oneof:"key"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEmployeeRequest) Reset() {
+	*x = GetEmployeeRequest{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEmployeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmployeeRequest) ProtoMessage() {}
+
+func (x *GetEmployeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmployeeRequest.ProtoReflect.Descriptor instead.
+func (*GetEmployeeRequest) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetEmployeeRequest) GetKey() isGetEmployeeRequest_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *GetEmployeeRequest) GetUid() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetEmployeeRequest_Uid); ok {
+			return x.Uid
+		}
+	}
+	return ""
+}
+
+func (x *GetEmployeeRequest) GetSlackId() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetEmployeeRequest_SlackId); ok {
+			return x.SlackId
+		}
+	}
+	return ""
+}
+
+func (x *GetEmployeeRequest) GetGithubId() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetEmployeeRequest_GithubId); ok {
+			return x.GithubId
+		}
+	}
+	return ""
+}
+
+func (x *GetEmployeeRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetEmployeeRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+type isGetEmployeeRequest_Key interface {
+	isGetEmployeeRequest_Key()
+}
+
+type GetEmployeeRequest_Uid struct {
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3,oneof"`
+}
+
+type GetEmployeeRequest_SlackId struct {
+	SlackId string `protobuf:"bytes,2,opt,name=slack_id,json=slackId,proto3,oneof"`
+}
+
+type GetEmployeeRequest_GithubId struct {
+	GithubId string `protobuf:"bytes,3,opt,name=github_id,json=githubId,proto3,oneof"`
+}
+
+type GetEmployeeRequest_Email struct {
+	Email string `protobuf:"bytes,4,opt,name=email,proto3,oneof"`
+}
+
+func (*GetEmployeeRequest_Uid) isGetEmployeeRequest_Key() {}
+
+func (*GetEmployeeRequest_SlackId) isGetEmployeeRequest_Key() {}
+
+func (*GetEmployeeRequest_GithubId) isGetEmployeeRequest_Key() {}
+
+func (*GetEmployeeRequest_Email) isGetEmployeeRequest_Key() {}
+
+type GetEntityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// type is "team", "org", "pillar" or "team_group". GetEntity and
+	// GetHierarchyPath require it; GetMembers defaults to "team".
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEntityRequest) Reset() {
+	*x = GetEntityRequest{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEntityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEntityRequest) ProtoMessage() {}
+
+func (x *GetEntityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEntityRequest.ProtoReflect.Descriptor instead.
+func (*GetEntityRequest) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEntityRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetEntityRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type IsMemberRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Member:
+	//
+	//	*IsMemberRequest_Uid
+	//	*IsMemberRequest_SlackId
+	Member isIsMemberRequest_Member `protobuf_oneof:"member"`
+	Name   string                   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// type is "team" (the default) or "org".
+	Type          string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsMemberRequest) Reset() {
+	*x = IsMemberRequest{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsMemberRequest) ProtoMessage() {}
+
+func (x *IsMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsMemberRequest.ProtoReflect.Descriptor instead.
+func (*IsMemberRequest) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IsMemberRequest) GetMember() isIsMemberRequest_Member {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *IsMemberRequest) GetUid() string {
+	if x != nil {
+		if x, ok := x.Member.(*IsMemberRequest_Uid); ok {
+			return x.Uid
+		}
+	}
+	return ""
+}
+
+func (x *IsMemberRequest) GetSlackId() string {
+	if x != nil {
+		if x, ok := x.Member.(*IsMemberRequest_SlackId); ok {
+			return x.SlackId
+		}
+	}
+	return ""
+}
+
+func (x *IsMemberRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *IsMemberRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type isIsMemberRequest_Member interface {
+	isIsMemberRequest_Member()
+}
+
+type IsMemberRequest_Uid struct {
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3,oneof"`
+}
+
+type IsMemberRequest_SlackId struct {
+	SlackId string `protobuf:"bytes,2,opt,name=slack_id,json=slackId,proto3,oneof"`
+}
+
+func (*IsMemberRequest_Uid) isIsMemberRequest_Member() {}
+
+func (*IsMemberRequest_SlackId) isIsMemberRequest_Member() {}
+
+type IsMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        bool                   `protobuf:"varint,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IsMemberResponse) Reset() {
+	*x = IsMemberResponse{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IsMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsMemberResponse) ProtoMessage() {}
+
+func (x *IsMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsMemberResponse.ProtoReflect.Descriptor instead.
+func (*IsMemberResponse) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IsMemberResponse) GetMember() bool {
+	if x != nil {
+		return x.Member
+	}
+	return false
+}
+
+type GetJiraOwnersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Component     string                 `protobuf:"bytes,2,opt,name=component,proto3" json:"component,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJiraOwnersRequest) Reset() {
+	*x = GetJiraOwnersRequest{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJiraOwnersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJiraOwnersRequest) ProtoMessage() {}
+
+func (x *GetJiraOwnersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJiraOwnersRequest.ProtoReflect.Descriptor instead.
+func (*GetJiraOwnersRequest) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetJiraOwnersRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *GetJiraOwnersRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+type GetVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{5}
+}
+
+type Employee struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Uid             string                 `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	FullName        string                 `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	JobTitle        string                 `protobuf:"bytes,4,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
+	SlackUid        string                 `protobuf:"bytes,5,opt,name=slack_uid,json=slackUid,proto3" json:"slack_uid,omitempty"`
+	GithubId        string                 `protobuf:"bytes,6,opt,name=github_id,json=githubId,proto3" json:"github_id,omitempty"`
+	ManagerUid      string                 `protobuf:"bytes,7,opt,name=manager_uid,json=managerUid,proto3" json:"manager_uid,omitempty"`
+	IsPeopleManager bool                   `protobuf:"varint,8,opt,name=is_people_manager,json=isPeopleManager,proto3" json:"is_people_manager,omitempty"`
+	Timezone        string                 `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	RhatGeo         string                 `protobuf:"bytes,10,opt,name=rhat_geo,json=rhatGeo,proto3" json:"rhat_geo,omitempty"`
+	CostCenter      int64                  `protobuf:"varint,11,opt,name=cost_center,json=costCenter,proto3" json:"cost_center,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Employee) Reset() {
+	*x = Employee{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Employee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Employee) ProtoMessage() {}
+
+func (x *Employee) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Employee.ProtoReflect.Descriptor instead.
+func (*Employee) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Employee) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Employee) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *Employee) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Employee) GetJobTitle() string {
+	if x != nil {
+		return x.JobTitle
+	}
+	return ""
+}
+
+func (x *Employee) GetSlackUid() string {
+	if x != nil {
+		return x.SlackUid
+	}
+	return ""
+}
+
+func (x *Employee) GetGithubId() string {
+	if x != nil {
+		return x.GithubId
+	}
+	return ""
+}
+
+func (x *Employee) GetManagerUid() string {
+	if x != nil {
+		return x.ManagerUid
+	}
+	return ""
+}
+
+func (x *Employee) GetIsPeopleManager() bool {
+	if x != nil {
+		return x.IsPeopleManager
+	}
+	return false
+}
+
+func (x *Employee) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Employee) GetRhatGeo() string {
+	if x != nil {
+		return x.RhatGeo
+	}
+	return ""
+}
+
+func (x *Employee) GetCostCenter() int64 {
+	if x != nil {
+		return x.CostCenter
+	}
+	return 0
+}
+
+type EmployeeList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Employees     []*Employee            `protobuf:"bytes,1,rep,name=employees,proto3" json:"employees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmployeeList) Reset() {
+	*x = EmployeeList{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmployeeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmployeeList) ProtoMessage() {}
+
+func (x *EmployeeList) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmployeeList.ProtoReflect.Descriptor instead.
+func (*EmployeeList) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EmployeeList) GetEmployees() []*Employee {
+	if x != nil {
+		return x.Employees
+	}
+	return nil
+}
+
+type EntityRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityRef) Reset() {
+	*x = EntityRef{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityRef) ProtoMessage() {}
+
+func (x *EntityRef) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityRef.ProtoReflect.Descriptor instead.
+func (*EntityRef) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EntityRef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EntityRef) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type EntityRefList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entities      []*EntityRef           `protobuf:"bytes,1,rep,name=entities,proto3" json:"entities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityRefList) Reset() {
+	*x = EntityRefList{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityRefList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityRefList) ProtoMessage() {}
+
+func (x *EntityRefList) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityRefList.ProtoReflect.Descriptor instead.
+func (*EntityRefList) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EntityRefList) GetEntities() []*EntityRef {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+type Entity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           string                 `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Parent        *EntityRef             `protobuf:"bytes,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	MemberUids    []string               `protobuf:"bytes,6,rep,name=member_uids,json=memberUids,proto3" json:"member_uids,omitempty"`
+	SlackChannels []string               `protobuf:"bytes,7,rep,name=slack_channels,json=slackChannels,proto3" json:"slack_channels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entity) Reset() {
+	*x = Entity{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entity) ProtoMessage() {}
+
+func (x *Entity) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entity.ProtoReflect.Descriptor instead.
+func (*Entity) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Entity) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Entity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Entity) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Entity) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Entity) GetParent() *EntityRef {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
+func (x *Entity) GetMemberUids() []string {
+	if x != nil {
+		return x.MemberUids
+	}
+	return nil
+}
+
+func (x *Entity) GetSlackChannels() []string {
+	if x != nil {
+		return x.SlackChannels
+	}
+	return nil
+}
+
+type EscalationContact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscalationContact) Reset() {
+	*x = EscalationContact{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscalationContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscalationContact) ProtoMessage() {}
+
+func (x *EscalationContact) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscalationContact.ProtoReflect.Descriptor instead.
+func (*EscalationContact) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EscalationContact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EscalationContact) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *EscalationContact) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type EscalationList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contacts      []*EscalationContact   `protobuf:"bytes,1,rep,name=contacts,proto3" json:"contacts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EscalationList) Reset() {
+	*x = EscalationList{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EscalationList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EscalationList) ProtoMessage() {}
+
+func (x *EscalationList) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EscalationList.ProtoReflect.Descriptor instead.
+func (*EscalationList) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *EscalationList) GetContacts() []*EscalationContact {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+type Version struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// load_time_unix_ms is when the data was loaded, in milliseconds since the
+	// Unix epoch; zero if no data is loaded.
+	LoadTimeUnixMs int64  `protobuf:"varint,1,opt,name=load_time_unix_ms,json=loadTimeUnixMs,proto3" json:"load_time_unix_ms,omitempty"`
+	OrgCount       int64  `protobuf:"varint,2,opt,name=org_count,json=orgCount,proto3" json:"org_count,omitempty"`
+	EmployeeCount  int64  `protobuf:"varint,3,opt,name=employee_count,json=employeeCount,proto3" json:"employee_count,omitempty"`
+	Checksum       string `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Version) Reset() {
+	*x = Version{}
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Version) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Version) ProtoMessage() {}
+
+func (x *Version) ProtoReflect() protoreflect.Message {
+	mi := &file_orgdata_v1_orgdata_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Version.ProtoReflect.Descriptor instead.
+func (*Version) Descriptor() ([]byte, []int) {
+	return file_orgdata_v1_orgdata_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Version) GetLoadTimeUnixMs() int64 {
+	if x != nil {
+		return x.LoadTimeUnixMs
+	}
+	return 0
+}
+
+func (x *Version) GetOrgCount() int64 {
+	if x != nil {
+		return x.OrgCount
+	}
+	return 0
+}
+
+func (x *Version) GetEmployeeCount() int64 {
+	if x != nil {
+		return x.EmployeeCount
+	}
+	return 0
+}
+
+func (x *Version) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+var File_orgdata_v1_orgdata_proto protoreflect.FileDescriptor
+
+const file_orgdata_v1_orgdata_proto_rawDesc = "" +
+	"\n" +
+	"\x18orgdata/v1/orgdata.proto\x12\x15cyborgdata.orgdata.v1\"\x83\x01\n" +
+	"\x12GetEmployeeRequest\x12\x12\n" +
+	"\x03uid\x18\x01 \x01(\tH\x00R\x03uid\x12\x1b\n" +
+	"\bslack_id\x18\x02 \x01(\tH\x00R\aslackId\x12\x1d\n" +
+	"\tgithub_id\x18\x03 \x01(\tH\x00R\bgithubId\x12\x16\n" +
+	"\x05email\x18\x04 \x01(\tH\x00R\x05emailB\x05\n" +
+	"\x03key\":\n" +
+	"\x10GetEntityRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"t\n" +
+	"\x0fIsMemberRequest\x12\x12\n" +
+	"\x03uid\x18\x01 \x01(\tH\x00R\x03uid\x12\x1b\n" +
+	"\bslack_id\x18\x02 \x01(\tH\x00R\aslackId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04typeB\b\n" +
+	"\x06member\"*\n" +
+	"\x10IsMemberResponse\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\bR\x06member\"N\n" +
+	"\x14GetJiraOwnersRequest\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x1c\n" +
+	"\tcomponent\x18\x02 \x01(\tR\tcomponent\"\x13\n" +
+	"\x11GetVersionRequest\"\xcb\x02\n" +
+	"\bEmployee\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12\x1b\n" +
+	"\tfull_name\x18\x02 \x01(\tR\bfullName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1b\n" +
+	"\tjob_title\x18\x04 \x01(\tR\bjobTitle\x12\x1b\n" +
+	"\tslack_uid\x18\x05 \x01(\tR\bslackUid\x12\x1b\n" +
+	"\tgithub_id\x18\x06 \x01(\tR\bgithubId\x12\x1f\n" +
+	"\vmanager_uid\x18\a \x01(\tR\n" +
+	"managerUid\x12*\n" +
+	"\x11is_people_manager\x18\b \x01(\bR\x0fisPeopleManager\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x12\x19\n" +
+	"\brhat_geo\x18\n" +
+	" \x01(\tR\arhatGeo\x12\x1f\n" +
+	"\vcost_center\x18\v \x01(\x03R\n" +
+	"costCenter\"M\n" +
+	"\fEmployeeList\x12=\n" +
+	"\temployees\x18\x01 \x03(\v2\x1f.cyborgdata.orgdata.v1.EmployeeR\temployees\"3\n" +
+	"\tEntityRef\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"M\n" +
+	"\rEntityRefList\x12<\n" +
+	"\bentities\x18\x01 \x03(\v2 .cyborgdata.orgdata.v1.EntityRefR\bentities\"\xe6\x01\n" +
+	"\x06Entity\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\tR\x03uid\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x128\n" +
+	"\x06parent\x18\x05 \x01(\v2 .cyborgdata.orgdata.v1.EntityRefR\x06parent\x12\x1f\n" +
+	"\vmember_uids\x18\x06 \x03(\tR\n" +
+	"memberUids\x12%\n" +
+	"\x0eslack_channels\x18\a \x03(\tR\rslackChannels\"[\n" +
+	"\x11EscalationContact\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"V\n" +
+	"\x0eEscalationList\x12D\n" +
+	"\bcontacts\x18\x01 \x03(\v2(.cyborgdata.orgdata.v1.EscalationContactR\bcontacts\"\x94\x01\n" +
+	"\aVersion\x12)\n" +
+	"\x11load_time_unix_ms\x18\x01 \x01(\x03R\x0eloadTimeUnixMs\x12\x1b\n" +
+	"\torg_count\x18\x02 \x01(\x03R\borgCount\x12%\n" +
+	"\x0eemployee_count\x18\x03 \x01(\x03R\remployeeCount\x12\x1a\n" +
+	"\bchecksum\x18\x04 \x01(\tR\bchecksum2\x98\b\n" +
+	"\aOrgData\x12Y\n" +
+	"\vGetEmployee\x12).cyborgdata.orgdata.v1.GetEmployeeRequest\x1a\x1f.cyborgdata.orgdata.v1.Employee\x12X\n" +
+	"\n" +
+	"GetManager\x12).cyborgdata.orgdata.v1.GetEmployeeRequest\x1a\x1f.cyborgdata.orgdata.v1.Employee\x12S\n" +
+	"\tGetEntity\x12'.cyborgdata.orgdata.v1.GetEntityRequest\x1a\x1d.cyborgdata.orgdata.v1.Entity\x12Z\n" +
+	"\n" +
+	"GetMembers\x12'.cyborgdata.orgdata.v1.GetEntityRequest\x1a#.cyborgdata.orgdata.v1.EmployeeList\x12a\n" +
+	"\x0eGetMemberships\x12).cyborgdata.orgdata.v1.GetEmployeeRequest\x1a$.cyborgdata.orgdata.v1.EntityRefList\x12[\n" +
+	"\bIsMember\x12&.cyborgdata.orgdata.v1.IsMemberRequest\x1a'.cyborgdata.orgdata.v1.IsMemberResponse\x12a\n" +
+	"\x10GetHierarchyPath\x12'.cyborgdata.orgdata.v1.GetEntityRequest\x1a$.cyborgdata.orgdata.v1.EntityRefList\x12c\n" +
+	"\x11GetTeamEscalation\x12'.cyborgdata.orgdata.v1.GetEntityRequest\x1a%.cyborgdata.orgdata.v1.EscalationList\x12c\n" +
+	"\x12GetComponentOwners\x12'.cyborgdata.orgdata.v1.GetEntityRequest\x1a$.cyborgdata.orgdata.v1.EntityRefList\x12b\n" +
+	"\rGetJiraOwners\x12+.cyborgdata.orgdata.v1.GetJiraOwnersRequest\x1a$.cyborgdata.orgdata.v1.EntityRefList\x12V\n" +
+	"\n" +
+	"GetVersion\x12(.cyborgdata.orgdata.v1.GetVersionRequest\x1a\x1e.cyborgdata.orgdata.v1.VersionB4Z2github.com/openshift-eng/cyborg-data/go/grpcserverb\x06proto3"
+
+var (
+	file_orgdata_v1_orgdata_proto_rawDescOnce sync.Once
+	file_orgdata_v1_orgdata_proto_rawDescData []byte
+)
+
+func file_orgdata_v1_orgdata_proto_rawDescGZIP() []byte {
+	file_orgdata_v1_orgdata_proto_rawDescOnce.Do(func() {
+		file_orgdata_v1_orgdata_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_orgdata_v1_orgdata_proto_rawDesc), len(file_orgdata_v1_orgdata_proto_rawDesc)))
+	})
+	return file_orgdata_v1_orgdata_proto_rawDescData
+}
+
+var file_orgdata_v1_orgdata_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_orgdata_v1_orgdata_proto_goTypes = []any{
+	(*GetEmployeeRequest)(nil),   // 0: cyborgdata.orgdata.v1.GetEmployeeRequest
+	(*GetEntityRequest)(nil),     // 1: cyborgdata.orgdata.v1.GetEntityRequest
+	(*IsMemberRequest)(nil),      // 2: cyborgdata.orgdata.v1.IsMemberRequest
+	(*IsMemberResponse)(nil),     // 3: cyborgdata.orgdata.v1.IsMemberResponse
+	(*GetJiraOwnersRequest)(nil), // 4: cyborgdata.orgdata.v1.GetJiraOwnersRequest
+	(*GetVersionRequest)(nil),    // 5: cyborgdata.orgdata.v1.GetVersionRequest
+	(*Employee)(nil),             // 6: cyborgdata.orgdata.v1.Employee
+	(*EmployeeList)(nil),         // 7: cyborgdata.orgdata.v1.EmployeeList
+	(*EntityRef)(nil),            // 8: cyborgdata.orgdata.v1.EntityRef
+	(*EntityRefList)(nil),        // 9: cyborgdata.orgdata.v1.EntityRefList
+	(*Entity)(nil),               // 10: cyborgdata.orgdata.v1.Entity
+	(*EscalationContact)(nil),    // 11: cyborgdata.orgdata.v1.EscalationContact
+	(*EscalationList)(nil),       // 12: cyborgdata.orgdata.v1.EscalationList
+	(*Version)(nil),              // 13: cyborgdata.orgdata.v1.Version
+}
+var file_orgdata_v1_orgdata_proto_depIdxs = []int32{
+	6,  // 0: cyborgdata.orgdata.v1.EmployeeList.employees:type_name -> cyborgdata.orgdata.v1.Employee
+	8,  // 1: cyborgdata.orgdata.v1.EntityRefList.entities:type_name -> cyborgdata.orgdata.v1.EntityRef
+	8,  // 2: cyborgdata.orgdata.v1.Entity.parent:type_name -> cyborgdata.orgdata.v1.EntityRef
+	11, // 3: cyborgdata.orgdata.v1.EscalationList.contacts:type_name -> cyborgdata.orgdata.v1.EscalationContact
+	0,  // 4: cyborgdata.orgdata.v1.OrgData.GetEmployee:input_type -> cyborgdata.orgdata.v1.GetEmployeeRequest
+	0,  // 5: cyborgdata.orgdata.v1.OrgData.GetManager:input_type -> cyborgdata.orgdata.v1.GetEmployeeRequest
+	1,  // 6: cyborgdata.orgdata.v1.OrgData.GetEntity:input_type -> cyborgdata.orgdata.v1.GetEntityRequest
+	1,  // 7: cyborgdata.orgdata.v1.OrgData.GetMembers:input_type -> cyborgdata.orgdata.v1.GetEntityRequest
+	0,  // 8: cyborgdata.orgdata.v1.OrgData.GetMemberships:input_type -> cyborgdata.orgdata.v1.GetEmployeeRequest
+	2,  // 9: cyborgdata.orgdata.v1.OrgData.IsMember:input_type -> cyborgdata.orgdata.v1.IsMemberRequest
+	1,  // 10: cyborgdata.orgdata.v1.OrgData.GetHierarchyPath:input_type -> cyborgdata.orgdata.v1.GetEntityRequest
+	1,  // 11: cyborgdata.orgdata.v1.OrgData.GetTeamEscalation:input_type -> cyborgdata.orgdata.v1.GetEntityRequest
+	1,  // 12: cyborgdata.orgdata.v1.OrgData.GetComponentOwners:input_type -> cyborgdata.orgdata.v1.GetEntityRequest
+	4,  // 13: cyborgdata.orgdata.v1.OrgData.GetJiraOwners:input_type -> cyborgdata.orgdata.v1.GetJiraOwnersRequest
+	5,  // 14: cyborgdata.orgdata.v1.OrgData.GetVersion:input_type -> cyborgdata.orgdata.v1.GetVersionRequest
+	6,  // 15: cyborgdata.orgdata.v1.OrgData.GetEmployee:output_type -> cyborgdata.orgdata.v1.Employee
+	6,  // 16: cyborgdata.orgdata.v1.OrgData.GetManager:output_type -> cyborgdata.orgdata.v1.Employee
+	10, // 17: cyborgdata.orgdata.v1.OrgData.GetEntity:output_type -> cyborgdata.orgdata.v1.Entity
+	7,  // 18: cyborgdata.orgdata.v1.OrgData.GetMembers:output_type -> cyborgdata.orgdata.v1.EmployeeList
+	9,  // 19: cyborgdata.orgdata.v1.OrgData.GetMemberships:output_type -> cyborgdata.orgdata.v1.EntityRefList
+	3,  // 20: cyborgdata.orgdata.v1.OrgData.IsMember:output_type -> cyborgdata.orgdata.v1.IsMemberResponse
+	9,  // 21: cyborgdata.orgdata.v1.OrgData.GetHierarchyPath:output_type -> cyborgdata.orgdata.v1.EntityRefList
+	12, // 22: cyborgdata.orgdata.v1.OrgData.GetTeamEscalation:output_type -> cyborgdata.orgdata.v1.EscalationList
+	9,  // 23: cyborgdata.orgdata.v1.OrgData.GetComponentOwners:output_type -> cyborgdata.orgdata.v1.EntityRefList
+	9,  // 24: cyborgdata.orgdata.v1.OrgData.GetJiraOwners:output_type -> cyborgdata.orgdata.v1.EntityRefList
+	13, // 25: cyborgdata.orgdata.v1.OrgData.GetVersion:output_type -> cyborgdata.orgdata.v1.Version
+	15, // [15:26] is the sub-list for method output_type
+	4,  // [4:15] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_orgdata_v1_orgdata_proto_init() }
+func file_orgdata_v1_orgdata_proto_init() {
+	if File_orgdata_v1_orgdata_proto != nil {
+		return
+	}
+	file_orgdata_v1_orgdata_proto_msgTypes[0].OneofWrappers = []any{
+		(*GetEmployeeRequest_Uid)(nil),
+		(*GetEmployeeRequest_SlackId)(nil),
+		(*GetEmployeeRequest_GithubId)(nil),
+		(*GetEmployeeRequest_Email)(nil),
+	}
+	file_orgdata_v1_orgdata_proto_msgTypes[2].OneofWrappers = []any{
+		(*IsMemberRequest_Uid)(nil),
+		(*IsMemberRequest_SlackId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orgdata_v1_orgdata_proto_rawDesc), len(file_orgdata_v1_orgdata_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_orgdata_v1_orgdata_proto_goTypes,
+		DependencyIndexes: file_orgdata_v1_orgdata_proto_depIdxs,
+		MessageInfos:      file_orgdata_v1_orgdata_proto_msgTypes,
+	}.Build()
+	File_orgdata_v1_orgdata_proto = out.File
+	file_orgdata_v1_orgdata_proto_goTypes = nil
+	file_orgdata_v1_orgdata_proto_depIdxs = nil
+}
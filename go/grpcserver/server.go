@@ -0,0 +1,285 @@
+// Package grpcserver serves an orgdatacore.ServiceInterface over gRPC, so
+// bots written in other languages can query one shared in-memory copy of the
+// data instead of each loading the full dump:
+//
+//	service := orgdatacore.NewService()
+//	// load data and start a watcher ...
+//	lis, _ := net.Listen("tcp", ":9090")
+//	grpcserver.NewServer(service).Serve(lis)
+//
+// The service is defined in proto/orgdata/v1/orgdata.proto at the root of
+// the repository, and orgdata.pb.go and orgdata_grpc.pb.go are generated
+// from it with protoc-gen-go and protoc-gen-go-grpc; run go generate after
+// editing it. Go clients can use NewOrgDataClient, and other languages
+// generate clients from the same file. Lists are sorted by UID
+// or name, except escalation contacts, which are in priority order. A lookup
+// that finds nothing fails with codes.NotFound, and a request missing a
+// required field with codes.InvalidArgument.
+//
+// Loading and watching are left to the embedding program.
+package grpcserver
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+//go:generate protoc -I ../../proto --go_out=. --go_opt=module=github.com/openshift-eng/cyborg-data/go/grpcserver --go-grpc_out=. --go-grpc_opt=module=github.com/openshift-eng/cyborg-data/go/grpcserver orgdata/v1/orgdata.proto
+
+// NewServer returns a gRPC server with the OrgData service registered,
+// answering queries from svc. opts are passed to grpc.NewServer.
+func NewServer(svc orgdatacore.ServiceInterface, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	RegisterOrgDataServer(server, &orgDataServer{svc: svc})
+	return server
+}
+
+// orgDataServer implements OrgDataServer.
+type orgDataServer struct {
+	UnimplementedOrgDataServer
+	svc orgdatacore.ServiceInterface
+}
+
+func (s *orgDataServer) GetEmployee(_ context.Context, req *GetEmployeeRequest) (*Employee, error) {
+	emp, err := s.lookupEmployee(req)
+	if err != nil {
+		return nil, err
+	}
+	return employeeFrom(emp), nil
+}
+
+func (s *orgDataServer) GetManager(_ context.Context, req *GetEmployeeRequest) (*Employee, error) {
+	emp, err := s.lookupEmployee(req)
+	if err != nil {
+		return nil, err
+	}
+	manager := s.svc.GetManagerForEmployee(emp.UID)
+	if manager == nil {
+		return nil, status.Errorf(codes.NotFound, "manager of employee %q not found", emp.UID)
+	}
+	return employeeFrom(manager), nil
+}
+
+func (s *orgDataServer) GetEntity(_ context.Context, req *GetEntityRequest) (*Entity, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing name")
+	}
+	var e *Entity
+	switch req.Type {
+	case "team":
+		if t := s.svc.GetTeamByName(req.Name); t != nil {
+			e = entityFrom(req.Type, t.UID, t.Name, t.Description, t.Parent, t.Group)
+		}
+	case "org":
+		if o := s.svc.GetOrgByName(req.Name); o != nil {
+			e = entityFrom(req.Type, o.UID, o.Name, o.Description, o.Parent, o.Group)
+		}
+	case "pillar":
+		if p := s.svc.GetPillarByName(req.Name); p != nil {
+			e = entityFrom(req.Type, p.UID, p.Name, p.Description, p.Parent, p.Group)
+		}
+	case "team_group":
+		if tg := s.svc.GetTeamGroupByName(req.Name); tg != nil {
+			e = entityFrom(req.Type, tg.UID, tg.Name, tg.Description, tg.Parent, tg.Group)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown type %q", req.Type)
+	}
+	if e == nil {
+		return nil, status.Errorf(codes.NotFound, "%s %q not found", req.Type, req.Name)
+	}
+	return e, nil
+}
+
+func (s *orgDataServer) GetMembers(_ context.Context, req *GetEntityRequest) (*EmployeeList, error) {
+	var members []orgdatacore.Employee
+	switch req.Type {
+	case "", "team":
+		members = s.svc.GetTeamMembers(req.Name)
+	case "org":
+		members = s.svc.GetOrgMembers(req.Name)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "members are only listed for teams and orgs, not %q", req.Type)
+	}
+	list := &EmployeeList{Employees: make([]*Employee, 0, len(members))}
+	for i := range members {
+		list.Employees = append(list.Employees, employeeFrom(&members[i]))
+	}
+	slices.SortStableFunc(list.Employees, func(a, b *Employee) int { return cmp.Compare(a.Uid, b.Uid) })
+	return list, nil
+}
+
+func (s *orgDataServer) GetMemberships(_ context.Context, req *GetEmployeeRequest) (*EntityRefList, error) {
+	emp, err := s.lookupEmployee(req)
+	if err != nil {
+		return nil, err
+	}
+	memberships := s.svc.GetUserMemberships(emp.UID)
+	list := &EntityRefList{Entities: make([]*EntityRef, 0, len(memberships))}
+	for _, m := range memberships {
+		list.Entities = append(list.Entities, &EntityRef{Name: m.Name, Type: m.Type})
+	}
+	sortRefs(list.Entities)
+	return list, nil
+}
+
+func (s *orgDataServer) IsMember(_ context.Context, req *IsMemberRequest) (*IsMemberResponse, error) {
+	var member bool
+	switch req.Type {
+	case "", "team":
+		switch key := req.Member.(type) {
+		case *IsMemberRequest_SlackId:
+			member = s.svc.IsSlackUserInTeam(key.SlackId, req.Name)
+		case *IsMemberRequest_Uid:
+			member = s.svc.IsEmployeeInTeam(key.Uid, req.Name)
+		default:
+			return nil, status.Error(codes.InvalidArgument, "missing uid or slack_id")
+		}
+	case "org":
+		switch key := req.Member.(type) {
+		case *IsMemberRequest_SlackId:
+			member = s.svc.IsSlackUserInOrg(key.SlackId, req.Name)
+		case *IsMemberRequest_Uid:
+			member = s.svc.IsEmployeeInOrg(key.Uid, req.Name)
+		default:
+			return nil, status.Error(codes.InvalidArgument, "missing uid or slack_id")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "membership is only checked for teams and orgs, not %q", req.Type)
+	}
+	return &IsMemberResponse{Member: member}, nil
+}
+
+func (s *orgDataServer) GetHierarchyPath(_ context.Context, req *GetEntityRequest) (*EntityRefList, error) {
+	if req.Type == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing type")
+	}
+	path := s.svc.GetHierarchyPath(req.Name, req.Type)
+	list := &EntityRefList{Entities: make([]*EntityRef, 0, len(path))}
+	for _, p := range path {
+		list.Entities = append(list.Entities, &EntityRef{Name: p.Name, Type: p.Type})
+	}
+	return list, nil
+}
+
+func (s *orgDataServer) GetTeamEscalation(_ context.Context, req *GetEntityRequest) (*EscalationList, error) {
+	contacts := s.svc.GetTeamEscalation(req.Name)
+	list := &EscalationList{Contacts: make([]*EscalationContact, 0, len(contacts))}
+	for _, c := range contacts {
+		list.Contacts = append(list.Contacts, &EscalationContact{Name: c.Name, Url: c.URL, Description: c.Description})
+	}
+	return list, nil
+}
+
+func (s *orgDataServer) GetComponentOwners(_ context.Context, req *GetEntityRequest) (*EntityRefList, error) {
+	owners := s.svc.GetTeamsForComponent(req.Name)
+	list := &EntityRefList{Entities: make([]*EntityRef, 0, len(owners))}
+	for _, o := range owners {
+		list.Entities = append(list.Entities, &EntityRef{Name: o.Name, Type: o.Type})
+	}
+	sortRefs(list.Entities)
+	return list, nil
+}
+
+func (s *orgDataServer) GetJiraOwners(_ context.Context, req *GetJiraOwnersRequest) (*EntityRefList, error) {
+	if req.Project == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing project")
+	}
+	var owners []orgdatacore.JiraOwnerInfo
+	if req.Component != "" {
+		owners = s.svc.GetTeamsByJiraComponent(req.Project, req.Component)
+	} else {
+		owners = s.svc.GetTeamsByJiraProject(req.Project)
+	}
+	list := &EntityRefList{Entities: make([]*EntityRef, 0, len(owners))}
+	for _, o := range owners {
+		list.Entities = append(list.Entities, &EntityRef{Name: o.Name, Type: o.Type})
+	}
+	sortRefs(list.Entities)
+	return list, nil
+}
+
+func (s *orgDataServer) GetVersion(context.Context, *GetVersionRequest) (*Version, error) {
+	v := s.svc.GetVersion()
+	resp := &Version{
+		OrgCount:      int64(v.OrgCount),
+		EmployeeCount: int64(v.EmployeeCount),
+		Checksum:      v.Checksum,
+	}
+	if !v.LoadTime.IsZero() {
+		resp.LoadTimeUnixMs = v.LoadTime.UnixMilli()
+	}
+	return resp, nil
+}
+
+// lookupEmployee finds the employee by whichever key req sets. A key that is
+// set but empty is looked up like any other and finds nobody.
+func (s *orgDataServer) lookupEmployee(req *GetEmployeeRequest) (*orgdatacore.Employee, error) {
+	var emp *orgdatacore.Employee
+	var what, key string
+	switch k := req.Key.(type) {
+	case *GetEmployeeRequest_Uid:
+		emp, what, key = s.svc.GetEmployeeByUID(k.Uid), "employee", k.Uid
+	case *GetEmployeeRequest_SlackId:
+		emp, what, key = s.svc.GetEmployeeBySlackID(k.SlackId), "employee with Slack ID", k.SlackId
+	case *GetEmployeeRequest_GithubId:
+		emp, what, key = s.svc.GetEmployeeByGitHubID(k.GithubId), "employee with GitHub ID", k.GithubId
+	case *GetEmployeeRequest_Email:
+		emp, what, key = s.svc.GetEmployeeByEmail(k.Email), "employee with email", k.Email
+	default:
+		return nil, status.Error(codes.InvalidArgument, "missing uid, slack_id, github_id or email")
+	}
+	if emp == nil {
+		return nil, status.Errorf(codes.NotFound, "%s %q not found", what, key)
+	}
+	return emp, nil
+}
+
+func employeeFrom(e *orgdatacore.Employee) *Employee {
+	return &Employee{
+		Uid:             e.UID,
+		FullName:        e.FullName,
+		Email:           e.Email,
+		JobTitle:        e.JobTitle,
+		SlackUid:        e.SlackUID,
+		GithubId:        e.GitHubID,
+		ManagerUid:      e.ManagerUID,
+		IsPeopleManager: e.IsPeopleManager,
+		Timezone:        e.Timezone,
+		RhatGeo:         e.RhatGeo,
+		CostCenter:      int64(e.CostCenter),
+	}
+}
+
+func entityFrom(typ, uid, name, description string, parent *orgdatacore.ParentInfo, group orgdatacore.Group) *Entity {
+	e := &Entity{
+		Uid:           uid,
+		Name:          name,
+		Type:          typ,
+		Description:   description,
+		MemberUids:    group.ResolvedPeopleUIDList,
+		SlackChannels: []string{},
+	}
+	if parent != nil {
+		e.Parent = &EntityRef{Name: parent.Name, Type: parent.Type}
+	}
+	if group.Slack != nil {
+		for _, ch := range group.Slack.Channels {
+			e.SlackChannels = append(e.SlackChannels, ch.Channel)
+		}
+	}
+	return e
+}
+
+func sortRefs(refs []*EntityRef) {
+	slices.SortStableFunc(refs, func(a, b *EntityRef) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Type, b.Type))
+	})
+}
@@ -0,0 +1,261 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+// TestGeneratedCodeMatchesProto fails when orgdata.proto was edited without
+// running go generate: every message field and rpc of the .proto file must
+// be in the generated descriptor, and nothing else.
+func TestGeneratedCodeMatchesProto(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("..", "..", "proto", "orgdata", "v1", "orgdata.proto"))
+	if err != nil {
+		t.Fatalf("read proto: %v", err)
+	}
+	messageRe := regexp.MustCompile(`^message (\w+) \{`)
+	fieldRe := regexp.MustCompile(`^(?:repeated )?\w+ (\w+) = (\d+);`)
+	rpcRe := regexp.MustCompile(`^rpc (\w+)\((\w+)\) returns \((\w+)\);`)
+	var fromProto []string
+	var message string
+	for _, line := range regexp.MustCompile(`\n\s*`).Split(string(src), -1) {
+		if m := messageRe.FindStringSubmatch(line); m != nil {
+			message = m[1]
+		} else if m := fieldRe.FindStringSubmatch(line); m != nil {
+			fromProto = append(fromProto, message+"."+m[1]+" = "+m[2])
+		} else if m := rpcRe.FindStringSubmatch(line); m != nil {
+			fromProto = append(fromProto, fmt.Sprintf("rpc %s(%s) %s", m[1], m[2], m[3]))
+		}
+	}
+
+	var generated []string
+	file := File_orgdata_v1_orgdata_proto
+	for i := range file.Messages().Len() {
+		msg := file.Messages().Get(i)
+		for j := range msg.Fields().Len() {
+			f := msg.Fields().Get(j)
+			generated = append(generated, fmt.Sprintf("%s.%s = %d", msg.Name(), f.Name(), f.Number()))
+		}
+	}
+	for i := range file.Services().Len() {
+		methods := file.Services().Get(i).Methods()
+		for j := range methods.Len() {
+			m := methods.Get(j)
+			generated = append(generated, fmt.Sprintf("rpc %s(%s) %s", m.Name(), m.Input().Name(), m.Output().Name()))
+		}
+	}
+
+	slices.Sort(fromProto)
+	slices.Sort(generated)
+	if !slices.Equal(fromProto, generated) {
+		t.Errorf("generated code does not match orgdata.proto; run go generate\nproto:     %v\ngenerated: %v", fromProto, generated)
+	}
+}
+
+func TestEmptyOneofValue(t *testing.T) {
+	b, err := proto.Marshal(&GetEmployeeRequest{Key: &GetEmployeeRequest_SlackId{}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var req GetEmployeeRequest
+	if err := proto.Unmarshal(b, &req); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if _, ok := req.Key.(*GetEmployeeRequest_SlackId); !ok {
+		t.Errorf("Key after a round trip = %T, want the empty slack_id that was set", req.Key)
+	}
+}
+
+func newTestClient(t *testing.T) OrgDataClient {
+	t.Helper()
+	service := orgdatacore.NewService()
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := NewServer(service)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewOrgDataClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	client := newTestClient(t)
+
+	byUID := func(uid string) *GetEmployeeRequest {
+		return &GetEmployeeRequest{Key: &GetEmployeeRequest_Uid{Uid: uid}}
+	}
+	tests := []struct {
+		name string
+		call func(context.Context) (proto.Message, error)
+		code codes.Code
+		want proto.Message
+	}{
+		{
+			name: "employee by uid",
+			call: func(ctx context.Context) (proto.Message, error) { return client.GetEmployee(ctx, byUID("jsmith")) },
+			want: &Employee{Uid: "jsmith", FullName: "John Smith", Email: "jsmith@example.com", JobTitle: "Software Engineer", SlackUid: "U12345678", ManagerUid: "adoe"},
+		},
+		{
+			name: "employee by github id",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetEmployee(ctx, &GetEmployeeRequest{Key: &GetEmployeeRequest_GithubId{GithubId: "bobw"}})
+			},
+			want: &Employee{Uid: "bwilson"},
+		},
+		{
+			name: "missing employee",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetEmployee(ctx, &GetEmployeeRequest{Key: &GetEmployeeRequest_Email{Email: "nobody@example.com"}})
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "empty key is looked up",
+			call: func(ctx context.Context) (proto.Message, error) { return client.GetEmployee(ctx, byUID("")) },
+			code: codes.NotFound,
+		},
+		{
+			name: "no key",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetEmployee(ctx, &GetEmployeeRequest{})
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "manager",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetManager(ctx, &GetEmployeeRequest{Key: &GetEmployeeRequest_SlackId{SlackId: "U12345678"}})
+			},
+			want: &Employee{Uid: "adoe"},
+		},
+		{
+			name: "team",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetEntity(ctx, &GetEntityRequest{Name: "test-team", Type: "team"})
+			},
+			want: &Entity{Name: "test-team", Type: "team", Parent: &EntityRef{Name: "test-org", Type: "org"}},
+		},
+		{
+			name: "unknown type",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetEntity(ctx, &GetEntityRequest{Name: "test-team", Type: "widget"})
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "members",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetMembers(ctx, &GetEntityRequest{Name: "test-team"})
+			},
+			want: &EmployeeList{Employees: []*Employee{{Uid: "adoe"}, {Uid: "jsmith"}}},
+		},
+		{
+			name: "memberships",
+			call: func(ctx context.Context) (proto.Message, error) { return client.GetMemberships(ctx, byUID("jsmith")) },
+			want: &EntityRefList{Entities: []*EntityRef{{Name: "test-org", Type: "org"}, {Name: "test-team", Type: "team"}}},
+		},
+		{
+			name: "is member",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.IsMember(ctx, &IsMemberRequest{Member: &IsMemberRequest_Uid{Uid: "jsmith"}, Name: "test-team"})
+			},
+			want: &IsMemberResponse{Member: true},
+		},
+		{
+			name: "is member without a member",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.IsMember(ctx, &IsMemberRequest{Name: "test-team"})
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "hierarchy path",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetHierarchyPath(ctx, &GetEntityRequest{Name: "test-team", Type: "team"})
+			},
+			want: &EntityRefList{Entities: []*EntityRef{{Name: "test-team", Type: "team"}}},
+		},
+		{
+			name: "jira component owners",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetJiraOwners(ctx, &GetJiraOwnersRequest{Project: "TEST", Component: "Core"})
+			},
+			want: &EntityRefList{Entities: []*EntityRef{{Name: "test-team", Type: "team"}}},
+		},
+		{
+			name: "version",
+			call: func(ctx context.Context) (proto.Message, error) { return client.GetVersion(ctx, &GetVersionRequest{}) },
+			want: &Version{EmployeeCount: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			resp, err := tt.call(ctx)
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("code = %v, want %v: %v", got, tt.code, err)
+			}
+			if tt.want != nil && !matches(resp.ProtoReflect(), tt.want.ProtoReflect()) {
+				t.Errorf("got %v, want %v", resp, tt.want)
+			}
+		})
+	}
+}
+
+// matches reports whether every field set in want, and in the messages and
+// lists it holds, has the same value in got.
+func matches(got, want protoreflect.Message) bool {
+	ok := true
+	want.Range(func(fd protoreflect.FieldDescriptor, wv protoreflect.Value) bool {
+		gv := got.Get(fd)
+		switch {
+		case fd.IsList():
+			wl, gl := wv.List(), gv.List()
+			ok = gl.Len() >= wl.Len()
+			for i := 0; ok && i < wl.Len(); i++ {
+				if fd.Message() != nil {
+					ok = matches(gl.Get(i).Message(), wl.Get(i).Message())
+				} else {
+					ok = gl.Get(i).Equal(wl.Get(i))
+				}
+			}
+		case fd.Message() != nil:
+			ok = got.Has(fd) && matches(gv.Message(), wv.Message())
+		default:
+			ok = gv.Equal(wv)
+		}
+		return ok
+	})
+	return ok
+}
@@ -0,0 +1,91 @@
+package orgdatacore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// HealthStatus is a point-in-time summary of a Service's health.
+type HealthStatus struct {
+	// Healthy is false when no data is loaded, the data is stale under
+	// WithMaxDataAge, or a watcher stopped because its source failed.
+	Healthy bool `json:"healthy"`
+	// Problems explains why the service is not healthy.
+	Problems []string `json:"problems"`
+
+	DataLoaded  bool      `json:"data_loaded"`
+	DataVersion string    `json:"data_version,omitempty"`
+	GeneratedAt string    `json:"generated_at,omitempty"`
+	LoadedAt    time.Time `json:"loaded_at"`
+	// DataAgeSeconds is the time since the data was loaded.
+	DataAgeSeconds float64 `json:"data_age_seconds"`
+
+	// WatcherAlive reports whether at least one watcher is running.
+	WatcherAlive bool            `json:"watcher_alive"`
+	Watchers     []WatcherStatus `json:"watchers"`
+
+	// LastError is the error of the most recent failed load, if any.
+	LastError           string `json:"last_error,omitempty"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+}
+
+// Healthz returns the service's health for readiness probes. Failed reloads
+// do not make the service unhealthy by themselves, since it keeps serving the
+// last good data; they are reported in LastError and ConsecutiveFailures, and
+// WithMaxDataAge turns prolonged failures into an unhealthy status.
+func (s *Service) Healthz() HealthStatus {
+	s.mu.RLock()
+	h := HealthStatus{
+		Problems:   []string{},
+		DataLoaded: s.data != nil,
+		LoadedAt:   s.version.LoadTime,
+	}
+	if s.data != nil {
+		h.DataVersion = s.data.Metadata.DataVersion
+		h.GeneratedAt = s.data.Metadata.GeneratedAt
+		h.DataAgeSeconds = s.clock.Now().Sub(s.version.LoadTime).Seconds()
+	}
+	failed := make([]string, 0, len(s.failedWatchers))
+	for name, err := range s.failedWatchers {
+		failed = append(failed, fmt.Sprintf("watcher %q stopped: %s", name, err))
+	}
+	s.mu.RUnlock()
+
+	if err := s.CheckFreshness(); err != nil {
+		h.Problems = append(h.Problems, err.Error())
+	}
+	slices.Sort(failed)
+	h.Problems = append(h.Problems, failed...)
+
+	h.Watchers = s.WatcherStatus()
+	h.WatcherAlive = len(h.Watchers) > 0
+
+	stats := s.LoadStats()
+	h.ConsecutiveFailures = stats.ConsecutiveFailures
+	if stats.Failures > 0 {
+		h.LastError = stats.LastError
+	}
+
+	h.Healthy = len(h.Problems) == 0
+	return h
+}
+
+// HealthHandler returns an http.Handler for readiness probes such as /readyz.
+// It responds with the Healthz status as JSON, with status 200 when the
+// service is healthy and 503 otherwise.
+func (s *Service) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		h := s.Healthz()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if !h.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(h); err != nil {
+			s.logger.Warn("failed to write health status", "error", err)
+		}
+	})
+}
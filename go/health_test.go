@@ -0,0 +1,98 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthz(t *testing.T) {
+	ctx := context.Background()
+
+	service := NewService()
+	if h := service.Healthz(); h.Healthy || h.DataLoaded || len(h.Problems) != 1 {
+		t.Errorf("Healthz() with no data = %+v, want unhealthy with one problem", h)
+	}
+
+	source := NewFakeDataSource(CreateTestDataJSON())
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	h := service.Healthz()
+	if !h.Healthy || !h.DataLoaded || h.DataVersion != "test-v1.0" || h.WatcherAlive || h.LastError != "" {
+		t.Errorf("Healthz() after load = %+v", h)
+	}
+
+	// A failed reload keeps the service healthy but is reported.
+	source.LoadError = errors.New("bucket unavailable")
+	_ = service.LoadFromDataSource(ctx, source)
+	if h := service.Healthz(); !h.Healthy || h.ConsecutiveFailures != 1 || !strings.Contains(h.LastError, "bucket unavailable") {
+		t.Errorf("Healthz() after failed reload = %+v", h)
+	}
+
+	// A watcher whose source fails makes the service unhealthy until restarted.
+	source.LoadError = nil
+	source.WatchError = errors.New("watch broke")
+	if err := service.StartNamedWatcher(ctx, "primary", source); err == nil {
+		t.Fatal("expected watcher error")
+	}
+	if h := service.Healthz(); h.Healthy || len(h.Problems) != 1 || !strings.Contains(h.Problems[0], `watcher "primary" stopped`) {
+		t.Errorf("Healthz() after watcher failure = %+v", h)
+	}
+	source.WatchError = nil
+	if err := service.StartNamedWatcher(ctx, "primary", source); err != nil {
+		t.Fatalf("StartNamedWatcher: %v", err)
+	}
+	defer service.StopWatcher()
+	if h := service.Healthz(); !h.Healthy {
+		t.Errorf("Healthz() after restarting the watcher = %+v", h)
+	}
+
+	// Stale data is unhealthy.
+	stale := NewService(WithMaxDataAge(time.Hour))
+	if err := stale.LoadFromDataSource(ctx, NewFakeDataSource(CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if h := stale.Healthz(); h.Healthy || !strings.Contains(h.Problems[0], "older than the maximum age") {
+		t.Errorf("Healthz() with stale data = %+v", h)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		load       bool
+		wantStatus int
+	}{
+		{"no data", false, http.StatusServiceUnavailable},
+		{"loaded", true, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService()
+			if tt.load {
+				if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(CreateTestDataJSON())); err != nil {
+					t.Fatalf("LoadFromDataSource: %v", err)
+				}
+			}
+
+			rec := httptest.NewRecorder()
+			service.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var h HealthStatus
+			if err := json.NewDecoder(rec.Body).Decode(&h); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if h.Healthy != tt.load {
+				t.Errorf("body healthy = %v, want %v", h.Healthy, tt.load)
+			}
+		})
+	}
+}
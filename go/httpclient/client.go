@@ -0,0 +1,557 @@
+// Package httpclient is a typed client for the REST API served by the
+// httpserver package and described by its openapi.yaml. Client implements
+// orgdatacore.ServiceInterface, so code written against the in-process
+// service can query a shared deployment instead:
+//
+//	var svc orgdatacore.ServiceInterface = httpclient.New("http://orgdata:8080")
+//	emp := svc.GetEmployeeByUID("jsmith")
+//
+// The interface methods return no errors, so a lookup the server answers
+// with 404 returns nil, as in process, and any other failed request is
+// logged and answered like a miss: nil, an empty list, false or a zero
+// value. Call Ping to check that the server is reachable.
+//
+// Client also implements orgdatacore.QueryerWithContext, whose methods,
+// such as GetEmployeeByUIDContext, send the request with the caller's
+// context and return the error of a failed request instead of logging it.
+//
+// The server loads and watches its own data: LoadFromDataSource and
+// StartDataSourceWatcher fail with ErrReadOnly and StopWatcher does nothing.
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// ErrReadOnly is returned by the methods that would load data, which only
+// the server does.
+var ErrReadOnly = errors.New("httpclient: data is loaded by the server")
+
+// Client queries a cyborg-data REST server.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+var (
+	_ orgdatacore.ServiceInterface   = (*Client)(nil)
+	_ orgdatacore.QueryerWithContext = (*Client)(nil)
+)
+
+// New returns a Client for the server at baseURL, such as
+// "http://orgdata:8080". Requests time out after 10 seconds.
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    http.DefaultClient,
+		timeout: 10 * time.Second,
+		logger:  slog.Default(),
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests,
+// http.DefaultClient by default, and returns c.
+func (c *Client) WithHTTPClient(hc *http.Client) *Client {
+	if hc != nil {
+		c.http = hc
+	}
+	return c
+}
+
+// WithTimeout sets the timeout of each request and returns c. Zero means no
+// timeout beyond the HTTP client's own.
+func (c *Client) WithTimeout(d time.Duration) *Client {
+	c.timeout = d
+	return c
+}
+
+// WithLogger sets the logger used to report failed requests, slog.Default
+// by default, and returns c.
+func (c *Client) WithLogger(logger *slog.Logger) *Client {
+	if logger != nil {
+		c.logger = logger
+	}
+	return c
+}
+
+// StatusError is a response with a status other than 200.
+type StatusError struct {
+	StatusCode int
+	// Message is the error the server reported, if any.
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("httpclient: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	}
+	return fmt.Sprintf("httpclient: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Ping fetches the server's data version, returning the error if the server
+// cannot be reached or fails to answer.
+func (c *Client) Ping(ctx context.Context) error {
+	var v versionResponse
+	return c.do(ctx, "/version", nil, &v)
+}
+
+// path joins escaped path segments onto a route, such as path("teams", name,
+// "members") for /teams/{name}/members.
+func path(segments ...string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(s))
+	}
+	return b.String()
+}
+
+// do fetches route with query and decodes the JSON response into out.
+func (c *Client) do(ctx context.Context, route string, query url.Values, out any) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	u := c.baseURL + route
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("httpclient: decode %s: %w", route, err)
+	}
+	return nil
+}
+
+// statusError returns a StatusError for resp, with the error message from
+// its JSON body if there is one.
+func statusError(resp *http.Response) *StatusError {
+	serr := &StatusError{StatusCode: resp.StatusCode}
+	var body struct {
+		Error string `json:"error"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&body) == nil {
+		serr.Message = body.Error
+	}
+	return serr
+}
+
+// getContext fetches route into a T.
+func getContext[T any](ctx context.Context, c *Client, route string, query url.Values) (T, error) {
+	var out T
+	if err := c.do(ctx, route, query, &out); err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
+// get fetches route into a T, returning the zero T if the request fails.
+func get[T any](c *Client, route string, query url.Values) T {
+	out, err := getContext[T](context.Background(), c, route, query)
+	if err != nil {
+		c.logger.Warn("org data request failed", "route", route, "error", err)
+	}
+	return out
+}
+
+// listContext fetches a JSON array, returning an empty slice rather than
+// nil for a null one.
+func listContext[T any](ctx context.Context, c *Client, route string, query url.Values) ([]T, error) {
+	out, err := getContext[[]T](ctx, c, route, query)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return []T{}, nil
+	}
+	return out, nil
+}
+
+// list fetches a JSON array, returning an empty slice if the request fails.
+func list[T any](c *Client, route string, query url.Values) []T {
+	out, err := listContext[T](context.Background(), c, route, query)
+	if err != nil {
+		c.logger.Warn("org data request failed", "route", route, "error", err)
+		return []T{}
+	}
+	return out
+}
+
+// lookupContext fetches a single entity, returning nil and no error if the
+// server has none.
+func lookupContext[T any](ctx context.Context, c *Client, route string) (*T, error) {
+	out := new(T)
+	err := c.do(ctx, route, nil, out)
+	if err == nil {
+		return out, nil
+	}
+	var serr *StatusError
+	if errors.As(err, &serr) && serr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	return nil, err
+}
+
+// lookup fetches a single entity, returning nil if the server has none or
+// the request fails. A 404 is an ordinary miss and is not logged.
+func lookup[T any](c *Client, route string) *T {
+	out, err := lookupContext[T](context.Background(), c, route)
+	if err != nil {
+		c.logger.Warn("org data request failed", "route", route, "error", err)
+	}
+	return out
+}
+
+// edges fetches a graph query, returning nil if the server has no such node
+// or the request fails.
+func edges(c *Client, route string, query url.Values) []orgdatacore.GraphEdge {
+	out := []orgdatacore.GraphEdge{}
+	err := c.do(context.Background(), route, query, &out)
+	if err == nil {
+		return out
+	}
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusNotFound {
+		c.logger.Warn("org data request failed", "route", route, "error", err)
+	}
+	return nil
+}
+
+func (c *Client) memberContext(ctx context.Context, route string, bySlackID bool) (bool, error) {
+	var query url.Values
+	if bySlackID {
+		query = url.Values{"slack_id": {"true"}}
+	}
+	out, err := getContext[struct {
+		Member bool `json:"member"`
+	}](ctx, c, route, query)
+	return out.Member, err
+}
+
+func (c *Client) member(route string, bySlackID bool) bool {
+	in, err := c.memberContext(context.Background(), route, bySlackID)
+	if err != nil {
+		c.logger.Warn("org data request failed", "route", route, "error", err)
+	}
+	return in
+}
+
+func (c *Client) GetEmployeeByUID(uid string) *orgdatacore.Employee {
+	return lookup[orgdatacore.Employee](c, path("employees", uid))
+}
+
+func (c *Client) GetEmployeeBySlackID(slackID string) *orgdatacore.Employee {
+	return lookup[orgdatacore.Employee](c, path("slack-users", slackID))
+}
+
+func (c *Client) GetEmployeeByGitHubID(githubID string) *orgdatacore.Employee {
+	return lookup[orgdatacore.Employee](c, path("github-users", githubID))
+}
+
+func (c *Client) GetEmployeeByEmail(email string) *orgdatacore.Employee {
+	return lookup[orgdatacore.Employee](c, path("emails", email))
+}
+
+func (c *Client) GetManagerForEmployee(uid string) *orgdatacore.Employee {
+	return lookup[orgdatacore.Employee](c, path("employees", uid, "manager"))
+}
+
+func (c *Client) GetTeamByName(teamName string) *orgdatacore.Team {
+	return lookup[orgdatacore.Team](c, path("teams", teamName))
+}
+
+func (c *Client) GetTeamsBySlackChannel(channel string) []orgdatacore.Team {
+	return list[orgdatacore.Team](c, path("slack-channels", channel, "teams"), nil)
+}
+
+func (c *Client) GetOrgByName(orgName string) *orgdatacore.Org {
+	return lookup[orgdatacore.Org](c, path("orgs", orgName))
+}
+
+func (c *Client) GetPillarByName(pillarName string) *orgdatacore.Pillar {
+	return lookup[orgdatacore.Pillar](c, path("pillars", pillarName))
+}
+
+func (c *Client) GetTeamGroupByName(teamGroupName string) *orgdatacore.TeamGroup {
+	return lookup[orgdatacore.TeamGroup](c, path("team-groups", teamGroupName))
+}
+
+func (c *Client) GetUserMemberships(uid string) []orgdatacore.MembershipInfo {
+	return list[orgdatacore.MembershipInfo](c, path("employees", uid, "memberships"), nil)
+}
+
+func (c *Client) GetUserTeams(uid string) []string {
+	return list[string](c, path("employees", uid, "user-teams"), nil)
+}
+
+func (c *Client) GetTeamsForUID(uid string) []string {
+	return list[string](c, path("employees", uid, "teams"), nil)
+}
+
+func (c *Client) GetTeamsForSlackID(slackID string) []string {
+	return list[string](c, path("slack-users", slackID, "teams"), nil)
+}
+
+func (c *Client) GetTeamMembers(teamName string) []orgdatacore.Employee {
+	return list[orgdatacore.Employee](c, path("teams", teamName, "members"), nil)
+}
+
+func (c *Client) GetOrgMembers(orgName string) []orgdatacore.Employee {
+	return list[orgdatacore.Employee](c, path("orgs", orgName, "members"), nil)
+}
+
+func (c *Client) IsEmployeeInTeam(uid string, teamName string) bool {
+	return c.member(path("teams", teamName, "members", uid), false)
+}
+
+func (c *Client) IsSlackUserInTeam(slackID string, teamName string) bool {
+	return c.member(path("teams", teamName, "members", slackID), true)
+}
+
+// AreEmployeesInTeam sends one request per employee.
+func (c *Client) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	out := make(map[string]bool, len(uids))
+	for _, uid := range uids {
+		out[uid] = c.IsEmployeeInTeam(uid, teamName)
+	}
+	return out
+}
+
+// IsEmployeeInAnyTeam sends one request per team, until one has the employee.
+func (c *Client) IsEmployeeInAnyTeam(uid string, teams []string) bool {
+	for _, team := range teams {
+		if c.IsEmployeeInTeam(uid, team) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) IsEmployeeInOrg(uid string, orgName string) bool {
+	return c.member(path("orgs", orgName, "members", uid), false)
+}
+
+func (c *Client) IsSlackUserInOrg(slackID string, orgName string) bool {
+	return c.member(path("orgs", orgName, "members", slackID), true)
+}
+
+func (c *Client) GetUserOrganizations(slackUserID string) []orgdatacore.OrgInfo {
+	return list[orgdatacore.OrgInfo](c, path("slack-users", slackUserID, "organizations"), nil)
+}
+
+func (c *Client) GetTeamEscalation(teamName string) []orgdatacore.EscalationContactInfo {
+	return list[orgdatacore.EscalationContactInfo](c, path("teams", teamName, "escalation"), nil)
+}
+
+type versionResponse struct {
+	LoadTime      time.Time         `json:"load_time"`
+	OrgCount      int               `json:"org_count"`
+	EmployeeCount int               `json:"employee_count"`
+	Checksum      string            `json:"checksum"`
+	ConfigMaps    map[string]string `json:"config_maps"`
+	AgeSeconds    float64           `json:"age_seconds"`
+	Stale         bool              `json:"stale"`
+}
+
+func (c *Client) GetVersion() orgdatacore.DataVersion {
+	v := get[versionResponse](c, "/version", nil)
+	return orgdatacore.DataVersion{
+		LoadTime:      v.LoadTime,
+		ConfigMaps:    v.ConfigMaps,
+		OrgCount:      v.OrgCount,
+		EmployeeCount: v.EmployeeCount,
+		Checksum:      v.Checksum,
+	}
+}
+
+func (c *Client) GetDataAge() time.Duration {
+	return time.Duration(get[versionResponse](c, "/version", nil).AgeSeconds * float64(time.Second))
+}
+
+// IsDataStale reports whether the server's data is older than maxAge. A
+// server that cannot be reached counts as stale.
+func (c *Client) IsDataStale(maxAge time.Duration) bool {
+	var v versionResponse
+	if err := c.do(context.Background(), "/version", url.Values{"max_age": {maxAge.String()}}, &v); err != nil {
+		c.logger.Warn("org data request failed", "route", "/version", "error", err)
+		return true
+	}
+	return v.Stale
+}
+
+// LoadFromDataSource fails with ErrReadOnly.
+func (c *Client) LoadFromDataSource(context.Context, orgdatacore.DataSource) error {
+	return ErrReadOnly
+}
+
+// StartDataSourceWatcher fails with ErrReadOnly.
+func (c *Client) StartDataSourceWatcher(context.Context, orgdatacore.DataSource) error {
+	return ErrReadOnly
+}
+
+// StopWatcher does nothing.
+func (c *Client) StopWatcher() {}
+
+func (c *Client) GetAllEmployeeUIDs() []string {
+	return list[string](c, "/names/employees", nil)
+}
+
+func (c *Client) GetAllEmployees() []orgdatacore.Employee {
+	return list[orgdatacore.Employee](c, "/employees", nil)
+}
+
+func (c *Client) GetAllTeamNames() []string {
+	return list[string](c, "/names/teams", nil)
+}
+
+func (c *Client) GetAllTeams() []orgdatacore.Team {
+	return list[orgdatacore.Team](c, "/teams", nil)
+}
+
+func (c *Client) GetAllOrgNames() []string {
+	return list[string](c, "/names/orgs", nil)
+}
+
+func (c *Client) GetAllOrgs() []orgdatacore.Org {
+	return list[orgdatacore.Org](c, "/orgs", nil)
+}
+
+func (c *Client) GetAllPillarNames() []string {
+	return list[string](c, "/names/pillars", nil)
+}
+
+func (c *Client) GetAllPillars() []orgdatacore.Pillar {
+	return list[orgdatacore.Pillar](c, "/pillars", nil)
+}
+
+func (c *Client) GetAllTeamGroupNames() []string {
+	return list[string](c, "/names/team-groups", nil)
+}
+
+func (c *Client) GetAllTeamGroups() []orgdatacore.TeamGroup {
+	return list[orgdatacore.TeamGroup](c, "/team-groups", nil)
+}
+
+func (c *Client) GetHierarchyPath(entityName string, entityType string) []orgdatacore.HierarchyPathEntry {
+	return list[orgdatacore.HierarchyPathEntry](c, path("hierarchy", entityName, "path"), url.Values{"type": {entityType}})
+}
+
+func (c *Client) GetDescendantsTree(entityName string) *orgdatacore.HierarchyNode {
+	return lookup[orgdatacore.HierarchyNode](c, path("hierarchy", entityName))
+}
+
+func (c *Client) FindPath(from, to orgdatacore.GraphNode) []orgdatacore.GraphEdge {
+	return edges(c, path("graph", string(from.Type), from.Name, "path", string(to.Type), to.Name), nil)
+}
+
+// Neighborhood returns nil without a request when hops is less than one.
+func (c *Client) Neighborhood(node orgdatacore.GraphNode, hops int) []orgdatacore.GraphEdge {
+	if hops < 1 {
+		return nil
+	}
+	return edges(c, path("graph", string(node.Type), node.Name, "neighborhood"), url.Values{"hops": {strconv.Itoa(hops)}})
+}
+
+// Search passes the options on as query parameters.
+func (c *Client) Search(query string, opts ...orgdatacore.SearchOption) []orgdatacore.SearchResult {
+	q := orgdatacore.NewSearchOptions(opts...)
+	params := url.Values{"q": {query}, "limit": {strconv.Itoa(q.Limit)}}
+	for _, typ := range q.Types {
+		params.Add("type", string(typ))
+	}
+	if q.Exact {
+		params.Set("exact", "true")
+	}
+	return list[orgdatacore.SearchResult](c, "/search", params)
+}
+
+func (c *Client) GetComponentByName(name string) *orgdatacore.Component {
+	return lookup[orgdatacore.Component](c, path("components", name))
+}
+
+func (c *Client) GetAllComponents() []orgdatacore.Component {
+	return list[orgdatacore.Component](c, "/components", nil)
+}
+
+func (c *Client) GetAllComponentNames() []string {
+	return list[string](c, "/names/components", nil)
+}
+
+func (c *Client) GetTeamsForComponent(componentName string) []orgdatacore.ComponentOwnerInfo {
+	return list[orgdatacore.ComponentOwnerInfo](c, path("components", componentName, "teams"), nil)
+}
+
+func (c *Client) GetComponentsForTeam(teamName string) []orgdatacore.ComponentOwnership {
+	return list[orgdatacore.ComponentOwnership](c, path("teams", teamName, "components"), nil)
+}
+
+func (c *Client) GetJiraProjects() []string {
+	return list[string](c, "/jira/projects", nil)
+}
+
+func (c *Client) GetJiraComponents(project string) []string {
+	return list[string](c, path("jira", "projects", project, "components"), nil)
+}
+
+func (c *Client) GetTeamsByJiraProject(project string) []orgdatacore.JiraOwnerInfo {
+	return list[orgdatacore.JiraOwnerInfo](c, path("jira", "projects", project, "teams"), nil)
+}
+
+func (c *Client) GetTeamsByJiraComponent(project, component string) []orgdatacore.JiraOwnerInfo {
+	return list[orgdatacore.JiraOwnerInfo](c, path("jira", "projects", project, "components", component, "teams"), nil)
+}
+
+func (c *Client) GetJiraOwnershipForTeam(teamName string) []orgdatacore.JiraOwnership {
+	return list[orgdatacore.JiraOwnership](c, path("teams", teamName, "jira"), nil)
+}
+
+func (c *Client) GetContextForTeam(teamName string) []orgdatacore.ContextItemInfo {
+	return c.GetContextForEntity(teamName, "team")
+}
+
+func (c *Client) GetContextForEntity(entityName string, entityType string) []orgdatacore.ContextItemInfo {
+	return list[orgdatacore.ContextItemInfo](c, path("context", entityType, entityName), nil)
+}
+
+func (c *Client) GetContextByType(entityName string, contextType string, entityType string) []orgdatacore.ContextItemInfo {
+	return list[orgdatacore.ContextItemInfo](c, path("context", entityType, entityName), url.Values{"context_type": {contextType}})
+}
+
+func (c *Client) GetAllContextTypesForEntity(entityName string, entityType string) []string {
+	return list[string](c, path("context", entityType, entityName, "types"), nil)
+}
+
+func (c *Client) GetContextTypeDescriptions() map[string]string {
+	if m := get[map[string]string](c, "/context/types", nil); m != nil {
+		return m
+	}
+	return map[string]string{}
+}
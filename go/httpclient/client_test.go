@@ -0,0 +1,219 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/httpserver"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+// newTestClient serves the test data over HTTP and returns a client for it
+// along with the in-process service it wraps.
+func newTestClient(t *testing.T) (*Client, *orgdatacore.Service) {
+	t.Helper()
+	service := orgdatacore.NewService()
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	ts := httptest.NewServer(httpserver.New(service))
+	t.Cleanup(ts.Close)
+	return New(ts.URL).WithHTTPClient(ts.Client()), service
+}
+
+func TestClientMatchesService(t *testing.T) {
+	client, service := newTestClient(t)
+
+	tests := []struct {
+		name string
+		call func(orgdatacore.ServiceInterface) any
+	}{
+		{"employee", func(s orgdatacore.ServiceInterface) any { return s.GetEmployeeByUID("jsmith") }},
+		{"missing employee", func(s orgdatacore.ServiceInterface) any { return s.GetEmployeeByUID("nobody") }},
+		{"slack user", func(s orgdatacore.ServiceInterface) any { return s.GetEmployeeBySlackID("U12345678") }},
+		{"github user", func(s orgdatacore.ServiceInterface) any { return s.GetEmployeeByGitHubID("bobw") }},
+		{"email", func(s orgdatacore.ServiceInterface) any { return s.GetEmployeeByEmail("adoe@example.com") }},
+		{"manager", func(s orgdatacore.ServiceInterface) any { return s.GetManagerForEmployee("jsmith") }},
+		{"team", func(s orgdatacore.ServiceInterface) any { return s.GetTeamByName("platform-team") }},
+		{"missing team", func(s orgdatacore.ServiceInterface) any { return s.GetTeamByName("nope") }},
+		{"org", func(s orgdatacore.ServiceInterface) any { return s.GetOrgByName("test-org") }},
+		{"pillar", func(s orgdatacore.ServiceInterface) any { return s.GetPillarByName("engineering") }},
+		{"team group", func(s orgdatacore.ServiceInterface) any { return s.GetTeamGroupByName("backend-teams") }},
+		{"teams for uid", func(s orgdatacore.ServiceInterface) any { return s.GetTeamsForUID("jsmith") }},
+		{"teams for slack id", func(s orgdatacore.ServiceInterface) any { return s.GetTeamsForSlackID("U98765432") }},
+		{"is member", func(s orgdatacore.ServiceInterface) any { return s.IsEmployeeInTeam("jsmith", "test-team") }},
+		{"is not member", func(s orgdatacore.ServiceInterface) any { return s.IsEmployeeInTeam("bwilson", "test-team") }},
+		{"slack member of org", func(s orgdatacore.ServiceInterface) any { return s.IsSlackUserInOrg("U12345678", "test-org") }},
+		{"escalation", func(s orgdatacore.ServiceInterface) any { return s.GetTeamEscalation("platform-team") }},
+		{"hierarchy path", func(s orgdatacore.ServiceInterface) any { return s.GetHierarchyPath("platform-team", "team") }},
+		{"path", func(s orgdatacore.ServiceInterface) any {
+			return s.FindPath(orgdatacore.GraphNode{Type: orgdatacore.EntityEmployee, Name: "bwilson"}, orgdatacore.GraphNode{Type: orgdatacore.EntityOrg, Name: "platform-org"})
+		}},
+		{"missing path", func(s orgdatacore.ServiceInterface) any {
+			return s.FindPath(orgdatacore.GraphNode{Type: orgdatacore.EntityTeam, Name: "nope"}, orgdatacore.GraphNode{Type: orgdatacore.EntityOrg, Name: "platform-org"})
+		}},
+		{"neighborhood", func(s orgdatacore.ServiceInterface) any {
+			return s.Neighborhood(orgdatacore.GraphNode{Type: orgdatacore.EntityTeam, Name: "test-team"}, 2)
+		}},
+		{"search", func(s orgdatacore.ServiceInterface) any {
+			return s.Search("test", orgdatacore.SearchTypes(orgdatacore.EntityTeam, orgdatacore.EntityOrg), orgdatacore.SearchLimit(0))
+		}},
+		{"exact search", func(s orgdatacore.ServiceInterface) any { return s.Search("plat", orgdatacore.SearchExact()) }},
+		{"jira projects", func(s orgdatacore.ServiceInterface) any { return sorted(s.GetJiraProjects()) }},
+		{"jira owners", func(s orgdatacore.ServiceInterface) any { return s.GetTeamsByJiraComponent("TEST", "Core") }},
+		{"all team names", func(s orgdatacore.ServiceInterface) any { return sorted(s.GetAllTeamNames()) }},
+		{"checksum", func(s orgdatacore.ServiceInterface) any { return s.GetVersion().Checksum }},
+		{"employee count", func(s orgdatacore.ServiceInterface) any { return s.GetVersion().EmployeeCount }},
+		{"stale", func(s orgdatacore.ServiceInterface) any { return s.IsDataStale(time.Hour) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, want := tt.call(client), tt.call(service)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("client = %#v\nservice = %#v", got, want)
+			}
+		})
+	}
+}
+
+// sorted sorts names in place, since the server sorts lists and the service
+// need not.
+func sorted(names []string) []string {
+	slices.Sort(names)
+	return names
+}
+
+func TestClientSortedLists(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	var uids []string
+	for _, emp := range client.GetTeamMembers("test-team") {
+		uids = append(uids, emp.UID)
+	}
+	if strings.Join(uids, ",") != "adoe,jsmith" {
+		t.Errorf("GetTeamMembers = %v, want adoe,jsmith", uids)
+	}
+	if tree := client.GetDescendantsTree("engineering"); tree == nil || len(tree.Children) == 0 {
+		t.Errorf("GetDescendantsTree(engineering) = %+v, want children", tree)
+	}
+	if got := client.GetTeamsForUID("nobody"); got == nil || len(got) != 0 {
+		t.Errorf("GetTeamsForUID(nobody) = %#v, want empty", got)
+	}
+}
+
+func TestClientUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	var logs bytes.Buffer
+	client := New(ts.URL).WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("Ping of a closed server succeeded")
+	}
+	if emp := client.GetEmployeeByUID("jsmith"); emp != nil {
+		t.Errorf("GetEmployeeByUID = %+v, want nil", emp)
+	}
+	if got := client.GetAllTeams(); got == nil || len(got) != 0 {
+		t.Errorf("GetAllTeams = %#v, want empty", got)
+	}
+	if !client.IsDataStale(time.Hour) {
+		t.Error("IsDataStale = false for an unreachable server")
+	}
+	if !strings.Contains(logs.String(), "org data request failed") {
+		t.Errorf("failed requests were not logged: %s", logs.String())
+	}
+}
+
+func TestClientNotFoundIsNotLogged(t *testing.T) {
+	client, _ := newTestClient(t)
+	var logs bytes.Buffer
+	client.WithLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	if emp := client.GetEmployeeByUID("nobody"); emp != nil {
+		t.Errorf("GetEmployeeByUID(nobody) = %+v, want nil", emp)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("a 404 was logged: %s", logs.String())
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	client := New("http://localhost")
+	if err := client.LoadFromDataSource(context.Background(), nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("LoadFromDataSource = %v, want ErrReadOnly", err)
+	}
+	if err := client.StartDataSourceWatcher(context.Background(), nil); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("StartDataSourceWatcher = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"no data loaded"}`))
+	}))
+	defer ts.Close()
+
+	err := New(ts.URL).Ping(context.Background())
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusServiceUnavailable || serr.Message != "no data loaded" {
+		t.Fatalf("Ping = %v, want a 503 StatusError", err)
+	}
+	if want := "httpclient: 503 Service Unavailable: no data loaded"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestClientContextQueries(t *testing.T) {
+	client, service := newTestClient(t)
+	q := orgdatacore.ContextQueries(client)
+	if q != orgdatacore.QueryerWithContext(client) {
+		t.Fatal("ContextQueries wrapped a Client instead of returning it")
+	}
+	ctx := context.Background()
+
+	emp, err := q.GetEmployeeByUIDContext(ctx, "jsmith")
+	if err != nil || !reflect.DeepEqual(emp, service.GetEmployeeByUID("jsmith")) {
+		t.Errorf("GetEmployeeByUIDContext = %+v, %v", emp, err)
+	}
+	if emp, err := q.GetEmployeeByUIDContext(ctx, "nobody"); emp != nil || err != nil {
+		t.Errorf("GetEmployeeByUIDContext(nobody) = %+v, %v, want nil, nil", emp, err)
+	}
+	if in, err := q.IsEmployeeInTeamContext(ctx, "jsmith", "test-team"); !in || err != nil {
+		t.Errorf("IsEmployeeInTeamContext = %v, %v, want true", in, err)
+	}
+	if teams, err := q.GetTeamsForUIDContext(ctx, "nobody"); teams == nil || len(teams) != 0 || err != nil {
+		t.Errorf("GetTeamsForUIDContext(nobody) = %#v, %v, want empty", teams, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := q.GetTeamMembersContext(cancelled, "test-team"); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetTeamMembersContext with a cancelled context = %v, want context.Canceled", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	_, err = New(ts.URL).GetEmployeeByUIDContext(ctx, "jsmith")
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GetEmployeeByUIDContext against a failing server = %v, want a 503 StatusError", err)
+	}
+}
@@ -0,0 +1,95 @@
+package httpclient
+
+import (
+	"context"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func (c *Client) GetEmployeeByUIDContext(ctx context.Context, uid string) (*orgdatacore.Employee, error) {
+	return lookupContext[orgdatacore.Employee](ctx, c, path("employees", uid))
+}
+
+func (c *Client) GetEmployeeBySlackIDContext(ctx context.Context, slackID string) (*orgdatacore.Employee, error) {
+	return lookupContext[orgdatacore.Employee](ctx, c, path("slack-users", slackID))
+}
+
+func (c *Client) GetEmployeeByGitHubIDContext(ctx context.Context, githubID string) (*orgdatacore.Employee, error) {
+	return lookupContext[orgdatacore.Employee](ctx, c, path("github-users", githubID))
+}
+
+func (c *Client) GetEmployeeByEmailContext(ctx context.Context, email string) (*orgdatacore.Employee, error) {
+	return lookupContext[orgdatacore.Employee](ctx, c, path("emails", email))
+}
+
+func (c *Client) GetManagerForEmployeeContext(ctx context.Context, uid string) (*orgdatacore.Employee, error) {
+	return lookupContext[orgdatacore.Employee](ctx, c, path("employees", uid, "manager"))
+}
+
+func (c *Client) GetTeamByNameContext(ctx context.Context, teamName string) (*orgdatacore.Team, error) {
+	return lookupContext[orgdatacore.Team](ctx, c, path("teams", teamName))
+}
+
+func (c *Client) GetTeamsBySlackChannelContext(ctx context.Context, channel string) ([]orgdatacore.Team, error) {
+	return listContext[orgdatacore.Team](ctx, c, path("slack-channels", channel, "teams"), nil)
+}
+
+func (c *Client) GetOrgByNameContext(ctx context.Context, orgName string) (*orgdatacore.Org, error) {
+	return lookupContext[orgdatacore.Org](ctx, c, path("orgs", orgName))
+}
+
+func (c *Client) GetPillarByNameContext(ctx context.Context, pillarName string) (*orgdatacore.Pillar, error) {
+	return lookupContext[orgdatacore.Pillar](ctx, c, path("pillars", pillarName))
+}
+
+func (c *Client) GetTeamGroupByNameContext(ctx context.Context, teamGroupName string) (*orgdatacore.TeamGroup, error) {
+	return lookupContext[orgdatacore.TeamGroup](ctx, c, path("team-groups", teamGroupName))
+}
+
+func (c *Client) GetUserMembershipsContext(ctx context.Context, uid string) ([]orgdatacore.MembershipInfo, error) {
+	return listContext[orgdatacore.MembershipInfo](ctx, c, path("employees", uid, "memberships"), nil)
+}
+
+func (c *Client) GetUserTeamsContext(ctx context.Context, uid string) ([]string, error) {
+	return listContext[string](ctx, c, path("employees", uid, "user-teams"), nil)
+}
+
+func (c *Client) GetTeamsForUIDContext(ctx context.Context, uid string) ([]string, error) {
+	return listContext[string](ctx, c, path("employees", uid, "teams"), nil)
+}
+
+func (c *Client) GetTeamsForSlackIDContext(ctx context.Context, slackID string) ([]string, error) {
+	return listContext[string](ctx, c, path("slack-users", slackID, "teams"), nil)
+}
+
+func (c *Client) GetTeamMembersContext(ctx context.Context, teamName string) ([]orgdatacore.Employee, error) {
+	return listContext[orgdatacore.Employee](ctx, c, path("teams", teamName, "members"), nil)
+}
+
+func (c *Client) GetOrgMembersContext(ctx context.Context, orgName string) ([]orgdatacore.Employee, error) {
+	return listContext[orgdatacore.Employee](ctx, c, path("orgs", orgName, "members"), nil)
+}
+
+func (c *Client) IsEmployeeInTeamContext(ctx context.Context, uid string, teamName string) (bool, error) {
+	return c.memberContext(ctx, path("teams", teamName, "members", uid), false)
+}
+
+func (c *Client) IsSlackUserInTeamContext(ctx context.Context, slackID string, teamName string) (bool, error) {
+	return c.memberContext(ctx, path("teams", teamName, "members", slackID), true)
+}
+
+func (c *Client) IsEmployeeInOrgContext(ctx context.Context, uid string, orgName string) (bool, error) {
+	return c.memberContext(ctx, path("orgs", orgName, "members", uid), false)
+}
+
+func (c *Client) IsSlackUserInOrgContext(ctx context.Context, slackID string, orgName string) (bool, error) {
+	return c.memberContext(ctx, path("orgs", orgName, "members", slackID), true)
+}
+
+func (c *Client) GetUserOrganizationsContext(ctx context.Context, slackUserID string) ([]orgdatacore.OrgInfo, error) {
+	return listContext[orgdatacore.OrgInfo](ctx, c, path("slack-users", slackUserID, "organizations"), nil)
+}
+
+func (c *Client) GetTeamEscalationContext(ctx context.Context, teamName string) ([]orgdatacore.EscalationContactInfo, error) {
+	return listContext[orgdatacore.EscalationContactInfo](ctx, c, path("teams", teamName, "escalation"), nil)
+}
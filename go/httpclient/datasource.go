@@ -0,0 +1,180 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// DataSource loads data from a server running in proxy mode (see
+// httpserver.Server.WithProxy), so services can share one fetch from GCS:
+//
+//	service := orgdatacore.NewService()
+//	source := httpclient.NewDataSource("http://orgdata:8080")
+//	err := service.StartDataSourceWatcher(ctx, source)
+//
+// Watch long-polls the server and reloads as soon as the server has reloaded.
+type DataSource struct {
+	baseURL     string
+	http        *http.Client
+	pollTimeout time.Duration
+	retryDelay  time.Duration
+	logger      *slog.Logger
+
+	mu       sync.Mutex
+	checksum string // of the last dump loaded
+}
+
+var _ orgdatacore.DataSource = (*DataSource)(nil)
+
+// NewDataSource returns a DataSource for the proxy at baseURL, such as
+// "http://orgdata:8080".
+func NewDataSource(baseURL string) *DataSource {
+	return &DataSource{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		http:        http.DefaultClient,
+		pollTimeout: 30 * time.Second,
+		retryDelay:  5 * time.Second,
+		logger:      slog.Default(),
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests,
+// http.DefaultClient by default, and returns d. Its timeout, if any, must be
+// longer than the poll timeout.
+func (d *DataSource) WithHTTPClient(hc *http.Client) *DataSource {
+	if hc != nil {
+		d.http = hc
+	}
+	return d
+}
+
+// WithPollTimeout sets how long the server holds each watch request when
+// nothing changes, 30 seconds by default, and returns d.
+func (d *DataSource) WithPollTimeout(timeout time.Duration) *DataSource {
+	if timeout > 0 {
+		d.pollTimeout = timeout
+	}
+	return d
+}
+
+// WithRetryDelay sets how long Watch waits after a failed request before
+// polling again, 5 seconds by default, and returns d.
+func (d *DataSource) WithRetryDelay(delay time.Duration) *DataSource {
+	if delay > 0 {
+		d.retryDelay = delay
+	}
+	return d
+}
+
+// WithLogger sets the logger used by Watch, slog.Default by default, and
+// returns d.
+func (d *DataSource) WithLogger(logger *slog.Logger) *DataSource {
+	if logger != nil {
+		d.logger = logger
+	}
+	return d
+}
+
+// Load fetches the server's current data dump.
+func (d *DataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+"/dump", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, statusError(resp)
+	}
+	d.mu.Lock()
+	d.checksum = strings.Trim(resp.Header.Get("ETag"), `"`)
+	d.mu.Unlock()
+	return resp.Body, nil
+}
+
+// Watch long-polls the server in the background and calls callback whenever
+// the server's data differs from the dump last loaded. Failed polls are
+// logged and retried. It returns at once; the polling stops when ctx is
+// cancelled.
+func (d *DataSource) Watch(ctx context.Context, callback func() error) error {
+	go func() {
+		for ctx.Err() == nil {
+			d.mu.Lock()
+			since := d.checksum
+			d.mu.Unlock()
+
+			changed, err := d.poll(ctx, since)
+			orgdatacore.ReportWatchCheck(ctx, time.Time{}, err)
+			switch {
+			case ctx.Err() != nil:
+			case err != nil:
+				d.logger.Error("failed to watch proxy", "source", d.String(), "error", err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(d.retryDelay):
+				}
+			case changed:
+				d.logger.Info("proxy data changed, reloading", "source", d.String())
+				if err := callback(); err != nil {
+					d.logger.Error("reload failed", "source", d.String(), "error", err)
+					select {
+					case <-ctx.Done():
+					case <-time.After(d.retryDelay):
+					}
+				}
+			}
+		}
+		d.logger.Debug("proxy watcher stopped", "source", d.String())
+	}()
+
+	d.logger.Info("proxy watcher started", "source", d.String())
+	return nil
+}
+
+// poll makes one long-poll request and reports whether the server's
+// checksum differs from since.
+func (d *DataSource) poll(ctx context.Context, since string) (bool, error) {
+	query := url.Values{"checksum": {since}, "timeout": {d.pollTimeout.String()}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+"/dump/watch?"+query.Encode(), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return false, nil
+	case http.StatusOK:
+		var body struct {
+			Checksum string `json:"checksum"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return false, fmt.Errorf("httpclient: decode /dump/watch: %w", err)
+		}
+		return body.Checksum != since, nil
+	default:
+		return false, statusError(resp)
+	}
+}
+
+func (d *DataSource) String() string { return "proxy:" + d.baseURL }
+
+// Close does nothing; stop watching by cancelling the context passed to
+// Watch.
+func (d *DataSource) Close() error { return nil }
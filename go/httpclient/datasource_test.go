@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/httpserver"
+)
+
+func TestDataSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	upstream := orgdatacore.NewService()
+	if err := upstream.LoadFromDataSource(ctx, orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	ts := httptest.NewServer(httpserver.New(upstream).WithProxy(upstream))
+	t.Cleanup(ts.Close)
+
+	source := NewDataSource(ts.URL).WithHTTPClient(ts.Client()).WithPollTimeout(time.Second).WithRetryDelay(10 * time.Millisecond)
+	downstream := orgdatacore.NewService()
+	if err := downstream.StartDataSourceWatcher(ctx, source); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+	if got, want := downstream.GetVersion().Checksum, dumpChecksum(t, upstream); got != want {
+		t.Errorf("downstream checksum = %s, want the dump's %s", got, want)
+	}
+
+	if err := upstream.ApplyChangeSet(orgdatacore.ChangeSet{
+		DataVersion:       "test-v2",
+		EmployeesUpserted: map[string]orgdatacore.Employee{"newhire": {UID: "newhire", SlackUID: "U444444"}},
+	}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for downstream.GetEmployeeByUID("newhire") == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("downstream did not reload after the upstream change")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got, want := downstream.GetVersion().Checksum, dumpChecksum(t, upstream); got != want {
+		t.Errorf("downstream checksum after reload = %s, want the dump's %s", got, want)
+	}
+}
+
+// dumpChecksum returns the SHA-256 of service's exported dump, which is the
+// checksum a service loading it through the proxy reports.
+func dumpChecksum(t *testing.T, service *orgdatacore.Service) string {
+	t.Helper()
+	dump, _, err := service.ExportDump()
+	if err != nil {
+		t.Fatalf("ExportDump: %v", err)
+	}
+	sum := sha256.Sum256(dump)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDataSourceLoadErrors(t *testing.T) {
+	empty := orgdatacore.NewService()
+	ts := httptest.NewServer(httpserver.New(empty).WithProxy(empty))
+	t.Cleanup(ts.Close)
+
+	_, err := NewDataSource(ts.URL).WithHTTPClient(ts.Client()).Load(context.Background())
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Load from a proxy without data = %v, want a 503 StatusError", err)
+	}
+}
@@ -0,0 +1,124 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// Long polls on /dump/watch answer after defaultWatchTimeout unless the
+// client asks otherwise, and never hold a request longer than maxWatchTimeout.
+const (
+	defaultWatchTimeout = 30 * time.Second
+	maxWatchTimeout     = 5 * time.Minute
+)
+
+// proxy serves a service's data dump to other services.
+type proxy struct {
+	svc *orgdatacore.Service
+
+	mu       sync.Mutex
+	checksum string // of dump
+	dump     []byte
+	changed  chan struct{} // closed and replaced on every reload
+}
+
+// WithProxy makes s also serve svc's loaded data as a DataSource for other
+// services, so a fleet of bots shares one fetch from GCS instead of each
+// making its own. httpclient.DataSource is the client side. Two more routes
+// are served, neither part of openapi.yaml:
+//
+//	/dump                       the loaded data dump; ETag is its checksum and If-None-Match is honored
+//	/dump/watch?checksum=...    long poll answering {"checksum": ...} once the loaded checksum differs,
+//	                            or 204 after ?timeout (30s by default, at most 5m)
+//
+// The encoded dump is cached until the next reload. svc must be the service s
+// answers queries from, or one loading the same data.
+func (s *Server) WithProxy(svc *orgdatacore.Service) *Server {
+	p := &proxy{svc: svc, changed: make(chan struct{})}
+	svc.OnReload(func(_, _ orgdatacore.DataVersion) {
+		p.mu.Lock()
+		close(p.changed)
+		p.changed = make(chan struct{})
+		p.mu.Unlock()
+	})
+
+	s.mux.HandleFunc("GET /dump", func(w http.ResponseWriter, r *http.Request) {
+		dump, checksum, err := p.load()
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		etag := `"` + checksum + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(dump)
+	})
+	s.mux.HandleFunc("GET /dump/watch", func(w http.ResponseWriter, r *http.Request) {
+		timeout := defaultWatchTimeout
+		if raw := r.URL.Query().Get("timeout"); raw != "" {
+			d, err := time.ParseDuration(raw)
+			if err != nil || d <= 0 {
+				s.writeError(w, badRequest(fmt.Sprintf("invalid timeout %q", raw)))
+				return
+			}
+			timeout = min(d, maxWatchTimeout)
+		}
+		since := r.URL.Query().Get("checksum")
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		for {
+			// Take the channel before reading the checksum, so a reload in
+			// between is not missed.
+			p.mu.Lock()
+			changed := p.changed
+			p.mu.Unlock()
+			if current := svc.GetVersion().Checksum; current != "" && current != since {
+				s.writeJSON(w, http.StatusOK, map[string]string{"checksum": current})
+				return
+			}
+			select {
+			case <-changed:
+			case <-timer.C:
+				w.WriteHeader(http.StatusNoContent)
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	return s
+}
+
+// load returns the encoded dump of the loaded data and its checksum,
+// encoding it only when the data changed since the last call.
+func (p *proxy) load() ([]byte, string, error) {
+	current := p.svc.GetVersion().Checksum
+	p.mu.Lock()
+	if p.dump != nil && p.checksum == current {
+		dump := p.dump
+		p.mu.Unlock()
+		return dump, current, nil
+	}
+	p.mu.Unlock()
+
+	dump, version, err := p.svc.ExportDump()
+	if errors.Is(err, orgdatacore.ErrNoData) {
+		return nil, "", &httpError{status: http.StatusServiceUnavailable, msg: "no data loaded"}
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	p.mu.Lock()
+	p.dump, p.checksum = dump, version.Checksum
+	p.mu.Unlock()
+	return dump, version.Checksum, nil
+}
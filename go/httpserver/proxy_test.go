@@ -0,0 +1,87 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestProxy(t *testing.T) {
+	empty := New(orgdatacore.NewService())
+	empty.WithProxy(orgdatacore.NewService())
+	rec := httptest.NewRecorder()
+	empty.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dump", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("GET /dump without data = %d, want 503", rec.Code)
+	}
+
+	server := newTestServer(t)
+	service := server.svc.(*orgdatacore.Service)
+	server.WithProxy(service)
+	checksum := service.GetVersion().Checksum
+
+	tests := []struct {
+		name        string
+		path        string
+		ifNoneMatch string
+		status      int
+		want        string // substring of the body
+	}{
+		{"dump", "/dump", "", 200, `"full_name":"John Smith"`},
+		{"dump not modified", "/dump", `"` + checksum + `"`, 304, ""},
+		{"dump modified", "/dump", `"stale"`, 200, `"metadata"`},
+		{"watch with old checksum", "/dump/watch?checksum=old", "", 200, `{"checksum":"` + checksum + `"}`},
+		{"watch times out", "/dump/watch?timeout=10ms&checksum=" + checksum, "", 204, ""},
+		{"watch bad timeout", "/dump/watch?timeout=soon", "", 400, `invalid timeout`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Errorf("GET %s = %d, want %d: %s", tt.path, rec.Code, tt.status, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Errorf("GET %s body = %.200s, want it to contain %s", tt.path, rec.Body, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxyWatchWakesOnReload(t *testing.T) {
+	server := newTestServer(t)
+	service := server.svc.(*orgdatacore.Service)
+	server.WithProxy(service)
+	checksum := service.GetVersion().Checksum
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dump/watch?timeout=1m&checksum="+checksum, nil))
+		done <- rec
+	}()
+	time.Sleep(20 * time.Millisecond)
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	select {
+	case rec := <-done:
+		want := `{"checksum":"` + service.GetVersion().Checksum + `"}`
+		if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != want {
+			t.Errorf("watch after reload = %d %s, want 200 %s", rec.Code, rec.Body, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not return after reload")
+	}
+}
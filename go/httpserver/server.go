@@ -0,0 +1,416 @@
+// Package httpserver exposes an orgdatacore.ServiceInterface as a read-only
+// REST API, so cyborg-data can be deployed as a sidecar or shared service
+// without every team writing its own handlers:
+//
+//	service := orgdatacore.NewService()
+//	// load data and start a watcher ...
+//	http.ListenAndServe(":8080", httpserver.New(service))
+//
+// Responses are JSON, using the field names of the data dump, which are the
+// ones the Python library uses. Lists are sorted by the render package, as in
+// the parity tests: by UID or name, except escalation contacts, which are in
+// priority order. A lookup that finds nothing responds 404 with a JSON error.
+//
+// The routes, all GET:
+//
+//	/version                                  loaded data version; ?max_age=1h adds "stale"
+//	/names/{kind}                             names of employees (UIDs), teams, orgs, pillars, team-groups or components
+//	/employees                                all employees
+//	/employees/{uid}                          one employee
+//	/employees/{uid}/manager                  the employee's manager
+//	/employees/{uid}/memberships              teams and orgs the employee belongs to
+//	/employees/{uid}/teams                    team names from the membership index
+//	/employees/{uid}/user-teams               team names from the membership index, as /teams
+//	/slack-users/{slack_id}                   employee by Slack ID
+//	/slack-users/{slack_id}/teams             team names
+//	/slack-users/{slack_id}/organizations     orgs, pillars and team groups
+//	/github-users/{github_id}                 employee by GitHub ID
+//	/emails/{email}                           employee by email
+//	/slack-channels/{channel}/teams           teams using a Slack channel
+//	/teams, /orgs, /pillars, /team-groups     all entities of a type
+//	/teams/{name}, /orgs/{name}, ...          one entity
+//	/teams/{name}/members                     team members
+//	/teams/{name}/members/{uid}               {"member": bool}; ?slack_id=true matches by Slack ID
+//	/teams/{name}/escalation                  escalation contacts
+//	/teams/{name}/components                  owned components
+//	/teams/{name}/jira                        owned Jira projects and components
+//	/orgs/{name}/members                      org members
+//	/orgs/{name}/members/{uid}                {"member": bool}; ?slack_id=true matches by Slack ID
+//	/hierarchy/{name}                         descendants tree
+//	/hierarchy/{name}/path?type=team          path to the root
+//	/graph/{type}/{name}/neighborhood         edges within ?hops=1 of an employee, team, org, pillar or team_group
+//	/graph/{type}/{name}/path/{to_type}/{to_name}  shortest chain of edges between two nodes
+//	/search?q=plat                            employees and groups matching a query, best first;
+//	                                          ?type=team (repeatable), ?limit=10 (0 for all), ?exact=true
+//	/components                               all components
+//	/components/{name}                        one component
+//	/components/{name}/teams                  owning teams
+//	/jira/projects                            Jira project keys
+//	/jira/projects/{project}/components       components of a project
+//	/jira/projects/{project}/teams            owners of a project
+//	/jira/projects/{project}/components/{component}/teams  owners of a component
+//	/context/types                            context type descriptions
+//	/context/{type}/{name}                    context items; ?context_type=runbook filters
+//	/context/{type}/{name}/types              context types present
+//	/openapi.yaml                             the OpenAPI description of these routes
+//
+// The routes are described by openapi.yaml, which is also exported as
+// OpenAPISpec. The httpclient package is a typed client for them.
+//
+// Loading and watching are left to the embedding program. WithProxy adds
+// routes serving the raw dump, so other services can load from this one.
+package httpserver
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/render"
+)
+
+// OpenAPISpec is the OpenAPI 3 description of the API, in YAML.
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
+
+// Server serves the REST API for one service.
+type Server struct {
+	svc    orgdatacore.ServiceInterface
+	mux    *http.ServeMux
+	logger *slog.Logger
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// New returns a Server answering queries from svc.
+func New(svc orgdatacore.ServiceInterface) *Server {
+	s := &Server{svc: svc, mux: http.NewServeMux(), logger: slog.Default()}
+	s.routes()
+	return s
+}
+
+// WithLogger sets the logger used to report failed responses, slog.Default
+// by default, and returns s.
+func (s *Server) WithLogger(logger *slog.Logger) *Server {
+	if logger != nil {
+		s.logger = logger
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	svc := s.svc
+	handle := func(pattern string, fn func(r *http.Request) (any, error)) {
+		s.mux.HandleFunc("GET "+pattern, func(w http.ResponseWriter, r *http.Request) {
+			v, err := fn(r)
+			if err != nil {
+				s.writeError(w, err)
+				return
+			}
+			s.writeJSON(w, http.StatusOK, v)
+		})
+	}
+
+	s.mux.HandleFunc("GET /openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(OpenAPISpec)
+	})
+
+	handle("/version", s.version)
+	handle("/names/{kind}", s.names)
+
+	handle("/employees", func(*http.Request) (any, error) {
+		return svc.GetAllEmployees(), nil
+	})
+	handle("/employees/{uid}", func(r *http.Request) (any, error) {
+		return found("employee", r.PathValue("uid"), svc.GetEmployeeByUID(r.PathValue("uid")))
+	})
+	handle("/employees/{uid}/manager", func(r *http.Request) (any, error) {
+		return found("manager of employee", r.PathValue("uid"), svc.GetManagerForEmployee(r.PathValue("uid")))
+	})
+	handle("/employees/{uid}/memberships", func(r *http.Request) (any, error) {
+		return svc.GetUserMemberships(r.PathValue("uid")), nil
+	})
+	handle("/employees/{uid}/teams", func(r *http.Request) (any, error) {
+		return svc.GetTeamsForUID(r.PathValue("uid")), nil
+	})
+	handle("/employees/{uid}/user-teams", func(r *http.Request) (any, error) {
+		return svc.GetUserTeams(r.PathValue("uid")), nil
+	})
+	handle("/slack-users/{slack_id}", func(r *http.Request) (any, error) {
+		return found("employee with Slack ID", r.PathValue("slack_id"), svc.GetEmployeeBySlackID(r.PathValue("slack_id")))
+	})
+	handle("/slack-users/{slack_id}/teams", func(r *http.Request) (any, error) {
+		return svc.GetTeamsForSlackID(r.PathValue("slack_id")), nil
+	})
+	handle("/slack-users/{slack_id}/organizations", func(r *http.Request) (any, error) {
+		return svc.GetUserOrganizations(r.PathValue("slack_id")), nil
+	})
+	handle("/github-users/{github_id}", func(r *http.Request) (any, error) {
+		return found("employee with GitHub ID", r.PathValue("github_id"), svc.GetEmployeeByGitHubID(r.PathValue("github_id")))
+	})
+	handle("/emails/{email}", func(r *http.Request) (any, error) {
+		return found("employee with email", r.PathValue("email"), svc.GetEmployeeByEmail(r.PathValue("email")))
+	})
+	handle("/slack-channels/{channel}/teams", func(r *http.Request) (any, error) {
+		return svc.GetTeamsBySlackChannel(r.PathValue("channel")), nil
+	})
+
+	handle("/teams", func(*http.Request) (any, error) {
+		return svc.GetAllTeams(), nil
+	})
+	handle("/teams/{name}", func(r *http.Request) (any, error) {
+		return found("team", r.PathValue("name"), svc.GetTeamByName(r.PathValue("name")))
+	})
+	handle("/teams/{name}/members", func(r *http.Request) (any, error) {
+		return svc.GetTeamMembers(r.PathValue("name")), nil
+	})
+	handle("/teams/{name}/members/{uid}", func(r *http.Request) (any, error) {
+		name, id := r.PathValue("name"), r.PathValue("uid")
+		if r.URL.Query().Get("slack_id") == "true" {
+			return membership(svc.IsSlackUserInTeam(id, name)), nil
+		}
+		return membership(svc.IsEmployeeInTeam(id, name)), nil
+	})
+	handle("/teams/{name}/escalation", func(r *http.Request) (any, error) {
+		return svc.GetTeamEscalation(r.PathValue("name")), nil
+	})
+	handle("/teams/{name}/components", func(r *http.Request) (any, error) {
+		return svc.GetComponentsForTeam(r.PathValue("name")), nil
+	})
+	handle("/teams/{name}/jira", func(r *http.Request) (any, error) {
+		return svc.GetJiraOwnershipForTeam(r.PathValue("name")), nil
+	})
+
+	handle("/orgs", func(*http.Request) (any, error) {
+		return svc.GetAllOrgs(), nil
+	})
+	handle("/orgs/{name}", func(r *http.Request) (any, error) {
+		return found("org", r.PathValue("name"), svc.GetOrgByName(r.PathValue("name")))
+	})
+	handle("/orgs/{name}/members", func(r *http.Request) (any, error) {
+		return svc.GetOrgMembers(r.PathValue("name")), nil
+	})
+	handle("/orgs/{name}/members/{uid}", func(r *http.Request) (any, error) {
+		name, id := r.PathValue("name"), r.PathValue("uid")
+		if r.URL.Query().Get("slack_id") == "true" {
+			return membership(svc.IsSlackUserInOrg(id, name)), nil
+		}
+		return membership(svc.IsEmployeeInOrg(id, name)), nil
+	})
+	handle("/pillars", func(*http.Request) (any, error) {
+		return svc.GetAllPillars(), nil
+	})
+	handle("/pillars/{name}", func(r *http.Request) (any, error) {
+		return found("pillar", r.PathValue("name"), svc.GetPillarByName(r.PathValue("name")))
+	})
+	handle("/team-groups", func(*http.Request) (any, error) {
+		return svc.GetAllTeamGroups(), nil
+	})
+	handle("/team-groups/{name}", func(r *http.Request) (any, error) {
+		return found("team group", r.PathValue("name"), svc.GetTeamGroupByName(r.PathValue("name")))
+	})
+
+	handle("/hierarchy/{name}", func(r *http.Request) (any, error) {
+		return found("entity", r.PathValue("name"), svc.GetDescendantsTree(r.PathValue("name")))
+	})
+	handle("/hierarchy/{name}/path", func(r *http.Request) (any, error) {
+		typ := r.URL.Query().Get("type")
+		if typ == "" {
+			return nil, badRequest("missing type parameter")
+		}
+		return svc.GetHierarchyPath(r.PathValue("name"), typ), nil
+	})
+
+	handle("/graph/{type}/{name}/neighborhood", s.neighborhood)
+	handle("/graph/{type}/{name}/path/{to_type}/{to_name}", func(r *http.Request) (any, error) {
+		from := graphNode(r.PathValue("type"), r.PathValue("name"))
+		to := graphNode(r.PathValue("to_type"), r.PathValue("to_name"))
+		path := svc.FindPath(from, to)
+		if path == nil {
+			return nil, &httpError{status: http.StatusNotFound, msg: fmt.Sprintf("no path from %s %q to %s %q", from.Type, from.Name, to.Type, to.Name)}
+		}
+		return path, nil
+	})
+
+	handle("/search", s.search)
+
+	handle("/components", func(*http.Request) (any, error) {
+		return svc.GetAllComponents(), nil
+	})
+	handle("/components/{name}", func(r *http.Request) (any, error) {
+		return found("component", r.PathValue("name"), svc.GetComponentByName(r.PathValue("name")))
+	})
+	handle("/components/{name}/teams", func(r *http.Request) (any, error) {
+		return svc.GetTeamsForComponent(r.PathValue("name")), nil
+	})
+
+	handle("/jira/projects", func(*http.Request) (any, error) {
+		return svc.GetJiraProjects(), nil
+	})
+	handle("/jira/projects/{project}/components", func(r *http.Request) (any, error) {
+		return svc.GetJiraComponents(r.PathValue("project")), nil
+	})
+	handle("/jira/projects/{project}/teams", func(r *http.Request) (any, error) {
+		return svc.GetTeamsByJiraProject(r.PathValue("project")), nil
+	})
+	handle("/jira/projects/{project}/components/{component}/teams", func(r *http.Request) (any, error) {
+		return svc.GetTeamsByJiraComponent(r.PathValue("project"), r.PathValue("component")), nil
+	})
+
+	handle("/context/types", func(*http.Request) (any, error) {
+		return nonNilMap(svc.GetContextTypeDescriptions()), nil
+	})
+	handle("/context/{type}/{name}", func(r *http.Request) (any, error) {
+		typ, name := r.PathValue("type"), r.PathValue("name")
+		var items []orgdatacore.ContextItemInfo
+		if contextType := r.URL.Query().Get("context_type"); contextType != "" {
+			items = svc.GetContextByType(name, contextType, typ)
+		} else {
+			items = svc.GetContextForEntity(name, typ)
+		}
+		return items, nil
+	})
+	handle("/context/{type}/{name}/types", func(r *http.Request) (any, error) {
+		return svc.GetAllContextTypesForEntity(r.PathValue("name"), r.PathValue("type")), nil
+	})
+}
+
+func (s *Server) version(r *http.Request) (any, error) {
+	v := s.svc.GetVersion()
+	resp := map[string]any{
+		"load_time":      v.LoadTime,
+		"org_count":      v.OrgCount,
+		"employee_count": v.EmployeeCount,
+		"checksum":       v.Checksum,
+		"config_maps":    nonNilMap(v.ConfigMaps),
+		"age_seconds":    s.svc.GetDataAge().Seconds(),
+	}
+	if raw := r.URL.Query().Get("max_age"); raw != "" {
+		maxAge, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("invalid max_age %q", raw))
+		}
+		resp["stale"] = s.svc.IsDataStale(maxAge)
+	}
+	return resp, nil
+}
+
+func (s *Server) names(r *http.Request) (any, error) {
+	var names []string
+	switch kind := r.PathValue("kind"); kind {
+	case "employees":
+		names = s.svc.GetAllEmployeeUIDs()
+	case "teams":
+		names = s.svc.GetAllTeamNames()
+	case "orgs":
+		names = s.svc.GetAllOrgNames()
+	case "pillars":
+		names = s.svc.GetAllPillarNames()
+	case "team-groups":
+		names = s.svc.GetAllTeamGroupNames()
+	case "components":
+		names = s.svc.GetAllComponentNames()
+	default:
+		return nil, &httpError{status: http.StatusNotFound, msg: fmt.Sprintf("unknown kind %q", kind)}
+	}
+	return names, nil
+}
+
+func (s *Server) neighborhood(r *http.Request) (any, error) {
+	hops := 1
+	if raw := r.URL.Query().Get("hops"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			return nil, badRequest(fmt.Sprintf("invalid hops %q", raw))
+		}
+		hops = n
+	}
+	node := graphNode(r.PathValue("type"), r.PathValue("name"))
+	edges := s.svc.Neighborhood(node, hops)
+	if edges == nil {
+		return nil, &httpError{status: http.StatusNotFound, msg: fmt.Sprintf("%s %q not found", node.Type, node.Name)}
+	}
+	return edges, nil
+}
+
+func (s *Server) search(r *http.Request) (any, error) {
+	params := r.URL.Query()
+	var opts []orgdatacore.SearchOption
+	for _, typ := range params["type"] {
+		opts = append(opts, orgdatacore.SearchTypes(orgdatacore.EntityType(typ)))
+	}
+	if raw := params.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, badRequest(fmt.Sprintf("invalid limit %q", raw))
+		}
+		opts = append(opts, orgdatacore.SearchLimit(n))
+	}
+	if params.Get("exact") == "true" {
+		opts = append(opts, orgdatacore.SearchExact())
+	}
+	return s.svc.Search(params.Get("q"), opts...), nil
+}
+
+func graphNode(typ, name string) orgdatacore.GraphNode {
+	return orgdatacore.GraphNode{Type: orgdatacore.EntityType(typ), Name: name}
+}
+
+// httpError is an error response with its status code.
+type httpError struct {
+	status int
+	msg    string
+}
+
+func (e *httpError) Error() string { return e.msg }
+
+func badRequest(msg string) error {
+	return &httpError{status: http.StatusBadRequest, msg: msg}
+}
+
+// found returns v, or a 404 error naming what was looked up if v is nil.
+func found[T any](what, key string, v *T) (any, error) {
+	if v == nil {
+		return nil, &httpError{status: http.StatusNotFound, msg: fmt.Sprintf("%s %q not found", what, key)}
+	}
+	return v, nil
+}
+
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if herr, ok := err.(*httpError); ok {
+		status = herr.status
+	}
+	s.writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(render.Sorted(v)); err != nil {
+		s.logger.Warn("failed to write response", "error", err)
+	}
+}
+
+func membership(member bool) map[string]bool {
+	return map[string]bool{"member": member}
+}
+
+func nonNilMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return map[K]V{}
+	}
+	return m
+}
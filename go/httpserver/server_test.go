@@ -0,0 +1,144 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	service := orgdatacore.NewService()
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	return New(service)
+}
+
+func TestServer(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		want   string // substring of the compact JSON body
+	}{
+		{"employee", "GET", "/employees/jsmith", 200, `"full_name":"John Smith"`},
+		{"missing employee", "GET", "/employees/nobody", 404, `{"error":"employee \"nobody\" not found"}`},
+		{"manager", "GET", "/employees/jsmith/manager", 200, `"uid":"adoe"`},
+		{"teams for uid", "GET", "/employees/jsmith/teams", 200, `["test-team"]`},
+		{"no teams", "GET", "/employees/nobody/teams", 200, `[]`},
+		{"slack user", "GET", "/slack-users/U12345678", 200, `"uid":"jsmith"`},
+		{"github user", "GET", "/github-users/bobw", 200, `"uid":"bwilson"`},
+		{"email", "GET", "/emails/adoe@example.com", 200, `"uid":"adoe"`},
+		{"team", "GET", "/teams/test-team", 200, `"name":"test-team"`},
+		{"team members sorted", "GET", "/teams/test-team/members", 200, `[{"uid":"adoe"`},
+		{"is member", "GET", "/teams/test-team/members/jsmith", 200, `{"member":true}`},
+		{"is slack member", "GET", "/teams/test-team/members/U98765432?slack_id=true", 200, `{"member":false}`},
+		{"orgs", "GET", "/orgs", 200, `"name":"platform-org"`},
+		{"names", "GET", "/names/teams", 200, `["platform-team","test-team"]`},
+		{"unknown names", "GET", "/names/widgets", 404, `unknown kind`},
+		{"path", "GET", "/hierarchy/test-team/path?type=team", 200, `[{"name":"test-team","type":"team"}`},
+		{"path without type", "GET", "/hierarchy/test-team/path", 400, `missing type parameter`},
+		{"tree", "GET", "/hierarchy/engineering", 200, `"children":[`},
+		{"neighborhood", "GET", "/graph/team/test-team/neighborhood", 200, `{"from":{"type":"employee","name":"adoe"},"to":{"type":"team","name":"test-team"},"kind":"member_of"}`},
+		{"neighborhood hops", "GET", "/graph/team/test-team/neighborhood?hops=0", 400, `invalid hops`},
+		{"neighborhood missing", "GET", "/graph/team/nope/neighborhood", 404, `not found`},
+		{"path", "GET", "/graph/team/test-team/path/team/test-team", 200, `[]`},
+		{"search", "GET", "/search?q=platform&type=team", 200, `[{"type":"team","name":"platform-team"`},
+		{"search nothing", "GET", "/search?q=", 200, `[]`},
+		{"search limit", "GET", "/search?q=platform&limit=x", 400, `invalid limit`},
+		{"no path", "GET", "/graph/team/test-team/path/team/nope", 404, `no path`},
+		{"jira projects", "GET", "/jira/projects", 200, `["PLAT","TEST"]`},
+		{"jira component owners", "GET", "/jira/projects/TEST/components/Core/teams", 200, `[{"name":"test-team","type":"team"}]`},
+		{"version", "GET", "/version?max_age=1h", 200, `"stale":false`},
+		{"bad max age", "GET", "/version?max_age=soon", 400, `invalid max_age`},
+		{"read only", "POST", "/employees/jsmith", 405, ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.status {
+				t.Fatalf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.status, rec.Body)
+			}
+			if tt.want == "" {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			if body := strings.TrimSpace(rec.Body.String()); !strings.Contains(body, tt.want) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerEmployeeJSON(t *testing.T) {
+	server := newTestServer(t)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/employees", nil))
+
+	var employees []orgdatacore.Employee
+	if err := json.NewDecoder(rec.Body).Decode(&employees); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	var uids []string
+	for _, emp := range employees {
+		uids = append(uids, emp.UID)
+	}
+	if strings.Join(uids, ",") != "adoe,bwilson,jsmith" {
+		t.Errorf("employees = %v, want all three sorted by UID", uids)
+	}
+}
+
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	source, err := os.ReadFile("server.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var routes []string
+	for _, m := range regexp.MustCompile(`handle\("([^"]+)"`).FindAllStringSubmatch(string(source), -1) {
+		routes = append(routes, m[1])
+	}
+	// Path keys are the only lines indented by exactly two spaces under
+	// "paths:".
+	var paths []string
+	inPaths := false
+	for _, line := range strings.Split(string(OpenAPISpec), "\n") {
+		switch {
+		case line == "paths:":
+			inPaths = true
+		case !strings.HasPrefix(line, " "):
+			inPaths = false
+		case inPaths && strings.HasPrefix(line, "  /"):
+			paths = append(paths, strings.TrimSuffix(strings.TrimSpace(line), ":"))
+		}
+	}
+	slices.Sort(routes)
+	slices.Sort(paths)
+	if !slices.Equal(routes, paths) {
+		t.Errorf("openapi.yaml paths = %v\nserver routes = %v", paths, routes)
+	}
+
+	rec := httptest.NewRecorder()
+	newTestServer(t).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Body.String(), "openapi: 3.") {
+		t.Errorf("GET /openapi.yaml = %d: %.40s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,107 @@
+package orgdatacore
+
+// UID, SlackID and GitHubID are the keys employees are looked up by. The
+// query methods of ServiceInterface take them as plain strings, so nothing
+// stops a Slack ID from being passed to GetEmployeeByUID, which then finds
+// no one. The functions taking an EmployeeID make the kind of key part of
+// the call instead:
+//
+//	emp := orgdatacore.EmployeeByID(svc, orgdatacore.SlackID(event.User))
+//	ok := orgdatacore.IsInTeam(svc, orgdatacore.SlackID(event.User), "platform-team")
+//
+// The string methods remain, and convert with string(id).
+type (
+	UID      string
+	SlackID  string
+	GitHubID string
+)
+
+func (u UID) String() string      { return string(u) }
+func (s SlackID) String() string  { return string(s) }
+func (g GitHubID) String() string { return string(g) }
+
+// EmployeeID is a UID, SlackID or GitHubID.
+type EmployeeID interface {
+	String() string
+
+	employee(s ServiceInterface) *Employee
+	teams(s ServiceInterface) []string
+	inTeam(s ServiceInterface, teamName string) bool
+	inOrg(s ServiceInterface, orgName string) bool
+}
+
+// EmployeeByID returns the employee id identifies, or nil.
+func EmployeeByID(s ServiceInterface, id EmployeeID) *Employee {
+	if id == nil {
+		return nil
+	}
+	return id.employee(s)
+}
+
+// TeamsForID returns the names of the teams the employee id identifies is
+// on.
+func TeamsForID(s ServiceInterface, id EmployeeID) []string {
+	if id == nil {
+		return []string{}
+	}
+	return id.teams(s)
+}
+
+// IsInTeam reports whether the employee id identifies is on teamName.
+func IsInTeam(s ServiceInterface, id EmployeeID, teamName string) bool {
+	return id != nil && id.inTeam(s, teamName)
+}
+
+// IsInOrg reports whether the employee id identifies is in orgName,
+// directly or through a team.
+func IsInOrg(s ServiceInterface, id EmployeeID, orgName string) bool {
+	return id != nil && id.inOrg(s, orgName)
+}
+
+func (u UID) employee(s ServiceInterface) *Employee { return s.GetEmployeeByUID(string(u)) }
+func (u UID) teams(s ServiceInterface) []string     { return s.GetTeamsForUID(string(u)) }
+func (u UID) inTeam(s ServiceInterface, teamName string) bool {
+	return s.IsEmployeeInTeam(string(u), teamName)
+}
+func (u UID) inOrg(s ServiceInterface, orgName string) bool {
+	return s.IsEmployeeInOrg(string(u), orgName)
+}
+
+func (id SlackID) employee(s ServiceInterface) *Employee { return s.GetEmployeeBySlackID(string(id)) }
+func (id SlackID) teams(s ServiceInterface) []string     { return s.GetTeamsForSlackID(string(id)) }
+func (id SlackID) inTeam(s ServiceInterface, teamName string) bool {
+	return s.IsSlackUserInTeam(string(id), teamName)
+}
+func (id SlackID) inOrg(s ServiceInterface, orgName string) bool {
+	return s.IsSlackUserInOrg(string(id), orgName)
+}
+
+// The service has no membership queries by GitHub ID, so GitHubID resolves
+// the employee's UID first.
+
+func (g GitHubID) employee(s ServiceInterface) *Employee { return s.GetEmployeeByGitHubID(string(g)) }
+
+func (g GitHubID) uid(s ServiceInterface) (UID, bool) {
+	emp := s.GetEmployeeByGitHubID(string(g))
+	if emp == nil {
+		return "", false
+	}
+	return UID(emp.UID), true
+}
+
+func (g GitHubID) teams(s ServiceInterface) []string {
+	if uid, ok := g.uid(s); ok {
+		return uid.teams(s)
+	}
+	return []string{}
+}
+
+func (g GitHubID) inTeam(s ServiceInterface, teamName string) bool {
+	uid, ok := g.uid(s)
+	return ok && uid.inTeam(s, teamName)
+}
+
+func (g GitHubID) inOrg(s ServiceInterface, orgName string) bool {
+	uid, ok := g.uid(s)
+	return ok && uid.inOrg(s, orgName)
+}
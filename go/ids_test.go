@@ -0,0 +1,40 @@
+package orgdatacore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmployeeIDs(t *testing.T) {
+	service := setupTestService(t)
+
+	for _, id := range []EmployeeID{UID("jsmith"), SlackID("U12345678"), GitHubID("jsmith-dev")} {
+		t.Run(id.String(), func(t *testing.T) {
+			if emp := EmployeeByID(service, id); emp == nil || emp.UID != "jsmith" {
+				t.Errorf("EmployeeByID = %+v, want jsmith", emp)
+			}
+			if got, want := TeamsForID(service, id), service.GetTeamsForUID("jsmith"); !reflect.DeepEqual(got, want) {
+				t.Errorf("TeamsForID = %v, want %v", got, want)
+			}
+			if !IsInTeam(service, id, "test-team") {
+				t.Error("IsInTeam(test-team) = false")
+			}
+			if !IsInOrg(service, id, "test-org") {
+				t.Error("IsInOrg(test-org) = false")
+			}
+		})
+	}
+
+	// The bug class the types exist for: a Slack ID used as a UID.
+	if EmployeeByID(service, UID("U12345678")) != nil {
+		t.Error("a Slack ID found an employee as a UID")
+	}
+	for _, id := range []EmployeeID{GitHubID("nobody"), nil} {
+		if EmployeeByID(service, id) != nil || IsInTeam(service, id, "test-team") || IsInOrg(service, id, "test-org") {
+			t.Errorf("%v matched an employee", id)
+		}
+		if got := TeamsForID(service, id); got == nil || len(got) != 0 {
+			t.Errorf("TeamsForID(%v) = %#v, want empty", id, got)
+		}
+	}
+}
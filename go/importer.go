@@ -0,0 +1,473 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-eng/cyborg-data/go/internal/yaml"
+)
+
+// importEntity is one org, pillar, team group or team in an import's YAML
+// file. Parent names an entity of any kind; names are unique across kinds.
+type importEntity struct {
+	Name        string                  `json:"name"`
+	UID         string                  `json:"uid"`
+	Description string                  `json:"description"`
+	Parent      string                  `json:"parent"`
+	Members     []string                `json:"members"`
+	Slack       *SlackConfig            `json:"slack"`
+	Roles       []RoleInfo              `json:"roles"`
+	Jiras       []JiraInfo              `json:"jiras"`
+	Repos       []RepoInfo              `json:"repos"`
+	Keywords    []string                `json:"keywords"`
+	Emails      []EmailInfo             `json:"emails"`
+	Escalation  []EscalationContactInfo `json:"escalation"`
+	Context     []ContextItemInfo       `json:"context"`
+}
+
+// importFile is the YAML file of an import.
+type importFile struct {
+	DataVersion string         `json:"data_version"`
+	Orgs        []importEntity `json:"orgs"`
+	Pillars     []importEntity `json:"pillars"`
+	TeamGroups  []importEntity `json:"team_groups"`
+	Teams       []importEntity `json:"teams"`
+}
+
+// importColumns sets the Employee field for each CSV column.
+var importColumns = map[string]func(e *Employee, v string) error{
+	"uid":         func(e *Employee, v string) error { e.UID = v; return nil },
+	"full_name":   func(e *Employee, v string) error { e.FullName = v; return nil },
+	"email":       func(e *Employee, v string) error { e.Email = v; return nil },
+	"job_title":   func(e *Employee, v string) error { e.JobTitle = v; return nil },
+	"slack_uid":   func(e *Employee, v string) error { e.SlackUID = v; return nil },
+	"github_id":   func(e *Employee, v string) error { e.GitHubID = v; return nil },
+	"rhat_geo":    func(e *Employee, v string) error { e.RhatGeo = v; return nil },
+	"manager_uid": func(e *Employee, v string) error { e.ManagerUID = v; return nil },
+	"timezone":    func(e *Employee, v string) error { e.Timezone = v; return nil },
+	"cost_center": func(e *Employee, v string) (err error) {
+		if v != "" {
+			e.CostCenter, err = strconv.Atoi(v)
+		}
+		return err
+	},
+	"is_people_manager": func(e *Employee, v string) (err error) {
+		if v != "" {
+			e.IsPeopleManager, err = strconv.ParseBool(v)
+		}
+		return err
+	},
+}
+
+// ImportData builds a dataset from simpler inputs than the full data dump,
+// for small orgs that do not run the indexing pipeline: a CSV of employees
+// and a YAML file describing orgs, pillars, team groups and teams.
+//
+// The CSV has a header row naming Employee JSON fields (uid, full_name,
+// email, job_title, slack_uid, github_id, rhat_geo, cost_center,
+// manager_uid, is_people_manager, timezone); uid is required. Without an
+// is_people_manager column, employees named as someone's manager are marked
+// as people managers. The YAML file lists entities under orgs, pillars,
+// team_groups and teams:
+//
+//	teams:
+//	  - name: platform-team
+//	    parent: backend-teams
+//	    members: [bwilson]
+//	    slack: {channels: [{channel: "#platform", types: [main]}]}
+//	    roles: [{people: [bwilson], roles: [tech_lead]}]
+//	    jiras: [{project: PLAT, component: Infrastructure}]
+//
+// Each entity has a name, and optionally a uid (the name by default), a
+// description, a parent naming another entity, members (employee UIDs), and
+// slack, roles, jiras, repos, keywords, emails, escalation and context as in
+// a group of the data dump. An org, pillar or team group has the members of
+// everything below it, and the membership, Slack, GitHub and Jira indexes are
+// built as the pipeline would. An optional top-level data_version sets the
+// version; it defaults to a hash of the inputs.
+//
+// Malformed input, unknown fields, duplicate names, unknown parents or
+// members and parent cycles fail with an error wrapping ErrInvalidImport.
+func ImportData(employees, teams io.Reader) (*Data, error) {
+	csvBytes, err := io.ReadAll(employees)
+	if err != nil {
+		return nil, err
+	}
+	yamlBytes, err := io.ReadAll(teams)
+	if err != nil {
+		return nil, err
+	}
+
+	emps, err := importEmployees(csvBytes)
+	if err != nil {
+		return nil, err
+	}
+	var file importFile
+	raw, err := yaml.ToJSON(yamlBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: teams file: %v", ErrInvalidImport, err)
+	}
+
+	data := &Data{
+		Lookups: Lookups{
+			Employees:  emps,
+			Teams:      map[string]Team{},
+			Orgs:       map[string]Org{},
+			Pillars:    map[string]Pillar{},
+			TeamGroups: map[string]TeamGroup{},
+			Components: map[string]Component{},
+		},
+		Indexes: Indexes{
+			Membership:       MembershipIndex{MembershipIndex: map[string][]MembershipInfo{}},
+			SlackIDMappings:  SlackIDMappings{SlackUIDToUID: map[string]string{}},
+			GitHubIDMappings: GitHubIDMappings{GitHubIDToUID: map[string]string{}},
+			Jira:             JiraIndex{},
+		},
+	}
+	if err := importGroups(data, &file); err != nil {
+		return nil, err
+	}
+	for uid, emp := range emps {
+		if emp.SlackUID != "" {
+			data.Indexes.SlackIDMappings.SlackUIDToUID[emp.SlackUID] = uid
+		}
+		if emp.GitHubID != "" {
+			data.Indexes.GitHubIDMappings.GitHubIDToUID[emp.GitHubID] = uid
+		}
+	}
+
+	version := file.DataVersion
+	if version == "" {
+		h := sha256.New()
+		h.Write(csvBytes)
+		h.Write(yamlBytes)
+		version = "import-" + hex.EncodeToString(h.Sum(nil)[:6])
+	}
+	data.Metadata = Metadata{
+		GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+		DataVersion:    version,
+		TotalEmployees: len(data.Lookups.Employees),
+		TotalOrgs:      len(data.Lookups.Orgs),
+		TotalTeams:     len(data.Lookups.Teams),
+	}
+	return data, nil
+}
+
+func importEmployees(src []byte) (map[string]Employee, error) {
+	r := csv.NewReader(bytes.NewReader(src))
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: employees CSV header: %v", ErrInvalidImport, err)
+	}
+	setters := make([]func(*Employee, string) error, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		header[i] = name
+		if setters[i] = importColumns[name]; setters[i] == nil {
+			return nil, fmt.Errorf("%w: employees CSV: unknown column %q", ErrInvalidImport, name)
+		}
+	}
+	if !slices.Contains(header, "uid") {
+		return nil, fmt.Errorf("%w: employees CSV: no uid column", ErrInvalidImport)
+	}
+
+	emps := map[string]Employee{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: employees CSV: %v", ErrInvalidImport, err)
+		}
+		line, _ := r.FieldPos(0)
+		var emp Employee
+		for i, v := range record {
+			if err := setters[i](&emp, strings.TrimSpace(v)); err != nil {
+				return nil, fmt.Errorf("%w: employees CSV line %d: %s: %v", ErrInvalidImport, line, header[i], err)
+			}
+		}
+		if emp.UID == "" {
+			return nil, fmt.Errorf("%w: employees CSV line %d: empty uid", ErrInvalidImport, line)
+		}
+		if _, dup := emps[emp.UID]; dup {
+			return nil, fmt.Errorf("%w: employees CSV line %d: duplicate uid %q", ErrInvalidImport, line, emp.UID)
+		}
+		emps[emp.UID] = emp
+	}
+
+	if !slices.Contains(header, "is_people_manager") {
+		for _, emp := range emps {
+			if mgr, ok := emps[emp.ManagerUID]; ok && !mgr.IsPeopleManager {
+				mgr.IsPeopleManager = true
+				emps[mgr.UID] = mgr
+			}
+		}
+	}
+	return emps, nil
+}
+
+// importGroups fills in the entities of file and the indexes derived from
+// them.
+func importGroups(data *Data, file *importFile) error {
+	type node struct {
+		entity   *importEntity
+		typ      string
+		children []string
+		people   []string
+	}
+	nodes := map[string]*node{}
+	var order []string
+	kinds := []struct {
+		typ      string
+		entities []importEntity
+	}{{"org", file.Orgs}, {"pillar", file.Pillars}, {"team_group", file.TeamGroups}, {"team", file.Teams}}
+	for _, kind := range kinds {
+		for i := range kind.entities {
+			e := &kind.entities[i]
+			if e.Name == "" {
+				return fmt.Errorf("%w: %s without a name", ErrInvalidImport, kind.typ)
+			}
+			if prev, dup := nodes[e.Name]; dup {
+				return fmt.Errorf("%w: %q is both a %s and a %s", ErrInvalidImport, e.Name, prev.typ, kind.typ)
+			}
+			for _, uid := range e.Members {
+				if _, ok := data.Lookups.Employees[uid]; !ok {
+					return fmt.Errorf("%w: %s %q: member %q is not in the employees CSV", ErrInvalidImport, kind.typ, e.Name, uid)
+				}
+			}
+			nodes[e.Name] = &node{entity: e, typ: kind.typ}
+			order = append(order, e.Name)
+		}
+	}
+
+	// Link parents, rejecting unknown parents and cycles.
+	for _, name := range order {
+		n := nodes[name]
+		if n.entity.Parent == "" {
+			continue
+		}
+		parent, ok := nodes[n.entity.Parent]
+		if !ok {
+			return fmt.Errorf("%w: %s %q: unknown parent %q", ErrInvalidImport, n.typ, name, n.entity.Parent)
+		}
+		if parent.typ == "team" {
+			return fmt.Errorf("%w: %s %q: parent %q is a team", ErrInvalidImport, n.typ, name, n.entity.Parent)
+		}
+		parent.children = append(parent.children, name)
+		seen := map[string]bool{name: true}
+		for p := n.entity.Parent; p != ""; p = nodes[p].entity.Parent {
+			if seen[p] {
+				return fmt.Errorf("%w: %s %q: parent cycle through %q", ErrInvalidImport, n.typ, name, p)
+			}
+			seen[p] = true
+		}
+	}
+
+	// Everyone in an entity or below it.
+	var people func(n *node) []string
+	people = func(n *node) []string {
+		if n.people != nil {
+			return n.people
+		}
+		uids := slices.Clone(n.entity.Members)
+		for _, child := range n.children {
+			uids = append(uids, people(nodes[child])...)
+		}
+		slices.Sort(uids)
+		n.people = slices.Compact(append([]string{}, uids...))
+		return n.people
+	}
+
+	membership := data.Indexes.Membership.MembershipIndex
+	addMembership := func(uid string, m MembershipInfo) {
+		if !slices.Contains(membership[uid], m) {
+			membership[uid] = append(membership[uid], m)
+		}
+	}
+	for _, name := range order {
+		n := nodes[name]
+		e := n.entity
+		uid := e.UID
+		if uid == "" {
+			uid = e.Name
+		}
+		var parent *ParentInfo
+		if e.Parent != "" {
+			parent = &ParentInfo{Name: e.Parent, Type: nodes[e.Parent].typ}
+		}
+		group := Group{
+			Type:                  GroupType{Name: n.typ},
+			ResolvedPeopleUIDList: people(n),
+			Slack:                 e.Slack,
+			Roles:                 e.Roles,
+			Jiras:                 e.Jiras,
+			Repos:                 e.Repos,
+			Keywords:              e.Keywords,
+			Emails:                e.Emails,
+			Escalation:            e.Escalation,
+			Context:               e.Context,
+		}
+		switch n.typ {
+		case "org":
+			data.Lookups.Orgs[name] = Org{UID: uid, Name: name, Description: e.Description, Type: n.typ, Parent: parent, Group: group}
+		case "pillar":
+			data.Lookups.Pillars[name] = Pillar{UID: uid, Name: name, Description: e.Description, Type: n.typ, Parent: parent, Group: group}
+		case "team_group":
+			data.Lookups.TeamGroups[name] = TeamGroup{UID: uid, Name: name, Description: e.Description, Type: n.typ, Parent: parent, Group: group}
+		case "team":
+			data.Lookups.Teams[name] = Team{UID: uid, Name: name, Description: e.Description, Type: n.typ, Parent: parent, Group: group}
+		}
+
+		// Direct members of a team or org belong to it and to every org
+		// above it, as in the pipeline's membership index.
+		if n.typ == "team" || n.typ == "org" {
+			for _, member := range e.Members {
+				addMembership(member, MembershipInfo{Name: name, Type: n.typ})
+				for p := e.Parent; p != ""; p = nodes[p].entity.Parent {
+					if nodes[p].typ == "org" {
+						addMembership(member, MembershipInfo{Name: p, Type: "org"})
+					}
+				}
+			}
+		}
+
+		for _, jira := range e.Jiras {
+			if jira.Project == "" {
+				continue
+			}
+			component := jira.Component
+			if component == "" {
+				component = "_project_level"
+			}
+			if data.Indexes.Jira[jira.Project] == nil {
+				data.Indexes.Jira[jira.Project] = map[string][]JiraOwnerInfo{}
+			}
+			owner := JiraOwnerInfo{Name: name, Type: n.typ}
+			if owners := data.Indexes.Jira[jira.Project][component]; !slices.Contains(owners, owner) {
+				data.Indexes.Jira[jira.Project][component] = append(owners, owner)
+			}
+		}
+	}
+	return nil
+}
+
+// ImportDataSource loads a dataset built by ImportData from a CSV of
+// employees and a YAML file of teams, and reloads when either file changes.
+type ImportDataSource struct {
+	employeesPath string
+	teamsPath     string
+	interval      time.Duration
+	logger        *slog.Logger
+	lastModTime   time.Time
+}
+
+// NewImportDataSource returns a DataSource importing the files at
+// employeesPath and teamsPath. Watch checks their modification times every
+// checkInterval, or every 30 seconds if it is zero.
+func NewImportDataSource(employeesPath, teamsPath string, checkInterval time.Duration) *ImportDataSource {
+	if checkInterval <= 0 {
+		checkInterval = 30 * time.Second
+	}
+	return &ImportDataSource{
+		employeesPath: employeesPath,
+		teamsPath:     teamsPath,
+		interval:      checkInterval,
+		logger:        slog.Default(),
+	}
+}
+
+func (s *ImportDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	employees, err := os.Open(s.employeesPath)
+	if err != nil {
+		return nil, err
+	}
+	defer employees.Close()
+	teams, err := os.Open(s.teamsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer teams.Close()
+
+	data, err := ImportData(employees, teams)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(raw)), nil
+}
+
+func (s *ImportDataSource) Watch(ctx context.Context, callback func() error) error {
+	s.lastModTime, _ = s.modTime()
+	ticker := time.NewTicker(s.interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Debug("import watcher stopped", "source", s.String())
+				return
+			case <-ticker.C:
+				modTime, err := s.modTime()
+				ReportWatchCheck(ctx, time.Now().Add(s.interval), err)
+				if err != nil {
+					s.logger.Error("failed to check import files", "source", s.String(), "error", err)
+					continue
+				}
+				if modTime.After(s.lastModTime) {
+					s.logger.Info("import files updated, reloading", "source", s.String())
+					s.lastModTime = modTime
+					if err := callback(); err != nil {
+						s.logger.Error("reload failed", "source", s.String(), "error", err)
+					}
+				}
+			}
+		}
+	}()
+
+	s.logger.Info("import watcher started", "source", s.String(), "interval", s.interval)
+	return nil
+}
+
+// modTime returns the later modification time of the two files.
+func (s *ImportDataSource) modTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{s.employeesPath, s.teamsPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+func (s *ImportDataSource) String() string {
+	return fmt.Sprintf("import:%s+%s", s.employeesPath, s.teamsPath)
+}
+
+func (s *ImportDataSource) Close() error { return nil }
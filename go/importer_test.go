@@ -0,0 +1,158 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func importTestData(t *testing.T) *Data {
+	t.Helper()
+	employees, err := os.Open(filepath.Join("..", "testdata", "import", "employees.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer employees.Close()
+	teams, err := os.Open(filepath.Join("..", "testdata", "import", "teams.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teams.Close()
+
+	data, err := ImportData(employees, teams)
+	if err != nil {
+		t.Fatalf("ImportData: %v", err)
+	}
+	return data
+}
+
+// TestImportDataMatchesDump imports the test org from CSV and YAML and
+// checks that it answers queries like the full dump it mirrors.
+func TestImportDataMatchesDump(t *testing.T) {
+	data := importTestData(t)
+	if issues := ValidateData(data).Issues; len(issues) != 0 {
+		t.Errorf("ValidateData issues = %+v", issues)
+	}
+	if data.Metadata.DataVersion != "import-test" {
+		t.Errorf("data_version = %q, want import-test", data.Metadata.DataVersion)
+	}
+	if !data.Lookups.Employees["adoe"].IsPeopleManager || data.Lookups.Employees["jsmith"].IsPeopleManager {
+		t.Error("people managers were not derived from manager_uid")
+	}
+
+	imported := NewService()
+	if err := imported.LoadFromDataSource(context.Background(), jsonSource(t, "import", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	dump := setupTestService(t)
+
+	sorted := func(s []string) []string { slices.Sort(s); return s }
+	tests := []struct {
+		name string
+		call func(s *Service) any
+	}{
+		{"employee", func(s *Service) any { return s.GetEmployeeBySlackID("U12345678") }},
+		{"github", func(s *Service) any { return s.GetEmployeeByGitHubID("bobw") }},
+		{"manager", func(s *Service) any { return s.GetManagerForEmployee("jsmith") }},
+		{"memberships", func(s *Service) any { return s.GetUserMemberships("bwilson") }},
+		{"teams for uid", func(s *Service) any { return sorted(s.GetTeamsForUID("jsmith")) }},
+		{"in org", func(s *Service) any { return s.IsEmployeeInOrg("bwilson", "test-org") }},
+		{"org members", func(s *Service) any { return sorted(s.GetAllEmployeeUIDs()) }},
+		{"team", func(s *Service) any {
+			t := s.GetTeamByName("test-team")
+			return []any{t.UID, t.Parent, t.Group.Slack, t.Group.Roles}
+		}},
+		{"hierarchy path", func(s *Service) any { return s.GetHierarchyPath("platform-team", "team") }},
+		{"descendants", func(s *Service) any { return s.GetDescendantsTree("test-org") }},
+		{"jira component", func(s *Service) any { return s.GetTeamsByJiraComponent("PLAT", "Infrastructure") }},
+		{"jira project", func(s *Service) any { return s.GetTeamsByJiraComponent("TEST", "_project_level") }},
+		{"pillar people", func(s *Service) any { return s.GetPillarByName("engineering").Group.ResolvedPeopleUIDList }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := tt.call(imported), tt.call(dump); !reflect.DeepEqual(got, want) {
+				t.Errorf("imported = %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestImportDataErrors(t *testing.T) {
+	const header = "uid,full_name\n"
+	tests := []struct {
+		name  string
+		csv   string
+		teams string
+		want  string
+	}{
+		{"unknown column", "uid,salary\n", "", `unknown column "salary"`},
+		{"no uid column", "full_name\n", "", "no uid column"},
+		{"empty uid", header + ",Nobody\n", "", "line 2: empty uid"},
+		{"duplicate uid", header + "a,A\na,B\n", "", `line 3: duplicate uid "a"`},
+		{"bad cost center", "uid,cost_center\na,x\n", "", "line 2: cost_center"},
+		{"bad yaml", header, "teams: [a\n", "unterminated flow collection"},
+		{"unknown field", header, "teams:\n  - name: a\n    memebers: [a]\n", `unknown field "memebers"`},
+		{"unnamed", header, "teams:\n  - parent: x\n", "team without a name"},
+		{"duplicate name", header, "orgs: [{name: a}]\nteams: [{name: a}]\n", `"a" is both a org and a team`},
+		{"unknown member", header, "teams: [{name: a, members: [ghost]}]\n", `member "ghost" is not in the employees CSV`},
+		{"unknown parent", header, "teams: [{name: a, parent: nowhere}]\n", `unknown parent "nowhere"`},
+		{"team parent", header, "teams: [{name: a}, {name: b, parent: a}]\n", `parent "a" is a team`},
+		{"cycle", header, "orgs: [{name: a, parent: b}, {name: b, parent: a}]\n", "parent cycle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportData(strings.NewReader(tt.csv), strings.NewReader(tt.teams))
+			if !errors.Is(err, ErrInvalidImport) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ImportData error = %v, want ErrInvalidImport containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportDataSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	employees := filepath.Join(dir, "employees.csv")
+	teams := filepath.Join(dir, "teams.yaml")
+	write := func(path, content string, mtime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	start := time.Now().Add(-time.Hour)
+	write(employees, "uid,slack_uid\na,U1\n", start)
+	write(teams, "teams: [{name: t, members: [a]}]\n", start)
+
+	service := NewService()
+	source := NewImportDataSource(employees, teams, 10*time.Millisecond)
+	if err := service.StartDataSourceWatcher(ctx, source); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+	if !service.IsSlackUserInTeam("U1", "t") {
+		t.Error("imported member is not in the team")
+	}
+
+	write(employees, "uid,slack_uid\na,U1\nb,U2\n", start.Add(time.Minute))
+	write(teams, "teams: [{name: t, members: [a, b]}]\n", start.Add(time.Minute))
+	deadline := time.Now().Add(5 * time.Second)
+	for !service.IsSlackUserInTeam("U2", "t") {
+		if time.Now().After(deadline) {
+			t.Fatal("service did not reload after the import files changed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
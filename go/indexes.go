@@ -0,0 +1,235 @@
+package orgdatacore
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// derivedIndexes holds secondary indexes computed from a loaded Data snapshot.
+// Each index is built on first use behind its own sync.Once, so callers that
+// only do UID lookups never pay for indexes they don't query.
+//
+// A derivedIndexes value is bound to exactly one *Data and is discarded when
+// new data is loaded.
+type derivedIndexes struct {
+	data *Data
+
+	emailOnce sync.Once
+	email     map[string]string // lowercased email -> uid
+
+	slackChannelOnce sync.Once
+	slackChannel     map[string][]string // normalized channel -> team names
+
+	managerOnce sync.Once
+	manager     map[string][]string // manager uid -> direct report uids
+
+	repoOnce sync.Once
+	repo     map[string][]string // normalized repo -> team names
+
+	graphOnce sync.Once
+	graph     map[GraphNode][]GraphEdge // node -> edges touching it; see FindPath
+
+	searchOnce sync.Once
+	search     *searchIndex // see Search
+
+	normalizedOnce sync.Once
+	normalized     map[string]map[string]string // kind -> normalized name -> name; see WithLookupNormalization
+
+	checksum string // see DataVersion.Checksum
+}
+
+func newDerivedIndexes(data *Data) *derivedIndexes {
+	return &derivedIndexes{data: data}
+}
+
+// derived returns the secondary indexes for the currently loaded data,
+// creating an empty (lazy) set if none exists yet.
+// Must be called with s.mu held.
+func (s *Service) derived() *derivedIndexes {
+	current := s.indexes.Load()
+	if current != nil && current.data == s.data {
+		return current
+	}
+	fresh := newDerivedIndexes(s.data)
+	if s.indexes.CompareAndSwap(current, fresh) {
+		return fresh
+	}
+	return s.indexes.Load()
+}
+
+func (d *derivedIndexes) emailIndex() map[string]string {
+	d.emailOnce.Do(func() {
+		d.email = make(map[string]string)
+		if d.data == nil {
+			return
+		}
+		for uid, emp := range d.data.Lookups.Employees {
+			if emp.Email == "" {
+				continue
+			}
+			key := strings.ToLower(emp.Email)
+			if _, exists := d.email[key]; !exists {
+				d.email[key] = uid
+			}
+		}
+	})
+	return d.email
+}
+
+// normalizedIndex maps the normalized names of teams, orgs, pillars and
+// team groups, and the normalized lowercased emails of employees, to the
+// names and UIDs they were normalized from. The service's normalization
+// does not change, so it is built once per dataset.
+func (d *derivedIndexes) normalizedIndex(n Normalization) map[string]map[string]string {
+	d.normalizedOnce.Do(func() {
+		d.normalized = make(map[string]map[string]string)
+		if d.data == nil {
+			return
+		}
+		add := func(kind, key, name string) {
+			key = n.Normalize(key)
+			if d.normalized[kind] == nil {
+				d.normalized[kind] = make(map[string]string)
+			}
+			if prev, exists := d.normalized[kind][key]; !exists || name < prev {
+				d.normalized[kind][key] = name
+			}
+		}
+		lookups := &d.data.Lookups
+		for name := range lookups.Teams {
+			add("team", name, name)
+		}
+		for name := range lookups.Orgs {
+			add("org", name, name)
+		}
+		for name := range lookups.Pillars {
+			add("pillar", name, name)
+		}
+		for name := range lookups.TeamGroups {
+			add("team_group", name, name)
+		}
+		for uid, emp := range lookups.Employees {
+			if emp.Email != "" {
+				add("email", strings.ToLower(emp.Email), uid)
+			}
+		}
+	})
+	return d.normalized
+}
+
+func (d *derivedIndexes) slackChannelIndex() map[string][]string {
+	d.slackChannelOnce.Do(func() {
+		d.slackChannel = make(map[string][]string)
+		if d.data == nil {
+			return
+		}
+		for _, team := range d.data.Lookups.Teams {
+			if team.Group.Slack == nil {
+				continue
+			}
+			for _, ch := range team.Group.Slack.Channels {
+				if ch.Channel != "" {
+					normalized := normalizeSlackChannel(ch.Channel)
+					d.slackChannel[normalized] = append(d.slackChannel[normalized], team.Name)
+				}
+			}
+		}
+	})
+	return d.slackChannel
+}
+
+func (d *derivedIndexes) managerIndex() map[string][]string {
+	d.managerOnce.Do(func() {
+		d.manager = make(map[string][]string)
+		if d.data == nil {
+			return
+		}
+		for uid, emp := range d.data.Lookups.Employees {
+			if emp.ManagerUID != "" {
+				d.manager[emp.ManagerUID] = append(d.manager[emp.ManagerUID], uid)
+			}
+		}
+	})
+	return d.manager
+}
+
+func (d *derivedIndexes) repoIndex() map[string][]string {
+	d.repoOnce.Do(func() {
+		d.repo = make(map[string][]string)
+		if d.data == nil {
+			return
+		}
+		for name, team := range d.data.Lookups.Teams {
+			seen := make(map[string]bool)
+			for _, r := range team.Group.Repos {
+				key := normalizeRepo(r.Repo)
+				if key == "" || seen[key] {
+					continue
+				}
+				seen[key] = true
+				d.repo[key] = append(d.repo[key], name)
+			}
+		}
+	})
+	return d.repo
+}
+
+// build forces construction of the given index. Unknown kinds are ignored.
+func (d *derivedIndexes) build(kind IndexKind) {
+	switch kind {
+	case IndexEmail:
+		d.emailIndex()
+	case IndexSlackChannel:
+		d.slackChannelIndex()
+	case IndexManager:
+		d.managerIndex()
+	case IndexRepo:
+		d.repoIndex()
+	case IndexGraph:
+		d.graphIndex()
+	case IndexSearch:
+		d.searchIndex()
+	}
+}
+
+// buildAll constructs the requested indexes concurrently, running at most
+// GOMAXPROCS builders at once, and returns when all of them are done.
+// Each index is independent and guarded by its own sync.Once, so builders
+// never contend with each other. This uses a plain semaphore rather than
+// errgroup to keep the default build free of non-stdlib dependencies;
+// index construction cannot fail, so there is no error to propagate.
+func (d *derivedIndexes) buildAll(kinds []IndexKind) {
+	if len(kinds) == 0 {
+		return
+	}
+	if len(kinds) == 1 {
+		d.build(kinds[0])
+		return
+	}
+
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for _, kind := range kinds {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(kind IndexKind) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			d.build(kind)
+		}(kind)
+	}
+	wg.Wait()
+}
+
+// normalizeRepo reduces a repository reference to a comparable key, so that
+// "https://github.com/org/repo.git" and "org/repo" resolve to the same entry.
+func normalizeRepo(repo string) string {
+	r := strings.ToLower(strings.TrimSpace(repo))
+	r = strings.TrimPrefix(r, "https://")
+	r = strings.TrimPrefix(r, "http://")
+	r = strings.TrimPrefix(r, "github.com/")
+	r = strings.TrimSuffix(r, "/")
+	r = strings.TrimSuffix(r, ".git")
+	return r
+}
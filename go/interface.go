@@ -24,6 +24,19 @@ type DataSource interface {
 	io.Closer
 }
 
+// DeltaDataSource is an optional extension of DataSource for sources that can
+// serve incremental changes instead of a full dump. When a watched source
+// implements it, reloads are applied as change sets and fall back to a full
+// load only when the source returns ErrFullReloadRequired.
+type DeltaDataSource interface {
+	DataSource
+
+	// LoadChanges returns the changes between sinceVersion (the data_version
+	// currently loaded) and the latest data. It returns ErrFullReloadRequired
+	// when no delta is available from that version.
+	LoadChanges(ctx context.Context, sinceVersion string) (*ChangeSet, error)
+}
+
 type ServiceInterface interface {
 	GetEmployeeByUID(uid string) *Employee
 	GetEmployeeBySlackID(slackID string) *Employee
@@ -44,6 +57,8 @@ type ServiceInterface interface {
 	GetOrgMembers(orgName string) []Employee
 	IsEmployeeInTeam(uid string, teamName string) bool
 	IsSlackUserInTeam(slackID string, teamName string) bool
+	AreEmployeesInTeam(uids []string, teamName string) map[string]bool
+	IsEmployeeInAnyTeam(uid string, teams []string) bool
 
 	IsEmployeeInOrg(uid string, orgName string) bool
 	IsSlackUserInOrg(slackID string, orgName string) bool
@@ -73,6 +88,13 @@ type ServiceInterface interface {
 	GetHierarchyPath(entityName string, entityType string) []HierarchyPathEntry
 	GetDescendantsTree(entityName string) *HierarchyNode
 
+	// Graph queries
+	FindPath(from GraphNode, to GraphNode) []GraphEdge
+	Neighborhood(node GraphNode, hops int) []GraphEdge
+
+	// Search
+	Search(query string, opts ...SearchOption) []SearchResult
+
 	// Component queries
 	GetComponentByName(name string) *Component
 	GetAllComponents() []Component
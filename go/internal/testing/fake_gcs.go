@@ -65,9 +65,13 @@ func (b *FakeBucket) UpdateBlob(name string, content []byte) error {
 	if !ok {
 		return fmt.Errorf("blob %s not found", name)
 	}
-	blob.Content = content
-	blob.Generation++
-	blob.Updated = time.Now()
+	// Replace rather than modify the blob, which readers may hold.
+	b.blobs[name] = &FakeBlob{
+		Name:       name,
+		Content:    content,
+		Generation: blob.Generation + 1,
+		Updated:    time.Now(),
+	}
 	return nil
 }
 
@@ -107,6 +111,16 @@ type FakeGCSDataSource struct {
 	bucket     *FakeBucket
 	objectPath string
 	bucketName string
+
+	mu           sync.Mutex
+	pollInterval time.Duration
+	watch        *fakeWatch
+}
+
+// fakeWatch is a running FakeGCSDataSource.Watch.
+type fakeWatch struct {
+	triggers chan chan error
+	stopped  chan struct{}
 }
 
 // NewFakeGCSDataSource creates a new fake GCS data source.
@@ -130,13 +144,83 @@ func (f *FakeGCSDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
 	return io.NopCloser(bytes.NewReader(blob.Content)), nil
 }
 
-// Watch monitors for changes (simplified for testing - just calls callback once).
+// SetPollInterval makes Watch check the blob's generation every interval and
+// call back when it changed, as the real GCS source does with the object's
+// update time. With the default of zero, Watch only calls back on
+// TriggerChange. Call it before Watch.
+func (f *FakeGCSDataSource) SetPollInterval(interval time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pollInterval = interval
+}
+
+// Watch starts watching in the background and returns, like the real GCS
+// source. The callback runs on each TriggerChange, and when the generation
+// changes if a poll interval is set, until ctx is cancelled.
 func (f *FakeGCSDataSource) Watch(ctx context.Context, callback func() error) error {
-	// In a real implementation, this would poll for changes
-	// For testing, we just return immediately without starting a watcher
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.watch != nil {
+		return fmt.Errorf("%s is already being watched", f)
+	}
+	w := &fakeWatch{triggers: make(chan chan error), stopped: make(chan struct{})}
+	f.watch = w
+	interval := f.pollInterval
+
+	go func() {
+		defer func() {
+			f.mu.Lock()
+			f.watch = nil
+			f.mu.Unlock()
+			close(w.stopped)
+		}()
+
+		var ticks <-chan time.Time
+		if interval > 0 {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			ticks = ticker.C
+		}
+		generation, _ := f.GetGeneration()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case done := <-w.triggers:
+				generation, _ = f.GetGeneration()
+				done <- callback()
+			case <-ticks:
+				if current, err := f.GetGeneration(); err == nil && current != generation {
+					generation = current
+					_ = callback()
+				}
+			}
+		}
+	}()
 	return nil
 }
 
+// TriggerChange makes a running Watch call its callback, as if the object
+// had changed, and returns the callback's error once it has run. It fails if
+// Watch is not running.
+func (f *FakeGCSDataSource) TriggerChange(ctx context.Context) error {
+	f.mu.Lock()
+	w := f.watch
+	f.mu.Unlock()
+	if w == nil {
+		return fmt.Errorf("%s is not being watched", f)
+	}
+	done := make(chan error, 1)
+	select {
+	case w.triggers <- done:
+	case <-w.stopped:
+		return fmt.Errorf("%s is not being watched", f)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-done
+}
+
 // String returns a description of this data source.
 func (f *FakeGCSDataSource) String() string {
 	return fmt.Sprintf("gs://%s/%s (fake)", f.bucketName, f.objectPath)
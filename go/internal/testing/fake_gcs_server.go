@@ -0,0 +1,221 @@
+package testing
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeGCSServer serves the buckets of a FakeGCSClient over the subset of the
+// GCS JSON and XML APIs that the storage SDK uses to read objects, so code
+// built with -tags gcs can run against it without cloud credentials. Writes
+// are not supported.
+//
+// Point the SDK at it either with STORAGE_EMULATOR_HOST set to EmulatorHost,
+// which disables authentication, or with Endpoint and the service account
+// from CredentialsJSON, which makes the SDK sign a JWT and exchange it for
+// an access token at the server, as it would with Google's token endpoint.
+type FakeGCSServer struct {
+	*httptest.Server
+	client *FakeGCSClient
+
+	mu      sync.Mutex
+	key     *rsa.PrivateKey
+	tokens  map[string]bool
+	objects int
+}
+
+// NewFakeGCSServer starts a server for client's buckets. Call Close when
+// done.
+func NewFakeGCSServer(client *FakeGCSClient) *FakeGCSServer {
+	s := &FakeGCSServer{client: client, tokens: make(map[string]bool)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", s.handleToken)
+	mux.HandleFunc("GET /storage/v1/b/{bucket}/o/{object...}", s.handleJSONObject)
+	mux.HandleFunc("GET /download/storage/v1/b/{bucket}/o/{object...}", s.handleJSONObject)
+	mux.HandleFunc("GET /{bucket}/{object...}", s.handleXMLObject)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// EmulatorHost returns the value for STORAGE_EMULATOR_HOST.
+func (s *FakeGCSServer) EmulatorHost() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// Endpoint returns the JSON API endpoint, for option.WithEndpoint.
+func (s *FakeGCSServer) Endpoint() string {
+	return s.URL + "/storage/v1/"
+}
+
+// CredentialsJSON returns a service account key file whose token URI is the
+// server. Once called, object requests must carry an access token issued to
+// that service account.
+func (s *FakeGCSServer) CredentialsJSON() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key == nil {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			panic(fmt.Sprintf("generate service account key: %v", err))
+		}
+		s.key = key
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(s.key),
+	})
+	creds, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "fake-project",
+		"private_key_id": "fake-key",
+		"private_key":    string(keyPEM),
+		"client_email":   "fake@fake-project.iam.gserviceaccount.com",
+		"client_id":      "1",
+		"token_uri":      s.URL + "/token",
+	})
+	return string(creds)
+}
+
+// ObjectRequests returns how many object requests the server has answered.
+func (s *FakeGCSServer) ObjectRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.objects
+}
+
+// handleToken implements the OAuth 2.0 JWT bearer grant used by service
+// accounts, checking the assertion's signature against the key.
+func (s *FakeGCSServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+		writeGCSError(w, http.StatusBadRequest, "unsupported grant_type")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key == nil || !verifyJWT(&s.key.PublicKey, r.FormValue("assertion")) {
+		writeGCSError(w, http.StatusUnauthorized, "invalid assertion")
+		return
+	}
+	token := fmt.Sprintf("fake-token-%d", len(s.tokens)+1)
+	s.tokens[token] = true
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// verifyJWT reports whether assertion is an RS256 JWT signed by key.
+func verifyJWT(key *rsa.PublicKey, assertion string) bool {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+}
+
+// lookup finds the requested blob, writing an error response and returning
+// false when the request is unauthorized or the blob does not exist.
+func (s *FakeGCSServer) lookup(w http.ResponseWriter, r *http.Request) (*FakeBlob, bool) {
+	s.mu.Lock()
+	s.objects++
+	requireAuth := s.key != nil
+	authorized := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	s.mu.Unlock()
+	if requireAuth && !authorized {
+		writeGCSError(w, http.StatusUnauthorized, "missing or invalid access token")
+		return nil, false
+	}
+
+	bucketName, objectName := r.PathValue("bucket"), r.PathValue("object")
+	if unescaped, err := url.PathUnescape(objectName); err == nil {
+		objectName = unescaped
+	}
+	bucket, ok := s.client.GetBucket(bucketName)
+	if !ok {
+		writeGCSError(w, http.StatusNotFound, fmt.Sprintf("bucket %s not found", bucketName))
+		return nil, false
+	}
+	blob, ok := bucket.GetBlob(objectName)
+	if !ok {
+		writeGCSError(w, http.StatusNotFound, fmt.Sprintf("object %s not found", objectName))
+		return nil, false
+	}
+	return blob, true
+}
+
+// handleJSONObject serves object metadata, or the content with alt=media.
+func (s *FakeGCSServer) handleJSONObject(w http.ResponseWriter, r *http.Request) {
+	blob, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	if r.URL.Query().Get("alt") == "media" {
+		writeBlobContent(w, r, blob)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"kind":           "storage#object",
+		"bucket":         r.PathValue("bucket"),
+		"name":           blob.Name,
+		"generation":     strconv.FormatInt(blob.Generation, 10),
+		"metageneration": "1",
+		"contentType":    "application/json",
+		"size":           strconv.Itoa(len(blob.Content)),
+		"updated":        blob.Updated.UTC().Format(time.RFC3339Nano),
+	})
+}
+
+// handleXMLObject serves object content the way the XML API does, which is
+// what the SDK's object readers use by default.
+func (s *FakeGCSServer) handleXMLObject(w http.ResponseWriter, r *http.Request) {
+	blob, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	writeBlobContent(w, r, blob)
+}
+
+func writeBlobContent(w http.ResponseWriter, r *http.Request, blob *FakeBlob) {
+	h := w.Header()
+	h.Set("Content-Type", "application/json")
+	h.Set("Content-Length", strconv.Itoa(len(blob.Content)))
+	h.Set("Last-Modified", blob.Updated.UTC().Format(http.TimeFormat))
+	h.Set("X-Goog-Generation", strconv.FormatInt(blob.Generation, 10))
+	h.Set("X-Goog-Metageneration", "1")
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(blob.Content)
+	}
+}
+
+// writeGCSError writes an error in the JSON API's format.
+func writeGCSError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{"code": code, "message": message},
+	})
+}
@@ -1,12 +1,16 @@
 package testing
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/openshift-eng/cyborg-data/go/internal/yaml"
 )
 
 // FileDataSource loads organizational data from local files.
@@ -17,8 +21,21 @@ type FileDataSource struct {
 	// PollInterval controls how frequently files are checked for changes.
 	// If zero, a default of 60s is used.
 	PollInterval time.Duration
+	// Clock is waited on between polls. If nil, the system clock is used.
+	Clock Clock
+}
+
+// Clock matches orgdatacore.Clock, which this package cannot import.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
 }
 
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
 // NewFileDataSource creates a new file-based data source for testing.
 // INTERNAL USE ONLY: This should only be used in test code.
 // If multiple paths provided, the last one is used (allows for fallback logic)
@@ -28,7 +45,8 @@ func NewFileDataSource(filePaths ...string) *FileDataSource {
 	}
 }
 
-// Load returns a reader for the organizational data file
+// Load returns a reader for the organizational data file. Files ending in
+// .yaml or .yml are converted to JSON.
 func (f *FileDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
 	if len(f.FilePaths) == 0 {
 		return nil, fmt.Errorf("no file paths provided")
@@ -36,6 +54,9 @@ func (f *FileDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
 
 	// Load the primary data file (use the last path if multiple provided)
 	filePath := f.FilePaths[len(f.FilePaths)-1]
+	if ext := strings.ToLower(filepath.Ext(filePath)); ext == ".yaml" || ext == ".yml" {
+		return loadYAML(filePath)
+	}
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
@@ -44,6 +65,19 @@ func (f *FileDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
 	return file, nil
 }
 
+// loadYAML reads a YAML data file as the equivalent JSON document.
+func loadYAML(filePath string) (io.ReadCloser, error) {
+	raw, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	out, err := yaml.ToJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s from YAML: %w", filePath, err)
+	}
+	return io.NopCloser(bytes.NewReader(out)), nil
+}
+
 // Watch monitors for file changes (basic implementation using polling)
 func (f *FileDataSource) Watch(ctx context.Context, callback func() error) error {
 	if len(f.FilePaths) == 0 {
@@ -63,15 +97,17 @@ func (f *FileDataSource) Watch(ctx context.Context, callback func() error) error
 	if interval == 0 {
 		interval = 60 * time.Second
 	}
-	ticker := time.NewTicker(interval)
+	clock := f.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
 
 	go func() {
-		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-ticker.C:
+			case <-clock.After(interval):
 				// Check if any files have changed
 				changed := false
 				for _, path := range f.FilePaths {
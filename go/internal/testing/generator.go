@@ -0,0 +1,289 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// defaultOrgFanout is the number of child orgs under each org in generated
+// hierarchies unless GeneratorConfig.OrgFanout says otherwise.
+const defaultOrgFanout = 4
+
+// defaultJiraProjects is the number of Jira projects generated components
+// are spread over unless GeneratorConfig.JiraProjects says otherwise.
+const defaultJiraProjects = 10
+
+// GeneratorConfig shapes a dataset built by Generate.
+type GeneratorConfig struct {
+	// Employees is the number of employees.
+	Employees int
+	// Teams is the number of teams. If zero, it follows from TeamSize.
+	Teams int
+	// TeamSize is the number of employees per team, used when Teams is
+	// zero; if both are zero teams have 10 employees.
+	TeamSize int
+	// Depth is the number of org levels above the teams, at least 1.
+	Depth int
+	// OrgFanout is the number of child orgs under each org, 4 if zero.
+	OrgFanout int
+	// SlackDensity is the fraction of teams, from 0 to 1, that have a Slack
+	// channel.
+	SlackDensity float64
+	// JiraDensity is the fraction of teams, from 0 to 1, that own a Jira
+	// component.
+	JiraDensity float64
+	// JiraProjects is the number of Jira projects the owned components are
+	// spread over, 10 if zero.
+	JiraProjects int
+}
+
+// The generator emits JSON through these minimal mirrors of the orgdatacore
+// types; importing orgdatacore here would create an import cycle with its tests.
+type genEmployee struct {
+	UID             string `json:"uid"`
+	FullName        string `json:"full_name"`
+	Email           string `json:"email"`
+	JobTitle        string `json:"job_title"`
+	SlackUID        string `json:"slack_uid,omitempty"`
+	GitHubID        string `json:"github_id,omitempty"`
+	ManagerUID      string `json:"manager_uid,omitempty"`
+	IsPeopleManager bool   `json:"is_people_manager,omitempty"`
+}
+
+type genRef struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type genChannel struct {
+	Channel string `json:"channel"`
+}
+
+type genSlack struct {
+	Channels []genChannel `json:"channels,omitempty"`
+}
+
+type genGroupType struct {
+	Name string `json:"name"`
+}
+
+type genJira struct {
+	Project   string `json:"project"`
+	Component string `json:"component"`
+}
+
+type genGroup struct {
+	Type                  genGroupType `json:"type"`
+	ResolvedPeopleUIDList []string     `json:"resolved_people_uid_list"`
+	Slack                 *genSlack    `json:"slack,omitempty"`
+	Jiras                 []genJira    `json:"jiras,omitempty"`
+}
+
+type genEntity struct {
+	UID    string   `json:"uid"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Parent *genRef  `json:"parent,omitempty"`
+	Group  genGroup `json:"group"`
+}
+
+// GenerateDataset builds a synthetic comprehensive-index JSON document for
+// benchmarks and load tests, with nEmployees employees in nTeams teams under
+// depth org levels, every team having a Slack channel. It is Generate with
+// those settings.
+func GenerateDataset(nEmployees, nTeams, depth int) []byte {
+	return Generate(GeneratorConfig{Employees: nEmployees, Teams: nTeams, Depth: depth, SlackDensity: 1})
+}
+
+// Generate builds a synthetic comprehensive-index JSON document shaped by
+// cfg. Output is deterministic for a given cfg.
+//
+// Naming scheme (so callers can query known entities):
+//   - employees: "user0" … "user{n-1}", Slack IDs "U0"…, GitHub IDs "gh-user0"…
+//   - teams: "team-0" … "team-{nTeams-1}", Slack channel "#team-N"
+//   - orgs: "org-L-I" for level L (0 = root) and index I, depth levels in total
+//   - Jira: team N owns component "team-N" of project "PROJ{N%projects}"
+//
+// Employee i belongs to team i%nTeams. The first member of each team is its
+// manager and reports to user0. Teams hang off the deepest org level. Slack
+// channels and Jira components are spread evenly over the teams, so a
+// density of 0.5 gives every other team one.
+func Generate(cfg GeneratorConfig) []byte {
+	nEmployees, nTeams, depth := cfg.Employees, cfg.Teams, cfg.Depth
+	if nTeams == 0 {
+		size := cfg.TeamSize
+		if size < 1 {
+			size = 10
+		}
+		nTeams = (nEmployees + size - 1) / size
+	}
+	if nTeams < 1 {
+		nTeams = 1
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	orgFanout := cfg.OrgFanout
+	if orgFanout < 1 {
+		orgFanout = defaultOrgFanout
+	}
+	jiraProjects := cfg.JiraProjects
+	if jiraProjects < 1 {
+		jiraProjects = defaultJiraProjects
+	}
+
+	// Build org levels; level L has at most orgFanout^L orgs, capped at nTeams.
+	levels := make([][]string, depth)
+	orgs := make(map[string]*genEntity)
+	count := 1
+	for l := 0; l < depth; l++ {
+		levels[l] = make([]string, count)
+		for i := 0; i < count; i++ {
+			name := fmt.Sprintf("org-%d-%d", l, i)
+			org := &genEntity{UID: name, Name: name, Type: "organization"}
+			org.Group.Type.Name = "organization"
+			org.Group.ResolvedPeopleUIDList = []string{}
+			if l > 0 {
+				org.Parent = &genRef{Name: levels[l-1][i/orgFanout], Type: "org"}
+			}
+			levels[l][i] = name
+			orgs[name] = org
+		}
+		count = min(count*orgFanout, nTeams)
+	}
+	leaves := levels[depth-1]
+
+	// ancestors[org] lists the org and every org above it.
+	ancestors := make(map[string][]string, len(orgs))
+	for _, level := range levels {
+		for _, name := range level {
+			chain := []string{name}
+			if p := orgs[name].Parent; p != nil {
+				chain = append(chain, ancestors[p.Name]...)
+			}
+			ancestors[name] = chain
+		}
+	}
+
+	teams := make(map[string]*genEntity, nTeams)
+	teamNames := make([]string, nTeams)
+	jira := make(map[string]map[string][]genRef)
+	for t := 0; t < nTeams; t++ {
+		name := fmt.Sprintf("team-%d", t)
+		team := &genEntity{
+			UID:    fmt.Sprintf("team-uid-%d", t),
+			Name:   name,
+			Type:   "team",
+			Parent: &genRef{Name: leaves[t%len(leaves)], Type: "org"},
+		}
+		team.Group.Type.Name = "team"
+		team.Group.ResolvedPeopleUIDList = []string{}
+		if spread(t, cfg.SlackDensity) {
+			team.Group.Slack = &genSlack{Channels: []genChannel{{Channel: "#" + name}}}
+		}
+		if spread(t, cfg.JiraDensity) {
+			project := fmt.Sprintf("PROJ%d", t%jiraProjects)
+			team.Group.Jiras = []genJira{{Project: project, Component: name}}
+			if jira[project] == nil {
+				jira[project] = make(map[string][]genRef)
+			}
+			jira[project][name] = []genRef{{Name: name, Type: "team"}}
+		}
+		teams[name] = team
+		teamNames[t] = name
+	}
+
+	employees := make(map[string]genEmployee, nEmployees)
+	membership := make(map[string][]genRef, nEmployees)
+	slackIndex := make(map[string]string, nEmployees)
+	githubIndex := make(map[string]string, nEmployees)
+	teamManager := make(map[string]string, nTeams)
+
+	for i := 0; i < nEmployees; i++ {
+		uid := fmt.Sprintf("user%d", i)
+		teamName := teamNames[i%nTeams]
+		team := teams[teamName]
+
+		emp := genEmployee{
+			UID:      uid,
+			FullName: fmt.Sprintf("User %d", i),
+			Email:    uid + "@example.com",
+			JobTitle: "Engineer",
+			SlackUID: fmt.Sprintf("U%d", i),
+			GitHubID: "gh-" + uid,
+		}
+		if mgr, ok := teamManager[teamName]; ok {
+			emp.ManagerUID = mgr
+		} else {
+			teamManager[teamName] = uid
+			emp.IsPeopleManager = true
+			emp.JobTitle = "Manager"
+			if uid != "user0" {
+				emp.ManagerUID = "user0"
+			}
+		}
+		employees[uid] = emp
+		slackIndex[emp.SlackUID] = uid
+		githubIndex[emp.GitHubID] = uid
+
+		team.Group.ResolvedPeopleUIDList = append(team.Group.ResolvedPeopleUIDList, uid)
+		refs := []genRef{{Name: teamName, Type: "team"}}
+		for _, orgName := range ancestors[team.Parent.Name] {
+			org := orgs[orgName]
+			org.Group.ResolvedPeopleUIDList = append(org.Group.ResolvedPeopleUIDList, uid)
+			refs = append(refs, genRef{Name: orgName, Type: "org"})
+		}
+		membership[uid] = refs
+	}
+
+	doc := map[string]any{
+		"metadata": map[string]any{
+			"generated_at":    "2024-01-01T00:00:00Z",
+			"data_version":    dataVersion(cfg, nTeams, depth),
+			"total_employees": nEmployees,
+			"total_orgs":      len(orgs),
+			"total_teams":     nTeams,
+		},
+		"lookups": map[string]any{
+			"employees": employees,
+			"teams":     teams,
+			"orgs":      orgs,
+		},
+		"indexes": map[string]any{
+			"membership":         map[string]any{"membership_index": membership},
+			"slack_id_mappings":  map[string]any{"slack_uid_to_uid": slackIndex},
+			"github_id_mappings": map[string]any{"github_id_to_uid": githubIndex},
+		},
+	}
+	if len(jira) > 0 {
+		doc["indexes"].(map[string]any)["jira"] = jira
+	}
+
+	out, err := json.Marshal(doc)
+	if err != nil {
+		// Only plain maps, slices, and structs are marshaled; this cannot fail.
+		panic(fmt.Sprintf("generate dataset: %v", err))
+	}
+	return out
+}
+
+// spread reports whether item i of a sequence gets a feature given to a
+// density fraction of the items, spacing those items evenly.
+func spread(i int, density float64) bool {
+	if density <= 0 {
+		return false
+	}
+	if density >= 1 {
+		return true
+	}
+	return int(float64(i+1)*density) > int(float64(i)*density)
+}
+
+// dataVersion names the generated dataset after the settings that shaped it.
+func dataVersion(cfg GeneratorConfig, nTeams, depth int) string {
+	v := fmt.Sprintf("synthetic-%d-%d-%d", cfg.Employees, nTeams, depth)
+	if cfg.SlackDensity != 1 || cfg.JiraDensity != 0 {
+		v += fmt.Sprintf("-s%g-j%g", cfg.SlackDensity, cfg.JiraDensity)
+	}
+	return v
+}
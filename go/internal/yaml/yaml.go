@@ -0,0 +1,535 @@
+// Package yaml converts the subset of YAML used for hand-written org data
+// files into JSON, so they can be decoded into the data model with
+// encoding/json and its struct tags. No YAML library is vendored, and the
+// files need little of the language.
+//
+// Supported: block mappings and sequences, including sequences of mappings
+// and sequences at the same indentation as their key; flow sequences and
+// mappings on one line ([a, b], {name: x}); plain, single- and double-quoted
+// scalars; literal (|) and folded (>) block scalars, with the "-" chomping
+// indicator; comments; and a leading "---". Plain scalars are typed as in
+// YAML 1.2: null, ~ and empty values are null, true and false are booleans,
+// and numbers are numbers. Anchors, aliases, tags, multi-document streams
+// and multi-line flow collections are rejected or unsupported.
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ToJSON converts a YAML document to JSON.
+func ToJSON(src []byte) ([]byte, error) {
+	v, err := Decode(src)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Decode parses a YAML document into map[string]any, []any, string, bool,
+// json.Number and nil values.
+func Decode(src []byte) (any, error) {
+	p := &parser{lines: splitLines(string(src))}
+	if !p.skipBlank() {
+		return nil, nil
+	}
+	v, err := p.block(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected indentation")
+	}
+	return v, nil
+}
+
+// line is one non-blank line, without its comment.
+type line struct {
+	num    int // 1-based
+	indent int
+	text   string
+	raw    string // the whole line, for block scalars
+}
+
+func splitLines(src string) []line {
+	var lines []line
+	content := false
+	for i, raw := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		text := strings.TrimRight(stripComment(raw), " \t")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || (!content && trimmed == "---") {
+			// Blank lines still matter inside block scalars, which read
+			// raw lines themselves.
+			lines = append(lines, line{num: i + 1, indent: -1, raw: raw})
+			continue
+		}
+		content = true
+		lines = append(lines, line{num: i + 1, indent: len(text) - len(trimmed), text: trimmed, raw: raw})
+	}
+	return lines
+}
+
+// stripComment removes a "#" comment that is not inside quotes.
+func stripComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote == '\'' && c == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || s[i-1] == ' ' || s[i-1] == '[' || s[i-1] == '{' || s[i-1] == ',' || s[i-1] == ':' || s[i-1] == '-' {
+				quote = c
+			}
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+type parser struct {
+	lines []line
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	num := 0
+	if p.pos < len(p.lines) {
+		num = p.lines[p.pos].num
+	} else if len(p.lines) > 0 {
+		num = p.lines[len(p.lines)-1].num
+	}
+	return fmt.Errorf("yaml: line %d: %s", num, fmt.Sprintf(format, args...))
+}
+
+// skipBlank moves past blank lines and reports whether a line is left.
+func (p *parser) skipBlank() bool {
+	for p.pos < len(p.lines) && p.lines[p.pos].indent < 0 {
+		p.pos++
+	}
+	return p.pos < len(p.lines)
+}
+
+// block parses the mapping or sequence starting at the current line, whose
+// entries are indented by indent.
+func (p *parser) block(indent int) (any, error) {
+	if !p.skipBlank() {
+		return nil, nil
+	}
+	if isSeqItem(p.lines[p.pos].text) {
+		return p.sequence(indent)
+	}
+	if _, _, ok := splitKey(p.lines[p.pos].text); ok {
+		return p.mapping(indent)
+	}
+	// A lone scalar document or value.
+	l := p.lines[p.pos]
+	p.pos++
+	return scalar(l.text)
+}
+
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *parser) sequence(indent int) ([]any, error) {
+	items := []any{}
+	for p.skipBlank() {
+		l := &p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if !isSeqItem(l.text) {
+			break
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(l.text, "-"), " ")
+		if rest == "" {
+			p.pos++
+			v, err := p.nested(indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			continue
+		}
+		// Parse the rest of the line as if it started a block of its own,
+		// so "- key: value" begins a mapping whose later keys line up with
+		// "key".
+		offset := len(l.text) - len(rest)
+		if _, _, ok := splitKey(rest); ok || isSeqItem(rest) {
+			l.indent += offset
+			l.text = rest
+			v, err := p.block(l.indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			continue
+		}
+		p.pos++
+		v, err := scalar(rest)
+		if err != nil {
+			return nil, p.errorAt(l.num, err)
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+func (p *parser) mapping(indent int) (map[string]any, error) {
+	m := map[string]any{}
+	for p.skipBlank() {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if isSeqItem(l.text) {
+			break
+		}
+		key, value, ok := splitKey(l.text)
+		if !ok {
+			return nil, p.errorf("expected \"key: value\", got %q", l.text)
+		}
+		if _, dup := m[key]; dup {
+			return nil, p.errorf("duplicate key %q", key)
+		}
+		p.pos++
+
+		var v any
+		var err error
+		switch {
+		case value == "":
+			// A nested block, or a sequence at the key's own indentation.
+			if p.skipBlank() && p.lines[p.pos].indent == indent && isSeqItem(p.lines[p.pos].text) {
+				v, err = p.sequence(indent)
+			} else {
+				v, err = p.nested(indent)
+			}
+		case value[0] == '|' || value[0] == '>':
+			v, err = p.blockScalar(indent, value)
+		default:
+			v, err = scalar(value)
+			if err != nil {
+				err = p.errorAt(l.num, err)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+	return m, nil
+}
+
+// nested parses the block indented deeper than parent, or returns nil if
+// there is none.
+func (p *parser) nested(parent int) (any, error) {
+	if !p.skipBlank() || p.lines[p.pos].indent <= parent {
+		return nil, nil
+	}
+	return p.block(p.lines[p.pos].indent)
+}
+
+// blockScalar reads the lines of a | or > scalar belonging to a key
+// indented by parent.
+func (p *parser) blockScalar(parent int, header string) (string, error) {
+	if header != "|" && header != "|-" && header != ">" && header != ">-" {
+		return "", p.errorf("unsupported block scalar header %q", header)
+	}
+	indent := -1
+	end := p.pos // after the last line of the scalar
+	for i := p.pos; i < len(p.lines); i++ {
+		// Measure the raw line: "#" starts no comment in a block scalar.
+		raw := p.lines[i].raw
+		trimmed := strings.TrimLeft(raw, " ")
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		ind := len(raw) - len(trimmed)
+		if ind <= parent {
+			break
+		}
+		if indent < 0 {
+			indent = ind
+		}
+		if ind < indent {
+			p.pos = i
+			return "", p.errorf("block scalar line is indented less than its first line")
+		}
+		end = i + 1
+	}
+	var raws []string
+	for i := p.pos; i < end; i++ {
+		raw := p.lines[i].raw
+		if strings.TrimSpace(raw) == "" {
+			raws = append(raws, "")
+		} else {
+			raws = append(raws, strings.TrimRight(raw[indent:], " \t"))
+		}
+	}
+	p.pos = end
+
+	var text string
+	if header[0] == '|' {
+		text = strings.Join(raws, "\n")
+	} else {
+		var b strings.Builder
+		for i, r := range raws {
+			switch {
+			case i == 0:
+			case r == "":
+				b.WriteByte('\n')
+			case raws[i-1] == "":
+			default:
+				b.WriteByte(' ')
+			}
+			b.WriteString(r)
+		}
+		text = b.String()
+	}
+	if !strings.HasSuffix(header, "-") && text != "" {
+		text += "\n"
+	}
+	return text, nil
+}
+
+func (p *parser) errorAt(num int, err error) error {
+	return fmt.Errorf("yaml: line %d: %w", num, err)
+}
+
+// splitKey splits "key: value" or "key:" outside quotes and flow
+// collections.
+func splitKey(text string) (key, value string, ok bool) {
+	if text == "" || text[0] == '[' || text[0] == '{' {
+		return "", "", false
+	}
+	end := 0
+	if text[0] == '"' || text[0] == '\'' {
+		n, err := quotedLen(text)
+		if err != nil {
+			return "", "", false
+		}
+		end = n
+	}
+	for i := end; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			raw := strings.TrimSpace(text[:i])
+			if raw == "" {
+				return "", "", false
+			}
+			k, err := scalar(raw)
+			if err != nil {
+				return "", "", false
+			}
+			return fmt.Sprint(k), strings.TrimSpace(text[i+1:]), true
+		}
+	}
+	return "", "", false
+}
+
+// quotedLen returns the length of the quoted string at the start of s.
+func quotedLen(s string) (int, error) {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q:
+			if q == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string %s", s)
+}
+
+var (
+	intPattern   = regexp.MustCompile(`^[-+]?[0-9]+$`)
+	floatPattern = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+)
+
+// scalar parses a value on one line: a quoted or plain scalar or a flow
+// collection.
+func scalar(text string) (any, error) {
+	if text == "" {
+		return nil, nil
+	}
+	switch text[0] {
+	case '[', '{':
+		f := &flow{s: text}
+		v, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		f.space()
+		if f.i != len(f.s) {
+			return nil, fmt.Errorf("unexpected %q after flow collection", f.s[f.i:])
+		}
+		return v, nil
+	case '"', '\'':
+		n, err := quotedLen(text)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(text[n:]) != "" {
+			return nil, fmt.Errorf("unexpected %q after string", text[n:])
+		}
+		return unquote(text[:n])
+	case '&', '*', '!':
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported: %s", text)
+	}
+	return plain(text), nil
+}
+
+func unquote(s string) (string, error) {
+	if s[0] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return strconv.Unquote(s)
+}
+
+// plain types a plain scalar.
+func plain(s string) any {
+	switch s {
+	case "null", "Null", "NULL", "~":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+	// Normalize numbers, as YAML allows forms JSON does not, like 007 or .5.
+	if intPattern.MatchString(s) {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(n, 10))
+		}
+	}
+	if floatPattern.MatchString(s) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	}
+	return s
+}
+
+// flow parses a flow collection on one line.
+type flow struct {
+	s string
+	i int
+}
+
+func (f *flow) space() {
+	for f.i < len(f.s) && f.s[f.i] == ' ' {
+		f.i++
+	}
+}
+
+func (f *flow) value() (any, error) {
+	f.space()
+	if f.i >= len(f.s) {
+		return nil, fmt.Errorf("unterminated flow collection %s", f.s)
+	}
+	switch f.s[f.i] {
+	case '[':
+		f.i++
+		items := []any{}
+		for {
+			f.space()
+			if f.i < len(f.s) && f.s[f.i] == ']' {
+				f.i++
+				return items, nil
+			}
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			if err := f.next(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.i++
+		m := map[string]any{}
+		for {
+			f.space()
+			if f.i < len(f.s) && f.s[f.i] == '}' {
+				f.i++
+				return m, nil
+			}
+			k, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			f.space()
+			if f.i >= len(f.s) || f.s[f.i] != ':' {
+				return nil, fmt.Errorf("expected \":\" in flow mapping %s", f.s)
+			}
+			f.i++
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(k)] = v
+			if err := f.next('}'); err != nil {
+				return nil, err
+			}
+		}
+	case '"', '\'':
+		n, err := quotedLen(f.s[f.i:])
+		if err != nil {
+			return nil, err
+		}
+		v, err := unquote(f.s[f.i : f.i+n])
+		f.i += n
+		return v, err
+	}
+	start := f.i
+	for f.i < len(f.s) && !strings.ContainsRune(",]}", rune(f.s[f.i])) &&
+		!(f.s[f.i] == ':' && (f.i+1 == len(f.s) || f.s[f.i+1] == ' ')) {
+		f.i++
+	}
+	return plain(strings.TrimSpace(f.s[start:f.i])), nil
+}
+
+// next consumes the "," between entries, or stops before the closing
+// bracket.
+func (f *flow) next(closing byte) error {
+	f.space()
+	switch {
+	case f.i >= len(f.s):
+		return fmt.Errorf("unterminated flow collection %s", f.s)
+	case f.s[f.i] == ',':
+		f.i++
+		return nil
+	case f.s[f.i] == closing:
+		return nil
+	}
+	return fmt.Errorf("expected \",\" or %q in flow collection %s", closing, f.s)
+}
@@ -0,0 +1,99 @@
+package yaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"empty", "", `null`},
+		{"scalar", "hello", `"hello"`},
+		{
+			name: "mapping",
+			yaml: "---\nname: test-team  # a comment\nsize: 3\nratio: .5\nzip: 007\nactive: true\nlead: ~\nempty:\n",
+			want: `{"active":true,"empty":null,"lead":null,"name":"test-team","ratio":0.5,"size":3,"zip":7}`,
+		},
+		{
+			name: "nested mapping",
+			yaml: "parent:\n  name: test-org\n  type: org\n",
+			want: `{"parent":{"name":"test-org","type":"org"}}`,
+		},
+		{
+			name: "sequences",
+			yaml: "indented:\n  - a\n  - b\nflush:\n- c\nflow: [d, \"e, f\", 'g''s']\n",
+			want: `{"flow":["d","e, f","g's"],"flush":["c"],"indented":["a","b"]}`,
+		},
+		{
+			name: "sequence of mappings",
+			yaml: "teams:\n  - name: a\n    members: [x, y]\n    slack:\n      channels:\n        - channel: \"#a\"\n          types: [main]\n  - name: b\n",
+			want: `{"teams":[{"members":["x","y"],"name":"a","slack":{"channels":[{"channel":"#a","types":["main"]}]}},{"name":"b"}]}`,
+		},
+		{
+			name: "nested sequences",
+			yaml: "- - a\n  - b\n-\n  - c\n",
+			want: `[["a","b"],["c"]]`,
+		},
+		{
+			name: "flow mapping",
+			yaml: "parent: {name: test-org, type: org, url: \"http://x\"}\n",
+			want: `{"parent":{"name":"test-org","type":"org","url":"http://x"}}`,
+		},
+		{
+			name: "quoted keys and values",
+			yaml: "\"a: b\": 'it''s # not a comment'\nurl: https://example.com/#x\nescaped: \"tab\\there\"\n",
+			want: `{"a: b":"it's # not a comment","escaped":"tab\there","url":"https://example.com/#x"}`,
+		},
+		{
+			name: "literal block",
+			yaml: "text: |\n  line one\n  # not a comment\n\n  line three\nnext: x\n",
+			want: `{"next":"x","text":"line one\n# not a comment\n\nline three\n"}`,
+		},
+		{
+			name: "folded block strip",
+			yaml: "text: >-\n  folded\n  lines\n\n  para\n",
+			want: `{"text":"folded lines\npara"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToJSON([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("ToJSON: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToJSON =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"duplicate key", "a: 1\na: 2\n", "line 2: duplicate key"},
+		{"bad indentation", "a:\n  b: 1\n    c: 2\n", "line 3: unexpected indentation"},
+		{"not a mapping entry", "a: 1\njust text\n", "line 2: expected \"key: value\""},
+		{"unterminated string", "a: \"open\n", "line 1: unterminated string"},
+		{"unterminated flow", "a: [x, y\n", "line 1: unterminated flow collection"},
+		{"alias", "a: *ref\n", "line 1: anchors, aliases and tags are not supported"},
+		{"block scalar header", "a: |+\n  x\n", "unsupported block scalar header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ToJSON([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ToJSON error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -5,20 +5,12 @@ package orgdatacore
 import "iter"
 
 // AllEmployeeUIDs returns an iterator over all employee UIDs.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach - data is collected while briefly
 // holding a read lock, then iteration proceeds without holding any lock.
 // This is safe for concurrent use and allows slow consumer operations.
-func (s *Service) AllEmployeeUIDs() iter.Seq[string] {
-	// Snapshot while holding lock
-	s.mu.RLock()
-	var uids []string
-	if s.data != nil && s.data.Lookups.Employees != nil {
-		uids = make([]string, 0, len(s.data.Lookups.Employees))
-		for uid := range s.data.Lookups.Employees {
-			uids = append(uids, uid)
-		}
-	}
-	s.mu.RUnlock()
+func (s *Service) AllEmployeeUIDs(opts ...EnumerationOption) iter.Seq[string] {
+	uids := s.employeeUIDSnapshot(opts)
 
 	// Iterate without lock
 	return func(yield func(string) bool) {
@@ -31,20 +23,11 @@ func (s *Service) AllEmployeeUIDs() iter.Seq[string] {
 }
 
 // AllEmployees returns an iterator over all employees.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach - data is collected while briefly
 // holding a read lock, then iteration proceeds without holding any lock.
-func (s *Service) AllEmployees() iter.Seq[*Employee] {
-	// Snapshot while holding lock
-	s.mu.RLock()
-	var employees []*Employee
-	if s.data != nil && s.data.Lookups.Employees != nil {
-		employees = make([]*Employee, 0, len(s.data.Lookups.Employees))
-		for _, emp := range s.data.Lookups.Employees {
-			e := emp // Copy to avoid reference issues
-			employees = append(employees, &e)
-		}
-	}
-	s.mu.RUnlock()
+func (s *Service) AllEmployees(opts ...EnumerationOption) iter.Seq[*Employee] {
+	employees := s.employeeSnapshot(opts)
 
 	// Iterate without lock
 	return func(yield func(*Employee) bool) {
@@ -57,201 +40,186 @@ func (s *Service) AllEmployees() iter.Seq[*Employee] {
 }
 
 // AllTeamNames returns an iterator over all team names.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllTeamNames() iter.Seq[string] {
-	s.mu.RLock()
-	var names []string
-	if s.data != nil && s.data.Lookups.Teams != nil {
-		names = make([]string, 0, len(s.data.Lookups.Teams))
-		for name := range s.data.Lookups.Teams {
-			names = append(names, name)
-		}
-	}
-	s.mu.RUnlock()
-
+func (s *Service) AllTeamNames(opts ...EnumerationOption) iter.Seq[string] {
+	entries := s.teamSnapshot(opts, false)
 	return func(yield func(string) bool) {
-		for _, name := range names {
-			if !yield(name) {
-				return
-			}
-		}
+		rangeNames(entries, yield)
 	}
 }
 
 // AllTeams returns an iterator over all teams with their names.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllTeams() iter.Seq2[string, *Team] {
-	type entry struct {
-		name string
-		team *Team
-	}
-
-	s.mu.RLock()
-	var entries []entry
-	if s.data != nil && s.data.Lookups.Teams != nil {
-		entries = make([]entry, 0, len(s.data.Lookups.Teams))
-		for name, team := range s.data.Lookups.Teams {
-			t := team // Copy to avoid reference issues
-			entries = append(entries, entry{name: name, team: &t})
-		}
-	}
-	s.mu.RUnlock()
-
+func (s *Service) AllTeams(opts ...EnumerationOption) iter.Seq2[string, *Team] {
+	entries := s.teamSnapshot(opts, true)
 	return func(yield func(string, *Team) bool) {
-		for _, e := range entries {
-			if !yield(e.name, e.team) {
-				return
-			}
-		}
+		rangeEntities(entries, yield)
 	}
 }
 
 // AllOrgNames returns an iterator over all organization names.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllOrgNames() iter.Seq[string] {
-	s.mu.RLock()
-	var names []string
-	if s.data != nil && s.data.Lookups.Orgs != nil {
-		names = make([]string, 0, len(s.data.Lookups.Orgs))
-		for name := range s.data.Lookups.Orgs {
-			names = append(names, name)
-		}
-	}
-	s.mu.RUnlock()
-
+func (s *Service) AllOrgNames(opts ...EnumerationOption) iter.Seq[string] {
+	entries := s.orgSnapshot(opts, false)
 	return func(yield func(string) bool) {
-		for _, name := range names {
-			if !yield(name) {
-				return
-			}
-		}
+		rangeNames(entries, yield)
 	}
 }
 
 // AllOrgs returns an iterator over all organizations with their names.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllOrgs() iter.Seq2[string, *Org] {
-	type entry struct {
-		name string
-		org  *Org
-	}
-
-	s.mu.RLock()
-	var entries []entry
-	if s.data != nil && s.data.Lookups.Orgs != nil {
-		entries = make([]entry, 0, len(s.data.Lookups.Orgs))
-		for name, org := range s.data.Lookups.Orgs {
-			o := org
-			entries = append(entries, entry{name: name, org: &o})
-		}
-	}
-	s.mu.RUnlock()
-
+func (s *Service) AllOrgs(opts ...EnumerationOption) iter.Seq2[string, *Org] {
+	entries := s.orgSnapshot(opts, true)
 	return func(yield func(string, *Org) bool) {
-		for _, e := range entries {
-			if !yield(e.name, e.org) {
-				return
-			}
-		}
+		rangeEntities(entries, yield)
 	}
 }
 
 // AllPillarNames returns an iterator over all pillar names.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllPillarNames() iter.Seq[string] {
-	s.mu.RLock()
-	var names []string
-	if s.data != nil && s.data.Lookups.Pillars != nil {
-		names = make([]string, 0, len(s.data.Lookups.Pillars))
-		for name := range s.data.Lookups.Pillars {
-			names = append(names, name)
-		}
+func (s *Service) AllPillarNames(opts ...EnumerationOption) iter.Seq[string] {
+	entries := s.pillarSnapshot(opts, false)
+	return func(yield func(string) bool) {
+		rangeNames(entries, yield)
+	}
+}
+
+// AllPillars returns an iterator over all pillars with their names.
+// Options such as WithinOrg narrow what it yields.
+// The iterator uses a snapshot approach for safe concurrent use.
+func (s *Service) AllPillars(opts ...EnumerationOption) iter.Seq2[string, *Pillar] {
+	entries := s.pillarSnapshot(opts, true)
+	return func(yield func(string, *Pillar) bool) {
+		rangeEntities(entries, yield)
 	}
-	s.mu.RUnlock()
+}
 
+// AllTeamGroupNames returns an iterator over all team group names.
+// Options such as WithinOrg narrow what it yields.
+// The iterator uses a snapshot approach for safe concurrent use.
+func (s *Service) AllTeamGroupNames(opts ...EnumerationOption) iter.Seq[string] {
+	entries := s.teamGroupSnapshot(opts, false)
 	return func(yield func(string) bool) {
-		for _, name := range names {
-			if !yield(name) {
-				return
-			}
-		}
+		rangeNames(entries, yield)
 	}
 }
 
-// AllPillars returns an iterator over all pillars with their names.
+// AllTeamGroups returns an iterator over all team groups with their names.
+// Options such as WithinOrg narrow what it yields.
 // The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllPillars() iter.Seq2[string, *Pillar] {
-	type entry struct {
-		name   string
-		pillar *Pillar
+func (s *Service) AllTeamGroups(opts ...EnumerationOption) iter.Seq2[string, *TeamGroup] {
+	entries := s.teamGroupSnapshot(opts, true)
+	return func(yield func(string, *TeamGroup) bool) {
+		rangeEntities(entries, yield)
 	}
+}
 
-	s.mu.RLock()
-	var entries []entry
-	if s.data != nil && s.data.Lookups.Pillars != nil {
-		entries = make([]entry, 0, len(s.data.Lookups.Pillars))
-		for name, pillar := range s.data.Lookups.Pillars {
-			p := pillar
-			entries = append(entries, entry{name: name, pillar: &p})
+// named is an entity and its name in an enumeration snapshot. The value is a
+// copy, so callers may keep or modify it, or nil when only names were taken.
+type named[T any] struct {
+	name  string
+	value *T
+}
+
+func rangeNames[T any](entries []named[T], fn func(string) bool) {
+	for _, e := range entries {
+		if !fn(e.name) {
+			return
 		}
 	}
-	s.mu.RUnlock()
+}
 
-	return func(yield func(string, *Pillar) bool) {
-		for _, e := range entries {
-			if !yield(e.name, e.pillar) {
-				return
-			}
+func rangeEntities[T any](entries []named[T], fn func(string, *T) bool) {
+	for _, e := range entries {
+		if !fn(e.name, e.value) {
+			return
 		}
 	}
 }
 
-// AllTeamGroupNames returns an iterator over all team group names.
-// The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllTeamGroupNames() iter.Seq[string] {
+func (s *Service) employeeUIDSnapshot(opts []EnumerationOption) []string {
 	s.mu.RLock()
-	var names []string
-	if s.data != nil && s.data.Lookups.TeamGroups != nil {
-		names = make([]string, 0, len(s.data.Lookups.TeamGroups))
-		for name := range s.data.Lookups.TeamGroups {
-			names = append(names, name)
+	defer s.mu.RUnlock()
+
+	filter := s.newEnumeration(opts)
+	var uids []string
+	if s.data != nil && s.data.Lookups.Employees != nil {
+		uids = make([]string, 0, len(s.data.Lookups.Employees))
+		for uid := range s.data.Lookups.Employees {
+			if s.matchEmployee(filter, uid) {
+				uids = append(uids, uid)
+			}
 		}
 	}
-	s.mu.RUnlock()
+	return uids
+}
 
-	return func(yield func(string) bool) {
-		for _, name := range names {
-			if !yield(name) {
-				return
+func (s *Service) employeeSnapshot(opts []EnumerationOption) []*Employee {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	filter := s.newEnumeration(opts)
+	var employees []*Employee
+	if s.data != nil && s.data.Lookups.Employees != nil {
+		employees = make([]*Employee, 0, len(s.data.Lookups.Employees))
+		for _, emp := range s.data.Lookups.Employees {
+			if s.matchEmployee(filter, emp.UID) {
+				e := emp // Copy to avoid reference issues
+				employees = append(employees, &e)
 			}
 		}
 	}
+	return employees
 }
 
-// AllTeamGroups returns an iterator over all team groups with their names.
-// The iterator uses a snapshot approach for safe concurrent use.
-func (s *Service) AllTeamGroups() iter.Seq2[string, *TeamGroup] {
-	type entry struct {
-		name      string
-		teamGroup *TeamGroup
-	}
+func (s *Service) teamSnapshot(opts []EnumerationOption, withValues bool) []named[Team] {
+	return entitySnapshot(s, opts, "team", func(l *Lookups) map[string]Team { return l.Teams }, withValues,
+		func(t Team) string { return t.Type })
+}
 
+func (s *Service) orgSnapshot(opts []EnumerationOption, withValues bool) []named[Org] {
+	return entitySnapshot(s, opts, "org", func(l *Lookups) map[string]Org { return l.Orgs }, withValues,
+		func(o Org) string { return o.Type })
+}
+
+func (s *Service) pillarSnapshot(opts []EnumerationOption, withValues bool) []named[Pillar] {
+	return entitySnapshot(s, opts, "pillar", func(l *Lookups) map[string]Pillar { return l.Pillars }, withValues,
+		func(p Pillar) string { return p.Type })
+}
+
+func (s *Service) teamGroupSnapshot(opts []EnumerationOption, withValues bool) []named[TeamGroup] {
+	return entitySnapshot(s, opts, "team_group", func(l *Lookups) map[string]TeamGroup { return l.TeamGroups }, withValues,
+		func(tg TeamGroup) string { return tg.Type })
+}
+
+// entitySnapshot collects the names of the entities of one kind that pass
+// opts while holding the read lock, and copies of the entities themselves
+// when withValues is set.
+func entitySnapshot[T deepCopier[T]](s *Service, opts []EnumerationOption, entityType string, lookup func(*Lookups) map[string]T, withValues bool, typeOf func(T) string) []named[T] {
 	s.mu.RLock()
-	var entries []entry
-	if s.data != nil && s.data.Lookups.TeamGroups != nil {
-		entries = make([]entry, 0, len(s.data.Lookups.TeamGroups))
-		for name, tg := range s.data.Lookups.TeamGroups {
-			teamGroup := tg
-			entries = append(entries, entry{name: name, teamGroup: &teamGroup})
-		}
-	}
-	s.mu.RUnlock()
+	defer s.mu.RUnlock()
 
-	return func(yield func(string, *TeamGroup) bool) {
-		for _, e := range entries {
-			if !yield(e.name, e.teamGroup) {
-				return
-			}
+	if s.data == nil {
+		return nil
+	}
+	filter := s.newEnumeration(opts)
+	m := lookup(&s.data.Lookups)
+	entries := make([]named[T], 0, len(m))
+	for name, entity := range m {
+		if !s.matchEntity(filter, name, entityType, typeOf(entity)) {
+			continue
+		}
+		e := named[T]{name: name}
+		if withValues {
+			v := owned(s, entity)
+			e.value = &v
 		}
+		entries = append(entries, e)
 	}
+	return entries
 }
@@ -343,3 +343,58 @@ func TestIterator_MultipleIterations(t *testing.T) {
 		t.Errorf("Multiple iterations returned different counts: %d vs %d", count1, count2)
 	}
 }
+
+func TestIterator_EnumerationOptions(t *testing.T) {
+	service := setupTestService(t)
+	sorted := func(names []string) []string {
+		slices.Sort(names)
+		return names
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"teams within platform-org", slices.Collect(service.AllTeamNames(WithinOrg("platform-org"))), []string{"platform-team"}},
+		{"teams within test-org", slices.Collect(service.AllTeamNames(WithinOrg("test-org"))), []string{"platform-team", "test-team"}},
+		{"teams within org and pillar", slices.Collect(service.AllTeamNames(WithinOrg("test-org"), WithinPillar("engineering"))), []string{"platform-team"}},
+		{"teams of type team", slices.Collect(service.AllTeamNames(OfType("team"))), []string{"platform-team", "test-team"}},
+		{"teams of unknown type", slices.Collect(service.AllTeamNames(OfType("squad"))), nil},
+		{"teams within unknown org", slices.Collect(service.AllTeamNames(WithinOrg("no-such-org"))), nil},
+		{"orgs within test-org", slices.Collect(service.AllOrgNames(WithinOrg("test-org"))), []string{"platform-org"}},
+		{"team groups within pillar", slices.Collect(service.AllTeamGroupNames(WithinPillar("engineering"))), []string{"backend-teams"}},
+		{"employees within platform-org", slices.Collect(service.AllEmployeeUIDs(WithinOrg("platform-org"))), []string{"bwilson"}},
+		{"employees within team group", slices.Collect(service.AllEmployeeUIDs(WithinTeamGroup("backend-teams"))), []string{"bwilson"}},
+		{"employees within test-org", slices.Collect(service.AllEmployeeUIDs(WithinOrg("test-org"), OfType("squad"))), []string{"adoe", "bwilson", "jsmith"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sorted(tt.got); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var teams []string
+	for name, team := range service.AllTeams(WithinTeamGroup("backend-teams")) {
+		if team.Name != name {
+			t.Errorf("AllTeams yielded %q with team %q", name, team.Name)
+		}
+		teams = append(teams, name)
+	}
+	if !slices.Equal(teams, []string{"platform-team"}) {
+		t.Errorf("AllTeams(WithinTeamGroup) = %v, want [platform-team]", teams)
+	}
+}
+
+func TestIterator_AllowsQueries(t *testing.T) {
+	service := setupTestService(t)
+
+	// The loop body runs without the lock held, so it may query the service.
+	for name := range service.AllTeamNames() {
+		if len(service.GetTeamMembers(name)) == 0 {
+			t.Errorf("GetTeamMembers(%q) while iterating AllTeamNames found nobody", name)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Package jirarouting routes incoming Jira issues: from an issue's project,
+// components and labels it finds the owning team, the people to suggest as
+// assignees and the Slack channel to notify, in one call, combining the
+// Jira index with the owner's roles and Slack configuration.
+//
+//	router := jirarouting.New(service)
+//	route, err := router.Route(jirarouting.Issue{Project: "OCPBUGS", Components: []string{"Networking"}})
+//	if err == nil {
+//		notify(route.SlackChannel, route.Assignees)
+//	}
+//
+// Owners are looked for in order: the issue's components, then its labels,
+// each taken as a component name of the project or as a team name, then the
+// project-level owners, then any owner in the project. The router works with
+// any orgdatacore.ServiceInterface, including httpclient.Client.
+package jirarouting
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// ErrNoRoute is returned when no owner is found for an issue.
+var ErrNoRoute = errors.New("jirarouting: no owner found")
+
+// projectLevel is the Jira index key for owners of a whole project.
+const projectLevel = "_project_level"
+
+// How a Route's owner was found.
+const (
+	MatchComponent = "component"
+	MatchLabel     = "label"
+	MatchProject   = "project"
+)
+
+// Issue is the part of a Jira issue used for routing.
+type Issue struct {
+	Project    string
+	Components []string
+	Labels     []string
+}
+
+// Route is where an issue should go.
+type Route struct {
+	// Owner is the entity the issue is routed to, normally a team. Owners
+	// lists every owner found at the same level, Owner first.
+	Owner  orgdatacore.JiraOwnerInfo
+	Owners []orgdatacore.JiraOwnerInfo
+	// MatchedBy is MatchComponent, MatchLabel or MatchProject, and Match the
+	// component or label that matched, if any.
+	MatchedBy string
+	Match     string
+	// Assignees are the owner's people holding the assignee roles, in role
+	// order. It is empty when nobody holds them.
+	Assignees []orgdatacore.Employee
+	// SlackChannel is the owner's channel to notify, nil if it has none.
+	SlackChannel *orgdatacore.ChannelInfo
+}
+
+// Router routes issues using the data of one service.
+type Router struct {
+	svc           orgdatacore.ServiceInterface
+	assigneeRoles []string
+	channelTypes  []string
+}
+
+// New returns a Router answering from svc. Assignees are the owner's tech
+// leads, then its managers, and the notified channel is its "main" channel.
+func New(svc orgdatacore.ServiceInterface) *Router {
+	return &Router{
+		svc:           svc,
+		assigneeRoles: []string{"tech_lead", "manager"},
+		channelTypes:  []string{"main"},
+	}
+}
+
+// WithAssigneeRoles sets the roles whose holders are suggested as
+// assignees, in order of preference, and returns r.
+func (r *Router) WithAssigneeRoles(roles ...string) *Router {
+	r.assigneeRoles = roles
+	return r
+}
+
+// WithChannelTypes sets the Slack channel types to notify, in order of
+// preference, and returns r. When the owner has no channel of these types,
+// its first channel is used.
+func (r *Router) WithChannelTypes(types ...string) *Router {
+	r.channelTypes = types
+	return r
+}
+
+// Route finds where issue should go. It fails with ErrNoRoute when the
+// project is unknown or nothing in it is owned.
+func (r *Router) Route(issue Issue) (Route, error) {
+	route, ok := r.findOwners(issue)
+	if !ok {
+		return Route{}, fmt.Errorf("%w: project %q", ErrNoRoute, issue.Project)
+	}
+	// Prefer a team over orgs and other owners.
+	if i := slices.IndexFunc(route.Owners, func(o orgdatacore.JiraOwnerInfo) bool { return o.Type == "team" }); i > 0 {
+		route.Owners = slices.Clone(route.Owners)
+		owner := route.Owners[i]
+		copy(route.Owners[1:i+1], route.Owners[:i])
+		route.Owners[0] = owner
+	}
+	route.Owner = route.Owners[0]
+
+	group := r.group(route.Owner)
+	route.Assignees = r.assignees(group)
+	route.SlackChannel = r.channel(group)
+	return route, nil
+}
+
+// findOwners returns the owners of issue at the first level that has any.
+func (r *Router) findOwners(issue Issue) (Route, bool) {
+	for _, component := range issue.Components {
+		if owners := r.svc.GetTeamsByJiraComponent(issue.Project, component); len(owners) > 0 {
+			return Route{Owners: owners, MatchedBy: MatchComponent, Match: component}, true
+		}
+	}
+	for _, label := range issue.Labels {
+		if owners := r.svc.GetTeamsByJiraComponent(issue.Project, label); len(owners) > 0 {
+			return Route{Owners: owners, MatchedBy: MatchLabel, Match: label}, true
+		}
+		if r.svc.GetTeamByName(label) != nil {
+			owners := []orgdatacore.JiraOwnerInfo{{Name: label, Type: "team"}}
+			return Route{Owners: owners, MatchedBy: MatchLabel, Match: label}, true
+		}
+	}
+	if owners := r.svc.GetTeamsByJiraComponent(issue.Project, projectLevel); len(owners) > 0 {
+		return Route{Owners: owners, MatchedBy: MatchProject}, true
+	}
+	if owners := r.svc.GetTeamsByJiraProject(issue.Project); len(owners) > 0 {
+		slices.SortFunc(owners, func(a, b orgdatacore.JiraOwnerInfo) int {
+			if a.Name != b.Name {
+				return cmp.Compare(a.Name, b.Name)
+			}
+			return cmp.Compare(a.Type, b.Type)
+		})
+		return Route{Owners: owners, MatchedBy: MatchProject}, true
+	}
+	return Route{}, false
+}
+
+// group returns the owner's group, or nil if the owner is not found.
+func (r *Router) group(owner orgdatacore.JiraOwnerInfo) *orgdatacore.Group {
+	switch owner.Type {
+	case "team":
+		if t := r.svc.GetTeamByName(owner.Name); t != nil {
+			return &t.Group
+		}
+	case "org":
+		if o := r.svc.GetOrgByName(owner.Name); o != nil {
+			return &o.Group
+		}
+	case "pillar":
+		if p := r.svc.GetPillarByName(owner.Name); p != nil {
+			return &p.Group
+		}
+	case "team_group":
+		if tg := r.svc.GetTeamGroupByName(owner.Name); tg != nil {
+			return &tg.Group
+		}
+	}
+	return nil
+}
+
+func (r *Router) assignees(group *orgdatacore.Group) []orgdatacore.Employee {
+	assignees := []orgdatacore.Employee{}
+	if group == nil {
+		return assignees
+	}
+	seen := make(map[string]bool)
+	for _, role := range r.assigneeRoles {
+		for _, info := range group.Roles {
+			if !slices.Contains(info.Roles, role) {
+				continue
+			}
+			for _, uid := range info.People {
+				if seen[uid] {
+					continue
+				}
+				seen[uid] = true
+				if emp := r.svc.GetEmployeeByUID(uid); emp != nil {
+					assignees = append(assignees, *emp)
+				}
+			}
+		}
+	}
+	return assignees
+}
+
+func (r *Router) channel(group *orgdatacore.Group) *orgdatacore.ChannelInfo {
+	if group == nil || group.Slack == nil || len(group.Slack.Channels) == 0 {
+		return nil
+	}
+	channels := group.Slack.Channels
+	for _, typ := range r.channelTypes {
+		for i := range channels {
+			if slices.Contains(channels[i].Types, typ) {
+				channel := channels[i]
+				return &channel
+			}
+		}
+	}
+	channel := channels[0]
+	return &channel
+}
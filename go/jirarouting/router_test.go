@@ -0,0 +1,130 @@
+package jirarouting
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func newTestRouter(t *testing.T) *Router {
+	t.Helper()
+	service := orgdatacore.NewService()
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	return New(service)
+}
+
+func TestRoute(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name      string
+		issue     Issue
+		owner     string
+		matchedBy string
+		match     string
+		assignees []string
+		channel   string
+	}{
+		{
+			name:      "component",
+			issue:     Issue{Project: "TEST", Components: []string{"Unknown", "Core"}},
+			owner:     "test-team",
+			matchedBy: MatchComponent,
+			match:     "Core",
+			assignees: []string{"jsmith", "adoe"},
+			channel:   "#test-team",
+		},
+		{
+			name:      "label as component",
+			issue:     Issue{Project: "PLAT", Labels: []string{"triaged", "Infrastructure"}},
+			owner:     "platform-team",
+			matchedBy: MatchLabel,
+			match:     "Infrastructure",
+			assignees: []string{"bwilson"},
+			channel:   "#platform",
+		},
+		{
+			name:      "label as team",
+			issue:     Issue{Project: "TEST", Labels: []string{"platform-team"}},
+			owner:     "platform-team",
+			matchedBy: MatchLabel,
+			match:     "platform-team",
+			assignees: []string{"bwilson"},
+			channel:   "#platform",
+		},
+		{
+			name:      "project level",
+			issue:     Issue{Project: "TEST", Components: []string{"Unknown"}},
+			owner:     "test-team",
+			matchedBy: MatchProject,
+			assignees: []string{"jsmith", "adoe"},
+			channel:   "#test-team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route, err := router.Route(tt.issue)
+			if err != nil {
+				t.Fatalf("Route: %v", err)
+			}
+			if route.Owner.Name != tt.owner || route.MatchedBy != tt.matchedBy || route.Match != tt.match {
+				t.Errorf("Route owner = %s by %s %q, want %s by %s %q",
+					route.Owner.Name, route.MatchedBy, route.Match, tt.owner, tt.matchedBy, tt.match)
+			}
+			assignees := []string{}
+			for _, emp := range route.Assignees {
+				assignees = append(assignees, emp.UID)
+			}
+			if !slices.Equal(assignees, tt.assignees) {
+				t.Errorf("Route assignees = %v, want %v", assignees, tt.assignees)
+			}
+			if route.SlackChannel == nil || route.SlackChannel.Channel != tt.channel {
+				t.Errorf("Route channel = %+v, want %s", route.SlackChannel, tt.channel)
+			}
+		})
+	}
+}
+
+func TestRouteOptions(t *testing.T) {
+	router := newTestRouter(t).WithAssigneeRoles("manager").WithChannelTypes("alerts")
+
+	route, err := router.Route(Issue{Project: "TEST", Components: []string{"Core"}})
+	if err != nil {
+		t.Fatalf("Route: %v", err)
+	}
+	if len(route.Assignees) != 1 || route.Assignees[0].UID != "adoe" {
+		t.Errorf("Route assignees = %+v, want adoe", route.Assignees)
+	}
+	if route.SlackChannel == nil || route.SlackChannel.Channel != "#test-alerts" {
+		t.Errorf("Route channel = %+v, want #test-alerts", route.SlackChannel)
+	}
+
+	// Without a channel of the wanted types, the first channel is used.
+	route, err = router.Route(Issue{Project: "PLAT"})
+	if err != nil {
+		t.Fatalf("Route: %v", err)
+	}
+	if route.SlackChannel == nil || route.SlackChannel.Channel != "#platform" {
+		t.Errorf("Route channel = %+v, want #platform", route.SlackChannel)
+	}
+}
+
+func TestRouteNoOwner(t *testing.T) {
+	router := newTestRouter(t)
+
+	if _, err := router.Route(Issue{Project: "NOPE", Components: []string{"Core"}}); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Route for an unknown project = %v, want ErrNoRoute", err)
+	}
+	if _, err := New(orgdatacore.NewService()).Route(Issue{Project: "TEST"}); !errors.Is(err, ErrNoRoute) {
+		t.Errorf("Route without data = %v, want ErrNoRoute", err)
+	}
+}
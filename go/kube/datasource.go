@@ -0,0 +1,109 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// DataSource loads data published by a Publisher from the ConfigMap or
+// Secret mounted as a volume. The kubelet updates mounted files in place
+// when the object changes, usually within a minute, and Watch reloads when
+// the file's content changes.
+type DataSource struct {
+	path     string
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu  sync.Mutex
+	sum [sha256.Size]byte // of the content last loaded
+}
+
+var _ orgdatacore.DataSource = (*DataSource)(nil)
+
+// NewDataSource returns a DataSource reading the mounted key at path, such
+// as "/etc/orgdata/orgdata.json". Watch checks it every 30 seconds.
+func NewDataSource(path string) *DataSource {
+	return &DataSource{path: path, interval: 30 * time.Second, logger: slog.Default()}
+}
+
+// WithInterval sets how often Watch checks the file and returns d.
+func (d *DataSource) WithInterval(interval time.Duration) *DataSource {
+	if interval > 0 {
+		d.interval = interval
+	}
+	return d
+}
+
+// WithLogger sets the logger used by Watch, slog.Default by default, and
+// returns d.
+func (d *DataSource) WithLogger(logger *slog.Logger) *DataSource {
+	if logger != nil {
+		d.logger = logger
+	}
+	return d
+}
+
+// Load reads the mounted file.
+func (d *DataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	content, err := os.ReadFile(d.path)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.sum = sha256.Sum256(content)
+	d.mu.Unlock()
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+// Watch checks the file in the background and calls callback when its
+// content differs from what was last loaded. It returns at once; checking
+// stops when ctx is cancelled.
+func (d *DataSource) Watch(ctx context.Context, callback func() error) error {
+	ticker := time.NewTicker(d.interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				d.logger.Debug("mounted file watcher stopped", "source", d.String())
+				return
+			case <-ticker.C:
+				d.check(ctx, callback)
+			}
+		}
+	}()
+
+	d.logger.Info("mounted file watcher started", "source", d.String(), "interval", d.interval)
+	return nil
+}
+
+func (d *DataSource) check(ctx context.Context, callback func() error) {
+	content, err := os.ReadFile(d.path)
+	orgdatacore.ReportWatchCheck(ctx, time.Now().Add(d.interval), err)
+	if err != nil {
+		d.logger.Error("failed to read mounted file", "source", d.String(), "error", err)
+		return
+	}
+	d.mu.Lock()
+	changed := sha256.Sum256(content) != d.sum
+	d.mu.Unlock()
+	if changed {
+		d.logger.Info("mounted file changed, reloading", "source", d.String())
+		if err := callback(); err != nil {
+			d.logger.Error("reload failed", "source", d.String(), "error", err)
+		}
+	}
+}
+
+func (d *DataSource) String() string { return "file:" + d.path }
+
+func (d *DataSource) Close() error { return nil }
@@ -0,0 +1,60 @@
+package kube
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestDataSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	upstream := loadedService(t)
+	dump, _, err := upstream.ExportDump()
+	if err != nil {
+		t.Fatalf("ExportDump: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "orgdata.json")
+	if err := os.WriteFile(path, dump, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	service := orgdatacore.NewService()
+	if err := service.StartDataSourceWatcher(ctx, NewDataSource(path).WithInterval(10*time.Millisecond)); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+	if got, want := service.GetVersion().Checksum, dumpChecksum(t, upstream); got != want {
+		t.Errorf("checksum = %s, want the dump's %s", got, want)
+	}
+
+	if err := upstream.ApplyChangeSet(orgdatacore.ChangeSet{
+		DataVersion:       "test-v2",
+		EmployeesUpserted: map[string]orgdatacore.Employee{"newhire": {UID: "newhire"}},
+	}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	dump, _, _ = upstream.ExportDump()
+	if err := os.WriteFile(path, dump, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for service.GetEmployeeByUID("newhire") == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("service did not reload after the mounted file changed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDataSourceMissingFile(t *testing.T) {
+	source := NewDataSource(filepath.Join(t.TempDir(), "missing.json"))
+	if _, err := source.Load(context.Background()); !os.IsNotExist(err) {
+		t.Errorf("Load of a missing file = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,290 @@
+// Package kube shares org data with in-cluster workloads that cannot reach
+// GCS. A Publisher writes the loaded dataset, optionally cut down to a
+// subset, into a ConfigMap or Secret on every reload; workloads mount it and
+// load it with DataSource:
+//
+//	// In the service that watches GCS:
+//	publisher := kube.NewPublisher(service, "orgdata", "orgdata")
+//	go publisher.Run(ctx)
+//
+//	// In each consumer, with the ConfigMap mounted at /etc/orgdata:
+//	err := service.StartDataSourceWatcher(ctx, kube.NewDataSource("/etc/orgdata/orgdata.json"))
+//
+// The publisher talks to the Kubernetes API directly, using the pod's
+// service account; its role needs create and update on the ConfigMap or
+// Secret. A ConfigMap or Secret holds at most 1 MiB, so large datasets must
+// be filtered with WithFilter.
+package kube
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// ErrTooLarge is returned when the dataset does not fit in a ConfigMap or
+// Secret.
+var ErrTooLarge = errors.New("kube: dataset exceeds the 1 MiB object limit")
+
+// maxObjectSize is the most data a ConfigMap or Secret can hold.
+const maxObjectSize = 1 << 20
+
+// ChecksumAnnotation is set on the published object to the SHA-256 of the
+// published dump.
+const ChecksumAnnotation = "cyborg-data.openshift.io/checksum"
+
+// Where a pod's service account credentials are mounted.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// Publisher writes the dataset of a service into a ConfigMap or Secret.
+type Publisher struct {
+	svc       *orgdatacore.Service
+	namespace string
+	name      string
+	key       string
+	secret    bool
+	filter    *orgdatacore.SubsetFilter
+	logger    *slog.Logger
+
+	apiURL string
+	token  func() (string, error)
+	http   *http.Client
+
+	mu        sync.Mutex // serializes publishes
+	published string     // checksum of the last dump published
+}
+
+// NewPublisher returns a Publisher writing svc's data to the ConfigMap name
+// in namespace, under the key "orgdata.json". It uses the in-cluster API
+// server and service account unless WithAPIServer is set.
+func NewPublisher(svc *orgdatacore.Service, namespace, name string) *Publisher {
+	return &Publisher{
+		svc:       svc,
+		namespace: namespace,
+		name:      name,
+		key:       "orgdata.json",
+		logger:    slog.Default(),
+	}
+}
+
+// WithKey sets the ConfigMap or Secret key holding the dump and returns p.
+func (p *Publisher) WithKey(key string) *Publisher {
+	if key != "" {
+		p.key = key
+	}
+	return p
+}
+
+// WithSecret makes p write a Secret instead of a ConfigMap, for data that
+// must not be readable by everyone in the namespace, and returns p.
+func (p *Publisher) WithSecret() *Publisher {
+	p.secret = true
+	return p
+}
+
+// WithFilter makes p publish only the subset selected by filter, as
+// returned by ExportSubset, and returns p.
+func (p *Publisher) WithFilter(filter orgdatacore.SubsetFilter) *Publisher {
+	p.filter = &filter
+	return p
+}
+
+// WithAPIServer sets the Kubernetes API server, bearer token and HTTP client
+// to use instead of the in-cluster ones, and returns p. hc may be nil for
+// http.DefaultClient.
+func (p *Publisher) WithAPIServer(apiURL, token string, hc *http.Client) *Publisher {
+	p.apiURL = strings.TrimRight(apiURL, "/")
+	p.token = func() (string, error) { return token, nil }
+	p.http = hc
+	if p.http == nil {
+		p.http = http.DefaultClient
+	}
+	return p
+}
+
+// WithLogger sets the logger used by Run, slog.Default by default, and
+// returns p.
+func (p *Publisher) WithLogger(logger *slog.Logger) *Publisher {
+	if logger != nil {
+		p.logger = logger
+	}
+	return p
+}
+
+// Run publishes the loaded data, then again after every reload, until ctx
+// is cancelled. Reloads that arrive while a publish is in progress are
+// coalesced into one. Failed publishes are logged and retried on the next
+// reload.
+func (p *Publisher) Run(ctx context.Context) {
+	reloaded := make(chan struct{}, 1)
+	unsubscribe := p.svc.OnReload(func(_, _ orgdatacore.DataVersion) {
+		select {
+		case reloaded <- struct{}{}:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	for {
+		if err := p.Publish(ctx); err != nil && ctx.Err() == nil && !errors.Is(err, orgdatacore.ErrNoData) {
+			p.logger.Error("failed to publish org data", "object", p.String(), "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloaded:
+		}
+	}
+}
+
+// Publish writes the loaded data now, creating the ConfigMap or Secret if
+// needed. It does nothing if the data has not changed since the last
+// publish. Without loaded data it fails with ErrNoData.
+func (p *Publisher) Publish(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var dump []byte
+	var err error
+	if p.filter != nil {
+		dump, err = p.svc.ExportSubset(*p.filter)
+	} else {
+		dump, _, err = p.svc.ExportDump()
+	}
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(dump)
+	checksum := hex.EncodeToString(sum[:])
+	if checksum == p.published {
+		return nil
+	}
+	if len(dump) > maxObjectSize {
+		return fmt.Errorf("%w: %d bytes", ErrTooLarge, len(dump))
+	}
+
+	object := map[string]any{
+		"apiVersion": "v1",
+		"metadata": map[string]any{
+			"name":        p.name,
+			"namespace":   p.namespace,
+			"annotations": map[string]string{ChecksumAnnotation: checksum},
+		},
+	}
+	if p.secret {
+		object["kind"] = "Secret"
+		object["type"] = "Opaque"
+		object["data"] = map[string][]byte{p.key: dump}
+	} else {
+		object["kind"] = "ConfigMap"
+		object["data"] = map[string]string{p.key: string(dump)}
+	}
+	body, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	// Replace the object, and create it if it does not exist yet.
+	status, err := p.do(ctx, http.MethodPut, p.collection()+"/"+url.PathEscape(p.name), body)
+	if status == http.StatusNotFound {
+		_, err = p.do(ctx, http.MethodPost, p.collection(), body)
+	}
+	if err != nil {
+		return err
+	}
+	p.published = checksum
+	p.logger.Info("published org data", "object", p.String(), "bytes", len(dump), "checksum", checksum)
+	return nil
+}
+
+func (p *Publisher) String() string {
+	kind := "configmap"
+	if p.secret {
+		kind = "secret"
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, p.namespace, p.name)
+}
+
+func (p *Publisher) collection() string {
+	resource := "configmaps"
+	if p.secret {
+		resource = "secrets"
+	}
+	return fmt.Sprintf("/api/v1/namespaces/%s/%s", url.PathEscape(p.namespace), resource)
+}
+
+// do sends body to the API server and returns the response status, with an
+// error for any status other than 2xx.
+func (p *Publisher) do(ctx context.Context, method, path string, body []byte) (int, error) {
+	if p.apiURL == "" {
+		if err := p.useInCluster(); err != nil {
+			return 0, err
+		}
+	}
+	token, err := p.token()
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.apiURL+path, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := p.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return resp.StatusCode, fmt.Errorf("kube: %s %s: %d %s", method, path, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return resp.StatusCode, nil
+}
+
+// useInCluster configures the API server and credentials a pod is given.
+// The token is read for every request, as the kubelet rotates it.
+func (p *Publisher) useInCluster() error {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return errors.New("kube: not running in a cluster; use WithAPIServer")
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return fmt.Errorf("kube: read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return errors.New("kube: no certificates in service account CA")
+	}
+	p.apiURL = "https://" + net.JoinHostPort(host, port)
+	p.token = func() (string, error) {
+		token, err := os.ReadFile(serviceAccountDir + "/token")
+		if err != nil {
+			return "", fmt.Errorf("kube: read service account token: %w", err)
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	p.http = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+	return nil
+}
@@ -0,0 +1,244 @@
+package kube
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// fakeAPIServer stores ConfigMaps and Secrets written with PUT and POST, as
+// the Kubernetes API server does.
+type fakeAPIServer struct {
+	mu       sync.Mutex
+	objects  map[string]json.RawMessage // by request path
+	requests []string
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	if r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	var object struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &object); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		if _, ok := f.objects[r.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.objects[r.URL.Path] = body
+	case http.MethodPost:
+		f.objects[r.URL.Path+"/"+object.Metadata.Name] = body
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (f *fakeAPIServer) object(t *testing.T, path string) (annotations map[string]string, data map[string]any) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var object struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(f.objects[path], &object); err != nil {
+		t.Fatalf("object %s: %v", path, err)
+	}
+	return object.Metadata.Annotations, object.Data
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPIServer, *httptest.Server) {
+	t.Helper()
+	api := &fakeAPIServer{objects: make(map[string]json.RawMessage)}
+	ts := httptest.NewServer(api)
+	t.Cleanup(ts.Close)
+	return api, ts
+}
+
+func loadedService(t *testing.T) *orgdatacore.Service {
+	t.Helper()
+	service := orgdatacore.NewService()
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	return service
+}
+
+// dumpChecksum returns the SHA-256 of service's exported dump, which is the
+// checksum a service loading the published object reports.
+func dumpChecksum(t *testing.T, service *orgdatacore.Service) string {
+	t.Helper()
+	dump, _, err := service.ExportDump()
+	if err != nil {
+		t.Fatalf("ExportDump: %v", err)
+	}
+	sum := sha256.Sum256(dump)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPublish(t *testing.T) {
+	ctx := context.Background()
+	api, ts := newFakeAPI(t)
+	service := loadedService(t)
+	publisher := NewPublisher(service, "ns", "orgdata").WithAPIServer(ts.URL, "test-token", ts.Client())
+
+	if err := publisher.Publish(ctx); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	// Unchanged data is not published again.
+	if err := publisher.Publish(ctx); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	want := []string{"PUT /api/v1/namespaces/ns/configmaps/orgdata", "POST /api/v1/namespaces/ns/configmaps"}
+	if strings.Join(api.requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", api.requests, want)
+	}
+
+	annotations, data := api.object(t, "/api/v1/namespaces/ns/configmaps/orgdata")
+	dump, _, _ := service.ExportDump()
+	if data["orgdata.json"] != string(dump) {
+		t.Errorf("published data = %.80v, want the exported dump", data["orgdata.json"])
+	}
+	if want := dumpChecksum(t, service); annotations[ChecksumAnnotation] != want {
+		t.Errorf("checksum annotation = %q, want %q", annotations[ChecksumAnnotation], want)
+	}
+}
+
+func TestPublishSecretWithFilter(t *testing.T) {
+	ctx := context.Background()
+	api, ts := newFakeAPI(t)
+	service := loadedService(t)
+	publisher := NewPublisher(service, "ns", "orgdata").
+		WithAPIServer(ts.URL, "test-token", ts.Client()).
+		WithSecret().
+		WithKey("squad.json").
+		WithFilter(orgdatacore.SubsetFilter{Teams: []string{"test-squad"}})
+
+	if err := publisher.Publish(ctx); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	_, data := api.object(t, "/api/v1/namespaces/ns/secrets/orgdata")
+	subset, err := service.ExportSubset(orgdatacore.SubsetFilter{Teams: []string{"test-squad"}})
+	if err != nil {
+		t.Fatalf("ExportSubset: %v", err)
+	}
+	// Secret data is base64, which encoding/json decodes into []byte.
+	var decoded struct {
+		Data map[string][]byte `json:"data"`
+	}
+	raw, _ := json.Marshal(map[string]any{"data": data})
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("decode secret: %v", err)
+	}
+	if string(decoded.Data["squad.json"]) != string(subset) {
+		t.Errorf("published secret = %.80s, want the exported subset", decoded.Data["squad.json"])
+	}
+}
+
+func TestPublishErrors(t *testing.T) {
+	ctx := context.Background()
+	_, ts := newFakeAPI(t)
+
+	tests := []struct {
+		name      string
+		publisher *Publisher
+		want      error
+		wantMsg   string
+	}{
+		{
+			name:      "no data",
+			publisher: NewPublisher(orgdatacore.NewService(), "ns", "x").WithAPIServer(ts.URL, "test-token", ts.Client()),
+			want:      orgdatacore.ErrNoData,
+		},
+		{
+			name:      "unauthorized",
+			publisher: NewPublisher(loadedService(t), "ns", "x").WithAPIServer(ts.URL, "wrong", ts.Client()),
+			wantMsg:   "401",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.publisher.Publish(ctx)
+			if err == nil || (tt.want != nil && !errors.Is(err, tt.want)) || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Publish = %v, want %v %s", err, tt.want, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRunPublishesOnReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	api, ts := newFakeAPI(t)
+	service := loadedService(t)
+	publisher := NewPublisher(service, "ns", "orgdata").WithAPIServer(ts.URL, "test-token", ts.Client())
+
+	done := make(chan struct{})
+	go func() {
+		publisher.Run(ctx)
+		close(done)
+	}()
+
+	published := func() string {
+		api.mu.Lock()
+		_, ok := api.objects["/api/v1/namespaces/ns/configmaps/orgdata"]
+		api.mu.Unlock()
+		if !ok {
+			return ""
+		}
+		annotations, _ := api.object(t, "/api/v1/namespaces/ns/configmaps/orgdata")
+		return annotations[ChecksumAnnotation]
+	}
+	waitFor := func(checksum string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for published() != checksum {
+			if time.Now().After(deadline) {
+				t.Fatalf("published checksum = %q, want %q", published(), checksum)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor(dumpChecksum(t, service))
+
+	if err := service.ApplyChangeSet(orgdatacore.ChangeSet{
+		DataVersion:       "test-v2",
+		EmployeesUpserted: map[string]orgdatacore.Employee{"newhire": {UID: "newhire"}},
+	}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	waitFor(dumpChecksum(t, service))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
@@ -1,6 +1,9 @@
 package orgdatacore
 
-import "log/slog"
+import (
+	"context"
+	"log/slog"
+)
 
 var pkgLogger = slog.Default()
 
@@ -15,3 +18,57 @@ func SetLogger(l *slog.Logger) {
 func GetLogger() *slog.Logger {
 	return pkgLogger
 }
+
+// Subsystems whose log level can be set with WithLogLevels.
+const (
+	// LogWatcher is the subsystem of data source watchers.
+	LogWatcher = "watcher"
+	// LogLoader is the subsystem of loading, reloading and rolling back data.
+	LogLoader = "loader"
+	// LogValidator is the subsystem of validating and repairing loaded data.
+	LogValidator = "validator"
+	// LogQuery is the subsystem of query logging; see WithQueryLogging.
+	LogQuery = "query"
+)
+
+// levelHandler passes records at or above level to the wrapped handler,
+// whatever level that handler would enable on its own.
+type levelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (h levelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return levelHandler{h.Handler.WithAttrs(attrs), h.level}
+}
+
+func (h levelHandler) WithGroup(name string) slog.Handler {
+	return levelHandler{h.Handler.WithGroup(name), h.level}
+}
+
+// subsystemLoggers returns a logger for each subsystem with a level in
+// levels, writing to logger's handler at that level. Unknown subsystems are
+// ignored.
+func subsystemLoggers(logger *slog.Logger, levels map[string]slog.Level) map[string]*slog.Logger {
+	loggers := make(map[string]*slog.Logger)
+	for subsystem, level := range levels {
+		switch subsystem {
+		case LogWatcher, LogLoader, LogValidator, LogQuery:
+			loggers[subsystem] = slog.New(levelHandler{logger.Handler(), level})
+		}
+	}
+	return loggers
+}
+
+// loggerFor returns the logger of subsystem, which is the service's logger
+// unless WithLogLevels set a level for it.
+func (s *Service) loggerFor(subsystem string) *slog.Logger {
+	if l, ok := s.loggers[subsystem]; ok {
+		return l
+	}
+	return s.logger
+}
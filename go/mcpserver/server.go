@@ -0,0 +1,226 @@
+// Package mcpserver exposes organizational data queries as Model Context
+// Protocol tools, so LLM assistants can answer ownership questions against
+// live data. It speaks the MCP stdio transport: newline-delimited JSON-RPC
+// 2.0 messages on a reader and writer, normally the process's stdin and
+// stdout.
+//
+//	service := orgdatacore.NewService()
+//	// load data and start a watcher ...
+//	mcpserver.New(service).Serve(ctx, os.Stdin, os.Stdout)
+//
+// The tools:
+//
+//	lookup_employee       an employee by uid, slack_id, github_id or email, with manager and teams
+//	find_owners           teams owning a GitHub repo, a component, or a Jira project or component
+//	get_escalation_chain  a team's escalation contacts and hierarchy, or an employee's management chain
+//
+// Tool results are JSON text. A lookup that finds nothing is a tool error,
+// so the assistant sees the message rather than the call failing.
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// protocolVersions are the MCP revisions the server accepts, newest first.
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Server answers MCP requests from one service.
+type Server struct {
+	svc    orgdatacore.ServiceInterface
+	logger *slog.Logger
+	tools  []tool
+}
+
+// New returns a Server answering tool calls from svc.
+func New(svc orgdatacore.ServiceInterface) *Server {
+	s := &Server{svc: svc, logger: slog.Default()}
+	s.tools = s.registerTools()
+	return s
+}
+
+// WithLogger sets the logger used to report malformed messages, slog.Default
+// by default, and returns s. It must not write to the transport's writer.
+func (s *Server) WithLogger(logger *slog.Logger) *Server {
+	if logger != nil {
+		s.logger = logger
+	}
+	return s
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted or ctx is done. It returns nil at the end of r.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		resp, ok := s.handle(ctx, line)
+		if !ok {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+// handle answers one message. ok is false for notifications, which get no
+// response.
+func (s *Server) handle(ctx context.Context, line []byte) (resp response, ok bool) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		s.logger.Warn("mcp: malformed message", "error", err)
+		return response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}, true
+	}
+	if req.ID == nil {
+		return response{}, false
+	}
+	resp = response{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: codeInvalidRequest, Message: "not a JSON-RPC 2.0 request"}
+		return resp, true
+	}
+
+	result, err := s.dispatch(ctx, req.Method, req.Params)
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		resp.Error = rerr
+		return resp, true
+	}
+	resp.Result = result
+	return resp, true
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, err
+			}
+		}
+		version := protocolVersions[0]
+		if slices.Contains(protocolVersions, p.ProtocolVersion) {
+			version = p.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo": map[string]string{
+				"name":    "cyborg-data",
+				"version": orgdatacore.GetVersionInfo().Version,
+			},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		list := make([]map[string]any, 0, len(s.tools))
+		for _, t := range s.tools {
+			list = append(list, map[string]any{
+				"name":        t.name,
+				"description": t.description,
+				"inputSchema": t.schema(),
+			})
+		}
+		return map[string]any{"tools": list}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, p.Name, p.Arguments)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+	}
+}
+
+// toolResult is the result of tools/call.
+type toolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *Server) callTool(ctx context.Context, name string, args json.RawMessage) (*toolResult, error) {
+	i := slices.IndexFunc(s.tools, func(t tool) bool { return t.name == name })
+	if i < 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", name)}
+	}
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	var params map[string]string
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("arguments must be an object of strings: %v", err)}
+	}
+
+	v, err := s.tools[i].call(ctx, params)
+	if err != nil {
+		return &toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	text, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &toolResult{Content: []textContent{{Type: "text", Text: string(text)}}}, nil
+}
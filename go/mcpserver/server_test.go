@@ -0,0 +1,141 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	service := orgdatacore.NewService()
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	return New(service)
+}
+
+// roundTrip sends one request line and returns the decoded response, or nil
+// if the server sent none.
+func roundTrip(t *testing.T, server *Server, line string) map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(line+"\n"), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	if out.Len() == 0 {
+		return nil
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response %q: %v", out.String(), err)
+	}
+	return resp
+}
+
+func TestProtocol(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name string
+		line string
+		want string // substring of the compact response; "" for no response
+	}{
+		{"initialize", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`, `"protocolVersion":"2024-11-05"`},
+		{"initialize newer", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2099-01-01"}}`, `"protocolVersion":"2025-06-18"`},
+		{"initialized notification", `{"jsonrpc":"2.0","method":"notifications/initialized"}`, ``},
+		{"ping", `{"jsonrpc":"2.0","id":"a","method":"ping"}`, `"result":{}`},
+		{"tools list", `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, `"name":"find_owners"`},
+		{"unknown method", `{"jsonrpc":"2.0","id":3,"method":"resources/list"}`, `"code":-32601`},
+		{"unknown tool", `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"drop_tables"}}`, `"code":-32602`},
+		{"parse error", `{not json`, `"code":-32700`},
+		{"not json-rpc", `{"id":5,"method":"ping"}`, `"code":-32600`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := roundTrip(t, server, tt.line)
+			if tt.want == "" {
+				if resp != nil {
+					t.Fatalf("got response %v, want none", resp)
+				}
+				return
+			}
+			body, _ := json.Marshal(resp)
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("response = %s, want it to contain %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestTools(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		tool    string
+		args    string
+		isError bool
+		want    string // substring of the tool's JSON text
+	}{
+		{"employee by slack id", "lookup_employee", `{"slack_id":"U12345678"}`, false, `"uid":"jsmith"`},
+		{"employee manager", "lookup_employee", `{"uid":"jsmith"}`, false, `"manager":{"uid":"adoe"`},
+		{"employee teams", "lookup_employee", `{"uid":"jsmith"}`, false, `"teams":["test-team"]`},
+		{"missing employee", "lookup_employee", `{"uid":"nobody"}`, true, `no matching employee`},
+		{"no key", "lookup_employee", `{}`, true, `set one of`},
+		{"repo url", "find_owners", `{"repo":"https://github.com/example/test-repo"}`, false, `[{"name":"test-team","type":"team"}]`},
+		{"repo name", "find_owners", `{"repo":"Example/Platform.git"}`, false, `[{"name":"platform-team","type":"team"}]`},
+		{"jira component", "find_owners", `{"jira_project":"TEST","jira_component":"Core"}`, false, `"name":"test-team"`},
+		{"no owners", "find_owners", `{"repo":"example/unknown"}`, true, `no owners found`},
+		{"team escalation", "get_escalation_chain", `{"team":"platform-team"}`, false, `"contacts":[{"name":"Platform on-call"`},
+		{"management chain", "get_escalation_chain", `{"uid":"jsmith"}`, false, `[{"uid":"jsmith"`},
+		{"missing team", "get_escalation_chain", `{"team":"nobody"}`, true, `team "nobody" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tt.tool + `","arguments":` + tt.args + `}}`
+			resp := roundTrip(t, server, line)
+			var result toolResult
+			raw, _ := json.Marshal(resp["result"])
+			if err := json.Unmarshal(raw, &result); err != nil || len(result.Content) != 1 {
+				t.Fatalf("result = %s, want one content item", raw)
+			}
+			if result.IsError != tt.isError {
+				t.Errorf("isError = %v, want %v: %s", result.IsError, tt.isError, result.Content[0].Text)
+			}
+			if text := result.Content[0].Text; !strings.Contains(text, tt.want) {
+				t.Errorf("text = %s, want it to contain %s", text, tt.want)
+			}
+		})
+	}
+}
+
+func TestServeSession(t *testing.T) {
+	server := newTestServer(t)
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d responses, want 2: %s", len(lines), out.String())
+	}
+	if !strings.Contains(lines[1], `"id":2`) {
+		t.Errorf("second response = %s, want id 2", lines[1])
+	}
+}
@@ -0,0 +1,190 @@
+package mcpserver
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// tool is an MCP tool taking string arguments.
+type tool struct {
+	name        string
+	description string
+	args        []toolArg
+	call        func(ctx context.Context, args map[string]string) (any, error)
+}
+
+type toolArg struct {
+	name        string
+	description string
+}
+
+func (t tool) schema() map[string]any {
+	props := make(map[string]any, len(t.args))
+	for _, a := range t.args {
+		props[a.name] = map[string]string{"type": "string", "description": a.description}
+	}
+	return map[string]any{"type": "object", "properties": props}
+}
+
+func (s *Server) registerTools() []tool {
+	return []tool{
+		{
+			name:        "lookup_employee",
+			description: "Look up an employee by exactly one of uid, slack_id, github_id or email. Returns the employee, their manager and their teams.",
+			args: []toolArg{
+				{"uid", "Employee UID (LDAP username)"},
+				{"slack_id", "Slack user ID, e.g. U012ABCDEF"},
+				{"github_id", "GitHub username"},
+				{"email", "Email address"},
+			},
+			call: s.lookupEmployee,
+		},
+		{
+			name:        "find_owners",
+			description: "Find the teams owning a GitHub repository, a component, or a Jira project (optionally narrowed to one of its components). Set one of repo, component or jira_project.",
+			args: []toolArg{
+				{"repo", "GitHub repository, as org/name or a URL"},
+				{"component", "Component name"},
+				{"jira_project", "Jira project key, e.g. OCPBUGS"},
+				{"jira_component", "Jira component within jira_project"},
+			},
+			call: s.findOwners,
+		},
+		{
+			name:        "get_escalation_chain",
+			description: "Get a team's escalation contacts in priority order and its path up the org hierarchy, or an employee's management chain up to the top. Set team or uid.",
+			args: []toolArg{
+				{"team", "Team name"},
+				{"uid", "Employee UID"},
+			},
+			call: s.escalationChain,
+		},
+	}
+}
+
+type employeeResult struct {
+	Employee *orgdatacore.Employee `json:"employee"`
+	Manager  *orgdatacore.Employee `json:"manager,omitempty"`
+	Teams    []string              `json:"teams"`
+}
+
+func (s *Server) lookupEmployee(_ context.Context, args map[string]string) (any, error) {
+	var emp *orgdatacore.Employee
+	switch {
+	case args["uid"] != "":
+		emp = s.svc.GetEmployeeByUID(args["uid"])
+	case args["slack_id"] != "":
+		emp = s.svc.GetEmployeeBySlackID(args["slack_id"])
+	case args["github_id"] != "":
+		emp = s.svc.GetEmployeeByGitHubID(args["github_id"])
+	case args["email"] != "":
+		emp = s.svc.GetEmployeeByEmail(args["email"])
+	default:
+		return nil, errors.New("set one of uid, slack_id, github_id or email")
+	}
+	if emp == nil {
+		return nil, errors.New("no matching employee found")
+	}
+	teams := append([]string{}, s.svc.GetTeamsForUID(emp.UID)...)
+	slices.Sort(teams)
+	return employeeResult{
+		Employee: emp,
+		Manager:  s.svc.GetManagerForEmployee(emp.UID),
+		Teams:    teams,
+	}, nil
+}
+
+// normalizeRepo reduces a repository reference to a comparable key, so that
+// "https://github.com/org/repo.git" and "org/repo" match.
+func normalizeRepo(repo string) string {
+	r := strings.ToLower(strings.TrimSpace(repo))
+	r = strings.TrimPrefix(r, "https://")
+	r = strings.TrimPrefix(r, "http://")
+	r = strings.TrimPrefix(r, "github.com/")
+	r = strings.TrimSuffix(r, "/")
+	return strings.TrimSuffix(r, ".git")
+}
+
+type owner struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func (s *Server) findOwners(_ context.Context, args map[string]string) (any, error) {
+	var owners []owner
+	switch {
+	case args["repo"] != "":
+		repo := normalizeRepo(args["repo"])
+		for _, team := range s.svc.GetAllTeams() {
+			if slices.ContainsFunc(team.Group.Repos, func(r orgdatacore.RepoInfo) bool { return normalizeRepo(r.Repo) == repo }) {
+				owners = append(owners, owner{Name: team.Name, Type: "team"})
+			}
+		}
+	case args["component"] != "":
+		for _, o := range s.svc.GetTeamsForComponent(args["component"]) {
+			owners = append(owners, owner{Name: o.Name, Type: o.Type})
+		}
+	case args["jira_project"] != "":
+		var infos []orgdatacore.JiraOwnerInfo
+		if args["jira_component"] != "" {
+			infos = s.svc.GetTeamsByJiraComponent(args["jira_project"], args["jira_component"])
+		} else {
+			infos = s.svc.GetTeamsByJiraProject(args["jira_project"])
+		}
+		for _, o := range infos {
+			owners = append(owners, owner{Name: o.Name, Type: o.Type})
+		}
+	default:
+		return nil, errors.New("set one of repo, component or jira_project")
+	}
+	if len(owners) == 0 {
+		return nil, errors.New("no owners found")
+	}
+	slices.SortFunc(owners, func(a, b owner) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Type, b.Type))
+	})
+	return owners, nil
+}
+
+type teamEscalation struct {
+	Team      string                              `json:"team"`
+	Contacts  []orgdatacore.EscalationContactInfo `json:"contacts"`
+	Hierarchy []orgdatacore.HierarchyPathEntry    `json:"hierarchy"`
+}
+
+func (s *Server) escalationChain(_ context.Context, args map[string]string) (any, error) {
+	switch {
+	case args["team"] != "":
+		name := args["team"]
+		if s.svc.GetTeamByName(name) == nil {
+			return nil, fmt.Errorf("team %q not found", name)
+		}
+		return teamEscalation{
+			Team:      name,
+			Contacts:  append([]orgdatacore.EscalationContactInfo{}, s.svc.GetTeamEscalation(name)...),
+			Hierarchy: append([]orgdatacore.HierarchyPathEntry{}, s.svc.GetHierarchyPath(name, "team")...),
+		}, nil
+	case args["uid"] != "":
+		emp := s.svc.GetEmployeeByUID(args["uid"])
+		if emp == nil {
+			return nil, fmt.Errorf("employee %q not found", args["uid"])
+		}
+		// The chain starts with the employee; seen guards against cycles
+		// in bad data.
+		chain := []orgdatacore.Employee{*emp}
+		seen := map[string]bool{emp.UID: true}
+		for m := s.svc.GetManagerForEmployee(emp.UID); m != nil && !seen[m.UID]; m = s.svc.GetManagerForEmployee(m.UID) {
+			seen[m.UID] = true
+			chain = append(chain, *m)
+		}
+		return chain, nil
+	default:
+		return nil, errors.New("set team or uid")
+	}
+}
@@ -0,0 +1,109 @@
+package orgdatacore
+
+import (
+	"maps"
+	"slices"
+	"sort"
+)
+
+// MembershipEvent is one change to who is where in the org, found by
+// comparing the data before and after a reload.
+type MembershipEvent struct {
+	Type MembershipEventType `json:"type"`
+	// UID is the employee, for every type but TeamMoved.
+	UID string `json:"uid,omitempty"`
+	// Team is the team joined, left or moved.
+	Team string `json:"team,omitempty"`
+	// Org is the organization joined or left, directly or through a team.
+	Org string `json:"org,omitempty"`
+	// OldManagerUID and NewManagerUID are set for ManagerChanged.
+	OldManagerUID string `json:"old_manager_uid,omitempty"`
+	NewManagerUID string `json:"new_manager_uid,omitempty"`
+	// OldParent and NewParent are set for TeamMoved. A nil parent means the
+	// team was a root.
+	OldParent *ParentInfo `json:"old_parent,omitempty"`
+	NewParent *ParentInfo `json:"new_parent,omitempty"`
+}
+
+// OnMembershipEvents registers fn to be called with the membership events of
+// every reload that has any: employees joining or leaving teams and orgs,
+// including by being added or removed, managers changing, and teams moving
+// in the hierarchy. It is not called on the first load. The returned
+// function unregisters fn.
+//
+// Events are ordered by type, in the order the MembershipEventType constants
+// are declared, then by employee or team. fn runs like an OnReload callback,
+// and must not modify events, which is shared between callbacks.
+func (s *Service) OnMembershipEvents(fn func(old, new DataVersion, events []MembershipEvent)) (unsubscribe func()) {
+	if fn == nil {
+		return func() {}
+	}
+	return s.subscribeReload(reloadSubscriber{eventsFn: fn})
+}
+
+// membershipEvents returns the membership events between a and b, whose diff
+// is changes.
+func membershipEvents(a, b *Data, changes *ChangeSet) []MembershipEvent {
+	var joinedTeams, leftTeams, joinedOrgs, leftOrgs, managers, moves []MembershipEvent
+
+	for _, c := range changes.MembershipChanges {
+		for _, team := range c.TeamsAdded {
+			joinedTeams = append(joinedTeams, MembershipEvent{Type: EmployeeJoinedTeam, UID: c.UID, Team: team})
+		}
+		for _, team := range c.TeamsRemoved {
+			leftTeams = append(leftTeams, MembershipEvent{Type: EmployeeLeftTeam, UID: c.UID, Team: team})
+		}
+	}
+
+	// Org memberships of employees whose membership list changed, or who
+	// were removed along with it.
+	before, after := a.Indexes.Membership.MembershipIndex, b.Indexes.Membership.MembershipIndex
+	uids := make([]string, 0, len(changes.MembershipUpserted))
+	for uid := range changes.MembershipUpserted {
+		uids = append(uids, uid)
+	}
+	for uid := range before {
+		if _, ok := after[uid]; !ok {
+			uids = append(uids, uid)
+		}
+	}
+	sort.Strings(uids)
+	for _, uid := range uids {
+		oldOrgs, newOrgs := orgSet(before[uid]), orgSet(after[uid])
+		for _, org := range slices.Sorted(maps.Keys(newOrgs)) {
+			if !oldOrgs[org] {
+				joinedOrgs = append(joinedOrgs, MembershipEvent{Type: EmployeeJoinedOrg, UID: uid, Org: org})
+			}
+		}
+		for _, org := range slices.Sorted(maps.Keys(oldOrgs)) {
+			if !newOrgs[org] {
+				leftOrgs = append(leftOrgs, MembershipEvent{Type: EmployeeLeftOrg, UID: uid, Org: org})
+			}
+		}
+	}
+
+	for _, c := range changes.ManagerChanges {
+		managers = append(managers, MembershipEvent{Type: ManagerChanged, UID: c.UID, OldManagerUID: c.OldManagerUID, NewManagerUID: c.NewManagerUID})
+	}
+	for _, m := range changes.HierarchyMoves {
+		if m.Type == EntityTeam {
+			moves = append(moves, MembershipEvent{Type: TeamMoved, Team: m.Name, OldParent: m.OldParent, NewParent: m.NewParent})
+		}
+	}
+
+	var events []MembershipEvent
+	for _, group := range [][]MembershipEvent{joinedTeams, leftTeams, joinedOrgs, leftOrgs, managers, moves} {
+		events = append(events, group...)
+	}
+	return events
+}
+
+func orgSet(memberships []MembershipInfo) map[string]bool {
+	orgs := make(map[string]bool)
+	for _, m := range memberships {
+		if m.Type == string(MembershipOrg) {
+			orgs[m.Name] = true
+		}
+	}
+	return orgs
+}
@@ -0,0 +1,85 @@
+package orgdatacore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMembershipEvents(t *testing.T) {
+	a := diffTestData()
+	b := applyChangeSet(a, &ChangeSet{
+		EmployeesUpserted: map[string]Employee{
+			"bob":  {UID: "bob", FullName: "Bob", ManagerUID: "carol"},
+			"dave": {UID: "dave", FullName: "Dave", ManagerUID: "alice"},
+		},
+		EmployeesRemoved: []string{"carol"},
+		TeamsUpserted: map[string]Team{
+			"team-b": {Name: "team-b", Parent: &ParentInfo{Name: "org-2", Type: "org"}},
+		},
+		MembershipUpserted: map[string][]MembershipInfo{
+			"bob":  {{Name: "team-b", Type: "team"}, {Name: "org-2", Type: "org"}},
+			"dave": {{Name: "team-a", Type: "team"}, {Name: "org-1", Type: "org"}},
+		},
+	})
+
+	got := membershipEvents(a, b, DiffSnapshots(a, b))
+	want := []MembershipEvent{
+		{Type: EmployeeJoinedTeam, UID: "bob", Team: "team-b"},
+		{Type: EmployeeJoinedTeam, UID: "dave", Team: "team-a"},
+		{Type: EmployeeLeftTeam, UID: "bob", Team: "team-a"},
+		{Type: EmployeeLeftTeam, UID: "carol", Team: "team-b"},
+		{Type: EmployeeJoinedOrg, UID: "bob", Org: "org-2"},
+		{Type: EmployeeJoinedOrg, UID: "dave", Org: "org-1"},
+		{Type: EmployeeLeftOrg, UID: "bob", Org: "org-1"},
+		{Type: EmployeeLeftOrg, UID: "carol", Org: "org-1"},
+		{Type: ManagerChanged, UID: "bob", OldManagerUID: "alice", NewManagerUID: "carol"},
+		{Type: TeamMoved, Team: "team-b", OldParent: &ParentInfo{Name: "org-1", Type: "org"}, NewParent: &ParentInfo{Name: "org-2", Type: "org"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("membershipEvents =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if events := membershipEvents(a, a, DiffSnapshots(a, a)); len(events) != 0 {
+		t.Errorf("membershipEvents of identical data = %+v, want none", events)
+	}
+}
+
+func TestOnMembershipEvents(t *testing.T) {
+	service := setupTestService(t)
+
+	var batches [][]MembershipEvent
+	unsubscribe := service.OnMembershipEvents(func(old, new DataVersion, events []MembershipEvent) {
+		batches = append(batches, events)
+	})
+
+	if err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"bwilson"}}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Fatalf("callback ran %d times, want 1", len(batches))
+	}
+	want := []MembershipEvent{
+		{Type: EmployeeLeftTeam, UID: "bwilson", Team: "platform-team"},
+		{Type: EmployeeLeftOrg, UID: "bwilson", Org: "platform-org"},
+		{Type: EmployeeLeftOrg, UID: "bwilson", Org: "test-org"},
+	}
+	if !reflect.DeepEqual(batches[0], want) {
+		t.Errorf("events = %+v, want %+v", batches[0], want)
+	}
+
+	// A reload that changes no memberships does not call back.
+	if err := service.ApplyChangeSet(ChangeSet{DataVersion: "unchanged-members"}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Errorf("callback ran for a reload without membership events: %+v", batches[1:])
+	}
+
+	unsubscribe()
+	if err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"adoe"}}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if len(batches) != 1 {
+		t.Error("callback ran after unsubscribe")
+	}
+}
@@ -0,0 +1,248 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// MergeFunc combines the data loaded from several sources, in source order,
+// into one dataset. It must not modify parts.
+type MergeFunc func(parts []*Data) (*Data, error)
+
+// MergedDataSource is a DataSource that combines several sources into one
+// dataset. Every load fetches all sources, merges them, and only then hands
+// the result to the Service, which validates and swaps it in as a whole: a
+// failing source fails the whole load and the service never serves a
+// half-updated combination.
+//
+// Use it wherever a DataSource is accepted, including watchers; a change in
+// any source reloads all of them.
+type MergedDataSource struct {
+	sources []DataSource
+	merge   MergeFunc
+
+	// reloadMu serializes Watch callbacks so concurrent changes in several
+	// sources trigger one coordinated reload at a time.
+	reloadMu sync.Mutex
+}
+
+// NewMergedDataSource returns a DataSource that merges sources with merge.
+// A nil merge uses MergeOverlay.
+func NewMergedDataSource(merge MergeFunc, sources ...DataSource) *MergedDataSource {
+	if merge == nil {
+		merge = MergeOverlay
+	}
+	return &MergedDataSource{sources: sources, merge: merge}
+}
+
+// Load fetches and merges every source and returns the merged data as JSON.
+// The Service does not use it: it merges the decoded data directly.
+func (m *MergedDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	data, _, err := m.loadData(ctx, func(r io.Reader) (*Data, error) { return decodeData(r, nil) }, nil)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(raw)), nil
+}
+
+// loadData fetches and decodes every source concurrently and merges them.
+// It returns the number of bytes read across all sources. If h is set, the
+// SHA-256 of each source's payload is written to it, in source order.
+func (m *MergedDataSource) loadData(ctx context.Context, decode func(io.Reader) (*Data, error), h io.Writer) (*Data, int64, error) {
+	if len(m.sources) == 0 {
+		return nil, 0, fmt.Errorf("%w: merged data source has no sources", ErrInvalidConfig)
+	}
+
+	parts := make([]*Data, len(m.sources))
+	sizes := make([]int64, len(m.sources))
+	sums := make([][]byte, len(m.sources))
+	errs := make([]error, len(m.sources))
+
+	var wg sync.WaitGroup
+	for i, source := range m.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts[i], sizes[i], sums[i], errs[i] = loadPart(ctx, source, decode)
+		}()
+	}
+	wg.Wait()
+
+	var total int64
+	for _, n := range sizes {
+		total += n
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, total, err
+	}
+
+	data, err := m.merge(parts)
+	if err != nil {
+		return nil, total, fmt.Errorf("failed to merge sources: %w", err)
+	}
+	if h != nil {
+		for _, sum := range sums {
+			h.Write(sum)
+		}
+	}
+	return data, total, nil
+}
+
+// loadPart fetches and decodes source, returning the payload's size and
+// SHA-256.
+func loadPart(ctx context.Context, source DataSource, decode func(io.Reader) (*Data, error)) (*Data, int64, []byte, error) {
+	reader, err := source.Load(ctx)
+	if err != nil {
+		return nil, 0, nil, NewLoadError(source.String(), err)
+	}
+	defer reader.Close()
+
+	var n int64
+	h := sha256.New()
+	data, err := decode(&countingReader{r: reader, n: &n, h: h})
+	if err != nil {
+		return nil, n, nil, NewLoadError(source.String(), fmt.Errorf("failed to parse JSON: %w", err))
+	}
+	return data, n, h.Sum(nil), nil
+}
+
+// Watch watches every source and calls callback when any of them changes.
+// It returns once every source's Watch has returned, or as soon as one fails,
+// in which case the others are stopped.
+func (m *MergedDataSource) Watch(ctx context.Context, callback func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	onChange := func() error {
+		m.reloadMu.Lock()
+		defer m.reloadMu.Unlock()
+		return callback()
+	}
+
+	errs := make(chan error, len(m.sources))
+	for _, source := range m.sources {
+		go func() {
+			err := source.Watch(ctx, onChange)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", source.String(), err)
+			}
+			errs <- err
+		}()
+	}
+
+	var first error
+	for range m.sources {
+		if err := <-errs; err != nil && first == nil {
+			first = err
+			cancel()
+		}
+	}
+	return first
+}
+
+func (m *MergedDataSource) String() string {
+	names := make([]string, len(m.sources))
+	for i, source := range m.sources {
+		names[i] = source.String()
+	}
+	return "merged(" + strings.Join(names, ", ") + ")"
+}
+
+// Close closes every source.
+func (m *MergedDataSource) Close() error {
+	var errs []error
+	for _, source := range m.sources {
+		errs = append(errs, source.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// MergeOverlay merges parts so later parts override earlier ones: entities,
+// memberships and ID mappings are combined by key, with the last part that
+// defines a key winning. The merged data_version joins the parts' versions
+// with "+", so a change in any part changes it, and generated_at is that of
+// the oldest part, so staleness checks see the most outdated source.
+func MergeOverlay(parts []*Data) (*Data, error) {
+	merged := &Data{}
+	versions := make([]string, 0, len(parts))
+	first := true
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		if first {
+			merged.Metadata = part.Metadata
+			merged.Metadata.ContextTypeDescriptions = nil
+			first = false
+		}
+		if part.Metadata.DataVersion != "" {
+			versions = append(versions, part.Metadata.DataVersion)
+		}
+		if olderThan(part.Metadata.GeneratedAt, merged.Metadata.GeneratedAt) {
+			merged.Metadata.GeneratedAt = part.Metadata.GeneratedAt
+		}
+		merged.Metadata.PIIFree = merged.Metadata.PIIFree && part.Metadata.PIIFree
+		merged.Metadata.ContextTypeDescriptions = overlay(merged.Metadata.ContextTypeDescriptions, part.Metadata.ContextTypeDescriptions)
+
+		merged.Lookups.Employees = overlay(merged.Lookups.Employees, part.Lookups.Employees)
+		merged.Lookups.Teams = overlay(merged.Lookups.Teams, part.Lookups.Teams)
+		merged.Lookups.Orgs = overlay(merged.Lookups.Orgs, part.Lookups.Orgs)
+		merged.Lookups.Pillars = overlay(merged.Lookups.Pillars, part.Lookups.Pillars)
+		merged.Lookups.TeamGroups = overlay(merged.Lookups.TeamGroups, part.Lookups.TeamGroups)
+		merged.Lookups.Components = overlay(merged.Lookups.Components, part.Lookups.Components)
+
+		merged.Indexes.Membership.MembershipIndex = overlay(merged.Indexes.Membership.MembershipIndex, part.Indexes.Membership.MembershipIndex)
+		merged.Indexes.SlackIDMappings.SlackUIDToUID = overlay(merged.Indexes.SlackIDMappings.SlackUIDToUID, part.Indexes.SlackIDMappings.SlackUIDToUID)
+		merged.Indexes.GitHubIDMappings.GitHubIDToUID = overlay(merged.Indexes.GitHubIDMappings.GitHubIDToUID, part.Indexes.GitHubIDMappings.GitHubIDToUID)
+		merged.Indexes.ComponentOwnership = overlay(merged.Indexes.ComponentOwnership, part.Indexes.ComponentOwnership)
+		for project, components := range part.Indexes.Jira {
+			if merged.Indexes.Jira == nil {
+				merged.Indexes.Jira = make(JiraIndex)
+			}
+			merged.Indexes.Jira[project] = overlay(merged.Indexes.Jira[project], components)
+		}
+	}
+
+	merged.Metadata.DataVersion = strings.Join(versions, "+")
+	merged.Metadata.TotalEmployees = len(merged.Lookups.Employees)
+	merged.Metadata.TotalOrgs = len(merged.Lookups.Orgs)
+	merged.Metadata.TotalTeams = len(merged.Lookups.Teams)
+	return merged, nil
+}
+
+// overlay copies src over dst, allocating dst if needed. It never modifies
+// src, so maps from the parts are not shared with the merged result.
+func overlay[K comparable, V any](dst, src map[K]V) map[K]V {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[K]V, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// olderThan reports whether generated_at a is before b. Unparseable values
+// are never older.
+func olderThan(a, b string) bool {
+	ta, okA := parseGeneratedAt(a)
+	tb, okB := parseGeneratedAt(b)
+	if !okA {
+		return false
+	}
+	return !okB || ta.Before(tb)
+}
@@ -0,0 +1,192 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// overridesData is a partial dataset adding one employee and reassigning
+// testuser2's Slack ID on top of CreateTestData.
+func overridesData() *Data {
+	return &Data{
+		Metadata: Metadata{GeneratedAt: "2023-12-31T00:00:00Z", DataVersion: "overrides-v3"},
+		Lookups: Lookups{
+			Employees: map[string]Employee{
+				"testuser2":  {UID: "testuser2", FullName: "Test User Two", SlackUID: "U999999"},
+				"contractor": {UID: "contractor", FullName: "Contractor", SlackUID: "U333333"},
+			},
+		},
+		Indexes: Indexes{
+			Membership: MembershipIndex{MembershipIndex: map[string][]MembershipInfo{
+				"contractor": {{Name: "test-squad", Type: "team"}},
+			}},
+			SlackIDMappings: SlackIDMappings{SlackUIDToUID: map[string]string{"U999999": "testuser2", "U333333": "contractor"}},
+			Jira:            JiraIndex{"PROJ": {"api": {{Name: "test-squad", Type: "team"}}}},
+		},
+	}
+}
+
+func TestMergeOverlay(t *testing.T) {
+	base, overrides := CreateTestData(), overridesData()
+	baseEmployees := len(base.Lookups.Employees)
+
+	merged, err := MergeOverlay([]*Data{base, nil, overrides})
+	if err != nil {
+		t.Fatalf("MergeOverlay: %v", err)
+	}
+
+	if got := merged.Metadata.DataVersion; got != "test-v1.0+overrides-v3" {
+		t.Errorf("DataVersion = %q, want test-v1.0+overrides-v3", got)
+	}
+	if got := merged.Metadata.GeneratedAt; got != "2023-12-31T00:00:00Z" {
+		t.Errorf("GeneratedAt = %q, want the oldest part's", got)
+	}
+	if got := merged.Metadata.TotalEmployees; got != 3 {
+		t.Errorf("TotalEmployees = %d, want 3", got)
+	}
+	if got := merged.Lookups.Employees["testuser2"].SlackUID; got != "U999999" {
+		t.Errorf("testuser2 SlackUID = %q, want the override", got)
+	}
+	if _, ok := merged.Lookups.Teams["test-squad"]; !ok {
+		t.Error("teams from the base part were dropped")
+	}
+	if got := merged.Indexes.SlackIDMappings.SlackUIDToUID["U333333"]; got != "contractor" {
+		t.Errorf("slack mapping U333333 = %q, want contractor", got)
+	}
+	if got := merged.Indexes.Jira["PROJ"]["api"]; len(got) != 1 {
+		t.Errorf("jira PROJ/api = %v, want one owner", got)
+	}
+	if len(base.Lookups.Employees) != baseEmployees || base.Indexes.SlackIDMappings.SlackUIDToUID["U333333"] != "" {
+		t.Error("MergeOverlay modified its input")
+	}
+}
+
+func jsonSource(t *testing.T, name string, data *Data) *FakeDataSource {
+	t.Helper()
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", name, err)
+	}
+	source := NewFakeDataSource(string(raw))
+	source.Description = name
+	return source
+}
+
+func TestMergedDataSource(t *testing.T) {
+	ctx := context.Background()
+	base := jsonSource(t, "base", CreateTestData())
+	overrides := jsonSource(t, "overrides", overridesData())
+	source := NewMergedDataSource(nil, base, overrides)
+
+	if got := source.String(); got != "merged(base, overrides)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	service := NewService()
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if emp := service.GetEmployeeBySlackID("U333333"); emp == nil || emp.UID != "contractor" {
+		t.Errorf("GetEmployeeBySlackID(U333333) = %v, want contractor", emp)
+	}
+	if !service.IsEmployeeInTeam("testuser1", "test-squad") {
+		t.Error("base memberships missing after merge")
+	}
+	if got := service.LoadStats().LastPayloadBytes; got != int64(len(base.Data)+len(overrides.Data)) {
+		t.Errorf("LastPayloadBytes = %d, want the sum of both sources", got)
+	}
+
+	// A failing source fails the whole load and leaves the merged data in place.
+	before := service.GetVersion()
+	overrides.LoadError = errors.New("overrides unavailable")
+	if err := service.LoadFromDataSource(ctx, source); err == nil {
+		t.Fatal("expected load to fail when one source fails")
+	}
+	if after := service.GetVersion(); !after.LoadTime.Equal(before.LoadTime) {
+		t.Error("data was swapped despite a failed source")
+	}
+	if emp := service.GetEmployeeByUID("contractor"); emp == nil {
+		t.Error("override data lost after failed load")
+	}
+
+	// Load returns the merged document for callers outside the Service.
+	overrides.LoadError = nil
+	reader, err := source.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	defer reader.Close()
+	var decoded Data
+	if err := json.NewDecoder(reader).Decode(&decoded); err != nil {
+		t.Fatalf("decode merged document: %v", err)
+	}
+	if len(decoded.Lookups.Employees) != 3 {
+		t.Errorf("merged document has %d employees, want 3", len(decoded.Lookups.Employees))
+	}
+
+	if err := source.Close(); err != nil || !base.CloseCalled || !overrides.CloseCalled {
+		t.Errorf("Close() = %v, closed base=%v overrides=%v", err, base.CloseCalled, overrides.CloseCalled)
+	}
+}
+
+func TestMergedDataSourceWatch(t *testing.T) {
+	primary, secondary := &pollingDataSource{}, &pollingDataSource{}
+	source := NewMergedDataSource(nil, primary, secondary)
+
+	var reloads int
+	service := NewService()
+	service.OnReload(func(_, _ DataVersion) { reloads++ })
+	if err := service.StartNamedWatcher(context.Background(), "merged", source); err != nil {
+		t.Fatalf("StartNamedWatcher: %v", err)
+	}
+	defer service.StopWatcher()
+
+	// A change in either source reloads both.
+	if err := secondary.callback(); err != nil {
+		t.Fatalf("reload after secondary change: %v", err)
+	}
+	primary.fail.Store(true)
+	if err := secondary.callback(); err == nil {
+		t.Fatal("expected reload to fail while primary is failing")
+	}
+	if reloads != 2 {
+		t.Errorf("got %d reloads, want 2 (initial load and one successful watch reload)", reloads)
+	}
+
+	watchErr := errors.New("watch failed")
+	failing := NewFakeDataSource(CreateTestDataJSON())
+	failing.WatchError = watchErr
+	err := NewMergedDataSource(nil, failing, &pollingDataSource{}).Watch(context.Background(), func() error { return nil })
+	if !errors.Is(err, watchErr) {
+		t.Errorf("Watch() = %v, want it to wrap the failing source's error", err)
+	}
+}
+
+func TestMergedDataSourceNoSources(t *testing.T) {
+	err := NewService().LoadFromDataSource(context.Background(), NewMergedDataSource(nil))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("LoadFromDataSource(empty merge) = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestMergedDataSourceCustomMerge(t *testing.T) {
+	var gotParts int
+	merge := func(parts []*Data) (*Data, error) {
+		gotParts = len(parts)
+		return parts[0], nil
+	}
+	source := NewMergedDataSource(merge, jsonSource(t, "a", CreateTestData()), jsonSource(t, "b", overridesData()))
+
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if gotParts != 2 {
+		t.Errorf("merge received %d parts, want 2", gotParts)
+	}
+	if got := service.GetAllEmployeeUIDs(); len(got) != 2 {
+		t.Errorf("employees = %v, want only the first part's", got)
+	}
+}
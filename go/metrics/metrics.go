@@ -0,0 +1,243 @@
+// Package metrics exposes orgdatacore service metrics to Prometheus. A
+// Registry is a prometheus.Collector, so it can be registered on the
+// application's own prometheus.Registerer and scraped from its existing
+// /metrics handler:
+//
+//	reg := metrics.NewRegistry()
+//	service := orgdatacore.NewService(orgdatacore.WithMetrics(reg))
+//	prometheus.MustRegister(reg)
+//
+// Programs without a Prometheus registry can serve it on its own instead:
+//
+//	http.Handle("/metrics", reg)
+//
+// Reload, data and watcher metrics are read from the service on every scrape.
+// Query metrics are recorded by passing the Registry as a QueryHook to a
+// ProfiledService:
+//
+//	svc := orgdatacore.NewProfiledService(service, reg)
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// QueryDurationBuckets are the upper bounds, in seconds, of the query latency
+// histogram. Lookups are in-memory, so most fall in the microsecond buckets.
+var QueryDurationBuckets = []float64{
+	0.000001, 0.0000025, 0.000005, 0.00001, 0.000025, 0.00005,
+	0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.1,
+}
+
+// Registry collects metrics for one orgdatacore.Service and the queries made
+// through a ProfiledService.
+type Registry struct {
+	service atomic.Pointer[orgdatacore.Service]
+
+	queries       *prometheus.CounterVec
+	queryDuration *prometheus.HistogramVec
+
+	// own serves the Registry alone, for ServeHTTP and WriteTo.
+	own *prometheus.Registry
+}
+
+var (
+	_ orgdatacore.MetricsRegisterer = (*Registry)(nil)
+	_ orgdatacore.QueryHook         = (*Registry)(nil)
+	_ prometheus.Collector          = (*Registry)(nil)
+	_ http.Handler                  = (*Registry)(nil)
+)
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	r := &Registry{
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "orgdata_queries_total",
+			Help: "Queries made through a ProfiledService, by method.",
+		}, []string{"method"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "orgdata_query_duration_seconds",
+			Help:    "Query latency, by method.",
+			Buckets: QueryDurationBuckets,
+		}, []string{"method"}),
+		own: prometheus.NewRegistry(),
+	}
+	r.own.MustRegister(r)
+	return r
+}
+
+// RegisterService makes r report on s. A Registry reports on one service;
+// registering another replaces it.
+func (r *Registry) RegisterService(s *orgdatacore.Service) {
+	r.service.Store(s)
+}
+
+// BeforeQuery implements orgdatacore.QueryHook.
+func (r *Registry) BeforeQuery(ctx context.Context, _ orgdatacore.QueryInfo) context.Context {
+	return ctx
+}
+
+// AfterQuery implements orgdatacore.QueryHook by counting the query and
+// recording its latency.
+func (r *Registry) AfterQuery(_ context.Context, q orgdatacore.QueryInfo, elapsed time.Duration) {
+	r.queries.WithLabelValues(q.Method).Inc()
+	r.queryDuration.WithLabelValues(q.Method).Observe(elapsed.Seconds())
+}
+
+// ServeHTTP serves the Registry's metrics alone, for programs without a
+// Prometheus registry of their own.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	promhttp.HandlerFor(r.own, promhttp.HandlerOpts{}).ServeHTTP(w, req)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	families, err := r.own.Gather()
+	if err != nil {
+		return 0, err
+	}
+	cw := &countingWriter{w: w}
+	enc := expfmt.NewEncoder(cw, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, mf := range families {
+		if err := enc.Encode(mf); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+var (
+	loadAttemptsDesc = prometheus.NewDesc("orgdata_load_attempts_total",
+		"Load attempts, including watcher reloads and change sets.", nil, nil)
+	loadFailuresDesc = prometheus.NewDesc("orgdata_load_failures_total",
+		"Failed load attempts.", nil, nil)
+	loadConsecutiveFailuresDesc = prometheus.NewDesc("orgdata_load_consecutive_failures",
+		"Failed load attempts since the last success.", nil, nil)
+	loadDurationDesc = prometheus.NewDesc("orgdata_load_duration_seconds_total",
+		"Time spent loading data.", nil, nil)
+	loadPhaseDesc = prometheus.NewDesc("orgdata_load_phase_seconds_total",
+		"Time spent loading data, by phase.", []string{"phase"}, nil)
+	loadPayloadDesc = prometheus.NewDesc("orgdata_load_payload_bytes_total",
+		"Bytes read from data sources.", nil, nil)
+	lastLoadDurationDesc = prometheus.NewDesc("orgdata_last_load_duration_seconds",
+		"Duration of the most recent load attempt.", nil, nil)
+	lastLoadSuccessDesc = prometheus.NewDesc("orgdata_last_load_success_timestamp_seconds",
+		"Unix time of the last successful load.", nil, nil)
+	dataLoadedDesc = prometheus.NewDesc("orgdata_data_loaded",
+		"Whether any data is loaded.", nil, nil)
+	dataAgeDesc = prometheus.NewDesc("orgdata_data_age_seconds",
+		"Time since the current data was loaded.", nil, nil)
+	degradedDesc = prometheus.NewDesc("orgdata_degraded",
+		"Whether the service has no data or stale data under its maximum age.", nil, nil)
+	entitiesDesc = prometheus.NewDesc("orgdata_entities",
+		"Loaded entities, by type.", []string{"type"}, nil)
+	watcherFailuresDesc = prometheus.NewDesc("orgdata_watcher_consecutive_failures",
+		"Failed checks and reloads since the watcher's last success.", []string{"watcher"}, nil)
+	watcherLastSuccessDesc = prometheus.NewDesc("orgdata_watcher_last_success_timestamp_seconds",
+		"Unix time of the watcher's last successful load.", []string{"watcher"}, nil)
+)
+
+// Describe implements prometheus.Collector.
+func (r *Registry) Describe(ch chan<- *prometheus.Desc) {
+	r.queries.Describe(ch)
+	r.queryDuration.Describe(ch)
+	for _, d := range []*prometheus.Desc{
+		loadAttemptsDesc, loadFailuresDesc, loadConsecutiveFailuresDesc, loadDurationDesc,
+		loadPhaseDesc, loadPayloadDesc, lastLoadDurationDesc, lastLoadSuccessDesc,
+		dataLoadedDesc, dataAgeDesc, degradedDesc, entitiesDesc,
+		watcherFailuresDesc, watcherLastSuccessDesc,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector. The service metrics are read from
+// the registered service, and left out until one is registered.
+func (r *Registry) Collect(ch chan<- prometheus.Metric) {
+	r.queries.Collect(ch)
+	r.queryDuration.Collect(ch)
+	if s := r.service.Load(); s != nil {
+		collectService(ch, s)
+	}
+}
+
+func collectService(ch chan<- prometheus.Metric, s *orgdatacore.Service) {
+	counter := func(d *prometheus.Desc, v float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v, labels...)
+	}
+	gauge := func(d *prometheus.Desc, v float64, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, labels...)
+	}
+
+	stats := s.LoadStats()
+	counter(loadAttemptsDesc, float64(stats.Attempts))
+	counter(loadFailuresDesc, float64(stats.Failures))
+	gauge(loadConsecutiveFailuresDesc, float64(stats.ConsecutiveFailures))
+	counter(loadDurationDesc, stats.TotalDuration.Seconds())
+	counter(loadPhaseDesc, stats.TotalPhases.Download.Seconds(), "download")
+	counter(loadPhaseDesc, stats.TotalPhases.Decode.Seconds(), "decode")
+	counter(loadPhaseDesc, stats.TotalPhases.Validate.Seconds(), "validate")
+	counter(loadPhaseDesc, stats.TotalPhases.Index.Seconds(), "index")
+	counter(loadPhaseDesc, stats.TotalPhases.Swap.Seconds(), "swap")
+	counter(loadPayloadDesc, float64(stats.TotalPayloadBytes))
+	gauge(lastLoadDurationDesc, stats.LastDuration.Seconds())
+	gauge(lastLoadSuccessDesc, unixSeconds(stats.LastSuccess))
+
+	gauge(dataLoadedDesc, boolValue(!s.GetVersion().LoadTime.IsZero()))
+	gauge(dataAgeDesc, s.GetDataAge().Seconds())
+	gauge(degradedDesc, boolValue(s.IsDegraded()))
+
+	gauge(entitiesDesc, float64(count(s.AllEmployeeUIDs())), "employee")
+	gauge(entitiesDesc, float64(count(s.AllTeamNames())), "team")
+	gauge(entitiesDesc, float64(count(s.AllOrgNames())), "org")
+	gauge(entitiesDesc, float64(count(s.AllPillarNames())), "pillar")
+	gauge(entitiesDesc, float64(count(s.AllTeamGroupNames())), "team_group")
+
+	for _, st := range s.WatcherStatus() {
+		gauge(watcherFailuresDesc, float64(st.ConsecutiveFailures), st.Name)
+		gauge(watcherLastSuccessDesc, unixSeconds(st.LastSuccess), st.Name)
+	}
+}
+
+func count(seq func(func(string) bool)) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+func unixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / 1e9
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	service := orgdatacore.NewService(orgdatacore.WithMetrics(reg))
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	svc := orgdatacore.NewProfiledService(service, reg)
+	svc.GetEmployeeByUID("jsmith")
+	svc.GetEmployeeByUID("nobody")
+	svc.IsEmployeeInTeam("jsmith", "test-team")
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	out := string(body)
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	for _, want := range []string{
+		"# TYPE orgdata_queries_total counter",
+		`orgdata_queries_total{method="GetEmployeeByUID"} 2`,
+		`orgdata_queries_total{method="IsEmployeeInTeam"} 1`,
+		`orgdata_query_duration_seconds_bucket{method="GetEmployeeByUID",le="+Inf"} 2`,
+		`orgdata_query_duration_seconds_count{method="GetEmployeeByUID"} 2`,
+		"orgdata_load_attempts_total 1",
+		"orgdata_load_failures_total 0",
+		"# TYPE orgdata_load_phase_seconds_total counter",
+		"orgdata_data_loaded 1",
+		"orgdata_degraded 0",
+		`orgdata_entities{type="employee"} 3`,
+		`orgdata_entities{type="team"} 2`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("metrics output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestRegistryCollector(t *testing.T) {
+	reg := NewRegistry()
+	service := orgdatacore.NewService(orgdatacore.WithMetrics(reg))
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	orgdatacore.NewProfiledService(service, reg).GetEmployeeByUID("testuser1")
+
+	// A pedantic registry checks that Collect matches Describe.
+	app := prometheus.NewPedanticRegistry()
+	if err := app.Register(reg); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	families, err := app.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, mf := range families {
+		got[mf.GetName()] = true
+	}
+	for _, name := range []string{"orgdata_queries_total", "orgdata_query_duration_seconds", "orgdata_load_attempts_total", "orgdata_entities"} {
+		if !got[name] {
+			t.Errorf("Gather() has no %s", name)
+		}
+	}
+}
+
+func TestRegistryWithoutService(t *testing.T) {
+	var b strings.Builder
+	if _, err := NewRegistry().WriteTo(&b); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if strings.Contains(b.String(), "orgdata_load_attempts_total") {
+		t.Error("service metrics written without a registered service")
+	}
+}
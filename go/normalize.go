@@ -0,0 +1,94 @@
+package orgdatacore
+
+import "strings"
+
+// Normalization says how names and emails are normalized before lookups
+// compare them, set with WithLookupNormalization. Strings pasted from Slack
+// often carry non-breaking spaces, smart quotes or doubled spaces that make
+// an exact match fail; normalizing both the stored names and the query lets
+// them match anyway. Each field enables one step, applied in the order
+// listed.
+type Normalization struct {
+	// Unicode, if set, normalizes the Unicode of the string first, for
+	// example with compatibility normalization, which turns fullwidth
+	// letters into ASCII, or by folding diacritics. The unicodenorm
+	// subpackage implements both; this package has no Unicode tables of
+	// its own.
+	Unicode UnicodeNormalizer
+	// FoldQuotes replaces typographic quotes with ASCII ' and ".
+	FoldQuotes bool
+	// TrimSpace removes leading and trailing white space, including
+	// non-breaking spaces.
+	TrimSpace bool
+	// CollapseSpace replaces each run of white space, including
+	// non-breaking spaces, with a single space, and trims like TrimSpace.
+	CollapseSpace bool
+}
+
+// UnicodeNormalizer is a Unicode normalization step of a Normalization.
+type UnicodeNormalizer interface {
+	Normalize(s string) string
+}
+
+// DefaultNormalization folds quotes and trims and collapses white space. It
+// does no Unicode normalization, which needs the unicodenorm subpackage.
+var DefaultNormalization = Normalization{
+	FoldQuotes:    true,
+	TrimSpace:     true,
+	CollapseSpace: true,
+}
+
+// WithLookupNormalization normalizes the names given to GetTeamByName,
+// GetOrgByName, GetPillarByName, GetTeamGroupByName, GetTeamMembers,
+// GetOrgMembers and the IsEmployeeIn*/IsSlackUserIn* membership checks, and
+// the address given to GetEmployeeByEmail, when they match no entity
+// exactly. The query then finds the entity whose normalized name matches
+// the normalized query; if several do, the one whose name sorts first.
+// Exact matches are unaffected and cost nothing extra. The index of
+// normalized names is built on the first lookup that needs it.
+func WithLookupNormalization(n Normalization) ServiceOption {
+	return func(c *serviceConfig) {
+		c.normalization = n
+	}
+}
+
+var quoteReplacer = strings.NewReplacer(
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'",
+	"“", `"`, "”", `"`, "„", `"`, "‟", `"`, "″", `"`,
+)
+
+// Normalize returns s normalized as n says.
+func (n Normalization) Normalize(s string) string {
+	if n.Unicode != nil {
+		s = n.Unicode.Normalize(s)
+	}
+	if n.FoldQuotes {
+		s = quoteReplacer.Replace(s)
+	}
+	if n.CollapseSpace {
+		s = strings.Join(strings.Fields(s), " ")
+	} else if n.TrimSpace {
+		s = strings.TrimSpace(s)
+	}
+	return s
+}
+
+// enabled reports whether n normalizes anything. It does not compare n
+// with the zero Normalization, which would panic on a Unicode normalizer
+// whose dynamic type is not comparable.
+func (n Normalization) enabled() bool {
+	return n.Unicode != nil || n.FoldQuotes || n.TrimSpace || n.CollapseSpace
+}
+
+// resolveKey returns the key of m that key stands for: key itself if it is
+// in m or normalization is off, otherwise the key whose normalized form
+// matches, or key if none does. Must be called with s.mu held.
+func resolveKey[T any](s *Service, m map[string]T, kind, key string) string {
+	if _, ok := m[key]; ok || !s.normalizationEnabled {
+		return key
+	}
+	if resolved, ok := s.derived().normalizedIndex(s.normalization)[kind][s.normalization.Normalize(key)]; ok {
+		return resolved
+	}
+	return key
+}
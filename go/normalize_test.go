@@ -0,0 +1,100 @@
+package orgdatacore
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// umlautFolder is a UnicodeNormalizer standing in for the unicodenorm
+// package, so the tests of this package need no Unicode tables.
+type umlautFolder struct{}
+
+func (umlautFolder) Normalize(s string) string {
+	return strings.ReplaceAll(s, "ü", "u")
+}
+
+// normalizerFunc is a UnicodeNormalizer whose dynamic type is not
+// comparable.
+type normalizerFunc func(string) string
+
+func (f normalizerFunc) Normalize(s string) string { return f(s) }
+
+func TestNormalization(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Normalization
+		in   string
+		want string
+	}{
+		{"off", Normalization{}, "  platform team ", "  platform team "},
+		{"nbsp", DefaultNormalization, "platform team", "platform team"},
+		{"whitespace", DefaultNormalization, "  platform \t  team\n", "platform team"},
+		{"trim only", Normalization{TrimSpace: true}, "  platform  team ", "platform  team"},
+		{"quotes", DefaultNormalization, "o’brien “team”", `o'brien "team"`},
+		{"unicode kept", DefaultNormalization, "Zürich ｔｅａｍ", "Zürich ｔｅａｍ"},
+		{"unicode step first", Normalization{Unicode: umlautFolder{}, CollapseSpace: true}, " Zürich  ", "Zurich"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.n.Normalize(tt.in); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupNormalization(t *testing.T) {
+	data := CreateTestData()
+	team := data.Lookups.Teams["test-squad"]
+	team.Name = "Zürich team"
+	data.Lookups.Teams["Zürich team"] = team
+	n := DefaultNormalization
+	n.Unicode = umlautFolder{}
+	ctx := context.Background()
+	service := NewService(WithLookupNormalization(n))
+	if err := service.LoadFromDataSource(ctx, jsonSource(t, "data", data)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := service.GetTeamByName("  zurich team"); got != nil {
+		t.Errorf("GetTeamByName matched case-insensitively: %+v", got)
+	}
+	if got := service.GetTeamByName("  Zurich team"); got == nil || got.Name != "Zürich team" {
+		t.Errorf("GetTeamByName(Zurich team) = %+v, want the Zürich team", got)
+	}
+	if got := service.GetTeamByName("test-squad "); got == nil || got.Name != "test-squad" {
+		t.Errorf("GetTeamByName with a trailing non-breaking space = %+v, want test-squad", got)
+	}
+	if got := service.GetEmployeeByEmail(" TestUser1@example.com "); got == nil || got.UID != "testuser1" {
+		t.Errorf("GetEmployeeByEmail = %+v, want testuser1", got)
+	}
+	if got, want := len(service.GetTeamMembers("test-squad ")), len(service.GetTeamMembers("test-squad")); got != want || got == 0 {
+		t.Errorf("GetTeamMembers(%q) returned %d members, want %d", "test-squad ", got, want)
+	}
+	if !service.IsEmployeeInTeam("testuser1", " test-squad") {
+		t.Error("IsEmployeeInTeam with a leading space = false")
+	}
+
+	plain := NewService()
+	if err := plain.LoadFromDataSource(ctx, jsonSource(t, "data", data)); err != nil {
+		t.Fatal(err)
+	}
+	if plain.GetTeamByName("test-squad ") != nil {
+		t.Error("lookup was normalized without WithLookupNormalization")
+	}
+}
+
+func TestLookupNormalizationIncomparable(t *testing.T) {
+	n := Normalization{Unicode: normalizerFunc(strings.ToLower)}
+	service := NewService(WithLookupNormalization(n))
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "data", CreateTestData())); err != nil {
+		t.Fatal(err)
+	}
+	if got := service.GetTeamByName("TEST-SQUAD"); got == nil || got.Name != "test-squad" {
+		t.Errorf("GetTeamByName(TEST-SQUAD) = %+v, want test-squad", got)
+	}
+	if got := service.Snapshot().GetTeamByName("Test-Squad"); got == nil || got.Name != "test-squad" {
+		t.Errorf("Snapshot().GetTeamByName(Test-Squad) = %+v, want test-squad", got)
+	}
+}
@@ -1,16 +1,52 @@
 package orgdatacore
 
-import "log/slog"
+import (
+	"log/slog"
+	"time"
+)
 
 // ServiceOption configures a Service instance.
 type ServiceOption func(*serviceConfig)
 
 type serviceConfig struct {
-	logger *slog.Logger
+	logger       *slog.Logger
+	eagerIndexes []IndexKind
+
+	queryCacheSize int
+	queryCacheTTL  time.Duration
+
+	sections   sectionSet
+	validators []func(*Data) error
+
+	checkSeverities     map[ValidationCheck]Severity
+	requiredSections    []Section
+	validationThreshold Severity
+	strictSchema        bool
+	unknownFieldCheck   bool
+	skipConsistency     bool
+	repair              bool
+	overlays            []DataSource
+	maxShrink           float64
+
+	maxDataAge time.Duration
+
+	snapshotHistory   int
+	lastKnownGoodPath string
+
+	metrics MetricsRegisterer
+
+	queryLogging  bool
+	queryLogLevel slog.Level
+	logLevels     map[string]slog.Level
+
+	clock Clock
+
+	normalization Normalization
+	copyPolicy    CopyPolicy
 }
 
 func defaultServiceConfig() *serviceConfig {
-	return &serviceConfig{logger: slog.Default()}
+	return &serviceConfig{logger: slog.Default(), clock: realClock{}}
 }
 
 // WithLogger sets a custom logger for the service.
@@ -21,3 +57,291 @@ func WithLogger(logger *slog.Logger) ServiceOption {
 		}
 	}
 }
+
+// WithEagerIndexes builds the given secondary indexes during every load,
+// before the new data is swapped in, instead of lazily on first use.
+// When more than one index is requested they are built concurrently.
+// Use this for latency-sensitive services that would rather pay the cost at
+// reload time than on the first query after a reload. Invalid kinds are ignored.
+func WithEagerIndexes(kinds ...IndexKind) ServiceOption {
+	return func(c *serviceConfig) {
+		for _, k := range kinds {
+			if k.IsValid() {
+				c.eagerIndexes = append(c.eagerIndexes, k)
+			}
+		}
+	}
+}
+
+// WithQueryCache caches the results of expensive derived queries
+// (GetUserOrganizations, GetOrgMembers, GetDescendantsTree), keyed by their
+// input. At most size results are kept, least recently used first out, and
+// each result expires after ttl; a non-positive ttl keeps results until the
+// next reload. The cache is cleared whenever new data is loaded.
+// A non-positive size disables caching, which is the default.
+func WithQueryCache(size int, ttl time.Duration) ServiceOption {
+	return func(c *serviceConfig) {
+		c.queryCacheSize = size
+		c.queryCacheTTL = ttl
+	}
+}
+
+// WithSections restricts loading to the given sections of the data document;
+// all other sections are skipped during decoding and never held in memory.
+// Metadata is always loaded. Queries that depend on a skipped section behave
+// as if that section were empty. For example, a sidecar that only resolves
+// employees by Slack ID can load just SectionEmployees and SectionSlackIDs.
+// Invalid sections are ignored. By default every section is loaded.
+func WithSections(sections ...Section) ServiceOption {
+	return func(c *serviceConfig) {
+		if c.sections == nil {
+			c.sections = make(sectionSet)
+		}
+		for _, sec := range sections {
+			if sec.IsValid() {
+				c.sections[sec] = true
+			}
+		}
+	}
+}
+
+// WithLoadValidator adds a check that every new dataset must pass before it
+// replaces the loaded one, whether it comes from a full load or a change set.
+// Validators run in the order they were added, after the built-in structural
+// checks. If one returns an error the new data is discarded, the previous
+// data stays in place, and the load fails with an error wrapping both
+// ErrLoadRejected and the validator's error.
+//
+// Validators run before the swap without holding the service lock, so they
+// may query the service to compare against the data currently loaded, for
+// example to reject a dump whose employee count dropped sharply. They must not
+// modify data.
+func WithLoadValidator(validator func(*Data) error) ServiceOption {
+	return func(c *serviceConfig) {
+		if validator != nil {
+			c.validators = append(c.validators, validator)
+		}
+	}
+}
+
+// WithCheckSeverity sets the severity of the issues reported by check,
+// overriding its default. Raise a check to SeverityError to reject data that
+// fails it, or lower it to SeverityInfo to keep it out of the logs. Invalid
+// checks or severities are ignored.
+func WithCheckSeverity(check ValidationCheck, severity Severity) ServiceOption {
+	return func(c *serviceConfig) {
+		if !check.IsValid() || !severity.IsValid() {
+			return
+		}
+		if c.checkSeverities == nil {
+			c.checkSeverities = make(map[ValidationCheck]Severity)
+		}
+		c.checkSeverities[check] = severity
+	}
+}
+
+// WithRequiredSections sets the sections that must not be empty for a new
+// dataset to load, replacing the default of SectionEmployees and
+// SectionMembership. Consumers whose dumps have no Jira data, say, leave it
+// out, and consumers that rely on it add it. With no sections nothing is
+// required. Sections skipped with WithSections are never required, nor are
+// employees and membership in PII-free dumps. Invalid sections are ignored.
+func WithRequiredSections(sections ...Section) ServiceOption {
+	return func(c *serviceConfig) {
+		c.requiredSections = []Section{}
+		for _, sec := range sections {
+			if sec.IsValid() {
+				c.requiredSections = append(c.requiredSections, sec)
+			}
+		}
+	}
+}
+
+// WithValidationThreshold sets the severity at which validation issues reject
+// a new dataset; the default is SeverityError. With SeverityWarning, any
+// warning keeps the previous data in place. The load fails with a
+// *ValidationError wrapping ErrInvalidData. Invalid severities are ignored.
+func WithValidationThreshold(severity Severity) ServiceOption {
+	return func(c *serviceConfig) {
+		if severity.IsValid() {
+			c.validationThreshold = severity
+		}
+	}
+}
+
+// WithStrictSchema checks every loaded document against DumpSchema before
+// decoding it, failing the load with a *SchemaError that locates the first
+// mismatch. The document is buffered in memory to be checked. Each part of a
+// MergedDataSource is checked, so parts must be complete documents.
+func WithStrictSchema() ServiceOption {
+	return func(c *serviceConfig) {
+		c.strictSchema = true
+	}
+}
+
+// WithUnknownFieldCheck reports the fields of every loaded document that the
+// Go types do not model, as CheckUnknownField validation issues, so additions
+// on the producer side that the library silently drops are noticed. They are
+// warnings unless changed with WithCheckSeverity. The document is buffered in
+// memory and parsed twice.
+func WithUnknownFieldCheck() ServiceOption {
+	return func(c *serviceConfig) {
+		c.unknownFieldCheck = true
+	}
+}
+
+// WithoutConsistencyChecks skips CheckMembershipMismatch, CheckManagerCycle
+// and CheckHierarchyCycle on every load. They walk every team membership and
+// every manager and parent link, and together add roughly 15% to the load time
+// of a 50,000-employee dump. Services reloading large dumps often can
+// skip them and leave them to ValidateData in the pipeline producing the dump,
+// which always runs them.
+func WithoutConsistencyChecks() ServiceOption {
+	return func(c *serviceConfig) {
+		c.skipConsistency = true
+	}
+}
+
+// WithRepair fixes common data issues in every fully loaded dataset before it
+// is validated, as RepairData does. Repairs are logged and listed in the
+// validation report, so the pipeline producing the data can be fixed. Change
+// sets are not repaired.
+func WithRepair() ServiceOption {
+	return func(c *serviceConfig) {
+		c.repair = true
+	}
+}
+
+// WithMaxShrink rejects new data with fewer employees than the loaded data by
+// more than fraction, such as 0.3 for 30%, failing the load with
+// ErrDataShrunk. This keeps a truncated upstream dump from wiping out the data
+// of a long-running service. A fraction outside (0, 1) disables the guard,
+// which is the default.
+func WithMaxShrink(fraction float64) ServiceOption {
+	return func(c *serviceConfig) {
+		if fraction > 0 && fraction < 1 {
+			c.maxShrink = fraction
+		} else {
+			c.maxShrink = 0
+		}
+	}
+}
+
+// WithMaxDataAge marks the service degraded once the loaded data was generated
+// more than d ago, according to the generated_at metadata. Use CheckFreshness
+// or IsDegraded in readiness probes to catch upstream pipeline outages that
+// leave the service serving an old dump. A zero or negative d disables the check.
+func WithMaxDataAge(d time.Duration) ServiceOption {
+	return func(c *serviceConfig) {
+		c.maxDataAge = d
+	}
+}
+
+// WithSnapshotHistory keeps the n datasets loaded before the current one in
+// memory so Service.Rollback can restore them. Each snapshot holds a full
+// dataset, so keep n small for large data files. Snapshots are off by default.
+func WithSnapshotHistory(n int) ServiceOption {
+	return func(c *serviceConfig) {
+		c.snapshotHistory = max(n, 0)
+	}
+}
+
+// WithLastKnownGoodPath writes every successfully loaded dataset, with the
+// overlay patches applied to it, to path, so Service.LoadLastKnownGood can
+// restore it after a restart while the primary source is down. The file is written in the background after OnReload
+// subscribers have been notified, so a process exiting right after a load
+// may keep the previous copy. It holds the same data as the source,
+// including any PII, so place it on storage with matching access controls.
+func WithLastKnownGoodPath(path string) ServiceOption {
+	return func(c *serviceConfig) {
+		c.lastKnownGoodPath = path
+	}
+}
+
+// MetricsRegisterer collects a Service's metrics. The metrics subpackage
+// provides a Registry that exposes them to Prometheus.
+type MetricsRegisterer interface {
+	// RegisterService is called once by NewService with the new service.
+	RegisterService(s *Service)
+}
+
+// WithMetrics registers the service with r, which reads reload outcomes,
+// data age and entity counts from it when scraped.
+func WithMetrics(r MetricsRegisterer) ServiceOption {
+	return func(c *serviceConfig) {
+		c.metrics = r
+	}
+}
+
+// WithQueryLogging logs every lookup made on the service — the method, its
+// arguments, whether it found anything and how long it took — at level through
+// the service's logger, to diagnose reports such as "user not found". Logging
+// is limited to 100 lines per second; the number of queries dropped is
+// reported on the next line. Use slog.LevelDebug so the lines only appear
+// when the logger is configured for debugging.
+//
+// Arguments such as Slack IDs and emails are logged as given, so enable this
+// only where such identifiers may be written to logs.
+func WithQueryLogging(level slog.Level) ServiceOption {
+	return func(c *serviceConfig) {
+		c.queryLogging = true
+		c.queryLogLevel = level
+	}
+}
+
+// WithLogLevels sets the minimum level logged by each named subsystem:
+// LogWatcher, LogLoader, LogValidator or LogQuery. For example, to see each
+// watcher poll without debug-logging every query:
+//
+//	orgdatacore.WithLogLevels(map[string]slog.Level{
+//		orgdatacore.LogWatcher: slog.LevelDebug,
+//		orgdatacore.LogQuery:   slog.LevelWarn,
+//	})
+//
+// A subsystem's level replaces the level check of the logger's handler, so
+// it can be lower than the handler's own. Subsystems without a level log
+// through the service's logger unchanged; unknown names are ignored. Later
+// calls add to or override the levels set by earlier ones.
+func WithLogLevels(levels map[string]slog.Level) ServiceOption {
+	return func(c *serviceConfig) {
+		if c.logLevels == nil {
+			c.logLevels = make(map[string]slog.Level, len(levels))
+		}
+		for subsystem, level := range levels {
+			c.logLevels[subsystem] = level
+		}
+	}
+}
+
+// WithClock sets the clock the service reads for load times, data age,
+// freshness, watcher status, event times and query cache expiry, so tests can
+// control time instead of sleeping. The default is the system clock.
+func WithClock(clock Clock) ServiceOption {
+	return func(c *serviceConfig) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// WithOptions applies opts in order, so a set of options can be shared as
+// one, such as a team's defaults that individual services then extend:
+//
+//	defaults := orgdatacore.WithOptions(
+//		orgdatacore.WithLogger(logger),
+//		orgdatacore.WithMetrics(registry),
+//		orgdatacore.WithMaxDataAge(36*time.Hour),
+//	)
+//	service := orgdatacore.NewService(defaults, orgdatacore.WithQueryCache(1024, time.Minute))
+//
+// Options given after it override those in it. Nil options are skipped, so
+// a bundle can include options that are set conditionally.
+func WithOptions(opts ...ServiceOption) ServiceOption {
+	return func(c *serviceConfig) {
+		for _, opt := range opts {
+			if opt != nil {
+				opt(c)
+			}
+		}
+	}
+}
@@ -16,13 +16,16 @@ type gcsConfig struct {
 	projectID       string
 	checkInterval   time.Duration
 	credentialsJSON string
+	endpoint        string
 	logger          *slog.Logger
+	clock           Clock
 }
 
 func defaultGCSConfig() *gcsConfig {
 	return &gcsConfig{
 		checkInterval: 5 * time.Minute,
 		logger:        slog.Default(),
+		clock:         realClock{},
 	}
 }
 
@@ -42,6 +45,14 @@ func WithCredentialsJSON(creds string) GCSOption {
 	}
 }
 
+// WithEndpoint sets the GCS JSON API endpoint, for example a local fake
+// server in tests. The default is Google's public endpoint.
+func WithEndpoint(endpoint string) GCSOption {
+	return func(c *gcsConfig) {
+		c.endpoint = endpoint
+	}
+}
+
 // WithProjectID sets the GCP project ID.
 func WithProjectID(projectID string) GCSOption {
 	return func(c *gcsConfig) {
@@ -58,3 +69,13 @@ func WithGCSLogger(logger *slog.Logger) GCSOption {
 	}
 }
 
+// WithGCSClock sets the clock the GCS source waits on between checks for
+// updates, so tests can advance it instead of sleeping. The default is the
+// system clock.
+func WithGCSClock(clock Clock) GCSOption {
+	return func(c *gcsConfig) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
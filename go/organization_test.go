@@ -311,3 +311,54 @@ func TestOrgInfoTypes(t *testing.T) {
 		}
 	}
 }
+
+// TestMembershipChecksDoNotAllocate verifies the Is* predicates stay allocation-free,
+// since they run on every incoming Slack message in bot consumers.
+func TestMembershipChecksDoNotAllocate(t *testing.T) {
+	service := setupTestService(t)
+
+	checks := map[string]func(){
+		"IsEmployeeInTeam":  func() { service.IsEmployeeInTeam("jsmith", "test-team") },
+		"IsSlackUserInTeam": func() { service.IsSlackUserInTeam("U12345678", "test-team") },
+		"IsEmployeeInOrg":   func() { service.IsEmployeeInOrg("bwilson", "test-org") },
+		"IsSlackUserInOrg":  func() { service.IsSlackUserInOrg("U98765432", "test-org") },
+		"IsEmployeeInOrg miss": func() {
+			service.IsEmployeeInOrg("jsmith", "platform-org")
+		},
+	}
+	for name, check := range checks {
+		if allocs := testing.AllocsPerRun(100, check); allocs != 0 {
+			t.Errorf("%s allocated %.1f times per call, want 0", name, allocs)
+		}
+	}
+}
+
+// TestIsEmployeeInOrgCyclicHierarchy verifies ancestor walks terminate on cyclic parent references
+func TestIsEmployeeInOrgCyclicHierarchy(t *testing.T) {
+	service := NewService()
+	service.data = &Data{
+		Lookups: Lookups{
+			Teams: map[string]Team{
+				"team-a": {Name: "team-a", Parent: &ParentInfo{Name: "org-a", Type: "org"}},
+			},
+			Orgs: map[string]Org{
+				"org-a": {Name: "org-a", Parent: &ParentInfo{Name: "org-b", Type: "org"}},
+				"org-b": {Name: "org-b", Parent: &ParentInfo{Name: "org-a", Type: "org"}},
+			},
+		},
+		Indexes: Indexes{
+			Membership: MembershipIndex{
+				MembershipIndex: map[string][]MembershipInfo{
+					"user": {{Name: "team-a", Type: "team"}},
+				},
+			},
+		},
+	}
+
+	if !service.IsEmployeeInOrg("user", "org-b") {
+		t.Error("expected user to be in org-b through team-a -> org-a -> org-b")
+	}
+	if service.IsEmployeeInOrg("user", "org-c") {
+		t.Error("expected user not to be in unknown org-c")
+	}
+}
@@ -0,0 +1,310 @@
+package orgdatacoretest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// Dataset builds a valid orgdatacore.Data for tests, so they need not embed
+// JSON fixtures:
+//
+//	data := orgdatacoretest.NewDataset().
+//		WithOrg("eng").
+//		WithTeam("platform", orgdatacoretest.InOrg("eng")).
+//		WithEmployee("adoe", orgdatacoretest.OnTeam("platform")).
+//		WithEmployee("jsmith", orgdatacoretest.OnTeam("platform"), orgdatacoretest.ManagedBy("adoe")).
+//		MustBuild()
+//
+// The dataset is built with orgdatacore.ImportData, so memberships, member
+// lists of parent entities and the Slack, GitHub and Jira indexes are filled
+// in as the indexing pipeline would. Entities and employees may be added in
+// any order.
+type Dataset struct {
+	version   string
+	employees []*employeeSpec
+	entities  []*entitySpec
+}
+
+type employeeSpec struct {
+	orgdatacore.Employee
+	teams []string
+}
+
+type entitySpec struct {
+	kind        string
+	Name        string                              `json:"name"`
+	UID         string                              `json:"uid,omitempty"`
+	Description string                              `json:"description,omitempty"`
+	Parent      string                              `json:"parent,omitempty"`
+	Members     []string                            `json:"members,omitempty"`
+	Slack       *orgdatacore.SlackConfig            `json:"slack,omitempty"`
+	Roles       []orgdatacore.RoleInfo              `json:"roles,omitempty"`
+	Jiras       []orgdatacore.JiraInfo              `json:"jiras,omitempty"`
+	Keywords    []string                            `json:"keywords,omitempty"`
+	Escalation  []orgdatacore.EscalationContactInfo `json:"escalation,omitempty"`
+}
+
+// EntityOption configures an org, pillar, team group or team.
+type EntityOption func(*entitySpec)
+
+// EmployeeOption configures an employee.
+type EmployeeOption func(*employeeSpec)
+
+// NewDataset returns an empty Dataset.
+func NewDataset() *Dataset {
+	return &Dataset{}
+}
+
+// WithVersion sets the data version. It defaults to a hash of the dataset.
+func (d *Dataset) WithVersion(version string) *Dataset {
+	d.version = version
+	return d
+}
+
+// WithOrg adds an org.
+func (d *Dataset) WithOrg(name string, opts ...EntityOption) *Dataset {
+	return d.withEntity("org", name, opts)
+}
+
+// WithPillar adds a pillar.
+func (d *Dataset) WithPillar(name string, opts ...EntityOption) *Dataset {
+	return d.withEntity("pillar", name, opts)
+}
+
+// WithTeamGroup adds a team group.
+func (d *Dataset) WithTeamGroup(name string, opts ...EntityOption) *Dataset {
+	return d.withEntity("team_group", name, opts)
+}
+
+// WithTeam adds a team.
+func (d *Dataset) WithTeam(name string, opts ...EntityOption) *Dataset {
+	return d.withEntity("team", name, opts)
+}
+
+func (d *Dataset) withEntity(kind, name string, opts []EntityOption) *Dataset {
+	e := &entitySpec{kind: kind, Name: name}
+	for _, opt := range opts {
+		opt(e)
+	}
+	d.entities = append(d.entities, e)
+	return d
+}
+
+// WithEmployee adds an employee. Unless set by options, the full name and
+// email are derived from uid.
+func (d *Dataset) WithEmployee(uid string, opts ...EmployeeOption) *Dataset {
+	e := &employeeSpec{Employee: orgdatacore.Employee{
+		UID:      uid,
+		FullName: "Test " + uid,
+		Email:    uid + "@example.com",
+		JobTitle: "Engineer",
+	}}
+	for _, opt := range opts {
+		opt(e)
+	}
+	d.employees = append(d.employees, e)
+	return d
+}
+
+// InOrg places an entity under the org name.
+func InOrg(name string) EntityOption { return under(name) }
+
+// InPillar places an entity under the pillar name.
+func InPillar(name string) EntityOption { return under(name) }
+
+// InTeamGroup places an entity under the team group name.
+func InTeamGroup(name string) EntityOption { return under(name) }
+
+func under(parent string) EntityOption {
+	return func(e *entitySpec) { e.Parent = parent }
+}
+
+// EntityUID sets an entity's UID, which defaults to its name.
+func EntityUID(uid string) EntityOption {
+	return func(e *entitySpec) { e.UID = uid }
+}
+
+// Description sets an entity's description.
+func Description(text string) EntityOption {
+	return func(e *entitySpec) { e.Description = text }
+}
+
+// SlackChannel adds a Slack channel of the given types, such as "main".
+func SlackChannel(channel string, types ...string) EntityOption {
+	return func(e *entitySpec) {
+		if e.Slack == nil {
+			e.Slack = &orgdatacore.SlackConfig{}
+		}
+		e.Slack.Channels = append(e.Slack.Channels, orgdatacore.ChannelInfo{Channel: channel, Types: types})
+	}
+}
+
+// Jira adds a Jira project and component owned by the entity; an empty
+// component owns the whole project.
+func Jira(project, component string) EntityOption {
+	return func(e *entitySpec) {
+		e.Jiras = append(e.Jiras, orgdatacore.JiraInfo{Project: project, Component: component})
+	}
+}
+
+// Role gives people the roles, such as "tech_lead", in the entity.
+func Role(role string, uids ...string) EntityOption {
+	return func(e *entitySpec) {
+		e.Roles = append(e.Roles, orgdatacore.RoleInfo{People: uids, Roles: []string{role}})
+	}
+}
+
+// Keywords adds search keywords.
+func Keywords(keywords ...string) EntityOption {
+	return func(e *entitySpec) { e.Keywords = append(e.Keywords, keywords...) }
+}
+
+// Escalation adds an escalation contact, in priority order.
+func Escalation(name, url string) EntityOption {
+	return func(e *entitySpec) {
+		e.Escalation = append(e.Escalation, orgdatacore.EscalationContactInfo{Name: name, URL: url})
+	}
+}
+
+// OnTeam makes the employee a direct member of the team (or org) name.
+func OnTeam(name string) EmployeeOption {
+	return func(e *employeeSpec) { e.teams = append(e.teams, name) }
+}
+
+// ManagedBy sets the employee's manager, who must also be in the dataset
+// and is marked as a people manager.
+func ManagedBy(uid string) EmployeeOption {
+	return func(e *employeeSpec) { e.ManagerUID = uid }
+}
+
+// FullName sets the employee's full name.
+func FullName(name string) EmployeeOption {
+	return func(e *employeeSpec) { e.Employee.FullName = name }
+}
+
+// Email sets the employee's email.
+func Email(email string) EmployeeOption {
+	return func(e *employeeSpec) { e.Employee.Email = email }
+}
+
+// JobTitle sets the employee's job title.
+func JobTitle(title string) EmployeeOption {
+	return func(e *employeeSpec) { e.Employee.JobTitle = title }
+}
+
+// SlackID sets the employee's Slack user ID.
+func SlackID(id string) EmployeeOption {
+	return func(e *employeeSpec) { e.SlackUID = id }
+}
+
+// GitHubID sets the employee's GitHub login.
+func GitHubID(id string) EmployeeOption {
+	return func(e *employeeSpec) { e.Employee.GitHubID = id }
+}
+
+// Build returns the dataset. Unknown teams, managers, parents or role
+// holders, duplicates and parent cycles are errors.
+func (d *Dataset) Build() (*orgdatacore.Data, error) {
+	uids := map[string]bool{}
+	for _, e := range d.employees {
+		uids[e.UID] = true
+	}
+	byName := map[string]*entitySpec{}
+	for _, e := range d.entities {
+		byName[e.Name] = e
+		e.Members = nil
+	}
+	for _, e := range d.employees {
+		if e.ManagerUID != "" && !uids[e.ManagerUID] {
+			return nil, fmt.Errorf("orgdatacoretest: employee %q: unknown manager %q", e.UID, e.ManagerUID)
+		}
+		for _, team := range e.teams {
+			entity, ok := byName[team]
+			if !ok {
+				return nil, fmt.Errorf("orgdatacoretest: employee %q: unknown team %q", e.UID, team)
+			}
+			entity.Members = append(entity.Members, e.UID)
+		}
+	}
+	for _, e := range d.entities {
+		for _, role := range e.Roles {
+			for _, uid := range role.People {
+				if !uids[uid] {
+					return nil, fmt.Errorf("orgdatacoretest: %s %q: unknown role holder %q", e.kind, e.Name, uid)
+				}
+			}
+		}
+	}
+
+	employees, err := d.employeesCSV()
+	if err != nil {
+		return nil, err
+	}
+	file := map[string]any{
+		"orgs":        []*entitySpec{},
+		"pillars":     []*entitySpec{},
+		"team_groups": []*entitySpec{},
+		"teams":       []*entitySpec{},
+	}
+	for _, e := range d.entities {
+		key := e.kind + "s"
+		file[key] = append(file[key].([]*entitySpec), e)
+	}
+	if d.version != "" {
+		file["data_version"] = d.version
+	}
+	// JSON on one line is a YAML flow mapping, which ImportData accepts.
+	teams, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := orgdatacore.ImportData(bytes.NewReader(employees), bytes.NewReader(teams))
+	if err != nil {
+		return nil, fmt.Errorf("orgdatacoretest: %w", err)
+	}
+	return data, nil
+}
+
+// MustBuild is like Build but panics on error, for use in test setup.
+func (d *Dataset) MustBuild() *orgdatacore.Data {
+	data, err := d.Build()
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// JSON returns the dataset in the format of the data dump, for writing a
+// fixture file or serving from a fake data source.
+func (d *Dataset) JSON() ([]byte, error) {
+	data, err := d.Build()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(data, "", "  ")
+}
+
+func (d *Dataset) employeesCSV() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	managers := map[string]bool{}
+	for _, e := range d.employees {
+		managers[e.ManagerUID] = true
+	}
+	rows := [][]string{{"uid", "full_name", "email", "job_title", "slack_uid", "github_id", "manager_uid", "is_people_manager"}}
+	for _, e := range d.employees {
+		rows = append(rows, []string{
+			e.UID, e.Employee.FullName, e.Employee.Email, e.Employee.JobTitle, e.SlackUID, e.Employee.GitHubID,
+			e.ManagerUID, strconv.FormatBool(managers[e.UID]),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
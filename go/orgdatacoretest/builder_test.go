@@ -0,0 +1,129 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func newBuiltService(t *testing.T, d *Dataset) *orgdatacore.Service {
+	t.Helper()
+	raw, err := d.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	service := orgdatacore.NewService()
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(string(raw))); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	return service
+}
+
+func TestDatasetBuild(t *testing.T) {
+	service := newBuiltService(t, NewDataset().
+		WithOrg("eng").
+		WithTeamGroup("backend", InOrg("eng")).
+		WithTeam("platform", InTeamGroup("backend"), SlackChannel("#platform", "main"), Jira("PLAT", "API"), Role("tech_lead", "jsmith")).
+		WithEmployee("jsmith", OnTeam("platform"), ManagedBy("adoe"), SlackID("U1"), GitHubID("jsmith-gh")).
+		WithEmployee("adoe", OnTeam("eng"), FullName("Alice Doe")))
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"team members", uidsOf(service.GetTeamMembers("platform")), []string{"jsmith"}},
+		{"org has members below it", uidsOf(service.GetOrgMembers("eng")), []string{"adoe", "jsmith"}},
+		{"in team", service.IsEmployeeInTeam("jsmith", "platform"), true},
+		{"in org through team", service.IsEmployeeInOrg("jsmith", "eng"), true},
+		{"manager", service.GetManagerForEmployee("jsmith").UID, "adoe"},
+		{"manager is a people manager", service.GetEmployeeByUID("adoe").IsPeopleManager, true},
+		{"full name", service.GetEmployeeByUID("adoe").FullName, "Alice Doe"},
+		{"default email", service.GetEmployeeByUID("jsmith").Email, "jsmith@example.com"},
+		{"slack lookup", service.GetEmployeeBySlackID("U1").UID, "jsmith"},
+		{"github lookup", service.GetEmployeeByGitHubID("jsmith-gh").UID, "jsmith"},
+		{"slack channel", len(service.GetTeamsBySlackChannel("#platform")), 1},
+		{"jira owner", service.GetTeamsByJiraComponent("PLAT", "API"), []orgdatacore.JiraOwnerInfo{{Name: "platform", Type: "team"}}},
+		{"hierarchy", service.GetHierarchyPath("platform", "team"), []orgdatacore.HierarchyPathEntry{
+			{Name: "platform", Type: "team"}, {Name: "backend", Type: "team_group"}, {Name: "eng", Type: "org"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatasetBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		dataset *Dataset
+		want    string
+	}{
+		{"unknown team", NewDataset().WithEmployee("jsmith", OnTeam("nope")), `unknown team "nope"`},
+		{"unknown manager", NewDataset().WithEmployee("jsmith", ManagedBy("nobody")), `unknown manager "nobody"`},
+		{"unknown role holder", NewDataset().WithTeam("t", Role("tech_lead", "nobody")), `unknown role holder "nobody"`},
+		{"unknown parent", NewDataset().WithTeam("t", InOrg("nope")), `unknown parent "nope"`},
+		{"duplicate entity", NewDataset().WithOrg("x").WithTeam("x"), `"x" is both`},
+		{"duplicate employee", NewDataset().WithEmployee("a").WithEmployee("a"), `duplicate uid "a"`},
+		{"parent cycle", NewDataset().WithOrg("a", InOrg("b")).WithOrg("b", InOrg("a")), "parent cycle"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.dataset.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Build() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatasetBuildRepeatable(t *testing.T) {
+	d := NewDataset().WithVersion("v1").WithTeam("t").WithEmployee("a", OnTeam("t"))
+	first := d.MustBuild()
+	second := d.MustBuild()
+	if got := second.Lookups.Teams["t"].Group.ResolvedPeopleUIDList; !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("members after a second Build = %v, want [a]", got)
+	}
+	if first.Metadata.DataVersion != "v1" {
+		t.Errorf("DataVersion = %q, want v1", first.Metadata.DataVersion)
+	}
+}
+
+func TestDatasetJSON(t *testing.T) {
+	raw, err := NewDataset().WithEmployee("a").JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data orgdatacore.Data
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := data.Lookups.Employees["a"]; !ok {
+		t.Errorf("employee a missing from %s", raw)
+	}
+}
+
+func TestMustBuildPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustBuild did not panic")
+		}
+	}()
+	NewDataset().WithEmployee("a", OnTeam("nope")).MustBuild()
+}
+
+func uidsOf(emps []orgdatacore.Employee) []string {
+	uids := make([]string, len(emps))
+	for i, e := range emps {
+		uids[i] = e.UID
+	}
+	return uids
+}
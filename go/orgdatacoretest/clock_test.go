@@ -0,0 +1,133 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestFakeClockAfter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	select {
+	case <-clock.After(0):
+	default:
+		t.Error("After(0) did not fire at once")
+	}
+
+	ch := clock.After(time.Minute)
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After(1m) fired after 30s")
+	default:
+	}
+	clock.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		if want := start.Add(time.Minute); !got.Equal(want) {
+			t.Errorf("After(1m) sent %v, want %v", got, want)
+		}
+	default:
+		t.Fatal("After(1m) did not fire after 1m")
+	}
+	if n := clock.Waiters(); n != 0 {
+		t.Errorf("Waiters() = %d, want 0", n)
+	}
+}
+
+func TestServiceWithClock(t *testing.T) {
+	generated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(generated.Add(time.Minute))
+	svc := orgdatacore.NewService(orgdatacore.WithClock(clock), orgdatacore.WithMaxDataAge(time.Hour))
+	raw, err := json.Marshal(orgdatacore.CreateTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(string(raw))); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	if got := svc.GetVersion().LoadTime; !got.Equal(clock.Now()) {
+		t.Errorf("LoadTime = %v, want %v", got, clock.Now())
+	}
+	if stats := svc.LoadStats(); !stats.LastAttempt.Equal(clock.Now()) || !stats.LastSuccess.Equal(clock.Now()) {
+		t.Errorf("LastAttempt = %v, LastSuccess = %v, want both %v", stats.LastAttempt, stats.LastSuccess, clock.Now())
+	}
+	if report := svc.LastLoadReport(); report == nil || !report.Started.Equal(clock.Now()) {
+		t.Errorf("LastLoadReport() = %+v, want Started %v", report, clock.Now())
+	}
+	if svc.IsDegraded() {
+		t.Error("service degraded one minute after generation")
+	}
+
+	clock.Advance(2 * time.Hour)
+	if got := svc.GetDataAge(); got != 2*time.Hour {
+		t.Errorf("GetDataAge() = %v, want 2h", got)
+	}
+	if err := svc.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource("{")); err == nil {
+		t.Fatal("LoadFromDataSource of invalid JSON succeeded")
+	}
+	if got := svc.LoadStats().LastFailure; !got.Equal(clock.Now()) {
+		t.Errorf("LastFailure = %v, want %v", got, clock.Now())
+	}
+	if !svc.IsDataStale(time.Hour) {
+		t.Error("IsDataStale(1h) = false after 2h")
+	}
+	if !svc.IsDegraded() {
+		t.Error("service not degraded two hours after generation")
+	}
+}
+
+func TestFileDataSourceWithClock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	writeData := func(data *orgdatacore.Data) {
+		t.Helper()
+		raw, err := json.Marshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeData(orgdatacore.CreateTestData())
+
+	clock := NewFakeClock(time.Now())
+	source := testingsupport.NewFileDataSource(path)
+	source.PollInterval = time.Minute
+	source.Clock = clock
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := orgdatacore.NewService()
+	if err := svc.StartDataSourceWatcher(ctx, source); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+
+	updated := orgdatacore.CreateTestData()
+	delete(updated.Lookups.Employees, "testuser2")
+	writeData(updated)
+	// Make the change visible even on filesystems with coarse timestamps.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	deadline := time.Now().Add(5 * time.Second)
+	for svc.GetEmployeeByUID("testuser2") != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("service did not reload after the poll interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,663 @@
+// Package orgdatacoretest provides test doubles and helpers for code built on
+// orgdatacore, so consumers can unit-test their bots and services without
+// loading JSON fixtures.
+//
+// FakeService is an orgdatacore.ServiceInterface whose methods can be stubbed
+// one at a time and which records every call:
+//
+//	fake := orgdatacoretest.NewFakeService()
+//	fake.GetEmployeeBySlackIDFunc = func(slackID string) *orgdatacore.Employee {
+//		return &orgdatacore.Employee{UID: "jsmith", SlackUID: slackID}
+//	}
+//	bot := NewBot(fake)
+//	bot.Handle("U123", "who am I?")
+//	if calls := fake.CallsTo("GetEmployeeBySlackID"); len(calls) != 1 {
+//		t.Errorf("GetEmployeeBySlackID called %d times, want 1", len(calls))
+//	}
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// Call is one recorded call to a FakeService method.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// FakeService is an in-memory orgdatacore.ServiceInterface for tests.
+//
+// Setting a method's <Method>Func field stubs that method. Methods that are
+// not stubbed are answered by a backing service: an empty one by default, so
+// every lookup finds nothing, or the one given to WithData or WithService.
+// Every call is recorded, stubbed or not. Set the Func fields before the
+// fake is used concurrently; the other methods are safe for concurrent use.
+type FakeService struct {
+	GetEmployeeByUIDFunc            func(uid string) *orgdatacore.Employee
+	GetEmployeeBySlackIDFunc        func(slackID string) *orgdatacore.Employee
+	GetEmployeeByGitHubIDFunc       func(githubID string) *orgdatacore.Employee
+	GetEmployeeByEmailFunc          func(email string) *orgdatacore.Employee
+	GetManagerForEmployeeFunc       func(uid string) *orgdatacore.Employee
+	GetTeamByNameFunc               func(teamName string) *orgdatacore.Team
+	GetTeamsBySlackChannelFunc      func(channel string) []orgdatacore.Team
+	GetOrgByNameFunc                func(orgName string) *orgdatacore.Org
+	GetPillarByNameFunc             func(pillarName string) *orgdatacore.Pillar
+	GetTeamGroupByNameFunc          func(teamGroupName string) *orgdatacore.TeamGroup
+	GetUserMembershipsFunc          func(uid string) []orgdatacore.MembershipInfo
+	GetUserTeamsFunc                func(uid string) []string
+	GetTeamsForUIDFunc              func(uid string) []string
+	GetTeamsForSlackIDFunc          func(slackID string) []string
+	GetTeamMembersFunc              func(teamName string) []orgdatacore.Employee
+	GetOrgMembersFunc               func(orgName string) []orgdatacore.Employee
+	IsEmployeeInTeamFunc            func(uid string, teamName string) bool
+	IsSlackUserInTeamFunc           func(slackID string, teamName string) bool
+	AreEmployeesInTeamFunc          func(uids []string, teamName string) map[string]bool
+	IsEmployeeInAnyTeamFunc         func(uid string, teams []string) bool
+	IsEmployeeInOrgFunc             func(uid string, orgName string) bool
+	IsSlackUserInOrgFunc            func(slackID string, orgName string) bool
+	GetUserOrganizationsFunc        func(slackUserID string) []orgdatacore.OrgInfo
+	GetTeamEscalationFunc           func(teamName string) []orgdatacore.EscalationContactInfo
+	GetVersionFunc                  func() orgdatacore.DataVersion
+	GetDataAgeFunc                  func() time.Duration
+	IsDataStaleFunc                 func(maxAge time.Duration) bool
+	LoadFromDataSourceFunc          func(ctx context.Context, source orgdatacore.DataSource) error
+	StartDataSourceWatcherFunc      func(ctx context.Context, source orgdatacore.DataSource) error
+	StopWatcherFunc                 func()
+	GetAllEmployeeUIDsFunc          func() []string
+	GetAllEmployeesFunc             func() []orgdatacore.Employee
+	GetAllTeamNamesFunc             func() []string
+	GetAllTeamsFunc                 func() []orgdatacore.Team
+	GetAllOrgNamesFunc              func() []string
+	GetAllOrgsFunc                  func() []orgdatacore.Org
+	GetAllPillarNamesFunc           func() []string
+	GetAllPillarsFunc               func() []orgdatacore.Pillar
+	GetAllTeamGroupNamesFunc        func() []string
+	GetAllTeamGroupsFunc            func() []orgdatacore.TeamGroup
+	GetHierarchyPathFunc            func(entityName string, entityType string) []orgdatacore.HierarchyPathEntry
+	GetDescendantsTreeFunc          func(entityName string) *orgdatacore.HierarchyNode
+	FindPathFunc                    func(from, to orgdatacore.GraphNode) []orgdatacore.GraphEdge
+	NeighborhoodFunc                func(node orgdatacore.GraphNode, hops int) []orgdatacore.GraphEdge
+	SearchFunc                      func(query string, opts ...orgdatacore.SearchOption) []orgdatacore.SearchResult
+	GetComponentByNameFunc          func(name string) *orgdatacore.Component
+	GetAllComponentsFunc            func() []orgdatacore.Component
+	GetAllComponentNamesFunc        func() []string
+	GetTeamsForComponentFunc        func(componentName string) []orgdatacore.ComponentOwnerInfo
+	GetComponentsForTeamFunc        func(teamName string) []orgdatacore.ComponentOwnership
+	GetJiraProjectsFunc             func() []string
+	GetJiraComponentsFunc           func(project string) []string
+	GetTeamsByJiraProjectFunc       func(project string) []orgdatacore.JiraOwnerInfo
+	GetTeamsByJiraComponentFunc     func(project string, component string) []orgdatacore.JiraOwnerInfo
+	GetJiraOwnershipForTeamFunc     func(teamName string) []orgdatacore.JiraOwnership
+	GetContextForTeamFunc           func(teamName string) []orgdatacore.ContextItemInfo
+	GetContextForEntityFunc         func(entityName string, entityType string) []orgdatacore.ContextItemInfo
+	GetContextByTypeFunc            func(entityName string, contextType string, entityType string) []orgdatacore.ContextItemInfo
+	GetAllContextTypesForEntityFunc func(entityName string, entityType string) []string
+	GetContextTypeDescriptionsFunc  func() map[string]string
+
+	mu      sync.Mutex
+	service orgdatacore.ServiceInterface
+	calls   []Call
+}
+
+var _ orgdatacore.ServiceInterface = (*FakeService)(nil)
+
+// NewFakeService returns a FakeService with no stubs and no data.
+func NewFakeService() *FakeService {
+	return &FakeService{service: orgdatacore.NewService(), calls: []Call{}}
+}
+
+// WithData answers the methods that are not stubbed from data, loaded into
+// an orgdatacore.Service. It panics if data does not load, as a test with a
+// broken fixture cannot go on.
+func (f *FakeService) WithData(data *orgdatacore.Data) *FakeService {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("orgdatacoretest: marshal data: %v", err))
+	}
+	service := orgdatacore.NewService()
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(string(raw))); err != nil {
+		panic(fmt.Sprintf("orgdatacoretest: load data: %v", err))
+	}
+	return f.WithService(service)
+}
+
+// WithService answers the methods that are not stubbed from service.
+func (f *FakeService) WithService(service orgdatacore.ServiceInterface) *FakeService {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.service = service
+	return f
+}
+
+// Calls returns every call made so far, in order.
+func (f *FakeService) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := make([]Call, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+// CallsTo returns the calls made so far to method, in order.
+func (f *FakeService) CallsTo(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := []Call{}
+	for _, call := range f.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets the calls recorded so far.
+func (f *FakeService) ResetCalls() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = []Call{}
+}
+
+func (f *FakeService) record(method string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if args == nil {
+		args = []any{}
+	}
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+}
+
+func (f *FakeService) backing() orgdatacore.ServiceInterface {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.service
+}
+
+func (f *FakeService) GetEmployeeByUID(uid string) *orgdatacore.Employee {
+	f.record("GetEmployeeByUID", uid)
+	if f.GetEmployeeByUIDFunc != nil {
+		return f.GetEmployeeByUIDFunc(uid)
+	}
+	return f.backing().GetEmployeeByUID(uid)
+}
+
+func (f *FakeService) GetEmployeeBySlackID(slackID string) *orgdatacore.Employee {
+	f.record("GetEmployeeBySlackID", slackID)
+	if f.GetEmployeeBySlackIDFunc != nil {
+		return f.GetEmployeeBySlackIDFunc(slackID)
+	}
+	return f.backing().GetEmployeeBySlackID(slackID)
+}
+
+func (f *FakeService) GetEmployeeByGitHubID(githubID string) *orgdatacore.Employee {
+	f.record("GetEmployeeByGitHubID", githubID)
+	if f.GetEmployeeByGitHubIDFunc != nil {
+		return f.GetEmployeeByGitHubIDFunc(githubID)
+	}
+	return f.backing().GetEmployeeByGitHubID(githubID)
+}
+
+func (f *FakeService) GetEmployeeByEmail(email string) *orgdatacore.Employee {
+	f.record("GetEmployeeByEmail", email)
+	if f.GetEmployeeByEmailFunc != nil {
+		return f.GetEmployeeByEmailFunc(email)
+	}
+	return f.backing().GetEmployeeByEmail(email)
+}
+
+func (f *FakeService) GetManagerForEmployee(uid string) *orgdatacore.Employee {
+	f.record("GetManagerForEmployee", uid)
+	if f.GetManagerForEmployeeFunc != nil {
+		return f.GetManagerForEmployeeFunc(uid)
+	}
+	return f.backing().GetManagerForEmployee(uid)
+}
+
+func (f *FakeService) GetTeamByName(teamName string) *orgdatacore.Team {
+	f.record("GetTeamByName", teamName)
+	if f.GetTeamByNameFunc != nil {
+		return f.GetTeamByNameFunc(teamName)
+	}
+	return f.backing().GetTeamByName(teamName)
+}
+
+func (f *FakeService) GetTeamsBySlackChannel(channel string) []orgdatacore.Team {
+	f.record("GetTeamsBySlackChannel", channel)
+	if f.GetTeamsBySlackChannelFunc != nil {
+		return f.GetTeamsBySlackChannelFunc(channel)
+	}
+	return f.backing().GetTeamsBySlackChannel(channel)
+}
+
+func (f *FakeService) GetOrgByName(orgName string) *orgdatacore.Org {
+	f.record("GetOrgByName", orgName)
+	if f.GetOrgByNameFunc != nil {
+		return f.GetOrgByNameFunc(orgName)
+	}
+	return f.backing().GetOrgByName(orgName)
+}
+
+func (f *FakeService) GetPillarByName(pillarName string) *orgdatacore.Pillar {
+	f.record("GetPillarByName", pillarName)
+	if f.GetPillarByNameFunc != nil {
+		return f.GetPillarByNameFunc(pillarName)
+	}
+	return f.backing().GetPillarByName(pillarName)
+}
+
+func (f *FakeService) GetTeamGroupByName(teamGroupName string) *orgdatacore.TeamGroup {
+	f.record("GetTeamGroupByName", teamGroupName)
+	if f.GetTeamGroupByNameFunc != nil {
+		return f.GetTeamGroupByNameFunc(teamGroupName)
+	}
+	return f.backing().GetTeamGroupByName(teamGroupName)
+}
+
+func (f *FakeService) GetUserMemberships(uid string) []orgdatacore.MembershipInfo {
+	f.record("GetUserMemberships", uid)
+	if f.GetUserMembershipsFunc != nil {
+		return f.GetUserMembershipsFunc(uid)
+	}
+	return f.backing().GetUserMemberships(uid)
+}
+
+func (f *FakeService) GetUserTeams(uid string) []string {
+	f.record("GetUserTeams", uid)
+	if f.GetUserTeamsFunc != nil {
+		return f.GetUserTeamsFunc(uid)
+	}
+	return f.backing().GetUserTeams(uid)
+}
+
+func (f *FakeService) GetTeamsForUID(uid string) []string {
+	f.record("GetTeamsForUID", uid)
+	if f.GetTeamsForUIDFunc != nil {
+		return f.GetTeamsForUIDFunc(uid)
+	}
+	return f.backing().GetTeamsForUID(uid)
+}
+
+func (f *FakeService) GetTeamsForSlackID(slackID string) []string {
+	f.record("GetTeamsForSlackID", slackID)
+	if f.GetTeamsForSlackIDFunc != nil {
+		return f.GetTeamsForSlackIDFunc(slackID)
+	}
+	return f.backing().GetTeamsForSlackID(slackID)
+}
+
+func (f *FakeService) GetTeamMembers(teamName string) []orgdatacore.Employee {
+	f.record("GetTeamMembers", teamName)
+	if f.GetTeamMembersFunc != nil {
+		return f.GetTeamMembersFunc(teamName)
+	}
+	return f.backing().GetTeamMembers(teamName)
+}
+
+func (f *FakeService) GetOrgMembers(orgName string) []orgdatacore.Employee {
+	f.record("GetOrgMembers", orgName)
+	if f.GetOrgMembersFunc != nil {
+		return f.GetOrgMembersFunc(orgName)
+	}
+	return f.backing().GetOrgMembers(orgName)
+}
+
+func (f *FakeService) IsEmployeeInTeam(uid string, teamName string) bool {
+	f.record("IsEmployeeInTeam", uid, teamName)
+	if f.IsEmployeeInTeamFunc != nil {
+		return f.IsEmployeeInTeamFunc(uid, teamName)
+	}
+	return f.backing().IsEmployeeInTeam(uid, teamName)
+}
+
+func (f *FakeService) IsSlackUserInTeam(slackID string, teamName string) bool {
+	f.record("IsSlackUserInTeam", slackID, teamName)
+	if f.IsSlackUserInTeamFunc != nil {
+		return f.IsSlackUserInTeamFunc(slackID, teamName)
+	}
+	return f.backing().IsSlackUserInTeam(slackID, teamName)
+}
+
+func (f *FakeService) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	f.record("AreEmployeesInTeam", uids, teamName)
+	if f.AreEmployeesInTeamFunc != nil {
+		return f.AreEmployeesInTeamFunc(uids, teamName)
+	}
+	return f.backing().AreEmployeesInTeam(uids, teamName)
+}
+
+func (f *FakeService) IsEmployeeInAnyTeam(uid string, teams []string) bool {
+	f.record("IsEmployeeInAnyTeam", uid, teams)
+	if f.IsEmployeeInAnyTeamFunc != nil {
+		return f.IsEmployeeInAnyTeamFunc(uid, teams)
+	}
+	return f.backing().IsEmployeeInAnyTeam(uid, teams)
+}
+
+func (f *FakeService) IsEmployeeInOrg(uid string, orgName string) bool {
+	f.record("IsEmployeeInOrg", uid, orgName)
+	if f.IsEmployeeInOrgFunc != nil {
+		return f.IsEmployeeInOrgFunc(uid, orgName)
+	}
+	return f.backing().IsEmployeeInOrg(uid, orgName)
+}
+
+func (f *FakeService) IsSlackUserInOrg(slackID string, orgName string) bool {
+	f.record("IsSlackUserInOrg", slackID, orgName)
+	if f.IsSlackUserInOrgFunc != nil {
+		return f.IsSlackUserInOrgFunc(slackID, orgName)
+	}
+	return f.backing().IsSlackUserInOrg(slackID, orgName)
+}
+
+func (f *FakeService) GetUserOrganizations(slackUserID string) []orgdatacore.OrgInfo {
+	f.record("GetUserOrganizations", slackUserID)
+	if f.GetUserOrganizationsFunc != nil {
+		return f.GetUserOrganizationsFunc(slackUserID)
+	}
+	return f.backing().GetUserOrganizations(slackUserID)
+}
+
+func (f *FakeService) GetTeamEscalation(teamName string) []orgdatacore.EscalationContactInfo {
+	f.record("GetTeamEscalation", teamName)
+	if f.GetTeamEscalationFunc != nil {
+		return f.GetTeamEscalationFunc(teamName)
+	}
+	return f.backing().GetTeamEscalation(teamName)
+}
+
+func (f *FakeService) GetVersion() orgdatacore.DataVersion {
+	f.record("GetVersion")
+	if f.GetVersionFunc != nil {
+		return f.GetVersionFunc()
+	}
+	return f.backing().GetVersion()
+}
+
+func (f *FakeService) GetDataAge() time.Duration {
+	f.record("GetDataAge")
+	if f.GetDataAgeFunc != nil {
+		return f.GetDataAgeFunc()
+	}
+	return f.backing().GetDataAge()
+}
+
+func (f *FakeService) IsDataStale(maxAge time.Duration) bool {
+	f.record("IsDataStale", maxAge)
+	if f.IsDataStaleFunc != nil {
+		return f.IsDataStaleFunc(maxAge)
+	}
+	return f.backing().IsDataStale(maxAge)
+}
+
+func (f *FakeService) LoadFromDataSource(ctx context.Context, source orgdatacore.DataSource) error {
+	f.record("LoadFromDataSource", ctx, source)
+	if f.LoadFromDataSourceFunc != nil {
+		return f.LoadFromDataSourceFunc(ctx, source)
+	}
+	return f.backing().LoadFromDataSource(ctx, source)
+}
+
+func (f *FakeService) StartDataSourceWatcher(ctx context.Context, source orgdatacore.DataSource) error {
+	f.record("StartDataSourceWatcher", ctx, source)
+	if f.StartDataSourceWatcherFunc != nil {
+		return f.StartDataSourceWatcherFunc(ctx, source)
+	}
+	return f.backing().StartDataSourceWatcher(ctx, source)
+}
+
+func (f *FakeService) StopWatcher() {
+	f.record("StopWatcher")
+	if f.StopWatcherFunc != nil {
+		f.StopWatcherFunc()
+		return
+	}
+	f.backing().StopWatcher()
+}
+
+func (f *FakeService) GetAllEmployeeUIDs() []string {
+	f.record("GetAllEmployeeUIDs")
+	if f.GetAllEmployeeUIDsFunc != nil {
+		return f.GetAllEmployeeUIDsFunc()
+	}
+	return f.backing().GetAllEmployeeUIDs()
+}
+
+func (f *FakeService) GetAllEmployees() []orgdatacore.Employee {
+	f.record("GetAllEmployees")
+	if f.GetAllEmployeesFunc != nil {
+		return f.GetAllEmployeesFunc()
+	}
+	return f.backing().GetAllEmployees()
+}
+
+func (f *FakeService) GetAllTeamNames() []string {
+	f.record("GetAllTeamNames")
+	if f.GetAllTeamNamesFunc != nil {
+		return f.GetAllTeamNamesFunc()
+	}
+	return f.backing().GetAllTeamNames()
+}
+
+func (f *FakeService) GetAllTeams() []orgdatacore.Team {
+	f.record("GetAllTeams")
+	if f.GetAllTeamsFunc != nil {
+		return f.GetAllTeamsFunc()
+	}
+	return f.backing().GetAllTeams()
+}
+
+func (f *FakeService) GetAllOrgNames() []string {
+	f.record("GetAllOrgNames")
+	if f.GetAllOrgNamesFunc != nil {
+		return f.GetAllOrgNamesFunc()
+	}
+	return f.backing().GetAllOrgNames()
+}
+
+func (f *FakeService) GetAllOrgs() []orgdatacore.Org {
+	f.record("GetAllOrgs")
+	if f.GetAllOrgsFunc != nil {
+		return f.GetAllOrgsFunc()
+	}
+	return f.backing().GetAllOrgs()
+}
+
+func (f *FakeService) GetAllPillarNames() []string {
+	f.record("GetAllPillarNames")
+	if f.GetAllPillarNamesFunc != nil {
+		return f.GetAllPillarNamesFunc()
+	}
+	return f.backing().GetAllPillarNames()
+}
+
+func (f *FakeService) GetAllPillars() []orgdatacore.Pillar {
+	f.record("GetAllPillars")
+	if f.GetAllPillarsFunc != nil {
+		return f.GetAllPillarsFunc()
+	}
+	return f.backing().GetAllPillars()
+}
+
+func (f *FakeService) GetAllTeamGroupNames() []string {
+	f.record("GetAllTeamGroupNames")
+	if f.GetAllTeamGroupNamesFunc != nil {
+		return f.GetAllTeamGroupNamesFunc()
+	}
+	return f.backing().GetAllTeamGroupNames()
+}
+
+func (f *FakeService) GetAllTeamGroups() []orgdatacore.TeamGroup {
+	f.record("GetAllTeamGroups")
+	if f.GetAllTeamGroupsFunc != nil {
+		return f.GetAllTeamGroupsFunc()
+	}
+	return f.backing().GetAllTeamGroups()
+}
+
+func (f *FakeService) GetHierarchyPath(entityName string, entityType string) []orgdatacore.HierarchyPathEntry {
+	f.record("GetHierarchyPath", entityName, entityType)
+	if f.GetHierarchyPathFunc != nil {
+		return f.GetHierarchyPathFunc(entityName, entityType)
+	}
+	return f.backing().GetHierarchyPath(entityName, entityType)
+}
+
+func (f *FakeService) GetDescendantsTree(entityName string) *orgdatacore.HierarchyNode {
+	f.record("GetDescendantsTree", entityName)
+	if f.GetDescendantsTreeFunc != nil {
+		return f.GetDescendantsTreeFunc(entityName)
+	}
+	return f.backing().GetDescendantsTree(entityName)
+}
+
+func (f *FakeService) FindPath(from, to orgdatacore.GraphNode) []orgdatacore.GraphEdge {
+	f.record("FindPath", from, to)
+	if f.FindPathFunc != nil {
+		return f.FindPathFunc(from, to)
+	}
+	return f.backing().FindPath(from, to)
+}
+
+func (f *FakeService) Neighborhood(node orgdatacore.GraphNode, hops int) []orgdatacore.GraphEdge {
+	f.record("Neighborhood", node, hops)
+	if f.NeighborhoodFunc != nil {
+		return f.NeighborhoodFunc(node, hops)
+	}
+	return f.backing().Neighborhood(node, hops)
+}
+
+// Search records its options as an orgdatacore.SearchOptions.
+func (f *FakeService) Search(query string, opts ...orgdatacore.SearchOption) []orgdatacore.SearchResult {
+	f.record("Search", query, orgdatacore.NewSearchOptions(opts...))
+	if f.SearchFunc != nil {
+		return f.SearchFunc(query, opts...)
+	}
+	return f.backing().Search(query, opts...)
+}
+
+func (f *FakeService) GetComponentByName(name string) *orgdatacore.Component {
+	f.record("GetComponentByName", name)
+	if f.GetComponentByNameFunc != nil {
+		return f.GetComponentByNameFunc(name)
+	}
+	return f.backing().GetComponentByName(name)
+}
+
+func (f *FakeService) GetAllComponents() []orgdatacore.Component {
+	f.record("GetAllComponents")
+	if f.GetAllComponentsFunc != nil {
+		return f.GetAllComponentsFunc()
+	}
+	return f.backing().GetAllComponents()
+}
+
+func (f *FakeService) GetAllComponentNames() []string {
+	f.record("GetAllComponentNames")
+	if f.GetAllComponentNamesFunc != nil {
+		return f.GetAllComponentNamesFunc()
+	}
+	return f.backing().GetAllComponentNames()
+}
+
+func (f *FakeService) GetTeamsForComponent(componentName string) []orgdatacore.ComponentOwnerInfo {
+	f.record("GetTeamsForComponent", componentName)
+	if f.GetTeamsForComponentFunc != nil {
+		return f.GetTeamsForComponentFunc(componentName)
+	}
+	return f.backing().GetTeamsForComponent(componentName)
+}
+
+func (f *FakeService) GetComponentsForTeam(teamName string) []orgdatacore.ComponentOwnership {
+	f.record("GetComponentsForTeam", teamName)
+	if f.GetComponentsForTeamFunc != nil {
+		return f.GetComponentsForTeamFunc(teamName)
+	}
+	return f.backing().GetComponentsForTeam(teamName)
+}
+
+func (f *FakeService) GetJiraProjects() []string {
+	f.record("GetJiraProjects")
+	if f.GetJiraProjectsFunc != nil {
+		return f.GetJiraProjectsFunc()
+	}
+	return f.backing().GetJiraProjects()
+}
+
+func (f *FakeService) GetJiraComponents(project string) []string {
+	f.record("GetJiraComponents", project)
+	if f.GetJiraComponentsFunc != nil {
+		return f.GetJiraComponentsFunc(project)
+	}
+	return f.backing().GetJiraComponents(project)
+}
+
+func (f *FakeService) GetTeamsByJiraProject(project string) []orgdatacore.JiraOwnerInfo {
+	f.record("GetTeamsByJiraProject", project)
+	if f.GetTeamsByJiraProjectFunc != nil {
+		return f.GetTeamsByJiraProjectFunc(project)
+	}
+	return f.backing().GetTeamsByJiraProject(project)
+}
+
+func (f *FakeService) GetTeamsByJiraComponent(project string, component string) []orgdatacore.JiraOwnerInfo {
+	f.record("GetTeamsByJiraComponent", project, component)
+	if f.GetTeamsByJiraComponentFunc != nil {
+		return f.GetTeamsByJiraComponentFunc(project, component)
+	}
+	return f.backing().GetTeamsByJiraComponent(project, component)
+}
+
+func (f *FakeService) GetJiraOwnershipForTeam(teamName string) []orgdatacore.JiraOwnership {
+	f.record("GetJiraOwnershipForTeam", teamName)
+	if f.GetJiraOwnershipForTeamFunc != nil {
+		return f.GetJiraOwnershipForTeamFunc(teamName)
+	}
+	return f.backing().GetJiraOwnershipForTeam(teamName)
+}
+
+func (f *FakeService) GetContextForTeam(teamName string) []orgdatacore.ContextItemInfo {
+	f.record("GetContextForTeam", teamName)
+	if f.GetContextForTeamFunc != nil {
+		return f.GetContextForTeamFunc(teamName)
+	}
+	return f.backing().GetContextForTeam(teamName)
+}
+
+func (f *FakeService) GetContextForEntity(entityName string, entityType string) []orgdatacore.ContextItemInfo {
+	f.record("GetContextForEntity", entityName, entityType)
+	if f.GetContextForEntityFunc != nil {
+		return f.GetContextForEntityFunc(entityName, entityType)
+	}
+	return f.backing().GetContextForEntity(entityName, entityType)
+}
+
+func (f *FakeService) GetContextByType(entityName string, contextType string, entityType string) []orgdatacore.ContextItemInfo {
+	f.record("GetContextByType", entityName, contextType, entityType)
+	if f.GetContextByTypeFunc != nil {
+		return f.GetContextByTypeFunc(entityName, contextType, entityType)
+	}
+	return f.backing().GetContextByType(entityName, contextType, entityType)
+}
+
+func (f *FakeService) GetAllContextTypesForEntity(entityName string, entityType string) []string {
+	f.record("GetAllContextTypesForEntity", entityName, entityType)
+	if f.GetAllContextTypesForEntityFunc != nil {
+		return f.GetAllContextTypesForEntityFunc(entityName, entityType)
+	}
+	return f.backing().GetAllContextTypesForEntity(entityName, entityType)
+}
+
+func (f *FakeService) GetContextTypeDescriptions() map[string]string {
+	f.record("GetContextTypeDescriptions")
+	if f.GetContextTypeDescriptionsFunc != nil {
+		return f.GetContextTypeDescriptionsFunc()
+	}
+	return f.backing().GetContextTypeDescriptions()
+}
@@ -0,0 +1,153 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestFakeServiceStubs(t *testing.T) {
+	fake := NewFakeService().WithData(orgdatacore.CreateTestData())
+	fake.GetEmployeeByUIDFunc = func(uid string) *orgdatacore.Employee {
+		return &orgdatacore.Employee{UID: uid, FullName: "Stubbed"}
+	}
+	fake.IsEmployeeInTeamFunc = func(uid, teamName string) bool {
+		return uid == "anyone"
+	}
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"stubbed lookup", fake.GetEmployeeByUID("nobody").FullName, "Stubbed"},
+		{"stubbed predicate", fake.IsEmployeeInTeam("anyone", "no-team"), true},
+		{"unstubbed from data", fake.GetEmployeeBySlackID("U111111").UID, "testuser1"},
+		{"unstubbed list from data", fake.GetTeamMembers("test-squad")[0].UID != "", true},
+		{"unstubbed missing", fake.GetTeamByName("no-team") == nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakeServiceEmptyByDefault(t *testing.T) {
+	fake := NewFakeService()
+	if emp := fake.GetEmployeeByUID("testuser1"); emp != nil {
+		t.Errorf("GetEmployeeByUID = %v, want nil", emp)
+	}
+	if uids := fake.GetAllEmployeeUIDs(); len(uids) != 0 {
+		t.Errorf("GetAllEmployeeUIDs = %v, want none", uids)
+	}
+}
+
+func TestFakeServiceWithService(t *testing.T) {
+	inner := NewFakeService()
+	inner.GetTeamByNameFunc = func(teamName string) *orgdatacore.Team {
+		return &orgdatacore.Team{Name: teamName}
+	}
+	fake := NewFakeService().WithService(inner)
+
+	if team := fake.GetTeamByName("platform"); team == nil || team.Name != "platform" {
+		t.Errorf("GetTeamByName = %v, want the inner service's team", team)
+	}
+	if n := len(inner.CallsTo("GetTeamByName")); n != 1 {
+		t.Errorf("inner service got %d calls, want 1", n)
+	}
+}
+
+func TestFakeServiceRecordsCalls(t *testing.T) {
+	fake := NewFakeService()
+	fake.GetEmployeeByUIDFunc = func(uid string) *orgdatacore.Employee { return nil }
+
+	fake.GetEmployeeByUID("jsmith")
+	fake.IsEmployeeInTeam("jsmith", "platform")
+	fake.GetEmployeeByUID("adoe")
+
+	want := []Call{
+		{Method: "GetEmployeeByUID", Args: []any{"jsmith"}},
+		{Method: "IsEmployeeInTeam", Args: []any{"jsmith", "platform"}},
+		{Method: "GetEmployeeByUID", Args: []any{"adoe"}},
+	}
+	if got := fake.Calls(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Calls() = %v, want %v", got, want)
+	}
+	if got := fake.CallsTo("GetEmployeeByUID"); len(got) != 2 || got[1].Args[0] != "adoe" {
+		t.Errorf("CallsTo(GetEmployeeByUID) = %v", got)
+	}
+	if got := fake.CallsTo("GetOrgByName"); got == nil || len(got) != 0 {
+		t.Errorf("CallsTo(GetOrgByName) = %#v, want an empty slice", got)
+	}
+
+	fake.ResetCalls()
+	if got := fake.Calls(); len(got) != 0 {
+		t.Errorf("Calls() after ResetCalls = %v, want none", got)
+	}
+}
+
+// TestFakeServiceRecordsEveryMethod calls every ServiceInterface method with
+// zero arguments and checks each call is recorded under the method's name.
+func TestFakeServiceRecordsEveryMethod(t *testing.T) {
+	fake := NewFakeService()
+	defer fake.StopWatcher()
+	iface := reflect.TypeOf((*orgdatacore.ServiceInterface)(nil)).Elem()
+	value := reflect.ValueOf(fake)
+
+	for i := 0; i < iface.NumMethod(); i++ {
+		method := iface.Method(i)
+		t.Run(method.Name, func(t *testing.T) {
+			args := make([]reflect.Value, method.Type.NumIn())
+			for j := range args {
+				args[j] = reflect.Zero(method.Type.In(j))
+			}
+			switch method.Name {
+			case "LoadFromDataSource", "StartDataSourceWatcher":
+				args[0] = reflect.ValueOf(context.Background())
+				args[1] = reflect.ValueOf(orgdatacore.NewFakeDataSource(orgdatacore.CreateTestDataJSON()))
+			}
+			call := value.MethodByName(method.Name).Call
+			if method.Type.IsVariadic() {
+				call = value.MethodByName(method.Name).CallSlice
+			}
+			call(args)
+			if n := len(fake.CallsTo(method.Name)); n != 1 {
+				t.Errorf("recorded %d calls, want 1", n)
+			}
+		})
+	}
+}
+
+func TestFakeServiceConcurrentCalls(t *testing.T) {
+	fake := NewFakeService().WithData(orgdatacore.CreateTestData())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fake.GetEmployeeByUID("testuser1")
+				fake.CallsTo("GetEmployeeByUID")
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(fake.CallsTo("GetEmployeeByUID")); n != 800 {
+		t.Errorf("recorded %d calls, want 800", n)
+	}
+}
+
+func TestFakeServiceWithDataPanicsOnBadData(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("WithData did not panic on data that does not load")
+		}
+	}()
+	NewFakeService().WithData(&orgdatacore.Data{})
+}
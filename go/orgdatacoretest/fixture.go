@@ -0,0 +1,67 @@
+package orgdatacoretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/internal/yaml"
+)
+
+// ReadFixture reads a data file in the dump format and returns it as JSON.
+// Files ending in .yaml or .yml are converted from YAML, which is easier to
+// write and review for the nested groups, parents and Jira indexes that
+// hierarchy and routing tests need:
+//
+//	lookups:
+//	  teams:
+//	    platform:
+//	      name: platform
+//	      parent: {name: eng, type: org}
+//
+// The YAML subset is the one ImportData reads team files in: block and
+// one-line flow collections, quoted and block scalars, and comments, but no
+// anchors or tags. Other files are returned as they are.
+func ReadFixture(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isYAMLFixture(path) {
+		return raw, nil
+	}
+	out, err := yaml.ToJSON(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return out, nil
+}
+
+// LoadFixture reads a data file with ReadFixture and decodes it. Fields the
+// data model does not know are an error, so typos in hand-written fixtures
+// are caught rather than silently dropped.
+func LoadFixture(path string) (*orgdatacore.Data, error) {
+	raw, err := ReadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var data orgdatacore.Data
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &data, nil
+}
+
+func isYAMLFixture(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestLoadFixtureYAML(t *testing.T) {
+	data, err := LoadFixture("testdata/hierarchy.yaml")
+	if err != nil {
+		t.Fatalf("LoadFixture: %v", err)
+	}
+	if got := data.Lookups.Teams["storage"].Description; got != "Owns the storage layer and its operators." {
+		t.Errorf("folded description = %q", got)
+	}
+
+	service := orgdatacore.NewService()
+	source := testingsupport.NewFileDataSource("testdata/hierarchy.yaml")
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	var path []string
+	for _, entry := range service.GetHierarchyPath("storage", "team") {
+		path = append(path, entry.Type+":"+entry.Name)
+	}
+	if want := []string{"team:storage", "team_group:infra", "pillar:platform", "org:eng"}; !reflect.DeepEqual(path, want) {
+		t.Errorf("GetHierarchyPath = %v, want %v", path, want)
+	}
+	if owners := service.GetTeamsByJiraComponent("STOR", "Operator"); len(owners) != 1 || owners[0].Name != "storage" {
+		t.Errorf("GetTeamsByJiraComponent = %+v, want storage", owners)
+	}
+	if !service.IsEmployeeInOrg("bdev", "eng") {
+		t.Error("bdev not in eng")
+	}
+}
+
+func TestLoadFixtureJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	raw, err := json.Marshal(orgdatacore.CreateTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := LoadFixture(path)
+	if err != nil {
+		t.Fatalf("LoadFixture: %v", err)
+	}
+	if _, ok := data.Lookups.Employees["testuser1"]; !ok {
+		t.Error("testuser1 missing from JSON fixture")
+	}
+}
+
+func TestLoadFixtureErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"missing file", filepath.Join(dir, "missing.yaml")},
+		{"invalid YAML", write("bad.yml", "lookups:\n  teams: [a,\n")},
+		{"unknown field", write("typo.yaml", "metadata:\n  data_verison: v1\n")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadFixture(tt.path); err == nil {
+				t.Error("LoadFixture succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,503 @@
+package orgdatacoretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/render"
+)
+
+// UpdateGoldenEnv is the environment variable that makes AssertGolden write
+// golden files instead of comparing against them: UPDATE_GOLDEN=1 go test.
+const UpdateGoldenEnv = "UPDATE_GOLDEN"
+
+// MarshalGolden returns v normalized with Normalize as indented JSON ending
+// in a newline, which is what AssertGolden compares.
+func MarshalGolden(v any) ([]byte, error) {
+	out, err := json.MarshalIndent(Normalize(v), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// AssertGolden checks that got, a query result, matches the golden file at
+// path once normalized, so consumers can pin the answers of the queries they
+// depend on:
+//
+//	orgdatacoretest.AssertGolden(t, "testdata/platform-members.golden.json",
+//		svc.GetTeamMembers("platform"))
+//
+// A mismatch fails the test with a line diff of the JSON. With UPDATE_GOLDEN
+// set to a non-empty value the file is written instead, creating its
+// directory if needed; review the change before committing it.
+func AssertGolden(t testing.TB, path string, got any) {
+	t.Helper()
+	actual, err := MarshalGolden(got)
+	if err != nil {
+		t.Fatalf("golden %s: %v", path, err)
+	}
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			t.Fatalf("golden %s: %v", path, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden %s: %v (run with %s=1 to create it)", path, err, UpdateGoldenEnv)
+	}
+	if !bytes.Equal(want, actual) {
+		t.Errorf("golden %s does not match (-want +got, run with %s=1 to update):\n%s",
+			path, UpdateGoldenEnv, lineDiff(string(want), string(actual)))
+	}
+}
+
+// maxDiffCells bounds the size of the table lineDiff builds.
+const maxDiffCells = 1 << 24
+
+// lineDiff returns a diff of the lines of a and b, with three lines of
+// context around each change and "..." for the lines skipped.
+func lineDiff(a, b string) string {
+	x := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	y := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+	if len(x)*len(y) > maxDiffCells {
+		// Too large to diff in memory; show where they start to differ.
+		k := 0
+		for k < len(x) && k < len(y) && x[k] == y[k] {
+			k++
+		}
+		return fmt.Sprintf("files differ from line %d (%d lines want, %d got)\n", k+1, len(x), len(y))
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:]
+	// and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type line struct {
+		op   byte
+		text string
+	}
+	var lines []line
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, line{' ', x[i]})
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{'-', x[i]})
+			i++
+		default:
+			lines = append(lines, line{'+', y[j]})
+			j++
+		}
+	}
+
+	const context = 3
+	show := make([]bool, len(lines))
+	for k, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for c := max(k-context, 0); c <= min(k+context, len(lines)-1); c++ {
+			show[c] = true
+		}
+	}
+	var sb strings.Builder
+	skipped := false
+	for k, l := range lines {
+		if !show[k] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			sb.WriteString("...\n")
+			skipped = false
+		}
+		fmt.Fprintf(&sb, "%c %s\n", l.op, l.text)
+	}
+	if skipped {
+		sb.WriteString("...\n")
+	}
+	return sb.String()
+}
+
+// Normalize returns output, the result of a ServiceInterface query, in the
+// stable form the parity check compares the Go and Python implementations
+// in: lists are sorted by render.Sorted, entities are reduced to their
+// identifying fields, and nil pointers become nil. Values of other types are
+// returned as they are.
+func Normalize(output any) any {
+	if output == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(output)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+
+	switch val := render.Sorted(output).(type) {
+	case bool:
+		return val
+	case string:
+		return val
+	case []string:
+		return val
+	case *orgdatacore.Employee:
+		return normalizeEmployee(val)
+	case []orgdatacore.Employee:
+		return normalizeEmployeeList(val)
+	case *orgdatacore.Team:
+		return normalizeTeam(val)
+	case *orgdatacore.Org:
+		return normalizeOrg(val)
+	case *orgdatacore.Pillar:
+		return normalizePillar(val)
+	case *orgdatacore.TeamGroup:
+		return normalizeTeamGroup(val)
+	case *orgdatacore.Component:
+		return normalizeComponent(val)
+	case []orgdatacore.Component:
+		return normalizeComponentList(val)
+	case []orgdatacore.HierarchyPathEntry:
+		return normalizeHierarchyPath(val)
+	case *orgdatacore.HierarchyNode:
+		return normalizeHierarchyNode(val)
+	case []orgdatacore.OrgInfo:
+		return normalizeOrgInfoList(val)
+	case []orgdatacore.JiraOwnerInfo:
+		return normalizeJiraOwnerList(val)
+	case []orgdatacore.JiraOwnership:
+		return normalizeJiraOwnershipList(val)
+	case []orgdatacore.Team:
+		return normalizeTeamList(val)
+	case []orgdatacore.Org:
+		return normalizeOrgList(val)
+	case []orgdatacore.Pillar:
+		return normalizePillarList(val)
+	case []orgdatacore.TeamGroup:
+		return normalizeTeamGroupList(val)
+	case []orgdatacore.MembershipInfo:
+		return normalizeMembershipInfoList(val)
+	case []orgdatacore.EscalationContactInfo:
+		return normalizeEscalationList(val)
+	case []orgdatacore.ComponentOwnerInfo:
+		return normalizeComponentOwnerInfoList(val)
+	case []orgdatacore.ComponentOwnership:
+		return normalizeComponentOwnershipList(val)
+	case []orgdatacore.ContextItemInfo:
+		return normalizeContextItemInfoList(val)
+	case []orgdatacore.SearchResult:
+		return val
+	case orgdatacore.DataVersion:
+		return normalizeDataVersion(val)
+	default:
+		return output
+	}
+}
+
+// normalizeDataVersion keeps the fields that depend only on the loaded
+// payload; the load time and ConfigMaps differ from run to run.
+func normalizeDataVersion(v orgdatacore.DataVersion) any {
+	return map[string]any{
+		"checksum":       v.Checksum,
+		"org_count":      v.OrgCount,
+		"employee_count": v.EmployeeCount,
+	}
+}
+
+func normalizeEmployee(emp *orgdatacore.Employee) any {
+	if emp == nil {
+		return nil
+	}
+	return map[string]any{
+		"uid":       emp.UID,
+		"full_name": emp.FullName,
+		"email":     emp.Email,
+	}
+}
+
+func normalizeEmployeeList(emps []orgdatacore.Employee) any {
+	result := make([]map[string]any, len(emps))
+	for i, emp := range emps {
+		result[i] = map[string]any{
+			"uid":       emp.UID,
+			"full_name": emp.FullName,
+			"email":     emp.Email,
+		}
+	}
+	return result
+}
+
+func normalizeTeam(team *orgdatacore.Team) any {
+	if team == nil {
+		return nil
+	}
+	return map[string]any{
+		"uid":         team.UID,
+		"name":        team.Name,
+		"description": team.Description,
+	}
+}
+
+func normalizeOrg(org *orgdatacore.Org) any {
+	if org == nil {
+		return nil
+	}
+	return map[string]any{
+		"uid":         org.UID,
+		"name":        org.Name,
+		"description": org.Description,
+	}
+}
+
+func normalizePillar(pillar *orgdatacore.Pillar) any {
+	if pillar == nil {
+		return nil
+	}
+	return map[string]any{
+		"uid":         pillar.UID,
+		"name":        pillar.Name,
+		"description": pillar.Description,
+	}
+}
+
+func normalizeTeamGroup(tg *orgdatacore.TeamGroup) any {
+	if tg == nil {
+		return nil
+	}
+	return map[string]any{
+		"uid":         tg.UID,
+		"name":        tg.Name,
+		"description": tg.Description,
+	}
+}
+
+func normalizeComponent(comp *orgdatacore.Component) any {
+	if comp == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":        comp.Name,
+		"description": comp.Description,
+	}
+}
+
+func normalizeComponentList(comps []orgdatacore.Component) any {
+	result := make([]map[string]any, len(comps))
+	for i, comp := range comps {
+		result[i] = map[string]any{
+			"name":        comp.Name,
+			"description": comp.Description,
+		}
+	}
+	return result
+}
+
+func normalizeHierarchyPath(path []orgdatacore.HierarchyPathEntry) any {
+	result := make([]map[string]any, len(path))
+	for i, entry := range path {
+		result[i] = map[string]any{
+			"name": entry.Name,
+			"type": entry.Type,
+		}
+	}
+	return result
+}
+
+func normalizeHierarchyNode(node *orgdatacore.HierarchyNode) any {
+	if node == nil {
+		return nil
+	}
+	return normalizeNodeRecursive(node)
+}
+
+func normalizeNodeRecursive(node *orgdatacore.HierarchyNode) map[string]any {
+	children := make([]map[string]any, len(node.Children))
+	for i := range node.Children {
+		children[i] = normalizeNodeRecursive(&node.Children[i])
+	}
+	return map[string]any{
+		"name":     node.Name,
+		"type":     node.Type,
+		"children": children,
+	}
+}
+
+func normalizeOrgInfoList(infos []orgdatacore.OrgInfo) any {
+	result := make([]map[string]any, len(infos))
+	for i, info := range infos {
+		result[i] = map[string]any{
+			"name": info.Name,
+			"type": string(info.Type),
+		}
+	}
+	return result
+}
+
+func normalizeJiraOwnerList(owners []orgdatacore.JiraOwnerInfo) any {
+	result := make([]map[string]any, len(owners))
+	for i, owner := range owners {
+		result[i] = map[string]any{
+			"name": owner.Name,
+			"type": owner.Type,
+		}
+	}
+	return result
+}
+
+func normalizeJiraOwnershipList(ownerships []orgdatacore.JiraOwnership) any {
+	result := make([]map[string]any, len(ownerships))
+	for i, ownership := range ownerships {
+		result[i] = map[string]any{
+			"project":   ownership.Project,
+			"component": ownership.Component,
+		}
+	}
+	return result
+}
+
+func normalizeTeamList(teams []orgdatacore.Team) any {
+	result := make([]map[string]any, len(teams))
+	for i, team := range teams {
+		result[i] = map[string]any{
+			"uid":         team.UID,
+			"name":        team.Name,
+			"description": team.Description,
+		}
+	}
+	return result
+}
+
+func normalizeOrgList(orgs []orgdatacore.Org) any {
+	result := make([]map[string]any, len(orgs))
+	for i, org := range orgs {
+		result[i] = map[string]any{
+			"uid":         org.UID,
+			"name":        org.Name,
+			"description": org.Description,
+		}
+	}
+	return result
+}
+
+func normalizePillarList(pillars []orgdatacore.Pillar) any {
+	result := make([]map[string]any, len(pillars))
+	for i, pillar := range pillars {
+		result[i] = map[string]any{
+			"uid":         pillar.UID,
+			"name":        pillar.Name,
+			"description": pillar.Description,
+		}
+	}
+	return result
+}
+
+func normalizeTeamGroupList(tgs []orgdatacore.TeamGroup) any {
+	result := make([]map[string]any, len(tgs))
+	for i, tg := range tgs {
+		result[i] = map[string]any{
+			"uid":         tg.UID,
+			"name":        tg.Name,
+			"description": tg.Description,
+		}
+	}
+	return result
+}
+
+func normalizeMembershipInfoList(infos []orgdatacore.MembershipInfo) any {
+	result := make([]map[string]any, len(infos))
+	for i, info := range infos {
+		result[i] = map[string]any{
+			"name": info.Name,
+			"type": info.Type,
+		}
+	}
+	return result
+}
+
+func normalizeEscalationList(contacts []orgdatacore.EscalationContactInfo) any {
+	result := make([]map[string]any, len(contacts))
+	for i, contact := range contacts {
+		result[i] = map[string]any{
+			"name":        contact.Name,
+			"url":         contact.URL,
+			"description": contact.Description,
+		}
+	}
+	// Escalation order matters (it's priority order), so don't sort
+	return result
+}
+
+func normalizeComponentOwnerInfoList(owners []orgdatacore.ComponentOwnerInfo) any {
+	result := make([]map[string]any, len(owners))
+	for i, owner := range owners {
+		result[i] = map[string]any{
+			"name":            owner.Name,
+			"type":            owner.Type,
+			"ownership_types": owner.OwnershipTypes,
+		}
+	}
+	return result
+}
+
+func normalizeComponentOwnershipList(ownerships []orgdatacore.ComponentOwnership) any {
+	result := make([]map[string]any, len(ownerships))
+	for i, ownership := range ownerships {
+		result[i] = map[string]any{
+			"component":       ownership.Component,
+			"ownership_types": ownership.OwnershipTypes,
+		}
+	}
+	return result
+}
+
+func normalizeContextItemInfoList(items []orgdatacore.ContextItemInfo) any {
+	result := make([]map[string]any, len(items))
+	for i, item := range items {
+		var owner any
+		if item.Owner != "" {
+			owner = item.Owner
+		}
+		types := item.Types
+		if types == nil {
+			types = []string{}
+		}
+		result[i] = map[string]any{
+			"types":         types,
+			"name":          item.Name,
+			"description":   item.Description,
+			"url":           item.URL,
+			"owner":         owner,
+			"inheritance":   item.Inheritance,
+			"source_entity": item.SourceEntity,
+			"source_type":   item.SourceType,
+		}
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want any
+	}{
+		{"nil employee", (*orgdatacore.Employee)(nil), nil},
+		{"strings sorted", []string{"b", "a"}, []string{"a", "b"}},
+		{
+			"employees sorted by uid",
+			[]orgdatacore.Employee{{UID: "b", FullName: "B"}, {UID: "a", FullName: "A"}},
+			[]map[string]any{
+				{"uid": "a", "full_name": "A", "email": ""},
+				{"uid": "b", "full_name": "B", "email": ""},
+			},
+		},
+		{
+			"escalation order kept",
+			[]orgdatacore.EscalationContactInfo{{Name: "second"}, {Name: "first"}},
+			[]map[string]any{
+				{"name": "second", "url": "", "description": ""},
+				{"name": "first", "url": "", "description": ""},
+			},
+		},
+		{"other types unchanged", 42, 42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Normalize = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingTB records failures instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recordingTB) Helper()               {}
+func (r *recordingTB) Errorf(string, ...any) { r.failed = true }
+func (r *recordingTB) Fatalf(string, ...any) { r.failed = true }
+
+func TestAssertGolden(t *testing.T) {
+	raw, err := json.Marshal(orgdatacore.CreateTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := orgdatacore.NewService()
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(string(raw))); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "golden", "members.json")
+
+	t.Setenv(UpdateGoldenEnv, "1")
+	AssertGolden(t, path, service.GetTeamMembers("test-squad"))
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	t.Setenv(UpdateGoldenEnv, "")
+	AssertGolden(t, path, service.GetTeamMembers("test-squad"))
+
+	tb := &recordingTB{TB: t}
+	AssertGolden(tb, path, []orgdatacore.Employee{})
+	if !tb.failed {
+		t.Error("AssertGolden passed with a different result")
+	}
+	tb = &recordingTB{TB: t}
+	AssertGolden(tb, filepath.Join(t.TempDir(), "missing.json"), nil)
+	if !tb.failed {
+		t.Error("AssertGolden passed with a missing golden file")
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\n3\n4\n5\n6\nseven\n8\n9\n10\n"
+	want := "...\n  4\n  5\n  6\n- 7\n+ seven\n  8\n  9\n  10\n"
+	if got := lineDiff(a, b); got != want {
+		t.Errorf("lineDiff =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,212 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// Mix configures what Hammer runs.
+type Mix struct {
+	// Workers is the number of goroutines; 8 if zero.
+	Workers int
+	// Queries and Reloads weigh how often a worker runs a query or a reload
+	// next. With both zero, workers only query.
+	Queries int
+	Reloads int
+	// Source is what reloads load from. It is required when Reloads is set.
+	Source orgdatacore.DataSource
+	// Custom operations, such as calls into the consumer's own code that
+	// reads the service, are run in place of a built-in query one time in
+	// two.
+	Custom []func(r *rand.Rand)
+}
+
+// HammerStats counts what Hammer did.
+type HammerStats struct {
+	Queries      int
+	Reloads      int
+	ReloadErrors int
+}
+
+// Hammer runs the mix of queries and reloads against service from several
+// goroutines at once until duration has passed, to shake out data races in
+// code built on the service. Run it under go test -race, which reports races
+// as test failures:
+//
+//	stats, err := orgdatacoretest.Hammer(svc, 2*time.Second, orgdatacoretest.Mix{
+//		Queries: 20, Reloads: 1, Source: source,
+//		Custom:  []func(*rand.Rand){func(*rand.Rand) { bot.Handle("U123", "who owns PROJ?") }},
+//	})
+//
+// Queries call the lookups of ServiceInterface with keys taken from the data
+// loaded when Hammer starts, and a few that match nothing. Panics in queries
+// or custom operations are recovered; the error joins the first of them with
+// the first reload error.
+func Hammer(service orgdatacore.ServiceInterface, duration time.Duration, mix Mix) (HammerStats, error) {
+	if mix.Reloads > 0 && mix.Source == nil {
+		return HammerStats{}, errors.New("orgdatacoretest: Hammer: Reloads set without a Source")
+	}
+	workers := mix.Workers
+	if workers <= 0 {
+		workers = 8
+	}
+	if mix.Queries <= 0 && mix.Reloads <= 0 {
+		mix.Queries = 1
+	}
+	keys := hammerKeysFrom(service)
+	deadline := time.Now().Add(duration)
+
+	var (
+		mu        sync.Mutex
+		stats     HammerStats
+		reloadErr error
+		panicErr  error
+		wg        sync.WaitGroup
+	)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			var local HammerStats
+			for time.Now().Before(deadline) {
+				if r.Intn(mix.Queries+mix.Reloads) < mix.Reloads {
+					local.Reloads++
+					if err := service.LoadFromDataSource(context.Background(), mix.Source); err != nil {
+						local.ReloadErrors++
+						mu.Lock()
+						if reloadErr == nil {
+							reloadErr = err
+						}
+						mu.Unlock()
+					}
+					continue
+				}
+				local.Queries++
+				if err := hammerQuery(service, r, keys, mix.Custom); err != nil {
+					mu.Lock()
+					if panicErr == nil {
+						panicErr = err
+					}
+					mu.Unlock()
+				}
+			}
+			mu.Lock()
+			stats.Queries += local.Queries
+			stats.Reloads += local.Reloads
+			stats.ReloadErrors += local.ReloadErrors
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return stats, errors.Join(panicErr, reloadErr)
+}
+
+// hammerKeys are lookup keys found in the data, plus some that match nothing.
+type hammerKeys struct {
+	uids, slackIDs, githubIDs, emails []string
+	teams, orgs, channels, projects   []string
+}
+
+func hammerKeysFrom(service orgdatacore.ServiceInterface) *hammerKeys {
+	k := &hammerKeys{
+		uids:      []string{"no-such-uid"},
+		slackIDs:  []string{"UNOSUCHID"},
+		githubIDs: []string{"no-such-login"},
+		emails:    []string{"nobody@example.com"},
+		teams:     append(service.GetAllTeamNames(), "no-such-team"),
+		orgs:      append(service.GetAllOrgNames(), "no-such-org"),
+		channels:  []string{"#no-such-channel"},
+		projects:  append(service.GetJiraProjects(), "NOSUCH"),
+	}
+	for _, emp := range service.GetAllEmployees() {
+		k.uids = append(k.uids, emp.UID)
+		if emp.SlackUID != "" {
+			k.slackIDs = append(k.slackIDs, emp.SlackUID)
+		}
+		if emp.GitHubID != "" {
+			k.githubIDs = append(k.githubIDs, emp.GitHubID)
+		}
+		if emp.Email != "" {
+			k.emails = append(k.emails, emp.Email)
+		}
+	}
+	for _, team := range service.GetAllTeams() {
+		if team.Group.Slack != nil {
+			for _, ch := range team.Group.Slack.Channels {
+				k.channels = append(k.channels, ch.Channel)
+			}
+		}
+	}
+	return k
+}
+
+// hammerQuery runs one random query, or custom operation, and returns the
+// panic it raised, if any.
+func hammerQuery(s orgdatacore.ServiceInterface, r *rand.Rand, k *hammerKeys, custom []func(*rand.Rand)) (err error) {
+	pick := func(from []string) string { return from[r.Intn(len(from))] }
+	var name string
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("orgdatacoretest: Hammer: %s panicked: %v", name, p)
+		}
+	}()
+
+	if len(custom) > 0 && r.Intn(2) == 0 {
+		i := r.Intn(len(custom))
+		name = fmt.Sprintf("custom operation %d", i)
+		custom[i](r)
+		return nil
+	}
+	switch r.Intn(14) {
+	case 0:
+		name = "GetEmployeeByUID"
+		s.GetEmployeeByUID(pick(k.uids))
+	case 1:
+		name = "GetEmployeeBySlackID"
+		s.GetEmployeeBySlackID(pick(k.slackIDs))
+	case 2:
+		name = "GetEmployeeByGitHubID"
+		s.GetEmployeeByGitHubID(pick(k.githubIDs))
+	case 3:
+		name = "GetEmployeeByEmail"
+		s.GetEmployeeByEmail(pick(k.emails))
+	case 4:
+		name = "GetManagerForEmployee"
+		s.GetManagerForEmployee(pick(k.uids))
+	case 5:
+		name = "GetTeamByName"
+		s.GetTeamByName(pick(k.teams))
+	case 6:
+		name = "GetTeamsBySlackChannel"
+		s.GetTeamsBySlackChannel(pick(k.channels))
+	case 7:
+		name = "GetTeamMembers"
+		s.GetTeamMembers(pick(k.teams))
+	case 8:
+		name = "GetOrgMembers"
+		s.GetOrgMembers(pick(k.orgs))
+	case 9:
+		name = "IsEmployeeInTeam"
+		s.IsEmployeeInTeam(pick(k.uids), pick(k.teams))
+	case 10:
+		name = "IsSlackUserInOrg"
+		s.IsSlackUserInOrg(pick(k.slackIDs), pick(k.orgs))
+	case 11:
+		name = "GetUserOrganizations"
+		s.GetUserOrganizations(pick(k.slackIDs))
+	case 12:
+		name = "GetHierarchyPath"
+		s.GetHierarchyPath(pick(k.teams), "team")
+	case 13:
+		name = "GetTeamsByJiraProject"
+		s.GetTeamsByJiraProject(pick(k.projects))
+	}
+	return nil
+}
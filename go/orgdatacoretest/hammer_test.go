@@ -0,0 +1,59 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestHammer(t *testing.T) {
+	raw, err := json.Marshal(orgdatacore.CreateTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := orgdatacore.NewFakeDataSource(string(raw))
+	service := orgdatacore.NewService(orgdatacore.WithQueryCache(16, 0))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	var custom atomic.Int64
+	stats, err := Hammer(service, 100*time.Millisecond, Mix{
+		Workers: 4,
+		Queries: 10,
+		Reloads: 1,
+		Source:  source,
+		Custom:  []func(*rand.Rand){func(*rand.Rand) { custom.Add(1) }},
+	})
+	if err != nil {
+		t.Fatalf("Hammer: %v", err)
+	}
+	if stats.Queries == 0 || stats.Reloads == 0 || custom.Load() == 0 {
+		t.Errorf("stats = %+v with %d custom operations, want all non-zero", stats, custom.Load())
+	}
+	if stats.ReloadErrors != 0 {
+		t.Errorf("ReloadErrors = %d, want 0", stats.ReloadErrors)
+	}
+}
+
+func TestHammerErrors(t *testing.T) {
+	if _, err := Hammer(NewFakeService(), time.Millisecond, Mix{Reloads: 1}); err == nil {
+		t.Error("Hammer with Reloads and no Source succeeded, want an error")
+	}
+
+	fake := NewFakeService()
+	fake.GetTeamMembersFunc = func(string) []orgdatacore.Employee { panic("boom") }
+	stats, err := Hammer(fake, 20*time.Millisecond, Mix{Workers: 2})
+	if err == nil || !strings.Contains(err.Error(), "GetTeamMembers panicked: boom") {
+		t.Errorf("Hammer error = %v, want the GetTeamMembers panic", err)
+	}
+	if stats.Queries == 0 {
+		t.Error("Hammer ran no queries after a panic")
+	}
+}
@@ -0,0 +1,236 @@
+package orgdatacoretest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// RandomData is a random but internally consistent dataset, for
+// property-based tests with testing/quick, which generates values of it
+// through its Generate method:
+//
+//	err := quick.Check(func(d orgdatacoretest.RandomData) bool {
+//		svc := newServiceFor(d.Data)
+//		return orgdatacoretest.CheckInvariants(svc) == nil
+//	}, nil)
+//
+// The datasets have orgs, pillars and team groups nested in random acyclic
+// hierarchies, teams anywhere in them, employees on zero to two teams with
+// acyclic management chains, and Slack channels, GitHub IDs and Jira
+// ownership on a random subset. quick's size bounds the number of employees.
+type RandomData struct {
+	*orgdatacore.Data
+}
+
+// Generate implements quick.Generator.
+func (RandomData) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(RandomData{RandomDataset(r, size).MustBuild()})
+}
+
+// RandomDataset returns a random Dataset with at most size employees, for
+// tests that add their own entities before building it. See RandomData.
+func RandomDataset(r *rand.Rand, size int) *Dataset {
+	d := NewDataset()
+	size = max(size, 1)
+
+	// Each entity's parent is one added before it, so hierarchies are
+	// acyclic by construction.
+	var parents []string
+	pick := func(from []string) string { return from[r.Intn(len(from))] }
+	var orgs []string
+	for i := range 1 + r.Intn(3) {
+		name := fmt.Sprintf("org-%d", i)
+		if i == 0 {
+			d.WithOrg(name)
+		} else {
+			d.WithOrg(name, InOrg(pick(orgs)))
+		}
+		orgs = append(orgs, name)
+	}
+	parents = append(parents, orgs...)
+	for i := range r.Intn(3) {
+		name := fmt.Sprintf("pillar-%d", i)
+		d.WithPillar(name, InOrg(pick(orgs)))
+		parents = append(parents, name)
+	}
+	for i := range r.Intn(3) {
+		name := fmt.Sprintf("group-%d", i)
+		d.WithTeamGroup(name, under(pick(parents)))
+		parents = append(parents, name)
+	}
+
+	teams := make([]string, 1+r.Intn(max(size/5, 1)))
+	for i := range teams {
+		teams[i] = fmt.Sprintf("team-%d", i)
+		opts := []EntityOption{under(pick(parents))}
+		if r.Intn(2) == 0 {
+			// Few channel names, so some channels are shared.
+			opts = append(opts, SlackChannel(fmt.Sprintf("#chan-%d", r.Intn(len(teams))), "main"))
+		}
+		if r.Intn(3) == 0 {
+			component := ""
+			if r.Intn(2) == 0 {
+				component = fmt.Sprintf("comp-%d", r.Intn(3))
+			}
+			opts = append(opts, Jira(fmt.Sprintf("PROJ%d", r.Intn(3)), component))
+		}
+		d.WithTeam(teams[i], opts...)
+	}
+
+	for i := range r.Intn(size + 1) {
+		uid := fmt.Sprintf("emp-%d", i)
+		var opts []EmployeeOption
+		if i > 0 && r.Intn(4) != 0 {
+			opts = append(opts, ManagedBy(fmt.Sprintf("emp-%d", r.Intn(i))))
+		}
+		onTeams := map[string]bool{}
+		for range r.Intn(3) {
+			team := pick(teams)
+			if !onTeams[team] {
+				onTeams[team] = true
+				opts = append(opts, OnTeam(team))
+			}
+		}
+		if r.Intn(4) != 0 {
+			opts = append(opts, SlackID(fmt.Sprintf("U%06d", i)))
+		}
+		if r.Intn(2) == 0 {
+			opts = append(opts, GitHubID(fmt.Sprintf("gh-%d", i)))
+		}
+		d.WithEmployee(uid, opts...)
+	}
+	return d
+}
+
+// CheckInvariants checks that the answers of s agree with each other, and
+// returns every disagreement found, joined, or nil. It holds for any
+// consistent dataset, so consumers can run it against their own data or
+// wrappers of ServiceInterface. It checks that:
+//
+//   - every employee is found by its UID, Slack ID and GitHub ID;
+//   - every member of GetTeamMembers and GetOrgMembers satisfies
+//     IsEmployeeInTeam or IsEmployeeInOrg, and lists the team in
+//     GetTeamsForUID, and the Slack variants agree;
+//   - every team of GetTeamsForUID has the employee as a member;
+//   - management chains and hierarchy paths have no cycles, and paths start
+//     at the entity asked about;
+//   - every owner of a Jira project or component lists it in
+//     GetJiraOwnershipForTeam.
+//
+// The cost is roughly the size of the data times the number of teams an
+// employee is on; it is meant for test datasets.
+func CheckInvariants(s orgdatacore.ServiceInterface) error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for _, emp := range s.GetAllEmployees() {
+		if got := s.GetEmployeeByUID(emp.UID); got == nil || got.UID != emp.UID {
+			fail("GetEmployeeByUID(%q) does not find the employee", emp.UID)
+		}
+		if emp.SlackUID != "" {
+			if got := s.GetEmployeeBySlackID(emp.SlackUID); got == nil || got.UID != emp.UID {
+				fail("GetEmployeeBySlackID(%q) does not find %q", emp.SlackUID, emp.UID)
+			}
+		}
+		if emp.GitHubID != "" {
+			if got := s.GetEmployeeByGitHubID(emp.GitHubID); got == nil || got.UID != emp.UID {
+				fail("GetEmployeeByGitHubID(%q) does not find %q", emp.GitHubID, emp.UID)
+			}
+		}
+		for _, team := range s.GetTeamsForUID(emp.UID) {
+			if !s.IsEmployeeInTeam(emp.UID, team) {
+				fail("GetTeamsForUID(%q) lists %q, but IsEmployeeInTeam is false", emp.UID, team)
+			}
+		}
+
+		seen := map[string]bool{emp.UID: true}
+		for m := s.GetManagerForEmployee(emp.UID); m != nil; m = s.GetManagerForEmployee(m.UID) {
+			if seen[m.UID] {
+				fail("management chain of %q cycles at %q", emp.UID, m.UID)
+				break
+			}
+			seen[m.UID] = true
+		}
+	}
+
+	for _, team := range s.GetAllTeamNames() {
+		for _, member := range s.GetTeamMembers(team) {
+			if !s.IsEmployeeInTeam(member.UID, team) {
+				fail("GetTeamMembers(%q) lists %q, but IsEmployeeInTeam is false", team, member.UID)
+			}
+			if !slices.Contains(s.GetTeamsForUID(member.UID), team) {
+				fail("GetTeamMembers(%q) lists %q, but GetTeamsForUID does not list the team", team, member.UID)
+			}
+			if member.SlackUID != "" && !s.IsSlackUserInTeam(member.SlackUID, team) {
+				fail("GetTeamMembers(%q) lists %q, but IsSlackUserInTeam(%q) is false", team, member.UID, member.SlackUID)
+			}
+		}
+	}
+
+	for _, org := range s.GetAllOrgNames() {
+		for _, member := range s.GetOrgMembers(org) {
+			if !s.IsEmployeeInOrg(member.UID, org) {
+				fail("GetOrgMembers(%q) lists %q, but IsEmployeeInOrg is false", org, member.UID)
+			}
+			if member.SlackUID != "" && !s.IsSlackUserInOrg(member.SlackUID, org) {
+				fail("GetOrgMembers(%q) lists %q, but IsSlackUserInOrg(%q) is false", org, member.UID, member.SlackUID)
+			}
+		}
+	}
+
+	entities := map[string][]string{
+		"org":        s.GetAllOrgNames(),
+		"pillar":     s.GetAllPillarNames(),
+		"team_group": s.GetAllTeamGroupNames(),
+		"team":       s.GetAllTeamNames(),
+	}
+	for kind, names := range entities {
+		for _, name := range names {
+			path := s.GetHierarchyPath(name, kind)
+			if len(path) == 0 || path[0].Name != name {
+				fail("GetHierarchyPath(%q, %q) = %v, want it to start at the entity", name, kind, path)
+				continue
+			}
+			seen := map[orgdatacore.HierarchyPathEntry]bool{}
+			for _, entry := range path {
+				if seen[entry] {
+					fail("GetHierarchyPath(%q, %q) = %v repeats %v", name, kind, path, entry)
+					break
+				}
+				seen[entry] = true
+			}
+		}
+	}
+
+	for _, project := range s.GetJiraProjects() {
+		for _, owner := range s.GetTeamsByJiraProject(project) {
+			if owner.Type != "team" {
+				continue
+			}
+			owned := s.GetJiraOwnershipForTeam(owner.Name)
+			if !slices.ContainsFunc(owned, func(o orgdatacore.JiraOwnership) bool { return o.Project == project }) {
+				fail("GetTeamsByJiraProject(%q) lists %q, but GetJiraOwnershipForTeam does not", project, owner.Name)
+			}
+		}
+		for _, component := range s.GetJiraComponents(project) {
+			for _, owner := range s.GetTeamsByJiraComponent(project, component) {
+				if owner.Type != "team" {
+					continue
+				}
+				want := orgdatacore.JiraOwnership{Project: project, Component: component}
+				if !slices.Contains(s.GetJiraOwnershipForTeam(owner.Name), want) {
+					fail("GetTeamsByJiraComponent(%q, %q) lists %q, but GetJiraOwnershipForTeam does not", project, component, owner.Name)
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package orgdatacoretest
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestCheckInvariantsRandomData(t *testing.T) {
+	property := func(d RandomData) bool {
+		raw, err := json.Marshal(d.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Random data may have no employees or memberships, which are
+		// required by default.
+		service := orgdatacore.NewService(orgdatacore.WithRequiredSections())
+		if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(string(raw))); err != nil {
+			t.Log(err)
+			return false
+		}
+		if err := CheckInvariants(service); err != nil {
+			t.Log(err)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 50}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckInvariantsFindsViolations(t *testing.T) {
+	data := RandomDataset(rand.New(rand.NewSource(1)), 20).
+		WithTeam("stubbed").
+		WithEmployee("member", OnTeam("stubbed")).
+		MustBuild()
+	fake := NewFakeService().WithData(data)
+	fake.IsEmployeeInTeamFunc = func(uid, teamName string) bool { return false }
+
+	if err := CheckInvariants(fake); err == nil {
+		t.Error("CheckInvariants found no violation with IsEmployeeInTeam always false")
+	}
+	if err := CheckInvariants(NewFakeService().WithData(data)); err != nil {
+		t.Errorf("CheckInvariants on consistent data: %v", err)
+	}
+}
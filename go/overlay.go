@@ -0,0 +1,358 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openshift-eng/cyborg-data/go/internal/yaml"
+)
+
+// Overlay is a set of local patches to apply on top of the loaded data, such
+// as a temporary Slack channel for a team or an interim manager, without
+// changing the upstream dump. It is read from YAML or JSON:
+//
+//	patches:
+//	  - type: employee
+//	    name: jsmith
+//	    set:
+//	      manager_uid: adoe
+//	    reason: Interim manager while bwilson is on leave
+//	    author: hr-ops
+//	    expires: 2026-12-01T00:00:00Z
+//	  - type: team
+//	    name: platform-team
+//	    set:
+//	      group:
+//	        slack:
+//	          channels:
+//	            - channel: "#platform-incident"
+//	    annotations:
+//	      status: incident
+type Overlay struct {
+	Patches []OverlayPatch `json:"patches"`
+}
+
+// OverlayPatch changes or annotates one employee, team, org, pillar or team
+// group.
+type OverlayPatch struct {
+	Type EntityType `json:"type"`
+	// Name is the UID of an employee, or the name of a group.
+	Name string `json:"name"`
+	// Set is a JSON merge patch (RFC 7396) applied to the entity as it is
+	// encoded in the data: objects are merged, a null removes a field, and
+	// any other value replaces it. It cannot change name or uid, nor the
+	// fields the producer's indexes are computed from; see WithOverlay.
+	Set map[string]any `json:"set,omitempty"`
+	// Annotations are notes on the entity for consumers of the service,
+	// returned by Annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Reason      string            `json:"reason,omitempty"`
+	Author      string            `json:"author,omitempty"`
+	// Expires, if set, is when the patch stops being applied. A patch that
+	// expires while its data is loaded stays in effect until the next load.
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// AppliedPatch is an overlay patch in effect on the loaded data.
+type AppliedPatch struct {
+	OverlayPatch
+	// Source is the overlay source the patch came from.
+	Source string `json:"source"`
+}
+
+// WithOverlay patches every fully loaded dataset with the Overlay read from
+// source, before it is repaired and validated, so the patches survive
+// reloads of the upstream dump. The overlay is read again on every load;
+// Reload picks up changes to it. With several overlays, later ones are
+// applied last and win. Patches to entities the data does not have, and
+// expired patches, are skipped; an overlay that cannot be read or decoded
+// fails the load. Change sets keep the patches of the entities they replace.
+//
+// Expiry is checked only when data is loaded: a patch that expires while its
+// data is loaded stays in effect until the next load or Reload.
+//
+// The indexes the service derives from the entities, such as the manager and
+// Slack channel indexes, reflect the patches. The indexes computed by the
+// producer cannot be, so patches may not set the fields they come from: an
+// employee's slack_uid or github_id, or a group's parent,
+// group.resolved_people_uid_list or group.jiras. ParseOverlay rejects them.
+func WithOverlay(source DataSource) ServiceOption {
+	return func(c *serviceConfig) {
+		if source != nil {
+			c.overlays = append(c.overlays, source)
+		}
+	}
+}
+
+// ParseOverlay decodes an Overlay from YAML or JSON, failing with an error
+// wrapping ErrInvalidOverlay if it is malformed.
+func ParseOverlay(src []byte) (*Overlay, error) {
+	raw := bytes.TrimSpace(src)
+	if !bytes.HasPrefix(raw, []byte("{")) {
+		var err error
+		if raw, err = yaml.ToJSON(src); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOverlay, err)
+		}
+	}
+	var overlay Overlay
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&overlay); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOverlay, err)
+	}
+	for i, p := range overlay.Patches {
+		switch {
+		case p.Type != EntityEmployee && p.Type != EntityTeam && p.Type != EntityOrg &&
+			p.Type != EntityPillar && p.Type != EntityTeamGroup:
+			return nil, fmt.Errorf("%w: patch %d: cannot patch entity type %q", ErrInvalidOverlay, i, p.Type)
+		case p.Name == "":
+			return nil, fmt.Errorf("%w: patch %d: name is required", ErrInvalidOverlay, i)
+		case hasKey(p.Set, "name") || hasKey(p.Set, "uid"):
+			return nil, fmt.Errorf("%w: patch %d: cannot change the name or uid of %s %q", ErrInvalidOverlay, i, p.Type, p.Name)
+		}
+		for _, path := range indexedFields(p.Type) {
+			if setsPath(p.Set, path) {
+				return nil, fmt.Errorf("%w: patch %d: cannot change %s of %s %q, which the producer's indexes are computed from",
+					ErrInvalidOverlay, i, strings.Join(path, "."), p.Type, p.Name)
+			}
+		}
+	}
+	return &overlay, nil
+}
+
+// indexedFields returns the paths of the fields of an entity type that the
+// producer's indexes are computed from: the Slack and GitHub ID mappings, the
+// membership index and the Jira index.
+func indexedFields(t EntityType) [][]string {
+	if t == EntityEmployee {
+		return [][]string{{"slack_uid"}, {"github_id"}}
+	}
+	return [][]string{{"parent"}, {"group", "resolved_people_uid_list"}, {"group", "jiras"}}
+}
+
+// setsPath reports whether the merge patch set can change the field at path,
+// either by setting it or by replacing or removing an object containing it.
+func setsPath(set map[string]any, path []string) bool {
+	v, ok := set[path[0]]
+	if !ok {
+		return false
+	}
+	if len(path) == 1 {
+		return true
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return true
+	}
+	return setsPath(m, path[1:])
+}
+
+func hasKey(m map[string]any, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// OverlayPatches returns the overlay patches in effect on an entity, in the
+// order they were applied. Group names are resolved like lookups by name.
+func (s *Service) OverlayPatches(entityType EntityType, name string) []AppliedPatch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil
+	}
+	node := s.resolveNode(GraphNode{Type: entityType, Name: name})
+	var out []AppliedPatch
+	for _, p := range s.data.overlays {
+		if p.Type == node.Type && p.Name == node.Name {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// AllOverlayPatches returns every overlay patch in effect, in the order they
+// were applied, for admin pages showing what differs from upstream.
+func (s *Service) AllOverlayPatches() []AppliedPatch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil
+	}
+	return slices.Clone(s.data.overlays)
+}
+
+// Annotations returns the overlay annotations of an entity, merged in the
+// order the patches were applied, or nil if it has none.
+func (s *Service) Annotations(entityType EntityType, name string) map[string]string {
+	var out map[string]string
+	for _, p := range s.OverlayPatches(entityType, name) {
+		for k, v := range p.Annotations {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// applyOverlays reads the overlay sources and patches data with them.
+func (s *Service) applyOverlays(ctx context.Context, data *Data) error {
+	if len(s.overlays) == 0 {
+		return nil
+	}
+	now := s.clock.Now()
+	for _, source := range s.overlays {
+		overlay, err := loadOverlay(ctx, source)
+		if err != nil {
+			return NewLoadError(source.String(), err)
+		}
+		for _, p := range overlay.Patches {
+			if !p.Expires.IsZero() && !now.Before(p.Expires) {
+				continue
+			}
+			ok, err := patchData(data, p)
+			if err != nil {
+				return NewLoadError(source.String(), fmt.Errorf("%w: %s %q: %v", ErrInvalidOverlay, p.Type, p.Name, err))
+			}
+			if !ok {
+				s.loggerFor(LogLoader).Warn("overlay patches unknown entity", "overlay", source.String(), "type", p.Type, "name", p.Name)
+				continue
+			}
+			data.overlays = append(data.overlays, AppliedPatch{OverlayPatch: p, Source: source.String()})
+		}
+	}
+	if n := len(data.overlays); n > 0 {
+		s.loggerFor(LogLoader).Info("overlays applied", "data_version", data.Metadata.DataVersion, "patches", n)
+	}
+	return nil
+}
+
+func loadOverlay(ctx context.Context, source DataSource) (*Overlay, error) {
+	r, err := source.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOverlay(src)
+}
+
+// reapplyOverlays carries the overlay patches of base over to next, the
+// result of applying cs to it: patches of entities cs replaced are applied
+// again, and patches of entities it removed are dropped.
+func reapplyOverlays(next *Data, cs *ChangeSet) {
+	if len(next.overlays) == 0 {
+		return
+	}
+	kept := make([]AppliedPatch, 0, len(next.overlays))
+	for _, p := range next.overlays {
+		var exists, upserted bool
+		switch p.Type {
+		case EntityEmployee:
+			_, exists = next.Lookups.Employees[p.Name]
+			_, upserted = cs.EmployeesUpserted[p.Name]
+		case EntityTeam:
+			_, exists = next.Lookups.Teams[p.Name]
+			_, upserted = cs.TeamsUpserted[p.Name]
+		case EntityOrg:
+			_, exists = next.Lookups.Orgs[p.Name]
+			_, upserted = cs.OrgsUpserted[p.Name]
+		case EntityPillar:
+			_, exists = next.Lookups.Pillars[p.Name]
+			_, upserted = cs.PillarsUpserted[p.Name]
+		case EntityTeamGroup:
+			_, exists = next.Lookups.TeamGroups[p.Name]
+			_, upserted = cs.TeamGroupsUpserted[p.Name]
+		}
+		if !exists {
+			continue
+		}
+		if upserted {
+			// The patch applied to the same entity type before, and a merge
+			// patch only fails on values the type cannot hold.
+			if _, err := patchData(next, p.OverlayPatch); err != nil {
+				continue
+			}
+		}
+		kept = append(kept, p)
+	}
+	next.overlays = kept
+}
+
+// patchData applies p to its entity in data, reporting whether data has the
+// entity. The lookup maps must not be shared with other data.
+func patchData(data *Data, p OverlayPatch) (bool, error) {
+	switch p.Type {
+	case EntityEmployee:
+		return patchEntity(data.Lookups.Employees, p)
+	case EntityTeam:
+		return patchEntity(data.Lookups.Teams, p)
+	case EntityOrg:
+		return patchEntity(data.Lookups.Orgs, p)
+	case EntityPillar:
+		return patchEntity(data.Lookups.Pillars, p)
+	case EntityTeamGroup:
+		return patchEntity(data.Lookups.TeamGroups, p)
+	}
+	return false, nil
+}
+
+func patchEntity[T any](m map[string]T, p OverlayPatch) (bool, error) {
+	entity, ok := m[p.Name]
+	if !ok {
+		return false, nil
+	}
+	if len(p.Set) == 0 {
+		return true, nil
+	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return true, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return true, err
+	}
+	mergePatch(doc, p.Set)
+	if raw, err = json.Marshal(doc); err != nil {
+		return true, err
+	}
+	var patched T
+	if err := json.Unmarshal(raw, &patched); err != nil {
+		return true, err
+	}
+	m[p.Name] = patched
+	return true, nil
+}
+
+// mergePatch applies the JSON merge patch to doc.
+func mergePatch(doc, patch map[string]any) {
+	for k, v := range patch {
+		if v == nil {
+			delete(doc, k)
+			continue
+		}
+		if pm, ok := v.(map[string]any); ok {
+			dm, ok := doc[k].(map[string]any)
+			if !ok {
+				dm = make(map[string]any)
+				doc[k] = dm
+			}
+			mergePatch(dm, pm)
+			continue
+		}
+		doc[k] = v
+	}
+}
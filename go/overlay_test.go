@@ -0,0 +1,143 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+const testOverlay = `
+patches:
+  - type: employee
+    name: jsmith
+    set:
+      manager_uid: bwilson
+    reason: Interim manager
+    author: hr-ops
+  - type: team
+    name: platform-team
+    set:
+      group:
+        slack:
+          channels:
+            - channel: "#platform-incident"
+    annotations:
+      status: incident
+  - type: employee
+    name: adoe
+    set:
+      job_title: Expired Title
+    expires: 2020-01-01T00:00:00Z
+  - type: team
+    name: nonexistent
+    annotations:
+      status: ignored
+`
+
+func loadWithOverlay(t *testing.T, overlay string) (*Service, error) {
+	t.Helper()
+	service := NewService(WithOverlay(NewFakeDataSource(overlay)))
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))
+	return service, service.LoadFromDataSource(context.Background(), source)
+}
+
+func TestWithOverlay(t *testing.T) {
+	service, err := loadWithOverlay(t, testOverlay)
+	if err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	if mgr := service.GetManagerForEmployee("jsmith"); mgr == nil || mgr.UID != "bwilson" {
+		t.Errorf("GetManagerForEmployee(jsmith) = %v, want the interim manager bwilson", mgr)
+	}
+	service.mu.RLock()
+	reports := service.derived().managerIndex()["bwilson"]
+	service.mu.RUnlock()
+	if len(reports) != 1 || reports[0] != "jsmith" {
+		t.Errorf("reports of bwilson = %v, want jsmith", reports)
+	}
+	if emp := service.GetEmployeeByUID("jsmith"); emp == nil || emp.FullName != "John Smith" {
+		t.Errorf("GetEmployeeByUID(jsmith) = %v, want fields not in the patch kept", emp)
+	}
+	if teams := service.GetTeamsBySlackChannel("platform-incident"); len(teams) != 1 || teams[0].Name != "platform-team" {
+		t.Errorf("GetTeamsBySlackChannel(platform-incident) = %v, want platform-team", teams)
+	}
+	if team := service.GetTeamByName("platform-team"); team == nil || team.Description != "Platform infrastructure team" {
+		t.Errorf("GetTeamByName(platform-team) = %v, want its description kept", team)
+	}
+	if emp := service.GetEmployeeByUID("adoe"); emp == nil || emp.JobTitle == "Expired Title" {
+		t.Errorf("GetEmployeeByUID(adoe) = %v, want the expired patch skipped", emp)
+	}
+
+	if got := service.Annotations(EntityTeam, "platform-team"); got["status"] != "incident" {
+		t.Errorf("Annotations(platform-team) = %v, want status=incident", got)
+	}
+	patches := service.OverlayPatches(EntityEmployee, "jsmith")
+	if len(patches) != 1 || patches[0].Source != "fake-data-source" || patches[0].Reason != "Interim manager" || patches[0].Author != "hr-ops" {
+		t.Errorf("OverlayPatches(jsmith) = %+v, want the interim manager patch with its provenance", patches)
+	}
+	if all := service.AllOverlayPatches(); len(all) != 2 {
+		t.Errorf("AllOverlayPatches() = %+v, want the 2 applied patches", all)
+	}
+	if got := service.Annotations(EntityTeam, "test-team"); got != nil {
+		t.Errorf("Annotations(test-team) = %v, want nil", got)
+	}
+}
+
+func TestWithOverlayChangeSet(t *testing.T) {
+	service, err := loadWithOverlay(t, testOverlay)
+	if err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	// An upstream update of jsmith keeps the overlay's manager.
+	jsmith := *service.GetEmployeeByUID("jsmith")
+	jsmith.ManagerUID = "adoe"
+	jsmith.JobTitle = "Staff Engineer"
+	err = service.ApplyChangeSet(ChangeSet{
+		EmployeesUpserted: map[string]Employee{"jsmith": jsmith},
+		TeamsRemoved:      []string{"platform-team"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if emp := service.GetEmployeeByUID("jsmith"); emp == nil || emp.ManagerUID != "bwilson" || emp.JobTitle != "Staff Engineer" {
+		t.Errorf("GetEmployeeByUID(jsmith) = %v, want the upstream update with the overlay's manager", emp)
+	}
+	if patches := service.OverlayPatches(EntityTeam, "platform-team"); len(patches) != 0 {
+		t.Errorf("OverlayPatches(platform-team) = %v, want none once the team is removed", patches)
+	}
+}
+
+func TestParseOverlay(t *testing.T) {
+	overlay, err := ParseOverlay([]byte(`{"patches": [{"type": "org", "name": "test-org", "annotations": {"owner": "ops"}}]}`))
+	if err != nil || len(overlay.Patches) != 1 || overlay.Patches[0].Annotations["owner"] != "ops" {
+		t.Errorf("ParseOverlay(JSON) = %+v, %v", overlay, err)
+	}
+
+	for name, src := range map[string]string{
+		"rename":         "patches:\n  - type: team\n    name: test-team\n    set:\n      name: other\n",
+		"slack uid":      "patches:\n  - type: employee\n    name: jsmith\n    set:\n      slack_uid: U999\n",
+		"github id":      "patches:\n  - type: employee\n    name: jsmith\n    set:\n      github_id: null\n",
+		"members":        "patches:\n  - type: team\n    name: test-team\n    set:\n      group:\n        resolved_people_uid_list: [bwilson]\n",
+		"group removed":  "patches:\n  - type: team\n    name: test-team\n    set:\n      group: null\n",
+		"jiras":          "patches:\n  - type: team\n    name: test-team\n    set:\n      group:\n        jiras: []\n",
+		"parent":         "patches:\n  - type: org\n    name: test-org\n    set:\n      parent:\n        name: platform-org\n",
+		"unknown type":   "patches:\n  - type: component\n    name: platform-api\n",
+		"missing name":   "patches:\n  - type: team\n",
+		"unknown field":  "patches:\n  - type: team\n    name: test-team\n    color: red\n",
+		"malformed yaml": "patches: [\n",
+	} {
+		if _, err := ParseOverlay([]byte(src)); !errors.Is(err, ErrInvalidOverlay) {
+			t.Errorf("ParseOverlay(%s): err = %v, want ErrInvalidOverlay", name, err)
+		}
+	}
+
+	// An invalid overlay fails the load.
+	if _, err := loadWithOverlay(t, "patches:\n  - type: team\n"); !errors.Is(err, ErrInvalidOverlay) {
+		t.Errorf("LoadFromDataSource with an invalid overlay: err = %v, want ErrInvalidOverlay", err)
+	}
+}
@@ -0,0 +1,359 @@
+package orgdatacore
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// pprof label keys attached to every query made through a ProfiledService.
+const (
+	ProfileLabelMethod = "orgdata_method"
+	ProfileLabelEntity = "orgdata_entity"
+)
+
+// QueryInfo identifies a query made through a ProfiledService.
+type QueryInfo struct {
+	// Method is the ServiceInterface method name, e.g. "GetUserOrganizations".
+	Method string
+	// Entity is the kind of entity the query is about: one of the EntityType
+	// values, or "component", "jira", "context", or "metadata".
+	Entity string
+}
+
+// QueryHook observes queries made through a ProfiledService.
+// Hooks run inside the pprof label scope of the query, so any CPU they use is
+// attributed to it as well; keep them cheap.
+type QueryHook interface {
+	// BeforeQuery is called before the query runs. The returned context is
+	// passed to later hooks and to AfterQuery, so a hook can start a trace
+	// span or attach values. ctx carries the query's pprof labels.
+	BeforeQuery(ctx context.Context, q QueryInfo) context.Context
+
+	// AfterQuery is called once the query has returned.
+	AfterQuery(ctx context.Context, q QueryInfo, elapsed time.Duration)
+}
+
+// ProfiledService is a ServiceInterface decorator that runs every query under
+// pprof.Do with method and entity labels, so CPU profiles of a production
+// process attribute time to individual org-data queries. Optional QueryHooks
+// are notified around each query.
+//
+// The labels cost a few allocations per call; wrap the Service only where the
+// profiling data is wanted.
+type ProfiledService struct {
+	inner ServiceInterface
+	hooks []QueryHook
+}
+
+var _ ServiceInterface = (*ProfiledService)(nil)
+
+// NewProfiledService wraps inner with pprof labels and the given hooks.
+func NewProfiledService(inner ServiceInterface, hooks ...QueryHook) *ProfiledService {
+	return &ProfiledService{inner: inner, hooks: hooks}
+}
+
+// Unwrap returns the wrapped service.
+func (p *ProfiledService) Unwrap() ServiceInterface {
+	return p.inner
+}
+
+func (p *ProfiledService) run(ctx context.Context, method, entity string, fn func(ctx context.Context)) {
+	labels := pprof.Labels(ProfileLabelMethod, method, ProfileLabelEntity, entity)
+	pprof.Do(ctx, labels, func(ctx context.Context) {
+		if len(p.hooks) == 0 {
+			fn(ctx)
+			return
+		}
+		q := QueryInfo{Method: method, Entity: entity}
+		for _, h := range p.hooks {
+			ctx = h.BeforeQuery(ctx, q)
+		}
+		start := time.Now()
+		fn(ctx)
+		elapsed := time.Since(start)
+		for i := len(p.hooks) - 1; i >= 0; i-- {
+			p.hooks[i].AfterQuery(ctx, q, elapsed)
+		}
+	})
+}
+
+// profiledQuery runs fn as the named query and returns its result.
+func profiledQuery[T any](p *ProfiledService, method, entity string, fn func() T) T {
+	var out T
+	p.run(context.Background(), method, entity, func(context.Context) { out = fn() })
+	return out
+}
+
+// Entity label values.
+const (
+	profileEntityEmployee  = string(EntityEmployee)
+	profileEntityTeam      = string(EntityTeam)
+	profileEntityOrg       = string(EntityOrg)
+	profileEntityPillar    = string(EntityPillar)
+	profileEntityTeamGroup = string(EntityTeamGroup)
+	profileEntityComponent = "component"
+	profileEntityJira      = "jira"
+	profileEntityContext   = "context"
+	profileEntityMetadata  = "metadata"
+	profileEntitySearch    = "search"
+)
+
+func (p *ProfiledService) GetEmployeeByUID(uid string) *Employee {
+	return profiledQuery(p, "GetEmployeeByUID", profileEntityEmployee, func() *Employee { return p.inner.GetEmployeeByUID(uid) })
+}
+
+func (p *ProfiledService) GetEmployeeBySlackID(slackID string) *Employee {
+	return profiledQuery(p, "GetEmployeeBySlackID", profileEntityEmployee, func() *Employee { return p.inner.GetEmployeeBySlackID(slackID) })
+}
+
+func (p *ProfiledService) GetEmployeeByGitHubID(githubID string) *Employee {
+	return profiledQuery(p, "GetEmployeeByGitHubID", profileEntityEmployee, func() *Employee { return p.inner.GetEmployeeByGitHubID(githubID) })
+}
+
+func (p *ProfiledService) GetEmployeeByEmail(email string) *Employee {
+	return profiledQuery(p, "GetEmployeeByEmail", profileEntityEmployee, func() *Employee { return p.inner.GetEmployeeByEmail(email) })
+}
+
+func (p *ProfiledService) GetManagerForEmployee(uid string) *Employee {
+	return profiledQuery(p, "GetManagerForEmployee", profileEntityEmployee, func() *Employee { return p.inner.GetManagerForEmployee(uid) })
+}
+
+func (p *ProfiledService) GetTeamByName(teamName string) *Team {
+	return profiledQuery(p, "GetTeamByName", profileEntityTeam, func() *Team { return p.inner.GetTeamByName(teamName) })
+}
+
+func (p *ProfiledService) GetTeamsBySlackChannel(channel string) []Team {
+	return profiledQuery(p, "GetTeamsBySlackChannel", profileEntityTeam, func() []Team { return p.inner.GetTeamsBySlackChannel(channel) })
+}
+
+func (p *ProfiledService) GetOrgByName(orgName string) *Org {
+	return profiledQuery(p, "GetOrgByName", profileEntityOrg, func() *Org { return p.inner.GetOrgByName(orgName) })
+}
+
+func (p *ProfiledService) GetPillarByName(pillarName string) *Pillar {
+	return profiledQuery(p, "GetPillarByName", profileEntityPillar, func() *Pillar { return p.inner.GetPillarByName(pillarName) })
+}
+
+func (p *ProfiledService) GetTeamGroupByName(teamGroupName string) *TeamGroup {
+	return profiledQuery(p, "GetTeamGroupByName", profileEntityTeamGroup, func() *TeamGroup { return p.inner.GetTeamGroupByName(teamGroupName) })
+}
+
+func (p *ProfiledService) GetUserMemberships(uid string) []MembershipInfo {
+	return profiledQuery(p, "GetUserMemberships", profileEntityEmployee, func() []MembershipInfo { return p.inner.GetUserMemberships(uid) })
+}
+
+func (p *ProfiledService) GetUserTeams(uid string) []string {
+	return profiledQuery(p, "GetUserTeams", profileEntityTeam, func() []string { return p.inner.GetUserTeams(uid) })
+}
+
+func (p *ProfiledService) GetTeamsForUID(uid string) []string {
+	return profiledQuery(p, "GetTeamsForUID", profileEntityTeam, func() []string { return p.inner.GetTeamsForUID(uid) })
+}
+
+func (p *ProfiledService) GetTeamsForSlackID(slackID string) []string {
+	return profiledQuery(p, "GetTeamsForSlackID", profileEntityTeam, func() []string { return p.inner.GetTeamsForSlackID(slackID) })
+}
+
+func (p *ProfiledService) GetTeamMembers(teamName string) []Employee {
+	return profiledQuery(p, "GetTeamMembers", profileEntityTeam, func() []Employee { return p.inner.GetTeamMembers(teamName) })
+}
+
+func (p *ProfiledService) GetOrgMembers(orgName string) []Employee {
+	return profiledQuery(p, "GetOrgMembers", profileEntityOrg, func() []Employee { return p.inner.GetOrgMembers(orgName) })
+}
+
+func (p *ProfiledService) IsEmployeeInTeam(uid string, teamName string) bool {
+	return profiledQuery(p, "IsEmployeeInTeam", profileEntityTeam, func() bool { return p.inner.IsEmployeeInTeam(uid, teamName) })
+}
+
+func (p *ProfiledService) IsSlackUserInTeam(slackID string, teamName string) bool {
+	return profiledQuery(p, "IsSlackUserInTeam", profileEntityTeam, func() bool { return p.inner.IsSlackUserInTeam(slackID, teamName) })
+}
+
+func (p *ProfiledService) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	return profiledQuery(p, "AreEmployeesInTeam", profileEntityTeam, func() map[string]bool { return p.inner.AreEmployeesInTeam(uids, teamName) })
+}
+
+func (p *ProfiledService) IsEmployeeInAnyTeam(uid string, teams []string) bool {
+	return profiledQuery(p, "IsEmployeeInAnyTeam", profileEntityTeam, func() bool { return p.inner.IsEmployeeInAnyTeam(uid, teams) })
+}
+
+func (p *ProfiledService) IsEmployeeInOrg(uid string, orgName string) bool {
+	return profiledQuery(p, "IsEmployeeInOrg", profileEntityOrg, func() bool { return p.inner.IsEmployeeInOrg(uid, orgName) })
+}
+
+func (p *ProfiledService) IsSlackUserInOrg(slackID string, orgName string) bool {
+	return profiledQuery(p, "IsSlackUserInOrg", profileEntityOrg, func() bool { return p.inner.IsSlackUserInOrg(slackID, orgName) })
+}
+
+func (p *ProfiledService) GetUserOrganizations(slackUserID string) []OrgInfo {
+	return profiledQuery(p, "GetUserOrganizations", profileEntityOrg, func() []OrgInfo { return p.inner.GetUserOrganizations(slackUserID) })
+}
+
+func (p *ProfiledService) GetTeamEscalation(teamName string) []EscalationContactInfo {
+	return profiledQuery(p, "GetTeamEscalation", profileEntityTeam, func() []EscalationContactInfo { return p.inner.GetTeamEscalation(teamName) })
+}
+
+// Version and staleness checks are trivial and pass through unlabeled.
+
+func (p *ProfiledService) GetVersion() DataVersion {
+	return p.inner.GetVersion()
+}
+
+func (p *ProfiledService) GetDataAge() time.Duration {
+	return p.inner.GetDataAge()
+}
+
+func (p *ProfiledService) IsDataStale(maxAge time.Duration) bool {
+	return p.inner.IsDataStale(maxAge)
+}
+
+// LoadFromDataSource labels the load so decode and indexing time shows up
+// under "LoadFromDataSource" in profiles.
+func (p *ProfiledService) LoadFromDataSource(ctx context.Context, source DataSource) error {
+	var err error
+	p.run(ctx, "LoadFromDataSource", profileEntityMetadata, func(ctx context.Context) {
+		err = p.inner.LoadFromDataSource(ctx, source)
+	})
+	return err
+}
+
+// StartDataSourceWatcher labels the watcher so reload CPU is attributed to it.
+// Hooks are not called, since the watcher blocks until stopped.
+func (p *ProfiledService) StartDataSourceWatcher(ctx context.Context, source DataSource) error {
+	var err error
+	labels := pprof.Labels(ProfileLabelMethod, "StartDataSourceWatcher", ProfileLabelEntity, profileEntityMetadata)
+	pprof.Do(ctx, labels, func(ctx context.Context) {
+		err = p.inner.StartDataSourceWatcher(ctx, source)
+	})
+	return err
+}
+
+func (p *ProfiledService) StopWatcher() {
+	p.inner.StopWatcher()
+}
+
+func (p *ProfiledService) GetAllEmployeeUIDs() []string {
+	return profiledQuery(p, "GetAllEmployeeUIDs", profileEntityEmployee, p.inner.GetAllEmployeeUIDs)
+}
+
+func (p *ProfiledService) GetAllEmployees() []Employee {
+	return profiledQuery(p, "GetAllEmployees", profileEntityEmployee, p.inner.GetAllEmployees)
+}
+
+func (p *ProfiledService) GetAllTeamNames() []string {
+	return profiledQuery(p, "GetAllTeamNames", profileEntityTeam, p.inner.GetAllTeamNames)
+}
+
+func (p *ProfiledService) GetAllTeams() []Team {
+	return profiledQuery(p, "GetAllTeams", profileEntityTeam, p.inner.GetAllTeams)
+}
+
+func (p *ProfiledService) GetAllOrgNames() []string {
+	return profiledQuery(p, "GetAllOrgNames", profileEntityOrg, p.inner.GetAllOrgNames)
+}
+
+func (p *ProfiledService) GetAllOrgs() []Org {
+	return profiledQuery(p, "GetAllOrgs", profileEntityOrg, p.inner.GetAllOrgs)
+}
+
+func (p *ProfiledService) GetAllPillarNames() []string {
+	return profiledQuery(p, "GetAllPillarNames", profileEntityPillar, p.inner.GetAllPillarNames)
+}
+
+func (p *ProfiledService) GetAllPillars() []Pillar {
+	return profiledQuery(p, "GetAllPillars", profileEntityPillar, p.inner.GetAllPillars)
+}
+
+func (p *ProfiledService) GetAllTeamGroupNames() []string {
+	return profiledQuery(p, "GetAllTeamGroupNames", profileEntityTeamGroup, p.inner.GetAllTeamGroupNames)
+}
+
+func (p *ProfiledService) GetAllTeamGroups() []TeamGroup {
+	return profiledQuery(p, "GetAllTeamGroups", profileEntityTeamGroup, p.inner.GetAllTeamGroups)
+}
+
+func (p *ProfiledService) GetHierarchyPath(entityName string, entityType string) []HierarchyPathEntry {
+	return profiledQuery(p, "GetHierarchyPath", entityType, func() []HierarchyPathEntry { return p.inner.GetHierarchyPath(entityName, entityType) })
+}
+
+func (p *ProfiledService) GetDescendantsTree(entityName string) *HierarchyNode {
+	return profiledQuery(p, "GetDescendantsTree", profileEntityOrg, func() *HierarchyNode { return p.inner.GetDescendantsTree(entityName) })
+}
+
+func (p *ProfiledService) FindPath(from, to GraphNode) []GraphEdge {
+	return profiledQuery(p, "FindPath", string(from.Type), func() []GraphEdge { return p.inner.FindPath(from, to) })
+}
+
+func (p *ProfiledService) Neighborhood(node GraphNode, hops int) []GraphEdge {
+	return profiledQuery(p, "Neighborhood", string(node.Type), func() []GraphEdge { return p.inner.Neighborhood(node, hops) })
+}
+
+func (p *ProfiledService) Search(query string, opts ...SearchOption) []SearchResult {
+	return profiledQuery(p, "Search", profileEntitySearch, func() []SearchResult { return p.inner.Search(query, opts...) })
+}
+
+func (p *ProfiledService) GetComponentByName(name string) *Component {
+	return profiledQuery(p, "GetComponentByName", profileEntityComponent, func() *Component { return p.inner.GetComponentByName(name) })
+}
+
+func (p *ProfiledService) GetAllComponents() []Component {
+	return profiledQuery(p, "GetAllComponents", profileEntityComponent, p.inner.GetAllComponents)
+}
+
+func (p *ProfiledService) GetAllComponentNames() []string {
+	return profiledQuery(p, "GetAllComponentNames", profileEntityComponent, p.inner.GetAllComponentNames)
+}
+
+func (p *ProfiledService) GetTeamsForComponent(componentName string) []ComponentOwnerInfo {
+	return profiledQuery(p, "GetTeamsForComponent", profileEntityComponent, func() []ComponentOwnerInfo { return p.inner.GetTeamsForComponent(componentName) })
+}
+
+func (p *ProfiledService) GetComponentsForTeam(teamName string) []ComponentOwnership {
+	return profiledQuery(p, "GetComponentsForTeam", profileEntityComponent, func() []ComponentOwnership { return p.inner.GetComponentsForTeam(teamName) })
+}
+
+func (p *ProfiledService) GetJiraProjects() []string {
+	return profiledQuery(p, "GetJiraProjects", profileEntityJira, p.inner.GetJiraProjects)
+}
+
+func (p *ProfiledService) GetJiraComponents(project string) []string {
+	return profiledQuery(p, "GetJiraComponents", profileEntityJira, func() []string { return p.inner.GetJiraComponents(project) })
+}
+
+func (p *ProfiledService) GetTeamsByJiraProject(project string) []JiraOwnerInfo {
+	return profiledQuery(p, "GetTeamsByJiraProject", profileEntityJira, func() []JiraOwnerInfo { return p.inner.GetTeamsByJiraProject(project) })
+}
+
+func (p *ProfiledService) GetTeamsByJiraComponent(project, component string) []JiraOwnerInfo {
+	return profiledQuery(p, "GetTeamsByJiraComponent", profileEntityJira, func() []JiraOwnerInfo { return p.inner.GetTeamsByJiraComponent(project, component) })
+}
+
+func (p *ProfiledService) GetJiraOwnershipForTeam(teamName string) []JiraOwnership {
+	return profiledQuery(p, "GetJiraOwnershipForTeam", profileEntityJira, func() []JiraOwnership { return p.inner.GetJiraOwnershipForTeam(teamName) })
+}
+
+func (p *ProfiledService) GetContextForTeam(teamName string) []ContextItemInfo {
+	return profiledQuery(p, "GetContextForTeam", profileEntityContext, func() []ContextItemInfo { return p.inner.GetContextForTeam(teamName) })
+}
+
+func (p *ProfiledService) GetContextForEntity(entityName string, entityType string) []ContextItemInfo {
+	return profiledQuery(p, "GetContextForEntity", profileEntityContext, func() []ContextItemInfo { return p.inner.GetContextForEntity(entityName, entityType) })
+}
+
+func (p *ProfiledService) GetContextByType(entityName string, contextType string, entityType string) []ContextItemInfo {
+	return profiledQuery(p, "GetContextByType", profileEntityContext, func() []ContextItemInfo {
+		return p.inner.GetContextByType(entityName, contextType, entityType)
+	})
+}
+
+func (p *ProfiledService) GetAllContextTypesForEntity(entityName string, entityType string) []string {
+	return profiledQuery(p, "GetAllContextTypesForEntity", profileEntityContext, func() []string {
+		return p.inner.GetAllContextTypesForEntity(entityName, entityType)
+	})
+}
+
+func (p *ProfiledService) GetContextTypeDescriptions() map[string]string {
+	return profiledQuery(p, "GetContextTypeDescriptions", profileEntityContext, p.inner.GetContextTypeDescriptions)
+}
@@ -0,0 +1,110 @@
+package orgdatacore
+
+import (
+	"context"
+	"path/filepath"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+type recordingHook struct {
+	calls  []string
+	labels []string
+}
+
+type hookKey struct{}
+
+func (h *recordingHook) BeforeQuery(ctx context.Context, q QueryInfo) context.Context {
+	h.calls = append(h.calls, "before:"+q.Method+":"+q.Entity)
+	label, _ := pprof.Label(ctx, ProfileLabelMethod)
+	h.labels = append(h.labels, label)
+	return context.WithValue(ctx, hookKey{}, q.Method)
+}
+
+func (h *recordingHook) AfterQuery(ctx context.Context, q QueryInfo, elapsed time.Duration) {
+	if v, _ := ctx.Value(hookKey{}).(string); v != q.Method {
+		h.calls = append(h.calls, "after:missing-context")
+		return
+	}
+	h.calls = append(h.calls, "after:"+q.Method)
+}
+
+func TestProfiledService(t *testing.T) {
+	hook := &recordingHook{}
+	profiled := NewProfiledService(NewService(), hook)
+
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	if err := profiled.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(testDataPath)); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+
+	if emp := profiled.GetEmployeeBySlackID("U12345678"); emp == nil || emp.UID != "jsmith" {
+		t.Errorf("GetEmployeeBySlackID through decorator = %v, want jsmith", emp)
+	}
+	if !profiled.IsEmployeeInOrg("bwilson", "test-org") {
+		t.Error("IsEmployeeInOrg through decorator should match the inner service")
+	}
+	if got := profiled.GetVersion(); got.EmployeeCount != 3 {
+		t.Errorf("GetVersion().EmployeeCount = %d, want 3", got.EmployeeCount)
+	}
+
+	wantCalls := []string{
+		"before:LoadFromDataSource:metadata", "after:LoadFromDataSource",
+		"before:GetEmployeeBySlackID:employee", "after:GetEmployeeBySlackID",
+		"before:IsEmployeeInOrg:org", "after:IsEmployeeInOrg",
+	}
+	if len(hook.calls) != len(wantCalls) {
+		t.Fatalf("hook calls = %v, want %v", hook.calls, wantCalls)
+	}
+	for i := range wantCalls {
+		if hook.calls[i] != wantCalls[i] {
+			t.Errorf("hook call %d = %q, want %q", i, hook.calls[i], wantCalls[i])
+		}
+	}
+
+	wantLabels := []string{"LoadFromDataSource", "GetEmployeeBySlackID", "IsEmployeeInOrg"}
+	for i, want := range wantLabels {
+		if hook.labels[i] != want {
+			t.Errorf("pprof label during call %d = %q, want %q", i, hook.labels[i], want)
+		}
+	}
+
+	if _, ok := profiled.Unwrap().(*Service); !ok {
+		t.Error("Unwrap should return the inner service")
+	}
+}
+
+func TestProfiledServiceHookOrder(t *testing.T) {
+	var order []string
+	first := &orderHook{name: "first", order: &order}
+	second := &orderHook{name: "second", order: &order}
+
+	NewProfiledService(NewService(), first, second).GetTeamByName("any")
+
+	want := []string{"before:first", "before:second", "after:second", "after:first"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("order[%d] = %q, want %q", i, order[i], want[i])
+		}
+	}
+}
+
+type orderHook struct {
+	name  string
+	order *[]string
+}
+
+func (h *orderHook) BeforeQuery(ctx context.Context, q QueryInfo) context.Context {
+	*h.order = append(*h.order, "before:"+h.name)
+	return ctx
+}
+
+func (h *orderHook) AfterQuery(ctx context.Context, q QueryInfo, elapsed time.Duration) {
+	*h.order = append(*h.order, "after:"+h.name)
+}
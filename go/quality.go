@@ -0,0 +1,176 @@
+package orgdatacore
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// QualityReport summarizes hygiene problems in a dataset, for org-data
+// dashboards. Every list is sorted so reports can be compared between loads.
+type QualityReport struct {
+	DataVersion string `json:"data_version"`
+	Employees   int    `json:"employees"`
+	Teams       int    `json:"teams"`
+
+	// MissingSlackID lists employees without a Slack ID.
+	MissingSlackID []string `json:"missing_slack_id"`
+	// MissingManager lists employees without a manager. The top of the
+	// hierarchy is expected here.
+	MissingManager []string `json:"missing_manager"`
+	// UnknownManager lists employees whose manager is not an employee.
+	UnknownManager []string `json:"unknown_manager"`
+	// TeamsWithoutMembers lists teams with no resolved members.
+	TeamsWithoutMembers []string `json:"teams_without_members"`
+	// UnresolvedUIDs lists references to UIDs that are not employees.
+	UnresolvedUIDs []UnresolvedUID `json:"unresolved_uids"`
+	// DuplicateIDs lists external IDs shared by more than one employee.
+	DuplicateIDs []DuplicateID `json:"duplicate_ids"`
+}
+
+// UnresolvedUID is a reference to a UID that is not in the employee lookup.
+type UnresolvedUID struct {
+	UID string `json:"uid"`
+	// Where names the referencing index: "membership_index",
+	// "slack_id_mappings", "github_id_mappings", or "team:<name>" for a
+	// team's resolved member list.
+	Where string `json:"where"`
+}
+
+// DuplicateID is an external ID used by several employees.
+type DuplicateID struct {
+	// Kind is "slack", "github", or "email". Emails are compared
+	// case-insensitively.
+	Kind  string   `json:"kind"`
+	Value string   `json:"value"`
+	UIDs  []string `json:"uids"`
+}
+
+// IssueCount returns the total number of problems in the report, counting
+// employees without a manager only beyond the one expected at the top.
+func (r QualityReport) IssueCount() int {
+	n := len(r.MissingSlackID) + len(r.UnknownManager) + len(r.TeamsWithoutMembers) +
+		len(r.UnresolvedUIDs) + len(r.DuplicateIDs)
+	if len(r.MissingManager) > 1 {
+		n += len(r.MissingManager) - 1
+	}
+	return n
+}
+
+// QualityReport analyzes the loaded data with AnalyzeQuality.
+// It returns ErrNoData if nothing is loaded.
+func (s *Service) QualityReport() (QualityReport, error) {
+	s.mu.RLock()
+	data := s.data
+	s.mu.RUnlock()
+
+	if data == nil {
+		return QualityReport{}, ErrNoData
+	}
+	// Loaded data is never modified in place, so it can be read unlocked.
+	return AnalyzeQuality(data), nil
+}
+
+// AnalyzeQuality reports hygiene problems in data. It works on any decoded
+// dataset, so it can also check a file before it is published.
+func AnalyzeQuality(data *Data) QualityReport {
+	employees := data.Lookups.Employees
+	r := QualityReport{
+		DataVersion:         data.Metadata.DataVersion,
+		Employees:           len(employees),
+		Teams:               len(data.Lookups.Teams),
+		MissingSlackID:      []string{},
+		MissingManager:      []string{},
+		UnknownManager:      []string{},
+		TeamsWithoutMembers: []string{},
+		UnresolvedUIDs:      []UnresolvedUID{},
+	}
+
+	for uid, emp := range employees {
+		if emp.SlackUID == "" {
+			r.MissingSlackID = append(r.MissingSlackID, uid)
+		}
+
+		switch _, known := employees[emp.ManagerUID]; {
+		case emp.ManagerUID == "":
+			r.MissingManager = append(r.MissingManager, uid)
+		case !known:
+			r.UnknownManager = append(r.UnknownManager, uid)
+		}
+	}
+
+	for name, team := range data.Lookups.Teams {
+		if len(team.Group.ResolvedPeopleUIDList) == 0 {
+			r.TeamsWithoutMembers = append(r.TeamsWithoutMembers, name)
+		}
+		for _, uid := range team.Group.ResolvedPeopleUIDList {
+			if _, ok := employees[uid]; !ok {
+				r.UnresolvedUIDs = append(r.UnresolvedUIDs, UnresolvedUID{UID: uid, Where: "team:" + name})
+			}
+		}
+	}
+	for uid := range data.Indexes.Membership.MembershipIndex {
+		if _, ok := employees[uid]; !ok {
+			r.UnresolvedUIDs = append(r.UnresolvedUIDs, UnresolvedUID{UID: uid, Where: "membership_index"})
+		}
+	}
+	for _, uid := range data.Indexes.SlackIDMappings.SlackUIDToUID {
+		if _, ok := employees[uid]; !ok {
+			r.UnresolvedUIDs = append(r.UnresolvedUIDs, UnresolvedUID{UID: uid, Where: "slack_id_mappings"})
+		}
+	}
+	for _, uid := range data.Indexes.GitHubIDMappings.GitHubIDToUID {
+		if _, ok := employees[uid]; !ok {
+			r.UnresolvedUIDs = append(r.UnresolvedUIDs, UnresolvedUID{UID: uid, Where: "github_id_mappings"})
+		}
+	}
+
+	r.DuplicateIDs = duplicateIDs(employees)
+
+	sort.Strings(r.MissingSlackID)
+	sort.Strings(r.MissingManager)
+	sort.Strings(r.UnknownManager)
+	sort.Strings(r.TeamsWithoutMembers)
+	slices.SortFunc(r.UnresolvedUIDs, func(a, b UnresolvedUID) int {
+		if c := strings.Compare(a.Where, b.Where); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UID, b.UID)
+	})
+	return r
+}
+
+// duplicateIDs returns the external IDs shared by more than one employee,
+// sorted by kind and value.
+func duplicateIDs(employees map[string]Employee) []DuplicateID {
+	idOwners := map[string]map[string][]string{"slack": {}, "github": {}, "email": {}}
+	for uid, emp := range employees {
+		if emp.SlackUID != "" {
+			idOwners["slack"][emp.SlackUID] = append(idOwners["slack"][emp.SlackUID], uid)
+		}
+		if emp.GitHubID != "" {
+			idOwners["github"][emp.GitHubID] = append(idOwners["github"][emp.GitHubID], uid)
+		}
+		if emp.Email != "" {
+			email := strings.ToLower(emp.Email)
+			idOwners["email"][email] = append(idOwners["email"][email], uid)
+		}
+	}
+
+	dups := []DuplicateID{}
+	for kind, owners := range idOwners {
+		for value, uids := range owners {
+			if len(uids) > 1 {
+				sort.Strings(uids)
+				dups = append(dups, DuplicateID{Kind: kind, Value: value, UIDs: uids})
+			}
+		}
+	}
+	slices.SortFunc(dups, func(a, b DuplicateID) int {
+		if c := strings.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+	return dups
+}
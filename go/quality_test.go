@@ -0,0 +1,64 @@
+package orgdatacore
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeQuality(t *testing.T) {
+	data := CreateTestData()
+	data.Lookups.Employees["testuser1"] = Employee{UID: "testuser1", Email: "Shared@example.com", SlackUID: "U111111", GitHubID: "gh", ManagerUID: "testuser2"}
+	data.Lookups.Employees["testuser2"] = Employee{UID: "testuser2", Email: "shared@example.com", GitHubID: "gh"}
+	data.Lookups.Employees["testuser3"] = Employee{UID: "testuser3", SlackUID: "U333333", ManagerUID: "departed"}
+	data.Lookups.Teams["empty-team"] = Team{Name: "empty-team"}
+	data.Lookups.Teams["test-squad"] = Team{Name: "test-squad", Group: Group{ResolvedPeopleUIDList: []string{"testuser1", "ghost"}}}
+	data.Indexes.Membership.MembershipIndex["ghost"] = []MembershipInfo{{Name: "test-squad", Type: "team"}}
+	data.Indexes.SlackIDMappings.SlackUIDToUID["U999999"] = "ghost"
+
+	r := AnalyzeQuality(data)
+
+	if r.Employees != 3 || r.Teams != 2 || r.DataVersion != "test-v1.0" {
+		t.Errorf("counts = %d employees, %d teams, version %q", r.Employees, r.Teams, r.DataVersion)
+	}
+	checks := []struct {
+		name      string
+		got, want any
+	}{
+		{"MissingSlackID", r.MissingSlackID, []string{"testuser2"}},
+		{"MissingManager", r.MissingManager, []string{"testuser2"}},
+		{"UnknownManager", r.UnknownManager, []string{"testuser3"}},
+		{"TeamsWithoutMembers", r.TeamsWithoutMembers, []string{"empty-team"}},
+		{"UnresolvedUIDs", r.UnresolvedUIDs, []UnresolvedUID{
+			{UID: "ghost", Where: "membership_index"},
+			{UID: "ghost", Where: "slack_id_mappings"},
+			{UID: "ghost", Where: "team:test-squad"},
+		}},
+		{"DuplicateIDs", r.DuplicateIDs, []DuplicateID{
+			{Kind: "email", Value: "shared@example.com", UIDs: []string{"testuser1", "testuser2"}},
+			{Kind: "github", Value: "gh", UIDs: []string{"testuser1", "testuser2"}},
+		}},
+	}
+	for _, c := range checks {
+		if !reflect.DeepEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if got := r.IssueCount(); got != 8 {
+		t.Errorf("IssueCount() = %d, want 8", got)
+	}
+}
+
+func TestServiceQualityReport(t *testing.T) {
+	if _, err := NewService().QualityReport(); !errors.Is(err, ErrNoData) {
+		t.Errorf("QualityReport() with no data = %v, want ErrNoData", err)
+	}
+
+	r, err := setupTestService(t).QualityReport()
+	if err != nil {
+		t.Fatalf("QualityReport: %v", err)
+	}
+	if r.Employees == 0 || r.MissingSlackID == nil || r.DuplicateIDs == nil {
+		t.Errorf("QualityReport() = %+v, want populated report with non-nil lists", r)
+	}
+}
@@ -0,0 +1,140 @@
+package orgdatacore
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// queryKind identifies which derived query a cached result belongs to.
+type queryKind uint8
+
+const (
+	queryUserOrganizations queryKind = iota
+	queryOrgMembers
+	queryDescendantsTree
+)
+
+type queryKey struct {
+	kind  queryKind
+	input string
+}
+
+type queryEntry struct {
+	key     queryKey
+	value   any
+	expires time.Time
+}
+
+// queryCache is a size-bounded LRU cache with per-entry TTL for the results of
+// expensive derived queries. It is purged whenever new data is installed.
+//
+// Callers read and populate the cache while holding the service read lock and
+// purge it while holding the write lock, so a cached value always belongs to
+// the currently loaded data. Cached values are shared; callers must copy them
+// before handing them out.
+type queryCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	clock   Clock
+	order   *list.List // front = most recently used
+	entries map[queryKey]*list.Element
+}
+
+// newQueryCache returns a cache holding at most size entries, each valid for
+// ttl on clock. A non-positive ttl disables expiry. Returns nil if size is not
+// positive.
+func newQueryCache(size int, ttl time.Duration, clock Clock) *queryCache {
+	if size <= 0 {
+		return nil
+	}
+	return &queryCache{
+		size:    size,
+		ttl:     ttl,
+		clock:   clock,
+		order:   list.New(),
+		entries: make(map[queryKey]*list.Element, size),
+	}
+}
+
+func (c *queryCache) get(key queryKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*queryEntry)
+	if c.ttl > 0 && c.clock.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (c *queryCache) put(key queryKey, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.clock.Now().Add(c.ttl)
+	}
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*queryEntry)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&queryEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*queryEntry).key)
+	}
+}
+
+func (c *queryCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+}
+
+func (c *queryCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// cachedQuery returns the cached result for key, or computes, caches, and
+// returns it. When caching is disabled compute is called directly.
+// Must be called with s.mu held.
+func cachedQuery[T any](s *Service, key queryKey, compute func() T) T {
+	if s.queryCache == nil {
+		return compute()
+	}
+	if v, ok := s.queryCache.get(key); ok {
+		return v.(T)
+	}
+	v := compute()
+	s.queryCache.put(key, v)
+	return v
+}
+
+func cloneHierarchyNode(n *HierarchyNode) *HierarchyNode {
+	if n == nil {
+		return nil
+	}
+	out := &HierarchyNode{Name: n.Name, Type: n.Type, Children: make([]HierarchyNode, len(n.Children))}
+	for i := range n.Children {
+		out.Children[i] = *cloneHierarchyNode(&n.Children[i])
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package orgdatacore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestQueryCacheLRU(t *testing.T) {
+	if newQueryCache(0, time.Minute, realClock{}) != nil {
+		t.Error("non-positive size should disable the cache")
+	}
+
+	c := newQueryCache(2, 0, realClock{})
+	a := queryKey{queryOrgMembers, "a"}
+	b := queryKey{queryOrgMembers, "b"}
+	d := queryKey{queryOrgMembers, "d"}
+
+	c.put(a, 1)
+	c.put(b, 2)
+	if _, ok := c.get(a); !ok { // a is now most recently used
+		t.Fatal("expected a to be cached")
+	}
+	c.put(d, 3)
+
+	if _, ok := c.get(b); ok {
+		t.Error("b should have been evicted as least recently used")
+	}
+	if v, ok := c.get(a); !ok || v.(int) != 1 {
+		t.Errorf("get(a) = %v, %v; want 1, true", v, ok)
+	}
+	if c.len() != 2 {
+		t.Errorf("len = %d, want 2", c.len())
+	}
+
+	c.purge()
+	if c.len() != 0 {
+		t.Errorf("len after purge = %d, want 0", c.len())
+	}
+}
+
+func TestQueryCacheTTL(t *testing.T) {
+	c := newQueryCache(10, time.Millisecond, realClock{})
+	key := queryKey{queryDescendantsTree, "x"}
+	c.put(key, "value")
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.get(key); ok {
+		t.Error("entry should have expired")
+	}
+	if c.len() != 0 {
+		t.Error("expired entry should be removed on access")
+	}
+}
+
+func TestServiceQueryCache(t *testing.T) {
+	service := NewService(WithQueryCache(16, time.Minute))
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	if err := service.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(testDataPath)); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+
+	orgs := service.GetUserOrganizations("U12345678")
+	members := service.GetOrgMembers("test-org")
+	tree := service.GetDescendantsTree("test-org")
+	if len(orgs) == 0 || len(members) == 0 || tree == nil {
+		t.Fatal("expected non-empty results from test data")
+	}
+	if got := service.queryCache.len(); got != 3 {
+		t.Errorf("cache entries = %d, want 3", got)
+	}
+
+	// Mutating a returned result must not corrupt the cached copy.
+	orgs[0].Name = "mutated"
+	members[0].UID = "mutated"
+	tree.Name = "mutated"
+	if len(tree.Children) > 0 {
+		tree.Children[0].Name = "mutated"
+	}
+	if got := service.GetUserOrganizations("U12345678"); got[0].Name == "mutated" {
+		t.Error("GetUserOrganizations returned a shared cached slice")
+	}
+	if got := service.GetOrgMembers("test-org"); got[0].UID == "mutated" {
+		t.Error("GetOrgMembers returned a shared cached slice")
+	}
+	if got := service.GetDescendantsTree("test-org"); got.Name == "mutated" ||
+		(len(got.Children) > 0 && got.Children[0].Name == "mutated") {
+		t.Error("GetDescendantsTree returned a shared cached tree")
+	}
+
+	// Misses for unknown inputs are not cached.
+	service.GetOrgMembers("no-such-org")
+	if got := service.queryCache.len(); got != 3 {
+		t.Errorf("cache entries after miss = %d, want 3", got)
+	}
+
+	// Reloading invalidates every cached result.
+	if err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"jsmith"}}); err != nil {
+		t.Fatalf("ApplyChangeSet failed: %v", err)
+	}
+	if got := service.queryCache.len(); got != 0 {
+		t.Errorf("cache entries after reload = %d, want 0", got)
+	}
+	for _, m := range service.GetOrgMembers("test-org") {
+		if m.UID == "jsmith" {
+			t.Error("stale cached result returned after reload")
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package orgdatacore
+
+import "context"
+
+// QueryerWithContext is implemented by ServiceInterface implementations
+// whose queries take a context, so callers can pass deadlines, cancellation
+// and tracing metadata through to a remote backend. Unlike ServiceInterface,
+// the methods return the error of a failed query instead of answering it
+// like a miss; a lookup that finds nothing still returns nil and no error.
+//
+// httpclient.Client implements it. ContextQueries adapts any other
+// ServiceInterface.
+type QueryerWithContext interface {
+	GetEmployeeByUIDContext(ctx context.Context, uid string) (*Employee, error)
+	GetEmployeeBySlackIDContext(ctx context.Context, slackID string) (*Employee, error)
+	GetEmployeeByGitHubIDContext(ctx context.Context, githubID string) (*Employee, error)
+	GetEmployeeByEmailContext(ctx context.Context, email string) (*Employee, error)
+	GetManagerForEmployeeContext(ctx context.Context, uid string) (*Employee, error)
+	GetTeamByNameContext(ctx context.Context, teamName string) (*Team, error)
+	GetTeamsBySlackChannelContext(ctx context.Context, channel string) ([]Team, error)
+	GetOrgByNameContext(ctx context.Context, orgName string) (*Org, error)
+	GetPillarByNameContext(ctx context.Context, pillarName string) (*Pillar, error)
+	GetTeamGroupByNameContext(ctx context.Context, teamGroupName string) (*TeamGroup, error)
+
+	GetUserMembershipsContext(ctx context.Context, uid string) ([]MembershipInfo, error)
+	GetUserTeamsContext(ctx context.Context, uid string) ([]string, error)
+	GetTeamsForUIDContext(ctx context.Context, uid string) ([]string, error)
+	GetTeamsForSlackIDContext(ctx context.Context, slackID string) ([]string, error)
+	GetTeamMembersContext(ctx context.Context, teamName string) ([]Employee, error)
+	GetOrgMembersContext(ctx context.Context, orgName string) ([]Employee, error)
+	IsEmployeeInTeamContext(ctx context.Context, uid string, teamName string) (bool, error)
+	IsSlackUserInTeamContext(ctx context.Context, slackID string, teamName string) (bool, error)
+
+	IsEmployeeInOrgContext(ctx context.Context, uid string, orgName string) (bool, error)
+	IsSlackUserInOrgContext(ctx context.Context, slackID string, orgName string) (bool, error)
+	GetUserOrganizationsContext(ctx context.Context, slackUserID string) ([]OrgInfo, error)
+
+	GetTeamEscalationContext(ctx context.Context, teamName string) ([]EscalationContactInfo, error)
+}
+
+// ContextQueries returns s as a QueryerWithContext: s itself if it
+// implements the interface, otherwise an adapter that returns ctx.Err()
+// without querying once ctx is done, and the answer of s otherwise. In-process
+// queries do not block, so the adapter is all a Service needs.
+func ContextQueries(s ServiceInterface) QueryerWithContext {
+	if q, ok := s.(QueryerWithContext); ok {
+		return q
+	}
+	return contextQueryer{s}
+}
+
+// contextQueryer adapts a ServiceInterface to QueryerWithContext.
+type contextQueryer struct {
+	s ServiceInterface
+}
+
+// withContext runs query unless ctx is already done.
+func withContext[T any](ctx context.Context, query func() T) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	return query(), nil
+}
+
+func (q contextQueryer) GetEmployeeByUIDContext(ctx context.Context, uid string) (*Employee, error) {
+	return withContext(ctx, func() *Employee { return q.s.GetEmployeeByUID(uid) })
+}
+
+func (q contextQueryer) GetEmployeeBySlackIDContext(ctx context.Context, slackID string) (*Employee, error) {
+	return withContext(ctx, func() *Employee { return q.s.GetEmployeeBySlackID(slackID) })
+}
+
+func (q contextQueryer) GetEmployeeByGitHubIDContext(ctx context.Context, githubID string) (*Employee, error) {
+	return withContext(ctx, func() *Employee { return q.s.GetEmployeeByGitHubID(githubID) })
+}
+
+func (q contextQueryer) GetEmployeeByEmailContext(ctx context.Context, email string) (*Employee, error) {
+	return withContext(ctx, func() *Employee { return q.s.GetEmployeeByEmail(email) })
+}
+
+func (q contextQueryer) GetManagerForEmployeeContext(ctx context.Context, uid string) (*Employee, error) {
+	return withContext(ctx, func() *Employee { return q.s.GetManagerForEmployee(uid) })
+}
+
+func (q contextQueryer) GetTeamByNameContext(ctx context.Context, teamName string) (*Team, error) {
+	return withContext(ctx, func() *Team { return q.s.GetTeamByName(teamName) })
+}
+
+func (q contextQueryer) GetTeamsBySlackChannelContext(ctx context.Context, channel string) ([]Team, error) {
+	return withContext(ctx, func() []Team { return q.s.GetTeamsBySlackChannel(channel) })
+}
+
+func (q contextQueryer) GetOrgByNameContext(ctx context.Context, orgName string) (*Org, error) {
+	return withContext(ctx, func() *Org { return q.s.GetOrgByName(orgName) })
+}
+
+func (q contextQueryer) GetPillarByNameContext(ctx context.Context, pillarName string) (*Pillar, error) {
+	return withContext(ctx, func() *Pillar { return q.s.GetPillarByName(pillarName) })
+}
+
+func (q contextQueryer) GetTeamGroupByNameContext(ctx context.Context, teamGroupName string) (*TeamGroup, error) {
+	return withContext(ctx, func() *TeamGroup { return q.s.GetTeamGroupByName(teamGroupName) })
+}
+
+func (q contextQueryer) GetUserMembershipsContext(ctx context.Context, uid string) ([]MembershipInfo, error) {
+	return withContext(ctx, func() []MembershipInfo { return q.s.GetUserMemberships(uid) })
+}
+
+func (q contextQueryer) GetUserTeamsContext(ctx context.Context, uid string) ([]string, error) {
+	return withContext(ctx, func() []string { return q.s.GetUserTeams(uid) })
+}
+
+func (q contextQueryer) GetTeamsForUIDContext(ctx context.Context, uid string) ([]string, error) {
+	return withContext(ctx, func() []string { return q.s.GetTeamsForUID(uid) })
+}
+
+func (q contextQueryer) GetTeamsForSlackIDContext(ctx context.Context, slackID string) ([]string, error) {
+	return withContext(ctx, func() []string { return q.s.GetTeamsForSlackID(slackID) })
+}
+
+func (q contextQueryer) GetTeamMembersContext(ctx context.Context, teamName string) ([]Employee, error) {
+	return withContext(ctx, func() []Employee { return q.s.GetTeamMembers(teamName) })
+}
+
+func (q contextQueryer) GetOrgMembersContext(ctx context.Context, orgName string) ([]Employee, error) {
+	return withContext(ctx, func() []Employee { return q.s.GetOrgMembers(orgName) })
+}
+
+func (q contextQueryer) IsEmployeeInTeamContext(ctx context.Context, uid string, teamName string) (bool, error) {
+	return withContext(ctx, func() bool { return q.s.IsEmployeeInTeam(uid, teamName) })
+}
+
+func (q contextQueryer) IsSlackUserInTeamContext(ctx context.Context, slackID string, teamName string) (bool, error) {
+	return withContext(ctx, func() bool { return q.s.IsSlackUserInTeam(slackID, teamName) })
+}
+
+func (q contextQueryer) IsEmployeeInOrgContext(ctx context.Context, uid string, orgName string) (bool, error) {
+	return withContext(ctx, func() bool { return q.s.IsEmployeeInOrg(uid, orgName) })
+}
+
+func (q contextQueryer) IsSlackUserInOrgContext(ctx context.Context, slackID string, orgName string) (bool, error) {
+	return withContext(ctx, func() bool { return q.s.IsSlackUserInOrg(slackID, orgName) })
+}
+
+func (q contextQueryer) GetUserOrganizationsContext(ctx context.Context, slackUserID string) ([]OrgInfo, error) {
+	return withContext(ctx, func() []OrgInfo { return q.s.GetUserOrganizations(slackUserID) })
+}
+
+func (q contextQueryer) GetTeamEscalationContext(ctx context.Context, teamName string) ([]EscalationContactInfo, error) {
+	return withContext(ctx, func() []EscalationContactInfo { return q.s.GetTeamEscalation(teamName) })
+}
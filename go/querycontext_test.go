@@ -0,0 +1,34 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestContextQueries(t *testing.T) {
+	service := setupTestService(t)
+	q := ContextQueries(service)
+
+	emp, err := q.GetEmployeeByUIDContext(context.Background(), "jsmith")
+	if err != nil || emp == nil || emp.UID != "jsmith" {
+		t.Fatalf("GetEmployeeByUIDContext = %v, %v, want jsmith", emp, err)
+	}
+	emp, err = q.GetEmployeeByUIDContext(context.Background(), "nobody")
+	if err != nil || emp != nil {
+		t.Errorf("GetEmployeeByUIDContext(nobody) = %v, %v, want nil, nil", emp, err)
+	}
+	in, err := q.IsEmployeeInTeamContext(context.Background(), "jsmith", "test-team")
+	if want := service.IsEmployeeInTeam("jsmith", "test-team"); err != nil || in != want {
+		t.Errorf("IsEmployeeInTeamContext = %v, %v, want %v", in, err, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if emp, err := q.GetEmployeeByUIDContext(ctx, "jsmith"); !errors.Is(err, context.Canceled) || emp != nil {
+		t.Errorf("GetEmployeeByUIDContext with a cancelled context = %v, %v, want nil, context.Canceled", emp, err)
+	}
+	if teams, err := q.GetTeamsForUIDContext(ctx, "jsmith"); !errors.Is(err, context.Canceled) || teams != nil {
+		t.Errorf("GetTeamsForUIDContext with a cancelled context = %v, %v, want nil, context.Canceled", teams, err)
+	}
+}
@@ -0,0 +1,70 @@
+package orgdatacore
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// maxQueryLogsPerSecond caps the query log lines written per second. Queries
+// beyond the cap are counted and reported on the next line written.
+const maxQueryLogsPerSecond = 100
+
+// queryLogger logs lookups made on a Service, rate-limited.
+type queryLogger struct {
+	logger *slog.Logger
+	level  slog.Level
+
+	mu          sync.Mutex
+	windowStart time.Time
+	logged      int
+	suppressed  int
+}
+
+func newQueryLogger(logger *slog.Logger, level slog.Level) *queryLogger {
+	return &queryLogger{logger: logger, level: level}
+}
+
+// log records one query. hit reports whether the query found anything, and
+// args are the query's arguments as slog key-value pairs.
+func (q *queryLogger) log(method string, start time.Time, hit func() bool, args ...any) {
+	elapsed := time.Since(start)
+	ctx := context.Background()
+	if !q.logger.Enabled(ctx, q.level) {
+		return
+	}
+
+	suppressed, ok := q.allow(start)
+	if !ok {
+		return
+	}
+
+	attrs := make([]any, 0, len(args)+8)
+	attrs = append(attrs, "method", method)
+	attrs = append(attrs, args...)
+	attrs = append(attrs, "hit", hit(), "duration", elapsed)
+	if suppressed > 0 {
+		attrs = append(attrs, "suppressed", suppressed)
+	}
+	q.logger.Log(ctx, q.level, "orgdata query", attrs...)
+}
+
+// allow reports whether a line may be written now, and how many queries were
+// dropped since the last line written.
+func (q *queryLogger) allow(now time.Time) (suppressed int, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if now.Sub(q.windowStart) >= time.Second {
+		q.windowStart = now
+		q.logged = 0
+	}
+	if q.logged >= maxQueryLogsPerSecond {
+		q.suppressed++
+		return 0, false
+	}
+	q.logged++
+	suppressed, q.suppressed = q.suppressed, 0
+	return suppressed, true
+}
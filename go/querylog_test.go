@@ -0,0 +1,128 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestQueryLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	service := NewService(WithLogger(logger), WithQueryLogging(slog.LevelDebug))
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	buf.Reset()
+
+	service.GetEmployeeBySlackID("U12345678")
+	service.GetEmployeeBySlackID("U00000000")
+	service.IsEmployeeInTeam("jsmith", "test-team")
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("parse log line %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d log lines, want 3:\n%s", len(lines), buf.String())
+	}
+
+	tests := []struct {
+		method string
+		arg    string
+		value  string
+		hit    bool
+	}{
+		{"GetEmployeeBySlackID", "slack_id", "U12345678", true},
+		{"GetEmployeeBySlackID", "slack_id", "U00000000", false},
+		{"IsEmployeeInTeam", "team_name", "test-team", true},
+	}
+	for i, tt := range tests {
+		entry := lines[i]
+		if entry["level"] != "DEBUG" || entry["method"] != tt.method || entry[tt.arg] != tt.value || entry["hit"] != tt.hit {
+			t.Errorf("line %d = %v, want method=%s %s=%s hit=%v", i, entry, tt.method, tt.arg, tt.value, tt.hit)
+		}
+		if _, ok := entry["duration"]; !ok {
+			t.Errorf("line %d has no duration", i)
+		}
+	}
+}
+
+func TestQueryLoggingDisabledLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	service := NewService(WithLogger(logger), WithQueryLogging(slog.LevelDebug))
+
+	service.GetEmployeeByUID("jsmith")
+	if buf.Len() != 0 {
+		t.Errorf("query logged below the logger's level: %s", buf.String())
+	}
+}
+
+func TestQueryLoggerRateLimit(t *testing.T) {
+	q := newQueryLogger(slog.Default(), slog.LevelDebug)
+	start := time.Now()
+
+	for i := range maxQueryLogsPerSecond {
+		if _, ok := q.allow(start); !ok {
+			t.Fatalf("query %d suppressed below the limit", i)
+		}
+	}
+	for range 3 {
+		if _, ok := q.allow(start.Add(500 * time.Millisecond)); ok {
+			t.Fatal("query allowed past the limit")
+		}
+	}
+
+	suppressed, ok := q.allow(start.Add(time.Second))
+	if !ok || suppressed != 3 {
+		t.Errorf("allow() in the next window = %d, %v; want 3 suppressed, true", suppressed, ok)
+	}
+}
+
+func TestWithLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	service := NewService(
+		WithLogger(logger),
+		WithQueryLogging(slog.LevelDebug),
+		WithLogLevels(map[string]slog.Level{LogQuery: slog.LevelDebug, LogLoader: slog.LevelError}),
+		WithLogLevels(map[string]slog.Level{LogWatcher: slog.LevelDebug, "no-such-subsystem": slog.LevelDebug}),
+	)
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if strings.Contains(buf.String(), "data loaded") {
+		t.Errorf("loader logged at Info with its level set to Error:\n%s", buf.String())
+	}
+
+	// The query level is below the handler's, and still applies.
+	service.GetEmployeeByUID("jsmith")
+	if !strings.Contains(buf.String(), "orgdata query") {
+		t.Errorf("query not logged at Debug with its level set to Debug:\n%s", buf.String())
+	}
+
+	ctx := context.Background()
+	if !service.loggerFor(LogWatcher).Enabled(ctx, slog.LevelDebug) {
+		t.Error("watcher logger does not enable Debug")
+	}
+	if service.loggerFor(LogValidator).Enabled(ctx, slog.LevelDebug) {
+		t.Error("validator logger enables Debug without a level set for it")
+	}
+	if _, ok := service.loggers["no-such-subsystem"]; ok {
+		t.Error("unknown subsystem got a logger")
+	}
+}
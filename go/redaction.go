@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"strings"
+	"sync/atomic"
 )
 
 // RedactingDataSource is a DataSource decorator that redacts PII fields
@@ -89,3 +92,60 @@ func redactPII(data *Data) {
 	data.Indexes.SlackIDMappings.SlackUIDToUID = map[string]string{}
 	data.Indexes.GitHubIDMappings.GitHubIDToUID = map[string]string{}
 }
+
+// redactedValue replaces redacted fields in logs and String.
+const redactedValue = "[REDACTED]"
+
+var logRedactionOff atomic.Bool
+
+// SetLogRedaction sets whether Employee.LogValue and Employee.String
+// redact emails and cost centers, which they do by default so that
+// structured logs in consuming services do not leak them. Turn it off only
+// where such data may be logged, such as local debugging.
+func SetLogRedaction(redact bool) {
+	logRedactionOff.Store(!redact)
+}
+
+// LogValue implements slog.LogValuer, logging the employee as a group with
+// the email and cost center redacted unless SetLogRedaction(false) was
+// called. Empty fields are left out.
+func (e Employee) LogValue() slog.Value {
+	email, costCenter := e.Email, fmt.Sprint(e.CostCenter)
+	if !logRedactionOff.Load() {
+		email, costCenter = redactedValue, redactedValue
+	}
+	attrs := []slog.Attr{slog.String("uid", e.UID)}
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	add("full_name", e.FullName)
+	if e.Email != "" {
+		add("email", email)
+	}
+	add("job_title", e.JobTitle)
+	add("slack_uid", e.SlackUID)
+	add("github_id", e.GitHubID)
+	if e.CostCenter != 0 {
+		add("cost_center", costCenter)
+	}
+	add("manager_uid", e.ManagerUID)
+	return slog.GroupValue(attrs...)
+}
+
+// String formats the employee's fields as key=value pairs, redacted as
+// LogValue redacts them, so printing an employee with %v does not leak them
+// either.
+func (e Employee) String() string {
+	var b strings.Builder
+	b.WriteString("Employee{")
+	for i, attr := range e.LogValue().Group() {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%s", attr.Key, attr.Value)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -1,8 +1,12 @@
 package orgdatacore
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -323,3 +327,31 @@ func TestRedactingDataSourceWithService(t *testing.T) {
 		}
 	})
 }
+
+func TestEmployeeLogValue(t *testing.T) {
+	emp := Employee{UID: "jsmith", FullName: "John Smith", Email: "jsmith@example.com", CostCenter: 1234, SlackUID: "U123"}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("lookup", "employee", emp)
+	logged := buf.String()
+	for _, leak := range []string{"jsmith@example.com", "1234"} {
+		if strings.Contains(logged, leak) {
+			t.Errorf("log line leaks %q: %s", leak, logged)
+		}
+	}
+	if !strings.Contains(logged, "employee.uid=jsmith") || !strings.Contains(logged, "employee.email=[REDACTED]") {
+		t.Errorf("log line = %s, want the UID and a redacted email", logged)
+	}
+	if got := fmt.Sprint(&emp); strings.Contains(got, "jsmith@example.com") || !strings.Contains(got, "uid=jsmith") {
+		t.Errorf("String() = %s, want it redacted", got)
+	}
+	if got := (Employee{UID: "x"}).String(); got != "Employee{uid=x}" {
+		t.Errorf("String() of a bare employee = %s, want Employee{uid=x}", got)
+	}
+
+	SetLogRedaction(false)
+	defer SetLogRedaction(true)
+	if got := emp.String(); !strings.Contains(got, "email=jsmith@example.com") || !strings.Contains(got, "cost_center=1234") {
+		t.Errorf("String() with redaction off = %s, want the email and cost center", got)
+	}
+}
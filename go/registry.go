@@ -0,0 +1,196 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Registry holds one Service per tenant, each loading its own dataset from
+// its own DataSource, so a single process can serve several org datasets,
+// such as the dumps of separate business units, side by side:
+//
+//	registry := orgdatacore.NewRegistry(orgdatacore.WithTenantDefaults(orgdatacore.WithLogger(logger)))
+//	registry.Register("emea", emeaSource)
+//	registry.Register("apac", apacSource)
+//	if err := registry.Load(ctx); err != nil {
+//		...
+//	}
+//	if service, ok := registry.Route("emea"); ok {
+//		employee := service.GetEmployeeByUID(uid)
+//	}
+//
+// Tenants share nothing: each has its own data, indexes, watchers and
+// subscribers. A Registry is safe for concurrent use.
+type Registry struct {
+	defaults []ServiceOption
+	router   func(key string) string
+
+	mu      sync.RWMutex
+	tenants map[string]*tenant
+}
+
+type tenant struct {
+	service *Service
+	source  DataSource
+}
+
+// RegistryOption configures a Registry.
+type RegistryOption func(*Registry)
+
+// WithTenantDefaults sets options applied to the Service of every tenant,
+// before the options given to Register.
+func WithTenantDefaults(opts ...ServiceOption) RegistryOption {
+	return func(r *Registry) {
+		r.defaults = append(r.defaults, opts...)
+	}
+}
+
+// WithRouter sets how Route maps a key, such as a request host, an email
+// domain or a business unit code, to a tenant name. By default the key is
+// the tenant name.
+func WithRouter(router func(key string) string) RegistryOption {
+	return func(r *Registry) {
+		if router != nil {
+			r.router = router
+		}
+	}
+}
+
+// NewRegistry returns a Registry with no tenants.
+func NewRegistry(opts ...RegistryOption) *Registry {
+	r := &Registry{
+		router:  func(key string) string { return key },
+		tenants: make(map[string]*tenant),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Register adds a tenant whose data comes from source, and returns its
+// Service, which has no data until Load or Watch is called. It returns
+// ErrTenantExists if the name is taken, and a ConfigError if name is empty
+// or source is nil.
+func (r *Registry) Register(name string, source DataSource, opts ...ServiceOption) (*Service, error) {
+	if name == "" {
+		return nil, NewConfigError("tenant", "name is required")
+	}
+	if source == nil {
+		return nil, NewConfigError("tenant", fmt.Sprintf("%q has no data source", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tenants[name]; ok {
+		return nil, fmt.Errorf("%w: %q", ErrTenantExists, name)
+	}
+	service := NewService(append(slices.Clone(r.defaults), opts...)...)
+	r.tenants[name] = &tenant{service: service, source: source}
+	return service, nil
+}
+
+// Remove stops the watchers of a tenant and removes it. Its data source is
+// not closed. It reports whether the tenant existed.
+func (r *Registry) Remove(name string) bool {
+	r.mu.Lock()
+	t, ok := r.tenants[name]
+	delete(r.tenants, name)
+	r.mu.Unlock()
+
+	if ok {
+		t.service.StopWatcher()
+	}
+	return ok
+}
+
+// Get returns the Service of a tenant.
+func (r *Registry) Get(name string) (*Service, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.tenants[name]
+	if !ok {
+		return nil, false
+	}
+	return t.service, true
+}
+
+// Route returns the Service of the tenant the router maps key to.
+func (r *Registry) Route(key string) (*Service, bool) {
+	return r.Get(r.router(key))
+}
+
+// Tenants returns the sorted names of the tenants.
+func (r *Registry) Tenants() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(r.tenants))
+}
+
+// Load loads every tenant from its data source, concurrently. A tenant that
+// fails keeps the data it had; the error joins the failures, each naming its
+// tenant.
+func (r *Registry) Load(ctx context.Context) error {
+	return r.each(func(t *tenant) error {
+		return t.service.LoadFromDataSource(ctx, t.source)
+	})
+}
+
+// Watch starts the watcher of every tenant, as StartDataSourceWatcher does,
+// and returns when all of them have returned: at once for polling sources,
+// or when ctx is cancelled for sources whose Watch blocks. Tenants registered
+// afterwards are not watched until Watch is called again, which returns
+// ErrWatcherAlreadyRunning for the tenants already watched. The error joins
+// the failures, each naming its tenant.
+func (r *Registry) Watch(ctx context.Context) error {
+	return r.each(func(t *tenant) error {
+		return t.service.StartDataSourceWatcher(ctx, t.source)
+	})
+}
+
+// StopWatchers stops the watchers of every tenant.
+func (r *Registry) StopWatchers() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tenants {
+		t.service.StopWatcher()
+	}
+}
+
+// each calls fn for every tenant concurrently and joins the errors.
+func (r *Registry) each(fn func(*tenant) error) error {
+	r.mu.RLock()
+	tenants := maps.Clone(r.tenants)
+	r.mu.RUnlock()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, name := range slices.Sorted(maps.Keys(tenants)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(tenants[name]); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("tenant %q: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	slices.SortFunc(errs, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+	return errors.Join(errs...)
+}
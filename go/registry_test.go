@@ -0,0 +1,103 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	orgData, err := os.ReadFile("../testdata/test_org_data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := NewRegistry(WithRouter(func(key string) string {
+		// Route by email domain.
+		_, domain, _ := strings.Cut(key, "@")
+		return strings.TrimSuffix(domain, ".example.com")
+	}))
+
+	if _, err := registry.Register("emea", NewFakeDataSource(string(orgData))); err != nil {
+		t.Fatalf("Register(emea): %v", err)
+	}
+	apac, err := registry.Register("apac", NewFakeDataSource(CreateTestDataJSON()))
+	if err != nil {
+		t.Fatalf("Register(apac): %v", err)
+	}
+	if _, err := registry.Register("apac", NewFakeDataSource("")); !errors.Is(err, ErrTenantExists) {
+		t.Errorf("Register of a taken name: err = %v, want ErrTenantExists", err)
+	}
+	if _, err := registry.Register("", NewFakeDataSource("")); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Register without a name: err = %v, want ErrInvalidConfig", err)
+	}
+	if got := registry.Tenants(); !slices.Equal(got, []string{"apac", "emea"}) {
+		t.Errorf("Tenants() = %v", got)
+	}
+
+	if err := registry.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// Tenants hold separate datasets.
+	emea, ok := registry.Get("emea")
+	if !ok || emea.GetEmployeeByUID("jsmith") == nil || emea.GetEmployeeByUID("testuser1") != nil {
+		t.Error("emea should hold the test org data only")
+	}
+	if apac.GetEmployeeByUID("testuser1") == nil || apac.GetEmployeeByUID("jsmith") != nil {
+		t.Error("apac should hold the generated test data only")
+	}
+
+	if service, ok := registry.Route("someone@apac.example.com"); !ok || service != apac {
+		t.Errorf("Route(apac address) = %v, %v, want the apac service", service, ok)
+	}
+	if _, ok := registry.Route("someone@latam.example.com"); ok {
+		t.Error("Route of an unknown tenant should fail")
+	}
+
+	if !registry.Remove("apac") || registry.Remove("apac") {
+		t.Error("Remove should report whether the tenant existed")
+	}
+	if _, ok := registry.Get("apac"); ok {
+		t.Error("removed tenant is still registered")
+	}
+}
+
+func TestRegistryLoadErrors(t *testing.T) {
+	registry := NewRegistry()
+	failing := NewFakeDataSource("")
+	failing.LoadError = errors.New("bucket unavailable")
+	registry.Register("good", NewFakeDataSource(CreateTestDataJSON()))
+	registry.Register("bad", failing)
+
+	err := registry.Load(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `tenant "bad"`) || strings.Contains(err.Error(), `tenant "good"`) {
+		t.Fatalf("Load error = %v, want a failure for tenant bad only", err)
+	}
+	if good, _ := registry.Get("good"); good.GetEmployeeByUID("testuser1") == nil {
+		t.Error("a failing tenant should not keep the others from loading")
+	}
+}
+
+func TestRegistryWatch(t *testing.T) {
+	registry := NewRegistry()
+	source := NewFakeDataSource(CreateTestDataJSON())
+	service, _ := registry.Register("default", source)
+
+	if err := registry.Watch(context.Background()); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if !source.WatchCalled || service.GetEmployeeByUID("testuser1") == nil {
+		t.Error("Watch should load and watch every tenant")
+	}
+	if got := service.Watchers(); !slices.Equal(got, []string{DefaultWatcherName}) {
+		t.Errorf("Watchers() = %v", got)
+	}
+
+	registry.StopWatchers()
+	if got := service.Watchers(); len(got) != 0 {
+		t.Errorf("Watchers() after StopWatchers = %v, want none", got)
+	}
+}
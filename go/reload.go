@@ -0,0 +1,127 @@
+package orgdatacore
+
+import (
+	"fmt"
+	"sync"
+)
+
+// reloadEvent describes one swap of the loaded dataset.
+type reloadEvent struct {
+	oldVersion, newVersion DataVersion
+	oldData, newData       *Data
+	generation             uint64 // Service.generation after the swap
+}
+
+type reloadSubscriber struct {
+	id        uint64
+	fn        func(old, new DataVersion)
+	changesFn func(old, new DataVersion, changes *ChangeSet)
+	eventsFn  func(old, new DataVersion, events []MembershipEvent)
+}
+
+// reloadSubscribers is the set of OnReload callbacks. It has its own lock so
+// callbacks can be registered and run without holding the service lock.
+type reloadSubscribers struct {
+	mu     sync.Mutex
+	nextID uint64
+	subs   []reloadSubscriber
+}
+
+// OnReload registers fn to be called every time new data is swapped in, whether
+// by LoadFromDataSource, the watcher, or ApplyChangeSet. old is the zero
+// DataVersion on the first load. The returned function unregisters fn.
+//
+// Callbacks run synchronously, in registration order, on the goroutine that
+// loaded the data and after the service lock has been released, so they may
+// query the service. A slow callback delays the watcher's next reload.
+// A panicking callback is logged and does not affect the others.
+func (s *Service) OnReload(fn func(old, new DataVersion)) (unsubscribe func()) {
+	if fn == nil {
+		return func() {}
+	}
+	return s.subscribeReload(reloadSubscriber{fn: fn})
+}
+
+// OnReloadChanges is like OnReload but also passes the structured diff
+// between the previous and the new data, as computed by DiffSnapshots.
+// changes is nil on the first load. The diff is computed once per reload,
+// and only when at least one OnReloadChanges callback is registered.
+// Callbacks must not modify changes, which is shared between them.
+func (s *Service) OnReloadChanges(fn func(old, new DataVersion, changes *ChangeSet)) (unsubscribe func()) {
+	if fn == nil {
+		return func() {}
+	}
+	return s.subscribeReload(reloadSubscriber{changesFn: fn})
+}
+
+func (s *Service) subscribeReload(sub reloadSubscriber) (unsubscribe func()) {
+	s.reloadSubs.mu.Lock()
+	s.reloadSubs.nextID++
+	id := s.reloadSubs.nextID
+	sub.id = id
+	s.reloadSubs.subs = append(s.reloadSubs.subs, sub)
+	s.reloadSubs.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.reloadSubs.mu.Lock()
+			defer s.reloadSubs.mu.Unlock()
+			for i, sub := range s.reloadSubs.subs {
+				if sub.id == id {
+					s.reloadSubs.subs = append(s.reloadSubs.subs[:i:i], s.reloadSubs.subs[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// publishReload notifies OnReload subscribers of ev.
+// Must be called without s.mu held.
+func (s *Service) publishReload(ev reloadEvent) {
+	s.reloadSubs.mu.Lock()
+	subs := s.reloadSubs.subs
+	s.reloadSubs.mu.Unlock()
+
+	var changes *ChangeSet
+	var events []MembershipEvent
+	if ev.oldData != nil {
+		for _, sub := range subs {
+			if sub.changesFn != nil || sub.eventsFn != nil {
+				changes = DiffSnapshots(ev.oldData, ev.newData)
+				break
+			}
+		}
+		for _, sub := range subs {
+			if sub.eventsFn != nil {
+				events = membershipEvents(ev.oldData, ev.newData, changes)
+				break
+			}
+		}
+	}
+
+	for _, sub := range subs {
+		if sub.eventsFn != nil && len(events) == 0 {
+			continue
+		}
+		s.runReloadCallback(sub, ev, changes, events)
+	}
+}
+
+func (s *Service) runReloadCallback(sub reloadSubscriber, ev reloadEvent, changes *ChangeSet, events []MembershipEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.loggerFor(LogLoader).Error("reload callback panicked", "error", fmt.Sprint(r))
+		}
+	}()
+	if sub.changesFn != nil {
+		sub.changesFn(ev.oldVersion, ev.newVersion, changes)
+		return
+	}
+	if sub.eventsFn != nil {
+		sub.eventsFn(ev.oldVersion, ev.newVersion, events)
+		return
+	}
+	sub.fn(ev.oldVersion, ev.newVersion)
+}
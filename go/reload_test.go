@@ -0,0 +1,123 @@
+package orgdatacore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestOnReload(t *testing.T) {
+	service := NewService()
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	source := testingsupport.NewFileDataSource(testDataPath)
+
+	type call struct{ old, new DataVersion }
+	var calls []call
+	var order []string
+	unsubscribe := service.OnReload(func(old, new DataVersion) {
+		calls = append(calls, call{old, new})
+		order = append(order, "first")
+		// Callbacks run after the lock is released, so querying must not deadlock.
+		if service.GetEmployeeByUID("adoe") == nil {
+			t.Error("new data should be visible from the callback")
+		}
+	})
+	service.OnReload(func(old, new DataVersion) { panic("boom") })
+	service.OnReload(func(old, new DataVersion) { order = append(order, "third") })
+
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 callback after first load, got %d", len(calls))
+	}
+	if !calls[0].old.LoadTime.IsZero() {
+		t.Error("old version should be zero on first load")
+	}
+	if calls[0].new.EmployeeCount != 3 {
+		t.Errorf("new EmployeeCount = %d, want 3", calls[0].new.EmployeeCount)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "third" {
+		t.Errorf("callbacks should run in registration order despite a panic, got %v", order)
+	}
+
+	if err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"jsmith"}}); err != nil {
+		t.Fatalf("ApplyChangeSet failed: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("expected a callback after ApplyChangeSet, got %d calls", len(calls))
+	}
+	if !calls[1].old.LoadTime.Equal(calls[0].new.LoadTime) || calls[1].new.EmployeeCount != 2 {
+		t.Errorf("second callback old=%+v new=%+v", calls[1].old, calls[1].new)
+	}
+
+	unsubscribe()
+	unsubscribe() // safe to call twice
+	if err := service.LoadFromDataSource(context.Background(), source); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Errorf("unsubscribed callback should not run, got %d calls", len(calls))
+	}
+	if len(order) != 5 || order[4] != "third" {
+		t.Errorf("remaining callbacks should still run, got %v", order)
+	}
+}
+
+func TestOnReloadNotCalledOnFailedLoad(t *testing.T) {
+	service := NewService()
+	called := false
+	service.OnReload(func(old, new DataVersion) { called = true })
+
+	if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(`{"metadata": {}}`)); err == nil {
+		t.Fatal("expected invalid data to fail")
+	}
+	if called {
+		t.Error("OnReload should not fire when a load fails")
+	}
+	if unsubscribe := service.OnReload(nil); unsubscribe == nil {
+		t.Error("OnReload(nil) should return a no-op unsubscribe")
+	}
+}
+
+func TestOnReloadChanges(t *testing.T) {
+	service := setupTestService(t)
+
+	var got *ChangeSet
+	calls := 0
+	service.OnReloadChanges(func(old, new DataVersion, changes *ChangeSet) {
+		calls++
+		got = changes
+	})
+
+	err := service.ApplyChangeSet(ChangeSet{
+		EmployeesUpserted: map[string]Employee{
+			"jsmith": {UID: "jsmith", FullName: "John Smith", Email: "jsmith@example.com", SlackUID: "U12345678", GitHubID: "jsmith-dev"},
+		},
+		EmployeesRemoved: []string{"bwilson"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyChangeSet failed: %v", err)
+	}
+	if calls != 1 || got == nil {
+		t.Fatalf("expected one callback with changes, got %d calls, changes=%v", calls, got)
+	}
+	if len(got.EmployeesRemoved) != 1 || got.EmployeesRemoved[0] != "bwilson" {
+		t.Errorf("EmployeesRemoved = %v, want [bwilson]", got.EmployeesRemoved)
+	}
+	if len(got.ManagerChanges) != 1 || got.ManagerChanges[0].OldManagerUID != "adoe" {
+		t.Errorf("ManagerChanges = %+v, want jsmith leaving adoe", got.ManagerChanges)
+	}
+
+	fresh := NewService()
+	firstLoadChanges := &ChangeSet{}
+	fresh.OnReloadChanges(func(old, new DataVersion, changes *ChangeSet) { firstLoadChanges = changes })
+	if err := fresh.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+	if firstLoadChanges != nil {
+		t.Error("changes should be nil on the first load")
+	}
+}
@@ -0,0 +1,116 @@
+// Package render encodes orgdatacore query results as JSON in a stable
+// form, so the same data always encodes to the same bytes. The HTTP server
+// writes its responses with it, and golden tests can compare against it
+// without sorting each result themselves:
+//
+//	body, err := render.Marshal(svc.GetTeamMembers("platform-team"))
+//
+// Lists are sorted the way the parity check between the Go and Python
+// implementations sorts them: employees by UID, entities by name, and
+// ownership and membership records by name and then type. Escalation
+// contacts, hierarchy paths and search results keep their order, which is
+// meaningful, and the children of a hierarchy tree are sorted at every
+// level. Lists are recognized by type alone, so encode an ordered list that
+// is not one of those, such as a management chain of employees, with
+// encoding/json instead. Nil lists encode as [] rather than null. Struct fields encode in
+// declaration order and map keys sorted, as encoding/json always does.
+package render
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// Marshal returns the JSON encoding of Sorted(v).
+func Marshal(v any) ([]byte, error) {
+	return json.Marshal(Sorted(v))
+}
+
+// MarshalIndent is Marshal with indentation, as json.MarshalIndent.
+func MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(Sorted(v), prefix, indent)
+}
+
+// Sorted returns a copy of v, the result of a query, with its lists in the
+// stable order described in the package documentation. v is not modified.
+// Values of types that are not query results are returned as they are.
+func Sorted(v any) any {
+	switch val := v.(type) {
+	case []string:
+		return sortBy(val, func(s string) string { return s })
+	case []orgdatacore.Employee:
+		return sortBy(val, func(e orgdatacore.Employee) string { return e.UID })
+	case []orgdatacore.Team:
+		return sortBy(val, func(t orgdatacore.Team) string { return t.Name })
+	case []orgdatacore.Org:
+		return sortBy(val, func(o orgdatacore.Org) string { return o.Name })
+	case []orgdatacore.Pillar:
+		return sortBy(val, func(p orgdatacore.Pillar) string { return p.Name })
+	case []orgdatacore.TeamGroup:
+		return sortBy(val, func(tg orgdatacore.TeamGroup) string { return tg.Name })
+	case []orgdatacore.Component:
+		return sortBy(val, func(c orgdatacore.Component) string { return c.Name })
+	case []orgdatacore.OrgInfo:
+		return sortBy(val, func(o orgdatacore.OrgInfo) string { return o.Name + "\x00" + string(o.Type) })
+	case []orgdatacore.MembershipInfo:
+		return sortBy(val, func(m orgdatacore.MembershipInfo) string { return m.Name + "\x00" + m.Type })
+	case []orgdatacore.JiraOwnerInfo:
+		return sortBy(val, func(o orgdatacore.JiraOwnerInfo) string { return o.Name + "\x00" + o.Type })
+	case []orgdatacore.JiraOwnership:
+		return sortBy(val, func(j orgdatacore.JiraOwnership) string { return j.Project + "\x00" + j.Component })
+	case []orgdatacore.ComponentOwnerInfo:
+		return sortBy(val, func(o orgdatacore.ComponentOwnerInfo) string { return o.Name + "\x00" + o.Type })
+	case []orgdatacore.ComponentOwnership:
+		return sortBy(val, func(c orgdatacore.ComponentOwnership) string { return c.Component })
+	case []orgdatacore.ContextItemInfo:
+		items := sortBy(val, func(c orgdatacore.ContextItemInfo) string { return c.Name + "\x00" + c.SourceEntity })
+		for i := range items {
+			if items[i].Types != nil {
+				items[i].Types = sortBy(items[i].Types, func(s string) string { return s })
+			}
+		}
+		return items
+	case []orgdatacore.EscalationContactInfo:
+		return nonNil(val)
+	case []orgdatacore.HierarchyPathEntry:
+		return nonNil(val)
+	case []orgdatacore.SearchResult:
+		return nonNil(val)
+	case *orgdatacore.HierarchyNode:
+		return sortTree(val)
+	default:
+		return v
+	}
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// sortBy returns a sorted copy of items, ordered by key.
+func sortBy[T any](items []T, key func(T) string) []T {
+	sorted := slices.Clone(nonNil(items))
+	slices.SortStableFunc(sorted, func(a, b T) int { return cmp.Compare(key(a), key(b)) })
+	return sorted
+}
+
+// sortTree returns a copy of node with the children at every level sorted
+// by name.
+func sortTree(node *orgdatacore.HierarchyNode) *orgdatacore.HierarchyNode {
+	if node == nil {
+		return nil
+	}
+	sorted := *node
+	sorted.Children = make([]orgdatacore.HierarchyNode, len(node.Children))
+	for i := range node.Children {
+		sorted.Children[i] = *sortTree(&node.Children[i])
+	}
+	slices.SortStableFunc(sorted.Children, func(a, b orgdatacore.HierarchyNode) int { return cmp.Compare(a.Name, b.Name) })
+	return &sorted
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestSorted(t *testing.T) {
+	emps := []orgdatacore.Employee{{UID: "c"}, {UID: "a"}, {UID: "b"}}
+	got := Sorted(emps).([]orgdatacore.Employee)
+	if uids := []string{got[0].UID, got[1].UID, got[2].UID}; !reflect.DeepEqual(uids, []string{"a", "b", "c"}) {
+		t.Errorf("Sorted employees = %v, want a b c", uids)
+	}
+	if emps[0].UID != "c" {
+		t.Error("Sorted modified its argument")
+	}
+
+	escalation := []orgdatacore.EscalationContactInfo{{Name: "second"}, {Name: "first"}}
+	if got := Sorted(escalation); !reflect.DeepEqual(got, escalation) {
+		t.Errorf("Sorted reordered escalation contacts: %v", got)
+	}
+
+	tree := &orgdatacore.HierarchyNode{Name: "root", Children: []orgdatacore.HierarchyNode{
+		{Name: "z", Children: []orgdatacore.HierarchyNode{{Name: "y"}, {Name: "x"}}},
+		{Name: "a"},
+	}}
+	sorted := Sorted(tree).(*orgdatacore.HierarchyNode)
+	if sorted.Children[0].Name != "a" || sorted.Children[1].Children[0].Name != "x" {
+		t.Errorf("Sorted tree = %+v, want children sorted at every level", sorted)
+	}
+	if tree.Children[0].Name != "z" {
+		t.Error("Sorted modified the tree")
+	}
+
+	if got := Sorted([]string(nil)); got == nil || len(got.([]string)) != 0 {
+		t.Errorf("Sorted(nil list) = %#v, want an empty list", got)
+	}
+	if got := Sorted(42); got != 42 {
+		t.Errorf("Sorted(42) = %v", got)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	a := []orgdatacore.OrgInfo{{Name: "b", Type: orgdatacore.OrgTypeOrganization}, {Name: "a", Type: orgdatacore.OrgTypeTeam}}
+	b := []orgdatacore.OrgInfo{a[1], a[0]}
+	ja, err := Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jb, err := Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(ja) != string(jb) {
+		t.Errorf("Marshal depends on input order:\n%s\n%s", ja, jb)
+	}
+	if got, err := Marshal([]orgdatacore.Team(nil)); err != nil || string(got) != "[]" {
+		t.Errorf("Marshal(nil teams) = %s, %v, want []", got, err)
+	}
+}
@@ -0,0 +1,168 @@
+package orgdatacore
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Repair is one fix applied to a dataset by RepairData.
+type Repair struct {
+	Kind RepairKind `json:"kind"`
+	// Entity names what was fixed: an employee UID or a group name.
+	Entity  string `json:"entity"`
+	Message string `json:"message"`
+}
+
+// RepairData fixes common data issues in place: it trims whitespace around
+// employee fields and member UIDs, lowercases emails, removes managers that
+// are the employee themselves, and drops repeated membership entries. It
+// returns every repair made, grouped by kind and sorted by entity, so the
+// pipeline producing the data can be fixed.
+func RepairData(data *Data) []Repair {
+	r := &repairs{list: []Repair{}}
+	repairWhitespace(r, data)
+	repairEmails(r, data)
+	repairSelfManagers(r, data)
+	repairDuplicateMemberships(r, data)
+	return r.list
+}
+
+type repairs struct {
+	list []Repair
+}
+
+func (r *repairs) add(kind RepairKind, entity, format string, args ...any) {
+	r.list = append(r.list, Repair{Kind: kind, Entity: entity, Message: fmt.Sprintf(format, args...)})
+}
+
+// groupLists calls fn with the member list of every team, org, pillar and
+// team group, in that order and sorted by name, and stores the list fn
+// returns.
+func groupLists(data *Data, fn func(typ, name string, uids []string) []string) {
+	lookups := &data.Lookups
+	for _, name := range slices.Sorted(maps.Keys(lookups.Teams)) {
+		team := lookups.Teams[name]
+		team.Group.ResolvedPeopleUIDList = fn("team", name, team.Group.ResolvedPeopleUIDList)
+		lookups.Teams[name] = team
+	}
+	for _, name := range slices.Sorted(maps.Keys(lookups.Orgs)) {
+		org := lookups.Orgs[name]
+		org.Group.ResolvedPeopleUIDList = fn("org", name, org.Group.ResolvedPeopleUIDList)
+		lookups.Orgs[name] = org
+	}
+	for _, name := range slices.Sorted(maps.Keys(lookups.Pillars)) {
+		pillar := lookups.Pillars[name]
+		pillar.Group.ResolvedPeopleUIDList = fn("pillar", name, pillar.Group.ResolvedPeopleUIDList)
+		lookups.Pillars[name] = pillar
+	}
+	for _, name := range slices.Sorted(maps.Keys(lookups.TeamGroups)) {
+		tg := lookups.TeamGroups[name]
+		tg.Group.ResolvedPeopleUIDList = fn("team_group", name, tg.Group.ResolvedPeopleUIDList)
+		lookups.TeamGroups[name] = tg
+	}
+}
+
+func repairWhitespace(r *repairs, data *Data) {
+	employees := data.Lookups.Employees
+	for _, uid := range slices.Sorted(maps.Keys(employees)) {
+		emp := employees[uid]
+		fields := []struct {
+			name  string
+			value *string
+		}{
+			{"uid", &emp.UID},
+			{"full_name", &emp.FullName},
+			{"email", &emp.Email},
+			{"job_title", &emp.JobTitle},
+			{"slack_uid", &emp.SlackUID},
+			{"github_id", &emp.GitHubID},
+			{"rhat_geo", &emp.RhatGeo},
+			{"manager_uid", &emp.ManagerUID},
+			{"timezone", &emp.Timezone},
+		}
+		changed := false
+		for _, f := range fields {
+			if trimmed := strings.TrimSpace(*f.value); trimmed != *f.value {
+				*f.value = trimmed
+				changed = true
+				r.add(RepairTrimWhitespace, uid, "employee %q: trimmed whitespace around %s", uid, f.name)
+			}
+		}
+		if changed {
+			employees[uid] = emp
+		}
+	}
+
+	groupLists(data, func(typ, name string, uids []string) []string {
+		for i, uid := range uids {
+			if trimmed := strings.TrimSpace(uid); trimmed != uid {
+				uids[i] = trimmed
+				r.add(RepairTrimWhitespace, name, "%s %q: trimmed whitespace around member %q", typ, name, trimmed)
+			}
+		}
+		return uids
+	})
+}
+
+func repairEmails(r *repairs, data *Data) {
+	employees := data.Lookups.Employees
+	for _, uid := range slices.Sorted(maps.Keys(employees)) {
+		emp := employees[uid]
+		if lower := strings.ToLower(emp.Email); lower != emp.Email {
+			r.add(RepairLowercaseEmail, uid, "employee %q: lowercased email %q", uid, emp.Email)
+			emp.Email = lower
+			employees[uid] = emp
+		}
+	}
+}
+
+func repairSelfManagers(r *repairs, data *Data) {
+	employees := data.Lookups.Employees
+	for _, uid := range slices.Sorted(maps.Keys(employees)) {
+		emp := employees[uid]
+		if emp.ManagerUID != "" && emp.ManagerUID == uid {
+			r.add(RepairSelfManager, uid, "employee %q: removed manager link to themselves", uid)
+			emp.ManagerUID = ""
+			employees[uid] = emp
+		}
+	}
+}
+
+func repairDuplicateMemberships(r *repairs, data *Data) {
+	index := data.Indexes.Membership.MembershipIndex
+	for _, uid := range slices.Sorted(maps.Keys(index)) {
+		memberships := index[uid]
+		seen := make(map[MembershipInfo]bool, len(memberships))
+		kept := make([]MembershipInfo, 0, len(memberships))
+		for _, m := range memberships {
+			if seen[m] {
+				r.add(RepairDuplicateMembership, uid, "membership index: removed repeated %s %q for %q", m.Type, m.Name, uid)
+				continue
+			}
+			seen[m] = true
+			kept = append(kept, m)
+		}
+		if len(kept) != len(memberships) {
+			index[uid] = kept
+		}
+	}
+
+	groupLists(data, func(typ, name string, uids []string) []string {
+		seen := make(map[string]bool, len(uids))
+		kept := make([]string, 0, len(uids))
+		for _, uid := range uids {
+			if seen[uid] {
+				r.add(RepairDuplicateMembership, name, "%s %q: removed repeated member %q", typ, name, uid)
+				continue
+			}
+			seen[uid] = true
+			kept = append(kept, uid)
+		}
+		if len(kept) == len(uids) {
+			return uids
+		}
+		return kept
+	})
+}
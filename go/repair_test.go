@@ -0,0 +1,126 @@
+package orgdatacore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRepairData(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Data)
+		want   []Repair
+		check  func(*testing.T, *Data)
+	}{
+		{
+			name:   "clean",
+			mutate: func(*Data) {},
+			want:   []Repair{},
+		},
+		{
+			name: "whitespace",
+			mutate: func(d *Data) {
+				emp := d.Lookups.Employees["testuser1"]
+				emp.FullName = " Test User 1\n"
+				emp.SlackUID = "U111111 "
+				d.Lookups.Employees["testuser1"] = emp
+				team := d.Lookups.Teams["test-squad"]
+				team.Group.ResolvedPeopleUIDList = []string{"testuser1", " testuser2"}
+				d.Lookups.Teams["test-squad"] = team
+			},
+			want: []Repair{
+				{Kind: RepairTrimWhitespace, Entity: "testuser1", Message: `employee "testuser1": trimmed whitespace around full_name`},
+				{Kind: RepairTrimWhitespace, Entity: "testuser1", Message: `employee "testuser1": trimmed whitespace around slack_uid`},
+				{Kind: RepairTrimWhitespace, Entity: "test-squad", Message: `team "test-squad": trimmed whitespace around member "testuser2"`},
+			},
+			check: func(t *testing.T, d *Data) {
+				if emp := d.Lookups.Employees["testuser1"]; emp.FullName != "Test User 1" || emp.SlackUID != "U111111" {
+					t.Errorf("employee = %+v, want trimmed fields", emp)
+				}
+				if uids := d.Lookups.Teams["test-squad"].Group.ResolvedPeopleUIDList; !reflect.DeepEqual(uids, []string{"testuser1", "testuser2"}) {
+					t.Errorf("members = %q, want trimmed UIDs", uids)
+				}
+			},
+		},
+		{
+			name: "email and self manager",
+			mutate: func(d *Data) {
+				emp := d.Lookups.Employees["testuser2"]
+				emp.Email = "TestUser2@Example.com"
+				emp.ManagerUID = "testuser2"
+				d.Lookups.Employees["testuser2"] = emp
+			},
+			want: []Repair{
+				{Kind: RepairLowercaseEmail, Entity: "testuser2", Message: `employee "testuser2": lowercased email "TestUser2@Example.com"`},
+				{Kind: RepairSelfManager, Entity: "testuser2", Message: `employee "testuser2": removed manager link to themselves`},
+			},
+			check: func(t *testing.T, d *Data) {
+				if emp := d.Lookups.Employees["testuser2"]; emp.Email != "testuser2@example.com" || emp.ManagerUID != "" {
+					t.Errorf("employee = %+v, want lowercased email and no manager", emp)
+				}
+			},
+		},
+		{
+			name: "duplicate memberships",
+			mutate: func(d *Data) {
+				index := d.Indexes.Membership.MembershipIndex
+				index["testuser1"] = append(index["testuser1"], MembershipInfo{Name: "test-squad", Type: "team"})
+				org := d.Lookups.Orgs["test-division"]
+				org.Group.ResolvedPeopleUIDList = []string{"testuser1", "testuser2", "testuser1"}
+				d.Lookups.Orgs["test-division"] = org
+			},
+			want: []Repair{
+				{Kind: RepairDuplicateMembership, Entity: "testuser1", Message: `membership index: removed repeated team "test-squad" for "testuser1"`},
+				{Kind: RepairDuplicateMembership, Entity: "test-division", Message: `org "test-division": removed repeated member "testuser1"`},
+			},
+			check: func(t *testing.T, d *Data) {
+				if n := len(d.Indexes.Membership.MembershipIndex["testuser1"]); n != 2 {
+					t.Errorf("testuser1 has %d memberships, want 2", n)
+				}
+				if uids := d.Lookups.Orgs["test-division"].Group.ResolvedPeopleUIDList; !reflect.DeepEqual(uids, []string{"testuser1", "testuser2"}) {
+					t.Errorf("members = %q, want duplicates removed", uids)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := CreateTestData()
+			tt.mutate(data)
+			got := RepairData(data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RepairData() = %+v\nwant %+v", got, tt.want)
+			}
+			if tt.check != nil {
+				tt.check(t, data)
+			}
+		})
+	}
+}
+
+func TestWithRepair(t *testing.T) {
+	data := CreateTestData()
+	emp := data.Lookups.Employees["testuser1"]
+	emp.Email = "TestUser1@Example.com"
+	data.Lookups.Employees["testuser1"] = emp
+
+	plain := NewService()
+	report, err := plain.ValidateDataSource(context.Background(), jsonSource(t, "data", data))
+	if err != nil || len(report.Repairs) != 0 {
+		t.Fatalf("ValidateDataSource without repair = %+v, %v, want no repairs", report, err)
+	}
+
+	service := NewService(WithRepair())
+	report, err = service.ValidateDataSource(context.Background(), jsonSource(t, "data", data))
+	if err != nil || len(report.Repairs) != 1 || report.Repairs[0].Kind != RepairLowercaseEmail {
+		t.Fatalf("ValidateDataSource = %+v, %v, want the email repair", report, err)
+	}
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "data", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if got := service.GetEmployeeByUID("testuser1").Email; got != "testuser1@example.com" {
+		t.Errorf("Email = %q, want it lowercased", got)
+	}
+}
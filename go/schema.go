@@ -0,0 +1,210 @@
+package orgdatacore
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed schema/comprehensive_index_dump.schema.json
+var dumpSchemaJSON []byte
+
+// DumpSchema returns the JSON Schema (draft 2020-12) describing the
+// comprehensive index dump, for producers that want to check their output.
+func DumpSchema() []byte {
+	return bytes.Clone(dumpSchemaJSON)
+}
+
+// SchemaError reports a value that does not match the dump schema.
+type SchemaError struct {
+	// Path is a JSON Pointer to the value, such as
+	// "/lookups/employees/jsmith/cost_center".
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%v: %s: %s", ErrSchemaMismatch, path, e.Message)
+}
+
+func (e *SchemaError) Is(target error) bool {
+	return target == ErrSchemaMismatch || target == ErrInvalidData
+}
+
+// ValidateAgainstSchema reads a JSON document from r and checks it against
+// DumpSchema. It returns a *SchemaError locating the first mismatch, so format
+// drift in the producer is caught precisely instead of decoding to zero
+// values. Object members are checked in sorted order, so the same document
+// always reports the same mismatch.
+func ValidateAgainstSchema(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaMismatch, err)
+	}
+	root, err := dumpSchema()
+	if err != nil {
+		return err
+	}
+	return root.check(doc, "", root)
+}
+
+// schemaNode is the subset of JSON Schema used by the dump schema: type,
+// properties, required, additionalProperties, items, anyOf, and local $ref.
+type schemaNode struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 schemaTypes            `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *schemaNode            `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+	AnyOf                []*schemaNode          `json:"anyOf"`
+	Defs                 map[string]*schemaNode `json:"$defs"`
+
+	// never is set for the boolean schema false.
+	never bool
+}
+
+func (n *schemaNode) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "true":
+		return nil
+	case "false":
+		n.never = true
+		return nil
+	}
+	type plain schemaNode
+	return json.Unmarshal(b, (*plain)(n))
+}
+
+// schemaTypes is the "type" keyword, either one type name or a list of them.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+var dumpSchema = sync.OnceValues(func() (*schemaNode, error) {
+	var root schemaNode
+	if err := json.Unmarshal(dumpSchemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("orgdatacore: invalid embedded schema: %w", err)
+	}
+	return &root, nil
+})
+
+func (n *schemaNode) check(v any, path string, root *schemaNode) error {
+	if n.Ref != "" {
+		def, ok := root.Defs[strings.TrimPrefix(n.Ref, "#/$defs/")]
+		if !ok {
+			return fmt.Errorf("orgdatacore: invalid embedded schema: unknown $ref %q", n.Ref)
+		}
+		return def.check(v, path, root)
+	}
+	if n.never {
+		return &SchemaError{Path: path, Message: "unexpected value"}
+	}
+	if len(n.AnyOf) > 0 {
+		var first error
+		for _, alt := range n.AnyOf {
+			err := alt.check(v, path, root)
+			if err == nil {
+				return nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		return first
+	}
+
+	if got := jsonType(v); len(n.Type) > 0 && !n.allows(got) {
+		return &SchemaError{Path: path, Message: fmt.Sprintf("expected %s, got %s", strings.Join(n.Type, " or "), got)}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range n.Required {
+			if _, ok := v[name]; !ok {
+				return &SchemaError{Path: path, Message: fmt.Sprintf("missing required property %q", name)}
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			prop, ok := n.Properties[key]
+			if !ok {
+				prop = n.AdditionalProperties
+			}
+			if prop == nil {
+				continue
+			}
+			if err := prop.check(v[key], path+"/"+escapePointer(key), root); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if n.Items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := n.Items.check(item, fmt.Sprintf("%s/%d", path, i), root); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n *schemaNode) allows(got string) bool {
+	for _, t := range n.Type {
+		if t == got || t == "number" && got == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON Schema type name of a value decoded with
+// UseNumber.
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// escapePointer escapes a JSON Pointer reference token (RFC 6901).
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
@@ -0,0 +1,116 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateAgainstSchema(t *testing.T) {
+	for _, path := range []string{"../testdata/test_org_data.json", "../testdata/test_org_data_pii_free.json"} {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatalf("open %s: %v", path, err)
+		}
+		if err := ValidateAgainstSchema(f); err != nil {
+			t.Errorf("ValidateAgainstSchema(%s) = %v", path, err)
+		}
+		f.Close()
+	}
+
+	tests := []struct {
+		name     string
+		doc      string
+		wantPath string
+		wantMsg  string
+	}{
+		{
+			name:     "missing section",
+			doc:      `{"metadata": {}, "lookups": {}}`,
+			wantPath: "",
+			wantMsg:  `missing required property "indexes"`,
+		},
+		{
+			name:     "wrong scalar type",
+			doc:      `{"metadata": {}, "lookups": {"employees": {"jsmith": {"uid": "jsmith", "cost_center": "12"}}}, "indexes": {}}`,
+			wantPath: "/lookups/employees/jsmith/cost_center",
+			wantMsg:  "expected integer or null, got string",
+		},
+		{
+			name:     "member list is not an array",
+			doc:      `{"metadata": {}, "lookups": {"teams": {"a/b": {"name": "a/b", "group": {"resolved_people_uid_list": "jsmith"}}}}, "indexes": {}}`,
+			wantPath: "/lookups/teams/a~1b/group/resolved_people_uid_list",
+			wantMsg:  "expected array or null, got string",
+		},
+		{
+			name:     "array item",
+			doc:      `{"metadata": {}, "lookups": {}, "indexes": {"membership": {"membership_index": {"jsmith": [{"name": "t", "type": "team"}, {"name": "t"}]}}}}`,
+			wantPath: "/indexes/membership/membership_index/jsmith/1",
+			wantMsg:  `missing required property "type"`,
+		},
+		{
+			name:     "null parent allowed, bad parent rejected",
+			doc:      `{"metadata": {}, "lookups": {"orgs": {"a": {"name": "a", "parent": null}, "b": {"name": "b", "parent": "a"}}}, "indexes": {}}`,
+			wantPath: "/lookups/orgs/b/parent",
+			wantMsg:  "expected object, got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgainstSchema(strings.NewReader(tt.doc))
+			var serr *SchemaError
+			if !errors.As(err, &serr) {
+				t.Fatalf("ValidateAgainstSchema() = %v, want a *SchemaError", err)
+			}
+			if serr.Path != tt.wantPath || serr.Message != tt.wantMsg {
+				t.Errorf("SchemaError = %q at %q, want %q at %q", serr.Message, serr.Path, tt.wantMsg, tt.wantPath)
+			}
+			if !errors.Is(err, ErrSchemaMismatch) || !errors.Is(err, ErrInvalidData) {
+				t.Errorf("error %v does not wrap ErrSchemaMismatch and ErrInvalidData", err)
+			}
+		})
+	}
+
+	if err := ValidateAgainstSchema(strings.NewReader("{")); !errors.Is(err, ErrSchemaMismatch) {
+		t.Errorf("ValidateAgainstSchema(truncated) = %v, want ErrSchemaMismatch", err)
+	}
+}
+
+func TestDumpSchemaIsValidJSON(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(DumpSchema(), &schema); err != nil {
+		t.Fatalf("DumpSchema() is not JSON: %v", err)
+	}
+	if _, err := dumpSchema(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithStrictSchema(t *testing.T) {
+	ctx := context.Background()
+	raw, err := os.ReadFile("../testdata/test_org_data.json")
+	if err != nil {
+		t.Fatalf("read test data: %v", err)
+	}
+	drifted := strings.Replace(string(raw), `"total_employees": 3`, `"total_employees": "3"`, 1)
+	if drifted == string(raw) {
+		t.Fatal("test data changed; update the drift replacement")
+	}
+
+	strict := NewService(WithStrictSchema())
+	if err := strict.LoadFromDataSource(ctx, NewFakeDataSource(string(raw))); err != nil {
+		t.Fatalf("strict load of valid data: %v", err)
+	}
+	err = strict.LoadFromDataSource(ctx, NewFakeDataSource(drifted))
+	var serr *SchemaError
+	if !errors.As(err, &serr) || serr.Path != "/metadata/total_employees" {
+		t.Errorf("strict load of drifted data = %v, want a *SchemaError at /metadata/total_employees", err)
+	}
+	if strict.GetVersion().EmployeeCount != 3 {
+		t.Error("drifted data replaced the loaded data")
+	}
+}
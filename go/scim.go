@@ -0,0 +1,199 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// SCIM 2.0 schema URNs (RFC 7643, RFC 7644).
+const (
+	SCIMUserSchema           = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMEnterpriseUserSchema = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	SCIMGroupSchema          = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SCIMListResponseSchema   = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+)
+
+// SCIMUser is an employee rendered as a SCIM 2.0 User resource, with the
+// enterprise extension for cost center and manager.
+type SCIMUser struct {
+	Schemas     []string            `json:"schemas"`
+	ID          string              `json:"id"`
+	UserName    string              `json:"userName"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Name        *SCIMName           `json:"name,omitempty"`
+	Title       string              `json:"title,omitempty"`
+	Timezone    string              `json:"timezone,omitempty"`
+	Active      bool                `json:"active"`
+	Emails      []SCIMMultiValue    `json:"emails,omitempty"`
+	Groups      []SCIMMultiValue    `json:"groups,omitempty"`
+	Enterprise  *SCIMEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        SCIMMeta            `json:"meta"`
+}
+
+// SCIMName is the name of a SCIMUser.
+type SCIMName struct {
+	Formatted string `json:"formatted"`
+}
+
+// SCIMEnterpriseUser holds the enterprise extension attributes of a
+// SCIMUser.
+type SCIMEnterpriseUser struct {
+	CostCenter string          `json:"costCenter,omitempty"`
+	Manager    *SCIMMultiValue `json:"manager,omitempty"`
+}
+
+// SCIMMultiValue is a SCIM multi-valued attribute entry, or a reference to
+// another resource: Value is the email address or the referenced resource's
+// id.
+type SCIMMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMGroup is a team rendered as a SCIM 2.0 Group resource.
+type SCIMGroup struct {
+	Schemas     []string         `json:"schemas"`
+	ID          string           `json:"id"`
+	DisplayName string           `json:"displayName"`
+	Members     []SCIMMultiValue `json:"members"`
+	Meta        SCIMMeta         `json:"meta"`
+}
+
+// SCIMMeta is the meta attribute of a SCIM resource.
+type SCIMMeta struct {
+	ResourceType string `json:"resourceType"`
+}
+
+// SCIMListResponse wraps resources in a SCIM ListResponse message, as
+// returned by a SCIM query endpoint.
+type SCIMListResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+// NewSCIMListResponse returns a ListResponse holding all of resources.
+func NewSCIMListResponse[T any](resources []T) SCIMListResponse[T] {
+	if resources == nil {
+		resources = []T{}
+	}
+	return SCIMListResponse[T]{
+		Schemas:      []string{SCIMListResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// NewSCIMUser renders emp as a SCIM User. The id and userName are the UID,
+// and the manager, if any, is referenced by UID. Groups are not set, since
+// they need the membership index; SCIMUsers sets them.
+func NewSCIMUser(emp Employee) SCIMUser {
+	u := SCIMUser{
+		Schemas:     []string{SCIMUserSchema},
+		ID:          emp.UID,
+		UserName:    emp.UID,
+		DisplayName: emp.FullName,
+		Title:       emp.JobTitle,
+		Timezone:    emp.Timezone,
+		Active:      true,
+		Meta:        SCIMMeta{ResourceType: "User"},
+	}
+	if emp.FullName != "" {
+		u.Name = &SCIMName{Formatted: emp.FullName}
+	}
+	if emp.Email != "" {
+		u.Emails = []SCIMMultiValue{{Value: emp.Email, Type: "work", Primary: true}}
+	}
+	if emp.CostCenter != 0 || emp.ManagerUID != "" {
+		u.Schemas = append(u.Schemas, SCIMEnterpriseUserSchema)
+		u.Enterprise = &SCIMEnterpriseUser{}
+		if emp.CostCenter != 0 {
+			u.Enterprise.CostCenter = strconv.Itoa(emp.CostCenter)
+		}
+		if emp.ManagerUID != "" {
+			u.Enterprise.Manager = &SCIMMultiValue{Value: emp.ManagerUID}
+		}
+	}
+	return u
+}
+
+// SCIMUsers returns every loaded employee as a SCIM User, sorted by id, with
+// their teams as groups and their manager's display name.
+func (s *Service) SCIMUsers() []SCIMUser {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return []SCIMUser{}
+	}
+	employees := s.data.Lookups.Employees
+	users := make([]SCIMUser, 0, len(employees))
+	for _, emp := range employees {
+		u := NewSCIMUser(emp)
+		if u.Enterprise != nil && u.Enterprise.Manager != nil {
+			if manager, ok := employees[emp.ManagerUID]; ok {
+				u.Enterprise.Manager.Display = manager.FullName
+			}
+		}
+		for _, m := range s.data.Indexes.Membership.MembershipIndex[emp.UID] {
+			if m.Type != "team" {
+				continue
+			}
+			if team, ok := s.data.Lookups.Teams[m.Name]; ok {
+				u.Groups = append(u.Groups, SCIMMultiValue{Value: scimGroupID(team), Display: team.Name, Type: "direct"})
+			}
+		}
+		slices.SortFunc(u.Groups, func(a, b SCIMMultiValue) int { return cmp.Compare(a.Display, b.Display) })
+		users = append(users, u)
+	}
+	slices.SortFunc(users, func(a, b SCIMUser) int { return cmp.Compare(a.ID, b.ID) })
+	return users
+}
+
+// SCIMGroups returns every loaded team as a SCIM Group, sorted by display
+// name, with its members referenced by UID.
+func (s *Service) SCIMGroups() []SCIMGroup {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return []SCIMGroup{}
+	}
+	groups := make([]SCIMGroup, 0, len(s.data.Lookups.Teams))
+	for _, team := range s.data.Lookups.Teams {
+		g := SCIMGroup{
+			Schemas:     []string{SCIMGroupSchema},
+			ID:          scimGroupID(team),
+			DisplayName: team.Name,
+			Members:     []SCIMMultiValue{},
+			Meta:        SCIMMeta{ResourceType: "Group"},
+		}
+		for _, uid := range team.Group.ResolvedPeopleUIDList {
+			member := SCIMMultiValue{Value: uid, Type: "User"}
+			if emp, ok := s.data.Lookups.Employees[uid]; ok {
+				member.Display = emp.FullName
+			}
+			g.Members = append(g.Members, member)
+		}
+		slices.SortFunc(g.Members, func(a, b SCIMMultiValue) int { return cmp.Compare(a.Value, b.Value) })
+		g.Members = slices.CompactFunc(g.Members, func(a, b SCIMMultiValue) bool { return a.Value == b.Value })
+		groups = append(groups, g)
+	}
+	slices.SortFunc(groups, func(a, b SCIMGroup) int { return cmp.Compare(a.DisplayName, b.DisplayName) })
+	return groups
+}
+
+// scimGroupID is the team's UID, or its name if the data has no UID.
+func scimGroupID(team Team) string {
+	if team.UID != "" {
+		return team.UID
+	}
+	return team.Name
+}
@@ -0,0 +1,142 @@
+package orgdatacore
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewSCIMUser(t *testing.T) {
+	tests := []struct {
+		name string
+		emp  Employee
+		want SCIMUser
+	}{
+		{
+			name: "full employee",
+			emp: Employee{
+				UID: "jdoe", FullName: "Jane Doe", Email: "jdoe@example.com", JobTitle: "Engineer",
+				Timezone: "Europe/Prague", CostCenter: 1234, ManagerUID: "boss",
+			},
+			want: SCIMUser{
+				Schemas:     []string{SCIMUserSchema, SCIMEnterpriseUserSchema},
+				ID:          "jdoe",
+				UserName:    "jdoe",
+				DisplayName: "Jane Doe",
+				Name:        &SCIMName{Formatted: "Jane Doe"},
+				Title:       "Engineer",
+				Timezone:    "Europe/Prague",
+				Active:      true,
+				Emails:      []SCIMMultiValue{{Value: "jdoe@example.com", Type: "work", Primary: true}},
+				Enterprise:  &SCIMEnterpriseUser{CostCenter: "1234", Manager: &SCIMMultiValue{Value: "boss"}},
+				Meta:        SCIMMeta{ResourceType: "User"},
+			},
+		},
+		{
+			name: "uid only",
+			emp:  Employee{UID: "bare"},
+			want: SCIMUser{
+				Schemas:  []string{SCIMUserSchema},
+				ID:       "bare",
+				UserName: "bare",
+				Active:   true,
+				Meta:     SCIMMeta{ResourceType: "User"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSCIMUser(tt.emp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSCIMUser() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSCIMUsers(t *testing.T) {
+	service := setupTestService(t)
+
+	users := service.SCIMUsers()
+	var ids []string
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	if want := []string{"adoe", "bwilson", "jsmith"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("SCIMUsers ids = %v, want %v", ids, want)
+	}
+
+	jsmith := users[2]
+	if want := (&SCIMMultiValue{Value: "adoe", Display: "Alice Doe"}); !reflect.DeepEqual(jsmith.Enterprise.Manager, want) {
+		t.Errorf("jsmith manager = %+v, want %+v", jsmith.Enterprise.Manager, want)
+	}
+	if want := []SCIMMultiValue{{Value: "team-001", Display: "test-team", Type: "direct"}}; !reflect.DeepEqual(jsmith.Groups, want) {
+		t.Errorf("jsmith groups = %+v, want %+v", jsmith.Groups, want)
+	}
+	if users[0].Enterprise != nil {
+		t.Errorf("adoe has no manager or cost center, got enterprise extension %+v", users[0].Enterprise)
+	}
+
+	if got := NewService().SCIMUsers(); got == nil || len(got) != 0 {
+		t.Errorf("SCIMUsers without data = %v, want empty", got)
+	}
+}
+
+func TestSCIMGroups(t *testing.T) {
+	service := setupTestService(t)
+
+	groups := service.SCIMGroups()
+	if len(groups) != 2 {
+		t.Fatalf("SCIMGroups returned %d groups, want 2", len(groups))
+	}
+	want := SCIMGroup{
+		Schemas:     []string{SCIMGroupSchema},
+		ID:          "team-001",
+		DisplayName: "test-team",
+		Members: []SCIMMultiValue{
+			{Value: "adoe", Display: "Alice Doe", Type: "User"},
+			{Value: "jsmith", Display: "John Smith", Type: "User"},
+		},
+		Meta: SCIMMeta{ResourceType: "Group"},
+	}
+	if !reflect.DeepEqual(groups[1], want) {
+		t.Errorf("SCIMGroups()[1] = %+v, want %+v", groups[1], want)
+	}
+	if groups[0].DisplayName != "platform-team" {
+		t.Errorf("SCIMGroups()[0] = %q, want platform-team", groups[0].DisplayName)
+	}
+
+	if got := NewService().SCIMGroups(); got == nil || len(got) != 0 {
+		t.Errorf("SCIMGroups without data = %v, want empty", got)
+	}
+}
+
+func TestSCIMListResponseJSON(t *testing.T) {
+	service := setupTestService(t)
+
+	out, err := json.Marshal(NewSCIMListResponse(service.SCIMUsers()))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, want := range []string{
+		`"schemas":["urn:ietf:params:scim:api:messages:2.0:ListResponse"]`,
+		`"totalResults":3`,
+		`"startIndex":1`,
+		`"Resources":[`,
+		`"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"manager":{"value":"adoe","display":"Alice Doe"}}`,
+		`"emails":[{"value":"jsmith@example.com","type":"work","primary":true}]`,
+	} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("ListResponse JSON missing %s\n%s", want, out)
+		}
+	}
+
+	empty, err := json.Marshal(NewSCIMListResponse[SCIMGroup](nil))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(empty), `"totalResults":0`) || !strings.Contains(string(empty), `"Resources":[]`) {
+		t.Errorf("empty ListResponse = %s", empty)
+	}
+}
@@ -0,0 +1,296 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SearchResult is an employee or group matching a Search query.
+type SearchResult struct {
+	Type EntityType `json:"type"`
+	// Name is the UID of an employee, or the name of a group.
+	Name string `json:"name"`
+	// Label is what to show for the result: the full name of an employee,
+	// or the tab name of a group, falling back to its name.
+	Label string  `json:"label"`
+	Score float64 `json:"score"`
+}
+
+// SearchOption adjusts a Search query.
+type SearchOption func(*SearchOptions)
+
+// SearchOptions are the settings of a Search query, for implementations of
+// ServiceInterface that pass a search on, such as httpclient.Client.
+type SearchOptions struct {
+	// Types, if not empty, keeps results of these entity types.
+	Types []EntityType
+	// Limit is the maximum number of results; zero or less returns every
+	// match.
+	Limit int
+	// Exact requires every word of the query to match a whole word.
+	Exact bool
+}
+
+// NewSearchOptions returns the settings opts make, starting from the
+// defaults.
+func NewSearchOptions(opts ...SearchOption) SearchOptions {
+	q := SearchOptions{Limit: defaultSearchLimit}
+	for _, opt := range opts {
+		opt(&q)
+	}
+	return q
+}
+
+// defaultSearchLimit is the number of results Search returns unless
+// SearchLimit says otherwise.
+const defaultSearchLimit = 10
+
+// SearchTypes keeps results of the given entity types.
+func SearchTypes(types ...EntityType) SearchOption {
+	return func(q *SearchOptions) {
+		q.Types = append(q.Types, types...)
+	}
+}
+
+// SearchLimit sets the maximum number of results. A limit of zero or less
+// returns every match.
+func SearchLimit(n int) SearchOption {
+	return func(q *SearchOptions) {
+		q.Limit = n
+	}
+}
+
+// SearchExact requires every word of the query to match a whole word, so the
+// last word is no longer completed as a prefix.
+func SearchExact() SearchOption {
+	return func(q *SearchOptions) {
+		q.Exact = true
+	}
+}
+
+// Search returns the employees, teams, organizations, pillars and team groups
+// matching query, best first. Names, descriptions, keywords and emails are
+// searched, with matches on names ranking above the others.
+//
+// Every word of the query must match a word of the result, ignoring case and
+// punctuation. The last word also matches words it is a prefix of, so a
+// query can be searched as it is typed:
+//
+//	results := service.Search("plat", orgdatacore.SearchTypes(orgdatacore.EntityTeam))
+//
+// The index is built on first use, or at load with
+// WithEagerIndexes(IndexSearch).
+func (s *Service) Search(query string, opts ...SearchOption) []SearchResult {
+	q := NewSearchOptions(opts...)
+	words := tokenize(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil
+	}
+	index := s.derived().searchIndex()
+
+	var scores map[int]float64
+	for i, word := range words {
+		matches := index.match(word, !q.Exact && i == len(words)-1)
+		if scores == nil {
+			scores = matches
+			continue
+		}
+		for doc, score := range scores {
+			if m, ok := matches[doc]; ok {
+				scores[doc] = score + m
+			} else {
+				delete(scores, doc)
+			}
+		}
+	}
+
+	phrase := strings.Join(words, " ")
+	results := make([]SearchResult, 0, len(scores))
+	for i, score := range scores {
+		doc := &index.docs[i]
+		if len(q.Types) > 0 && !slices.Contains(q.Types, doc.typ) {
+			continue
+		}
+		for _, name := range doc.names {
+			if name == phrase {
+				score += searchWholeNameBonus
+				break
+			}
+			if strings.HasPrefix(name, phrase) {
+				score += searchNamePrefixBonus
+				break
+			}
+		}
+		results = append(results, SearchResult{Type: doc.typ, Name: doc.name, Label: doc.label, Score: score})
+	}
+	slices.SortFunc(results, compareSearchResults)
+	return q.limited(results)
+}
+
+// compareSearchResults orders results best first: by score, then shorter
+// labels, then type and name.
+func compareSearchResults(a, b SearchResult) int {
+	return cmp.Or(
+		cmp.Compare(b.Score, a.Score),
+		cmp.Compare(len(a.Label), len(b.Label)),
+		cmp.Compare(a.Type, b.Type),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// limited returns the first q.Limit of results.
+func (q SearchOptions) limited(results []SearchResult) []SearchResult {
+	if q.Limit > 0 && len(results) > q.Limit {
+		return results[:q.Limit]
+	}
+	return results
+}
+
+// Weights of a word by the field it was found in, and bonuses for results
+// whose name is the query, or starts with it. A word completed from a prefix
+// counts half.
+const (
+	searchNameWeight        = 4
+	searchKeywordWeight     = 2
+	searchEmailWeight       = 2
+	searchDescriptionWeight = 1
+
+	searchWholeNameBonus  = 8
+	searchNamePrefixBonus = 4
+)
+
+// searchIndex maps the words of the searchable fields to the entities they
+// appear in.
+type searchIndex struct {
+	docs     []searchDoc
+	postings map[string]map[int]float64 // word -> doc -> weight of its best field
+	words    []string                   // keys of postings, sorted for prefix lookups
+}
+
+type searchDoc struct {
+	typ   EntityType
+	name  string
+	label string
+	names []string // tokenized names, each joined by spaces
+}
+
+// match returns the weight of word in each document it appears in, also
+// counting the words it is a prefix of when prefix is set.
+func (x *searchIndex) match(word string, prefix bool) map[int]float64 {
+	matches := make(map[int]float64, len(x.postings[word]))
+	for doc, weight := range x.postings[word] {
+		matches[doc] = weight
+	}
+	if !prefix {
+		return matches
+	}
+	for i := sort.SearchStrings(x.words, word); i < len(x.words) && strings.HasPrefix(x.words[i], word); i++ {
+		if x.words[i] == word {
+			continue
+		}
+		for doc, weight := range x.postings[x.words[i]] {
+			matches[doc] = max(matches[doc], weight/2)
+		}
+	}
+	return matches
+}
+
+func (x *searchIndex) add(doc searchDoc) int {
+	x.docs = append(x.docs, doc)
+	return len(x.docs) - 1
+}
+
+// addField indexes the words of text as found in the given field of doc.
+func (x *searchIndex) addField(doc int, weight float64, text string) {
+	for _, word := range tokenize(text) {
+		docs := x.postings[word]
+		if docs == nil {
+			docs = make(map[int]float64)
+			x.postings[word] = docs
+		}
+		docs[doc] = max(docs[doc], weight)
+	}
+}
+
+func (x *searchIndex) addGroup(typ EntityType, name, tabName, description string, group Group) {
+	label := tabName
+	if label == "" {
+		label = name
+	}
+	names := []string{strings.Join(tokenize(name), " ")}
+	if tabName != "" {
+		names = append(names, strings.Join(tokenize(tabName), " "))
+	}
+	doc := x.add(searchDoc{typ: typ, name: name, label: label, names: names})
+	x.addField(doc, searchNameWeight, name)
+	x.addField(doc, searchNameWeight, tabName)
+	x.addField(doc, searchDescriptionWeight, description)
+	for _, kw := range group.Keywords {
+		x.addField(doc, searchKeywordWeight, kw)
+	}
+	for _, email := range group.Emails {
+		x.addField(doc, searchEmailWeight, email.Address)
+	}
+}
+
+func (d *derivedIndexes) searchIndex() *searchIndex {
+	d.searchOnce.Do(func() {
+		x := &searchIndex{postings: make(map[string]map[int]float64)}
+		d.search = x
+		if d.data == nil {
+			return
+		}
+		lookups := &d.data.Lookups
+		for uid, emp := range lookups.Employees {
+			label := emp.FullName
+			if label == "" {
+				label = uid
+			}
+			names := []string{strings.Join(tokenize(uid), " ")}
+			if emp.FullName != "" {
+				names = append(names, strings.Join(tokenize(emp.FullName), " "))
+			}
+			doc := x.add(searchDoc{typ: EntityEmployee, name: uid, label: label, names: names})
+			x.addField(doc, searchNameWeight, uid)
+			x.addField(doc, searchNameWeight, emp.FullName)
+			x.addField(doc, searchEmailWeight, emp.Email)
+			x.addField(doc, searchDescriptionWeight, emp.JobTitle)
+		}
+		for name, t := range lookups.Teams {
+			x.addGroup(EntityTeam, name, t.TabName, t.Description, t.Group)
+		}
+		for name, o := range lookups.Orgs {
+			x.addGroup(EntityOrg, name, o.TabName, o.Description, o.Group)
+		}
+		for name, p := range lookups.Pillars {
+			x.addGroup(EntityPillar, name, p.TabName, p.Description, p.Group)
+		}
+		for name, tg := range lookups.TeamGroups {
+			x.addGroup(EntityTeamGroup, name, tg.TabName, tg.Description, tg.Group)
+		}
+		x.words = make([]string, 0, len(x.postings))
+		for word := range x.postings {
+			x.words = append(x.words, word)
+		}
+		sort.Strings(x.words)
+	})
+	return d.search
+}
+
+// tokenize splits text into lowercase words at anything that is not a letter
+// or digit.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
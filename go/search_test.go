@@ -0,0 +1,56 @@
+package orgdatacore
+
+import (
+	"slices"
+	"testing"
+)
+
+func resultNames(results []SearchResult) []string {
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = string(r.Type) + ":" + r.Name
+	}
+	return names
+}
+
+func TestSearch(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name  string
+		query string
+		opts  []SearchOption
+		want  []string
+	}{
+		{"prefix of a name", "plat", nil, []string{"org:platform-org", "team:platform-team"}},
+		{"filtered by type", "plat", []SearchOption{SearchTypes(EntityTeam)}, []string{"team:platform-team"}},
+		{"limited", "plat", []SearchOption{SearchLimit(1)}, []string{"org:platform-org"}},
+		{"full name", "John Smith", nil, []string{"employee:jsmith"}},
+		{"case and punctuation ignored", "PLATFORM-team", nil, []string{"team:platform-team"}},
+		{"keyword", "qa", nil, []string{"team:test-team"}},
+		{"email", "bwilson@example.com", nil, []string{"employee:bwilson"}},
+		{"name ranks above description", "engineer", nil, []string{"pillar:engineering", "employee:bwilson", "employee:jsmith", "team_group:backend-teams"}},
+		{"exact", "engineer", []SearchOption{SearchExact()}, []string{"employee:bwilson", "employee:jsmith"}},
+		{"every word must match", "platform qa", nil, []string{}},
+		{"no match", "nonexistent", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultNames(service.Search(tt.query, tt.opts...))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	results := service.Search("John")
+	if len(results) == 0 || results[0].Label != "John Smith" || results[0].Score <= 0 {
+		t.Errorf("Search(John) = %v, want John Smith with a positive score", results)
+	}
+	if results := service.Search("  -- "); results != nil {
+		t.Errorf("Search of no words = %v, want nil", results)
+	}
+	if results := NewService().Search("plat"); results != nil {
+		t.Errorf("Search on an empty service = %v, want nil", results)
+	}
+}
@@ -0,0 +1,135 @@
+package orgdatacore
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+)
+
+// sectionSet records which sections of the data document to decode.
+// A nil set means every section is decoded.
+type sectionSet map[Section]bool
+
+// has reports whether sec is decoded under this set.
+func (s sectionSet) has(sec Section) bool {
+	return s == nil || s[sec]
+}
+
+// list returns the sections in the set in document order, or nil for the
+// nil set.
+func (s sectionSet) list() []Section {
+	if s == nil {
+		return nil
+	}
+	var out []Section
+	for _, sec := range allSections {
+		if s[sec] {
+			out = append(out, sec)
+		}
+	}
+	return out
+}
+
+// missingFrom returns the sections decoded under this set that a document
+// holding only the sections in have lacks. An empty have means the document
+// holds every section.
+func (s sectionSet) missingFrom(have []Section) []Section {
+	if len(have) == 0 {
+		return nil
+	}
+	var missing []Section
+	for _, sec := range allSections {
+		if s.has(sec) && !slices.Contains(have, sec) {
+			missing = append(missing, sec)
+		}
+	}
+	return missing
+}
+
+// allSections lists every Section, in document order.
+var allSections = []Section{
+	SectionEmployees, SectionTeams, SectionOrgs, SectionPillars, SectionTeamGroups, SectionComponents,
+	SectionMembership, SectionSlackIDs, SectionGitHubIDs, SectionJira, SectionComponentOwnership,
+}
+
+// optionalSection decodes its JSON value only when keep is set. Skipped
+// values are scanned for syntax but never materialized, so sections a
+// consumer does not need cost no retained memory.
+type optionalSection[T any] struct {
+	keep  bool
+	value T
+}
+
+func (o *optionalSection[T]) UnmarshalJSON(b []byte) error {
+	if !o.keep {
+		return nil
+	}
+	return json.Unmarshal(b, &o.value)
+}
+
+// sectionedDocument mirrors Data with every droppable section wrapped in
+// optionalSection.
+type sectionedDocument struct {
+	Metadata Metadata `json:"metadata"`
+	Lookups  struct {
+		Employees  optionalSection[map[string]Employee]  `json:"employees"`
+		Teams      optionalSection[map[string]Team]      `json:"teams"`
+		Orgs       optionalSection[map[string]Org]       `json:"orgs"`
+		Pillars    optionalSection[map[string]Pillar]    `json:"pillars"`
+		TeamGroups optionalSection[map[string]TeamGroup] `json:"team_groups"`
+		Components optionalSection[map[string]Component] `json:"components"`
+	} `json:"lookups"`
+	Indexes struct {
+		Membership         optionalSection[MembershipIndex]                 `json:"membership"`
+		SlackIDMappings    optionalSection[SlackIDMappings]                 `json:"slack_id_mappings"`
+		GitHubIDMappings   optionalSection[GitHubIDMappings]                `json:"github_id_mappings"`
+		Jira               optionalSection[JiraIndex]                       `json:"jira"`
+		ComponentOwnership optionalSection[map[string][]ComponentOwnerInfo] `json:"component_ownership"`
+	} `json:"indexes"`
+}
+
+// decodeData decodes a data document from r, skipping sections not in sections.
+func decodeData(r io.Reader, sections sectionSet) (*Data, error) {
+	var data Data
+	if sections == nil {
+		if err := json.NewDecoder(r).Decode(&data); err != nil {
+			return nil, err
+		}
+		return &data, nil
+	}
+
+	var doc sectionedDocument
+	doc.Lookups.Employees.keep = sections.has(SectionEmployees)
+	doc.Lookups.Teams.keep = sections.has(SectionTeams)
+	doc.Lookups.Orgs.keep = sections.has(SectionOrgs)
+	doc.Lookups.Pillars.keep = sections.has(SectionPillars)
+	doc.Lookups.TeamGroups.keep = sections.has(SectionTeamGroups)
+	doc.Lookups.Components.keep = sections.has(SectionComponents)
+	doc.Indexes.Membership.keep = sections.has(SectionMembership)
+	doc.Indexes.SlackIDMappings.keep = sections.has(SectionSlackIDs)
+	doc.Indexes.GitHubIDMappings.keep = sections.has(SectionGitHubIDs)
+	doc.Indexes.Jira.keep = sections.has(SectionJira)
+	doc.Indexes.ComponentOwnership.keep = sections.has(SectionComponentOwnership)
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	data.Metadata = doc.Metadata
+	data.Lookups = Lookups{
+		Employees:  doc.Lookups.Employees.value,
+		Teams:      doc.Lookups.Teams.value,
+		Orgs:       doc.Lookups.Orgs.value,
+		Pillars:    doc.Lookups.Pillars.value,
+		TeamGroups: doc.Lookups.TeamGroups.value,
+		Components: doc.Lookups.Components.value,
+	}
+	data.Indexes = Indexes{
+		Membership:         doc.Indexes.Membership.value,
+		SlackIDMappings:    doc.Indexes.SlackIDMappings.value,
+		GitHubIDMappings:   doc.Indexes.GitHubIDMappings.value,
+		Jira:               doc.Indexes.Jira.value,
+		ComponentOwnership: doc.Indexes.ComponentOwnership.value,
+	}
+	return &data, nil
+}
@@ -0,0 +1,103 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestWithSections(t *testing.T) {
+	service := NewService(WithSections(SectionEmployees, SectionSlackIDs, Section("bogus")))
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	if err := service.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(testDataPath)); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+
+	if emp := service.GetEmployeeBySlackID("U12345678"); emp == nil || emp.UID != "jsmith" {
+		t.Errorf("GetEmployeeBySlackID should work with employees and slack sections loaded, got %v", emp)
+	}
+	if v := service.GetVersion(); v.EmployeeCount != 3 {
+		t.Errorf("EmployeeCount = %d, want 3", v.EmployeeCount)
+	}
+
+	service.mu.RLock()
+	data := service.data
+	service.mu.RUnlock()
+
+	if data.Metadata.DataVersion != "test-abc123" {
+		t.Errorf("metadata should always be loaded, got data_version %q", data.Metadata.DataVersion)
+	}
+	if data.Lookups.Teams != nil || data.Lookups.Orgs != nil || data.Lookups.Pillars != nil {
+		t.Error("hierarchy lookups should be skipped")
+	}
+	if data.Indexes.Membership.MembershipIndex != nil || data.Indexes.Jira != nil {
+		t.Error("membership and jira indexes should be skipped")
+	}
+	if data.Indexes.GitHubIDMappings.GitHubIDToUID != nil {
+		t.Error("github mappings should be skipped")
+	}
+
+	if team := service.GetTeamByName("test-team"); team != nil {
+		t.Error("GetTeamByName should return nil when teams are skipped")
+	}
+	if teams := service.GetTeamsForUID("jsmith"); len(teams) != 0 {
+		t.Errorf("GetTeamsForUID should be empty when membership is skipped, got %v", teams)
+	}
+	if service.IsEmployeeInTeam("jsmith", "test-team") {
+		t.Error("IsEmployeeInTeam should be false when membership is skipped")
+	}
+}
+
+func TestWithSectionsValidation(t *testing.T) {
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+
+	// Without the employees section loaded, its absence is not an error.
+	service := NewService(WithSections(SectionTeams, SectionOrgs))
+	if err := service.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(testDataPath)); err != nil {
+		t.Fatalf("Failed to load with teams and orgs only: %v", err)
+	}
+	if service.GetTeamByName("test-team") == nil {
+		t.Error("GetTeamByName should work with teams loaded")
+	}
+
+	// A requested section that is missing from the document still fails validation.
+	service = NewService(WithSections(SectionEmployees))
+	source := NewFakeDataSource(`{"metadata": {}, "lookups": {"employees": {}}}`)
+	if err := service.LoadFromDataSource(context.Background(), source); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("expected ErrInvalidData for empty employees, got %v", err)
+	}
+}
+
+func TestDecodeDataMatchesFullDecode(t *testing.T) {
+	doc := testingsupport.GenerateDataset(50, 5, 2)
+
+	full, err := decodeData(bytes.NewReader(doc), nil)
+	if err != nil {
+		t.Fatalf("full decode failed: %v", err)
+	}
+	all := make(sectionSet)
+	for _, sec := range []Section{SectionEmployees, SectionTeams, SectionOrgs, SectionPillars, SectionTeamGroups,
+		SectionComponents, SectionMembership, SectionSlackIDs, SectionGitHubIDs, SectionJira, SectionComponentOwnership} {
+		all[sec] = true
+	}
+	sectioned, err := decodeData(bytes.NewReader(doc), all)
+	if err != nil {
+		t.Fatalf("sectioned decode failed: %v", err)
+	}
+
+	if len(sectioned.Lookups.Employees) != len(full.Lookups.Employees) ||
+		len(sectioned.Lookups.Teams) != len(full.Lookups.Teams) ||
+		len(sectioned.Lookups.Orgs) != len(full.Lookups.Orgs) ||
+		len(sectioned.Indexes.Membership.MembershipIndex) != len(full.Indexes.Membership.MembershipIndex) ||
+		len(sectioned.Indexes.SlackIDMappings.SlackUIDToUID) != len(full.Indexes.SlackIDMappings.SlackUIDToUID) {
+		t.Error("decoding with every section selected should match a full decode")
+	}
+
+	if _, err := decodeData(bytes.NewReader([]byte(`{"lookups": {"teams": [}}`)), sectionSet{}); err == nil {
+		t.Error("malformed JSON in a skipped section should still be rejected")
+	}
+}
@@ -1,23 +1,60 @@
 package orgdatacore
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Service struct {
-	mu                sync.RWMutex
-	data              *Data
-	version           DataVersion
-	logger            *slog.Logger
-	watcherRunning    bool
-	watcherCancel     context.CancelFunc
-	slackChannelIndex map[string][]string
+	mu           sync.RWMutex
+	data         *Data
+	version      DataVersion
+	logger       *slog.Logger
+	loggers      map[string]*slog.Logger // by subsystem, see WithLogLevels
+	watchers     map[string]*watcher
+	watcherSeq   uint64     // start order of the next watcher
+	watcherLoads sync.Mutex // serializes loads by watchers
+	indexes      atomic.Pointer[derivedIndexes]
+	eagerIndexes []IndexKind
+	queryCache   *queryCache
+	sections     sectionSet
+	reloadSubs   reloadSubscribers
+	eventSubs    eventSubscribers
+	validators   []func(*Data) error
+	policy       validationPolicy
+	strictSchema bool
+	unknownCheck bool
+	repair       bool
+	overlays     []DataSource
+	maxShrink    float64
+	loadStats    loadStats
+	lastSource   DataSource
+	maxDataAge   time.Duration
+	clock        Clock
+
+	normalization        Normalization
+	normalizationEnabled bool // normalization.enabled(), computed once
+	copyPolicy           CopyPolicy
+
+	snapshotLimit     int
+	snapshots         []*derivedIndexes // previous datasets, oldest first
+	generation        uint64            // bumped by every swapData
+	lastKnownGoodPath string
+	lastKnownGood     lastKnownGoodWriter
+
+	queryLog *queryLogger // nil unless WithQueryLogging is set
+
+	// failedWatchers maps watchers that stopped because their source failed
+	// to the error, until a watcher of the same name is started again.
+	failedWatchers map[string]string
 }
 
 func NewService(opts ...ServiceOption) *Service {
@@ -25,108 +62,267 @@ func NewService(opts ...ServiceOption) *Service {
 	for _, opt := range opts {
 		opt(cfg)
 	}
-	return &Service{logger: cfg.logger}
+	s := &Service{
+		logger:       cfg.logger,
+		loggers:      subsystemLoggers(cfg.logger, cfg.logLevels),
+		eagerIndexes: cfg.eagerIndexes,
+		queryCache:   newQueryCache(cfg.queryCacheSize, cfg.queryCacheTTL, cfg.clock),
+		sections:     cfg.sections,
+		validators:   cfg.validators,
+		overlays:     cfg.overlays,
+		policy:       validationPolicy{severities: cfg.checkSeverities, required: cfg.requiredSections, threshold: cfg.validationThreshold, skipConsistency: cfg.skipConsistency},
+		strictSchema: cfg.strictSchema,
+		unknownCheck: cfg.unknownFieldCheck,
+		repair:       cfg.repair,
+		maxShrink:    cfg.maxShrink,
+		maxDataAge:   cfg.maxDataAge,
+		clock:        cfg.clock,
+
+		normalization:        cfg.normalization,
+		normalizationEnabled: cfg.normalization.enabled(),
+		copyPolicy:           cfg.copyPolicy,
+
+		snapshotLimit:     cfg.snapshotHistory,
+		lastKnownGoodPath: cfg.lastKnownGoodPath,
+	}
+	if cfg.queryLogging {
+		s.queryLog = newQueryLogger(s.loggerFor(LogQuery), cfg.queryLogLevel)
+	}
+	if cfg.metrics != nil {
+		cfg.metrics.RegisterService(s)
+	}
+	return s
 }
 
 func (s *Service) LoadFromDataSource(ctx context.Context, source DataSource) error {
-	reader, err := source.Load(ctx)
-	if err != nil {
-		return NewLoadError(source.String(), err)
-	}
-	defer func() {
-		if closeErr := reader.Close(); closeErr != nil {
-			s.logger.Warn("failed to close reader", "source", source.String(), "error", closeErr)
-		}
-	}()
+	s.setLastSource(source)
+	attempt := s.startLoad(source.String())
+	err := s.loadFromDataSource(ctx, source, attempt)
+	s.finishLoad(attempt, err)
+	return err
+}
 
-	var orgData Data
-	if err := json.NewDecoder(reader).Decode(&orgData); err != nil {
-		return NewLoadError(source.String(), fmt.Errorf("failed to parse JSON: %w", err))
+// MustLoadFromDataSource is like LoadFromDataSource but panics on error, for
+// CLIs and init-time wiring where the program cannot continue without data.
+func (s *Service) MustLoadFromDataSource(ctx context.Context, source DataSource) {
+	if err := s.LoadFromDataSource(ctx, source); err != nil {
+		panic(err)
 	}
+}
+
+// LoadFromReader loads data already in hand, such as an HTTP request body or
+// an embedded asset, with the same decoding, validation and events as
+// LoadFromDataSource. sourceName stands for the source in logs, errors and
+// load reports. The reader is read once, so Reload does not load from it
+// again: it keeps using the DataSource loaded before, if any.
+func (s *Service) LoadFromReader(ctx context.Context, r io.Reader, sourceName string) error {
+	source := readerSource{name: sourceName, r: r}
+	attempt := s.startLoad(source.String())
+	err := s.loadFromDataSource(ctx, source, attempt)
+	s.finishLoad(attempt, err)
+	return err
+}
+
+// LoadFromBytes is LoadFromReader for data in a byte slice.
+func (s *Service) LoadFromBytes(ctx context.Context, data []byte, sourceName string) error {
+	return s.LoadFromReader(ctx, bytes.NewReader(data), sourceName)
+}
+
+// readerSource serves a reader given to LoadFromReader.
+type readerSource struct {
+	name string
+	r    io.Reader
+}
+
+func (r readerSource) Load(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(r.r), nil
+}
+
+func (r readerSource) Watch(ctx context.Context, _ func() error) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (r readerSource) String() string { return r.name }
+
+func (r readerSource) Close() error { return nil }
 
-	if err := validateData(&orgData); err != nil {
-		return NewLoadError(source.String(), err)
+// Reload loads again from the DataSource most recently passed to
+// LoadFromDataSource, LoadChangesFromDataSource or a watcher, using incremental
+// changes when the source supports them. Admin endpoints and SIGHUP handlers
+// can use it to force a refresh without touching the running watchers.
+// It returns ErrNoDataSource if no source has been used yet.
+func (s *Service) Reload(ctx context.Context) error {
+	s.mu.RLock()
+	source := s.lastSource
+	s.mu.RUnlock()
+
+	if source == nil {
+		return ErrNoDataSource
 	}
+	s.loggerFor(LogLoader).Info("reloading data", "source", source.String())
+	return s.refreshFromDataSource(ctx, source)
+}
 
+func (s *Service) setLastSource(source DataSource) {
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	s.lastSource = source
+	s.mu.Unlock()
+}
 
-	s.data = &orgData
-	s.version = DataVersion{
-		LoadTime:      time.Now(),
-		OrgCount:      len(orgData.Lookups.Orgs),
-		EmployeeCount: len(orgData.Lookups.Employees),
+func (s *Service) loadFromDataSource(ctx context.Context, source DataSource, attempt *loadAttempt) error {
+	orgData, err := s.fetchData(ctx, source, attempt)
+	if err != nil {
+		return err
 	}
 
-	s.slackChannelIndex = make(map[string][]string)
-	for _, team := range orgData.Lookups.Teams {
-		if team.Group.Slack == nil {
-			continue
-		}
-		for _, ch := range team.Group.Slack.Channels {
-			if ch.Channel != "" {
-				normalized := normalizeSlackChannel(ch.Channel)
-				s.slackChannelIndex[normalized] = append(s.slackChannelIndex[normalized], team.Name)
-			}
-		}
+	start := time.Now()
+	if err := s.applyOverlays(ctx, orgData); err != nil {
+		return err
 	}
-
-	s.logger.Info("data loaded", "source", source.String(), "employees", s.version.EmployeeCount, "orgs", s.version.OrgCount)
-	return nil
+	return s.installFetched(source.String(), orgData, attempt.checksum(orgData), attempt, start)
 }
 
-func (s *Service) StartDataSourceWatcher(ctx context.Context, source DataSource) error {
-	s.mu.Lock()
-	if s.watcherRunning {
-		s.mu.Unlock()
-		return ErrWatcherAlreadyRunning
+// installFetched repairs, validates and indexes data fetched from source,
+// its overlays already applied, and installs it. start is when the
+// validate phase began.
+func (s *Service) installFetched(source string, orgData *Data, checksum string, attempt *loadAttempt, start time.Time) error {
+	s.repairData(orgData, attempt)
+	if _, err := s.validate(orgData, attempt); err != nil {
+		return NewLoadError(source, err)
 	}
-	s.watcherRunning = true
+	start = timePhase(&attempt.phases.Validate, start)
 
-	// Create a cancellable context so StopWatcher can terminate the watcher
-	watchCtx, cancel := context.WithCancel(ctx)
-	s.watcherCancel = cancel
+	indexes := s.prepareIndexes(orgData, checksum)
+	start = timePhase(&attempt.phases.Index, start)
+
+	s.mu.Lock()
+	ev := s.installData(orgData, indexes)
 	s.mu.Unlock()
+	timePhase(&attempt.phases.Swap, start)
+	attempt.install(ev, false)
 
-	if err := s.LoadFromDataSource(watchCtx, source); err != nil {
-		s.mu.Lock()
-		s.watcherRunning = false
-		s.watcherCancel = nil
-		s.mu.Unlock()
-		cancel() // Clean up the context
-		return err
+	s.loggerFor(LogLoader).Info("data loaded", "source", source, "employees", ev.newVersion.EmployeeCount, "orgs", ev.newVersion.OrgCount)
+	s.warnIfStale(orgData)
+	s.publishReload(ev)
+	s.persistLastKnownGood(ev)
+	return nil
+}
+
+// fetchData loads and decodes the data from source.
+func (s *Service) fetchData(ctx context.Context, source DataSource, attempt *loadAttempt) (*Data, error) {
+	start := time.Now()
+	if merged, ok := source.(*MergedDataSource); ok {
+		var mu sync.Mutex
+		counts := make(map[string]int)
+		data, n, err := merged.loadData(ctx, func(r io.Reader) (*Data, error) {
+			data, unknown, err := s.decode(r)
+			mu.Lock()
+			for _, f := range unknown {
+				counts[f.Path] += f.Count
+			}
+			mu.Unlock()
+			return data, err
+		}, attempt.hash)
+		for path, n := range counts {
+			attempt.unknownFields = append(attempt.unknownFields, UnknownField{Path: path, Count: n})
+		}
+		slices.SortFunc(attempt.unknownFields, func(a, b UnknownField) int { return strings.Compare(a.Path, b.Path) })
+		attempt.bytes += n
+		timePhase(&attempt.phases.Download, start)
+		if err != nil {
+			return nil, NewLoadError(source.String(), err)
+		}
+		return data, nil
 	}
 
-	err := source.Watch(watchCtx, func() error {
-		if err := s.LoadFromDataSource(watchCtx, source); err != nil {
-			s.logger.Error("failed to reload data", "source", source.String(), "error", err)
-			return err
+	reader, err := source.Load(ctx)
+	start = timePhase(&attempt.phases.Download, start)
+	if err != nil {
+		return nil, NewLoadError(source.String(), err)
+	}
+	defer func() {
+		if closeErr := reader.Close(); closeErr != nil {
+			s.loggerFor(LogLoader).Warn("failed to close reader", "source", source.String(), "error", closeErr)
 		}
-		return nil
-	})
+	}()
 
-	// Clear watcher state when Watch exits (context cancelled, error, etc.)
-	s.mu.Lock()
-	s.watcherRunning = false
-	s.watcherCancel = nil
-	s.mu.Unlock()
+	// Reads are timed as download; the rest of decoding is decode.
+	downloaded := attempt.phases.Download
+	data, unknown, err := s.decode(attempt.reader(reader))
+	attempt.unknownFields = unknown
+	attempt.phases.Decode += time.Since(start) - (attempt.phases.Download - downloaded)
+	if err != nil {
+		return nil, NewLoadError(source.String(), fmt.Errorf("failed to parse JSON: %w", err))
+	}
+	return data, nil
+}
 
-	return err
+// decode decodes a data document from r, checking it against the schema
+// first under WithStrictSchema, and returning the fields it does not model
+// under WithUnknownFieldCheck.
+func (s *Service) decode(r io.Reader) (*Data, []UnknownField, error) {
+	if !s.strictSchema && !s.unknownCheck {
+		data, err := decodeData(r, s.sections)
+		return data, nil, err
+	}
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.strictSchema {
+		if err := ValidateAgainstSchema(bytes.NewReader(raw)); err != nil {
+			return nil, nil, err
+		}
+	}
+	var unknown []UnknownField
+	if s.unknownCheck {
+		if unknown, err = FindUnknownFields(bytes.NewReader(raw)); err != nil {
+			return nil, nil, err
+		}
+	}
+	data, err := decodeData(bytes.NewReader(raw), s.sections)
+	return data, unknown, err
 }
 
-// StopWatcher stops the running watcher by cancelling its context.
-// This signals the DataSource.Watch method to exit. The method is safe to call
-// even if no watcher is running.
-func (s *Service) StopWatcher() {
-	s.mu.Lock()
-	cancel := s.watcherCancel
-	s.watcherCancel = nil
-	s.watcherRunning = false
-	s.mu.Unlock()
+// prepareIndexes builds any eagerly-requested indexes for data, whose
+// checksum is given. It is called before taking the write lock so readers
+// keep serving the previous data in the meantime. The remaining indexes are
+// built lazily on first use.
+func (s *Service) prepareIndexes(data *Data, checksum string) *derivedIndexes {
+	indexes := newDerivedIndexes(data)
+	indexes.checksum = checksum
+	indexes.buildAll(s.eagerIndexes)
+	return indexes
+}
+
+// installData makes data the current dataset and returns the resulting
+// reload event, which the caller publishes once s.mu is released.
+// The replaced dataset is kept as a snapshot for Rollback.
+// Must be called with s.mu held for writing.
+func (s *Service) installData(data *Data, indexes *derivedIndexes) reloadEvent {
+	s.pushSnapshot()
+	return s.swapData(data, indexes)
+}
 
-	if cancel != nil {
-		cancel()
+// swapData is installData without taking a snapshot.
+// Must be called with s.mu held for writing.
+func (s *Service) swapData(data *Data, indexes *derivedIndexes) reloadEvent {
+	s.generation++
+	ev := reloadEvent{oldVersion: s.version, oldData: s.data, newData: data, generation: s.generation}
+	s.data = data
+	s.version = DataVersion{
+		LoadTime:      s.clock.Now(),
+		OrgCount:      len(data.Lookups.Orgs),
+		EmployeeCount: len(data.Lookups.Employees),
+		Checksum:      indexes.checksum,
+	}
+	s.indexes.Store(indexes)
+	if s.queryCache != nil {
+		s.queryCache.purge()
 	}
+	ev.newVersion = s.version
+	return ev
 }
 
 func (s *Service) GetVersion() DataVersion {
@@ -144,7 +340,7 @@ func (s *Service) GetDataAge() time.Duration {
 	if s.version.LoadTime.IsZero() {
 		return 0
 	}
-	return time.Since(s.version.LoadTime)
+	return s.clock.Now().Sub(s.version.LoadTime)
 }
 
 // IsDataStale returns true if data is older than maxAge, or if no data is loaded.
@@ -156,10 +352,14 @@ func (s *Service) IsDataStale(maxAge time.Duration) bool {
 	if s.data == nil || s.version.LoadTime.IsZero() {
 		return true
 	}
-	return time.Since(s.version.LoadTime) > maxAge
+	return s.clock.Now().Sub(s.version.LoadTime) > maxAge
 }
 
-func (s *Service) GetEmployeeByUID(uid string) *Employee {
+func (s *Service) GetEmployeeByUID(uid string) (out *Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetEmployeeByUID", time.Now(), func() bool { return out != nil }, "uid", uid)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -172,7 +372,11 @@ func (s *Service) GetEmployeeByUID(uid string) *Employee {
 	return nil
 }
 
-func (s *Service) GetEmployeeBySlackID(slackID string) *Employee {
+func (s *Service) GetEmployeeBySlackID(slackID string) (out *Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetEmployeeBySlackID", time.Now(), func() bool { return out != nil }, "slack_id", slackID)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -189,7 +393,11 @@ func (s *Service) GetEmployeeBySlackID(slackID string) *Employee {
 	return nil
 }
 
-func (s *Service) GetEmployeeByGitHubID(githubID string) *Employee {
+func (s *Service) GetEmployeeByGitHubID(githubID string) (out *Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetEmployeeByGitHubID", time.Now(), func() bool { return out != nil }, "github_id", githubID)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -206,24 +414,38 @@ func (s *Service) GetEmployeeByGitHubID(githubID string) *Employee {
 	return nil
 }
 
-func (s *Service) GetEmployeeByEmail(email string) *Employee {
+// GetEmployeeByEmail finds an employee by email address (case-insensitive).
+// An empty address matches no one, not even employees without an email.
+// The email index is built on first call.
+func (s *Service) GetEmployeeByEmail(email string) (out *Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetEmployeeByEmail", time.Now(), func() bool { return out != nil }, "email", email)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if s.data == nil || s.data.Lookups.Employees == nil {
+	if s.data == nil || s.data.Lookups.Employees == nil || email == "" {
 		return nil
 	}
-	emailLower := strings.ToLower(email)
-	for _, emp := range s.data.Lookups.Employees {
-		if strings.ToLower(emp.Email) == emailLower {
-			e := emp
-			return &e
-		}
+	uid, exists := s.derived().emailIndex()[strings.ToLower(email)]
+	if !exists && s.normalizationEnabled {
+		uid, exists = s.derived().normalizedIndex(s.normalization)["email"][s.normalization.Normalize(strings.ToLower(email))]
+	}
+	if !exists {
+		return nil
+	}
+	if emp, exists := s.data.Lookups.Employees[uid]; exists {
+		return &emp
 	}
 	return nil
 }
 
-func (s *Service) GetManagerForEmployee(uid string) *Employee {
+func (s *Service) GetManagerForEmployee(uid string) (out *Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetManagerForEmployee", time.Now(), func() bool { return out != nil }, "uid", uid)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -240,14 +462,20 @@ func (s *Service) GetManagerForEmployee(uid string) *Employee {
 	return nil
 }
 
-func (s *Service) GetTeamByName(teamName string) *Team {
+func (s *Service) GetTeamByName(teamName string) (out *Team) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamByName", time.Now(), func() bool { return out != nil }, "team_name", teamName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if s.data == nil || s.data.Lookups.Teams == nil {
 		return nil
 	}
+	teamName = resolveKey(s, s.data.Lookups.Teams, "team", teamName)
 	if team, exists := s.data.Lookups.Teams[teamName]; exists {
+		team = owned(s, team)
 		return &team
 	}
 	return nil
@@ -257,15 +485,19 @@ func normalizeSlackChannel(channel string) string {
 	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(channel), "#"))
 }
 
-func (s *Service) GetTeamsBySlackChannel(channel string) []Team {
+func (s *Service) GetTeamsBySlackChannel(channel string) (out []Team) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamsBySlackChannel", time.Now(), func() bool { return len(out) > 0 }, "channel", channel)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if s.data == nil || s.slackChannelIndex == nil || channel == "" {
+	if s.data == nil || channel == "" {
 		return []Team{}
 	}
 
-	teamNames, exists := s.slackChannelIndex[normalizeSlackChannel(channel)]
+	teamNames, exists := s.derived().slackChannelIndex()[normalizeSlackChannel(channel)]
 	if !exists {
 		return []Team{}
 	}
@@ -273,7 +505,7 @@ func (s *Service) GetTeamsBySlackChannel(channel string) []Team {
 	var teams []Team
 	for _, name := range teamNames {
 		if team, exists := s.data.Lookups.Teams[name]; exists {
-			teams = append(teams, team)
+			teams = append(teams, owned(s, team))
 		}
 	}
 	if teams == nil {
@@ -282,46 +514,68 @@ func (s *Service) GetTeamsBySlackChannel(channel string) []Team {
 	return teams
 }
 
-func (s *Service) GetOrgByName(orgName string) *Org {
+func (s *Service) GetOrgByName(orgName string) (out *Org) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetOrgByName", time.Now(), func() bool { return out != nil }, "org_name", orgName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if s.data == nil || s.data.Lookups.Orgs == nil {
 		return nil
 	}
+	orgName = resolveKey(s, s.data.Lookups.Orgs, "org", orgName)
 	if org, exists := s.data.Lookups.Orgs[orgName]; exists {
+		org = owned(s, org)
 		return &org
 	}
 	return nil
 }
 
-func (s *Service) GetPillarByName(pillarName string) *Pillar {
+func (s *Service) GetPillarByName(pillarName string) (out *Pillar) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetPillarByName", time.Now(), func() bool { return out != nil }, "pillar_name", pillarName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if s.data == nil || s.data.Lookups.Pillars == nil {
 		return nil
 	}
+	pillarName = resolveKey(s, s.data.Lookups.Pillars, "pillar", pillarName)
 	if pillar, exists := s.data.Lookups.Pillars[pillarName]; exists {
+		pillar = owned(s, pillar)
 		return &pillar
 	}
 	return nil
 }
 
-func (s *Service) GetTeamGroupByName(teamGroupName string) *TeamGroup {
+func (s *Service) GetTeamGroupByName(teamGroupName string) (out *TeamGroup) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamGroupByName", time.Now(), func() bool { return out != nil }, "team_group_name", teamGroupName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if s.data == nil || s.data.Lookups.TeamGroups == nil {
 		return nil
 	}
+	teamGroupName = resolveKey(s, s.data.Lookups.TeamGroups, "team_group", teamGroupName)
 	if tg, exists := s.data.Lookups.TeamGroups[teamGroupName]; exists {
+		tg = owned(s, tg)
 		return &tg
 	}
 	return nil
 }
 
-func (s *Service) GetTeamsForUID(uid string) []string {
+func (s *Service) GetTeamsForUID(uid string) (out []string) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamsForUID", time.Now(), func() bool { return len(out) > 0 }, "uid", uid)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -343,7 +597,11 @@ func (s *Service) getTeamsForUID(uid string) []string {
 	return teams
 }
 
-func (s *Service) GetTeamsForSlackID(slackID string) []string {
+func (s *Service) GetTeamsForSlackID(slackID string) (out []string) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamsForSlackID", time.Now(), func() bool { return len(out) > 0 }, "slack_id", slackID)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -354,7 +612,11 @@ func (s *Service) GetTeamsForSlackID(slackID string) []string {
 	return s.getTeamsForUID(uid)
 }
 
-func (s *Service) GetTeamMembers(teamName string) []Employee {
+func (s *Service) GetTeamMembers(teamName string) (out []Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamMembers", time.Now(), func() bool { return len(out) > 0 }, "team_name", teamName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -362,7 +624,7 @@ func (s *Service) GetTeamMembers(teamName string) []Employee {
 		return []Employee{}
 	}
 
-	team, exists := s.data.Lookups.Teams[teamName]
+	team, exists := s.data.Lookups.Teams[resolveKey(s, s.data.Lookups.Teams, "team", teamName)]
 	if !exists {
 		return []Employee{}
 	}
@@ -376,24 +638,80 @@ func (s *Service) GetTeamMembers(teamName string) []Employee {
 	return members
 }
 
-func (s *Service) IsEmployeeInTeam(uid string, teamName string) bool {
+func (s *Service) IsEmployeeInTeam(uid string, teamName string) (out bool) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("IsEmployeeInTeam", time.Now(), func() bool { return out }, "uid", uid, "team_name", teamName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	return s.isEmployeeInTeam(uid, teamName)
 }
 
+// AreEmployeesInTeam reports, for each of uids, whether the employee is a
+// member of teamName, taking the lock once for all of them. Middlewares
+// that authorize many users per request can use it instead of calling
+// IsEmployeeInTeam for each.
+func (s *Service) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	members := 0
+	if s.queryLog != nil {
+		defer s.queryLog.log("AreEmployeesInTeam", time.Now(), func() bool { return members > 0 }, "uids", uids, "team_name", teamName)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]bool, len(uids))
+	for _, uid := range uids {
+		if s.isEmployeeInTeam(uid, teamName) {
+			out[uid] = true
+			members++
+		} else {
+			out[uid] = false
+		}
+	}
+	return out
+}
+
+// IsEmployeeInAnyTeam reports whether the employee is a member of any of
+// teams, taking the lock once.
+func (s *Service) IsEmployeeInAnyTeam(uid string, teams []string) (out bool) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("IsEmployeeInAnyTeam", time.Now(), func() bool { return out }, "uid", uid, "teams", teams)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, team := range teams {
+		if s.isEmployeeInTeam(uid, team) {
+			return true
+		}
+	}
+	return false
+}
+
 // isEmployeeInTeam is the internal version that assumes the lock is held.
+// It scans the membership index in place and does not allocate.
 func (s *Service) isEmployeeInTeam(uid string, teamName string) bool {
-	for _, team := range s.getTeamsForUID(uid) {
-		if team == teamName {
+	if s.data == nil {
+		return false
+	}
+	teamName = resolveKey(s, s.data.Lookups.Teams, "team", teamName)
+	for _, m := range s.data.Indexes.Membership.MembershipIndex[uid] {
+		if m.Name == teamName && m.Type == string(MembershipTeam) {
 			return true
 		}
 	}
 	return false
 }
 
-func (s *Service) IsSlackUserInTeam(slackID string, teamName string) bool {
+func (s *Service) IsSlackUserInTeam(slackID string, teamName string) (out bool) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("IsSlackUserInTeam", time.Now(), func() bool { return out }, "slack_id", slackID, "team_name", teamName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -404,7 +722,11 @@ func (s *Service) IsSlackUserInTeam(slackID string, teamName string) bool {
 	return s.isEmployeeInTeam(uid, teamName)
 }
 
-func (s *Service) IsEmployeeInOrg(uid string, orgName string) bool {
+func (s *Service) IsEmployeeInOrg(uid string, orgName string) (out bool) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("IsEmployeeInOrg", time.Now(), func() bool { return out }, "uid", uid, "org_name", orgName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -412,28 +734,51 @@ func (s *Service) IsEmployeeInOrg(uid string, orgName string) bool {
 }
 
 // isEmployeeInOrg is the internal version that assumes the lock is held.
+// It walks parent references in place rather than materializing hierarchy
+// paths, so it does not allocate.
 func (s *Service) isEmployeeInOrg(uid string, orgName string) bool {
 	if s.data == nil || s.data.Indexes.Membership.MembershipIndex == nil {
 		return false
 	}
+	orgName = resolveKey(s, s.data.Lookups.Orgs, "org", orgName)
 
 	for _, m := range s.data.Indexes.Membership.MembershipIndex[uid] {
 		if m.Type == string(MembershipOrg) && m.Name == orgName {
 			return true
 		}
-		if m.Type == string(MembershipTeam) {
-			hierarchyPath := s.computeHierarchyPath(m.Name, "team")
-			for _, entry := range hierarchyPath {
-				if strings.ToLower(entry.Type) == "org" && entry.Name == orgName {
-					return true
-				}
-			}
+		if m.Type == string(MembershipTeam) && s.hasOrgAncestor(m.Name, orgName) {
+			return true
 		}
 	}
 	return false
 }
 
-func (s *Service) IsSlackUserInOrg(slackID string, orgName string) bool {
+// hasOrgAncestor reports whether orgName appears among the ancestors of
+// teamName. Instead of tracking visited entities, the walk is bounded by the
+// total number of entities, which guarantees termination on cyclic data
+// without allocating. Must be called with s.mu held.
+func (s *Service) hasOrgAncestor(teamName, orgName string) bool {
+	team, ok := s.data.Lookups.Teams[teamName]
+	if !ok {
+		return false
+	}
+	lookups := &s.data.Lookups
+	limit := len(lookups.Teams) + len(lookups.Orgs) + len(lookups.Pillars) + len(lookups.TeamGroups)
+	parent := team.Parent
+	for i := 0; parent != nil && i < limit; i++ {
+		if parent.Name == orgName && strings.EqualFold(parent.Type, "org") {
+			return true
+		}
+		parent = s.getEntityParent(parent.Name, parent.Type)
+	}
+	return false
+}
+
+func (s *Service) IsSlackUserInOrg(slackID string, orgName string) (out bool) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("IsSlackUserInOrg", time.Now(), func() bool { return out }, "slack_id", slackID, "org_name", orgName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -444,7 +789,11 @@ func (s *Service) IsSlackUserInOrg(slackID string, orgName string) bool {
 	return s.isEmployeeInOrg(uid, orgName)
 }
 
-func (s *Service) GetUserOrganizations(slackUserID string) []OrgInfo {
+func (s *Service) GetUserOrganizations(slackUserID string) (out []OrgInfo) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetUserOrganizations", time.Now(), func() bool { return len(out) > 0 }, "slack_id", slackUserID)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -457,6 +806,15 @@ func (s *Service) GetUserOrganizations(slackUserID string) []OrgInfo {
 		return []OrgInfo{}
 	}
 
+	orgs := cachedQuery(s, queryKey{queryUserOrganizations, uid}, func() []OrgInfo {
+		return s.computeUserOrganizations(uid)
+	})
+	return slices.Clone(orgs)
+}
+
+// computeUserOrganizations resolves every organizational unit uid belongs to.
+// Must be called with s.mu held.
+func (s *Service) computeUserOrganizations(uid string) []OrgInfo {
 	var orgs []OrgInfo
 	seen := make(map[string]bool)
 
@@ -696,6 +1054,15 @@ func (s *Service) GetDescendantsTree(entityName string) *HierarchyNode {
 		return nil
 	}
 
+	tree := cachedQuery(s, queryKey{queryDescendantsTree, entityName}, func() *HierarchyNode {
+		return s.computeDescendantsTree(entityName, entityType)
+	})
+	return cloneHierarchyNode(tree)
+}
+
+// computeDescendantsTree builds the subtree rooted at entityName.
+// Must be called with s.mu held.
+func (s *Service) computeDescendantsTree(entityName, entityType string) *HierarchyNode {
 	// Build children map by scanning all entities
 	childrenMap := make(map[string][]struct{ name, typ string })
 
@@ -742,7 +1109,11 @@ func (s *Service) GetDescendantsTree(entityName string) *HierarchyNode {
 }
 
 // GetComponentByName returns a component by name.
-func (s *Service) GetComponentByName(name string) *Component {
+func (s *Service) GetComponentByName(name string) (out *Component) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetComponentByName", time.Now(), func() bool { return out != nil }, "name", name)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -750,6 +1121,7 @@ func (s *Service) GetComponentByName(name string) *Component {
 		return nil
 	}
 	if component, exists := s.data.Lookups.Components[name]; exists {
+		component = owned(s, component)
 		return &component
 	}
 	return nil
@@ -767,7 +1139,7 @@ func (s *Service) GetAllComponents() []Component {
 	for _, component := range s.data.Lookups.Components {
 		components = append(components, component)
 	}
-	return components
+	return ownedAll(s, components)
 }
 
 // GetAllComponentNames returns all component names.
@@ -891,7 +1263,11 @@ func (s *Service) GetJiraOwnershipForTeam(teamName string) []JiraOwnership {
 }
 
 // GetUserMemberships returns all memberships for a user.
-func (s *Service) GetUserMemberships(uid string) []MembershipInfo {
+func (s *Service) GetUserMemberships(uid string) (out []MembershipInfo) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetUserMemberships", time.Now(), func() bool { return len(out) > 0 }, "uid", uid)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -908,7 +1284,11 @@ func (s *Service) GetUserMemberships(uid string) []MembershipInfo {
 }
 
 // GetUserTeams returns team names for a user.
-func (s *Service) GetUserTeams(uid string) []string {
+func (s *Service) GetUserTeams(uid string) (out []string) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetUserTeams", time.Now(), func() bool { return len(out) > 0 }, "uid", uid)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -942,7 +1322,7 @@ func (s *Service) GetAllTeams() []Team {
 	for _, team := range s.data.Lookups.Teams {
 		teams = append(teams, team)
 	}
-	return teams
+	return ownedAll(s, teams)
 }
 
 // GetAllOrgs returns all organizations.
@@ -957,7 +1337,7 @@ func (s *Service) GetAllOrgs() []Org {
 	for _, org := range s.data.Lookups.Orgs {
 		orgs = append(orgs, org)
 	}
-	return orgs
+	return ownedAll(s, orgs)
 }
 
 // GetAllPillars returns all pillars.
@@ -972,7 +1352,7 @@ func (s *Service) GetAllPillars() []Pillar {
 	for _, pillar := range s.data.Lookups.Pillars {
 		pillars = append(pillars, pillar)
 	}
-	return pillars
+	return ownedAll(s, pillars)
 }
 
 // GetAllTeamGroups returns all team groups.
@@ -987,35 +1367,47 @@ func (s *Service) GetAllTeamGroups() []TeamGroup {
 	for _, tg := range s.data.Lookups.TeamGroups {
 		tgs = append(tgs, tg)
 	}
-	return tgs
+	return ownedAll(s, tgs)
 }
 
 // GetOrgMembers returns all members of an organization.
-func (s *Service) GetOrgMembers(orgName string) []Employee {
+func (s *Service) GetOrgMembers(orgName string) (out []Employee) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetOrgMembers", time.Now(), func() bool { return len(out) > 0 }, "org_name", orgName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if s.data == nil || s.data.Lookups.Orgs == nil {
 		return []Employee{}
 	}
+	orgName = resolveKey(s, s.data.Lookups.Orgs, "org", orgName)
 	org, exists := s.data.Lookups.Orgs[orgName]
 	if !exists {
 		return []Employee{}
 	}
-	var members []Employee
-	for _, uid := range org.Group.ResolvedPeopleUIDList {
-		if emp, exists := s.data.Lookups.Employees[uid]; exists {
-			members = append(members, emp)
+	members := cachedQuery(s, queryKey{queryOrgMembers, orgName}, func() []Employee {
+		var members []Employee
+		for _, uid := range org.Group.ResolvedPeopleUIDList {
+			if emp, exists := s.data.Lookups.Employees[uid]; exists {
+				members = append(members, emp)
+			}
 		}
-	}
-	if members == nil {
-		return []Employee{}
-	}
-	return members
+		if members == nil {
+			return []Employee{}
+		}
+		return members
+	})
+	return slices.Clone(members)
 }
 
 // GetTeamEscalation returns the escalation contacts for a team.
-func (s *Service) GetTeamEscalation(teamName string) []EscalationContactInfo {
+func (s *Service) GetTeamEscalation(teamName string) (out []EscalationContactInfo) {
+	if s.queryLog != nil {
+		defer s.queryLog.log("GetTeamEscalation", time.Now(), func() bool { return len(out) > 0 }, "team_name", teamName)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -1206,22 +1598,86 @@ func (s *Service) GetContextTypeDescriptions() map[string]string {
 	return result
 }
 
-// validateData checks that required data structures are present.
-func validateData(data *Data) error {
-	if data.Metadata.PIIFree {
-		if len(data.Lookups.Employees) > 0 {
-			return fmt.Errorf("%w: pii_free is set but lookups.employees is not empty", ErrInvalidData)
-		}
-		if len(data.Indexes.Membership.MembershipIndex) > 0 {
-			return fmt.Errorf("%w: pii_free is set but membership_index is not empty", ErrInvalidData)
-		}
+// checkShrink returns ErrDataShrunk if data has too many fewer employees than
+// the loaded data under WithMaxShrink.
+func (s *Service) checkShrink(data *Data) error {
+	if s.maxShrink == 0 {
 		return nil
 	}
-	if len(data.Lookups.Employees) == 0 {
-		return fmt.Errorf("%w: missing lookups.employees", ErrInvalidData)
+	s.mu.RLock()
+	current := s.version.EmployeeCount
+	s.mu.RUnlock()
+
+	next := len(data.Lookups.Employees)
+	if current == 0 || next >= current {
+		return nil
 	}
-	if len(data.Indexes.Membership.MembershipIndex) == 0 {
-		return fmt.Errorf("%w: missing indexes.membership.membership_index", ErrInvalidData)
+	if drop := float64(current-next) / float64(current); drop > s.maxShrink {
+		return fmt.Errorf("%w: employees would drop from %d to %d (%.0f%%), more than the %.0f%% allowed",
+			ErrDataShrunk, current, next, drop*100, s.maxShrink*100)
 	}
 	return nil
 }
+
+// repairData repairs freshly decoded data under WithRepair, recording the
+// repairs in attempt.
+func (s *Service) repairData(data *Data, attempt *loadAttempt) {
+	if !s.repair {
+		return
+	}
+	attempt.repairs = RepairData(data)
+	if n := len(attempt.repairs); n > 0 {
+		s.loggerFor(LogValidator).Warn("repaired data", "data_version", data.Metadata.DataVersion,
+			"repairs", n, "first", attempt.repairs[0].Message)
+	}
+}
+
+// validate runs the validation checks and the load validators on data, adding
+// the unknown fields found and the repairs made while fetching it in attempt
+// to the report. It returns the validation report even when data is rejected.
+func (s *Service) validate(data *Data, attempt *loadAttempt) (*ValidationReport, error) {
+	report := s.policy.run(data, s.sections)
+	report.Issues = append(report.Issues, s.policy.unknownFieldIssues(attempt.unknownFields)...)
+	if attempt.repairs != nil {
+		report.Repairs = attempt.repairs
+	}
+	attempt.validated(data, s.sections, report)
+	if err := s.policy.gate(report); err != nil {
+		return report, err
+	}
+	if issues := report.AtLeast(SeverityWarning); len(issues) > 0 {
+		s.loggerFor(LogValidator).Warn("data has validation issues", "data_version", report.DataVersion,
+			"issues", len(issues), "first", issues[0].Message)
+	}
+	if err := s.checkShrink(data); err != nil {
+		return report, err
+	}
+	for _, v := range s.validators {
+		if err := v(data); err != nil {
+			return report, fmt.Errorf("%w: %w", ErrLoadRejected, err)
+		}
+	}
+	return report, nil
+}
+
+// ValidateDataSource fetches, decodes, and validates the data from source as
+// LoadFromDataSource would, without replacing the loaded data, recording load
+// stats, or emitting events. The pipeline producing the data can use it as a
+// CI gate. The report is returned whenever the data could be decoded, and the
+// error is the one LoadFromDataSource would return.
+func (s *Service) ValidateDataSource(ctx context.Context, source DataSource) (*ValidationReport, error) {
+	attempt := newLoadAttempt(source.String(), s.clock)
+	data, err := s.fetchData(ctx, source, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyOverlays(ctx, data); err != nil {
+		return nil, err
+	}
+	s.repairData(data, attempt)
+	report, err := s.validate(data, attempt)
+	if err != nil {
+		return report, NewLoadError(source.String(), err)
+	}
+	return report, nil
+}
@@ -568,7 +568,7 @@ func TestStopWatcher(t *testing.T) {
 
 	// Verify the watcher flag was reset
 	service.mu.RLock()
-	running := service.watcherRunning
+	running := len(service.watchers) > 0
 	service.mu.RUnlock()
 
 	if running {
@@ -679,7 +679,7 @@ func TestWatcherStateClearsOnExit(t *testing.T) {
 
 	// Verify watcher is running
 	service.mu.RLock()
-	running := service.watcherRunning
+	running := len(service.watchers) > 0
 	service.mu.RUnlock()
 	if !running {
 		t.Error("Expected watcherRunning to be true while watcher is running")
@@ -698,7 +698,7 @@ func TestWatcherStateClearsOnExit(t *testing.T) {
 
 	// Verify watcher state is cleared
 	service.mu.RLock()
-	running = service.watcherRunning
+	running = len(service.watchers) > 0
 	service.mu.RUnlock()
 	if running {
 		t.Error("Expected watcherRunning to be false after watcher exits")
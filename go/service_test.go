@@ -2,8 +2,16 @@ package orgdatacore
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
 )
@@ -38,6 +46,44 @@ func TestNewService(t *testing.T) {
 	}
 }
 
+func TestWithOptions(t *testing.T) {
+	defaults := WithOptions(WithMaxDataAge(time.Hour), nil, WithQueryCache(8, 0))
+	service := NewService(defaults, WithMaxDataAge(2*time.Hour))
+	if service.maxDataAge != 2*time.Hour {
+		t.Errorf("maxDataAge = %v, want the later option's 2h", service.maxDataAge)
+	}
+	if service.queryCache == nil {
+		t.Error("query cache from the bundled options is not enabled")
+	}
+}
+
+func TestLoadFromBytes(t *testing.T) {
+	raw, err := os.ReadFile(filepath.Join("..", "testdata", "test_org_data.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	service := NewService()
+	if err := service.LoadFromBytes(ctx, raw, "embedded"); err != nil {
+		t.Fatalf("LoadFromBytes: %v", err)
+	}
+	if service.GetEmployeeByUID("jsmith") == nil {
+		t.Error("jsmith not loaded")
+	}
+	if err := service.Reload(ctx); !errors.Is(err, ErrNoDataSource) {
+		t.Errorf("Reload after LoadFromBytes = %v, want ErrNoDataSource", err)
+	}
+
+	err = service.LoadFromReader(ctx, strings.NewReader("{not json"), "request body")
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.Source != "request body" {
+		t.Errorf("LoadFromReader of invalid JSON = %v, want a LoadError from the request body", err)
+	}
+	if service.GetEmployeeByUID("jsmith") == nil {
+		t.Error("failed load replaced the loaded data")
+	}
+}
+
 // TestServiceInterface ensures Service implements ServiceInterface
 func TestServiceInterface(t *testing.T) {
 	var _ ServiceInterface = (*Service)(nil)
@@ -166,3 +212,242 @@ func TestGetVersion(t *testing.T) {
 		t.Errorf("Expected 2 orgs, got %d", version.OrgCount)
 	}
 }
+
+// TestSecondaryIndexesBuiltLazily verifies secondary indexes are not built at
+// load time and are rebuilt for each newly loaded dataset.
+func TestSecondaryIndexesBuiltLazily(t *testing.T) {
+	service := setupTestService(t)
+
+	idx := service.indexes.Load()
+	if idx == nil {
+		t.Fatal("expected index holder to be created on load")
+	}
+	if idx.email != nil || idx.slackChannel != nil || idx.manager != nil || idx.repo != nil {
+		t.Error("secondary indexes should not be built before first use")
+	}
+
+	if emp := service.GetEmployeeByEmail("adoe@example.com"); emp == nil {
+		t.Fatal("expected to find adoe by email")
+	}
+	if idx.email == nil {
+		t.Error("email index should be built after GetEmployeeByEmail")
+	}
+	if idx.manager != nil {
+		t.Error("manager index should still be unbuilt")
+	}
+
+	// Reloading discards previously built indexes.
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	if err := service.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(testDataPath)); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if reloaded := service.indexes.Load(); reloaded == idx || reloaded.email != nil {
+		t.Error("reload should replace the index holder with an unbuilt one")
+	}
+}
+
+// TestEagerIndexes verifies WithEagerIndexes builds the requested indexes during load
+func TestEagerIndexes(t *testing.T) {
+	service := NewService(WithEagerIndexes(IndexEmail, IndexManager, IndexRepo, IndexKind("bogus")))
+
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	if err := service.LoadFromDataSource(context.Background(), testingsupport.NewFileDataSource(testDataPath)); err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+
+	idx := service.indexes.Load()
+	if idx.email == nil || idx.manager == nil || idx.repo == nil {
+		t.Error("requested indexes should be built at load time")
+	}
+	if idx.slackChannel != nil {
+		t.Error("indexes not requested should remain lazy")
+	}
+	if got := idx.repo["example/test-repo"]; len(got) != 1 || got[0] != "test-team" {
+		t.Errorf("eager repo index = %v, want [test-team]", got)
+	}
+}
+
+// TestLoadValidator verifies WithLoadValidator can reject data before it is swapped in
+func TestLoadValidator(t *testing.T) {
+	testDataPath := filepath.Join("..", "testdata", "test_org_data.json")
+	ctx := context.Background()
+
+	t.Run("rejects load", func(t *testing.T) {
+		service := NewService(WithLoadValidator(func(d *Data) error {
+			if len(d.Lookups.Employees) < 10 {
+				return errors.New("too few employees")
+			}
+			return nil
+		}))
+		err := service.LoadFromDataSource(ctx, testingsupport.NewFileDataSource(testDataPath))
+		if !errors.Is(err, ErrLoadRejected) {
+			t.Fatalf("expected ErrLoadRejected, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "too few employees") {
+			t.Errorf("error should include the validator's reason, got %v", err)
+		}
+		if service.GetVersion().EmployeeCount != 0 {
+			t.Error("rejected data should not be installed")
+		}
+	})
+
+	t.Run("compares against previous load", func(t *testing.T) {
+		var service *Service
+		service = NewService(WithLoadValidator(func(d *Data) error {
+			prev := service.GetVersion().EmployeeCount
+			if prev > 0 && 2*len(d.Lookups.Employees) < prev {
+				return fmt.Errorf("employee count dropped from %d to %d", prev, len(d.Lookups.Employees))
+			}
+			return nil
+		}))
+		if err := service.LoadFromDataSource(ctx, testingsupport.NewFileDataSource(testDataPath)); err != nil {
+			t.Fatalf("initial load failed: %v", err)
+		}
+		err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"jsmith", "adoe"}})
+		if !errors.Is(err, ErrLoadRejected) {
+			t.Fatalf("expected ErrLoadRejected, got %v", err)
+		}
+		if service.GetEmployeeByUID("jsmith") == nil {
+			t.Error("previous data should remain after a rejected change set")
+		}
+		if err := service.ApplyChangeSet(ChangeSet{EmployeesRemoved: []string{"jsmith"}}); err != nil {
+			t.Errorf("small change should pass the validator: %v", err)
+		}
+	})
+
+	t.Run("runs in order after built-in checks", func(t *testing.T) {
+		var order []string
+		service := NewService(
+			WithLoadValidator(func(*Data) error { order = append(order, "first"); return nil }),
+			WithLoadValidator(nil),
+			WithLoadValidator(func(*Data) error { order = append(order, "second"); return nil }),
+		)
+		if err := service.LoadFromDataSource(ctx, NewFakeDataSource(`{"metadata": {}}`)); !errors.Is(err, ErrInvalidData) {
+			t.Fatalf("expected ErrInvalidData, got %v", err)
+		}
+		if len(order) != 0 {
+			t.Error("validators should not run when built-in checks fail")
+		}
+		if err := service.LoadFromDataSource(ctx, testingsupport.NewFileDataSource(testDataPath)); err != nil {
+			t.Fatalf("load failed: %v", err)
+		}
+		if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+			t.Errorf("validators ran as %v, want [first second]", order)
+		}
+	})
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+
+	if err := NewService().Reload(ctx); !errors.Is(err, ErrNoDataSource) {
+		t.Errorf("Reload() without a source = %v, want ErrNoDataSource", err)
+	}
+
+	raw, err := os.ReadFile("../testdata/test_org_data.json")
+	if err != nil {
+		t.Fatalf("read test data: %v", err)
+	}
+	source := NewFakeDataSource(string(raw))
+	service := NewService()
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	source.Data = strings.Replace(string(raw), `"test-abc123"`, `"test-abc124"`, 1)
+	if err := service.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := service.GetVersionHistory(); len(got) != 2 || got[1].DataVersion != "test-abc124" {
+		t.Errorf("after Reload, version history = %+v, want test-abc124 loaded", got)
+	}
+
+	source.LoadError = errors.New("unavailable")
+	if err := service.Reload(ctx); err == nil {
+		t.Error("expected Reload to return the load error")
+	}
+}
+
+func TestMustLoadFromDataSource(t *testing.T) {
+	ctx := context.Background()
+	service := NewService()
+	service.MustLoadFromDataSource(ctx, NewFakeDataSource(CreateTestDataJSON()))
+	if service.GetEmployeeByUID("testuser1") == nil {
+		t.Error("testuser1 not loaded")
+	}
+
+	source := NewFakeDataSource("")
+	source.LoadError = errors.New("unavailable")
+	defer func() {
+		err, _ := recover().(error)
+		if err == nil || !strings.Contains(err.Error(), "unavailable") {
+			t.Errorf("MustLoadFromDataSource panicked with %v, want the load error", err)
+		}
+	}()
+	NewService().MustLoadFromDataSource(ctx, source)
+}
+
+func TestDataVersionChecksum(t *testing.T) {
+	ctx := context.Background()
+	load := func(service *Service, data *Data) string {
+		t.Helper()
+		if err := service.LoadFromDataSource(ctx, jsonSource(t, "data", data)); err != nil {
+			t.Fatalf("LoadFromDataSource: %v", err)
+		}
+		return service.GetVersion().Checksum
+	}
+
+	first := load(NewService(), CreateTestData())
+	raw, err := json.Marshal(CreateTestData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum := sha256.Sum256(raw); first != hex.EncodeToString(sum[:]) {
+		t.Fatalf("Checksum = %q, want the payload's SHA-256 %x", first, sum)
+	}
+	if again := load(NewService(WithEagerIndexes()), CreateTestData()); again != first {
+		t.Errorf("Checksum of the same data = %q, want %q", again, first)
+	}
+
+	// Replicas applying the same change set to the same data agree.
+	cs := ChangeSet{DataVersion: "test-cs", EmployeesUpserted: map[string]Employee{"newhire": {UID: "newhire"}}}
+	var chained []string
+	for range 2 {
+		replica := NewService()
+		load(replica, CreateTestData())
+		if err := replica.ApplyChangeSet(cs); err != nil {
+			t.Fatalf("ApplyChangeSet: %v", err)
+		}
+		chained = append(chained, replica.GetVersion().Checksum)
+	}
+	if chained[0] != chained[1] || chained[0] == first || len(chained[0]) != 64 {
+		t.Errorf("Checksums after the same change set = %q, want equal, new hex SHA-256s", chained)
+	}
+
+	merged := func() string {
+		t.Helper()
+		replica := NewService()
+		source := NewMergedDataSource(nil, jsonSource(t, "base", CreateTestData()), jsonSource(t, "overrides", overridesData()))
+		if err := replica.LoadFromDataSource(ctx, source); err != nil {
+			t.Fatalf("LoadFromDataSource: %v", err)
+		}
+		return replica.GetVersion().Checksum
+	}
+	if a, b := merged(), merged(); a != b || a == first {
+		t.Errorf("Checksums of the same merged sources = %q and %q, want equal and unlike the base's", a, b)
+	}
+
+	service := NewService(WithSnapshotHistory(1))
+	load(service, CreateTestData())
+	changed := CreateTestData()
+	changed.Metadata.DataVersion = "test-changed"
+	if sum := load(service, changed); sum == first {
+		t.Error("Checksum did not change with the data")
+	}
+	if err := service.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if sum := service.GetVersion().Checksum; sum != first {
+		t.Errorf("Checksum after rollback = %q, want %q", sum, first)
+	}
+}
@@ -0,0 +1,147 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// SlackUsergroupMapping binds a Slack usergroup to the team, org, pillar or
+// team group whose members it should hold.
+type SlackUsergroupMapping struct {
+	// Handle is the usergroup's handle, such as "platform-team".
+	Handle string
+	// Name and Type identify the entity.
+	Name string
+	Type EntityType
+}
+
+// SlackUsergroupChange is what a sync job must do to one usergroup. Members
+// are Slack user IDs, sorted.
+type SlackUsergroupChange struct {
+	Handle string
+	// Create is set when the usergroup does not exist yet; Add then holds
+	// all of its members.
+	Create bool
+	Add    []string
+	Remove []string
+}
+
+// SlackUsergroupPlan lists the changes that bring Slack usergroups in line
+// with the org data.
+type SlackUsergroupPlan struct {
+	// Changes holds one entry per usergroup that needs changing, sorted by
+	// handle. Usergroups already in sync are left out.
+	Changes []SlackUsergroupChange
+	// MissingSlackIDs lists, by handle, the UIDs of members who cannot be
+	// added because they have no Slack ID.
+	MissingSlackIDs map[string][]string
+}
+
+// DesiredSlackUsergroups returns the Slack user IDs each mapped usergroup
+// should hold: the members of its entity that have Slack IDs, sorted. UIDs
+// of members without a Slack ID are returned by handle in missing. An entity
+// type other than a team, org, pillar or team group fails with a
+// *ConfigError, an unknown entity with ErrNotFound, and without loaded data
+// DesiredSlackUsergroups fails with ErrNoData.
+func (s *Service) DesiredSlackUsergroups(mappings []SlackUsergroupMapping) (desired, missing map[string][]string, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil, nil, ErrNoData
+	}
+	desired = make(map[string][]string, len(mappings))
+	missing = make(map[string][]string)
+	for _, m := range mappings {
+		switch m.Type {
+		case EntityTeam, EntityOrg, EntityPillar, EntityTeamGroup:
+		default:
+			return nil, nil, NewConfigError("SlackUsergroupMapping.Type",
+				fmt.Sprintf("usergroup %q maps to %q, not a team, org, pillar or team group", m.Handle, m.Type))
+		}
+		group := s.getEntityGroup(m.Name, string(m.Type))
+		if group == nil {
+			return nil, nil, NewNotFoundError(string(m.Type), m.Name)
+		}
+		ids := desired[m.Handle]
+		if ids == nil {
+			ids = []string{}
+		}
+		for _, uid := range group.ResolvedPeopleUIDList {
+			if emp, ok := s.data.Lookups.Employees[uid]; ok && emp.SlackUID != "" {
+				ids = append(ids, emp.SlackUID)
+			} else {
+				missing[m.Handle] = append(missing[m.Handle], uid)
+			}
+		}
+		slices.Sort(ids)
+		desired[m.Handle] = slices.Compact(ids)
+	}
+	for handle, uids := range missing {
+		slices.Sort(uids)
+		missing[handle] = slices.Compact(uids)
+	}
+	return desired, missing, nil
+}
+
+// DiffSlackUsergroups compares the desired members of each usergroup, by
+// handle, with its current members as reported by the Slack API, and
+// returns the changes sorted by handle. Usergroups in current but not in
+// desired are not managed by the mapping and are left alone.
+func DiffSlackUsergroups(desired, current map[string][]string) []SlackUsergroupChange {
+	changes := []SlackUsergroupChange{}
+	for handle, want := range desired {
+		have, exists := current[handle]
+		change := SlackUsergroupChange{Handle: handle, Create: !exists}
+		change.Add, change.Remove = diffMembers(want, have)
+		if change.Create || len(change.Add) > 0 || len(change.Remove) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b SlackUsergroupChange) int { return cmp.Compare(a.Handle, b.Handle) })
+	return changes
+}
+
+// PlanSlackUsergroups combines DesiredSlackUsergroups and
+// DiffSlackUsergroups: given each usergroup's current members by handle, it
+// returns the changes a sync job should apply. Note that Slack does not allow
+// an enabled usergroup without members, so a change removing every member
+// should be applied by disabling the usergroup.
+func (s *Service) PlanSlackUsergroups(mappings []SlackUsergroupMapping, current map[string][]string) (SlackUsergroupPlan, error) {
+	desired, missing, err := s.DesiredSlackUsergroups(mappings)
+	if err != nil {
+		return SlackUsergroupPlan{}, err
+	}
+	return SlackUsergroupPlan{
+		Changes:         DiffSlackUsergroups(desired, current),
+		MissingSlackIDs: missing,
+	}, nil
+}
+
+// diffMembers returns the sorted members of want missing from have, and of
+// have missing from want.
+func diffMembers(want, have []string) (add, remove []string) {
+	wantSet := make(map[string]bool, len(want))
+	for _, id := range want {
+		wantSet[id] = true
+	}
+	haveSet := make(map[string]bool, len(have))
+	for _, id := range have {
+		haveSet[id] = true
+	}
+	add, remove = []string{}, []string{}
+	for id := range wantSet {
+		if !haveSet[id] {
+			add = append(add, id)
+		}
+	}
+	for id := range haveSet {
+		if !wantSet[id] {
+			remove = append(remove, id)
+		}
+	}
+	slices.Sort(add)
+	slices.Sort(remove)
+	return add, remove
+}
@@ -0,0 +1,127 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDesiredSlackUsergroups(t *testing.T) {
+	service := setupTestService(t)
+
+	desired, missing, err := service.DesiredSlackUsergroups([]SlackUsergroupMapping{
+		{Handle: "test-team", Name: "test-team", Type: EntityTeam},
+		{Handle: "everyone", Name: "test-org", Type: EntityOrg},
+		{Handle: "engineering", Name: "engineering", Type: EntityPillar},
+	})
+	if err != nil {
+		t.Fatalf("DesiredSlackUsergroups: %v", err)
+	}
+	want := map[string][]string{
+		"test-team":   {"U12345678", "U87654321"},
+		"everyone":    {"U12345678", "U87654321", "U98765432"},
+		"engineering": {"U98765432"},
+	}
+	if !reflect.DeepEqual(desired, want) {
+		t.Errorf("desired = %v, want %v", desired, want)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing = %v, want none", missing)
+	}
+}
+
+func TestDesiredSlackUsergroupsErrors(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name    string
+		service *Service
+		mapping SlackUsergroupMapping
+		want    error
+	}{
+		{"no data", NewService(), SlackUsergroupMapping{Handle: "t", Name: "test-team", Type: EntityTeam}, ErrNoData},
+		{"unknown team", service, SlackUsergroupMapping{Handle: "t", Name: "nope", Type: EntityTeam}, ErrNotFound},
+		{"employee type", service, SlackUsergroupMapping{Handle: "t", Name: "jsmith", Type: EntityEmployee}, ErrInvalidConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.service.DesiredSlackUsergroups([]SlackUsergroupMapping{tt.mapping}); !errors.Is(err, tt.want) {
+				t.Errorf("DesiredSlackUsergroups = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffSlackUsergroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string][]string
+		current map[string][]string
+		want    []SlackUsergroupChange
+	}{
+		{
+			name:    "in sync",
+			desired: map[string][]string{"a": {"U1", "U2"}},
+			current: map[string][]string{"a": {"U2", "U1"}},
+			want:    []SlackUsergroupChange{},
+		},
+		{
+			name:    "add and remove",
+			desired: map[string][]string{"a": {"U1", "U3"}},
+			current: map[string][]string{"a": {"U1", "U2"}},
+			want:    []SlackUsergroupChange{{Handle: "a", Add: []string{"U3"}, Remove: []string{"U2"}}},
+		},
+		{
+			name:    "create",
+			desired: map[string][]string{"b": {"U2", "U1"}, "a": {}},
+			current: map[string][]string{},
+			want: []SlackUsergroupChange{
+				{Handle: "a", Create: true, Add: []string{}, Remove: []string{}},
+				{Handle: "b", Create: true, Add: []string{"U1", "U2"}, Remove: []string{}},
+			},
+		},
+		{
+			name:    "unmanaged usergroups left alone",
+			desired: map[string][]string{},
+			current: map[string][]string{"other": {"U9"}},
+			want:    []SlackUsergroupChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffSlackUsergroups(tt.desired, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffSlackUsergroups = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanSlackUsergroups(t *testing.T) {
+	data := CreateTestData()
+	emp := data.Lookups.Employees["testuser2"]
+	emp.SlackUID = ""
+	data.Lookups.Employees["testuser2"] = emp
+	delete(data.Indexes.SlackIDMappings.SlackUIDToUID, "U222222")
+	service := NewService()
+	if err := service.LoadFromDataSource(context.Background(), jsonSource(t, "slack", data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	plan, err := service.PlanSlackUsergroups(
+		[]SlackUsergroupMapping{{Handle: "squad", Name: "test-squad", Type: EntityTeam}},
+		map[string][]string{"squad": {"U111111", "U-gone"}},
+	)
+	if err != nil {
+		t.Fatalf("PlanSlackUsergroups: %v", err)
+	}
+	want := SlackUsergroupPlan{
+		Changes:         []SlackUsergroupChange{{Handle: "squad", Add: []string{}, Remove: []string{"U-gone"}}},
+		MissingSlackIDs: map[string][]string{"squad": {"testuser2"}},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("plan = %+v, want %+v", plan, want)
+	}
+}
@@ -0,0 +1,290 @@
+package orgdatacore
+
+import (
+	"context"
+	"time"
+)
+
+// Snapshot is a read-only view of the dataset a Service had loaded when
+// Snapshot was called. Its queries answer from that dataset even after the
+// service reloads, so a multi-step workflow, such as generating a report,
+// sees one consistent version throughout. Holding a Snapshot keeps its
+// dataset in memory.
+//
+// Snapshot implements ServiceInterface, so code written against a service
+// can run against one unchanged. It never loads data: LoadFromDataSource
+// and StartDataSourceWatcher fail with ErrSnapshotReadOnly and StopWatcher
+// does nothing. It is unrelated to the snapshots kept for Rollback.
+type Snapshot struct {
+	s *Service
+}
+
+var _ ServiceInterface = (*Snapshot)(nil)
+
+// Snapshot returns a Snapshot of the currently loaded dataset. Taking one
+// copies no data, since reloads replace the dataset rather than modify it.
+// A snapshot of a service with no data loaded answers every query like an
+// empty service.
+func (s *Service) Snapshot() *Snapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	frozen := &Service{
+		data:     s.data,
+		version:  s.version,
+		logger:   s.logger,
+		loggers:  s.loggers,
+		sections: s.sections,
+		clock:    s.clock,
+		queryLog: s.queryLog,
+
+		normalization:        s.normalization,
+		normalizationEnabled: s.normalizationEnabled,
+		copyPolicy:           s.copyPolicy,
+	}
+	if s.queryCache != nil {
+		frozen.queryCache = newQueryCache(s.queryCache.size, s.queryCache.ttl, s.clock)
+	}
+	if indexes := s.indexes.Load(); indexes != nil && indexes.data == s.data {
+		frozen.indexes.Store(indexes)
+	}
+	return &Snapshot{s: frozen}
+}
+
+func (v *Snapshot) LoadFromDataSource(context.Context, DataSource) error {
+	return ErrSnapshotReadOnly
+}
+
+func (v *Snapshot) StartDataSourceWatcher(context.Context, DataSource) error {
+	return ErrSnapshotReadOnly
+}
+
+func (v *Snapshot) StopWatcher() {}
+
+func (v *Snapshot) GetEmployeeByUID(uid string) *Employee {
+	return v.s.GetEmployeeByUID(uid)
+}
+
+func (v *Snapshot) GetEmployeeBySlackID(slackID string) *Employee {
+	return v.s.GetEmployeeBySlackID(slackID)
+}
+
+func (v *Snapshot) GetEmployeeByGitHubID(githubID string) *Employee {
+	return v.s.GetEmployeeByGitHubID(githubID)
+}
+
+func (v *Snapshot) GetEmployeeByEmail(email string) *Employee {
+	return v.s.GetEmployeeByEmail(email)
+}
+
+func (v *Snapshot) GetManagerForEmployee(uid string) *Employee {
+	return v.s.GetManagerForEmployee(uid)
+}
+
+func (v *Snapshot) GetTeamByName(teamName string) *Team {
+	return v.s.GetTeamByName(teamName)
+}
+
+func (v *Snapshot) GetTeamsBySlackChannel(channel string) []Team {
+	return v.s.GetTeamsBySlackChannel(channel)
+}
+
+func (v *Snapshot) GetOrgByName(orgName string) *Org {
+	return v.s.GetOrgByName(orgName)
+}
+
+func (v *Snapshot) GetPillarByName(pillarName string) *Pillar {
+	return v.s.GetPillarByName(pillarName)
+}
+
+func (v *Snapshot) GetTeamGroupByName(teamGroupName string) *TeamGroup {
+	return v.s.GetTeamGroupByName(teamGroupName)
+}
+
+func (v *Snapshot) GetUserMemberships(uid string) []MembershipInfo {
+	return v.s.GetUserMemberships(uid)
+}
+
+func (v *Snapshot) GetUserTeams(uid string) []string {
+	return v.s.GetUserTeams(uid)
+}
+
+func (v *Snapshot) GetTeamsForUID(uid string) []string {
+	return v.s.GetTeamsForUID(uid)
+}
+
+func (v *Snapshot) GetTeamsForSlackID(slackID string) []string {
+	return v.s.GetTeamsForSlackID(slackID)
+}
+
+func (v *Snapshot) GetTeamMembers(teamName string) []Employee {
+	return v.s.GetTeamMembers(teamName)
+}
+
+func (v *Snapshot) GetOrgMembers(orgName string) []Employee {
+	return v.s.GetOrgMembers(orgName)
+}
+
+func (v *Snapshot) IsEmployeeInTeam(uid string, teamName string) bool {
+	return v.s.IsEmployeeInTeam(uid, teamName)
+}
+
+func (v *Snapshot) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	return v.s.AreEmployeesInTeam(uids, teamName)
+}
+
+func (v *Snapshot) IsEmployeeInAnyTeam(uid string, teams []string) bool {
+	return v.s.IsEmployeeInAnyTeam(uid, teams)
+}
+
+func (v *Snapshot) IsSlackUserInTeam(slackID string, teamName string) bool {
+	return v.s.IsSlackUserInTeam(slackID, teamName)
+}
+
+func (v *Snapshot) IsEmployeeInOrg(uid string, orgName string) bool {
+	return v.s.IsEmployeeInOrg(uid, orgName)
+}
+
+func (v *Snapshot) IsSlackUserInOrg(slackID string, orgName string) bool {
+	return v.s.IsSlackUserInOrg(slackID, orgName)
+}
+
+func (v *Snapshot) GetUserOrganizations(slackUserID string) []OrgInfo {
+	return v.s.GetUserOrganizations(slackUserID)
+}
+
+func (v *Snapshot) GetTeamEscalation(teamName string) []EscalationContactInfo {
+	return v.s.GetTeamEscalation(teamName)
+}
+
+func (v *Snapshot) GetVersion() DataVersion {
+	return v.s.GetVersion()
+}
+
+func (v *Snapshot) GetDataAge() time.Duration {
+	return v.s.GetDataAge()
+}
+
+func (v *Snapshot) IsDataStale(maxAge time.Duration) bool {
+	return v.s.IsDataStale(maxAge)
+}
+
+func (v *Snapshot) GetAllEmployeeUIDs() []string {
+	return v.s.GetAllEmployeeUIDs()
+}
+
+func (v *Snapshot) GetAllEmployees() []Employee {
+	return v.s.GetAllEmployees()
+}
+
+func (v *Snapshot) GetAllTeamNames() []string {
+	return v.s.GetAllTeamNames()
+}
+
+func (v *Snapshot) GetAllTeams() []Team {
+	return v.s.GetAllTeams()
+}
+
+func (v *Snapshot) GetAllOrgNames() []string {
+	return v.s.GetAllOrgNames()
+}
+
+func (v *Snapshot) GetAllOrgs() []Org {
+	return v.s.GetAllOrgs()
+}
+
+func (v *Snapshot) GetAllPillarNames() []string {
+	return v.s.GetAllPillarNames()
+}
+
+func (v *Snapshot) GetAllPillars() []Pillar {
+	return v.s.GetAllPillars()
+}
+
+func (v *Snapshot) GetAllTeamGroupNames() []string {
+	return v.s.GetAllTeamGroupNames()
+}
+
+func (v *Snapshot) GetAllTeamGroups() []TeamGroup {
+	return v.s.GetAllTeamGroups()
+}
+
+func (v *Snapshot) GetHierarchyPath(entityName string, entityType string) []HierarchyPathEntry {
+	return v.s.GetHierarchyPath(entityName, entityType)
+}
+
+func (v *Snapshot) GetDescendantsTree(entityName string) *HierarchyNode {
+	return v.s.GetDescendantsTree(entityName)
+}
+
+func (v *Snapshot) FindPath(from, to GraphNode) []GraphEdge {
+	return v.s.FindPath(from, to)
+}
+
+func (v *Snapshot) Neighborhood(node GraphNode, hops int) []GraphEdge {
+	return v.s.Neighborhood(node, hops)
+}
+
+func (v *Snapshot) Search(query string, opts ...SearchOption) []SearchResult {
+	return v.s.Search(query, opts...)
+}
+
+func (v *Snapshot) GetComponentByName(name string) *Component {
+	return v.s.GetComponentByName(name)
+}
+
+func (v *Snapshot) GetAllComponents() []Component {
+	return v.s.GetAllComponents()
+}
+
+func (v *Snapshot) GetAllComponentNames() []string {
+	return v.s.GetAllComponentNames()
+}
+
+func (v *Snapshot) GetTeamsForComponent(componentName string) []ComponentOwnerInfo {
+	return v.s.GetTeamsForComponent(componentName)
+}
+
+func (v *Snapshot) GetComponentsForTeam(teamName string) []ComponentOwnership {
+	return v.s.GetComponentsForTeam(teamName)
+}
+
+func (v *Snapshot) GetJiraProjects() []string {
+	return v.s.GetJiraProjects()
+}
+
+func (v *Snapshot) GetJiraComponents(project string) []string {
+	return v.s.GetJiraComponents(project)
+}
+
+func (v *Snapshot) GetTeamsByJiraProject(project string) []JiraOwnerInfo {
+	return v.s.GetTeamsByJiraProject(project)
+}
+
+func (v *Snapshot) GetTeamsByJiraComponent(project, component string) []JiraOwnerInfo {
+	return v.s.GetTeamsByJiraComponent(project, component)
+}
+
+func (v *Snapshot) GetJiraOwnershipForTeam(teamName string) []JiraOwnership {
+	return v.s.GetJiraOwnershipForTeam(teamName)
+}
+
+func (v *Snapshot) GetContextForTeam(teamName string) []ContextItemInfo {
+	return v.s.GetContextForTeam(teamName)
+}
+
+func (v *Snapshot) GetContextForEntity(entityName string, entityType string) []ContextItemInfo {
+	return v.s.GetContextForEntity(entityName, entityType)
+}
+
+func (v *Snapshot) GetContextByType(entityName string, contextType string, entityType string) []ContextItemInfo {
+	return v.s.GetContextByType(entityName, contextType, entityType)
+}
+
+func (v *Snapshot) GetAllContextTypesForEntity(entityName string, entityType string) []string {
+	return v.s.GetAllContextTypesForEntity(entityName, entityType)
+}
+
+func (v *Snapshot) GetContextTypeDescriptions() map[string]string {
+	return v.s.GetContextTypeDescriptions()
+}
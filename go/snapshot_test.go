@@ -0,0 +1,60 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	service := NewService(WithQueryCache(16, 0))
+	ctx := context.Background()
+	if err := service.LoadFromDataSource(ctx, jsonSource(t, "v1", CreateTestData())); err != nil {
+		t.Fatal(err)
+	}
+
+	snap := service.Snapshot()
+	before := snap.GetVersion()
+	teams := snap.GetTeamsForUID("testuser2")
+
+	updated := CreateTestData()
+	updated.Metadata.DataVersion = "v2"
+	delete(updated.Lookups.Employees, "testuser2")
+	if err := service.LoadFromDataSource(ctx, jsonSource(t, "v2", updated)); err != nil {
+		t.Fatal(err)
+	}
+	if service.GetEmployeeByUID("testuser2") != nil {
+		t.Fatal("testuser2 still loaded in the service after the reload")
+	}
+
+	if snap.GetEmployeeByUID("testuser2") == nil {
+		t.Error("snapshot lost testuser2 when the service reloaded")
+	}
+	if got := snap.GetVersion(); !reflect.DeepEqual(got, before) {
+		t.Errorf("snapshot version changed from %+v to %+v", before, got)
+	}
+	if got := snap.GetTeamsForUID("testuser2"); !reflect.DeepEqual(got, teams) {
+		t.Errorf("GetTeamsForUID = %v, want %v", got, teams)
+	}
+	if got := service.Snapshot().GetVersion(); reflect.DeepEqual(got, before) {
+		t.Error("a new snapshot still has the old version")
+	}
+
+	if err := snap.LoadFromDataSource(ctx, jsonSource(t, "v3", CreateTestData())); !errors.Is(err, ErrSnapshotReadOnly) {
+		t.Errorf("LoadFromDataSource = %v, want ErrSnapshotReadOnly", err)
+	}
+	if err := snap.StartDataSourceWatcher(ctx, nil); !errors.Is(err, ErrSnapshotReadOnly) {
+		t.Errorf("StartDataSourceWatcher = %v, want ErrSnapshotReadOnly", err)
+	}
+}
+
+func TestSnapshotWithoutData(t *testing.T) {
+	snap := NewService().Snapshot()
+	if snap.GetEmployeeByUID("testuser1") != nil || len(snap.GetAllTeams()) != 0 {
+		t.Error("snapshot of an empty service returned data")
+	}
+	if !snap.IsDataStale(0) {
+		t.Error("snapshot of an empty service is not stale")
+	}
+}
@@ -0,0 +1,201 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// pushSnapshot keeps the current dataset, with its indexes, so Rollback can
+// restore it. Must be called with s.mu held for writing.
+func (s *Service) pushSnapshot() {
+	if s.snapshotLimit <= 0 || s.data == nil {
+		return
+	}
+	if len(s.snapshots) == s.snapshotLimit {
+		s.snapshots = append(s.snapshots[:0:0], s.snapshots[1:]...)
+	}
+	s.snapshots = append(s.snapshots, s.indexes.Load())
+}
+
+// Rollback reverts to the dataset that was loaded before the current one,
+// for when a bad dump slipped past validation. Snapshots are kept only with
+// WithSnapshotHistory; each call steps one snapshot further back, and the
+// data rolled back from is discarded. It returns ErrNoSnapshot when there is
+// nothing to roll back to.
+//
+// A running watcher will load the source again on its next change, so stop it
+// or fix the source first if the bad dump is still published.
+func (s *Service) Rollback() error {
+	s.mu.Lock()
+	n := len(s.snapshots)
+	if n == 0 {
+		s.mu.Unlock()
+		return ErrNoSnapshot
+	}
+	prev := s.snapshots[n-1]
+	s.snapshots = s.snapshots[:n-1]
+	bad := s.data.Metadata.DataVersion
+	ev := s.swapData(prev.data, prev)
+	s.mu.Unlock()
+
+	s.loggerFor(LogLoader).Warn("rolled back data", "from", bad, "to", prev.data.Metadata.DataVersion)
+	s.publishReload(ev)
+	s.persistLastKnownGood(ev)
+	return nil
+}
+
+// Snapshots returns the data_version of each snapshot Rollback can restore,
+// oldest first.
+func (s *Service) Snapshots() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := make([]string, 0, len(s.snapshots))
+	for _, snap := range s.snapshots {
+		versions = append(versions, snap.data.Metadata.DataVersion)
+	}
+	return versions
+}
+
+// lastKnownGoodWriter serializes the background writes of the
+// last-known-good file.
+type lastKnownGoodWriter struct {
+	mu      sync.Mutex // held while writing
+	pending sync.WaitGroup
+}
+
+// persistLastKnownGood writes the data installed by ev to the path set with
+// WithLastKnownGoodPath. The write runs in the background so loads do not
+// wait for the encoding, and it is skipped once newer data has been
+// installed, so the file never goes back to an older dataset when loads
+// race. The file is replaced atomically so a crash never leaves a truncated
+// copy. Failures are logged: the data is already loaded and keeps being
+// served.
+func (s *Service) persistLastKnownGood(ev reloadEvent) {
+	if s.lastKnownGoodPath == "" {
+		return
+	}
+	s.lastKnownGood.pending.Add(1)
+	go func() {
+		defer s.lastKnownGood.pending.Done()
+		s.lastKnownGood.mu.Lock()
+		defer s.lastKnownGood.mu.Unlock()
+
+		s.mu.RLock()
+		current := s.generation
+		s.mu.RUnlock()
+		if ev.generation != current {
+			return
+		}
+		file := lastKnownGoodFile[*Data]{
+			Checksum: ev.newVersion.Checksum,
+			Sections: s.sections.list(),
+			Overlays: ev.newData.overlays,
+			Data:     ev.newData,
+		}
+		if err := writeFileAtomic(s.lastKnownGoodPath, file); err != nil {
+			s.loggerFor(LogLoader).Error("failed to persist last-known-good data", "path", s.lastKnownGoodPath, "error", err)
+		}
+	}()
+}
+
+func writeFileAtomic(path string, v any) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(v); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ExportDump returns the loaded dataset encoded as a JSON data dump, which
+// LoadFromDataSource accepts, along with the version it was loaded as. The
+// dump is encoded afresh, so a service loading it reports the dump's SHA-256
+// as its Checksum rather than the version's. Without loaded data ExportDump
+// fails with ErrNoData.
+func (s *Service) ExportDump() ([]byte, DataVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil, DataVersion{}, ErrNoData
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(s.data); err != nil {
+		return nil, DataVersion{}, err
+	}
+	return buf.Bytes(), s.version, nil
+}
+
+// LoadLastKnownGood loads the data persisted with WithLastKnownGoodPath. Call
+// it at startup when the primary source is unavailable, so the service can
+// serve the last data it validated instead of none. The data is restored with
+// the overlay patches that were in effect when it was written, which are not
+// read again. It returns ErrNoSnapshot if no path is configured, nothing has
+// been persisted yet, or the file lacks sections this service loads because
+// it was written under narrower WithSections.
+func (s *Service) LoadLastKnownGood(ctx context.Context) error {
+	if s.lastKnownGoodPath == "" {
+		return fmt.Errorf("%w: no last-known-good path configured", ErrNoSnapshot)
+	}
+	if _, err := os.Stat(s.lastKnownGoodPath); os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s does not exist", ErrNoSnapshot, s.lastKnownGoodPath)
+	}
+
+	source := "last-known-good:" + s.lastKnownGoodPath
+	attempt := s.startLoad(source)
+	err := s.loadLastKnownGood(source, attempt)
+	s.finishLoad(attempt, err)
+	return err
+}
+
+func (s *Service) loadLastKnownGood(source string, attempt *loadAttempt) error {
+	start := time.Now()
+	f, err := os.Open(s.lastKnownGoodPath)
+	if err != nil {
+		return NewLoadError(source, err)
+	}
+	defer f.Close()
+
+	var file lastKnownGoodFile[json.RawMessage]
+	if err := json.NewDecoder(attempt.reader(f)).Decode(&file); err != nil {
+		return NewLoadError(source, fmt.Errorf("failed to parse JSON: %w", err))
+	}
+	if missing := s.sections.missingFrom(file.Sections); len(missing) > 0 {
+		return fmt.Errorf("%w: %s lacks sections %v", ErrNoSnapshot, s.lastKnownGoodPath, missing)
+	}
+	data, err := decodeData(bytes.NewReader(file.Data), s.sections)
+	if err != nil {
+		return NewLoadError(source, fmt.Errorf("failed to parse JSON: %w", err))
+	}
+	data.overlays = file.Overlays
+	start = timePhase(&attempt.phases.Decode, start)
+	return s.installFetched(source, data, file.Checksum, attempt, start)
+}
+
+// lastKnownGoodFile is the format of the file written for
+// WithLastKnownGoodPath. D is *Data when writing and json.RawMessage when
+// reading, so the data can be decoded with the loading service's sections.
+type lastKnownGoodFile[D any] struct {
+	// Checksum is the DataVersion.Checksum of the data when it was loaded.
+	Checksum string `json:"checksum"`
+	// Sections are the sections Data holds; empty means every section.
+	Sections []Section `json:"sections,omitempty"`
+	// Overlays are the patches already applied to Data.
+	Overlays []AppliedPatch `json:"overlays,omitempty"`
+	Data     D              `json:"data"`
+}
@@ -0,0 +1,214 @@
+package orgdatacore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestRollback(t *testing.T) {
+	service := NewService(WithSnapshotHistory(2))
+	if err := service.Rollback(); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Rollback() with no data = %v, want ErrNoSnapshot", err)
+	}
+
+	ctx := context.Background()
+	for _, version := range []string{"v1", "v2", "v3", "v4"} {
+		data := CreateTestData()
+		data.Metadata.DataVersion = version
+		if version == "v4" {
+			delete(data.Lookups.Employees, "testuser2")
+		}
+		if err := service.LoadFromDataSource(ctx, jsonSource(t, version, data)); err != nil {
+			t.Fatalf("load %s: %v", version, err)
+		}
+	}
+	if got := service.Snapshots(); !reflect.DeepEqual(got, []string{"v2", "v3"}) {
+		t.Errorf("Snapshots() = %v, want [v2 v3]", got)
+	}
+
+	var reloaded []string
+	service.OnReloadChanges(func(_, _ DataVersion, changes *ChangeSet) {
+		reloaded = append(reloaded, changes.DataVersion)
+	})
+
+	if err := service.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if service.GetEmployeeByUID("testuser2") == nil {
+		t.Error("employee removed by the bad dump is still missing after rollback")
+	}
+	if err := service.Rollback(); err != nil {
+		t.Fatalf("second Rollback: %v", err)
+	}
+	if err := service.Rollback(); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Rollback() past the history = %v, want ErrNoSnapshot", err)
+	}
+	if !reflect.DeepEqual(reloaded, []string{"v3", "v2"}) {
+		t.Errorf("reload subscribers saw %v, want [v3 v2]", reloaded)
+	}
+
+	// Snapshots are off by default.
+	service = setupTestService(t)
+	if err := service.ApplyChangeSet(ChangeSet{DataVersion: "next"}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if err := service.Rollback(); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("Rollback() without WithSnapshotHistory = %v, want ErrNoSnapshot", err)
+	}
+}
+
+func TestLastKnownGood(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orgdata.json")
+
+	service := NewService(WithLastKnownGoodPath(path))
+	if err := service.LoadLastKnownGood(ctx); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("LoadLastKnownGood() before anything was persisted = %v, want ErrNoSnapshot", err)
+	}
+	if err := service.LoadFromDataSource(ctx, NewFakeDataSource(CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if err := service.ApplyChangeSet(ChangeSet{
+		DataVersion:       "test-v1.1",
+		EmployeesUpserted: map[string]Employee{"newhire": {UID: "newhire", SlackUID: "U444444"}},
+	}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+
+	// A failed load does not overwrite the persisted copy.
+	bad := NewFakeDataSource(`{"metadata": {}}`)
+	if err := service.LoadFromDataSource(ctx, bad); err == nil {
+		t.Fatal("expected invalid data to be rejected")
+	}
+
+	service.lastKnownGood.pending.Wait()
+	restarted := NewService(WithLastKnownGoodPath(path))
+	if err := restarted.LoadLastKnownGood(ctx); err != nil {
+		t.Fatalf("LoadLastKnownGood: %v", err)
+	}
+	if emp := restarted.GetEmployeeBySlackID("U444444"); emp == nil || emp.UID != "newhire" {
+		t.Errorf("restored data is missing the change set: GetEmployeeBySlackID = %v", emp)
+	}
+	if got := restarted.GetVersionHistory(); len(got) != 1 || got[0].DataVersion != "test-v1.1" {
+		t.Errorf("version history after restore = %+v", got)
+	}
+
+	if err := NewService().LoadLastKnownGood(ctx); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("LoadLastKnownGood() without a path = %v, want ErrNoSnapshot", err)
+	}
+}
+
+func TestLastKnownGoodOverlays(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orgdata.json")
+	service := NewService(WithLastKnownGoodPath(path), WithOverlay(NewFakeDataSource(testOverlay)))
+	source := testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	service.lastKnownGood.pending.Wait()
+
+	// The overlay is not read again: an unreadable one would fail the load.
+	restarted := NewService(WithLastKnownGoodPath(path), WithOverlay(NewFakeDataSource("patches: [")))
+	if err := restarted.LoadLastKnownGood(ctx); err != nil {
+		t.Fatalf("LoadLastKnownGood: %v", err)
+	}
+	if mgr := restarted.GetManagerForEmployee("jsmith"); mgr == nil || mgr.UID != "bwilson" {
+		t.Errorf("GetManagerForEmployee(jsmith) = %v, want the patched manager bwilson", mgr)
+	}
+	if got, want := restarted.AllOverlayPatches(), service.AllOverlayPatches(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllOverlayPatches() after restore = %+v, want %+v", got, want)
+	}
+	if got, want := restarted.GetVersion().Checksum, service.GetVersion().Checksum; got != want {
+		t.Errorf("Checksum after restore = %s, want %s", got, want)
+	}
+}
+
+func TestLastKnownGoodSections(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orgdata.json")
+	sections := WithSections(SectionEmployees, SectionSlackIDs)
+	service := NewService(WithLastKnownGoodPath(path), sections)
+	if err := service.LoadFromDataSource(ctx, NewFakeDataSource(CreateTestDataJSON())); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	service.lastKnownGood.pending.Wait()
+
+	if err := NewService(WithLastKnownGoodPath(path)).LoadLastKnownGood(ctx); !errors.Is(err, ErrNoSnapshot) {
+		t.Errorf("LoadLastKnownGood() needing every section = %v, want ErrNoSnapshot", err)
+	}
+	narrow := NewService(WithLastKnownGoodPath(path), WithSections(SectionEmployees))
+	if err := narrow.LoadLastKnownGood(ctx); err != nil {
+		t.Fatalf("LoadLastKnownGood with fewer sections: %v", err)
+	}
+	if emp := narrow.GetEmployeeByUID("testuser1"); emp == nil {
+		t.Error("GetEmployeeByUID(testuser1) = nil after restore")
+	}
+}
+
+func TestLastKnownGoodConcurrentLoads(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orgdata.json")
+	service := NewService(WithLastKnownGoodPath(path))
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data := CreateTestData()
+			data.Metadata.DataVersion = fmt.Sprintf("v%d", i)
+			if err := service.LoadFromDataSource(ctx, jsonSource(t, data.Metadata.DataVersion, data)); err != nil {
+				t.Errorf("load v%d: %v", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	service.lastKnownGood.pending.Wait()
+
+	restarted := NewService(WithLastKnownGoodPath(path))
+	if err := restarted.LoadLastKnownGood(ctx); err != nil {
+		t.Fatalf("LoadLastKnownGood: %v", err)
+	}
+	want := service.data.Metadata.DataVersion
+	if got := restarted.data.Metadata.DataVersion; got != want {
+		t.Errorf("persisted data_version = %s, want the last installed %s", got, want)
+	}
+}
+
+func TestExportDump(t *testing.T) {
+	ctx := context.Background()
+	if _, _, err := NewService().ExportDump(); !errors.Is(err, ErrNoData) {
+		t.Errorf("ExportDump() without data = %v, want ErrNoData", err)
+	}
+
+	service := setupTestService(t)
+	dump, version, err := service.ExportDump()
+	if err != nil {
+		t.Fatalf("ExportDump: %v", err)
+	}
+	if version.Checksum != service.GetVersion().Checksum {
+		t.Errorf("ExportDump version checksum = %s, want the loaded %s", version.Checksum, service.GetVersion().Checksum)
+	}
+
+	reloaded := NewService()
+	if err := reloaded.LoadFromDataSource(ctx, NewFakeDataSource(string(dump))); err != nil {
+		t.Fatalf("LoadFromDataSource(dump): %v", err)
+	}
+	sum := sha256.Sum256(dump)
+	if got := reloaded.GetVersion().Checksum; got != hex.EncodeToString(sum[:]) {
+		t.Errorf("reloaded checksum = %s, want the dump's SHA-256 %x", got, sum)
+	}
+	if again, _, _ := reloaded.ExportDump(); string(again) != string(dump) {
+		t.Error("exporting the reloaded dump gave a different dump")
+	}
+}
@@ -0,0 +1,132 @@
+package orgdatacore
+
+import "strings"
+
+// Statistics summarizes the size and shape of the loaded data, for admin
+// dashboards and command-line tools.
+type Statistics struct {
+	DataVersion string `json:"data_version"`
+
+	Employees  int `json:"employees"`
+	Teams      int `json:"teams"`
+	Orgs       int `json:"orgs"`
+	Pillars    int `json:"pillars"`
+	TeamGroups int `json:"team_groups"`
+	Components int `json:"components"`
+
+	// MembershipEdges counts the employee-to-group entries in the membership
+	// index, which also covers memberships inherited through the hierarchy.
+	MembershipEdges int `json:"membership_edges"`
+	// TeamMemberEdges counts the members listed across all teams.
+	TeamMemberEdges int `json:"team_member_edges"`
+
+	// ManagementDepth is the length of the longest manager chain, counting
+	// the employee at its bottom.
+	ManagementDepth int `json:"management_depth"`
+	// HierarchyDepth is the length of the longest GetHierarchyPath, from a
+	// team, org, pillar or team group up to its root.
+	HierarchyDepth int `json:"hierarchy_depth"`
+
+	// IndexSizes maps each lookup index to its number of keys.
+	IndexSizes map[string]int `json:"index_sizes"`
+}
+
+// GetStatistics returns entity counts, membership edge counts, hierarchy
+// depths and index sizes for the loaded data. Secondary indexes that have
+// not been used yet are built to be counted. Without data it returns zero
+// counts.
+func (s *Service) GetStatistics() Statistics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st := Statistics{IndexSizes: map[string]int{}}
+	if s.data == nil {
+		return st
+	}
+
+	lookups := &s.data.Lookups
+	indexes := &s.data.Indexes
+	st.DataVersion = s.data.Metadata.DataVersion
+	st.Employees = len(lookups.Employees)
+	st.Teams = len(lookups.Teams)
+	st.Orgs = len(lookups.Orgs)
+	st.Pillars = len(lookups.Pillars)
+	st.TeamGroups = len(lookups.TeamGroups)
+	st.Components = len(lookups.Components)
+
+	for _, memberships := range indexes.Membership.MembershipIndex {
+		st.MembershipEdges += len(memberships)
+	}
+	for _, team := range lookups.Teams {
+		st.TeamMemberEdges += len(team.Group.ResolvedPeopleUIDList)
+	}
+
+	st.ManagementDepth = longestChain(lookups.Employees, func(uid string) (string, bool) {
+		manager := lookups.Employees[uid].ManagerUID
+		_, ok := lookups.Employees[manager]
+		return manager, ok
+	})
+
+	type entity struct{ name, typ string }
+	entities := make(map[entity]struct{}, st.Teams+st.Orgs+st.Pillars+st.TeamGroups)
+	for name := range lookups.Teams {
+		entities[entity{name, "team"}] = struct{}{}
+	}
+	for name := range lookups.Orgs {
+		entities[entity{name, "org"}] = struct{}{}
+	}
+	for name := range lookups.Pillars {
+		entities[entity{name, "pillar"}] = struct{}{}
+	}
+	for name := range lookups.TeamGroups {
+		entities[entity{name, "team_group"}] = struct{}{}
+	}
+	// A parent that is not itself an entity still counts as a level, as it
+	// does in GetHierarchyPath.
+	st.HierarchyDepth = longestChain(entities, func(e entity) (entity, bool) {
+		parent := s.getEntityParent(e.name, e.typ)
+		if parent == nil {
+			return entity{}, false
+		}
+		return entity{parent.Name, strings.ToLower(parent.Type)}, true
+	})
+
+	derived := s.derived()
+	st.IndexSizes["membership"] = len(indexes.Membership.MembershipIndex)
+	st.IndexSizes["slack_id"] = len(indexes.SlackIDMappings.SlackUIDToUID)
+	st.IndexSizes["github_id"] = len(indexes.GitHubIDMappings.GitHubIDToUID)
+	st.IndexSizes["component_ownership"] = len(indexes.ComponentOwnership)
+	st.IndexSizes["email"] = len(derived.emailIndex())
+	st.IndexSizes["manager"] = len(derived.managerIndex())
+	st.IndexSizes["slack_channel"] = len(derived.slackChannelIndex())
+	st.IndexSizes["repo"] = len(derived.repoIndex())
+	return st
+}
+
+// longestChain returns the length of the longest chain of parent links
+// starting from any key of nodes. parent reports a node's parent, if it has
+// one. A cycle is cut where it closes, so cyclic data still terminates.
+func longestChain[K comparable, V any](nodes map[K]V, parent func(K) (K, bool)) int {
+	const visiting = -1
+	depth := make(map[K]int, len(nodes))
+
+	var walk func(K) int
+	walk = func(n K) int {
+		if d, ok := depth[n]; ok {
+			return max(d, 0)
+		}
+		depth[n] = visiting
+		d := 1
+		if p, ok := parent(n); ok {
+			d += walk(p)
+		}
+		depth[n] = d
+		return d
+	}
+
+	longest := 0
+	for n := range nodes {
+		longest = max(longest, walk(n))
+	}
+	return longest
+}
@@ -0,0 +1,68 @@
+package orgdatacore
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetStatistics(t *testing.T) {
+	if st := NewService().GetStatistics(); st.Employees != 0 || st.IndexSizes == nil {
+		t.Errorf("GetStatistics() without data = %+v, want zero counts", st)
+	}
+
+	st := setupTestService(t).GetStatistics()
+	want := Statistics{
+		DataVersion:     "test-abc123",
+		Employees:       3,
+		Teams:           2,
+		Orgs:            2,
+		Pillars:         1,
+		TeamGroups:      1,
+		Components:      2,
+		MembershipEdges: 7,
+		TeamMemberEdges: 3,
+		// jsmith -> adoe
+		ManagementDepth: 2,
+		// platform-team -> backend-teams -> engineering -> platform-org -> test-org
+		HierarchyDepth: 5,
+		IndexSizes:     st.IndexSizes,
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("GetStatistics() = %+v\nwant %+v", st, want)
+	}
+	for _, index := range []string{"membership", "slack_id", "github_id", "email", "manager"} {
+		if st.IndexSizes[index] == 0 {
+			t.Errorf("IndexSizes[%q] = 0, want entries", index)
+		}
+	}
+	if st.IndexSizes["email"] != 3 || st.IndexSizes["manager"] != 1 {
+		t.Errorf("IndexSizes = %v, want 3 emails and 1 manager", st.IndexSizes)
+	}
+}
+
+func TestLongestChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		parents map[string]string
+		want    int
+	}{
+		{"empty", map[string]string{}, 0},
+		{"roots", map[string]string{"a": "", "b": ""}, 1},
+		{"chain", map[string]string{"a": "b", "b": "c", "c": "", "d": "c"}, 3},
+		{"dangling parent", map[string]string{"a": "missing"}, 2},
+		{"cycle", map[string]string{"a": "b", "b": "c", "c": "a"}, 3},
+		{"self parent", map[string]string{"a": "a"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := longestChain(tt.parents, func(n string) (string, bool) {
+				p := tt.parents[n]
+				return p, p != ""
+			})
+			if got != tt.want {
+				t.Errorf("longestChain() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,327 @@
+package orgdatacore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxVersionHistory is the number of successful loads GetVersionHistory keeps.
+const maxVersionHistory = 20
+
+// LoadStats counts the load attempts made by a Service, whether by
+// LoadFromDataSource, a watcher, or ApplyChangeSet. Alert on Failures or
+// ConsecutiveFailures to catch reloads that fail silently behind stale data.
+type LoadStats struct {
+	Attempts  uint64 `json:"attempts"`
+	Successes uint64 `json:"successes"`
+	Failures  uint64 `json:"failures"`
+	// ConsecutiveFailures counts failed attempts since the last success.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	// LastAttempt, LastSuccess and LastFailure are read from the service's
+	// Clock; durations are measured on the real clock.
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success"`
+	LastFailure time.Time `json:"last_failure"`
+	LastError   string    `json:"last_error,omitempty"`
+
+	// LastDuration and TotalDuration cover every attempt, successful or not.
+	LastDuration  time.Duration `json:"last_duration"`
+	TotalDuration time.Duration `json:"total_duration"`
+	// LastPayloadBytes and TotalPayloadBytes count bytes read from sources.
+	// Change sets are not counted.
+	LastPayloadBytes  int64 `json:"last_payload_bytes"`
+	TotalPayloadBytes int64 `json:"total_payload_bytes"`
+	// LastPhases and TotalPhases split the durations by load phase.
+	LastPhases  LoadPhases `json:"last_phases"`
+	TotalPhases LoadPhases `json:"total_phases"`
+}
+
+// LoadPhases breaks a load's duration down by phase, to tell whether slow
+// reloads are network-bound (Download) or CPU-bound (Decode, Validate, Index).
+// A phase a failed load did not reach is zero. Reload callbacks are not
+// counted.
+type LoadPhases struct {
+	// Download is the time spent in the DataSource: opening the payload and
+	// reading it. For a MergedDataSource it also covers decoding the parts,
+	// which are fetched concurrently; for change sets it is LoadChanges.
+	Download time.Duration `json:"download"`
+	// Decode is the time spent parsing the payload, or applying a change set.
+	Decode   time.Duration `json:"decode"`
+	Validate time.Duration `json:"validate"`
+	// Index is the time spent building the derived indexes.
+	Index time.Duration `json:"index"`
+	// Swap is the time spent waiting for the write lock and installing the
+	// new data.
+	Swap time.Duration `json:"swap"`
+}
+
+func (p *LoadPhases) add(q LoadPhases) {
+	p.Download += q.Download
+	p.Decode += q.Decode
+	p.Validate += q.Validate
+	p.Index += q.Index
+	p.Swap += q.Swap
+}
+
+// VersionRecord describes one successful load.
+type VersionRecord struct {
+	Version DataVersion `json:"version"`
+	// DataVersion is the data_version from the loaded metadata.
+	DataVersion string `json:"data_version"`
+	// Source is the DataSource the data came from, "changeset" for change
+	// sets applied directly with ApplyChangeSet.
+	Source string `json:"source,omitempty"`
+	// Incremental is set when the load applied a change set.
+	Incremental  bool          `json:"incremental"`
+	Duration     time.Duration `json:"duration"`
+	PayloadBytes int64         `json:"payload_bytes"`
+	Phases       LoadPhases    `json:"phases"`
+}
+
+// LoadReport describes what a load attempt did, successful or not.
+type LoadReport struct {
+	// Source is the DataSource loaded from, "changeset" for change sets
+	// applied directly with ApplyChangeSet.
+	Source string `json:"source,omitempty"`
+	// DataVersion is the data_version of the loaded data, empty if the
+	// attempt failed before decoding it.
+	DataVersion  string        `json:"data_version,omitempty"`
+	Started      time.Time     `json:"started"`
+	Duration     time.Duration `json:"duration"`
+	PayloadBytes int64         `json:"payload_bytes"`
+	Phases       LoadPhases    `json:"phases"`
+	// Installed is set when the attempt replaced the loaded data.
+	Installed bool   `json:"installed"`
+	Error     string `json:"error,omitempty"`
+	// Sections counts the entries in each loaded section of the data, such
+	// as employees or Jira projects.
+	Sections map[Section]int `json:"sections"`
+	// Validation lists the validation issues and repairs, nil if the
+	// attempt failed before validating the data.
+	Validation *ValidationReport `json:"validation,omitempty"`
+}
+
+// loadStats records load attempts. It has its own lock so recording never
+// contends with queries.
+type loadStats struct {
+	mu      sync.Mutex
+	stats   LoadStats
+	history []VersionRecord
+	last    *LoadReport
+}
+
+// loadAttempt tracks one load while it runs.
+type loadAttempt struct {
+	source string
+	clock  Clock
+	// start times the attempt on the real clock; started is when it began
+	// on the service's clock.
+	start     time.Time
+	started   time.Time
+	bytes     int64
+	hash      hash.Hash // of the payload read; see checksum
+	phases    LoadPhases
+	installed *VersionRecord
+
+	// unknownFields are the fields found under WithUnknownFieldCheck.
+	unknownFields []UnknownField
+	// repairs are the repairs made under WithRepair.
+	repairs []Repair
+	// dataVersion, sections and validation describe the validated data.
+	dataVersion string
+	sections    map[Section]int
+	validation  *ValidationReport
+}
+
+func newLoadAttempt(source string, clock Clock) *loadAttempt {
+	return &loadAttempt{source: source, clock: clock, start: time.Now(), started: clock.Now(), hash: sha256.New()}
+}
+
+// install records that the attempt swapped in new data. It is called before
+// reload callbacks run so their time is not counted as load time.
+func (a *loadAttempt) install(ev reloadEvent, incremental bool) {
+	a.installed = &VersionRecord{
+		Version:      ev.newVersion,
+		DataVersion:  ev.newData.Metadata.DataVersion,
+		Source:       a.source,
+		Incremental:  incremental,
+		Duration:     time.Since(a.start),
+		PayloadBytes: a.bytes,
+		Phases:       a.phases,
+	}
+}
+
+// reader wraps r so the bytes read from it count toward the payload size and
+// the checksum, and the time spent reading toward the download phase.
+func (a *loadAttempt) reader(r io.Reader) io.Reader {
+	return &countingReader{r: r, n: &a.bytes, d: &a.phases.Download, h: a.hash}
+}
+
+// checksum returns the DataVersion.Checksum of data loaded by the attempt:
+// the SHA-256 of the payload read, followed by the overlay patches applied
+// to data, if any, encoded as JSON.
+func (a *loadAttempt) checksum(data *Data) string {
+	if len(data.overlays) > 0 {
+		if err := json.NewEncoder(a.hash).Encode(data.overlays); err != nil {
+			return ""
+		}
+	}
+	return hex.EncodeToString(a.hash.Sum(nil))
+}
+
+// validated records the data validated during the attempt and its report.
+func (a *loadAttempt) validated(data *Data, loaded sectionSet, report *ValidationReport) {
+	a.dataVersion = data.Metadata.DataVersion
+	a.sections = make(map[Section]int)
+	for _, sec := range allSections {
+		if loaded.has(sec) {
+			_, a.sections[sec] = sectionSize(data, sec)
+		}
+	}
+	a.validation = report
+}
+
+// report describes the attempt once it is over.
+func (a *loadAttempt) report(duration time.Duration, err error) *LoadReport {
+	r := &LoadReport{
+		Source:       a.source,
+		DataVersion:  a.dataVersion,
+		Started:      a.started,
+		Duration:     duration,
+		PayloadBytes: a.bytes,
+		Phases:       a.phases,
+		Installed:    a.installed != nil,
+		Sections:     a.sections,
+		Validation:   a.validation,
+	}
+	if r.Sections == nil {
+		r.Sections = map[Section]int{}
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// timePhase adds the time since start to phase and returns the current time,
+// so consecutive phases can be timed in sequence.
+func timePhase(phase *time.Duration, start time.Time) time.Time {
+	now := time.Now()
+	*phase += now.Sub(start)
+	return now
+}
+
+// record adds the outcome of a to the stats.
+func (l *loadStats) record(a *loadAttempt, err error) {
+	duration := time.Since(a.start)
+	if a.installed != nil {
+		duration = a.installed.Duration
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	st := &l.stats
+	st.Attempts++
+	st.LastAttempt = a.started
+	st.LastDuration = duration
+	st.TotalDuration += duration
+	st.LastPayloadBytes = a.bytes
+	st.TotalPayloadBytes += a.bytes
+	st.LastPhases = a.phases
+	st.TotalPhases.add(a.phases)
+	l.last = a.report(duration, err)
+
+	if err != nil {
+		st.Failures++
+		st.ConsecutiveFailures++
+		st.LastFailure = a.clock.Now()
+		st.LastError = err.Error()
+		return
+	}
+	st.Successes++
+	st.ConsecutiveFailures = 0
+	st.LastSuccess = a.clock.Now()
+
+	if a.installed != nil {
+		if len(l.history) == maxVersionHistory {
+			l.history = append(l.history[:0:0], l.history[1:]...)
+		}
+		l.history = append(l.history, *a.installed)
+	}
+}
+
+// LoadStats returns counters for the load attempts made so far.
+func (s *Service) LoadStats() LoadStats {
+	s.loadStats.mu.Lock()
+	defer s.loadStats.mu.Unlock()
+	return s.loadStats.stats
+}
+
+// LastLoadReport describes the most recent load attempt: its source, timing,
+// section sizes, validation issues and repairs, and error if it failed. It
+// returns nil before the first attempt. The report is a copy the caller may
+// modify.
+func (s *Service) LastLoadReport() *LoadReport {
+	s.loadStats.mu.Lock()
+	defer s.loadStats.mu.Unlock()
+	return s.loadStats.last.clone()
+}
+
+// clone returns a deep copy of r, nil if r is nil.
+func (r *LoadReport) clone() *LoadReport {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	c.Sections = maps.Clone(r.Sections)
+	if r.Validation != nil {
+		c.Validation = &ValidationReport{
+			DataVersion: r.Validation.DataVersion,
+			Issues:      slices.Clone(r.Validation.Issues),
+			Repairs:     slices.Clone(r.Validation.Repairs),
+		}
+	}
+	return &c
+}
+
+// GetVersionHistory returns the most recent successful loads, oldest first.
+// Up to 20 loads are kept.
+func (s *Service) GetVersionHistory() []VersionRecord {
+	s.loadStats.mu.Lock()
+	defer s.loadStats.mu.Unlock()
+
+	history := make([]VersionRecord, len(s.loadStats.history))
+	copy(history, s.loadStats.history)
+	return history
+}
+
+// countingReader counts the bytes read through it in n and, if d is set, the
+// time spent reading in d. If h is set, the bytes are also written to h.
+type countingReader struct {
+	r io.Reader
+	n *int64
+	d *time.Duration
+	h hash.Hash
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := c.r.Read(p)
+	if c.d != nil {
+		*c.d += time.Since(start)
+	}
+	*c.n += int64(n)
+	if c.h != nil {
+		c.h.Write(p[:n])
+	}
+	return n, err
+}
@@ -0,0 +1,203 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadStats(t *testing.T) {
+	ctx := context.Background()
+	raw, err := os.ReadFile("../testdata/test_org_data.json")
+	if err != nil {
+		t.Fatalf("read test data: %v", err)
+	}
+
+	service := NewService()
+	if stats := service.LoadStats(); stats.Attempts != 0 {
+		t.Errorf("Attempts before any load = %d, want 0", stats.Attempts)
+	}
+	if history := service.GetVersionHistory(); history == nil || len(history) != 0 {
+		t.Errorf("GetVersionHistory() before any load = %v, want empty", history)
+	}
+
+	source := NewFakeDataSource(string(raw))
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	source.LoadError = errors.New("bucket unavailable")
+	for range 2 {
+		if err := service.LoadFromDataSource(ctx, source); err == nil {
+			t.Fatal("expected load error")
+		}
+	}
+
+	stats := service.LoadStats()
+	if stats.Attempts != 3 || stats.Successes != 1 || stats.Failures != 2 || stats.ConsecutiveFailures != 2 {
+		t.Errorf("stats = %+v, want 3 attempts, 1 success, 2 failures, 2 consecutive", stats)
+	}
+	if stats.TotalPayloadBytes != int64(len(raw)) || stats.LastPayloadBytes != 0 {
+		t.Errorf("payload bytes: total=%d last=%d, want %d and 0", stats.TotalPayloadBytes, stats.LastPayloadBytes, len(raw))
+	}
+	if stats.LastError == "" || stats.LastFailure.Before(stats.LastSuccess) {
+		t.Errorf("last failure not recorded: %+v", stats)
+	}
+
+	if err := service.ApplyChangeSet(ChangeSet{DataVersion: "test-abc124"}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+	if stats := service.LoadStats(); stats.Successes != 2 || stats.ConsecutiveFailures != 0 {
+		t.Errorf("after change set: Successes=%d ConsecutiveFailures=%d, want 2 and 0", stats.Successes, stats.ConsecutiveFailures)
+	}
+
+	history := service.GetVersionHistory()
+	if len(history) != 2 {
+		t.Fatalf("GetVersionHistory() returned %d records, want 2", len(history))
+	}
+	full, delta := history[0], history[1]
+	if full.DataVersion != "test-abc123" || full.Source != "fake-data-source" || full.Incremental || full.PayloadBytes != int64(len(raw)) {
+		t.Errorf("full load record = %+v", full)
+	}
+	if delta.DataVersion != "test-abc124" || delta.Source != "changeset" || !delta.Incremental {
+		t.Errorf("change set record = %+v", delta)
+	}
+	if !delta.Version.LoadTime.Equal(service.GetVersion().LoadTime) {
+		t.Errorf("latest record version %v, want current %v", delta.Version.LoadTime, service.GetVersion().LoadTime)
+	}
+}
+
+func TestVersionHistoryIsBounded(t *testing.T) {
+	service := setupTestService(t)
+	for range maxVersionHistory + 5 {
+		if err := service.ApplyChangeSet(ChangeSet{}); err != nil {
+			t.Fatalf("ApplyChangeSet: %v", err)
+		}
+	}
+
+	if got := len(service.GetVersionHistory()); got != maxVersionHistory {
+		t.Errorf("GetVersionHistory() kept %d records, want %d", got, maxVersionHistory)
+	}
+	if got := service.LoadStats().Successes; got != maxVersionHistory+6 {
+		t.Errorf("Successes = %d, want %d", got, maxVersionHistory+6)
+	}
+}
+
+// slowReader delays every read, like a slow network.
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (s slowReader) Read(p []byte) (int, error) {
+	time.Sleep(s.delay)
+	return s.r.Read(p)
+}
+
+type slowDataSource struct {
+	*FakeDataSource
+	delay time.Duration
+}
+
+func (s slowDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	rc, err := s.FakeDataSource.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(slowReader{r: rc, delay: s.delay}), nil
+}
+
+func TestLoadPhases(t *testing.T) {
+	ctx := context.Background()
+	raw, err := os.ReadFile("../testdata/test_org_data.json")
+	if err != nil {
+		t.Fatalf("read test data: %v", err)
+	}
+	const delay = 20 * time.Millisecond
+
+	service := NewService()
+	source := slowDataSource{FakeDataSource: NewFakeDataSource(string(raw)), delay: delay}
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	stats := service.LoadStats()
+	phases := stats.LastPhases
+	if phases.Download < delay {
+		t.Errorf("Download = %v, want at least the %v read delay", phases.Download, delay)
+	}
+	if phases.Decode >= delay {
+		t.Errorf("Decode = %v includes read time", phases.Decode)
+	}
+	if phases.Index <= 0 || phases.Swap <= 0 {
+		t.Errorf("phases = %+v, want index and swap timed", phases)
+	}
+	sum := phases.Download + phases.Decode + phases.Validate + phases.Index + phases.Swap
+	if sum > stats.LastDuration {
+		t.Errorf("phases sum to %v, more than the load's %v", sum, stats.LastDuration)
+	}
+	if history := service.GetVersionHistory(); history[0].Phases != phases {
+		t.Errorf("version record phases = %+v, want %+v", history[0].Phases, phases)
+	}
+
+	rejecting := NewService(WithLoadValidator(func(*Data) error { return errors.New("rejected") }))
+	if err := rejecting.LoadFromDataSource(ctx, NewFakeDataSource(string(raw))); err == nil {
+		t.Fatal("expected validation error")
+	}
+	if phases := rejecting.LoadStats().LastPhases; phases.Index != 0 || phases.Swap != 0 {
+		t.Errorf("rejected load phases = %+v, want no index or swap time", phases)
+	}
+	if total := rejecting.LoadStats().TotalPhases; total != rejecting.LoadStats().LastPhases {
+		t.Errorf("TotalPhases = %+v after one load, want LastPhases", total)
+	}
+}
+
+func TestLastLoadReport(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(WithRepair())
+	if report := service.LastLoadReport(); report != nil {
+		t.Errorf("LastLoadReport() before any load = %+v, want nil", report)
+	}
+
+	data := CreateTestData()
+	emp := data.Lookups.Employees["testuser1"]
+	emp.Email = "TestUser1@example.com"
+	emp.ManagerUID = "gone"
+	data.Lookups.Employees["testuser1"] = emp
+	source := jsonSource(t, "good", data)
+	if err := service.LoadFromDataSource(ctx, source); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+
+	report := service.LastLoadReport()
+	if report.Source != "good" || report.DataVersion != "test-v1.0" || !report.Installed || report.Error != "" {
+		t.Errorf("report = %+v, want an installed load of test-v1.0 from good", report)
+	}
+	if report.PayloadBytes == 0 || report.Duration <= 0 || report.Phases.Decode <= 0 {
+		t.Errorf("report = %+v, want timings and payload size", report)
+	}
+	if report.Sections[SectionEmployees] != 2 || report.Sections[SectionTeams] != 1 || report.Sections[SectionJira] != 0 {
+		t.Errorf("Sections = %v, want 2 employees, 1 team and no Jira projects", report.Sections)
+	}
+	if v := report.Validation; v == nil || len(v.Warnings()) != 1 || len(v.Repairs) != 1 {
+		t.Errorf("Validation = %+v, want one warning and one repair", v)
+	}
+	report.Sections[SectionEmployees] = 0
+	report.Validation.Issues[0].Entity = "changed"
+	report.Validation.Repairs[0].Entity = "changed"
+	if again := service.LastLoadReport(); again.Sections[SectionEmployees] != 2 || again.Validation.Issues[0].Entity == "changed" || again.Validation.Repairs[0].Entity == "changed" {
+		t.Errorf("changing the returned report changed the service's: %+v", again)
+	}
+
+	source.LoadError = errors.New("bucket unavailable")
+	if err := service.LoadFromDataSource(ctx, source); err == nil {
+		t.Fatal("expected load error")
+	}
+	report = service.LastLoadReport()
+	if report.Installed || report.Error == "" || report.Validation != nil || len(report.Sections) != 0 {
+		t.Errorf("report after failure = %+v, want the error only", report)
+	}
+}
@@ -0,0 +1,182 @@
+package orgdatacore
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// SubsetFilter selects the part of the loaded data ExportSubset keeps.
+type SubsetFilter struct {
+	// Orgs names orgs to export together with every pillar, team group,
+	// org and team below them.
+	Orgs []string
+	// Teams names teams to export.
+	Teams []string
+}
+
+// ExportSubset returns a comprehensive-index JSON document holding only the
+// entities selected by filter and their members, for sharing a scoped
+// dataset with a partner team without exposing the rest of the company. The
+// document can be loaded like any other dump.
+//
+// Everything in it refers only to exported data: the indexes, Jira owners and
+// component owners are cut down to the exported entities and employees,
+// components with no exported owner are dropped, and parents and managers
+// outside the subset are cleared, so exported entities may become roots. The
+// metadata totals are recounted.
+//
+// An empty filter fails with ErrInvalidExport, an unknown org or team with
+// ErrNotFound, and without loaded data ExportSubset fails with ErrNoData.
+func (s *Service) ExportSubset(filter SubsetFilter) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.data == nil {
+		return nil, ErrNoData
+	}
+	if len(filter.Orgs) == 0 && len(filter.Teams) == 0 {
+		return nil, fmt.Errorf("%w: subset filter selects no orgs or teams", ErrInvalidExport)
+	}
+	src := s.data
+
+	// Selected entities, keyed "type:name".
+	selected := make(map[string]bool)
+	var walk func(n *HierarchyNode)
+	walk = func(n *HierarchyNode) {
+		selected[n.Type+":"+n.Name] = true
+		for i := range n.Children {
+			walk(&n.Children[i])
+		}
+	}
+	for _, name := range filter.Orgs {
+		if _, ok := src.Lookups.Orgs[name]; !ok {
+			return nil, NewNotFoundError("org", name)
+		}
+		walk(s.computeDescendantsTree(name, "org"))
+	}
+	for _, name := range filter.Teams {
+		if _, ok := src.Lookups.Teams[name]; !ok {
+			return nil, NewNotFoundError("team", name)
+		}
+		selected["team:"+name] = true
+	}
+
+	out := &Data{
+		Metadata: src.Metadata,
+		Lookups: Lookups{
+			Employees:  make(map[string]Employee),
+			Teams:      subsetEntities(src.Lookups.Teams, "team", selected, func(t *Team) **ParentInfo { return &t.Parent }),
+			Orgs:       subsetEntities(src.Lookups.Orgs, "org", selected, func(o *Org) **ParentInfo { return &o.Parent }),
+			Pillars:    subsetEntities(src.Lookups.Pillars, "pillar", selected, func(p *Pillar) **ParentInfo { return &p.Parent }),
+			TeamGroups: subsetEntities(src.Lookups.TeamGroups, "team_group", selected, func(tg *TeamGroup) **ParentInfo { return &tg.Parent }),
+			Components: make(map[string]Component),
+		},
+		Indexes: Indexes{
+			Membership:         MembershipIndex{MembershipIndex: make(map[string][]MembershipInfo)},
+			SlackIDMappings:    SlackIDMappings{SlackUIDToUID: make(map[string]string)},
+			GitHubIDMappings:   GitHubIDMappings{GitHubIDToUID: make(map[string]string)},
+			Jira:               make(JiraIndex),
+			ComponentOwnership: make(map[string][]ComponentOwnerInfo),
+		},
+	}
+
+	// Members of every exported entity.
+	addMembers := func(g Group) {
+		for _, uid := range g.ResolvedPeopleUIDList {
+			if emp, ok := src.Lookups.Employees[uid]; ok {
+				out.Lookups.Employees[uid] = emp
+			}
+		}
+	}
+	for _, t := range out.Lookups.Teams {
+		addMembers(t.Group)
+	}
+	for _, o := range out.Lookups.Orgs {
+		addMembers(o.Group)
+	}
+	for _, p := range out.Lookups.Pillars {
+		addMembers(p.Group)
+	}
+	for _, tg := range out.Lookups.TeamGroups {
+		addMembers(tg.Group)
+	}
+	for uid, emp := range out.Lookups.Employees {
+		if _, ok := out.Lookups.Employees[emp.ManagerUID]; !ok && emp.ManagerUID != "" {
+			emp.ManagerUID = ""
+			out.Lookups.Employees[uid] = emp
+		}
+	}
+
+	for uid := range out.Lookups.Employees {
+		var memberships []MembershipInfo
+		for _, m := range src.Indexes.Membership.MembershipIndex[uid] {
+			if selected[m.Type+":"+m.Name] {
+				memberships = append(memberships, m)
+			}
+		}
+		if memberships != nil {
+			out.Indexes.Membership.MembershipIndex[uid] = memberships
+		}
+	}
+	for slackID, uid := range src.Indexes.SlackIDMappings.SlackUIDToUID {
+		if _, ok := out.Lookups.Employees[uid]; ok {
+			out.Indexes.SlackIDMappings.SlackUIDToUID[slackID] = uid
+		}
+	}
+	for githubID, uid := range src.Indexes.GitHubIDMappings.GitHubIDToUID {
+		if _, ok := out.Lookups.Employees[uid]; ok {
+			out.Indexes.GitHubIDMappings.GitHubIDToUID[githubID] = uid
+		}
+	}
+
+	keepOwner := func(name, typ string) bool { return selected[typ+":"+name] }
+	for project, components := range src.Indexes.Jira {
+		for component, owners := range components {
+			kept := slices.DeleteFunc(slices.Clone(owners), func(o JiraOwnerInfo) bool { return !keepOwner(o.Name, o.Type) })
+			if len(kept) == 0 {
+				continue
+			}
+			if out.Indexes.Jira[project] == nil {
+				out.Indexes.Jira[project] = make(map[string][]JiraOwnerInfo)
+			}
+			out.Indexes.Jira[project][component] = kept
+		}
+	}
+	for name, owners := range src.Indexes.ComponentOwnership {
+		kept := slices.DeleteFunc(slices.Clone(owners), func(o ComponentOwnerInfo) bool { return !keepOwner(o.Name, o.Type) })
+		if len(kept) == 0 {
+			continue
+		}
+		out.Indexes.ComponentOwnership[name] = kept
+		if c, ok := src.Lookups.Components[name]; ok {
+			if c.Parent != nil && !keepOwner(c.Parent.Name, c.Parent.Type) {
+				c.Parent = nil
+				c.ParentPath = ""
+			}
+			out.Lookups.Components[name] = c
+		}
+	}
+
+	out.Metadata.TotalEmployees = len(out.Lookups.Employees)
+	out.Metadata.TotalOrgs = len(out.Lookups.Orgs)
+	out.Metadata.TotalTeams = len(out.Lookups.Teams)
+	return json.Marshal(out)
+}
+
+// subsetEntities returns the entities of typ that are selected, with parents
+// outside the selection cleared. parent returns the address of an entity's
+// parent field.
+func subsetEntities[T any](entities map[string]T, typ string, selected map[string]bool, parent func(*T) **ParentInfo) map[string]T {
+	out := make(map[string]T)
+	for name, e := range entities {
+		if !selected[typ+":"+name] {
+			continue
+		}
+		if p := parent(&e); *p != nil && !selected[(*p).Type+":"+(*p).Name] {
+			*p = nil
+		}
+		out[name] = e
+	}
+	return out
+}
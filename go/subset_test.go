@@ -0,0 +1,172 @@
+package orgdatacore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestExportSubset(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name          string
+		filter        SubsetFilter
+		wantEmployees []string
+		wantTeams     []string
+		wantOrgs      []string
+		wantPillars   []string
+	}{
+		{
+			name:          "org with descendants",
+			filter:        SubsetFilter{Orgs: []string{"platform-org"}},
+			wantEmployees: []string{"bwilson"},
+			wantTeams:     []string{"platform-team"},
+			wantOrgs:      []string{"platform-org"},
+			wantPillars:   []string{"engineering"},
+		},
+		{
+			name:          "single team",
+			filter:        SubsetFilter{Teams: []string{"test-team"}},
+			wantEmployees: []string{"adoe", "jsmith"},
+			wantTeams:     []string{"test-team"},
+			wantOrgs:      []string{},
+			wantPillars:   []string{},
+		},
+		{
+			name:          "org and team",
+			filter:        SubsetFilter{Orgs: []string{"platform-org"}, Teams: []string{"test-team"}},
+			wantEmployees: []string{"adoe", "bwilson", "jsmith"},
+			wantTeams:     []string{"platform-team", "test-team"},
+			wantOrgs:      []string{"platform-org"},
+			wantPillars:   []string{"engineering"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := service.ExportSubset(tt.filter)
+			if err != nil {
+				t.Fatalf("ExportSubset: %v", err)
+			}
+			var data Data
+			if err := json.Unmarshal(raw, &data); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := sortedKeys(data.Lookups.Employees); !slices.Equal(got, tt.wantEmployees) {
+				t.Errorf("employees = %v, want %v", got, tt.wantEmployees)
+			}
+			if got := sortedKeys(data.Lookups.Teams); !slices.Equal(got, tt.wantTeams) {
+				t.Errorf("teams = %v, want %v", got, tt.wantTeams)
+			}
+			if got := sortedKeys(data.Lookups.Orgs); !slices.Equal(got, tt.wantOrgs) {
+				t.Errorf("orgs = %v, want %v", got, tt.wantOrgs)
+			}
+			if got := sortedKeys(data.Lookups.Pillars); !slices.Equal(got, tt.wantPillars) {
+				t.Errorf("pillars = %v, want %v", got, tt.wantPillars)
+			}
+			if data.Metadata.TotalEmployees != len(tt.wantEmployees) || data.Metadata.TotalTeams != len(tt.wantTeams) {
+				t.Errorf("metadata totals = %d employees, %d teams", data.Metadata.TotalEmployees, data.Metadata.TotalTeams)
+			}
+			if issues := ValidateData(&data).Issues; len(issues) != 0 {
+				t.Errorf("subset has validation issues: %+v", issues)
+			}
+		})
+	}
+}
+
+func TestExportSubsetLoads(t *testing.T) {
+	service := setupTestService(t)
+	raw, err := service.ExportSubset(SubsetFilter{Orgs: []string{"platform-org"}})
+	if err != nil {
+		t.Fatalf("ExportSubset: %v", err)
+	}
+
+	subset := NewService()
+	if err := subset.LoadFromDataSource(context.Background(), NewFakeDataSource(string(raw))); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	if emp := subset.GetEmployeeBySlackID("U12345678"); emp != nil {
+		t.Errorf("jsmith is outside the subset but found by Slack ID: %+v", emp)
+	}
+	if got := subset.GetTeamsForUID("bwilson"); !slices.Equal(got, []string{"platform-team"}) {
+		t.Errorf("GetTeamsForUID(bwilson) = %v", got)
+	}
+	if got := subset.GetOrgByName("platform-org"); got == nil || got.Parent != nil {
+		t.Errorf("platform-org = %+v, want a root", got)
+	}
+	if got := subset.GetJiraProjects(); !slices.Equal(got, []string{"PLAT"}) {
+		t.Errorf("GetJiraProjects = %v, want [PLAT]", got)
+	}
+	owners := subset.GetTeamsForComponent("auth-service")
+	if len(owners) != 1 || owners[0].Name != "platform-team" {
+		t.Errorf("auth-service owners = %+v, want only platform-team", owners)
+	}
+	if bytes.Contains(raw, []byte("jsmith")) {
+		t.Error("subset mentions jsmith, who is outside it")
+	}
+}
+
+func TestExportSubsetClearsOutsideManagers(t *testing.T) {
+	service := setupTestService(t)
+	raw, err := service.ExportSubset(SubsetFilter{Orgs: []string{"test-org"}})
+	if err != nil {
+		t.Fatalf("ExportSubset: %v", err)
+	}
+	var data Data
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := data.Lookups.Employees["jsmith"].ManagerUID; got != "adoe" {
+		t.Errorf("jsmith's manager = %q, want adoe kept", got)
+	}
+
+	data.Lookups.Employees["jsmith"] = Employee{UID: "jsmith", ManagerUID: "outsider"}
+	other := NewService()
+	if err := other.LoadFromDataSource(context.Background(), jsonSource(t, "outside manager", &data)); err != nil {
+		t.Fatalf("LoadFromDataSource: %v", err)
+	}
+	raw, err = other.ExportSubset(SubsetFilter{Teams: []string{"test-team"}})
+	if err != nil {
+		t.Fatalf("ExportSubset: %v", err)
+	}
+	if bytes.Contains(raw, []byte("outsider")) {
+		t.Errorf("subset references a manager outside it: %s", raw)
+	}
+}
+
+func TestExportSubsetErrors(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name    string
+		service *Service
+		filter  SubsetFilter
+		want    error
+	}{
+		{"no data", NewService(), SubsetFilter{Teams: []string{"test-team"}}, ErrNoData},
+		{"empty filter", service, SubsetFilter{}, ErrInvalidExport},
+		{"unknown org", service, SubsetFilter{Orgs: []string{"nope"}}, ErrNotFound},
+		{"unknown team", service, SubsetFilter{Teams: []string{"nope"}}, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.service.ExportSubset(tt.filter); !errors.Is(err, tt.want) {
+				t.Errorf("ExportSubset = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -324,6 +324,39 @@ func TestIsEmployeeInTeam(t *testing.T) {
 	}
 }
 
+func TestAreEmployeesInTeam(t *testing.T) {
+	service := setupTestService(t)
+
+	got := service.AreEmployeesInTeam([]string{"jsmith", "bwilson", "nonexistent"}, "test-team")
+	want := map[string]bool{"jsmith": true, "bwilson": false, "nonexistent": false}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AreEmployeesInTeam = %v, want %v", got, want)
+	}
+	if got := service.AreEmployeesInTeam(nil, "test-team"); got == nil || len(got) != 0 {
+		t.Errorf("AreEmployeesInTeam(nil) = %#v, want an empty map", got)
+	}
+}
+
+func TestIsEmployeeInAnyTeam(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		uid      string
+		teams    []string
+		expected bool
+	}{
+		{"jsmith", []string{"platform-team", "test-team"}, true},
+		{"bwilson", []string{"test-team", "platform-team"}, true},
+		{"jsmith", []string{"platform-team", "nonexistent-team"}, false},
+		{"jsmith", nil, false},
+	}
+	for _, tt := range tests {
+		if got := service.IsEmployeeInAnyTeam(tt.uid, tt.teams); got != tt.expected {
+			t.Errorf("IsEmployeeInAnyTeam(%q, %v) = %v, expected %v", tt.uid, tt.teams, got, tt.expected)
+		}
+	}
+}
+
 // TestIsSlackUserInTeam tests Slack user team membership checks
 func TestIsSlackUserInTeam(t *testing.T) {
 	service := setupTestService(t)
@@ -653,3 +686,31 @@ func TestGroupExtendedFields(t *testing.T) {
 		t.Errorf("expected 1 component role, got %d", len(team.Group.ComponentRoles))
 	}
 }
+
+// TestRepoIndex tests the lazily-built repository ownership index
+func TestRepoIndex(t *testing.T) {
+	service := setupTestService(t)
+
+	tests := []struct {
+		name     string
+		repo     string
+		expected []string
+	}{
+		{name: "full URL", repo: "https://github.com/example/test-repo", expected: []string{"test-team"}},
+		{name: "short form", repo: "example/platform", expected: []string{"platform-team"}},
+		{name: "case and suffix insensitive", repo: "Example/Platform.git", expected: []string{"platform-team"}},
+		{name: "unknown repo", repo: "example/unknown", expected: []string{}},
+		{name: "empty repo", repo: "", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service.mu.RLock()
+			result := append([]string{}, service.derived().repoIndex()[normalizeRepo(tt.repo)]...)
+			service.mu.RUnlock()
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("teams owning %q = %v, expected %v", tt.repo, result, tt.expected)
+			}
+		})
+	}
+}
@@ -163,6 +163,9 @@ type Data struct {
 	Metadata Metadata `json:"metadata"`
 	Lookups  Lookups  `json:"lookups"`
 	Indexes  Indexes  `json:"indexes"`
+
+	// overlays are the overlay patches applied to this data; see WithOverlay.
+	overlays []AppliedPatch
 }
 
 // Metadata contains summary information about the data
@@ -365,4 +368,12 @@ type DataVersion struct {
 	ConfigMaps    map[string]string // ConfigMap name -> checksum/version
 	OrgCount      int
 	EmployeeCount int
+	// Checksum is the hex SHA-256 of the payload the data was loaded from,
+	// hashed as it is read, so replicas loading the same dump report the
+	// same checksum, as the Python library does. For a MergedDataSource it
+	// is the SHA-256 of its sources' SHA-256 sums, in order; overlay patches
+	// applied to the data are hashed after the payload, as JSON. A change
+	// set chains it: its checksum is the SHA-256 of the previous checksum
+	// followed by the change set as JSON.
+	Checksum string
 }
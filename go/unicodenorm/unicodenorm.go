@@ -0,0 +1,42 @@
+// Package unicodenorm implements the Unicode steps of lookup normalization
+// for orgdatacore. It is a separate package so that orgdatacore itself keeps
+// no dependencies outside the standard library:
+//
+//	n := orgdatacore.DefaultNormalization
+//	n.Unicode = unicodenorm.Normalizer{FoldDiacritics: true}
+//	service := orgdatacore.NewService(orgdatacore.WithLookupNormalization(n))
+//	service.GetTeamByName("Zurich team") // finds "Zürich team"
+package unicodenorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer applies Unicode compatibility normalization (NFKC), which among
+// other things turns non-breaking spaces into spaces and fullwidth letters
+// into ASCII. It implements orgdatacore.UnicodeNormalizer.
+type Normalizer struct {
+	// FoldDiacritics also strips combining marks, so "Zürich" matches
+	// "Zurich". It can make distinct names match.
+	FoldDiacritics bool
+}
+
+// NFKC is a Normalizer that keeps diacritics.
+var NFKC = Normalizer{}
+
+// Normalize returns s normalized as n says.
+func (n Normalizer) Normalize(s string) string {
+	s = norm.NFKC.String(s)
+	if n.FoldDiacritics {
+		s = norm.NFC.String(strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Mn, r) {
+				return -1
+			}
+			return r
+		}, norm.NFD.String(s)))
+	}
+	return s
+}
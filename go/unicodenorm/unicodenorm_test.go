@@ -0,0 +1,55 @@
+package unicodenorm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+func TestNormalizer(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Normalizer
+		in   string
+		want string
+	}{
+		{"nbsp", NFKC, "platform team", "platform team"},
+		{"fullwidth", NFKC, "ｔｅａｍ", "team"},
+		{"diacritics kept", NFKC, "Zürich", "Zürich"},
+		{"diacritics folded", Normalizer{FoldDiacritics: true}, "Zürich café", "Zurich cafe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.n.Normalize(tt.in); got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupNormalization(t *testing.T) {
+	data := orgdatacore.CreateTestData()
+	team := data.Lookups.Teams["test-squad"]
+	team.Name = "Zürich team"
+	data.Lookups.Teams["Zürich team"] = team
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := orgdatacore.DefaultNormalization
+	n.Unicode = Normalizer{FoldDiacritics: true}
+	service := orgdatacore.NewService(orgdatacore.WithLookupNormalization(n))
+	if err := service.LoadFromDataSource(context.Background(), orgdatacore.NewFakeDataSource(string(raw))); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := service.GetTeamByName("  Zurich team"); got == nil || got.Name != "Zürich team" {
+		t.Errorf("GetTeamByName(Zurich team) = %+v, want the Zürich team", got)
+	}
+	if got := service.GetTeamByName("ｔｅｓｔ-ｓｑｕａｄ"); got == nil || got.Name != "test-squad" {
+		t.Errorf("GetTeamByName in fullwidth letters = %+v, want test-squad", got)
+	}
+}
@@ -0,0 +1,134 @@
+package orgdatacore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// UnknownField is a field present in a dump that the Go types do not model,
+// so decoding drops it.
+type UnknownField struct {
+	// Path locates the field like a JSON Pointer, with map keys and array
+	// indexes replaced by "*", such as "/lookups/teams/*/group/type/visualize".
+	Path string `json:"path"`
+	// Count is the number of times the field occurs.
+	Count int `json:"count"`
+}
+
+// FindUnknownFields reads a data document from r and returns the fields it
+// contains that Data does not model, sorted by path. Use it to notice when the
+// producer adds data the library silently drops. Fields are matched
+// case-insensitively, as encoding/json does. The nested "component" object
+// of a component is not inspected.
+func FindUnknownFields(r io.Reader) ([]UnknownField, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	walkUnknownFields(doc, reflect.TypeFor[Data](), "", counts)
+
+	fields := make([]UnknownField, 0, len(counts))
+	for path, n := range counts {
+		fields = append(fields, UnknownField{Path: path, Count: n})
+	}
+	slices.SortFunc(fields, func(a, b UnknownField) int { return strings.Compare(a.Path, b.Path) })
+	return fields, nil
+}
+
+func walkUnknownFields(v any, t reflect.Type, path string, counts map[string]int) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		known := knownFields(t)
+		for key, value := range obj {
+			field, ok := known[strings.ToLower(key)]
+			if !ok {
+				counts[path+"/"+escapePointer(key)]++
+				continue
+			}
+			if field != nil {
+				walkUnknownFields(value, field, path+"/"+escapePointer(key), counts)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := v.(map[string]any); ok {
+			for _, value := range obj {
+				walkUnknownFields(value, t.Elem(), path+"/*", counts)
+			}
+		}
+	case reflect.Slice:
+		if items, ok := v.([]any); ok {
+			for _, item := range items {
+				walkUnknownFields(item, t.Elem(), path+"/*", counts)
+			}
+		}
+	}
+}
+
+// knownFieldsCache maps a struct type to its JSON field names, lowercased,
+// and the type of each field. A nil type marks a field that is accepted but
+// not inspected.
+var knownFieldsCache sync.Map // reflect.Type -> map[string]reflect.Type
+
+func knownFields(t reflect.Type) map[string]reflect.Type {
+	if known, ok := knownFieldsCache.Load(t); ok {
+		return known.(map[string]reflect.Type)
+	}
+
+	known := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[strings.ToLower(name)] = f.Type
+	}
+	// Component.UnmarshalJSON also reads a nested "component" object.
+	if t == reflect.TypeFor[Component]() {
+		known["component"] = nil
+	}
+
+	knownFieldsCache.Store(t, known)
+	return known
+}
+
+// unknownFieldIssues reports fields as CheckUnknownField issues.
+func (p validationPolicy) unknownFieldIssues(fields []UnknownField) []ValidationIssue {
+	severity := SeverityWarning
+	if sev, ok := p.severities[CheckUnknownField]; ok {
+		severity = sev
+	}
+	issues := make([]ValidationIssue, 0, len(fields))
+	for _, f := range fields {
+		issues = append(issues, ValidationIssue{
+			Check:    CheckUnknownField,
+			Severity: severity,
+			Entity:   f.Path,
+			Message:  fmt.Sprintf("dump field %s is not modeled and was dropped (%d occurrences)", f.Path, f.Count),
+		})
+	}
+	return issues
+}
@@ -0,0 +1,127 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindUnknownFields(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []UnknownField
+	}{
+		{
+			name: "all modeled",
+			doc:  `{"metadata": {"data_version": "v1"}, "lookups": {"employees": {"a": {"uid": "a"}}}}`,
+			want: []UnknownField{},
+		},
+		{
+			name: "case-insensitive match",
+			doc:  `{"Metadata": {"Data_Version": "v1"}}`,
+			want: []UnknownField{},
+		},
+		{
+			name: "new fields",
+			doc: `{
+				"metadata": {"data_version": "v1", "producer": "py"},
+				"lookups": {"employees": {
+					"a": {"uid": "a", "pronouns": "they/them"},
+					"b": {"uid": "b", "pronouns": "she/her"}
+				}},
+				"extra": {"nested": true}
+			}`,
+			want: []UnknownField{
+				{Path: "/extra", Count: 1},
+				{Path: "/lookups/employees/*/pronouns", Count: 2},
+				{Path: "/metadata/producer", Count: 1},
+			},
+		},
+		{
+			name: "escaped key",
+			doc:  `{"metadata": {"a/b": 1}}`,
+			want: []UnknownField{{Path: "/metadata/a~1b", Count: 1}},
+		},
+		{
+			name: "nested component object",
+			doc:  `{"lookups": {"components": {"api": {"name": "api", "component": {"anything": 1}}}}}`,
+			want: []UnknownField{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindUnknownFields(strings.NewReader(tt.doc))
+			if err != nil {
+				t.Fatalf("FindUnknownFields: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindUnknownFields() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := FindUnknownFields(strings.NewReader("{")); err == nil {
+		t.Error("FindUnknownFields(invalid JSON) = nil error")
+	}
+}
+
+func TestFindUnknownFieldsTestdata(t *testing.T) {
+	f, err := os.Open("../testdata/test_org_data.json")
+	if err != nil {
+		t.Fatalf("open testdata: %v", err)
+	}
+	defer f.Close()
+
+	fields, err := FindUnknownFields(f)
+	if err != nil {
+		t.Fatalf("FindUnknownFields: %v", err)
+	}
+	want := map[string]int{
+		"/lookups/teams/*/group/type/visualize": 2,
+		"/metadata/total_pillars":               1,
+	}
+	for _, field := range fields {
+		if n, ok := want[field.Path]; ok {
+			if field.Count != n {
+				t.Errorf("%s counted %d times, want %d", field.Path, field.Count, n)
+			}
+			delete(want, field.Path)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("fields not reported: %v (got %+v)", want, fields)
+	}
+}
+
+func TestUnknownFieldCheck(t *testing.T) {
+	ctx := context.Background()
+	const doc = `{"metadata": {"data_version": "v1", "producer": "py"}, "lookups": {"employees": {"a": {"uid": "a"}}}, "indexes": {"membership": {"membership_index": {"a": []}}}}`
+
+	if err := NewService().LoadFromDataSource(ctx, NewFakeDataSource(doc)); err != nil {
+		t.Errorf("LoadFromDataSource without the check: %v", err)
+	}
+	if err := NewService(WithUnknownFieldCheck()).LoadFromDataSource(ctx, NewFakeDataSource(doc)); err != nil {
+		t.Errorf("LoadFromDataSource with the check at warning: %v", err)
+	}
+
+	service := NewService(WithUnknownFieldCheck(), WithCheckSeverity(CheckUnknownField, SeverityError))
+	err := service.LoadFromDataSource(ctx, NewFakeDataSource(doc))
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("LoadFromDataSource = %v, want a *ValidationError", err)
+	}
+	want := []ValidationIssue{{
+		Check:    CheckUnknownField,
+		Severity: SeverityError,
+		Entity:   "/metadata/producer",
+		Message:  "dump field /metadata/producer is not modeled and was dropped (1 occurrences)",
+	}}
+	if !reflect.DeepEqual(verr.Issues, want) {
+		t.Errorf("Issues = %+v, want %+v", verr.Issues, want)
+	}
+}
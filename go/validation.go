@@ -0,0 +1,590 @@
+package orgdatacore
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ValidationIssue is one problem found while validating a dataset.
+type ValidationIssue struct {
+	Check    ValidationCheck `json:"check"`
+	Severity Severity        `json:"severity"`
+	// Entity names what the issue is about: a UID, a team name, or a section.
+	Entity  string `json:"entity,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport lists the issues found in a dataset, grouped by check in
+// the order the checks run and sorted by entity and message within each check.
+type ValidationReport struct {
+	DataVersion string            `json:"data_version"`
+	Issues      []ValidationIssue `json:"issues"`
+	// Repairs lists the fixes applied to the data under WithRepair, before
+	// it was checked.
+	Repairs []Repair `json:"repairs"`
+}
+
+// Errors returns the issues with SeverityError.
+func (r *ValidationReport) Errors() []ValidationIssue {
+	return r.withSeverity(SeverityError)
+}
+
+// Warnings returns the issues with SeverityWarning.
+func (r *ValidationReport) Warnings() []ValidationIssue {
+	return r.withSeverity(SeverityWarning)
+}
+
+// AtLeast returns the issues as severe as min or more.
+func (r *ValidationReport) AtLeast(min Severity) []ValidationIssue {
+	issues := []ValidationIssue{}
+	for _, issue := range r.Issues {
+		if issue.Severity.AtLeast(min) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (r *ValidationReport) withSeverity(sev Severity) []ValidationIssue {
+	issues := []ValidationIssue{}
+	for _, issue := range r.Issues {
+		if issue.Severity == sev {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// ValidateData runs the built-in checks on data with their default
+// severities, without loading it. Use it to check a dump before publishing it.
+func ValidateData(data *Data) *ValidationReport {
+	return validationPolicy{}.run(data, nil)
+}
+
+// validationPolicy configures how seriously each check is taken and which
+// issues reject a load.
+type validationPolicy struct {
+	severities map[ValidationCheck]Severity // overrides of the defaults
+	required   []Section                    // nil means defaultRequiredSections
+	threshold  Severity                     // zero means SeverityError
+	// skipConsistency skips the checks marked consistency, under
+	// WithoutConsistencyChecks.
+	skipConsistency bool
+}
+
+// defaultRequiredSections are the sections that must not be empty unless
+// changed with WithRequiredSections.
+var defaultRequiredSections = []Section{SectionEmployees, SectionMembership}
+
+// validationCheck is one of the checks run on every dataset.
+type validationCheck struct {
+	check    ValidationCheck
+	severity Severity // default
+	run      func(v *validation)
+	// consistency marks the checks that walk every membership or every
+	// manager and parent link, and can be skipped with
+	// WithoutConsistencyChecks.
+	consistency bool
+}
+
+// validationChecks are run in order on every new dataset.
+var validationChecks = []validationCheck{
+	{CheckPIIFree, SeverityError, checkPIIFree, false},
+	{CheckRequiredSections, SeverityError, checkRequiredSections, false},
+	{CheckDanglingManager, SeverityWarning, checkDanglingManagers, false},
+	{CheckUnknownMember, SeverityWarning, checkUnknownMembers, false},
+	{CheckMissingTeam, SeverityWarning, checkMissingTeams, false},
+	{CheckMembershipMismatch, SeverityWarning, checkMembershipMismatches, true},
+	{CheckUnknownUID, SeverityWarning, checkUnknownUIDs, false},
+	{CheckDanglingParent, SeverityWarning, checkDanglingParents, false},
+	{CheckUnknownEntity, SeverityWarning, checkUnknownEntities, false},
+	{CheckManagerCycle, SeverityWarning, checkManagerCycles, true},
+	{CheckHierarchyCycle, SeverityWarning, checkHierarchyCycles, true},
+	{CheckDuplicateID, SeverityWarning, checkDuplicateIDs, false},
+	{CheckNameCollision, SeverityWarning, checkNameCollisions, false},
+}
+
+// validation is the state of one validation run.
+type validation struct {
+	data     *Data
+	sections sectionSet
+	required []Section
+	check    ValidationCheck
+	severity Severity
+	report   *ValidationReport
+}
+
+func (v *validation) add(entity, format string, args ...any) {
+	v.report.Issues = append(v.report.Issues, ValidationIssue{
+		Check:    v.check,
+		Severity: v.severity,
+		Entity:   entity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// hasEmployees reports whether employee references can be checked: the
+// employee section is loaded and not empty, and the dump is not PII-free.
+func (v *validation) hasEmployees() bool {
+	return v.sections.has(SectionEmployees) && len(v.data.Lookups.Employees) > 0 && !v.data.Metadata.PIIFree
+}
+
+// entityExists reports whether the entity of type typ named name is in the
+// lookups. Types whose section is not loaded, and types that are not
+// entities, are assumed to exist since they cannot be checked.
+func (v *validation) entityExists(typ, name string) bool {
+	lookups := &v.data.Lookups
+	var ok bool
+	switch strings.ToLower(typ) {
+	case "team":
+		if !v.sections.has(SectionTeams) {
+			return true
+		}
+		_, ok = lookups.Teams[name]
+	case "org":
+		if !v.sections.has(SectionOrgs) {
+			return true
+		}
+		_, ok = lookups.Orgs[name]
+	case "pillar":
+		if !v.sections.has(SectionPillars) {
+			return true
+		}
+		_, ok = lookups.Pillars[name]
+	case "team_group":
+		if !v.sections.has(SectionTeamGroups) {
+			return true
+		}
+		_, ok = lookups.TeamGroups[name]
+	default:
+		return true
+	}
+	return ok
+}
+
+// run checks data. Sections excluded from loading are not checked.
+func (p validationPolicy) run(data *Data, sections sectionSet) *ValidationReport {
+	report := &ValidationReport{DataVersion: data.Metadata.DataVersion, Issues: []ValidationIssue{}, Repairs: []Repair{}}
+	required := p.required
+	if required == nil {
+		required = defaultRequiredSections
+	}
+	for _, c := range validationChecks {
+		if c.consistency && p.skipConsistency {
+			continue
+		}
+		v := &validation{data: data, sections: sections, required: required, check: c.check, severity: c.severity, report: report}
+		if sev, ok := p.severities[c.check]; ok {
+			v.severity = sev
+		}
+
+		start := len(report.Issues)
+		c.run(v)
+		slices.SortStableFunc(report.Issues[start:], func(a, b ValidationIssue) int {
+			if c := strings.Compare(a.Entity, b.Entity); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Message, b.Message)
+		})
+	}
+	return report
+}
+
+// gate returns a ValidationError if report has issues at or above the
+// policy's threshold.
+func (p validationPolicy) gate(report *ValidationReport) error {
+	threshold := p.threshold
+	if threshold == "" {
+		threshold = SeverityError
+	}
+	if issues := report.AtLeast(threshold); len(issues) > 0 {
+		return &ValidationError{Issues: issues, Report: report}
+	}
+	return nil
+}
+
+func checkPIIFree(v *validation) {
+	if !v.data.Metadata.PIIFree {
+		return
+	}
+	if len(v.data.Lookups.Employees) > 0 {
+		v.add("employees", "pii_free is set but lookups.employees is not empty")
+	}
+	if len(v.data.Indexes.Membership.MembershipIndex) > 0 {
+		v.add("membership", "pii_free is set but membership_index is not empty")
+	}
+}
+
+func checkRequiredSections(v *validation) {
+	for _, sec := range v.required {
+		if !v.sections.has(sec) {
+			continue
+		}
+		if v.data.Metadata.PIIFree && (sec == SectionEmployees || sec == SectionMembership) {
+			continue
+		}
+		if path, n := sectionSize(v.data, sec); n == 0 {
+			v.add(string(sec), "missing %s", path)
+		}
+	}
+}
+
+// sectionSize returns the path of the main map of sec in the dump, and its
+// number of entries in data.
+func sectionSize(data *Data, sec Section) (string, int) {
+	switch sec {
+	case SectionEmployees:
+		return "lookups.employees", len(data.Lookups.Employees)
+	case SectionTeams:
+		return "lookups.teams", len(data.Lookups.Teams)
+	case SectionOrgs:
+		return "lookups.orgs", len(data.Lookups.Orgs)
+	case SectionPillars:
+		return "lookups.pillars", len(data.Lookups.Pillars)
+	case SectionTeamGroups:
+		return "lookups.team_groups", len(data.Lookups.TeamGroups)
+	case SectionComponents:
+		return "lookups.components", len(data.Lookups.Components)
+	case SectionMembership:
+		return "indexes.membership.membership_index", len(data.Indexes.Membership.MembershipIndex)
+	case SectionSlackIDs:
+		return "indexes.slack_id_mappings.slack_uid_to_uid", len(data.Indexes.SlackIDMappings.SlackUIDToUID)
+	case SectionGitHubIDs:
+		return "indexes.github_id_mappings.github_id_to_uid", len(data.Indexes.GitHubIDMappings.GitHubIDToUID)
+	case SectionJira:
+		return "indexes.jira", len(data.Indexes.Jira)
+	case SectionComponentOwnership:
+		return "indexes.component_ownership", len(data.Indexes.ComponentOwnership)
+	}
+	return string(sec), 0
+}
+
+func checkDanglingManagers(v *validation) {
+	if !v.hasEmployees() {
+		return
+	}
+	employees := v.data.Lookups.Employees
+	for uid, emp := range employees {
+		if emp.ManagerUID == "" {
+			continue
+		}
+		if _, ok := employees[emp.ManagerUID]; !ok {
+			v.add(uid, "employee %q has manager %q, which is not an employee", uid, emp.ManagerUID)
+		}
+	}
+}
+
+func checkUnknownMembers(v *validation) {
+	if !v.hasEmployees() {
+		return
+	}
+	employees := v.data.Lookups.Employees
+	check := func(typ, name string, group Group) {
+		for _, uid := range group.ResolvedPeopleUIDList {
+			if _, ok := employees[uid]; !ok {
+				v.add(name, "%s %q lists member %q, which is not an employee", typ, name, uid)
+			}
+		}
+	}
+	for name, team := range v.data.Lookups.Teams {
+		check("team", name, team.Group)
+	}
+	for name, org := range v.data.Lookups.Orgs {
+		check("org", name, org.Group)
+	}
+	for name, pillar := range v.data.Lookups.Pillars {
+		check("pillar", name, pillar.Group)
+	}
+	for name, tg := range v.data.Lookups.TeamGroups {
+		check("team_group", name, tg.Group)
+	}
+}
+
+func checkMissingTeams(v *validation) {
+	if !v.sections.has(SectionMembership) || !v.sections.has(SectionTeams) {
+		return
+	}
+	missing := make(map[string]int)
+	for _, memberships := range v.data.Indexes.Membership.MembershipIndex {
+		for _, m := range memberships {
+			if m.Type != string(MembershipTeam) {
+				continue
+			}
+			if _, ok := v.data.Lookups.Teams[m.Name]; !ok {
+				missing[m.Name]++
+			}
+		}
+	}
+	for name, n := range missing {
+		v.add(name, "membership index references team %q for %d employees, but it is not in lookups.teams", name, n)
+	}
+}
+
+// checkMembershipMismatches compares the team memberships in the membership
+// index, used by GetTeamsForUID, with the team member lists, used by
+// GetTeamMembers. Teams missing from the lookups and UIDs that are not
+// employees are left to CheckMissingTeam and CheckUnknownMember.
+//
+// It holds every team membership in one map while it runs, which makes it one
+// of the costliest checks: together with the cycle checks it adds roughly 15%
+// to the load time of a 50,000-employee dump (see
+// BenchmarkLargeLoadWithoutConsistencyChecks). WithoutConsistencyChecks skips
+// it.
+func checkMembershipMismatches(v *validation) {
+	if !v.sections.has(SectionMembership) || !v.sections.has(SectionTeams) || v.data.Metadata.PIIFree {
+		return
+	}
+	employees := v.data.Lookups.Employees
+	isEmployee := func(uid string) bool {
+		if !v.hasEmployees() {
+			return true
+		}
+		_, ok := employees[uid]
+		return ok
+	}
+
+	// Each indexed membership is marked listed when a team list names it, so
+	// one map serves both directions.
+	const (
+		indexed = iota + 1
+		listed
+	)
+	type membership struct{ team, uid string }
+	state := make(map[membership]int)
+	for uid, memberships := range v.data.Indexes.Membership.MembershipIndex {
+		for _, m := range memberships {
+			if m.Type == string(MembershipTeam) {
+				state[membership{m.Name, uid}] = indexed
+			}
+		}
+	}
+	for name, team := range v.data.Lookups.Teams {
+		for _, uid := range team.Group.ResolvedPeopleUIDList {
+			m := membership{name, uid}
+			switch state[m] {
+			case indexed:
+				state[m] = listed
+			case 0:
+				if isEmployee(uid) {
+					v.add(name, "team %q lists %q, but the membership index does not", name, uid)
+				}
+				state[m] = listed
+			}
+		}
+	}
+	for m, st := range state {
+		if st != indexed {
+			continue
+		}
+		if _, ok := v.data.Lookups.Teams[m.team]; ok && isEmployee(m.uid) {
+			v.add(m.team, "membership index puts %q in team %q, but the team does not list them", m.uid, m.team)
+		}
+	}
+}
+
+func checkUnknownUIDs(v *validation) {
+	if !v.hasEmployees() {
+		return
+	}
+	employees := v.data.Lookups.Employees
+	for uid := range v.data.Indexes.Membership.MembershipIndex {
+		if _, ok := employees[uid]; !ok {
+			v.add(uid, "membership index lists %q, which is not an employee", uid)
+		}
+	}
+	for slackID, uid := range v.data.Indexes.SlackIDMappings.SlackUIDToUID {
+		if _, ok := employees[uid]; !ok {
+			v.add(uid, "Slack ID %q maps to %q, which is not an employee", slackID, uid)
+		}
+	}
+	for githubID, uid := range v.data.Indexes.GitHubIDMappings.GitHubIDToUID {
+		if _, ok := employees[uid]; !ok {
+			v.add(uid, "GitHub ID %q maps to %q, which is not an employee", githubID, uid)
+		}
+	}
+}
+
+func checkDanglingParents(v *validation) {
+	check := func(typ, name string, parent *ParentInfo) {
+		if parent != nil && !v.entityExists(parent.Type, parent.Name) {
+			v.add(name, "%s %q has parent %s %q, which does not exist", typ, name, parent.Type, parent.Name)
+		}
+	}
+	for name, team := range v.data.Lookups.Teams {
+		check("team", name, team.Parent)
+	}
+	for name, org := range v.data.Lookups.Orgs {
+		check("org", name, org.Parent)
+	}
+	for name, pillar := range v.data.Lookups.Pillars {
+		check("pillar", name, pillar.Parent)
+	}
+	for name, tg := range v.data.Lookups.TeamGroups {
+		check("team_group", name, tg.Parent)
+	}
+	for name, component := range v.data.Lookups.Components {
+		check("component", name, component.Parent)
+	}
+}
+
+func checkUnknownEntities(v *validation) {
+	type ref struct{ typ, name string }
+	// missing maps each unresolved entity to where it is referenced, keeping
+	// the first place in sorted order so reports are stable.
+	missing := make(map[ref]string)
+	note := func(typ, name, where string) {
+		r := ref{typ, name}
+		if prev, seen := missing[r]; seen {
+			missing[r] = min(prev, where)
+		} else if !v.entityExists(typ, name) {
+			missing[r] = where
+		}
+	}
+
+	for _, memberships := range v.data.Indexes.Membership.MembershipIndex {
+		for _, m := range memberships {
+			// Teams are reported by CheckMissingTeam.
+			if m.Type != string(MembershipTeam) {
+				note(m.Type, m.Name, "membership index")
+			}
+		}
+	}
+	for project, components := range v.data.Indexes.Jira {
+		for component, owners := range components {
+			for _, o := range owners {
+				note(o.Type, o.Name, fmt.Sprintf("Jira %s/%s", project, component))
+			}
+		}
+	}
+	for component, owners := range v.data.Indexes.ComponentOwnership {
+		for _, o := range owners {
+			note(o.Type, o.Name, "component "+component)
+		}
+	}
+
+	for r, where := range missing {
+		v.add(r.name, "%s references %s %q, which does not exist", where, r.typ, r.name)
+	}
+}
+
+// checkManagerCycles follows every employee's manager chain, sorting all UIDs
+// first so cycles are reported from a stable starting point. Like
+// checkHierarchyCycles it is skipped under WithoutConsistencyChecks.
+func checkManagerCycles(v *validation) {
+	if !v.hasEmployees() {
+		return
+	}
+	employees := v.data.Lookups.Employees
+	uids := slices.Sorted(maps.Keys(employees))
+	cycles := findCycles(uids, func(uid string) (string, bool) {
+		manager := employees[uid].ManagerUID
+		_, ok := employees[manager]
+		return manager, ok
+	})
+	for _, cycle := range cycles {
+		v.add(cycle[0], "manager chain loops: %s -> %s", strings.Join(cycle, " -> "), cycle[0])
+	}
+}
+
+// checkHierarchyCycles follows the parent links of every team, org, pillar
+// and team group.
+func checkHierarchyCycles(v *validation) {
+	type entity struct{ typ, name string }
+	lookups := &v.data.Lookups
+	parentOf := make(map[entity]*ParentInfo)
+	for name, team := range lookups.Teams {
+		parentOf[entity{"team", name}] = team.Parent
+	}
+	for name, org := range lookups.Orgs {
+		parentOf[entity{"org", name}] = org.Parent
+	}
+	for name, pillar := range lookups.Pillars {
+		parentOf[entity{"pillar", name}] = pillar.Parent
+	}
+	for name, tg := range lookups.TeamGroups {
+		parentOf[entity{"team_group", name}] = tg.Parent
+	}
+
+	nodes := slices.SortedFunc(maps.Keys(parentOf), func(a, b entity) int {
+		if c := strings.Compare(a.typ, b.typ); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	cycles := findCycles(nodes, func(e entity) (entity, bool) {
+		parent := parentOf[e]
+		if parent == nil {
+			return entity{}, false
+		}
+		p := entity{strings.ToLower(parent.Type), parent.Name}
+		_, ok := parentOf[p]
+		return p, ok
+	})
+	for _, cycle := range cycles {
+		path := make([]string, 0, len(cycle)+1)
+		for _, e := range append(cycle, cycle[0]) {
+			path = append(path, fmt.Sprintf("%s %q", e.typ, e.name))
+		}
+		v.add(cycle[0].name, "hierarchy loops: %s", strings.Join(path, " -> "))
+	}
+}
+
+// findCycles follows parent links from each of nodes, in order, and returns
+// every cycle found, each once, starting at the node where it was entered.
+func findCycles[K comparable](nodes []K, parent func(K) (K, bool)) [][]K {
+	const (
+		onPath = iota + 1
+		done
+	)
+	state := make(map[K]int, len(nodes))
+	cycles := [][]K{}
+
+	for _, start := range nodes {
+		var path []K
+		n, ok := start, true
+		for ok && state[n] == 0 {
+			state[n] = onPath
+			path = append(path, n)
+			n, ok = parent(n)
+		}
+		if ok && state[n] == onPath {
+			i := slices.Index(path, n)
+			cycles = append(cycles, slices.Clone(path[i:]))
+		}
+		for _, p := range path {
+			state[p] = done
+		}
+	}
+	return cycles
+}
+
+func checkDuplicateIDs(v *validation) {
+	if !v.hasEmployees() {
+		return
+	}
+	labels := map[string]string{"slack": "Slack ID", "github": "GitHub ID", "email": "email"}
+	for _, dup := range duplicateIDs(v.data.Lookups.Employees) {
+		v.add(dup.Value, "%s %q is shared by employees %s", labels[dup.Kind], dup.Value, strings.Join(dup.UIDs, ", "))
+	}
+}
+
+func checkNameCollisions(v *validation) {
+	types := make(map[string][]string)
+	note := func(typ string, names []string) {
+		for _, name := range names {
+			types[name] = append(types[name], typ)
+		}
+	}
+	note("team", slices.Collect(maps.Keys(v.data.Lookups.Teams)))
+	note("org", slices.Collect(maps.Keys(v.data.Lookups.Orgs)))
+	note("pillar", slices.Collect(maps.Keys(v.data.Lookups.Pillars)))
+	note("team_group", slices.Collect(maps.Keys(v.data.Lookups.TeamGroups)))
+
+	for name, typs := range types {
+		if len(typs) > 1 {
+			v.add(name, "name %q is shared by entities of types %s", name, strings.Join(typs, ", "))
+		}
+	}
+}
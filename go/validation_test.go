@@ -0,0 +1,419 @@
+package orgdatacore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateData(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Data)
+		want   []ValidationIssue
+	}{
+		{
+			name:   "valid",
+			mutate: func(*Data) {},
+			want:   []ValidationIssue{},
+		},
+		{
+			name:   "missing employees",
+			mutate: func(d *Data) { d.Lookups.Employees = nil },
+			want: []ValidationIssue{
+				{Check: CheckRequiredSections, Severity: SeverityError, Entity: "employees", Message: "missing lookups.employees"},
+			},
+		},
+		{
+			name: "pii_free with employees",
+			mutate: func(d *Data) {
+				d.Metadata.PIIFree = true
+				d.Indexes.Membership.MembershipIndex = nil
+			},
+			want: []ValidationIssue{
+				{Check: CheckPIIFree, Severity: SeverityError, Entity: "employees", Message: "pii_free is set but lookups.employees is not empty"},
+			},
+		},
+		{
+			name: "dangling manager",
+			mutate: func(d *Data) {
+				emp := d.Lookups.Employees["testuser1"]
+				emp.ManagerUID = "gone"
+				d.Lookups.Employees["testuser1"] = emp
+			},
+			want: []ValidationIssue{
+				{Check: CheckDanglingManager, Severity: SeverityWarning, Entity: "testuser1", Message: `employee "testuser1" has manager "gone", which is not an employee`},
+			},
+		},
+		{
+			name: "unknown member and missing team",
+			mutate: func(d *Data) {
+				team := d.Lookups.Teams["test-squad"]
+				team.Group.ResolvedPeopleUIDList = append(team.Group.ResolvedPeopleUIDList, "ghost")
+				d.Lookups.Teams["test-squad"] = team
+				d.Indexes.Membership.MembershipIndex["testuser1"] = append(d.Indexes.Membership.MembershipIndex["testuser1"], MembershipInfo{Name: "old-team", Type: "team"})
+			},
+			want: []ValidationIssue{
+				{Check: CheckUnknownMember, Severity: SeverityWarning, Entity: "test-squad", Message: `team "test-squad" lists member "ghost", which is not an employee`},
+				{Check: CheckMissingTeam, Severity: SeverityWarning, Entity: "old-team", Message: `membership index references team "old-team" for 1 employees, but it is not in lookups.teams`},
+			},
+		},
+		{
+			name: "membership mismatch",
+			mutate: func(d *Data) {
+				team := d.Lookups.Teams["test-squad"]
+				team.Group.ResolvedPeopleUIDList = []string{"testuser1"}
+				d.Lookups.Teams["test-squad"] = team
+				d.Indexes.Membership.MembershipIndex["testuser1"] = []MembershipInfo{}
+				d.Indexes.Membership.MembershipIndex["testuser2"] = []MembershipInfo{{Name: "test-squad", Type: "team"}}
+			},
+			want: []ValidationIssue{
+				{Check: CheckMembershipMismatch, Severity: SeverityWarning, Entity: "test-squad", Message: `membership index puts "testuser2" in team "test-squad", but the team does not list them`},
+				{Check: CheckMembershipMismatch, Severity: SeverityWarning, Entity: "test-squad", Message: `team "test-squad" lists "testuser1", but the membership index does not`},
+			},
+		},
+		{
+			name: "unknown uids",
+			mutate: func(d *Data) {
+				d.Indexes.Membership.MembershipIndex["former"] = []MembershipInfo{{Name: "test-squad", Type: "team"}}
+				d.Indexes.SlackIDMappings.SlackUIDToUID["U999999"] = "former"
+				d.Indexes.GitHubIDMappings.GitHubIDToUID["ghost"] = "nobody"
+				org := d.Lookups.Orgs["test-division"]
+				org.Group.ResolvedPeopleUIDList = append(org.Group.ResolvedPeopleUIDList, "former")
+				d.Lookups.Orgs["test-division"] = org
+			},
+			want: []ValidationIssue{
+				{Check: CheckUnknownMember, Severity: SeverityWarning, Entity: "test-division", Message: `org "test-division" lists member "former", which is not an employee`},
+				{Check: CheckUnknownUID, Severity: SeverityWarning, Entity: "former", Message: `Slack ID "U999999" maps to "former", which is not an employee`},
+				{Check: CheckUnknownUID, Severity: SeverityWarning, Entity: "former", Message: `membership index lists "former", which is not an employee`},
+				{Check: CheckUnknownUID, Severity: SeverityWarning, Entity: "nobody", Message: `GitHub ID "ghost" maps to "nobody", which is not an employee`},
+			},
+		},
+		{
+			name: "dangling parent and unknown entities",
+			mutate: func(d *Data) {
+				team := d.Lookups.Teams["test-squad"]
+				team.Parent = &ParentInfo{Name: "renamed-division", Type: "org"}
+				d.Lookups.Teams["test-squad"] = team
+				d.Indexes.Membership.MembershipIndex["testuser2"] = append(d.Indexes.Membership.MembershipIndex["testuser2"], MembershipInfo{Name: "old-pillar", Type: "pillar"})
+				d.Indexes.Jira = JiraIndex{"PROJ": {"_project_level": {{Name: "old-org", Type: "org"}}}}
+				d.Indexes.ComponentOwnership = map[string][]ComponentOwnerInfo{"api": {{Name: "old-org", Type: "org"}, {Name: "test-squad", Type: "team"}}}
+			},
+			want: []ValidationIssue{
+				{Check: CheckDanglingParent, Severity: SeverityWarning, Entity: "test-squad", Message: `team "test-squad" has parent org "renamed-division", which does not exist`},
+				{Check: CheckUnknownEntity, Severity: SeverityWarning, Entity: "old-org", Message: `Jira PROJ/_project_level references org "old-org", which does not exist`},
+				{Check: CheckUnknownEntity, Severity: SeverityWarning, Entity: "old-pillar", Message: `membership index references pillar "old-pillar", which does not exist`},
+			},
+		},
+		{
+			name: "manager cycles",
+			mutate: func(d *Data) {
+				setManager := func(uid, manager string) {
+					emp := d.Lookups.Employees[uid]
+					emp.UID, emp.ManagerUID = uid, manager
+					d.Lookups.Employees[uid] = emp
+				}
+				setManager("testuser1", "testuser2")
+				setManager("testuser2", "testuser1")
+				setManager("testuser3", "testuser1") // leads into the cycle
+				setManager("testuser4", "testuser4")
+			},
+			want: []ValidationIssue{
+				{Check: CheckManagerCycle, Severity: SeverityWarning, Entity: "testuser1", Message: "manager chain loops: testuser1 -> testuser2 -> testuser1"},
+				{Check: CheckManagerCycle, Severity: SeverityWarning, Entity: "testuser4", Message: "manager chain loops: testuser4 -> testuser4"},
+			},
+		},
+		{
+			name: "hierarchy cycle",
+			mutate: func(d *Data) {
+				org := d.Lookups.Orgs["test-division"]
+				org.Parent = &ParentInfo{Name: "test-squad", Type: "team"}
+				d.Lookups.Orgs["test-division"] = org
+			},
+			want: []ValidationIssue{
+				{Check: CheckHierarchyCycle, Severity: SeverityWarning, Entity: "test-division", Message: `hierarchy loops: org "test-division" -> team "test-squad" -> org "test-division"`},
+			},
+		},
+		{
+			name: "duplicate external ids",
+			mutate: func(d *Data) {
+				emp := d.Lookups.Employees["testuser2"]
+				emp.SlackUID = "U111111"
+				emp.Email = "TestUser1@example.com"
+				d.Lookups.Employees["testuser2"] = emp
+			},
+			want: []ValidationIssue{
+				{Check: CheckDuplicateID, Severity: SeverityWarning, Entity: "U111111", Message: `Slack ID "U111111" is shared by employees testuser1, testuser2`},
+				{Check: CheckDuplicateID, Severity: SeverityWarning, Entity: "testuser1@example.com", Message: `email "testuser1@example.com" is shared by employees testuser1, testuser2`},
+			},
+		},
+		{
+			name: "name collisions",
+			mutate: func(d *Data) {
+				d.Lookups.Pillars = map[string]Pillar{"test-squad": {Name: "test-squad", Type: "pillar"}}
+				d.Lookups.TeamGroups = map[string]TeamGroup{
+					"test-squad":    {Name: "test-squad", Type: "team_group"},
+					"test-division": {Name: "test-division", Type: "team_group"},
+				}
+			},
+			want: []ValidationIssue{
+				{Check: CheckNameCollision, Severity: SeverityWarning, Entity: "test-division", Message: `name "test-division" is shared by entities of types org, team_group`},
+				{Check: CheckNameCollision, Severity: SeverityWarning, Entity: "test-squad", Message: `name "test-squad" is shared by entities of types team, pillar, team_group`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := CreateTestData()
+			tt.mutate(data)
+			report := ValidateData(data)
+			if !reflect.DeepEqual(report.Issues, tt.want) {
+				t.Errorf("Issues = %+v\nwant %+v", report.Issues, tt.want)
+			}
+			if report.DataVersion != data.Metadata.DataVersion {
+				t.Errorf("DataVersion = %q, want %q", report.DataVersion, data.Metadata.DataVersion)
+			}
+		})
+	}
+}
+
+func TestValidationThreshold(t *testing.T) {
+	ctx := context.Background()
+	good := jsonSource(t, "good", CreateTestData())
+	bad := CreateTestData()
+	emp := bad.Lookups.Employees["testuser1"]
+	emp.ManagerUID = "gone"
+	bad.Lookups.Employees["testuser1"] = emp
+	bad.Metadata.DataVersion = "bad"
+
+	tests := []struct {
+		name     string
+		opts     []ServiceOption
+		rejected bool
+	}{
+		{"warnings pass by default", nil, false},
+		{"warning threshold", []ServiceOption{WithValidationThreshold(SeverityWarning)}, true},
+		{"check raised to error", []ServiceOption{WithCheckSeverity(CheckDanglingManager, SeverityError)}, true},
+		{"check lowered to info", []ServiceOption{WithValidationThreshold(SeverityWarning), WithCheckSeverity(CheckDanglingManager, SeverityInfo)}, false},
+		{"invalid options ignored", []ServiceOption{WithValidationThreshold("fatal"), WithCheckSeverity("spelling", SeverityError)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(tt.opts...)
+			if err := service.LoadFromDataSource(ctx, good); err != nil {
+				t.Fatalf("LoadFromDataSource(good): %v", err)
+			}
+
+			err := service.LoadFromDataSource(ctx, jsonSource(t, "bad", bad))
+			if !tt.rejected {
+				if err != nil {
+					t.Fatalf("LoadFromDataSource(bad): %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, ErrInvalidData) {
+				t.Fatalf("LoadFromDataSource(bad) = %v, want a *ValidationError wrapping ErrInvalidData", err)
+			}
+			if len(verr.Issues) != 1 || verr.Issues[0].Check != CheckDanglingManager {
+				t.Errorf("Issues = %+v, want the dangling manager", verr.Issues)
+			}
+			if !strings.Contains(err.Error(), `manager "gone"`) {
+				t.Errorf("error %q does not describe the issue", err)
+			}
+			if v := service.GetVersion(); v.EmployeeCount != 2 || service.GetEmployeeByUID("testuser1").ManagerUID != "" {
+				t.Error("rejected data was swapped in")
+			}
+		})
+	}
+}
+
+func TestWithoutConsistencyChecks(t *testing.T) {
+	ctx := context.Background()
+	data := CreateTestData()
+	emp := data.Lookups.Employees["testuser1"]
+	emp.ManagerUID = "testuser1"
+	data.Lookups.Employees["testuser1"] = emp
+	org := data.Lookups.Orgs["test-division"]
+	org.Parent = &ParentInfo{Name: "test-squad", Type: "team"}
+	data.Lookups.Orgs["test-division"] = org
+	data.Indexes.Membership.MembershipIndex["testuser1"] = []MembershipInfo{}
+
+	var checks []ValidationCheck
+	for _, issue := range ValidateData(data).Issues {
+		checks = append(checks, issue.Check)
+	}
+	if want := []ValidationCheck{CheckMembershipMismatch, CheckManagerCycle, CheckHierarchyCycle}; !reflect.DeepEqual(checks, want) {
+		t.Fatalf("ValidateData checks = %v, want %v", checks, want)
+	}
+
+	if err := NewService(WithValidationThreshold(SeverityWarning)).LoadFromDataSource(ctx, jsonSource(t, "cycles", data)); err == nil {
+		t.Error("LoadFromDataSource = nil, want the consistency issues to reject it")
+	}
+	service := NewService(WithValidationThreshold(SeverityWarning), WithoutConsistencyChecks())
+	if err := service.LoadFromDataSource(ctx, jsonSource(t, "cycles", data)); err != nil {
+		t.Errorf("LoadFromDataSource under WithoutConsistencyChecks: %v", err)
+	}
+}
+
+func TestValidationSkipsExcludedSections(t *testing.T) {
+	data := CreateTestData()
+	emp := data.Lookups.Employees["testuser1"]
+	emp.ManagerUID = "gone"
+	data.Lookups.Employees["testuser1"] = emp
+	data.Indexes.Membership.MembershipIndex = nil
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	service := NewService(WithSections(SectionTeams), WithValidationThreshold(SeverityWarning))
+	if err := service.LoadFromDataSource(context.Background(), NewFakeDataSource(string(raw))); err != nil {
+		t.Errorf("LoadFromDataSource without employees or membership: %v", err)
+	}
+}
+
+func TestValidateDataSource(t *testing.T) {
+	ctx := context.Background()
+	bad := CreateTestData()
+	emp := bad.Lookups.Employees["testuser1"]
+	emp.ManagerUID = "gone"
+	bad.Lookups.Employees["testuser1"] = emp
+	bad.Metadata.DataVersion = "bad"
+
+	tests := []struct {
+		name     string
+		opts     []ServiceOption
+		source   DataSource
+		issues   int
+		wantErr  bool
+		noReport bool
+	}{
+		{name: "valid", source: jsonSource(t, "good", CreateTestData())},
+		{name: "warnings", source: jsonSource(t, "bad", bad), issues: 1},
+		{name: "rejected", opts: []ServiceOption{WithValidationThreshold(SeverityWarning)}, source: jsonSource(t, "bad", bad), issues: 1, wantErr: true},
+		{name: "undecodable", source: NewFakeDataSource("{"), wantErr: true, noReport: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(tt.opts...)
+			report, err := service.ValidateDataSource(ctx, tt.source)
+			var loadErr *LoadError
+			if tt.wantErr != errors.As(err, &loadErr) {
+				t.Fatalf("ValidateDataSource error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.noReport {
+				if report != nil {
+					t.Errorf("report = %+v, want nil", report)
+				}
+			} else if report == nil || len(report.Issues) != tt.issues {
+				t.Errorf("report = %+v, want %d issues", report, tt.issues)
+			}
+
+			if service.GetVersion().EmployeeCount != 0 || service.LoadStats().Attempts != 0 {
+				t.Error("ValidateDataSource loaded the data")
+			}
+		})
+	}
+}
+
+func TestRequiredSections(t *testing.T) {
+	noMembership := CreateTestData()
+	noMembership.Indexes.Membership.MembershipIndex = nil
+	noJira := CreateTestData()
+	noJira.Indexes.Jira = nil
+
+	tests := []struct {
+		name    string
+		opts    []ServiceOption
+		data    *Data
+		missing []string
+	}{
+		{"default", nil, noMembership, []string{"membership"}},
+		{"membership not required", []ServiceOption{WithRequiredSections(SectionEmployees)}, noMembership, nil},
+		{"jira required", []ServiceOption{WithRequiredSections(SectionEmployees, SectionJira)}, noJira, []string{"jira"}},
+		{"jira required but skipped", []ServiceOption{WithRequiredSections(SectionJira), WithSections(SectionEmployees)}, noJira, nil},
+		{"nothing required", []ServiceOption{WithRequiredSections()}, &Data{}, nil},
+		{"invalid sections ignored", []ServiceOption{WithRequiredSections("payroll")}, noMembership, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewService(tt.opts...).LoadFromDataSource(context.Background(), jsonSource(t, "data", tt.data))
+			if tt.missing == nil {
+				if err != nil {
+					t.Fatalf("LoadFromDataSource: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("LoadFromDataSource = %v, want a *ValidationError", err)
+			}
+			var missing []string
+			for _, issue := range verr.Issues {
+				if issue.Check == CheckRequiredSections {
+					missing = append(missing, issue.Entity)
+				}
+			}
+			if !reflect.DeepEqual(missing, tt.missing) {
+				t.Errorf("missing sections = %v, want %v", missing, tt.missing)
+			}
+		})
+	}
+}
+
+func TestMaxShrink(t *testing.T) {
+	ctx := context.Background()
+	withEmployees := func(n int) *Data {
+		data := CreateTestData()
+		for i := range n - len(data.Lookups.Employees) {
+			uid := fmt.Sprintf("extra%d", i)
+			data.Lookups.Employees[uid] = Employee{UID: uid}
+		}
+		return data
+	}
+
+	tests := []struct {
+		name     string
+		opts     []ServiceOption
+		next     int
+		rejected bool
+	}{
+		{"disabled by default", nil, 2, false},
+		{"small drop", []ServiceOption{WithMaxShrink(0.3)}, 8, false},
+		{"exact limit", []ServiceOption{WithMaxShrink(0.3)}, 7, false},
+		{"large drop", []ServiceOption{WithMaxShrink(0.3)}, 6, true},
+		{"growth", []ServiceOption{WithMaxShrink(0.3)}, 20, false},
+		{"invalid fraction", []ServiceOption{WithMaxShrink(1.5)}, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewService(tt.opts...)
+			if err := service.LoadFromDataSource(ctx, jsonSource(t, "current", withEmployees(10))); err != nil {
+				t.Fatalf("LoadFromDataSource(current): %v", err)
+			}
+
+			err := service.LoadFromDataSource(ctx, jsonSource(t, "next", withEmployees(tt.next)))
+			if tt.rejected != errors.Is(err, ErrDataShrunk) {
+				t.Fatalf("LoadFromDataSource(next) = %v, want rejected %v", err, tt.rejected)
+			}
+			want := tt.next
+			if tt.rejected {
+				want = 10
+			}
+			if got := service.GetVersion().EmployeeCount; got != want {
+				t.Errorf("EmployeeCount = %d, want %d", got, want)
+			}
+		})
+	}
+}
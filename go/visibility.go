@@ -0,0 +1,576 @@
+package orgdatacore
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// VisibilityPolicy is what one audience of a service may see.
+type VisibilityPolicy struct {
+	// Orgs, if not empty, limits the caller to these organizations: teams,
+	// orgs, pillars and team groups are visible when they are one of them or
+	// under one of them, and employees when they are members of one.
+	// Components, Jira projects and context types are not scoped, but the
+	// teams owning them are.
+	Orgs []string
+	// HideCostCenters clears the cost center of every employee returned.
+	HideCostCenters bool
+	// HideEmails clears the email of every employee returned, and
+	// GetEmployeeByEmail returns nil, so a caller cannot probe for emails.
+	HideEmails bool
+}
+
+// VisibleService is a ServiceInterface decorator that enforces a
+// VisibilityPolicy on every query, so a service answering several audiences
+// can hand each one a view instead of filtering results in every handler:
+//
+//	view := orgdatacore.NewVisibleService(svc, orgdatacore.VisibilityPolicy{
+//		Orgs:            []string{caller.Org},
+//		HideCostCenters: !caller.IsHR,
+//	})
+//
+// What is not visible behaves as if it did not exist: lookups return nil,
+// lists leave it out, and membership checks are false. Hierarchy paths stop
+// at the scoping org. A view cannot load or watch data: LoadFromDataSource and
+// StartDataSourceWatcher fail with ErrVisibleLoad, and StopWatcher does
+// nothing; manage the data through the wrapped service.
+type VisibleService struct {
+	inner  ServiceInterface
+	policy VisibilityPolicy
+}
+
+var _ ServiceInterface = (*VisibleService)(nil)
+
+// NewVisibleService wraps inner with policy.
+func NewVisibleService(inner ServiceInterface, policy VisibilityPolicy) *VisibleService {
+	policy.Orgs = slices.Clone(policy.Orgs)
+	return &VisibleService{inner: inner, policy: policy}
+}
+
+// Unwrap returns the wrapped service.
+func (v *VisibleService) Unwrap() ServiceInterface {
+	return v.inner
+}
+
+// scoped reports whether the policy limits visibility to some orgs.
+func (v *VisibleService) scoped() bool {
+	return len(v.policy.Orgs) > 0
+}
+
+func (v *VisibleService) scopeOrg(e HierarchyPathEntry) bool {
+	return e.Type == string(EntityOrg) && slices.Contains(v.policy.Orgs, e.Name)
+}
+
+// entityVisible reports whether the team, org, pillar or team group is
+// visible.
+func (v *VisibleService) entityVisible(name, entityType string) bool {
+	if !v.scoped() {
+		return true
+	}
+	return slices.ContainsFunc(v.inner.GetHierarchyPath(name, entityType), v.scopeOrg)
+}
+
+func (v *VisibleService) employeeVisible(uid string) bool {
+	if !v.scoped() {
+		return true
+	}
+	for _, org := range v.policy.Orgs {
+		if v.inner.IsEmployeeInOrg(uid, org) {
+			return true
+		}
+	}
+	return false
+}
+
+// employee returns emp redacted, or nil if it is not visible.
+func (v *VisibleService) employee(emp *Employee) *Employee {
+	if emp == nil || !v.employeeVisible(emp.UID) {
+		return nil
+	}
+	out := *emp
+	v.redact(&out)
+	return &out
+}
+
+func (v *VisibleService) employees(emps []Employee) []Employee {
+	out := make([]Employee, 0, len(emps))
+	for _, emp := range emps {
+		if v.employeeVisible(emp.UID) {
+			v.redact(&emp)
+			out = append(out, emp)
+		}
+	}
+	return out
+}
+
+func (v *VisibleService) redact(emp *Employee) {
+	if v.policy.HideCostCenters {
+		emp.CostCenter = 0
+	}
+	if v.policy.HideEmails {
+		emp.Email = ""
+	}
+}
+
+// visibleNames keeps the names of the visible entities of entityType.
+func (v *VisibleService) visibleNames(names []string, entityType EntityType) []string {
+	if !v.scoped() {
+		return names
+	}
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if v.entityVisible(name, string(entityType)) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// visibleOwners keeps the owners, such as JiraOwnerInfo, that are visible.
+func visibleOwners[T any](v *VisibleService, owners []T, nameType func(T) (string, string)) []T {
+	if !v.scoped() {
+		return owners
+	}
+	out := make([]T, 0, len(owners))
+	for _, owner := range owners {
+		if v.entityVisible(nameType(owner)) {
+			out = append(out, owner)
+		}
+	}
+	return out
+}
+
+// visibleEntity returns entity if the entity named name is visible.
+func visibleEntity[T any](v *VisibleService, entity *T, name string, entityType EntityType) *T {
+	if entity == nil || !v.entityVisible(name, string(entityType)) {
+		return nil
+	}
+	return entity
+}
+
+// visibleEntities keeps the visible entities of entityType.
+func visibleEntities[T any](v *VisibleService, entities []T, name func(T) string, entityType EntityType) []T {
+	if !v.scoped() {
+		return entities
+	}
+	out := make([]T, 0, len(entities))
+	for _, entity := range entities {
+		if v.entityVisible(name(entity), string(entityType)) {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+func (v *VisibleService) GetEmployeeByUID(uid string) *Employee {
+	return v.employee(v.inner.GetEmployeeByUID(uid))
+}
+
+func (v *VisibleService) GetEmployeeBySlackID(slackID string) *Employee {
+	return v.employee(v.inner.GetEmployeeBySlackID(slackID))
+}
+
+func (v *VisibleService) GetEmployeeByGitHubID(githubID string) *Employee {
+	return v.employee(v.inner.GetEmployeeByGitHubID(githubID))
+}
+
+func (v *VisibleService) GetEmployeeByEmail(email string) *Employee {
+	if v.policy.HideEmails {
+		return nil
+	}
+	return v.employee(v.inner.GetEmployeeByEmail(email))
+}
+
+func (v *VisibleService) GetManagerForEmployee(uid string) *Employee {
+	if !v.employeeVisible(uid) {
+		return nil
+	}
+	return v.employee(v.inner.GetManagerForEmployee(uid))
+}
+
+func (v *VisibleService) GetTeamByName(teamName string) *Team {
+	team := v.inner.GetTeamByName(teamName)
+	if team == nil {
+		return nil
+	}
+	return visibleEntity(v, team, team.Name, EntityTeam)
+}
+
+func (v *VisibleService) GetTeamsBySlackChannel(channel string) []Team {
+	return visibleEntities(v, v.inner.GetTeamsBySlackChannel(channel), func(t Team) string { return t.Name }, EntityTeam)
+}
+
+func (v *VisibleService) GetOrgByName(orgName string) *Org {
+	org := v.inner.GetOrgByName(orgName)
+	if org == nil {
+		return nil
+	}
+	return visibleEntity(v, org, org.Name, EntityOrg)
+}
+
+func (v *VisibleService) GetPillarByName(pillarName string) *Pillar {
+	pillar := v.inner.GetPillarByName(pillarName)
+	if pillar == nil {
+		return nil
+	}
+	return visibleEntity(v, pillar, pillar.Name, EntityPillar)
+}
+
+func (v *VisibleService) GetTeamGroupByName(teamGroupName string) *TeamGroup {
+	tg := v.inner.GetTeamGroupByName(teamGroupName)
+	if tg == nil {
+		return nil
+	}
+	return visibleEntity(v, tg, tg.Name, EntityTeamGroup)
+}
+
+func (v *VisibleService) GetUserMemberships(uid string) []MembershipInfo {
+	if !v.employeeVisible(uid) {
+		return []MembershipInfo{}
+	}
+	return visibleOwners(v, v.inner.GetUserMemberships(uid), func(m MembershipInfo) (string, string) { return m.Name, m.Type })
+}
+
+func (v *VisibleService) GetUserTeams(uid string) []string {
+	if !v.employeeVisible(uid) {
+		return []string{}
+	}
+	return v.visibleNames(v.inner.GetUserTeams(uid), EntityTeam)
+}
+
+func (v *VisibleService) GetTeamsForUID(uid string) []string {
+	if !v.employeeVisible(uid) {
+		return []string{}
+	}
+	return v.visibleNames(v.inner.GetTeamsForUID(uid), EntityTeam)
+}
+
+func (v *VisibleService) GetTeamsForSlackID(slackID string) []string {
+	if v.GetEmployeeBySlackID(slackID) == nil {
+		return []string{}
+	}
+	return v.visibleNames(v.inner.GetTeamsForSlackID(slackID), EntityTeam)
+}
+
+func (v *VisibleService) GetTeamMembers(teamName string) []Employee {
+	if !v.entityVisible(teamName, string(EntityTeam)) {
+		return []Employee{}
+	}
+	return v.employees(v.inner.GetTeamMembers(teamName))
+}
+
+func (v *VisibleService) GetOrgMembers(orgName string) []Employee {
+	if !v.entityVisible(orgName, string(EntityOrg)) {
+		return []Employee{}
+	}
+	return v.employees(v.inner.GetOrgMembers(orgName))
+}
+
+func (v *VisibleService) IsEmployeeInTeam(uid string, teamName string) bool {
+	return v.employeeVisible(uid) && v.entityVisible(teamName, string(EntityTeam)) && v.inner.IsEmployeeInTeam(uid, teamName)
+}
+
+func (v *VisibleService) AreEmployeesInTeam(uids []string, teamName string) map[string]bool {
+	out := v.inner.AreEmployeesInTeam(uids, teamName)
+	teamVisible := v.entityVisible(teamName, string(EntityTeam))
+	for uid, member := range out {
+		out[uid] = member && teamVisible && v.employeeVisible(uid)
+	}
+	return out
+}
+
+func (v *VisibleService) IsEmployeeInAnyTeam(uid string, teams []string) bool {
+	if !v.employeeVisible(uid) {
+		return false
+	}
+	visible := slices.DeleteFunc(slices.Clone(teams), func(team string) bool { return !v.entityVisible(team, string(EntityTeam)) })
+	return v.inner.IsEmployeeInAnyTeam(uid, visible)
+}
+
+func (v *VisibleService) IsSlackUserInTeam(slackID string, teamName string) bool {
+	return v.GetEmployeeBySlackID(slackID) != nil && v.entityVisible(teamName, string(EntityTeam)) && v.inner.IsSlackUserInTeam(slackID, teamName)
+}
+
+func (v *VisibleService) IsEmployeeInOrg(uid string, orgName string) bool {
+	return v.employeeVisible(uid) && v.entityVisible(orgName, string(EntityOrg)) && v.inner.IsEmployeeInOrg(uid, orgName)
+}
+
+func (v *VisibleService) IsSlackUserInOrg(slackID string, orgName string) bool {
+	return v.GetEmployeeBySlackID(slackID) != nil && v.entityVisible(orgName, string(EntityOrg)) && v.inner.IsSlackUserInOrg(slackID, orgName)
+}
+
+func (v *VisibleService) GetUserOrganizations(slackUserID string) []OrgInfo {
+	if v.GetEmployeeBySlackID(slackUserID) == nil {
+		return []OrgInfo{}
+	}
+	return visibleOwners(v, v.inner.GetUserOrganizations(slackUserID), func(o OrgInfo) (string, string) {
+		return o.Name, orgInfoEntityType(o.Type)
+	})
+}
+
+// orgInfoEntityType maps the type of an OrgInfo to the entity type it names.
+func orgInfoEntityType(t OrgInfoType) string {
+	switch t {
+	case OrgTypeOrganization:
+		return string(EntityOrg)
+	case OrgTypeTeam, OrgTypeParentTeam:
+		return string(EntityTeam)
+	case OrgTypePillar:
+		return string(EntityPillar)
+	case OrgTypeTeamGroup:
+		return string(EntityTeamGroup)
+	}
+	return ""
+}
+
+func (v *VisibleService) GetTeamEscalation(teamName string) []EscalationContactInfo {
+	if !v.entityVisible(teamName, string(EntityTeam)) {
+		return []EscalationContactInfo{}
+	}
+	return v.inner.GetTeamEscalation(teamName)
+}
+
+func (v *VisibleService) GetVersion() DataVersion {
+	return v.inner.GetVersion()
+}
+
+func (v *VisibleService) GetDataAge() time.Duration {
+	return v.inner.GetDataAge()
+}
+
+func (v *VisibleService) IsDataStale(maxAge time.Duration) bool {
+	return v.inner.IsDataStale(maxAge)
+}
+
+func (v *VisibleService) LoadFromDataSource(context.Context, DataSource) error {
+	return ErrVisibleLoad
+}
+
+func (v *VisibleService) StartDataSourceWatcher(context.Context, DataSource) error {
+	return ErrVisibleLoad
+}
+
+func (v *VisibleService) StopWatcher() {}
+
+func (v *VisibleService) GetAllEmployeeUIDs() []string {
+	if !v.scoped() {
+		return v.inner.GetAllEmployeeUIDs()
+	}
+	uids := v.inner.GetAllEmployeeUIDs()
+	out := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		if v.employeeVisible(uid) {
+			out = append(out, uid)
+		}
+	}
+	return out
+}
+
+func (v *VisibleService) GetAllEmployees() []Employee {
+	return v.employees(v.inner.GetAllEmployees())
+}
+
+func (v *VisibleService) GetAllTeamNames() []string {
+	return v.visibleNames(v.inner.GetAllTeamNames(), EntityTeam)
+}
+
+func (v *VisibleService) GetAllTeams() []Team {
+	return visibleEntities(v, v.inner.GetAllTeams(), func(t Team) string { return t.Name }, EntityTeam)
+}
+
+func (v *VisibleService) GetAllOrgNames() []string {
+	return v.visibleNames(v.inner.GetAllOrgNames(), EntityOrg)
+}
+
+func (v *VisibleService) GetAllOrgs() []Org {
+	return visibleEntities(v, v.inner.GetAllOrgs(), func(o Org) string { return o.Name }, EntityOrg)
+}
+
+func (v *VisibleService) GetAllPillarNames() []string {
+	return v.visibleNames(v.inner.GetAllPillarNames(), EntityPillar)
+}
+
+func (v *VisibleService) GetAllPillars() []Pillar {
+	return visibleEntities(v, v.inner.GetAllPillars(), func(p Pillar) string { return p.Name }, EntityPillar)
+}
+
+func (v *VisibleService) GetAllTeamGroupNames() []string {
+	return v.visibleNames(v.inner.GetAllTeamGroupNames(), EntityTeamGroup)
+}
+
+func (v *VisibleService) GetAllTeamGroups() []TeamGroup {
+	return visibleEntities(v, v.inner.GetAllTeamGroups(), func(tg TeamGroup) string { return tg.Name }, EntityTeamGroup)
+}
+
+func (v *VisibleService) GetHierarchyPath(entityName string, entityType string) []HierarchyPathEntry {
+	path := v.inner.GetHierarchyPath(entityName, entityType)
+	if !v.scoped() {
+		return path
+	}
+	for i, entry := range path {
+		if v.scopeOrg(entry) {
+			return path[:i+1]
+		}
+	}
+	return []HierarchyPathEntry{}
+}
+
+func (v *VisibleService) GetDescendantsTree(entityName string) *HierarchyNode {
+	// Everything under a visible entity is visible.
+	if !v.entityVisible(entityName, "") {
+		return nil
+	}
+	return v.inner.GetDescendantsTree(entityName)
+}
+
+// FindPath returns nil when the path passes through a node the policy hides,
+// even if a longer one avoids it.
+func (v *VisibleService) FindPath(from, to GraphNode) []GraphEdge {
+	path := v.inner.FindPath(from, to)
+	if !v.scoped() || path == nil {
+		return path
+	}
+	if !v.nodeVisible(from) || !v.nodeVisible(to) || slices.ContainsFunc(path, v.edgeHidden) {
+		return nil
+	}
+	return path
+}
+
+// Neighborhood leaves out the edges touching a node the policy hides; hops
+// are still counted through them.
+func (v *VisibleService) Neighborhood(node GraphNode, hops int) []GraphEdge {
+	edges := v.inner.Neighborhood(node, hops)
+	if !v.scoped() || edges == nil {
+		return edges
+	}
+	if !v.nodeVisible(node) {
+		return nil
+	}
+	return slices.DeleteFunc(edges, v.edgeHidden)
+}
+
+func (v *VisibleService) nodeVisible(n GraphNode) bool {
+	if n.Type == EntityEmployee {
+		return v.employeeVisible(n.Name)
+	}
+	return v.entityVisible(n.Name, string(n.Type))
+}
+
+func (v *VisibleService) edgeHidden(e GraphEdge) bool {
+	return !v.nodeVisible(e.From) || !v.nodeVisible(e.To)
+}
+
+// Search leaves out results the policy hides before applying the limit. With
+// HideEmails, employees are matched without their email.
+func (v *VisibleService) Search(query string, opts ...SearchOption) []SearchResult {
+	if !v.scoped() && !v.policy.HideEmails {
+		return v.inner.Search(query, opts...)
+	}
+	q := NewSearchOptions(opts...)
+	results := v.inner.Search(query, append(slices.Clone(opts), SearchLimit(0))...)
+	results = slices.DeleteFunc(results, func(r SearchResult) bool {
+		if r.Type == EntityEmployee {
+			return !v.employeeVisible(r.Name) || v.policy.HideEmails && !v.matchesWithoutEmail(r.Name, query, q.Exact)
+		}
+		return !v.entityVisible(r.Name, string(r.Type))
+	})
+	return q.limited(results)
+}
+
+// matchesWithoutEmail reports whether every word of query matches the UID,
+// name or job title of the employee, as Search matches them.
+func (v *VisibleService) matchesWithoutEmail(uid, query string, exact bool) bool {
+	emp := v.inner.GetEmployeeByUID(uid)
+	if emp == nil {
+		return false
+	}
+	fields := slices.Concat(tokenize(emp.UID), tokenize(emp.FullName), tokenize(emp.JobTitle))
+	words := tokenize(query)
+	for i, word := range words {
+		prefix := !exact && i == len(words)-1
+		if !slices.ContainsFunc(fields, func(f string) bool { return f == word || prefix && strings.HasPrefix(f, word) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *VisibleService) GetComponentByName(name string) *Component {
+	return v.inner.GetComponentByName(name)
+}
+
+func (v *VisibleService) GetAllComponents() []Component {
+	return v.inner.GetAllComponents()
+}
+
+func (v *VisibleService) GetAllComponentNames() []string {
+	return v.inner.GetAllComponentNames()
+}
+
+func (v *VisibleService) GetTeamsForComponent(componentName string) []ComponentOwnerInfo {
+	return visibleOwners(v, v.inner.GetTeamsForComponent(componentName), func(o ComponentOwnerInfo) (string, string) { return o.Name, o.Type })
+}
+
+func (v *VisibleService) GetComponentsForTeam(teamName string) []ComponentOwnership {
+	if !v.entityVisible(teamName, string(EntityTeam)) {
+		return []ComponentOwnership{}
+	}
+	return v.inner.GetComponentsForTeam(teamName)
+}
+
+func (v *VisibleService) GetJiraProjects() []string {
+	return v.inner.GetJiraProjects()
+}
+
+func (v *VisibleService) GetJiraComponents(project string) []string {
+	return v.inner.GetJiraComponents(project)
+}
+
+func (v *VisibleService) GetTeamsByJiraProject(project string) []JiraOwnerInfo {
+	return visibleOwners(v, v.inner.GetTeamsByJiraProject(project), func(o JiraOwnerInfo) (string, string) { return o.Name, o.Type })
+}
+
+func (v *VisibleService) GetTeamsByJiraComponent(project, component string) []JiraOwnerInfo {
+	return visibleOwners(v, v.inner.GetTeamsByJiraComponent(project, component), func(o JiraOwnerInfo) (string, string) { return o.Name, o.Type })
+}
+
+func (v *VisibleService) GetJiraOwnershipForTeam(teamName string) []JiraOwnership {
+	if !v.entityVisible(teamName, string(EntityTeam)) {
+		return []JiraOwnership{}
+	}
+	return v.inner.GetJiraOwnershipForTeam(teamName)
+}
+
+func (v *VisibleService) GetContextForTeam(teamName string) []ContextItemInfo {
+	if !v.entityVisible(teamName, string(EntityTeam)) {
+		return []ContextItemInfo{}
+	}
+	return v.inner.GetContextForTeam(teamName)
+}
+
+func (v *VisibleService) GetContextForEntity(entityName string, entityType string) []ContextItemInfo {
+	if !v.entityVisible(entityName, entityType) {
+		return []ContextItemInfo{}
+	}
+	return v.inner.GetContextForEntity(entityName, entityType)
+}
+
+func (v *VisibleService) GetContextByType(entityName string, contextType string, entityType string) []ContextItemInfo {
+	if !v.entityVisible(entityName, entityType) {
+		return []ContextItemInfo{}
+	}
+	return v.inner.GetContextByType(entityName, contextType, entityType)
+}
+
+func (v *VisibleService) GetAllContextTypesForEntity(entityName string, entityType string) []string {
+	if !v.entityVisible(entityName, entityType) {
+		return []string{}
+	}
+	return v.inner.GetAllContextTypesForEntity(entityName, entityType)
+}
+
+func (v *VisibleService) GetContextTypeDescriptions() map[string]string {
+	return v.inner.GetContextTypeDescriptions()
+}
@@ -0,0 +1,157 @@
+package orgdatacore
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	testingsupport "github.com/openshift-eng/cyborg-data/go/internal/testing"
+)
+
+func TestVisibleService(t *testing.T) {
+	service := setupTestService(t)
+	bwilson := *service.GetEmployeeByUID("bwilson")
+	bwilson.CostCenter = 1234
+	if err := service.ApplyChangeSet(ChangeSet{EmployeesUpserted: map[string]Employee{"bwilson": bwilson}}); err != nil {
+		t.Fatalf("ApplyChangeSet: %v", err)
+	}
+
+	view := NewVisibleService(service, VisibilityPolicy{Orgs: []string{"platform-org"}, HideCostCenters: true})
+
+	if emp := view.GetEmployeeByUID("bwilson"); emp == nil || emp.CostCenter != 0 || emp.Email == "" {
+		t.Errorf("GetEmployeeByUID(bwilson) = %+v, want bwilson without a cost center", emp)
+	}
+	if emp := view.GetEmployeeBySlackID("U12345678"); emp != nil {
+		t.Errorf("GetEmployeeBySlackID of jsmith, outside platform-org, = %+v, want nil", emp)
+	}
+	if got := view.GetAllTeamNames(); !slices.Equal(got, []string{"platform-team"}) {
+		t.Errorf("GetAllTeamNames = %v, want [platform-team]", got)
+	}
+	if team := view.GetTeamByName("test-team"); team != nil {
+		t.Errorf("GetTeamByName(test-team) = %+v, want nil", team)
+	}
+	if got := view.GetAllEmployeeUIDs(); !slices.Equal(got, []string{"bwilson"}) {
+		t.Errorf("GetAllEmployeeUIDs = %v, want [bwilson]", got)
+	}
+	if members := view.GetOrgMembers("test-org"); len(members) != 0 {
+		t.Errorf("GetOrgMembers(test-org) = %v, want none", members)
+	}
+	if !view.IsEmployeeInOrg("bwilson", "platform-org") || view.IsEmployeeInOrg("bwilson", "test-org") {
+		t.Error("IsEmployeeInOrg should be true for platform-org only")
+	}
+	want := map[string]bool{"jsmith": false, "bwilson": false}
+	if got := view.AreEmployeesInTeam([]string{"jsmith", "bwilson"}, "test-team"); !maps.Equal(got, want) {
+		t.Errorf("AreEmployeesInTeam(test-team), outside platform-org, = %v, want %v", got, want)
+	}
+	if !view.IsEmployeeInAnyTeam("bwilson", []string{"test-team", "platform-team"}) || view.IsEmployeeInAnyTeam("jsmith", []string{"test-team"}) {
+		t.Error("IsEmployeeInAnyTeam should only count platform-team")
+	}
+
+	wantPath := []HierarchyPathEntry{
+		{Name: "platform-team", Type: "team"},
+		{Name: "backend-teams", Type: "team_group"},
+		{Name: "engineering", Type: "pillar"},
+		{Name: "platform-org", Type: "org"},
+	}
+	if got := view.GetHierarchyPath("platform-team", "team"); !reflect.DeepEqual(got, wantPath) {
+		t.Errorf("GetHierarchyPath(platform-team) = %v, want %v", got, wantPath)
+	}
+	for _, org := range view.GetUserOrganizations("U98765432") {
+		if org.Name == "test-org" {
+			t.Errorf("GetUserOrganizations lists test-org, outside platform-org")
+		}
+	}
+	owners := view.GetTeamsForComponent("auth-service")
+	if len(owners) != 1 || owners[0].Name != "platform-team" {
+		t.Errorf("GetTeamsForComponent(auth-service) = %v, want platform-team only", owners)
+	}
+	if tree := view.GetDescendantsTree("test-org"); tree != nil {
+		t.Errorf("GetDescendantsTree(test-org) = %+v, want nil", tree)
+	}
+
+	if path := view.FindPath(employeeNode("bwilson"), orgNode("platform-org")); len(path) != 4 {
+		t.Errorf("FindPath(bwilson, platform-org) = %v, want 4 edges through platform-team", path)
+	}
+	if path := view.FindPath(teamNode("platform-team"), teamNode("test-team")); path != nil {
+		t.Errorf("FindPath(platform-team, test-team) = %v, want nil", path)
+	}
+	if edges := view.Neighborhood(teamNode("test-team"), 1); edges != nil {
+		t.Errorf("Neighborhood(test-team) = %v, want nil", edges)
+	}
+	if got, want := resultNames(view.Search("engineer", SearchLimit(2))), []string{"pillar:engineering", "employee:bwilson"}; !slices.Equal(got, want) {
+		t.Errorf("Search(engineer), outside platform-org, = %v, want %v", got, want)
+	}
+	if got := view.Search("qa"); len(got) != 0 {
+		t.Errorf("Search(qa) = %v, want test-team hidden", got)
+	}
+	for _, edge := range view.Neighborhood(orgNode("platform-org"), 3) {
+		if edge.From.Name == "test-org" || edge.To.Name == "test-org" {
+			t.Errorf("Neighborhood(platform-org) has %v, outside platform-org", edge)
+		}
+	}
+}
+
+func TestVisibleServiceUnscoped(t *testing.T) {
+	service := setupTestService(t)
+	view := NewVisibleService(service, VisibilityPolicy{HideEmails: true})
+
+	if got, want := len(view.GetAllEmployees()), len(service.GetAllEmployees()); got != want {
+		t.Errorf("GetAllEmployees returned %d employees, want all %d", got, want)
+	}
+	for _, emp := range view.GetTeamMembers("test-team") {
+		if emp.Email != "" {
+			t.Errorf("GetTeamMembers returned %s with email %q, want it hidden", emp.UID, emp.Email)
+		}
+	}
+	if emp := view.GetEmployeeByEmail("jsmith@example.com"); emp != nil {
+		t.Errorf("GetEmployeeByEmail = %+v, want nil with emails hidden", emp)
+	}
+	if emp := view.GetEmployeeByUID("jsmith"); emp == nil || emp.Email != "" {
+		t.Errorf("GetEmployeeByUID(jsmith) = %+v, want jsmith without an email", emp)
+	}
+	if emp := NewVisibleService(service, VisibilityPolicy{}).GetEmployeeByEmail("jsmith@example.com"); emp == nil || emp.UID != "jsmith" {
+		t.Errorf("GetEmployeeByEmail without HideEmails = %+v, want jsmith", emp)
+	}
+	if got := view.Search("bwilson@example.com"); len(got) != 0 {
+		t.Errorf("Search by email = %v, want nothing with emails hidden", got)
+	}
+	if got, want := resultNames(view.Search("bob")), []string{"employee:bwilson"}; !slices.Equal(got, want) {
+		t.Errorf("Search(bob) = %v, want %v", got, want)
+	}
+	if view.Unwrap() != ServiceInterface(service) {
+		t.Error("Unwrap does not return the wrapped service")
+	}
+}
+
+func TestVisibleServiceReadOnly(t *testing.T) {
+	service := setupTestService(t)
+	ctx := context.Background()
+	if err := service.StartDataSourceWatcher(ctx, testingsupport.NewFileDataSource(filepath.Join("..", "testdata", "test_org_data.json"))); err != nil {
+		t.Fatalf("StartDataSourceWatcher: %v", err)
+	}
+	defer service.StopWatcher()
+	view := NewVisibleService(service, VisibilityPolicy{Orgs: []string{"platform-org"}})
+
+	source := NewFakeDataSource(CreateTestDataJSON())
+	if err := view.LoadFromDataSource(ctx, source); !errors.Is(err, ErrVisibleLoad) {
+		t.Errorf("LoadFromDataSource: err = %v, want ErrVisibleLoad", err)
+	}
+	if err := view.StartDataSourceWatcher(ctx, source); !errors.Is(err, ErrVisibleLoad) {
+		t.Errorf("StartDataSourceWatcher: err = %v, want ErrVisibleLoad", err)
+	}
+	if source.WatchCalled {
+		t.Error("StartDataSourceWatcher watched the source")
+	}
+	if service.GetEmployeeByUID("testuser1") != nil {
+		t.Error("the view's LoadFromDataSource replaced the wrapped service's data")
+	}
+
+	view.StopWatcher()
+	if got := service.Watchers(); !slices.Equal(got, []string{DefaultWatcherName}) {
+		t.Error("the view's StopWatcher stopped the wrapped service's watcher")
+	}
+}
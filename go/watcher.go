@@ -0,0 +1,275 @@
+package orgdatacore
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatcherName is the name of the watcher started by StartDataSourceWatcher.
+const DefaultWatcherName = "default"
+
+// WatcherStatus reports the health of a running watcher.
+type WatcherStatus struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+
+	StartedAt time.Time `json:"started_at"`
+	// LastCheck is when the source last checked for changes. Sources that do
+	// not call ReportWatchCheck only report checks that found a change.
+	LastCheck time.Time `json:"last_check"`
+	// LastSuccess is when data from this watcher was last loaded successfully.
+	LastSuccess time.Time `json:"last_success"`
+	// LastError is the most recent check or reload error, if any.
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorTime time.Time `json:"last_error_time"`
+	// ConsecutiveFailures counts failed checks and reloads since the last success.
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// NextCheck is when the source next plans to check, if it reports one.
+	NextCheck time.Time `json:"next_check"`
+}
+
+// watcher is a running DataSource watch loop.
+type watcher struct {
+	cancel context.CancelFunc
+	clock  Clock
+	source DataSource
+	seq    uint64 // start order, for merging
+
+	mu     sync.Mutex
+	status WatcherStatus
+}
+
+type watcherContextKey struct{}
+
+func (w *watcher) recordResult(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.clock.Now()
+	if err != nil {
+		w.status.LastError = err.Error()
+		w.status.LastErrorTime = now
+		w.status.ConsecutiveFailures++
+		return
+	}
+	w.status.LastSuccess = now
+	w.status.ConsecutiveFailures = 0
+}
+
+// ReportWatchCheck lets a DataSource's Watch loop report that it just checked
+// for changes, so WatcherStatus can show check times between reloads.
+// ctx must be the context passed to Watch. next is when the source plans to
+// check again (zero if unknown), and err is the check's error, if any; a
+// failed check counts toward ConsecutiveFailures. It is a no-op when ctx does
+// not belong to a Service watcher.
+func ReportWatchCheck(ctx context.Context, next time.Time, err error) {
+	w, ok := ctx.Value(watcherContextKey{}).(*watcher)
+	if !ok {
+		return
+	}
+	w.mu.Lock()
+	w.status.LastCheck = w.clock.Now()
+	w.status.NextCheck = next
+	w.mu.Unlock()
+
+	if err != nil {
+		w.recordResult(err)
+	}
+}
+
+// StartDataSourceWatcher loads data from source and then watches it for
+// changes, reloading on each update.
+// It is equivalent to StartNamedWatcher with DefaultWatcherName.
+func (s *Service) StartDataSourceWatcher(ctx context.Context, source DataSource) error {
+	return s.StartNamedWatcher(ctx, DefaultWatcherName, source)
+}
+
+// StartNamedWatcher is like StartDataSourceWatcher but registers the watcher
+// under name, so several sources can be watched with independent lifecycles,
+// such as a primary GCS object plus a file of local overrides. While more
+// than one watcher runs, every load by any of them fetches all the watched
+// sources and merges them with MergeOverlay, in the order the watchers were
+// started, so later watchers override earlier ones and a reload by one keeps
+// what the others loaded. As with a MergedDataSource, a source that fails to
+// load fails the whole load, and change sets are not used. Loads by different
+// watchers never run at the same time. A watcher that stops leaves its data
+// in place until another watcher reloads.
+//
+// It returns when source.Watch returns. A Watch that returns nil before its
+// context is cancelled is assumed to keep watching in the background, and the
+// watcher stays registered until it is stopped or ctx is cancelled.
+// It returns ErrWatcherAlreadyRunning if a watcher with that name is running.
+func (s *Service) StartNamedWatcher(ctx context.Context, name string, source DataSource) error {
+	s.mu.Lock()
+	if _, running := s.watchers[name]; running {
+		s.mu.Unlock()
+		return ErrWatcherAlreadyRunning
+	}
+
+	// Create a cancellable context so StopWatcher can terminate the watcher
+	watchCtx, cancel := context.WithCancel(ctx)
+	w := &watcher{
+		cancel: cancel,
+		clock:  s.clock,
+		source: source,
+		seq:    s.watcherSeq,
+		status: WatcherStatus{Name: name, Source: source.String(), StartedAt: s.clock.Now()},
+	}
+	watchCtx = context.WithValue(watchCtx, watcherContextKey{}, w)
+	if s.watchers == nil {
+		s.watchers = make(map[string]*watcher)
+	}
+	s.watchers[name] = w
+	s.watcherSeq++
+	delete(s.failedWatchers, name)
+	s.mu.Unlock()
+
+	var stopOnce sync.Once
+	unregister := func(err error) {
+		s.mu.Lock()
+		if s.watchers[name] == w {
+			delete(s.watchers, name)
+		}
+		s.mu.Unlock()
+		cancel()
+		stopOnce.Do(func() {
+			s.emit(Event{Type: EventWatcherStopped, Source: source.String(), Watcher: name, Err: err})
+		})
+	}
+
+	err := s.loadForWatcher(watchCtx, source)
+	w.recordResult(err)
+	if err != nil {
+		unregister(err)
+		return err
+	}
+
+	err = source.Watch(watchCtx, func() error {
+		w.mu.Lock()
+		w.status.LastCheck = w.clock.Now()
+		w.mu.Unlock()
+		s.loggerFor(LogWatcher).Debug("watcher checking source", "watcher", name, "source", source.String())
+
+		err := s.loadForWatcher(watchCtx, source)
+		w.recordResult(err)
+		if err != nil {
+			s.loggerFor(LogWatcher).Error("failed to reload data", "watcher", name, "source", source.String(), "error", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil || watchCtx.Err() != nil {
+		var cause error
+		if err != nil && watchCtx.Err() == nil {
+			s.recordWatcherFailure(name, err)
+			cause = err
+		}
+		unregister(cause)
+		return err
+	}
+
+	// Watch is polling in the background; unregister once it is stopped.
+	go func() {
+		<-watchCtx.Done()
+		unregister(nil)
+	}()
+	return nil
+}
+
+// loadForWatcher loads source for one of the running watchers: alone if it is
+// the only one, and merged with the others' sources otherwise.
+func (s *Service) loadForWatcher(ctx context.Context, source DataSource) error {
+	s.watcherLoads.Lock()
+	defer s.watcherLoads.Unlock()
+
+	s.mu.RLock()
+	watchers := slices.SortedFunc(maps.Values(s.watchers), func(a, b *watcher) int { return cmp.Compare(a.seq, b.seq) })
+	s.mu.RUnlock()
+	if len(watchers) <= 1 {
+		return s.refreshFromDataSource(ctx, source)
+	}
+
+	sources := make([]DataSource, len(watchers))
+	for i, w := range watchers {
+		sources[i] = w.source
+	}
+	return s.LoadFromDataSource(ctx, NewMergedDataSource(MergeOverlay, sources...))
+}
+
+// StopWatcher stops every running watcher by cancelling its context.
+// This signals each DataSource.Watch method to exit. The method is safe to
+// call even if no watcher is running.
+func (s *Service) StopWatcher() {
+	s.mu.Lock()
+	watchers := s.watchers
+	s.watchers = nil
+	s.mu.Unlock()
+
+	for _, w := range watchers {
+		w.cancel()
+	}
+}
+
+// StopNamedWatcher stops the watcher registered under name, if it is running.
+func (s *Service) StopNamedWatcher(name string) {
+	s.mu.Lock()
+	w := s.watchers[name]
+	delete(s.watchers, name)
+	s.mu.Unlock()
+
+	if w != nil {
+		w.cancel()
+	}
+}
+
+// Watchers returns the sorted names of the running watchers.
+func (s *Service) Watchers() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.watchers))
+	for name := range s.watchers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// WatcherStatus returns the status of every running watcher, sorted by name.
+// Readiness probes can use it to fail when data has not refreshed within an
+// SLO, for example by checking LastSuccess or ConsecutiveFailures.
+func (s *Service) WatcherStatus() []WatcherStatus {
+	s.mu.RLock()
+	watchers := make([]*watcher, 0, len(s.watchers))
+	for _, w := range s.watchers {
+		watchers = append(watchers, w)
+	}
+	s.mu.RUnlock()
+
+	statuses := make([]WatcherStatus, 0, len(watchers))
+	for _, w := range watchers {
+		w.mu.Lock()
+		statuses = append(statuses, w.status)
+		w.mu.Unlock()
+	}
+	slices.SortFunc(statuses, func(a, b WatcherStatus) int { return strings.Compare(a.Name, b.Name) })
+	return statuses
+}
+
+// recordWatcherFailure remembers that the named watcher stopped on its own
+// because its source failed, so Healthz can report it.
+func (s *Service) recordWatcherFailure(name string, err error) {
+	s.loggerFor(LogWatcher).Error("watcher stopped", "watcher", name, "error", err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failedWatchers == nil {
+		s.failedWatchers = make(map[string]string)
+	}
+	s.failedWatchers[name] = err.Error()
+}
@@ -0,0 +1,243 @@
+package orgdatacore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// contextDataSource serves fixed data and watches until its context is cancelled.
+type contextDataSource struct {
+	blockingDataSource
+	name     string
+	watching chan struct{}
+}
+
+func newContextDataSource(name string) *contextDataSource {
+	return &contextDataSource{name: name, watching: make(chan struct{})}
+}
+
+func (c *contextDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	return c.blockingDataSource.Load(ctx)
+}
+
+func (c *contextDataSource) Watch(ctx context.Context, _ func() error) error {
+	close(c.watching)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *contextDataSource) String() string { return c.name }
+
+func startWatcher(t *testing.T, service *Service, name string, source *contextDataSource) <-chan error {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() { done <- service.StartNamedWatcher(context.Background(), name, source) }()
+	select {
+	case <-source.watching:
+	case err := <-done:
+		t.Fatalf("watcher %q exited early: %v", name, err)
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for watcher %q to start", name)
+	}
+	return done
+}
+
+func waitStopped(t *testing.T, name string, done <-chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("watcher %q returned %v, want context.Canceled", name, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for watcher %q to stop", name)
+	}
+}
+
+func TestNamedWatchers(t *testing.T) {
+	service := NewService()
+
+	primary := startWatcher(t, service, "primary", newContextDataSource("primary-source"))
+	overrides := startWatcher(t, service, "overrides", newContextDataSource("overrides-source"))
+
+	if got := service.Watchers(); !reflect.DeepEqual(got, []string{"overrides", "primary"}) {
+		t.Errorf("Watchers() = %v, want [overrides primary]", got)
+	}
+
+	err := service.StartNamedWatcher(context.Background(), "primary", newContextDataSource("dup"))
+	if !errors.Is(err, ErrWatcherAlreadyRunning) {
+		t.Errorf("expected ErrWatcherAlreadyRunning for duplicate name, got %v", err)
+	}
+
+	service.StopNamedWatcher("primary")
+	waitStopped(t, "primary", primary)
+	if got := service.Watchers(); !reflect.DeepEqual(got, []string{"overrides"}) {
+		t.Errorf("Watchers() after stopping primary = %v, want [overrides]", got)
+	}
+
+	// The name can be reused once the previous watcher has stopped.
+	restarted := startWatcher(t, service, "primary", newContextDataSource("primary-source"))
+
+	service.StopWatcher()
+	waitStopped(t, "overrides", overrides)
+	waitStopped(t, "primary", restarted)
+	if got := service.Watchers(); len(got) != 0 {
+		t.Errorf("Watchers() after StopWatcher = %v, want none", got)
+	}
+
+	service.StopNamedWatcher("missing") // no-op
+}
+
+// triggeredDataSource serves fixed data and reloads when the test calls the
+// callback given to Watch.
+type triggeredDataSource struct {
+	*FakeDataSource
+	callback func() error
+}
+
+func (d *triggeredDataSource) Watch(_ context.Context, callback func() error) error {
+	d.callback = callback
+	return nil
+}
+
+func TestNamedWatchersMerge(t *testing.T) {
+	ctx := context.Background()
+	raw := CreateTestDataJSON()
+	primary := &triggeredDataSource{FakeDataSource: NewFakeDataSource(raw)}
+	overrides := &triggeredDataSource{FakeDataSource: jsonSource(t, "overrides", overridesData())}
+
+	service := NewService()
+	defer service.StopWatcher()
+	if err := service.StartNamedWatcher(ctx, "primary", primary); err != nil {
+		t.Fatalf("StartNamedWatcher(primary): %v", err)
+	}
+	sum := sha256.Sum256([]byte(raw))
+	if got, want := service.GetVersion().Checksum, hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("Checksum with one watcher = %q, want the primary's %q", got, want)
+	}
+	if err := service.StartNamedWatcher(ctx, "overrides", overrides); err != nil {
+		t.Fatalf("StartNamedWatcher(overrides): %v", err)
+	}
+
+	// Each watcher's reload keeps what the other loaded, and the watcher
+	// started last wins.
+	for _, reload := range []struct {
+		name     string
+		callback func() error
+	}{{"overrides", overrides.callback}, {"primary", primary.callback}} {
+		if err := reload.callback(); err != nil {
+			t.Fatalf("%s reload: %v", reload.name, err)
+		}
+		if service.GetEmployeeByUID("testuser1") == nil || service.GetEmployeeByUID("contractor") == nil {
+			t.Errorf("after the %s reload, want the data of both watchers", reload.name)
+		}
+		if got := service.GetEmployeeBySlackID("U999999"); got == nil || got.UID != "testuser2" {
+			t.Errorf("after the %s reload, GetEmployeeBySlackID(U999999) = %v, want the override", reload.name, got)
+		}
+	}
+
+	// A failing source fails the merged load and keeps the data.
+	overrides.Data = "not json"
+	if err := primary.callback(); err == nil {
+		t.Error("primary reload with a broken override = nil, want an error")
+	}
+	if service.GetEmployeeByUID("contractor") == nil {
+		t.Error("a failed merged load replaced the data")
+	}
+
+	// Once the overrides watcher stops, the primary loads alone again.
+	service.StopNamedWatcher("overrides")
+	if err := primary.callback(); err != nil {
+		t.Fatalf("primary reload: %v", err)
+	}
+	if service.GetEmployeeByUID("contractor") != nil {
+		t.Error("after the overrides watcher stopped, want only the primary's data")
+	}
+}
+
+// pollingDataSource returns from Watch immediately, like sources that poll in a
+// background goroutine, and lets the test drive the polls.
+type pollingDataSource struct {
+	blockingDataSource
+	fail     atomic.Bool
+	ctx      context.Context
+	callback func() error
+}
+
+func (p *pollingDataSource) Load(ctx context.Context) (io.ReadCloser, error) {
+	if p.fail.Load() {
+		return nil, errors.New("load failed")
+	}
+	return p.blockingDataSource.Load(ctx)
+}
+
+func (p *pollingDataSource) Watch(ctx context.Context, callback func() error) error {
+	p.ctx, p.callback = ctx, callback
+	return nil
+}
+
+func (p *pollingDataSource) String() string { return "polling-source" }
+
+func TestWatcherStatus(t *testing.T) {
+	service := NewService()
+	source := &pollingDataSource{}
+
+	if err := service.StartNamedWatcher(context.Background(), "poller", source); err != nil {
+		t.Fatalf("StartNamedWatcher: %v", err)
+	}
+	statuses := service.WatcherStatus()
+	if len(statuses) != 1 {
+		t.Fatalf("WatcherStatus() = %v, want one watcher still running after Watch returned", statuses)
+	}
+	status := statuses[0]
+	if status.Name != "poller" || status.Source != "polling-source" {
+		t.Errorf("status = %+v, want poller/polling-source", status)
+	}
+	if status.LastSuccess.IsZero() || status.ConsecutiveFailures != 0 {
+		t.Errorf("after initial load: LastSuccess=%v ConsecutiveFailures=%d", status.LastSuccess, status.ConsecutiveFailures)
+	}
+
+	next := time.Now().Add(time.Minute)
+	ReportWatchCheck(source.ctx, next, nil)
+	ReportWatchCheck(source.ctx, next, errors.New("metadata unavailable"))
+	source.fail.Store(true)
+	if err := source.callback(); err == nil {
+		t.Fatal("expected reload error")
+	}
+
+	status = service.WatcherStatus()[0]
+	if !status.NextCheck.Equal(next) {
+		t.Errorf("NextCheck = %v, want %v", status.NextCheck, next)
+	}
+	if status.LastCheck.IsZero() {
+		t.Error("LastCheck not recorded")
+	}
+	if status.ConsecutiveFailures != 2 || !strings.Contains(status.LastError, "load failed") {
+		t.Errorf("after failures: ConsecutiveFailures=%d LastError=%q, want 2 and the load error",
+			status.ConsecutiveFailures, status.LastError)
+	}
+
+	source.fail.Store(false)
+	if err := source.callback(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if status = service.WatcherStatus()[0]; status.ConsecutiveFailures != 0 {
+		t.Errorf("ConsecutiveFailures after success = %d, want 0", status.ConsecutiveFailures)
+	}
+
+	service.StopNamedWatcher("poller")
+	if got := service.WatcherStatus(); len(got) != 0 {
+		t.Errorf("WatcherStatus() after stop = %v, want none", got)
+	}
+
+	// Reports from contexts that do not belong to a watcher are ignored.
+	ReportWatchCheck(context.Background(), next, nil)
+}
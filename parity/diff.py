@@ -0,0 +1,181 @@
+"""Structural diff of Go and Python outputs for parity failure reports.
+
+A mismatch between two large outputs is reported as the list of paths that
+differ ("$.teams[name=foo].members[2]") rather than both JSON blobs, and
+failures are summarized per method so a run with many mismatches can be
+triaged from the top.
+"""
+
+import json
+from dataclasses import dataclass, field
+from typing import Any
+
+# Keys that identify an entity in a list of objects. Lists whose items all
+# carry one of them are matched by that key rather than by position, so one
+# missing team shows up as one removal instead of a shifted list.
+IDENTITY_KEYS = ("uid", "name", "component", "project")
+
+# Values longer than this are abbreviated in diff lines.
+MAX_VALUE_LENGTH = 120
+
+
+@dataclass
+class DiffEntry:
+    """One difference between the Go and Python output.
+
+    kind is "added" (only in Python), "removed" (only in Go) or "changed".
+    """
+
+    path: str
+    kind: str
+    go: Any = None
+    python: Any = None
+
+    def to_dict(self) -> dict[str, Any]:
+        return {"path": self.path, "kind": self.kind, "go": self.go, "python": self.python}
+
+    def describe(self, left: str = "Go", right: str = "Python") -> str:
+        """Render the entry as one line, naming the two sides left and right."""
+        if self.kind == "added":
+            return f"+ {self.path}: {short(self.python)} ({right} only)"
+        if self.kind == "removed":
+            return f"- {self.path}: {short(self.go)} ({left} only)"
+        return f"~ {self.path}: {left} {short(self.go)} != {right} {short(self.python)}"
+
+    def __str__(self) -> str:
+        return self.describe()
+
+
+def structural_diff(go: Any, python: Any, path: str = "$") -> list[DiffEntry]:
+    """Return the path-level differences between two JSON values."""
+    if isinstance(go, dict) and isinstance(python, dict):
+        entries = []
+        for key in sorted(set(go) | set(python)):
+            child = f"{path}.{key}"
+            if key not in python:
+                entries.append(DiffEntry(child, "removed", go=go[key]))
+            elif key not in go:
+                entries.append(DiffEntry(child, "added", python=python[key]))
+            else:
+                entries += structural_diff(go[key], python[key], child)
+        return entries
+
+    if isinstance(go, list) and isinstance(python, list):
+        key = identity_key(go, python)
+        if key is not None:
+            return diff_keyed_lists(go, python, key, path)
+        entries = []
+        for i in range(max(len(go), len(python))):
+            child = f"{path}[{i}]"
+            if i >= len(python):
+                entries.append(DiffEntry(child, "removed", go=go[i]))
+            elif i >= len(go):
+                entries.append(DiffEntry(child, "added", python=python[i]))
+            else:
+                entries += structural_diff(go[i], python[i], child)
+        return entries
+
+    # True == 1 in Python, but not in JSON.
+    if go != python or isinstance(go, bool) != isinstance(python, bool):
+        return [DiffEntry(path, "changed", go=go, python=python)]
+    return []
+
+
+def identity_key(go: list[Any], python: list[Any]) -> str | None:
+    """Return the identity key every item of both lists has, unique per list."""
+    items = go + python
+    if not items or not all(isinstance(item, dict) for item in items):
+        return None
+    for key in IDENTITY_KEYS:
+        if all(isinstance(item.get(key), str) for item in items):
+            if len({i[key] for i in go}) == len(go) and len({i[key] for i in python}) == len(python):
+                return key
+    return None
+
+
+def diff_keyed_lists(go: list[Any], python: list[Any], key: str, path: str) -> list[DiffEntry]:
+    go_by_id = {item[key]: item for item in go}
+    python_by_id = {item[key]: item for item in python}
+    entries = []
+    for ident in sorted(set(go_by_id) | set(python_by_id)):
+        child = f"{path}[{key}={ident}]"
+        if ident not in python_by_id:
+            entries.append(DiffEntry(child, "removed", go=go_by_id[ident]))
+        elif ident not in go_by_id:
+            entries.append(DiffEntry(child, "added", python=python_by_id[ident]))
+        else:
+            entries += structural_diff(go_by_id[ident], python_by_id[ident], child)
+    if not entries and [i[key] for i in go] != [i[key] for i in python]:
+        entries.append(DiffEntry(f"{path}[*]", "changed", go=[i[key] for i in go], python=[i[key] for i in python]))
+    return entries
+
+
+def short(value: Any) -> str:
+    """Render a value for a diff line, abbreviated if long."""
+    text = json.dumps(value, sort_keys=True, ensure_ascii=False)
+    if len(text) > MAX_VALUE_LENGTH:
+        return text[: MAX_VALUE_LENGTH - 3] + "..."
+    return text
+
+
+@dataclass
+class ParityFailure:
+    """One test case on which the implementations disagree.
+
+    diffs is set for output mismatches; other failures (a missing result,
+    an error on one side, a Go panic) carry their explanation in details.
+    """
+
+    method: str
+    case: str
+    reason: str
+    details: list[str] = field(default_factory=list)
+    diffs: list[DiffEntry] = field(default_factory=list)
+
+    # Diff lines printed per failure; the rest are counted.
+    MAX_DIFF_LINES = 10
+
+    def to_dict(self) -> dict[str, Any]:
+        return {
+            "method": self.method,
+            "case": self.case,
+            "reason": self.reason,
+            "details": self.details,
+            "diffs": [d.to_dict() for d in self.diffs],
+        }
+
+    def __str__(self) -> str:
+        lines = [f"  {self.method}/{self.case}: {self.reason}"]
+        lines += [f"    {d}" for d in self.details]
+        lines += [f"    {d}" for d in self.diffs[: self.MAX_DIFF_LINES]]
+        if len(self.diffs) > self.MAX_DIFF_LINES:
+            lines.append(f"    ... and {len(self.diffs) - self.MAX_DIFF_LINES} more differences")
+        return "\n".join(lines)
+
+
+def summarize_failures(failures: list[ParityFailure]) -> list[dict[str, Any]]:
+    """Group failures by method, most failing cases first.
+
+    Each entry counts the method's failing cases and its added, removed and
+    changed paths over all of them.
+    """
+    by_method: dict[str, dict[str, Any]] = {}
+    for f in failures:
+        entry = by_method.setdefault(
+            f.method, {"method": f.method, "cases": 0, "added": 0, "removed": 0, "changed": 0}
+        )
+        entry["cases"] += 1
+        for d in f.diffs:
+            entry[d.kind] += 1
+    return sorted(by_method.values(), key=lambda e: (-e["cases"], e["method"]))
+
+
+def print_failure_summary(summary: list[dict[str, Any]]) -> None:
+    """Print summarize_failures output as a table."""
+    width = max([len("Method")] + [len(e["method"]) for e in summary])
+    print(f"  {'Method':<{width}}  {'Cases':>6}  {'Added':>6}  {'Removed':>7}  {'Changed':>7}")
+    for e in summary:
+        print(
+            f"  {e['method']:<{width}}  {e['cases']:>6}  {e['added']:>6}"
+            f"  {e['removed']:>7}  {e['changed']:>7}"
+        )
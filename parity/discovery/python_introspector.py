@@ -25,13 +25,20 @@ EXCLUDED_METHODS = {
     "load_from_data_source",
     "start_data_source_watcher",
     "stop_watcher",
-    "get_version",
     "get_data_age",
     "is_data_stale",
     # Python-only (intentional, not a parity issue)
     "is_healthy",
     "is_ready",
     "initialize",
+    # Go-only (intentional): built on Go options Python does not have
+    "check_freshness",  # WithMaxDataAge; Python callers use is_data_stale
+    "rollback",  # WithSnapshotHistory; Python keeps no previous datasets
+    "export_dump",  # re-encodes Go's typed dataset, as snapshots and last-known-good do
+    "get_version_history",  # Go load statistics, which Python does not record
+    "overlay_patches",  # WithOverlay; Python applies no overlays
+    "all_overlay_patches",  # WithOverlay
+    "annotations",  # WithOverlay
 }
 
 
@@ -75,6 +82,13 @@ def introspect_python_service(python_root: Path | None = None) -> list[PythonMet
         for param_name, param in sig.parameters.items():
             if param_name == 'self':
                 continue
+            # Keyword-only options, like Go's variadic options, keep their
+            # defaults.
+            if (
+                param.kind is inspect.Parameter.KEYWORD_ONLY
+                and param.default is not inspect.Parameter.empty
+            ):
+                continue
             params.append((param_name, hints.get(param_name, Any)))
 
         return_type = hints.get('return', Any)
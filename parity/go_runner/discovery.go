@@ -0,0 +1,91 @@
+package main
+
+//go:generate go run gen_manifest.go
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+)
+
+// serviceInterface is the API surface under test.
+var serviceInterface = reflect.TypeOf((*orgdatacore.ServiceInterface)(nil)).Elem()
+
+// excludedMethods are ServiceInterface methods that cannot be parity tested
+// from a fixed dataset: lifecycle methods and time-dependent queries. It
+// mirrors EXCLUDED_METHODS in parity/discovery/python_introspector.py.
+var excludedMethods = map[string]bool{
+	"LoadFromDataSource":     true,
+	"StartDataSourceWatcher": true,
+	"StopWatcher":            true,
+	"GetDataAge":             true,
+	"IsDataStale":            true,
+}
+
+// discoverMethods returns the names of the ServiceInterface methods, sorted.
+// It fails if the generated manifest does not describe every method with
+// the right number of parameters, which means ServiceInterface changed
+// without go generate being run.
+func discoverMethods() ([]string, error) {
+	names := make([]string, 0, serviceInterface.NumMethod())
+	for i := 0; i < serviceInterface.NumMethod(); i++ {
+		method := serviceInterface.Method(i)
+		params, ok := methodParams[method.Name]
+		if !ok || len(params) != method.Type.NumIn() {
+			return nil, fmt.Errorf("manifest_gen.go is out of date for %s; run go generate", method.Name)
+		}
+		names = append(names, method.Name)
+	}
+	if len(methodParams) != len(names) {
+		return nil, fmt.Errorf("manifest_gen.go lists methods missing from ServiceInterface; run go generate")
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Coverage reports which testable ServiceInterface methods have test cases.
+type Coverage struct {
+	// Methods is the number of testable methods: all of ServiceInterface but
+	// the excluded ones.
+	Methods int     `json:"methods"`
+	Covered int     `json:"covered"`
+	Percent float64 `json:"percent"`
+	// Uncovered lists the testable methods without test cases, sorted.
+	Uncovered []string `json:"uncovered"`
+	// Excluded lists the methods left out of parity testing, sorted.
+	Excluded []string `json:"excluded"`
+	// Cases counts the test cases of every testable method.
+	Cases map[string]int `json:"cases"`
+}
+
+// coverage computes the coverage of methods by the test cases in config.
+func coverage(methods []string, config RunConfig) Coverage {
+	cases := map[string]int{}
+	for _, spec := range config.Methods {
+		cases[spec.GoName] += len(spec.TestCases)
+	}
+	c := Coverage{
+		Uncovered: []string{},
+		Excluded:  []string{},
+		Cases:     map[string]int{},
+	}
+	for _, name := range methods {
+		if excludedMethods[name] {
+			c.Excluded = append(c.Excluded, name)
+			continue
+		}
+		c.Methods++
+		c.Cases[name] = cases[name]
+		if cases[name] > 0 {
+			c.Covered++
+		} else {
+			c.Uncovered = append(c.Uncovered, name)
+		}
+	}
+	if c.Methods > 0 {
+		c.Percent = 100 * float64(c.Covered) / float64(c.Methods)
+	}
+	return c
+}
@@ -0,0 +1,101 @@
+//go:build ignore
+
+// gen_manifest writes manifest_gen.go, the parameter names of every
+// ServiceInterface method, from go/interface.go. Go reflection does not
+// expose parameter names, so the runner matches test case inputs against
+// this manifest. Run it with go generate after changing ServiceInterface.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "../../go/interface.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var iface *ast.InterfaceType
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok && spec.Name.Name == "ServiceInterface" {
+			iface, _ = spec.Type.(*ast.InterfaceType)
+		}
+		return iface == nil
+	})
+	if iface == nil {
+		log.Fatal("ServiceInterface not found in go/interface.go")
+	}
+
+	params := map[string][]string{}
+	for _, method := range iface.Methods.List {
+		fn, ok := method.Type.(*ast.FuncType)
+		if !ok || len(method.Names) == 0 {
+			continue
+		}
+		names := []string{}
+		for _, field := range fn.Params.List {
+			for _, name := range field.Names {
+				names = append(names, snakeCase(name.Name))
+			}
+		}
+		params[method.Names[0].Name] = names
+	}
+
+	methods := make([]string, 0, len(params))
+	for name := range params {
+		methods = append(methods, name)
+	}
+	sort.Strings(methods)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_manifest.go from go/interface.go; DO NOT EDIT.\n\n")
+	buf.WriteString("package main\n\n")
+	buf.WriteString("// methodParams maps each ServiceInterface method to its parameter names,\n")
+	buf.WriteString("// in the snake_case used by test case inputs.\n")
+	buf.WriteString("var methodParams = map[string][]string{\n")
+	for _, name := range methods {
+		quoted := make([]string, len(params[name]))
+		for i, p := range params[name] {
+			quoted[i] = fmt.Sprintf("%q", p)
+		}
+		fmt.Fprintf(&buf, "%q: {%s},\n", name, strings.Join(quoted, ", "))
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("manifest_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// snakeCase converts a Go parameter name such as slackUserID to slack_user_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && unicode.IsLower(runes[i-1])
+			endOfAcronym := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || endOfAcronym {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/orgdatacoretest"
+)
+
+// defaultIteratorCap is the number of items an iterator may yield when
+// RunConfig.IteratorCap is zero.
+const defaultIteratorCap = 10000
+
+// iteratorFor returns the name of the *Service iterator method that
+// enumerates the same items as the ServiceInterface method goName, such as
+// AllTeams for GetAllTeams, or "" if there is none. Iterators are not part
+// of ServiceInterface, so they are compared against the Python counterpart
+// of their list method.
+func iteratorFor(goName string) string {
+	rest, ok := strings.CutPrefix(goName, "GetAll")
+	if !ok {
+		return ""
+	}
+	name := "All" + rest
+	method, ok := reflect.TypeOf((*orgdatacore.Service)(nil)).MethodByName(name)
+	// Iterators take only the receiver and, optionally, enumeration options,
+	// which are left out so they yield everything.
+	if !ok || method.Type.NumOut() != 1 {
+		return ""
+	}
+	if in := method.Type.NumIn(); in != 1 && (in != 2 || !method.Type.IsVariadic()) {
+		return ""
+	}
+	if out := method.Type.Out(0); !out.CanSeq() && !out.CanSeq2() {
+		return ""
+	}
+	return name
+}
+
+// runIterator materializes the iterator method name into a slice and
+// serializes it like the output of the list method it mirrors, so it is
+// sorted the same way. An iter.Seq2 contributes its values. An iterator
+// yielding more than limit items is reported as an error.
+func runIterator(svc *orgdatacore.Service, name, iteratorOf string, tc TestCase, limit int) (result TestResult) {
+	result = TestResult{
+		MethodGoName: name,
+		CaseName:     tc.Name,
+		IteratorOf:   iteratorOf,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result.Output = nil
+			result.Error = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+
+	seq := reflect.ValueOf(svc).MethodByName(name).Call(nil)[0]
+	var elemType reflect.Type
+	if seq.Type().CanSeq2() {
+		elemType = seq.Type().In(0).In(1)
+	} else {
+		elemType = seq.Type().In(0).In(0)
+	}
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	items := reflect.MakeSlice(reflect.SliceOf(elemType), 0, 0)
+	add := func(v reflect.Value) bool {
+		if items.Len() == limit {
+			result.Error = fmt.Sprintf("iterator yielded more than %d items", limit)
+			return false
+		}
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		items = reflect.Append(items, v)
+		return true
+	}
+	if seq.Type().CanSeq2() {
+		for _, v := range seq.Seq2() {
+			if !add(v) {
+				break
+			}
+		}
+	} else {
+		for v := range seq.Seq() {
+			if !add(v) {
+				break
+			}
+		}
+	}
+
+	if result.Error == "" {
+		result.Output = orgdatacoretest.Normalize(items.Interface())
+	}
+	return result
+}
@@ -3,21 +3,48 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"reflect"
-	"sort"
+	"runtime"
+	"sync"
+	"time"
 
 	orgdatacore "github.com/openshift-eng/cyborg-data/go"
+	"github.com/openshift-eng/cyborg-data/go/orgdatacoretest"
 )
 
 // RunConfig is the configuration passed via stdin.
 type RunConfig struct {
 	TestDataPath string       `json:"test_data_path"`
 	Methods      []MethodSpec `json:"methods"`
+	// BenchIterations, if positive, times every test case over that many
+	// calls and reports the mean in TestResult.NsPerOp.
+	BenchIterations int `json:"bench_iterations,omitempty"`
+	// Workers is the number of test cases run concurrently, runtime.NumCPU()
+	// if zero. Cases run one at a time when benchmarking, so timings are not
+	// skewed by contention.
+	Workers int `json:"workers,omitempty"`
+	// CaseTimeoutMs bounds each test case, including its bench iterations;
+	// zero means no limit. A case that runs over is reported with an error
+	// starting "timeout: ".
+	CaseTimeoutMs int `json:"case_timeout_ms,omitempty"`
+	// MaxUncovered is how many testable ServiceInterface methods may have no
+	// test cases before the runner exits 1; negative means any number.
+	MaxUncovered int `json:"max_uncovered,omitempty"`
+	// IteratorCap is the number of items a *Service All* iterator may yield,
+	// defaultIteratorCap if zero.
+	IteratorCap int `json:"iterator_cap,omitempty"`
+}
+
+// RunOutput is the output written to stdout.
+type RunOutput struct {
+	Results  []TestResult `json:"results"`
+	Coverage Coverage     `json:"coverage"`
 }
 
 // MethodSpec describes a method to test.
@@ -38,34 +65,54 @@ type TestResult struct {
 	CaseName     string      `json:"case_name"`
 	Output       interface{} `json:"output"`
 	Error        string      `json:"error,omitempty"`
+	NsPerOp      float64     `json:"ns_per_op,omitempty"`
+	// IteratorOf is set on the result of an All* iterator, run alongside
+	// each case of the GetAll* method it names, to compare the iterator with
+	// that method's Python counterpart.
+	IteratorOf string `json:"iterator_of,omitempty"`
 }
 
 func main() {
-	var config RunConfig
-	if err := json.NewDecoder(os.Stdin).Decode(&config); err != nil {
+	raw, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+		os.Exit(2)
+	}
+	config, err := parseRunConfig(raw)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
 		os.Exit(2)
 	}
 
+	methods, err := discoverMethods()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error discovering methods: %v\n", err)
+		os.Exit(2)
+	}
+
 	svc, err := loadService(config.TestDataPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading service: %v\n", err)
 		os.Exit(2)
 	}
 
-	results := []TestResult{}
-	for _, method := range config.Methods {
-		for _, tc := range method.TestCases {
-			results = append(results, runTestCase(svc, method.GoName, tc))
-		}
+	output := RunOutput{
+		Results:  runAll(svc, config),
+		Coverage: coverage(methods, config),
 	}
 
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(results); err != nil {
+	if err := encoder.Encode(output); err != nil {
 		fmt.Fprintf(os.Stderr, "Error encoding results: %v\n", err)
 		os.Exit(2)
 	}
+
+	// The results are still written, so the caller can report coverage.
+	if uncovered := output.Coverage.Uncovered; config.MaxUncovered >= 0 && len(uncovered) > config.MaxUncovered {
+		fmt.Fprintf(os.Stderr, "ServiceInterface methods without test cases: %v\n", uncovered)
+		os.Exit(1)
+	}
 }
 
 func loadService(testDataPath string) (*orgdatacore.Service, error) {
@@ -97,40 +144,151 @@ func (f *fileDataSource) Close() error {
 	return nil
 }
 
-func runTestCase(svc *orgdatacore.Service, goName string, tc TestCase) TestResult {
-	result := TestResult{
+type job struct {
+	goName string
+	tc     TestCase
+	// iteratorOf is set when goName is an iterator run for a case of the
+	// GetAll* method it names.
+	iteratorOf string
+}
+
+// runAll runs every test case of config on a pool of workers. Results are
+// in config order whatever the order cases finish in.
+func runAll(svc *orgdatacore.Service, config RunConfig) []TestResult {
+	jobs := []job{}
+	for _, method := range config.Methods {
+		for _, tc := range method.TestCases {
+			jobs = append(jobs, job{goName: method.GoName, tc: tc})
+			if iterator := iteratorFor(method.GoName); iterator != "" {
+				jobs = append(jobs, job{goName: iterator, tc: tc, iteratorOf: method.GoName})
+			}
+		}
+	}
+	iteratorCap := config.IteratorCap
+	if iteratorCap <= 0 {
+		iteratorCap = defaultIteratorCap
+	}
+
+	workers := config.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if config.BenchIterations > 0 {
+		workers = 1
+	}
+	timeout := time.Duration(config.CaseTimeoutMs) * time.Millisecond
+
+	results := make([]TestResult, len(jobs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = runWithTimeout(svc, jobs[i], config.BenchIterations, iteratorCap, timeout)
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// runWithTimeout runs a test case, giving up after timeout if it is
+// positive. Methods take no context, so a case that times out keeps running
+// in the background until the runner exits.
+func runWithTimeout(svc *orgdatacore.Service, j job, benchIterations, iteratorCap int, timeout time.Duration) TestResult {
+	run := func() TestResult {
+		if j.iteratorOf != "" {
+			return runIterator(svc, j.goName, j.iteratorOf, j.tc, iteratorCap)
+		}
+		return runTestCase(svc, j.goName, j.tc, benchIterations)
+	}
+	if timeout <= 0 {
+		return run()
+	}
+	done := make(chan TestResult, 1)
+	go func() {
+		done <- run()
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result
+	case <-timer.C:
+		return TestResult{
+			MethodGoName: j.goName,
+			CaseName:     j.tc.Name,
+			Error:        fmt.Sprintf("timeout: no result after %s", timeout),
+			IteratorOf:   j.iteratorOf,
+		}
+	}
+}
+
+func runTestCase(svc *orgdatacore.Service, goName string, tc TestCase, benchIterations int) (result TestResult) {
+	result = TestResult{
 		MethodGoName: goName,
 		CaseName:     tc.Name,
 	}
 
-	svcValue := reflect.ValueOf(svc)
-	methodValue := svcValue.MethodByName(goName)
-	if !methodValue.IsValid() {
-		result.Error = fmt.Sprintf("method %s not found", goName)
+	if _, ok := serviceInterface.MethodByName(goName); !ok {
+		result.Error = fmt.Sprintf("method %s not found in ServiceInterface", goName)
 		return result
 	}
+	methodValue := reflect.ValueOf(svc).MethodByName(goName)
+
+	// A panicking method is reported as an error rather than crashing the
+	// run; the orchestrator fails any case whose error has this prefix.
+	defer func() {
+		if r := recover(); r != nil {
+			result.Output = nil
+			result.Error = fmt.Sprintf("panic: %v", r)
+		}
+	}()
 
-	args, err := buildArgs(goName, methodValue.Type(), tc.Inputs)
+	args, _, err := buildArgs(methodParams[goName], methodValue.Type(), tc.Inputs)
 	if err != nil {
 		result.Error = err.Error()
 		return result
 	}
 
-	returnValues := methodValue.Call(args)
+	call := methodValue.Call
+	if methodValue.Type().IsVariadic() {
+		call = methodValue.CallSlice
+	}
+	returnValues := call(args)
 	if len(returnValues) > 0 {
-		result.Output = serializeOutput(returnValues[0].Interface())
+		result.Output = orgdatacoretest.Normalize(returnValues[0].Interface())
+	}
+
+	if benchIterations > 0 {
+		start := time.Now()
+		for i := 0; i < benchIterations; i++ {
+			call(args)
+		}
+		result.NsPerOp = float64(time.Since(start).Nanoseconds()) / float64(benchIterations)
 	}
 
 	return result
 }
 
-func buildArgs(methodName string, methodType reflect.Type, inputs map[string]interface{}) ([]reflect.Value, error) {
+// buildArgs converts the inputs of a test case into arguments for a method
+// of methodType, and reports which input keys were used. The variadic
+// parameter of a variadic method is always returned as a slice, for
+// CallSlice: it accepts a list, a single value, or no input at all.
+func buildArgs(paramNames []string, methodType reflect.Type, inputs map[string]interface{}) ([]reflect.Value, map[string]bool, error) {
 	numParams := methodType.NumIn()
 	args := make([]reflect.Value, numParams)
-	paramNames := inferParamNames(methodName, methodType)
+	used := map[string]bool{}
 
 	for i := 0; i < numParams; i++ {
 		paramType := methodType.In(i)
+		variadic := methodType.IsVariadic() && i == numParams-1
 		paramName := ""
 		if i < len(paramNames) {
 			paramName = paramNames[i]
@@ -138,545 +296,117 @@ func buildArgs(methodName string, methodType reflect.Type, inputs map[string]int
 
 		var input interface{}
 		var found bool
+		var key string
 
 		if paramName != "" {
 			input, found = inputs[paramName]
+			key = paramName
 		}
 
-		if !found && len(inputs) == 1 {
-			for _, v := range inputs {
-				input = v
-				found = true
+		if !found && len(inputs) == 1 && !variadic {
+			for k, v := range inputs {
+				key, input, found = k, v, true
 				break
 			}
 		}
 
 		if !found {
-			for key, val := range inputs {
-				if matchesParamName(key, paramName, paramType) {
-					input = val
-					found = true
+			for k, v := range inputs {
+				if matchesParamName(k, paramName) {
+					key, input, found = k, v, true
 					break
 				}
 			}
 		}
 
+		if !found && variadic {
+			args[i] = reflect.MakeSlice(paramType, 0, 0)
+			continue
+		}
 		if !found {
-			return nil, fmt.Errorf("missing input for parameter %d (%s)", i, paramName)
+			return nil, nil, fmt.Errorf("missing input for parameter %d (%s)", i, paramName)
 		}
+		used[key] = true
 
+		if _, isList := input.([]interface{}); variadic && !isList {
+			input = []interface{}{input}
+		}
 		argValue, err := convertToType(input, paramType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert arg %d: %v", i, err)
+			return nil, nil, fmt.Errorf("failed to convert arg %d: %v", i, err)
 		}
 		args[i] = argValue
 	}
 
-	return args, nil
-}
-
-// methodParamNames maps Go methods to parameter names (in Python snake_case).
-// Required because Go reflection doesn't expose parameter names.
-var methodParamNames = map[string][]string{
-	"GetEmployeeByUID":       {"uid"},
-	"GetEmployeeBySlackID":   {"slack_id"},
-	"GetEmployeeByGitHubID":  {"github_id"},
-	"GetEmployeeByEmail":     {"email"},
-	"GetManagerForEmployee":  {"uid"},
-	"GetTeamByName":          {"team_name"},
-	"GetOrgByName":           {"org_name"},
-	"GetPillarByName":        {"pillar_name"},
-	"GetTeamGroupByName":     {"team_group_name"},
-	"GetTeamsForUID":         {"uid"},
-	"GetTeamsForSlackID":     {"slack_id"},
-	"GetTeamMembers":         {"team_name"},
-	"GetUserOrganizations":   {"slack_id"},
-	"GetDescendantsTree":     {"name"},
-	"GetComponentByName":     {"name"},
-	"GetJiraComponents":      {"project"},
-	"GetTeamsByJiraProject":  {"project"},
-	"GetJiraOwnershipForTeam": {"team_name"},
-	"GetUserMemberships":     {"uid"},
-	"GetUserTeams":           {"uid"},
-	"GetOrgMembers":          {"org_name"},
-	"GetTeamEscalation":      {"team_name"},
-	"GetTeamsForComponent":   {"component_name"},
-	"GetComponentsForTeam":   {"team_name"},
-	"IsEmployeeInTeam":       {"uid", "team_name"},
-	"IsSlackUserInTeam":      {"slack_id", "team_name"},
-	"IsEmployeeInOrg":        {"uid", "org_name"},
-	"IsSlackUserInOrg":       {"slack_id", "org_name"},
-	"GetHierarchyPath":             {"name", "entity_type"},
-	"GetTeamsByJiraComponent":      {"project", "component"},
-	"GetContextForTeam":            {"team_name"},
-	"GetContextForEntity":          {"entity_name", "entity_type"},
-	"GetContextByType":             {"entity_name", "context_type", "entity_type"},
-	"GetAllContextTypesForEntity":  {"entity_name", "entity_type"},
-	"GetContextTypeDescriptions":   {},
+	return args, used, nil
 }
 
-func inferParamNames(methodName string, methodType reflect.Type) []string {
-	if names, ok := methodParamNames[methodName]; ok {
-		return names
-	}
-
-	names := []string{}
-	for i := 0; i < methodType.NumIn(); i++ {
-		if methodType.In(i).Kind() == reflect.String && i == 0 {
-			names = append(names, "uid")
-		} else {
-			names = append(names, fmt.Sprintf("param%d", i))
-		}
-	}
-	return names
+// paramAliases lists other input keys accepted for a parameter name, for
+// inputs generated from a Python parameter named differently from Go's.
+var paramAliases = map[string][]string{
+	"uid":           {"employee_uid"},
+	"slack_id":      {"slack_user_id", "slack_uid"},
+	"slack_user_id": {"slack_id", "slack_uid"},
+	"github_id":     {"github_login"},
+	"team_name":     {"team"},
+	"org_name":      {"org"},
+	"pillar_name":   {"pillar"},
+	"name":          {"entity_name", "component_name"},
+	"entity_name":   {"name"},
+	"project":       {"jira_project"},
+	"component":     {"jira_component"},
+	"from":          {"from_node"},
+	"to":            {"to_node"},
 }
 
-func matchesParamName(inputKey, paramName string, paramType reflect.Type) bool {
+func matchesParamName(inputKey, paramName string) bool {
 	if inputKey == paramName {
 		return true
 	}
-
-	mappings := map[string][]string{
-		"uid":       {"uid", "employee_uid"},
-		"slackID":   {"slack_id", "slackID"},
-		"githubID":  {"github_id", "githubID"},
-		"teamName":  {"team_name", "teamName", "team"},
-		"orgName":   {"org_name", "orgName", "org"},
-		"pillarName": {"pillar_name", "pillarName", "pillar"},
-		"name":      {"name", "entity_name"},
-		"email":     {"email"},
-		"project":   {"project", "jira_project"},
-		"component": {"component", "jira_component"},
-	}
-
-	for goName, pythonNames := range mappings {
-		if paramName == goName {
-			for _, pn := range pythonNames {
-				if inputKey == pn {
-					return true
-				}
-			}
+	for _, alias := range paramAliases[paramName] {
+		if inputKey == alias {
+			return true
 		}
 	}
-
 	return false
 }
 
+// convertToType converts a decoded JSON input to targetType. Strings,
+// numbers and booleans are converted directly, so named types such as
+// EntityType work; anything else, such as structs, slices and maps, is
+// round-tripped through JSON into the target type.
 func convertToType(input interface{}, targetType reflect.Type) (reflect.Value, error) {
 	switch targetType.Kind() {
 	case reflect.String:
 		if s, ok := input.(string); ok {
-			return reflect.ValueOf(s), nil
+			return reflect.ValueOf(s).Convert(targetType), nil
 		}
 		return reflect.Value{}, fmt.Errorf("expected string, got %T", input)
-	case reflect.Int, reflect.Int64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
 		if f, ok := input.(float64); ok {
-			return reflect.ValueOf(int(f)), nil
+			return reflect.ValueOf(f).Convert(targetType), nil
 		}
 		return reflect.Value{}, fmt.Errorf("expected number, got %T", input)
 	case reflect.Bool:
 		if b, ok := input.(bool); ok {
-			return reflect.ValueOf(b), nil
+			return reflect.ValueOf(b).Convert(targetType), nil
 		}
 		return reflect.Value{}, fmt.Errorf("expected bool, got %T", input)
-	default:
-		return reflect.Value{}, fmt.Errorf("unsupported type: %s", targetType.Kind())
-	}
-}
-
-func serializeOutput(output interface{}) interface{} {
-	if output == nil {
-		return nil
-	}
-
-	v := reflect.ValueOf(output)
-	if v.Kind() == reflect.Ptr && v.IsNil() {
-		return nil
-	}
-
-	switch val := output.(type) {
-	case bool:
-		return val
-	case string:
-		return val
-	case []string:
-		return serializeStringList(val)
-	case *orgdatacore.Employee:
-		return serializeEmployee(val)
-	case []orgdatacore.Employee:
-		return serializeEmployeeList(val)
-	case *orgdatacore.Team:
-		return serializeTeam(val)
-	case *orgdatacore.Org:
-		return serializeOrg(val)
-	case *orgdatacore.Pillar:
-		return serializePillar(val)
-	case *orgdatacore.TeamGroup:
-		return serializeTeamGroup(val)
-	case *orgdatacore.Component:
-		return serializeComponent(val)
-	case []orgdatacore.Component:
-		return serializeComponentList(val)
-	case []orgdatacore.HierarchyPathEntry:
-		return serializeHierarchyPath(val)
-	case *orgdatacore.HierarchyNode:
-		return serializeHierarchyNode(val)
-	case []orgdatacore.OrgInfo:
-		return serializeOrgInfoList(val)
-	case []orgdatacore.JiraOwnerInfo:
-		return serializeJiraOwnerList(val)
-	case []orgdatacore.JiraOwnership:
-		return serializeJiraOwnershipList(val)
-	case []orgdatacore.Team:
-		return serializeTeamList(val)
-	case []orgdatacore.Org:
-		return serializeOrgList(val)
-	case []orgdatacore.Pillar:
-		return serializePillarList(val)
-	case []orgdatacore.TeamGroup:
-		return serializeTeamGroupList(val)
-	case []orgdatacore.MembershipInfo:
-		return serializeMembershipInfoList(val)
-	case []orgdatacore.EscalationContactInfo:
-		return serializeEscalationList(val)
-	case []orgdatacore.ComponentOwnerInfo:
-		return serializeComponentOwnerInfoList(val)
-	case []orgdatacore.ComponentOwnership:
-		return serializeComponentOwnershipList(val)
-	case []orgdatacore.ContextItemInfo:
-		return serializeContextItemInfoList(val)
-	default:
-		return output
-	}
-}
-
-func serializeStringList(v []string) interface{} {
-	sorted := make([]string, len(v))
-	copy(sorted, v)
-	sort.Strings(sorted)
-	return sorted
-}
-
-func serializeEmployee(emp *orgdatacore.Employee) interface{} {
-	if emp == nil {
-		return nil
-	}
-	return map[string]interface{}{
-		"uid":       emp.UID,
-		"full_name": emp.FullName,
-		"email":     emp.Email,
-	}
-}
-
-func serializeEmployeeList(emps []orgdatacore.Employee) interface{} {
-	result := make([]map[string]interface{}, len(emps))
-	for i, emp := range emps {
-		result[i] = map[string]interface{}{
-			"uid":       emp.UID,
-			"full_name": emp.FullName,
-			"email":     emp.Email,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["uid"].(string) < result[j]["uid"].(string)
-	})
-	return result
-}
-
-func serializeTeam(team *orgdatacore.Team) interface{} {
-	if team == nil {
-		return nil
-	}
-	return map[string]interface{}{
-		"uid":         team.UID,
-		"name":        team.Name,
-		"description": team.Description,
-	}
-}
-
-func serializeOrg(org *orgdatacore.Org) interface{} {
-	if org == nil {
-		return nil
-	}
-	return map[string]interface{}{
-		"uid":         org.UID,
-		"name":        org.Name,
-		"description": org.Description,
-	}
-}
-
-func serializePillar(pillar *orgdatacore.Pillar) interface{} {
-	if pillar == nil {
-		return nil
-	}
-	return map[string]interface{}{
-		"uid":         pillar.UID,
-		"name":        pillar.Name,
-		"description": pillar.Description,
-	}
-}
-
-func serializeTeamGroup(tg *orgdatacore.TeamGroup) interface{} {
-	if tg == nil {
-		return nil
-	}
-	return map[string]interface{}{
-		"uid":         tg.UID,
-		"name":        tg.Name,
-		"description": tg.Description,
-	}
-}
-
-func serializeComponent(comp *orgdatacore.Component) interface{} {
-	if comp == nil {
-		return nil
-	}
-	return map[string]interface{}{
-		"name":        comp.Name,
-		"description": comp.Description,
-	}
-}
-
-func serializeComponentList(comps []orgdatacore.Component) interface{} {
-	result := make([]map[string]interface{}, len(comps))
-	for i, comp := range comps {
-		result[i] = map[string]interface{}{
-			"name":        comp.Name,
-			"description": comp.Description,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializeHierarchyPath(path []orgdatacore.HierarchyPathEntry) interface{} {
-	result := make([]map[string]interface{}, len(path))
-	for i, entry := range path {
-		result[i] = map[string]interface{}{
-			"name": entry.Name,
-			"type": entry.Type,
-		}
-	}
-	return result
-}
-
-func serializeHierarchyNode(node *orgdatacore.HierarchyNode) interface{} {
-	if node == nil {
-		return nil
-	}
-	return serializeNodeRecursive(node)
-}
-
-func serializeNodeRecursive(node *orgdatacore.HierarchyNode) map[string]interface{} {
-	children := make([]map[string]interface{}, len(node.Children))
-	for i := range node.Children {
-		children[i] = serializeNodeRecursive(&node.Children[i])
-	}
-	sort.Slice(children, func(i, j int) bool {
-		return children[i]["name"].(string) < children[j]["name"].(string)
-	})
-	return map[string]interface{}{
-		"name":     node.Name,
-		"type":     node.Type,
-		"children": children,
-	}
-}
-
-func serializeOrgInfoList(infos []orgdatacore.OrgInfo) interface{} {
-	result := make([]map[string]interface{}, len(infos))
-	for i, info := range infos {
-		result[i] = map[string]interface{}{
-			"name": info.Name,
-			"type": string(info.Type),
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializeJiraOwnerList(owners []orgdatacore.JiraOwnerInfo) interface{} {
-	result := make([]map[string]interface{}, len(owners))
-	for i, owner := range owners {
-		result[i] = map[string]interface{}{
-			"name": owner.Name,
-			"type": owner.Type,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializeJiraOwnershipList(ownerships []orgdatacore.JiraOwnership) interface{} {
-	result := make([]map[string]interface{}, len(ownerships))
-	for i, ownership := range ownerships {
-		result[i] = map[string]interface{}{
-			"project":   ownership.Project,
-			"component": ownership.Component,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		if result[i]["project"].(string) != result[j]["project"].(string) {
-			return result[i]["project"].(string) < result[j]["project"].(string)
-		}
-		return result[i]["component"].(string) < result[j]["component"].(string)
-	})
-	return result
-}
-
-func serializeTeamList(teams []orgdatacore.Team) interface{} {
-	result := make([]map[string]interface{}, len(teams))
-	for i, team := range teams {
-		result[i] = map[string]interface{}{
-			"uid":         team.UID,
-			"name":        team.Name,
-			"description": team.Description,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializeOrgList(orgs []orgdatacore.Org) interface{} {
-	result := make([]map[string]interface{}, len(orgs))
-	for i, org := range orgs {
-		result[i] = map[string]interface{}{
-			"uid":         org.UID,
-			"name":        org.Name,
-			"description": org.Description,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializePillarList(pillars []orgdatacore.Pillar) interface{} {
-	result := make([]map[string]interface{}, len(pillars))
-	for i, pillar := range pillars {
-		result[i] = map[string]interface{}{
-			"uid":         pillar.UID,
-			"name":        pillar.Name,
-			"description": pillar.Description,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializeTeamGroupList(tgs []orgdatacore.TeamGroup) interface{} {
-	result := make([]map[string]interface{}, len(tgs))
-	for i, tg := range tgs {
-		result[i] = map[string]interface{}{
-			"uid":         tg.UID,
-			"name":        tg.Name,
-			"description": tg.Description,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["name"].(string) < result[j]["name"].(string)
-	})
-	return result
-}
-
-func serializeMembershipInfoList(infos []orgdatacore.MembershipInfo) interface{} {
-	result := make([]map[string]interface{}, len(infos))
-	for i, info := range infos {
-		result[i] = map[string]interface{}{
-			"name": info.Name,
-			"type": info.Type,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		if result[i]["name"].(string) != result[j]["name"].(string) {
-			return result[i]["name"].(string) < result[j]["name"].(string)
-		}
-		return result[i]["type"].(string) < result[j]["type"].(string)
-	})
-	return result
-}
-
-func serializeEscalationList(contacts []orgdatacore.EscalationContactInfo) interface{} {
-	result := make([]map[string]interface{}, len(contacts))
-	for i, contact := range contacts {
-		result[i] = map[string]interface{}{
-			"name":        contact.Name,
-			"url":         contact.URL,
-			"description": contact.Description,
-		}
-	}
-	// Escalation order matters (it's priority order), so don't sort
-	return result
-}
-
-func serializeComponentOwnerInfoList(owners []orgdatacore.ComponentOwnerInfo) interface{} {
-	result := make([]map[string]interface{}, len(owners))
-	for i, owner := range owners {
-		result[i] = map[string]interface{}{
-			"name":            owner.Name,
-			"type":            owner.Type,
-			"ownership_types": owner.OwnershipTypes,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		if result[i]["name"].(string) != result[j]["name"].(string) {
-			return result[i]["name"].(string) < result[j]["name"].(string)
-		}
-		return result[i]["type"].(string) < result[j]["type"].(string)
-	})
-	return result
-}
-
-func serializeComponentOwnershipList(ownerships []orgdatacore.ComponentOwnership) interface{} {
-	result := make([]map[string]interface{}, len(ownerships))
-	for i, ownership := range ownerships {
-		result[i] = map[string]interface{}{
-			"component":      ownership.Component,
-			"ownership_types": ownership.OwnershipTypes,
-		}
-	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i]["component"].(string) < result[j]["component"].(string)
-	})
-	return result
-}
-
-func serializeContextItemInfoList(items []orgdatacore.ContextItemInfo) interface{} {
-	result := make([]map[string]interface{}, len(items))
-	for i, item := range items {
-		var owner interface{}
-		if item.Owner != "" {
-			owner = item.Owner
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map, reflect.Ptr, reflect.Interface:
+		raw, err := json.Marshal(input)
+		if err != nil {
+			return reflect.Value{}, err
 		}
-		types := make([]string, len(item.Types))
-		copy(types, item.Types)
-		sort.Strings(types)
-		result[i] = map[string]interface{}{
-			"types":         types,
-			"name":          item.Name,
-			"description":   item.Description,
-			"url":           item.URL,
-			"owner":         owner,
-			"inheritance":   item.Inheritance,
-			"source_entity": item.SourceEntity,
-			"source_type":   item.SourceType,
+		target := reflect.New(targetType)
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(target.Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("decode %s: %v", targetType, err)
 		}
+		return target.Elem(), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type: %s", targetType)
 	}
-	sort.Slice(result, func(i, j int) bool {
-		if result[i]["name"].(string) != result[j]["name"].(string) {
-			return result[i]["name"].(string) < result[j]["name"].(string)
-		}
-		return result[i]["source_entity"].(string) < result[j]["source_entity"].(string)
-	})
-	return result
 }
@@ -0,0 +1,68 @@
+// Code generated by gen_manifest.go from go/interface.go; DO NOT EDIT.
+
+package main
+
+// methodParams maps each ServiceInterface method to its parameter names,
+// in the snake_case used by test case inputs.
+var methodParams = map[string][]string{
+	"AreEmployeesInTeam":          {"uids", "team_name"},
+	"FindPath":                    {"from", "to"},
+	"GetAllComponentNames":        {},
+	"GetAllComponents":            {},
+	"GetAllContextTypesForEntity": {"entity_name", "entity_type"},
+	"GetAllEmployeeUIDs":          {},
+	"GetAllEmployees":             {},
+	"GetAllOrgNames":              {},
+	"GetAllOrgs":                  {},
+	"GetAllPillarNames":           {},
+	"GetAllPillars":               {},
+	"GetAllTeamGroupNames":        {},
+	"GetAllTeamGroups":            {},
+	"GetAllTeamNames":             {},
+	"GetAllTeams":                 {},
+	"GetComponentByName":          {"name"},
+	"GetComponentsForTeam":        {"team_name"},
+	"GetContextByType":            {"entity_name", "context_type", "entity_type"},
+	"GetContextForEntity":         {"entity_name", "entity_type"},
+	"GetContextForTeam":           {"team_name"},
+	"GetContextTypeDescriptions":  {},
+	"GetDataAge":                  {},
+	"GetDescendantsTree":          {"entity_name"},
+	"GetEmployeeByEmail":          {"email"},
+	"GetEmployeeByGitHubID":       {"github_id"},
+	"GetEmployeeBySlackID":        {"slack_id"},
+	"GetEmployeeByUID":            {"uid"},
+	"GetHierarchyPath":            {"entity_name", "entity_type"},
+	"GetJiraComponents":           {"project"},
+	"GetJiraOwnershipForTeam":     {"team_name"},
+	"GetJiraProjects":             {},
+	"GetManagerForEmployee":       {"uid"},
+	"GetOrgByName":                {"org_name"},
+	"GetOrgMembers":               {"org_name"},
+	"GetPillarByName":             {"pillar_name"},
+	"GetTeamByName":               {"team_name"},
+	"GetTeamEscalation":           {"team_name"},
+	"GetTeamGroupByName":          {"team_group_name"},
+	"GetTeamMembers":              {"team_name"},
+	"GetTeamsByJiraComponent":     {"project", "component"},
+	"GetTeamsByJiraProject":       {"project"},
+	"GetTeamsBySlackChannel":      {"channel"},
+	"GetTeamsForComponent":        {"component_name"},
+	"GetTeamsForSlackID":          {"slack_id"},
+	"GetTeamsForUID":              {"uid"},
+	"GetUserMemberships":          {"uid"},
+	"GetUserOrganizations":        {"slack_user_id"},
+	"GetUserTeams":                {"uid"},
+	"GetVersion":                  {},
+	"IsDataStale":                 {"max_age"},
+	"IsEmployeeInAnyTeam":         {"uid", "teams"},
+	"IsEmployeeInOrg":             {"uid", "org_name"},
+	"IsEmployeeInTeam":            {"uid", "team_name"},
+	"IsSlackUserInOrg":            {"slack_id", "org_name"},
+	"IsSlackUserInTeam":           {"slack_id", "team_name"},
+	"LoadFromDataSource":          {"ctx", "source"},
+	"Neighborhood":                {"node", "hops"},
+	"Search":                      {"query", "opts"},
+	"StartDataSourceWatcher":      {"ctx", "source"},
+	"StopWatcher":                 {},
+}
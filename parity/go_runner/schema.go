@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// runConfigSchema is the JSON Schema of RunConfig. The runner validates its
+// input against it before running anything, so a malformed config fails
+// with every problem listed rather than case by case.
+//
+//go:embed runconfig.schema.json
+var runConfigSchema []byte
+
+// schema is the subset of JSON Schema used by runconfig.schema.json.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Defs                 map[string]*schema `json:"$defs"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Minimum              *float64           `json:"minimum"`
+	MinLength            *int               `json:"minLength"`
+}
+
+// parseRunConfig validates raw against the RunConfig schema and the
+// ServiceInterface, and decodes it. The error lists every problem found,
+// each prefixed with the JSON pointer of the offending value.
+func parseRunConfig(raw []byte) (RunConfig, error) {
+	var root schema
+	if err := json.Unmarshal(runConfigSchema, &root); err != nil {
+		return RunConfig{}, fmt.Errorf("runconfig.schema.json: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return RunConfig{}, err
+	}
+	problems := root.validate(&root, doc, "")
+	if len(problems) == 0 {
+		var config RunConfig
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return RunConfig{}, err
+		}
+		problems = checkConfig(config)
+		if len(problems) == 0 {
+			return config, nil
+		}
+	}
+	return RunConfig{}, fmt.Errorf("invalid config:\n  %s", strings.Join(problems, "\n  "))
+}
+
+// validate returns the problems of v at path against s. root resolves
+// $ref, which may only point into root's $defs.
+func (s *schema) validate(root *schema, v any, path string) []string {
+	if s.Ref != "" {
+		def, ok := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			return []string{fmt.Sprintf("%s: unresolvable $ref %s", pointer(path), s.Ref)}
+		}
+		return def.validate(root, v, path)
+	}
+
+	if problem := s.checkType(v); problem != "" {
+		return []string{fmt.Sprintf("%s: %s", pointer(path), problem)}
+	}
+
+	problems := []string{}
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required %q", pointer(path), name))
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if prop, ok := s.Properties[key]; ok {
+				problems = append(problems, prop.validate(root, v[key], path+"/"+key)...)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				problems = append(problems, fmt.Sprintf("%s: unknown field %q", pointer(path), key))
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				problems = append(problems, s.Items.validate(root, item, fmt.Sprintf("%s/%d", path, i))...)
+			}
+		}
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			problems = append(problems, fmt.Sprintf("%s: shorter than %d characters", pointer(path), *s.MinLength))
+		}
+	case json.Number:
+		if f, _ := v.Float64(); s.Minimum != nil && f < *s.Minimum {
+			problems = append(problems, fmt.Sprintf("%s: %s is less than %v", pointer(path), v, *s.Minimum))
+		}
+	}
+	return problems
+}
+
+// checkType returns why v is not of s.Type, or "" if it is.
+func (s *schema) checkType(v any) string {
+	ok := true
+	switch s.Type {
+	case "":
+		return ""
+	case "object":
+		_, ok = v.(map[string]any)
+	case "array":
+		_, ok = v.([]any)
+	case "string":
+		_, ok = v.(string)
+	case "boolean":
+		_, ok = v.(bool)
+	case "number":
+		_, ok = v.(json.Number)
+	case "integer":
+		n, isNumber := v.(json.Number)
+		_, err := n.Int64()
+		ok = isNumber && err == nil
+	}
+	if ok {
+		return ""
+	}
+	return fmt.Sprintf("expected %s, got %s", s.Type, jsonType(v))
+}
+
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// checkConfig returns the problems of a schema-valid config that the schema
+// cannot express: methods missing from ServiceInterface, duplicate case
+// names, and inputs that do not bind to the method's parameters.
+func checkConfig(config RunConfig) []string {
+	problems := []string{}
+	for i, method := range config.Methods {
+		path := fmt.Sprintf("/methods/%d", i)
+		m, ok := serviceInterface.MethodByName(method.GoName)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s/go_name: %s is not a ServiceInterface method", path, method.GoName))
+			continue
+		}
+		params := methodParams[method.GoName]
+		seen := map[string]bool{}
+		for j, tc := range method.TestCases {
+			casePath := fmt.Sprintf("%s/test_cases/%d", path, j)
+			if seen[tc.Name] {
+				problems = append(problems, fmt.Sprintf("%s/name: duplicate case name %q in %s", casePath, tc.Name, method.GoName))
+			}
+			seen[tc.Name] = true
+
+			_, used, err := buildArgs(params, m.Type, tc.Inputs)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s/inputs: %s: %v", casePath, method.GoName, err))
+				continue
+			}
+			for _, key := range sortedKeys(tc.Inputs) {
+				if !used[key] {
+					problems = append(problems, fmt.Sprintf("%s/inputs: %s takes no input %q (parameters: %s)",
+						casePath, method.GoName, key, strings.Join(params, ", ")))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+"""Golden files for the parity check.
+
+--record writes every method's normalized outputs to a golden file; --verify
+compares each implementation against them. Parity alone cannot catch a
+format change made to both implementations at once, nor tell which side
+regressed when only one changes; the golden files can.
+
+Files live in parity/golden/v<GOLDEN_FORMAT_VERSION>/<GoMethodName>.json,
+one per method. Bump GOLDEN_FORMAT_VERSION when the file layout changes.
+"""
+
+import hashlib
+import json
+from pathlib import Path
+from typing import Any, Callable
+
+from diff import structural_diff
+
+GOLDEN_FORMAT_VERSION = 1
+
+# Case names generated by --fuzz; their inputs are random, so they are
+# never recorded.
+FUZZ_CASE_PREFIX = "fuzz_"
+
+
+def golden_dir(root: Path) -> Path:
+    """Return the directory holding golden files of the current format."""
+    return root / f"v{GOLDEN_FORMAT_VERSION}"
+
+
+def file_sha256(path: Path) -> str:
+    """Return the hex SHA-256 of a file."""
+    return hashlib.sha256(path.read_bytes()).hexdigest()
+
+
+def golden_case(result: dict[str, Any], normalize: Callable[[Any], Any]) -> dict[str, Any]:
+    """Return what is recorded of one result: its output, or that it errored.
+
+    Error messages differ between languages, so only their presence is kept.
+    """
+    if result.get("error"):
+        return {"error": True}
+    return {"output": normalize(result.get("output"))}
+
+
+def record_golden(
+    root: Path,
+    test_data_path: Path,
+    results: list[dict[str, Any]],
+    method_specs: list[dict[str, Any]],
+    normalize: Callable[[Any], Any],
+) -> int:
+    """Write a golden file per method from Go results and return the count.
+
+    Files of methods that no longer exist are removed.
+    """
+    directory = golden_dir(root)
+    directory.mkdir(parents=True, exist_ok=True)
+    by_key = {(r["method_go_name"], r["case_name"]): r for r in results}
+    data_sha = file_sha256(test_data_path)
+
+    written = set()
+    for spec in method_specs:
+        cases = {}
+        for tc in spec["test_cases"]:
+            if tc["name"].startswith(FUZZ_CASE_PREFIX):
+                continue
+            result = by_key.get((spec["go_name"], tc["name"]))
+            if result is None:
+                continue
+            cases[tc["name"]] = {"inputs": tc["inputs"], **golden_case(result, normalize)}
+        golden = {
+            "format_version": GOLDEN_FORMAT_VERSION,
+            "method": spec["go_name"],
+            "test_data_sha256": data_sha,
+            "cases": cases,
+        }
+        path = directory / f"{spec['go_name']}.json"
+        path.write_text(json.dumps(golden, indent=2, sort_keys=True, ensure_ascii=False) + "\n")
+        written.add(path.name)
+
+    for stale in directory.glob("*.json"):
+        if stale.name not in written:
+            stale.unlink()
+    return len(written)
+
+
+def verify_golden(
+    root: Path,
+    test_data_path: Path,
+    implementation: str,
+    results: list[dict[str, Any]],
+    result_name_key: str,
+    method_specs: list[dict[str, Any]],
+    name_key: str,
+    normalize: Callable[[Any], Any],
+) -> list[str]:
+    """Compare one implementation's results with the golden files.
+
+    result_name_key and name_key select the method name field of the results
+    ("method_go_name") and of the specs ("go_name"). Returns failure messages.
+    """
+    directory = golden_dir(root)
+    by_key = {(r[result_name_key], r["case_name"]): r for r in results}
+    data_sha = file_sha256(test_data_path)
+    failures = []
+
+    for spec in method_specs:
+        go_name = spec["go_name"]
+        path = directory / f"{go_name}.json"
+        if not path.exists():
+            failures.append(f"  {go_name}: no golden file {path}; run with --record")
+            continue
+        golden = json.loads(path.read_text())
+        if golden.get("format_version") != GOLDEN_FORMAT_VERSION:
+            failures.append(f"  {go_name}: golden file has format {golden.get('format_version')}; run with --record")
+            continue
+        if golden.get("test_data_sha256") != data_sha:
+            failures.append(f"  {go_name}: test data changed since the golden file was recorded; run with --record")
+            continue
+
+        for tc in spec["test_cases"]:
+            case_name = tc["name"]
+            if case_name.startswith(FUZZ_CASE_PREFIX):
+                continue
+            want = golden["cases"].get(case_name)
+            if want is None:
+                failures.append(f"  {go_name}/{case_name}: not in golden file; run with --record")
+                continue
+            if want["inputs"] != tc["inputs"]:
+                failures.append(f"  {go_name}/{case_name}: inputs changed since recording; run with --record")
+                continue
+            result = by_key.get((spec[name_key], case_name))
+            if result is None:
+                continue
+            got = golden_case(result, normalize)
+            expected = {k: v for k, v in want.items() if k != "inputs"}
+            if got != expected:
+                lines = [f"  {go_name}/{case_name}: {implementation} output differs from golden file"]
+                lines += [
+                    f"    {d.describe('Golden', implementation)}" for d in structural_diff(expected, got)
+                ]
+                failures.append("\n".join(lines))
+    return failures
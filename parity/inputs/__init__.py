@@ -1,5 +1,6 @@
 """Inputs module for generating test data."""
 
+from .fuzz import FuzzInputGenerator
 from .generator import TestCase, TestInputGenerator
 from .test_data_catalog import TestDataCatalog, load_catalog
 
@@ -8,4 +9,5 @@ __all__ = [
     "load_catalog",
     "TestInputGenerator",
     "TestCase",
+    "FuzzInputGenerator",
 ]
@@ -0,0 +1,79 @@
+"""Generate random and malformed inputs for the parity check's fuzz mode."""
+
+import random
+import string
+from typing import Any
+
+from .generator import TestCase, TestInputGenerator
+from .test_data_catalog import TestDataCatalog
+
+# Characters that commonly trip up lookups: case folding, normalization,
+# right-to-left text, zero-width joiners, emoji and control characters.
+UNICODE_SAMPLES = [
+    "é", "é", "ß", "İ", "ǅ", "日本語", "Ⅻ", "‮", "‍", "﻿",
+    "🙂", "👩‍💻", "\u0000", "\x7f", "\t", "\n",
+]
+
+STRUCTURED_SAMPLES = [
+    "", " ", "\t\n", "../../etc/passwd", "' OR 1=1 --", "%s%n%x", "{{.}}",
+    "${jndi:ldap://x}", "<script>", "null", "None", "0", "-1", "_project_level",
+]
+
+
+class FuzzInputGenerator:
+    """Generate random test cases for a method's string parameters.
+
+    Values mix malformed strings, unicode, huge strings, and mutations of
+    valid catalog values (case changes, padding, truncation), so both
+    implementations are exercised on inputs that are almost, but not quite,
+    found. Generation is deterministic for a given seed.
+    """
+
+    def __init__(self, catalog: TestDataCatalog, seed: int) -> None:
+        self.valid = TestInputGenerator(catalog)
+        self.rng = random.Random(seed)
+
+    def generate_for_params(
+        self, params: list[tuple[str, Any]], count: int
+    ) -> list[TestCase]:
+        """Generate count fuzz cases.
+
+        Methods without parameters, or with parameters that are not strings,
+        get none.
+        """
+        if not params or any(t not in (str, Any) for _, t in params):
+            return []
+        return [
+            TestCase(
+                name=f"fuzz_{i}",
+                inputs={name: self._value_for_param(name) for name, _ in params},
+            )
+            for i in range(count)
+        ]
+
+    def _value_for_param(self, param_name: str) -> str:
+        valid = self.valid._get_values_for_param(param_name)
+        strategy = self.rng.randrange(5)
+        if strategy == 0 and valid:
+            return self._mutate(self.rng.choice(valid))
+        if strategy == 1:
+            return self.rng.choice(STRUCTURED_SAMPLES)
+        if strategy == 2:
+            return "".join(self.rng.choices(UNICODE_SAMPLES, k=self.rng.randint(1, 8)))
+        if strategy == 3:
+            return self.rng.choice(string.ascii_letters) * self.rng.choice([1_000, 10_000, 100_000])
+        return "".join(
+            self.rng.choices(string.printable, k=self.rng.randint(1, 40))
+        )
+
+    def _mutate(self, value: str) -> str:
+        mutation = self.rng.randrange(5)
+        if mutation == 0:
+            return value.upper()
+        if mutation == 1:
+            return f" {value} "
+        if mutation == 2:
+            return value[: max(1, len(value) - 1)]
+        if mutation == 3:
+            return value + self.rng.choice(UNICODE_SAMPLES)
+        return value.swapcase()
@@ -50,6 +50,16 @@ class TestInputGenerator:
             missing_inputs[param_name] = self._get_invalid_value_for_param(param_name)
         test_cases.append(TestCase(name="missing", inputs=missing_inputs))
 
+        # An empty email must not match employees that have no email.
+        for param_name, _ in params:
+            if "email" in param_name.lower():
+                test_cases.append(
+                    TestCase(
+                        name=f"empty_{param_name}",
+                        inputs={**valid_inputs, param_name: ""},
+                    )
+                )
+
         for param_name, _ in params:
             values = self._get_values_for_param(param_name)
             for i, value in enumerate(values[1:3], start=2):  # Up to 2 more values
@@ -65,6 +75,32 @@ class TestInputGenerator:
         """Get valid values for a parameter based on its name."""
         name_lower = param_name.lower()
 
+        # Graph nodes: paths run from employees toward groups.
+        if name_lower in ("node", "from_node"):
+            return self._graph_nodes()
+        if name_lower == "to_node":
+            return self._graph_nodes()[::-1]
+        if name_lower == "hops":
+            return [1, 2, 3]
+        # Search queries: a prefix, a whole name and an email.
+        if name_lower == "query":
+            values = []
+            if self.catalog.team_names:
+                values.append(self.catalog.team_names[0][:4])
+            if self.catalog.pillar_names:
+                values.append(self.catalog.pillar_names[0])
+            if self.catalog.employee_emails:
+                values.append(self.catalog.employee_emails[0])
+            return values
+
+        # List parameters: every value, one, and none.
+        if name_lower == "uids":
+            uids = self.catalog.employee_uids
+            return [uids, uids[:1], []]
+        if name_lower == "teams":
+            teams = self.catalog.team_names
+            return [teams, teams[:1], []]
+
         if name_lower in ("uid", "employee_uid", "employeeuid", "manager_uid", "manageruid"):
             return self.catalog.employee_uids
         if "email" in name_lower:
@@ -100,10 +136,31 @@ class TestInputGenerator:
 
         return []
 
+    def _graph_nodes(self) -> list[dict[str, str]]:
+        """Get an employee and one group of each type, as graph nodes."""
+        nodes = [
+            ("employee", self.catalog.employee_uids),
+            ("team", self.catalog.team_names),
+            ("org", self.catalog.org_names),
+            ("pillar", self.catalog.pillar_names),
+            ("team_group", self.catalog.team_group_names),
+        ]
+        return [{"type": t, "name": names[0]} for t, names in nodes if names]
+
     def _get_invalid_value_for_param(self, param_name: str) -> Any:
         """Get an invalid/missing value for a parameter."""
         name_lower = param_name.lower()
 
+        if name_lower in ("node", "from_node", "to_node"):
+            return {"type": "team", "name": self.catalog.invalid_team}
+        if name_lower == "hops":
+            return 0
+        if name_lower == "query":
+            return self.catalog.invalid_team
+        if name_lower == "uids":
+            return [self.catalog.invalid_uid]
+        if name_lower == "teams":
+            return [self.catalog.invalid_team]
         if name_lower in ("uid", "employee_uid", "employeeuid", "manager_uid"):
             return self.catalog.invalid_uid
         if "email" in name_lower:
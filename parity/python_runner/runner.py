@@ -4,16 +4,21 @@
 Accepts method configuration via stdin and outputs JSON results.
 """
 
+import inspect
 import json
+import os
+import signal
 import sys
+import time
+from concurrent.futures import ProcessPoolExecutor
 from dataclasses import dataclass
 from pathlib import Path
-from typing import Any
+from typing import Any, get_type_hints
 
 REPO_ROOT = Path(__file__).parent.parent.parent
 sys.path.insert(0, str(REPO_ROOT / "python"))
 
-from pydantic import BaseModel
+from pydantic import BaseModel, TypeAdapter
 
 from orgdatacore import Service  # noqa: E402
 from orgdatacore._internal.testing import FileDataSource  # noqa: E402
@@ -78,6 +83,13 @@ ENTITY_REGISTRY: dict[str, EntityConfig] = {
         fields=("name", "url", "description"),
         preserve_order=True,
     ),
+    "DataVersion": EntityConfig(
+        fields=("checksum", "org_count", "employee_count"),
+    ),
+    "SearchResult": EntityConfig(
+        fields=("type", "name", "label", "score"),
+        preserve_order=True,
+    ),
     "ComponentOwnerInfo": EntityConfig(
         fields=("name", "type", "ownership_types"),
         sort_by=("name", "type"),
@@ -94,21 +106,88 @@ ENTITY_REGISTRY: dict[str, EntityConfig] = {
 }
 
 
+# Prefix of the error reported for a test case that ran over
+# case_timeout_ms; the orchestrator fails any case with it.
+TIMEOUT_PREFIX = "timeout: "
+
+
+class CaseTimeout(BaseException):
+    """Raised in a worker when a test case runs over its time limit.
+
+    A BaseException, so that run_test_case, which reports every Exception
+    as the method's error, lets it through.
+    """
+
+
+# The service of a worker process, loaded once by init_worker.
+_worker_service: Service | None = None
+
+
 def main() -> None:
-    """Read config from stdin, run tests, output results."""
-    config = json.load(sys.stdin)
-    svc = load_service(config["test_data_path"])
+    """Read config from stdin, run tests, output results.
 
-    results = []
-    for method_spec in config["methods"]:
-        python_name = method_spec["python_name"]
-        for tc in method_spec["test_cases"]:
-            results.append(run_test_case(svc, python_name, tc))
+    Test cases run in config["workers"] processes (os.cpu_count() if unset),
+    each with its own Service, or one at a time when benchmarking. Results
+    are in config order whatever the order cases finish in.
+    """
+    config = json.load(sys.stdin)
+    bench_iterations = config.get("bench_iterations", 0)
+    timeout_ms = config.get("case_timeout_ms", 0)
+    jobs = [
+        (method_spec["python_name"], tc, bench_iterations, timeout_ms)
+        for method_spec in config["methods"]
+        for tc in method_spec["test_cases"]
+    ]
+
+    workers = config.get("workers") or os.cpu_count() or 1
+    if bench_iterations > 0:
+        workers = 1
+
+    if workers == 1:
+        init_worker(config["test_data_path"])
+        results = [run_job(job) for job in jobs]
+    else:
+        with ProcessPoolExecutor(
+            max_workers=workers, initializer=init_worker, initargs=(config["test_data_path"],)
+        ) as executor:
+            results = list(executor.map(run_job, jobs, chunksize=max(1, len(jobs) // (workers * 4))))
 
     json.dump(results, sys.stdout, indent=2, default=json_serializer)
     print()
 
 
+def init_worker(test_data_path: str) -> None:
+    """Load the service of the current process."""
+    global _worker_service
+    _worker_service = load_service(test_data_path)
+
+
+def run_job(job: tuple[str, dict[str, Any], int, int]) -> dict[str, Any]:
+    """Run one test case on the worker's service, bounded by its timeout."""
+    python_name, tc, bench_iterations, timeout_ms = job
+    assert _worker_service is not None
+    if timeout_ms <= 0:
+        return run_test_case(_worker_service, python_name, tc, bench_iterations)
+
+    def on_alarm(signum: int, frame: Any) -> None:
+        raise CaseTimeout
+
+    previous = signal.signal(signal.SIGALRM, on_alarm)
+    signal.setitimer(signal.ITIMER_REAL, timeout_ms / 1000)
+    try:
+        return run_test_case(_worker_service, python_name, tc, bench_iterations)
+    except CaseTimeout:
+        return {
+            "method_python_name": python_name,
+            "case_name": tc["name"],
+            "output": None,
+            "error": f"{TIMEOUT_PREFIX}no result after {timeout_ms}ms",
+        }
+    finally:
+        signal.setitimer(signal.ITIMER_REAL, 0)
+        signal.signal(signal.SIGALRM, previous)
+
+
 def load_service(test_data_path: str) -> Service:
     """Load service with test data."""
     svc = Service()
@@ -117,8 +196,14 @@ def load_service(test_data_path: str) -> Service:
     return svc
 
 
-def run_test_case(svc: Service, python_name: str, tc: dict[str, Any]) -> dict[str, Any]:
-    """Run a single test case."""
+def run_test_case(
+    svc: Service, python_name: str, tc: dict[str, Any], bench_iterations: int = 0
+) -> dict[str, Any]:
+    """Run a single test case.
+
+    If bench_iterations is positive, the call is then timed over that many
+    iterations and the mean is reported as ns_per_op.
+    """
     result: dict[str, Any] = {
         "method_python_name": python_name,
         "case_name": tc["name"],
@@ -133,8 +218,14 @@ def run_test_case(svc: Service, python_name: str, tc: dict[str, Any]) -> dict[st
     inputs = tc.get("inputs", {})
 
     try:
-        output = method(**inputs)
+        args, kwargs = bind_inputs(method, inputs)
+        output = method(*args, **kwargs)
         result["output"] = serialize_output(output)
+        if bench_iterations > 0:
+            start = time.perf_counter_ns()
+            for _ in range(bench_iterations):
+                method(*args, **kwargs)
+            result["ns_per_op"] = (time.perf_counter_ns() - start) / bench_iterations
     except TypeError:
         try:
             output = method(*list(inputs.values()))
@@ -149,6 +240,51 @@ def run_test_case(svc: Service, python_name: str, tc: dict[str, Any]) -> dict[st
     return result
 
 
+def bind_inputs(method: Any, inputs: dict[str, Any]) -> tuple[list[Any], dict[str, Any]]:
+    """Convert JSON inputs to the method's annotated parameter types.
+
+    Lists and dicts are validated into the annotated type, so methods taking
+    models, lists or mappings get the values the Go runner decodes. A *args
+    parameter accepts a list, a single value, or no input, like a Go
+    variadic parameter.
+    """
+    try:
+        hints = get_type_hints(method)
+    except Exception:
+        hints = {}
+
+    args: list[Any] = []
+    kwargs: dict[str, Any] = {}
+    params = inspect.signature(method).parameters
+    unknown = sorted(set(inputs) - set(params))
+    if unknown:
+        # Let the caller fall back to positional arguments.
+        raise TypeError(f"unexpected inputs: {unknown}")
+
+    # Parameters are passed positionally up to the first one without an
+    # input, so that a *args parameter after them lines up.
+    positional = True
+    for name, param in params.items():
+        if name not in inputs:
+            positional = False
+            continue
+        value = inputs[name]
+        hint = hints.get(name)
+        if param.kind is inspect.Parameter.VAR_POSITIONAL:
+            values = value if isinstance(value, list) else [value]
+            if hint is not None:
+                values = [TypeAdapter(hint).validate_python(v) for v in values]
+            args.extend(values)
+            continue
+        if hint is not None and isinstance(value, (dict, list)):
+            value = TypeAdapter(hint).validate_python(value)
+        if positional and param.kind is not inspect.Parameter.KEYWORD_ONLY:
+            args.append(value)
+        else:
+            kwargs[name] = value
+    return args, kwargs
+
+
 def serialize_output(output: Any) -> Any:
     """Serialize output for comparison with Go."""
     if output is None:
@@ -174,6 +310,9 @@ def serialize_entity(entity: Any) -> dict[str, Any]:
 
     if entity_type == "HierarchyNode":
         return serialize_hierarchy_node(entity)
+    if entity_type == "GraphEdge":
+        # Keys "from" and "to" as in Go; lists of edges keep their order.
+        return entity.model_dump(by_alias=True, mode="json")
 
     config = ENTITY_REGISTRY.get(entity_type)
     if config is not None:
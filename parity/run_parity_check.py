@@ -5,33 +5,164 @@ This script automatically discovers API methods in both Go and Python,
 generates test inputs from test data, runs both implementations, and
 compares outputs.
 
+With --bench, every test case is also timed over --iterations calls in both
+implementations and a per-method latency report is printed (and written as
+JSON with --bench-report), so performance regressions are tracked alongside
+output parity. Timings never affect the exit code.
+
+With --fuzz, --fuzz-cases random and malformed inputs (unicode, huge strings,
+near-miss variants of valid values) are added for every method taking string
+parameters, to check that neither implementation crashes and that both agree
+on what is not found. The seed is printed so a failing run can be repeated
+with --seed.
+
+--record writes every method's outputs to golden files under parity/golden
+once both implementations agree, and --verify also checks both against them,
+catching output-format changes even when only one implementation changes.
+
+The Go runner reports which ServiceInterface methods have test cases; the
+check fails when more than --max-uncovered have none.
+
+Go's All* iterators, which are not in ServiceInterface, are materialized
+(up to --iterator-cap items) alongside every case of the GetAll* method
+they mirror and compared with that method's Python counterpart.
+
+Each runner runs test cases on --workers concurrent workers (one at a time
+with --bench), with results kept in input order; a case running longer than
+--case-timeout seconds fails.
+
+Output mismatches are reported as structural diffs: the paths added,
+removed or changed between the Go and Python output, with a per-method
+summary table. --diff-report writes every failure and its diff as JSON.
+
 Exit codes:
 - 0: All parity checks passed
 - 1: Parity failures detected
 - 2: Infrastructure error
 """
 
+import argparse
 import json
+import random
 import subprocess
 import sys
 from pathlib import Path
 from typing import Any
 
 PARITY_ROOT = Path(__file__).parent
+GOLDEN_ROOT = PARITY_ROOT / "golden"
 REPO_ROOT = PARITY_ROOT.parent
 sys.path.insert(0, str(PARITY_ROOT))
 sys.path.insert(0, str(REPO_ROOT / "python"))
 
+from diff import (  # noqa: E402
+    ParityFailure,
+    print_failure_summary,
+    structural_diff,
+    summarize_failures,
+)
 from discovery import normalize, parse_go_interface  # noqa: E402
 from discovery.python_introspector import (  # noqa: E402
     EXCLUDED_METHODS,
     introspect_python_service,
 )
-from inputs import TestInputGenerator, load_catalog  # noqa: E402
+from golden import golden_dir, record_golden, verify_golden  # noqa: E402
+from inputs import FuzzInputGenerator, TestInputGenerator, load_catalog  # noqa: E402
+
+
+def parse_args(argv: list[str] | None = None) -> argparse.Namespace:
+    """Parse command line arguments."""
+    parser = argparse.ArgumentParser(description=__doc__.splitlines()[0])
+    parser.add_argument(
+        "--bench",
+        action="store_true",
+        help="time each method in both implementations and report latency ratios",
+    )
+    parser.add_argument(
+        "--iterations",
+        type=int,
+        default=1000,
+        help="calls per test case in --bench mode (default: 1000)",
+    )
+    parser.add_argument(
+        "--bench-report",
+        type=Path,
+        help="write the --bench report as JSON to this file",
+    )
+    parser.add_argument(
+        "--workers",
+        type=int,
+        default=0,
+        help="test cases run concurrently by each runner (default: CPU count)",
+    )
+    parser.add_argument(
+        "--case-timeout",
+        type=float,
+        default=10.0,
+        help="seconds a test case may run before it fails; 0 disables (default: 10)",
+    )
+    parser.add_argument(
+        "--max-uncovered",
+        type=int,
+        default=0,
+        help="ServiceInterface methods allowed without test cases before the check "
+        "fails; negative allows any number (default: 0)",
+    )
+    parser.add_argument(
+        "--iterator-cap",
+        type=int,
+        default=10000,
+        help="items a Go All* iterator may yield before it fails (default: 10000)",
+    )
+    parser.add_argument(
+        "--diff-report",
+        type=Path,
+        help="write every parity failure, with its structural diff, as JSON to this file",
+    )
+    golden = parser.add_mutually_exclusive_group()
+    golden.add_argument(
+        "--record",
+        action="store_true",
+        help="write each method's outputs to golden files under parity/golden",
+    )
+    golden.add_argument(
+        "--verify",
+        action="store_true",
+        help="also compare each implementation's outputs with the golden files",
+    )
+    parser.add_argument(
+        "--fuzz",
+        action="store_true",
+        help="add random and malformed inputs for every method taking strings",
+    )
+    parser.add_argument(
+        "--fuzz-cases",
+        type=int,
+        default=50,
+        help="fuzz cases per method in --fuzz mode (default: 50)",
+    )
+    parser.add_argument(
+        "--seed",
+        type=int,
+        help="seed for --fuzz inputs (default: random)",
+    )
+    return parser.parse_args(argv)
 
 
-def main() -> int:
+def main(argv: list[str] | None = None) -> int:
     """Run parity check and return exit code."""
+    args = parse_args(argv)
+    bench_iterations = args.iterations if args.bench else 0
+    if args.bench and args.iterations < 1:
+        print("ERROR: --iterations must be positive")
+        return 2
+    if args.iterator_cap < 1:
+        print("ERROR: --iterator-cap must be positive")
+        return 2
+    if args.workers < 0 or args.case_timeout < 0:
+        print("ERROR: --workers and --case-timeout must not be negative")
+        return 2
+
     print("=" * 60)
     print("Dynamic API Parity Check")
     print("=" * 60)
@@ -98,6 +229,11 @@ def main() -> int:
     print("Step 3: Generating test inputs...")
     catalog = load_catalog(test_data_path)
     generator = TestInputGenerator(catalog)
+    fuzzer = None
+    if args.fuzz:
+        seed = args.seed if args.seed is not None else random.randrange(2**32)
+        fuzzer = FuzzInputGenerator(catalog, seed)
+        print(f"  Fuzzing with --seed {seed}, {args.fuzz_cases} cases per method")
 
     # Match Go and Python methods by normalized name
     go_methods_by_name = {m.name: m for m in go_methods}
@@ -115,6 +251,8 @@ def main() -> int:
     method_test_cases = []
     for go_method, python_method in testable_methods:
         test_cases = generator.generate_for_params(python_method.params)
+        if fuzzer is not None:
+            test_cases += fuzzer.generate_for_params(python_method.params, args.fuzz_cases)
         method_test_cases.append({
             "go_name": go_method.name,
             "python_name": python_method.name,
@@ -125,21 +263,31 @@ def main() -> int:
     go_config = {
         "test_data_path": str(test_data_path),
         "methods": method_test_cases,
+        "bench_iterations": bench_iterations,
+        "workers": args.workers,
+        "case_timeout_ms": int(args.case_timeout * 1000),
+        "max_uncovered": args.max_uncovered,
+        "iterator_cap": args.iterator_cap,
     }
 
     try:
-        go_results = run_go_runner(go_runner_path, go_config)
+        go_results, coverage, coverage_ok = run_go_runner(go_runner_path, go_config)
     except Exception as e:
         print(f"ERROR: Go runner failed: {e}")
         return 2
 
     print(f"  Got {len(go_results)} results")
     print()
+    print_coverage(coverage)
+    print()
 
     print("Step 5: Running Python implementation...")
     python_config = {
         "test_data_path": str(test_data_path),
         "methods": method_test_cases,
+        "bench_iterations": bench_iterations,
+        "workers": args.workers,
+        "case_timeout_ms": int(args.case_timeout * 1000),
     }
 
     try:
@@ -151,14 +299,58 @@ def main() -> int:
     print(f"  Got {len(python_results)} results")
     print()
 
+    if args.bench:
+        print(f"Benchmark ({args.iterations} iterations per case):")
+        report = bench_report(go_results, python_results, method_test_cases)
+        print_bench_report(report)
+        if args.bench_report:
+            args.bench_report.write_text(json.dumps(report, indent=2) + "\n")
+            print(f"  Wrote {args.bench_report}")
+        print()
+
     print("Step 6: Comparing outputs...")
-    failures = compare_results(go_results, python_results, method_test_cases)
+    parity_failures = compare_results(go_results, python_results, method_test_cases)
+    if args.diff_report:
+        args.diff_report.write_text(
+            json.dumps([f.to_dict() for f in parity_failures], indent=2) + "\n"
+        )
+        print(f"  Wrote {args.diff_report}")
+    failures = [str(f) for f in parity_failures]
+    if not coverage_ok:
+        failures.insert(0, (
+            f"  Coverage: {len(coverage['uncovered'])} ServiceInterface methods without "
+            f"test cases, more than --max-uncovered {args.max_uncovered}: "
+            + ", ".join(coverage["uncovered"])
+        ))
+
+    if args.verify:
+        print("Step 7: Verifying against golden files...")
+        for implementation, results, result_key, name_key in (
+            ("Go", go_results, "method_go_name", "go_name"),
+            ("Python", python_results, "method_python_name", "python_name"),
+        ):
+            failures += verify_golden(
+                GOLDEN_ROOT, test_data_path, implementation, results, result_key,
+                method_test_cases, name_key, normalize_output,
+            )
+
+    if args.record:
+        if failures:
+            print("  Not recording golden files while parity checks fail")
+        else:
+            count = record_golden(
+                GOLDEN_ROOT, test_data_path, go_results, method_test_cases, normalize_output
+            )
+            print(f"  Recorded {count} golden files in {golden_dir(GOLDEN_ROOT)}")
 
     if failures:
         print()
         print("=" * 60)
         print(f"FAILED: {len(failures)} parity failures detected")
         print("=" * 60)
+        if parity_failures:
+            print_failure_summary(summarize_failures(parity_failures))
+            print()
         for failure in failures[:20]:
             print(failure)
         if len(failures) > 20:
@@ -172,8 +364,23 @@ def main() -> int:
     return 0
 
 
-def run_go_runner(runner_path: Path, config: dict[str, Any]) -> list[dict[str, Any]]:
-    """Run the Go test runner and return results."""
+# The Go runner reports a method that panicked as an error with this prefix.
+# A panic is always a failure, even if Python raised too.
+GO_PANIC_PREFIX = "panic: "
+
+# Both runners report a test case that ran over --case-timeout as an error
+# with this prefix. A timeout is always a failure, even on both sides.
+TIMEOUT_PREFIX = "timeout: "
+
+
+def run_go_runner(
+    runner_path: Path, config: dict[str, Any]
+) -> tuple[list[dict[str, Any]], dict[str, Any], bool]:
+    """Run the Go test runner.
+
+    Returns the results, the runner's ServiceInterface coverage report, and
+    whether coverage is within config["max_uncovered"].
+    """
     build_result = subprocess.run(
         ["go", "build", "-mod=mod", "-o", "runner", "."],
         cwd=runner_path,
@@ -189,10 +396,13 @@ def run_go_runner(runner_path: Path, config: dict[str, Any]) -> list[dict[str, A
         capture_output=True,
         text=True,
     )
-    if result.returncode != 0:
+    # Exit code 1 means too many methods are uncovered; results are still
+    # written.
+    if result.returncode not in (0, 1):
         raise RuntimeError(f"Go runner failed: {result.stderr}")
 
-    return json.loads(result.stdout)
+    output = json.loads(result.stdout)
+    return output["results"], output["coverage"], result.returncode == 0
 
 
 def run_python_runner(
@@ -215,13 +425,22 @@ def compare_results(
     go_results: list[dict[str, Any]],
     python_results: list[dict[str, Any]],
     method_specs: list[dict[str, Any]],
-) -> list[str]:
-    """Compare Go and Python results, return list of failure messages."""
+) -> list[ParityFailure]:
+    """Compare Go and Python results, return the failing cases.
+
+    Go's All* iterator results, which name their GetAll* method in
+    iterator_of, are compared with the Python result of that method.
+    Output mismatches carry a structural diff of the normalized outputs.
+    """
     failures = []
 
     go_by_key: dict[str, dict[str, Any]] = {}
+    iterators_by_key: dict[str, list[dict[str, Any]]] = {}
     for r in go_results:
-        go_by_key[f"{r['method_go_name']}:{r['case_name']}"] = r
+        if r.get("iterator_of"):
+            iterators_by_key.setdefault(f"{r['iterator_of']}:{r['case_name']}", []).append(r)
+        else:
+            go_by_key[f"{r['method_go_name']}:{r['case_name']}"] = r
 
     python_by_key: dict[str, dict[str, Any]] = {}
     for r in python_results:
@@ -240,41 +459,122 @@ def compare_results(
             py_result = python_by_key.get(py_key)
 
             if go_result is None:
-                failures.append(f"  {go_name}/{case_name}: Missing Go result")
+                failures.append(ParityFailure(go_name, case_name, "Missing Go result"))
                 continue
             if py_result is None:
-                failures.append(f"  {python_name}/{case_name}: Missing Python result")
+                failures.append(ParityFailure(go_name, case_name, "Missing Python result"))
                 continue
 
-            go_error = go_result.get("error", "")
-            py_error = py_result.get("error", "")
+            for r in [go_result] + iterators_by_key.get(go_key, []):
+                failure = compare_case(r["method_go_name"], case_name, r, py_result)
+                if failure is not None:
+                    failures.append(failure)
 
-            if go_error and py_error:
-                continue
-            if go_error and not py_error:
-                failures.append(
-                    f"  {go_name}/{case_name}: Go errored but Python succeeded\n"
-                    f"    Go error: {go_error}"
-                )
-                continue
-            if py_error and not go_error:
-                failures.append(
-                    f"  {go_name}/{case_name}: Python errored but Go succeeded\n"
-                    f"    Python error: {py_error}"
-                )
-                continue
+    return failures
 
-            go_output = normalize_output(go_result.get("output"))
-            py_output = normalize_output(py_result.get("output"))
 
-            if go_output != py_output:
-                failures.append(
-                    f"  {go_name}/{case_name}: Output mismatch\n"
-                    f"    Go:     {json.dumps(go_output, sort_keys=True)}\n"
-                    f"    Python: {json.dumps(py_output, sort_keys=True)}"
-                )
+def compare_case(
+    go_name: str, case_name: str, go_result: dict[str, Any], py_result: dict[str, Any]
+) -> ParityFailure | None:
+    """Compare one Go result with its Python result."""
+    go_error = go_result.get("error", "")
+    py_error = py_result.get("error", "")
 
-    return failures
+    if go_error.startswith((GO_PANIC_PREFIX, TIMEOUT_PREFIX)):
+        return ParityFailure(go_name, case_name, f"Go {go_error}")
+    if py_error.startswith(TIMEOUT_PREFIX):
+        return ParityFailure(go_name, case_name, f"Python {py_error}")
+
+    if go_error and py_error:
+        return None
+    if go_error and not py_error:
+        return ParityFailure(
+            go_name, case_name, "Go errored but Python succeeded",
+            details=[f"Go error: {go_error}"],
+        )
+    if py_error and not go_error:
+        return ParityFailure(
+            go_name, case_name, "Python errored but Go succeeded",
+            details=[f"Python error: {py_error}"],
+        )
+
+    go_output = normalize_output(go_result.get("output"))
+    py_output = normalize_output(py_result.get("output"))
+    if go_output != py_output:
+        return ParityFailure(
+            go_name, case_name, "Output mismatch",
+            diffs=structural_diff(go_output, py_output),
+        )
+    return None
+
+
+def print_coverage(coverage: dict[str, Any]) -> None:
+    """Print the Go runner's ServiceInterface coverage report."""
+    print(
+        f"Coverage: {coverage['covered']}/{coverage['methods']} ServiceInterface methods "
+        f"have test cases ({coverage['percent']:.1f}%), {len(coverage['excluded'])} excluded"
+    )
+    for name in coverage["uncovered"]:
+        print(f"  - No test cases: {name}")
+
+
+def bench_report(
+    go_results: list[dict[str, Any]],
+    python_results: list[dict[str, Any]],
+    method_specs: list[dict[str, Any]],
+) -> list[dict[str, Any]]:
+    """Summarize --bench timings per method.
+
+    Each entry holds the mean ns per call over the method's test cases in
+    each implementation and their ratio (Python / Go). Cases that errored or
+    were not timed in either implementation are left out. Entries are sorted
+    by ratio, slowest Python relative to Go first.
+    """
+    go_ns = {
+        (r["method_go_name"], r["case_name"]): r["ns_per_op"]
+        for r in go_results
+        if r.get("ns_per_op") and not r.get("error")
+    }
+    python_ns = {
+        (r["method_python_name"], r["case_name"]): r["ns_per_op"]
+        for r in python_results
+        if r.get("ns_per_op") and not r.get("error")
+    }
+
+    report = []
+    for spec in method_specs:
+        pairs = [
+            (go_ns[(spec["go_name"], tc["name"])], python_ns[(spec["python_name"], tc["name"])])
+            for tc in spec["test_cases"]
+            if (spec["go_name"], tc["name"]) in go_ns
+            and (spec["python_name"], tc["name"]) in python_ns
+        ]
+        if not pairs:
+            continue
+        go_mean = sum(g for g, _ in pairs) / len(pairs)
+        python_mean = sum(p for _, p in pairs) / len(pairs)
+        report.append({
+            "method": spec["go_name"],
+            "cases": len(pairs),
+            "go_ns_per_op": round(go_mean, 1),
+            "python_ns_per_op": round(python_mean, 1),
+            "ratio": round(python_mean / go_mean, 2) if go_mean else None,
+        })
+
+    report.sort(key=lambda e: (-(e["ratio"] or 0), e["method"]))
+    return report
+
+
+def print_bench_report(report: list[dict[str, Any]]) -> None:
+    """Print a --bench report as a table."""
+    width = max([len("Method")] + [len(e["method"]) for e in report])
+    print(f"  {'Method':<{width}}  {'Go ns/op':>12}  {'Python ns/op':>12}  {'Py/Go':>7}")
+    for e in report:
+        ratio = f"{e['ratio']:.2f}" if e["ratio"] is not None else "-"
+        print(
+            f"  {e['method']:<{width}}  {e['go_ns_per_op']:>12,.0f}"
+            f"  {e['python_ns_per_op']:>12,.0f}  {ratio:>7}"
+        )
 
 
 def normalize_output(output: Any) -> Any:
@@ -59,6 +59,9 @@ from ._types import (
     EscalationContactInfo,
     GCSConfig,
     GitHubIDMappings,
+    GraphEdge,
+    GraphEdgeKind,
+    GraphNode,
     Group,
     GroupType,
     HierarchyNode,
@@ -81,6 +84,7 @@ from ._types import (
     RepoInfo,
     ResourceInfo,
     RoleInfo,
+    SearchResult,
     SlackConfig,
     SlackIDMappings,
     Team,
@@ -114,6 +118,9 @@ __all__ = [
     "MembershipInfo",
     "HierarchyPathEntry",
     "HierarchyNode",
+    "GraphNode",
+    "GraphEdge",
+    "SearchResult",
     "SlackIDMappings",
     "GitHubIDMappings",
     "JiraIndex",
@@ -137,6 +144,7 @@ __all__ = [
     "GCSConfig",
     "MembershipType",
     "OrgInfoType",
+    "GraphEdgeKind",
     "PIIMode",
     "DataSource",
     "RedactingDataSource",
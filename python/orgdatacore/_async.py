@@ -17,6 +17,7 @@ Example:
 """
 
 import asyncio
+import hashlib
 import inspect
 import json
 from collections.abc import Awaitable, Callable
@@ -26,7 +27,17 @@ from typing import Any, BinaryIO
 
 from ._exceptions import ConfigurationError, DataLoadError, GCSError
 from ._log import get_logger
-from ._service import _normalize_slack_channel, parse_data
+from ._service import (
+    _DEFAULT_SEARCH_LIMIT,
+    _build_graph,
+    _find_path,
+    _GraphEdgeKey,
+    _GraphNodeKey,
+    _neighborhood,
+    _normalize_slack_channel,
+    _SearchIndex,
+    parse_data,
+)
 from ._types import (
     Component,
     ComponentOwnerInfo,
@@ -37,6 +48,8 @@ from ._types import (
     Employee,
     EscalationContactInfo,
     GCSConfig,
+    GraphEdge,
+    GraphNode,
     HierarchyNode,
     HierarchyPathEntry,
     JiraOwnerInfo,
@@ -46,6 +59,7 @@ from ._types import (
     OrgInfo,
     OrgInfoType,
     Pillar,
+    SearchResult,
     Team,
     TeamGroup,
 )
@@ -84,6 +98,8 @@ class AsyncService:
         self._watcher_task: asyncio.Task[None] | None = None
         self._watcher_source: Any | None = None
         self._slack_channel_index: dict[str, list[str]] = {}
+        self._graph: dict[_GraphNodeKey, list[_GraphEdgeKey]] | None = None
+        self._search_index: _SearchIndex | None = None
 
     async def initialize(self) -> None:
         """Initialize the service if a data source was provided.
@@ -120,8 +136,8 @@ class AsyncService:
 
         try:
             content = reader.read()
-            if isinstance(content, bytes):
-                content = content.decode("utf-8")
+            if isinstance(content, str):
+                content = content.encode("utf-8")
             raw_data = json.loads(content)
         except json.JSONDecodeError as e:
             logger.error(
@@ -150,8 +166,11 @@ class AsyncService:
                 load_time=datetime.now(),
                 org_count=len(org_data.lookups.orgs),
                 employee_count=len(org_data.lookups.employees),
+                checksum=hashlib.sha256(content).hexdigest(),
             )
 
+            self._graph = None
+            self._search_index = None
             self._slack_channel_index = {}
             for team in org_data.lookups.teams.values():
                 if team.group.slack is None:
@@ -320,9 +339,12 @@ class AsyncService:
             return self._data.lookups.employees.get(uid)
 
     async def get_employee_by_email(self, email: str) -> Employee | None:
-        """Get an employee by their email address."""
+        """Get an employee by their email address.
+
+        An empty address matches no one, not even employees without an email.
+        """
         async with self._lock:
-            if self._data is None:
+            if self._data is None or not email:
                 return None
             for emp in self._data.lookups.employees.values():
                 if emp.email.lower() == email.lower():
@@ -479,6 +501,19 @@ class AsyncService:
             return False
         return await self.is_employee_in_team(uid, team_name)
 
+    async def are_employees_in_team(
+        self, uids: list[str], team_name: str
+    ) -> dict[str, bool]:
+        """Report, for each UID, whether the employee is in a specific team."""
+        return {uid: await self.is_employee_in_team(uid, team_name) for uid in uids}
+
+    async def is_employee_in_any_team(self, uid: str, teams: list[str]) -> bool:
+        """Check if an employee is in any of the given teams."""
+        for team in teams:
+            if await self.is_employee_in_team(uid, team):
+                return True
+        return False
+
     async def is_employee_in_org(self, uid: str, org_name: str) -> bool:
         """Check if an employee is in a specific organization."""
         async with self._lock:
@@ -633,6 +668,63 @@ class AsyncService:
 
             return build_node(entity_name, entity_type, set())
 
+    async def find_path(
+        self, from_node: GraphNode, to_node: GraphNode
+    ) -> list[GraphEdge] | None:
+        """Get the shortest chain of relationships connecting two nodes.
+
+        Returns:
+            The edges from from_node to to_node, an empty list if they are
+            the same node, or None if either is unknown or they are not
+            connected.
+        """
+        async with self._lock:
+            if self._data is None:
+                return None
+            if self._graph is None:
+                self._graph = _build_graph(self._data)
+            return _find_path(self._graph, from_node, to_node)
+
+    async def neighborhood(self, node: GraphNode, hops: int) -> list[GraphEdge] | None:
+        """Get the edges of every relationship within hops steps of a node.
+
+        Returns:
+            Edges ordered by distance from node, or None if node is unknown
+            or hops is less than one.
+        """
+        async with self._lock:
+            if self._data is None:
+                return None
+            if self._graph is None:
+                self._graph = _build_graph(self._data)
+            return _neighborhood(self._graph, node, hops)
+
+    async def search(
+        self,
+        query: str,
+        *,
+        types: list[str] | None = None,
+        limit: int = _DEFAULT_SEARCH_LIMIT,
+        exact: bool = False,
+    ) -> list[SearchResult]:
+        """Search employees, teams, orgs, pillars and team groups, best first.
+
+        Args:
+            query: Words to search for
+            types: Entity types to keep, such as ["team", "org"]; all if None
+            limit: Maximum number of results; zero or less returns all
+            exact: Match the last word as a whole word only
+
+        Returns:
+            Matching results, scored and ranked as the Go library ranks them.
+        """
+        async with self._lock:
+            if self._data is None:
+                return []
+            if self._search_index is None:
+                self._search_index = _SearchIndex(self._data)
+            return self._search_index.search(query, types, limit, exact)
+
     async def get_user_organizations(self, slack_user_id: str) -> list[OrgInfo]:
         """Get the complete organizational hierarchy a Slack user belongs to."""
         async with self._lock:
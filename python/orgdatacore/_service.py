@@ -1,7 +1,11 @@
 """Service implementation for orgdatacore."""
 
+import bisect
+import hashlib
 import json
 import threading
+from collections import deque
+from collections.abc import Mapping
 from datetime import datetime, timedelta
 from typing import Any, cast
 
@@ -19,6 +23,9 @@ from ._types import (
     Employee,
     EscalationContactInfo,
     GitHubIDMappings,
+    GraphEdge,
+    GraphEdgeKind,
+    GraphNode,
     HierarchyNode,
     HierarchyPathEntry,
     Indexes,
@@ -33,6 +40,7 @@ from ._types import (
     OrgInfo,
     OrgInfoType,
     Pillar,
+    SearchResult,
     SlackIDMappings,
     Team,
     TeamGroup,
@@ -168,6 +176,297 @@ def _validate_data(data: Data, source: DataSource) -> None:
         )
 
 
+# Graph nodes are (type, name) and edges (kind, from type, from name, to type,
+# to name), so that edges sort like Go's compareEdges.
+_GraphNodeKey = tuple[str, str]
+_GraphEdgeKey = tuple[str, str, str, str, str]
+_NO_NODE: _GraphNodeKey = ("", "")
+
+
+def _build_graph(data: Data) -> dict[_GraphNodeKey, list[_GraphEdgeKey]]:
+    """Build the edges touching each node of the org graph.
+
+    Every node is present even when it has no edges. An employee is linked
+    to the groups of their membership index entries, except for the
+    ancestors of another of those groups, which the hierarchy already
+    connects them to.
+    """
+    lookups = data.lookups
+    groups: list[tuple[str, Mapping[str, Team | Org | Pillar | TeamGroup]]] = [
+        ("team", lookups.teams),
+        ("org", lookups.orgs),
+        ("pillar", lookups.pillars),
+        ("team_group", lookups.team_groups),
+    ]
+    graph: dict[_GraphNodeKey, list[_GraphEdgeKey]] = {
+        ("employee", uid): [] for uid in lookups.employees
+    }
+    for kind, entities in groups:
+        for name in entities:
+            graph[(kind, name)] = []
+
+    def add(edge: _GraphEdgeKey) -> None:
+        to = (edge[3], edge[4])
+        if to not in graph:
+            return  # dangling reference
+        graph[(edge[1], edge[2])].append(edge)
+        graph[to].append(edge)
+
+    parents: dict[_GraphNodeKey, _GraphNodeKey] = {}
+    for kind, entities in groups:
+        for name, entity in entities.items():
+            if entity.parent is not None:
+                parent = (entity.parent.type, entity.parent.name)
+                parents[(kind, name)] = parent
+                add((GraphEdgeKind.CHILD_OF, kind, name, *parent))
+
+    membership_index = data.indexes.membership.membership_index
+    for uid, emp in lookups.employees.items():
+        if emp.manager_uid and emp.manager_uid != uid:
+            manager = ("employee", emp.manager_uid)
+            add((GraphEdgeKind.REPORTS_TO, "employee", uid, *manager))
+        memberships = [(m.type, m.name) for m in membership_index.get(uid, ())]
+        implied: set[_GraphNodeKey] = set()
+        for group in memberships:
+            # Bounded by the number of groups so it terminates on cyclic data.
+            p = parents.get(group, _NO_NODE)
+            for _ in range(len(parents)):
+                if p == _NO_NODE:
+                    break
+                implied.add(p)
+                p = parents.get(p, _NO_NODE)
+        for group in memberships:
+            if group not in implied:
+                add((GraphEdgeKind.MEMBER_OF, "employee", uid, *group))
+
+    return {node: sorted(set(edges)) for node, edges in graph.items()}
+
+
+def _other_node(edge: _GraphEdgeKey, node: _GraphNodeKey) -> _GraphNodeKey:
+    if (edge[1], edge[2]) == node:
+        return (edge[3], edge[4])
+    return (edge[1], edge[2])
+
+
+def _graph_edges(edges: list[_GraphEdgeKey]) -> list[GraphEdge]:
+    return [
+        GraphEdge(
+            from_node=GraphNode(type=e[1], name=e[2]),
+            to_node=GraphNode(type=e[3], name=e[4]),
+            kind=e[0],
+        )
+        for e in edges
+    ]
+
+
+def _find_path(
+    graph: dict[_GraphNodeKey, list[_GraphEdgeKey]],
+    from_node: GraphNode,
+    to_node: GraphNode,
+) -> list[GraphEdge] | None:
+    """Breadth-first search, remembering the edge each node was reached by."""
+    start, goal = (from_node.type, from_node.name), (to_node.type, to_node.name)
+    if start not in graph or goal not in graph:
+        return None
+    if start == goal:
+        return []
+
+    via: dict[_GraphNodeKey, _GraphEdgeKey | None] = {start: None}
+    queue = deque([start])
+    while queue:
+        node = queue.popleft()
+        for edge in graph[node]:
+            nxt = _other_node(edge, node)
+            if nxt in via:
+                continue
+            via[nxt] = edge
+            if nxt == goal:
+                path: list[_GraphEdgeKey] = []
+                n = goal
+                while n != start:
+                    e = cast(_GraphEdgeKey, via[n])
+                    path.append(e)
+                    n = _other_node(e, n)
+                path.reverse()
+                return _graph_edges(path)
+            queue.append(nxt)
+    return None
+
+
+def _neighborhood(
+    graph: dict[_GraphNodeKey, list[_GraphEdgeKey]], node: GraphNode, hops: int
+) -> list[GraphEdge] | None:
+    """Collect the edges within hops of node, nearest first."""
+    start = (node.type, node.name)
+    if hops < 1 or start not in graph:
+        return None
+
+    edges: list[_GraphEdgeKey] = []
+    seen_edges: set[_GraphEdgeKey] = set()
+    seen = {start}
+    frontier = [start]
+    for _ in range(hops):
+        nxt: list[_GraphNodeKey] = []
+        for n in frontier:
+            for edge in graph[n]:
+                if edge not in seen_edges:
+                    seen_edges.add(edge)
+                    edges.append(edge)
+                other = _other_node(edge, n)
+                if other not in seen:
+                    seen.add(other)
+                    nxt.append(other)
+        frontier = nxt
+    return _graph_edges(edges)
+
+
+# Weights of a word by the field it was found in, and bonuses for results
+# whose name is the query, or starts with it, as in Go's Search. A word
+# completed from a prefix counts half.
+_SEARCH_NAME_WEIGHT = 4.0
+_SEARCH_KEYWORD_WEIGHT = 2.0
+_SEARCH_EMAIL_WEIGHT = 2.0
+_SEARCH_DESCRIPTION_WEIGHT = 1.0
+_SEARCH_WHOLE_NAME_BONUS = 8.0
+_SEARCH_NAME_PREFIX_BONUS = 4.0
+_DEFAULT_SEARCH_LIMIT = 10
+
+
+def _tokenize(text: str) -> list[str]:
+    """Split text into lowercase words at anything not a letter or digit."""
+    words: list[str] = []
+    word: list[str] = []
+    for c in text.lower():
+        if c.isalpha() or c.isdecimal():
+            word.append(c)
+        elif word:
+            words.append("".join(word))
+            word = []
+    if word:
+        words.append("".join(word))
+    return words
+
+
+class _SearchDoc:
+    __slots__ = ("type", "name", "label", "names")
+
+    def __init__(self, type_: str, name: str, label: str, names: list[str]) -> None:
+        self.type = type_
+        self.name = name
+        self.label = label
+        self.names = names  # tokenized names, each joined by spaces
+
+
+class _SearchIndex:
+    """Maps the words of the searchable fields to the entities they are in."""
+
+    def __init__(self, data: Data) -> None:
+        self.docs: list[_SearchDoc] = []
+        # word -> doc -> weight of its best field
+        self.postings: dict[str, dict[int, float]] = {}
+
+        lookups = data.lookups
+        for uid, emp in lookups.employees.items():
+            names = [" ".join(_tokenize(uid))]
+            if emp.full_name:
+                names.append(" ".join(_tokenize(emp.full_name)))
+            doc = self._add(_SearchDoc("employee", uid, emp.full_name or uid, names))
+            self._add_field(doc, _SEARCH_NAME_WEIGHT, uid)
+            self._add_field(doc, _SEARCH_NAME_WEIGHT, emp.full_name)
+            self._add_field(doc, _SEARCH_EMAIL_WEIGHT, emp.email)
+            self._add_field(doc, _SEARCH_DESCRIPTION_WEIGHT, emp.job_title)
+        groups: list[tuple[str, Mapping[str, Team | Org | Pillar | TeamGroup]]] = [
+            ("team", lookups.teams),
+            ("org", lookups.orgs),
+            ("pillar", lookups.pillars),
+            ("team_group", lookups.team_groups),
+        ]
+        for kind, entities in groups:
+            for name, entity in entities.items():
+                self._add_group(kind, name, entity)
+        self.words = sorted(self.postings)  # for prefix lookups
+
+    def _add(self, doc: _SearchDoc) -> int:
+        self.docs.append(doc)
+        return len(self.docs) - 1
+
+    def _add_field(self, doc: int, weight: float, text: str) -> None:
+        for word in _tokenize(text):
+            docs = self.postings.setdefault(word, {})
+            docs[doc] = max(docs.get(doc, 0.0), weight)
+
+    def _add_group(
+        self, kind: str, name: str, entity: Team | Org | Pillar | TeamGroup
+    ) -> None:
+        names = [" ".join(_tokenize(name))]
+        if entity.tab_name:
+            names.append(" ".join(_tokenize(entity.tab_name)))
+        doc = self._add(_SearchDoc(kind, name, entity.tab_name or name, names))
+        self._add_field(doc, _SEARCH_NAME_WEIGHT, name)
+        self._add_field(doc, _SEARCH_NAME_WEIGHT, entity.tab_name)
+        self._add_field(doc, _SEARCH_DESCRIPTION_WEIGHT, entity.description)
+        for kw in entity.group.keywords:
+            self._add_field(doc, _SEARCH_KEYWORD_WEIGHT, kw)
+        for email in entity.group.emails:
+            self._add_field(doc, _SEARCH_EMAIL_WEIGHT, email.address)
+
+    def match(self, word: str, prefix: bool) -> dict[int, float]:
+        """Weight of word in each doc, counting completions when prefix is set."""
+        matches = dict(self.postings.get(word, {}))
+        if not prefix:
+            return matches
+        i = bisect.bisect_left(self.words, word)
+        while i < len(self.words) and self.words[i].startswith(word):
+            if self.words[i] != word:
+                for doc, weight in self.postings[self.words[i]].items():
+                    matches[doc] = max(matches.get(doc, 0.0), weight / 2)
+            i += 1
+        return matches
+
+    def search(
+        self, query: str, types: list[str] | None, limit: int, exact: bool
+    ) -> list[SearchResult]:
+        words = _tokenize(query)
+        if not words:
+            return []
+
+        scores: dict[int, float] | None = None
+        for i, word in enumerate(words):
+            matches = self.match(word, not exact and i == len(words) - 1)
+            if scores is None:
+                scores = matches
+                continue
+            scores = {
+                doc: score + matches[doc]
+                for doc, score in scores.items()
+                if doc in matches
+            }
+
+        phrase = " ".join(words)
+        results: list[SearchResult] = []
+        for i, score in (scores or {}).items():
+            doc = self.docs[i]
+            if types and doc.type not in types:
+                continue
+            for name in doc.names:
+                if name == phrase:
+                    score += _SEARCH_WHOLE_NAME_BONUS
+                    break
+                if name.startswith(phrase):
+                    score += _SEARCH_NAME_PREFIX_BONUS
+                    break
+            results.append(
+                SearchResult(
+                    type=doc.type, name=doc.name, label=doc.label, score=score
+                )
+            )
+        # Labels compare by their UTF-8 length, as in Go.
+        results.sort(key=lambda r: (-r.score, len(r.label.encode()), r.type, r.name))
+        if limit > 0:
+            del results[limit:]
+        return results
+
+
 class Service:
     """
     Service implements the core organizational data service.
@@ -200,6 +499,8 @@ class Service:
         self._watcher_running = False
         self._stop_event = threading.Event()
         self._slack_channel_index: dict[str, list[str]] = {}
+        self._graph: dict[_GraphNodeKey, list[_GraphEdgeKey]] | None = None
+        self._search_index: _SearchIndex | None = None
 
         if data_source is not None:
             self.load_from_data_source(data_source)
@@ -226,7 +527,10 @@ class Service:
             raise DataLoadError(f"failed to load from data source {source}: {e}") from e
 
         try:
-            raw_data = json.load(reader)
+            content = reader.read()
+            if isinstance(content, str):
+                content = content.encode("utf-8")
+            raw_data = json.loads(content)
         except json.JSONDecodeError as e:
             logger.error(
                 "Failed to parse JSON", extra={"source": str(source), "error": str(e)}
@@ -256,8 +560,11 @@ class Service:
                 load_time=datetime.now(),
                 org_count=len(org_data.lookups.orgs),
                 employee_count=len(org_data.lookups.employees),
+                checksum=hashlib.sha256(content).hexdigest(),
             )
 
+            self._graph = None
+            self._search_index = None
             self._slack_channel_index = {}
             for team in org_data.lookups.teams.values():
                 if team.group.slack is None:
@@ -383,9 +690,12 @@ class Service:
             return self._data.lookups.employees.get(uid)
 
     def get_employee_by_email(self, email: str) -> Employee | None:
-        """Get an employee by their email address."""
+        """Get an employee by their email address.
+
+        An empty address matches no one, not even employees without an email.
+        """
         with self._lock:
-            if self._data is None or not self._data.lookups.employees:
+            if self._data is None or not self._data.lookups.employees or not email:
                 return None
             email_lower = email.lower()
             for emp in self._data.lookups.employees.values():
@@ -643,6 +953,19 @@ class Service:
                 return False
             return self._is_employee_in_team(uid, team_name)
 
+    def are_employees_in_team(self, uids: list[str], team_name: str) -> dict[str, bool]:
+        """Report, for each UID, whether the employee is in a specific team.
+
+        The lock is taken once for all of them.
+        """
+        with self._lock:
+            return {uid: self._is_employee_in_team(uid, team_name) for uid in uids}
+
+    def is_employee_in_any_team(self, uid: str, teams: list[str]) -> bool:
+        """Check if an employee is in any of the given teams."""
+        with self._lock:
+            return any(self._is_employee_in_team(uid, team) for team in teams)
+
     def is_employee_in_org(self, uid: str, org_name: str) -> bool:
         """Check if an employee is in a specific organization."""
         with self._lock:
@@ -984,6 +1307,82 @@ class Service:
 
             return build_node(entity_name, entity_type, set())
 
+    def _graph_index(self) -> dict[_GraphNodeKey, list[_GraphEdgeKey]]:
+        """Internal: Build the org graph on first use. Caller must hold lock."""
+        if self._graph is None:
+            self._graph = _build_graph(cast(Data, self._data))
+        return self._graph
+
+    def find_path(
+        self, from_node: GraphNode, to_node: GraphNode
+    ) -> list[GraphEdge] | None:
+        """Get the shortest chain of relationships connecting two nodes.
+
+        Edges are followed in either direction but keep the direction the
+        data states. Of several shortest paths, the same one is returned
+        every time, and it is the one the Go library returns.
+
+        Args:
+            from_node: Employee (by UID) or team, org, pillar or team group
+            to_node: Node to reach
+
+        Returns:
+            The edges from from_node to to_node, an empty list if they are
+            the same node, or None if either is unknown or they are not
+            connected.
+        """
+        with self._lock:
+            if self._data is None:
+                return None
+            return _find_path(self._graph_index(), from_node, to_node)
+
+    def neighborhood(self, node: GraphNode, hops: int) -> list[GraphEdge] | None:
+        """Get the edges of every relationship within hops steps of a node.
+
+        Args:
+            node: Employee (by UID) or team, org, pillar or team group
+            hops: How many relationships away to go
+
+        Returns:
+            Edges ordered by distance from node, or None if node is unknown
+            or hops is less than one.
+        """
+        with self._lock:
+            if self._data is None:
+                return None
+            return _neighborhood(self._graph_index(), node, hops)
+
+    def search(
+        self,
+        query: str,
+        *,
+        types: list[str] | None = None,
+        limit: int = _DEFAULT_SEARCH_LIMIT,
+        exact: bool = False,
+    ) -> list[SearchResult]:
+        """Search employees, teams, orgs, pillars and team groups, best first.
+
+        Names, descriptions, keywords and emails are searched, with matches
+        on names ranking above the others. Every word of the query must
+        match a word of the result, ignoring case and punctuation; the last
+        word also matches words it is a prefix of, unless exact is set.
+
+        Args:
+            query: Words to search for
+            types: Entity types to keep, such as ["team", "org"]; all if None
+            limit: Maximum number of results; zero or less returns all
+            exact: Match the last word as a whole word only
+
+        Returns:
+            Matching results, scored and ranked as the Go library ranks them.
+        """
+        with self._lock:
+            if self._data is None:
+                return []
+            if self._search_index is None:
+                self._search_index = _SearchIndex(self._data)
+            return self._search_index.search(query, types, limit, exact)
+
     def get_jira_projects(self) -> list[str]:
         """Get all Jira project keys."""
         with self._lock:
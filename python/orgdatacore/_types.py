@@ -23,6 +23,14 @@ class MembershipType(StrEnum):
     ORG = "org"
 
 
+class GraphEdgeKind(StrEnum):
+    """Relationships between nodes of the org graph."""
+
+    MEMBER_OF = "member_of"
+    REPORTS_TO = "reports_to"
+    CHILD_OF = "child_of"
+
+
 class OrgInfoType(StrEnum):
     """Organization info types returned by get_user_organizations."""
 
@@ -424,6 +432,42 @@ class HierarchyNode(BaseModel):
     children: tuple["HierarchyNode", ...] = ()
 
 
+class GraphNode(BaseModel):
+    """An employee, identified by UID, or a team, org, pillar or team group,
+    identified by name, in the org graph."""
+
+    model_config = ConfigDict(frozen=True)
+
+    type: str = ""
+    name: str = ""
+
+
+class GraphEdge(BaseModel):
+    """A relationship between two nodes of the org graph, directed the way the
+    data states it. Serialized with "from" and "to" keys, as in Go."""
+
+    model_config = ConfigDict(frozen=True, populate_by_name=True)
+
+    from_node: GraphNode = Field(default_factory=GraphNode, alias="from")
+    to_node: GraphNode = Field(default_factory=GraphNode, alias="to")
+    kind: GraphEdgeKind = GraphEdgeKind.MEMBER_OF
+
+
+class SearchResult(BaseModel):
+    """An employee or group matching a search query.
+
+    name is the UID of an employee or the name of a group; label is the full
+    name of an employee, or the tab name of a group, falling back to name.
+    """
+
+    model_config = ConfigDict(frozen=True)
+
+    type: str = ""
+    name: str = ""
+    label: str = ""
+    score: float = 0.0
+
+
 class ComponentOwnerInfo(BaseModel):
     """Represents an entity that owns a component, with ownership type."""
 
@@ -521,6 +565,9 @@ class DataVersion(BaseModel):
     config_maps: dict[str, str] = Field(default_factory=dict)
     org_count: int = 0
     employee_count: int = 0
+    # Hex SHA-256 of the payload bytes as read from the source, so Go and
+    # Python services that loaded the same file report the same value.
+    checksum: str = ""
 
 
 class GCSConfig(BaseModel):
@@ -7,7 +7,13 @@ from typing import BinaryIO
 
 import pytest
 
-from orgdatacore import AsyncService, DataLoadError
+from orgdatacore import (
+    AsyncService,
+    DataLoadError,
+    GraphEdge,
+    GraphEdgeKind,
+    GraphNode,
+)
 from orgdatacore._internal.testing import create_test_data_json
 
 
@@ -386,6 +392,61 @@ class TestAsyncService:
         assert await service.is_employee_in_team("testuser1", "nonexistent") is False
         assert await service.is_employee_in_team("nonexistent", "test-squad") is False
 
+    @pytest.mark.asyncio
+    async def test_bulk_team_membership(self) -> None:
+        """Test checking several memberships at once."""
+        source = AsyncFakeDataSource(data=create_test_data_json())
+        service = AsyncService()
+        await service.load_from_data_source(source)
+
+        result = await service.are_employees_in_team(
+            ["testuser1", "nonexistent"], "test-squad"
+        )
+        assert result == {"testuser1": True, "nonexistent": False}
+        assert await service.is_employee_in_any_team(
+            "testuser1", ["nonexistent", "test-squad"]
+        )
+        assert not await service.is_employee_in_any_team("testuser1", ["nonexistent"])
+
+    @pytest.mark.asyncio
+    async def test_graph_queries(self) -> None:
+        """Test finding paths and neighborhoods in the org graph."""
+        source = AsyncFakeDataSource(data=create_test_data_json())
+        service = AsyncService()
+        await service.load_from_data_source(source)
+
+        user = GraphNode(type="employee", name="testuser1")
+        squad = GraphNode(type="team", name="test-squad")
+        division = GraphNode(type="org", name="test-division")
+        path = await service.find_path(user, division)
+        assert path is not None
+        assert len(path) == 4
+        assert path[0] == GraphEdge(
+            from_node=user, to_node=squad, kind=GraphEdgeKind.MEMBER_OF
+        )
+        assert await service.neighborhood(user, 1) == [
+            path[0],
+            GraphEdge(
+                from_node=user,
+                to_node=GraphNode(type="employee", name="testuser2"),
+                kind=GraphEdgeKind.REPORTS_TO,
+            ),
+        ]
+        assert await service.neighborhood(user, 0) is None
+
+    @pytest.mark.asyncio
+    async def test_search(self) -> None:
+        """Test searching by name, job title and type."""
+        source = AsyncFakeDataSource(data=create_test_data_json())
+        service = AsyncService()
+        await service.load_from_data_source(source)
+
+        results = await service.search("manager")
+        assert [(r.type, r.name) for r in results] == [("employee", "testuser2")]
+        results = await service.search("test", types=["team"])
+        assert [(r.type, r.name) for r in results] == [("team", "test-squad")]
+        assert await service.search("nonexistent") == []
+
     @pytest.mark.asyncio
     async def test_is_slack_user_in_team(self) -> None:
         """Test checking if Slack user is in team."""
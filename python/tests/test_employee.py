@@ -101,6 +101,31 @@ class TestGetEmployeeByGitHubID:
             assert result.uid == expected_uid
 
 
+class TestGetEmployeeByEmail:
+    """Tests for employee lookup by email."""
+
+    @pytest.mark.parametrize(
+        "email,expected_uid",
+        [
+            ("jsmith@example.com", "jsmith"),
+            ("ADOE@example.com", "adoe"),
+            ("nobody@example.com", None),
+            ("", None),
+        ],
+    )
+    def test_get_employee_by_email(
+        self, service: Service, email: str, expected_uid: str | None
+    ):
+        """Test employee lookup by email."""
+        result = service.get_employee_by_email(email)
+
+        if expected_uid is None:
+            assert result is None
+        else:
+            assert result is not None
+            assert result.uid == expected_uid
+
+
 class TestEmployeeFields:
     """Tests that all employee fields are properly loaded."""
 
@@ -0,0 +1,105 @@
+"""Tests for org graph queries."""
+
+from orgdatacore import GraphEdge, GraphEdgeKind, GraphNode, Service
+
+
+def team(name: str) -> GraphNode:
+    return GraphNode(type="team", name=name)
+
+
+def org(name: str) -> GraphNode:
+    return GraphNode(type="org", name=name)
+
+
+def employee(uid: str) -> GraphNode:
+    return GraphNode(type="employee", name=uid)
+
+
+def edge(from_node: GraphNode, to_node: GraphNode, kind: GraphEdgeKind) -> GraphEdge:
+    return GraphEdge(from_node=from_node, to_node=to_node, kind=kind)
+
+
+class TestFindPath:
+    """Tests for find_path method."""
+
+    def test_find_path_between_teams(self, service: Service) -> None:
+        """Test the path from one team up to a shared org and back down."""
+        engineering = GraphNode(type="pillar", name="engineering")
+        backend = GraphNode(type="team_group", name="backend-teams")
+        assert service.find_path(team("test-team"), team("platform-team")) == [
+            edge(team("test-team"), org("test-org"), GraphEdgeKind.CHILD_OF),
+            edge(org("platform-org"), org("test-org"), GraphEdgeKind.CHILD_OF),
+            edge(engineering, org("platform-org"), GraphEdgeKind.CHILD_OF),
+            edge(backend, engineering, GraphEdgeKind.CHILD_OF),
+            edge(team("platform-team"), backend, GraphEdgeKind.CHILD_OF),
+        ]
+
+    def test_find_path_through_team(self, service: Service) -> None:
+        """Test that employees are linked to their team, not the orgs above it."""
+        path = service.find_path(employee("bwilson"), org("platform-org"))
+        assert path is not None
+        assert len(path) == 4
+        assert path[0] == edge(
+            employee("bwilson"), team("platform-team"), GraphEdgeKind.MEMBER_OF
+        )
+
+    def test_find_path_to_manager(self, service: Service) -> None:
+        """Test the path from an employee to their manager."""
+        assert service.find_path(employee("jsmith"), employee("adoe")) == [
+            edge(employee("jsmith"), employee("adoe"), GraphEdgeKind.REPORTS_TO)
+        ]
+
+    def test_find_path_to_itself(self, service: Service) -> None:
+        """Test that a node is connected to itself by an empty path."""
+        assert service.find_path(team("test-team"), team("test-team")) == []
+
+    def test_find_path_unknown(self, service: Service) -> None:
+        """Test that unknown nodes have no path."""
+        assert service.find_path(team("test-team"), team("nonexistent")) is None
+        assert service.find_path(org("test-team"), team("platform-team")) is None
+
+    def test_find_path_empty_service(self, empty_service: Service) -> None:
+        """Test find_path before any data is loaded."""
+        assert empty_service.find_path(team("test-team"), team("test-team")) is None
+
+    def test_graph_edge_serializes_like_go(self, service: Service) -> None:
+        """Test that edges dump with Go's from and to keys."""
+        path = service.find_path(employee("jsmith"), employee("adoe"))
+        assert path is not None
+        assert path[0].model_dump(by_alias=True, mode="json") == {
+            "from": {"type": "employee", "name": "jsmith"},
+            "to": {"type": "employee", "name": "adoe"},
+            "kind": "reports_to",
+        }
+
+
+class TestNeighborhood:
+    """Tests for neighborhood method."""
+
+    def test_neighborhood_one_hop(self, service: Service) -> None:
+        """Test the direct relationships of a team."""
+        assert service.neighborhood(team("test-team"), 1) == [
+            edge(team("test-team"), org("test-org"), GraphEdgeKind.CHILD_OF),
+            edge(employee("adoe"), team("test-team"), GraphEdgeKind.MEMBER_OF),
+            edge(employee("jsmith"), team("test-team"), GraphEdgeKind.MEMBER_OF),
+        ]
+
+    def test_neighborhood_two_hops(self, service: Service) -> None:
+        """Test that farther edges follow the nearer ones."""
+        near = service.neighborhood(team("test-team"), 1)
+        edges = service.neighborhood(team("test-team"), 2)
+        assert near is not None and edges is not None
+        assert edges[: len(near)] == near
+        assert (
+            edge(org("platform-org"), org("test-org"), GraphEdgeKind.CHILD_OF)
+            in edges
+        )
+        assert (
+            edge(employee("jsmith"), employee("adoe"), GraphEdgeKind.REPORTS_TO)
+            in edges
+        )
+
+    def test_neighborhood_invalid(self, service: Service) -> None:
+        """Test that no hops or an unknown node give None."""
+        assert service.neighborhood(team("test-team"), 0) is None
+        assert service.neighborhood(team("nonexistent"), 1) is None
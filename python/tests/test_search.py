@@ -0,0 +1,74 @@
+"""Tests for search."""
+
+from typing import Any
+
+import pytest
+
+from orgdatacore import SearchResult, Service
+
+
+def result_names(results: list[SearchResult]) -> list[str]:
+    return [f"{r.type}:{r.name}" for r in results]
+
+
+class TestSearch:
+    """Tests for search method."""
+
+    @pytest.mark.parametrize(
+        ("query", "kwargs", "want"),
+        [
+            ("plat", {}, ["org:platform-org", "team:platform-team"]),
+            ("plat", {"types": ["team"]}, ["team:platform-team"]),
+            ("plat", {"limit": 1}, ["org:platform-org"]),
+            ("John Smith", {}, ["employee:jsmith"]),
+            ("PLATFORM-team", {}, ["team:platform-team"]),
+            ("qa", {}, ["team:test-team"]),
+            ("bwilson@example.com", {}, ["employee:bwilson"]),
+            (
+                "engineer",
+                {},
+                [
+                    "pillar:engineering",
+                    "employee:bwilson",
+                    "employee:jsmith",
+                    "team_group:backend-teams",
+                ],
+            ),
+            ("engineer", {"exact": True}, ["employee:bwilson", "employee:jsmith"]),
+            ("platform qa", {}, []),
+            ("nonexistent", {}, []),
+        ],
+        ids=[
+            "prefix of a name",
+            "filtered by type",
+            "limited",
+            "full name",
+            "case and punctuation ignored",
+            "keyword",
+            "email",
+            "name ranks above description",
+            "exact",
+            "every word must match",
+            "no match",
+        ],
+    )
+    def test_search(
+        self, service: Service, query: str, kwargs: dict[str, Any], want: list[str]
+    ) -> None:
+        """Test matching and ranking, as in Go's TestSearch."""
+        assert result_names(service.search(query, **kwargs)) == want
+
+    def test_search_result_fields(self, service: Service) -> None:
+        """Test that results carry a label and a positive score."""
+        results = service.search("John")
+        assert results
+        assert results[0].label == "John Smith"
+        assert results[0].score > 0
+
+    def test_search_no_words(self, service: Service) -> None:
+        """Test that a query without words matches nothing."""
+        assert service.search("  -- ") == []
+
+    def test_search_empty_service(self, empty_service: Service) -> None:
+        """Test search before any data is loaded."""
+        assert empty_service.search("plat") == []
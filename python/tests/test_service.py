@@ -1,5 +1,6 @@
 """Tests for the Service class - service initialization and data loading."""
 
+import hashlib
 from datetime import datetime
 from pathlib import Path
 
@@ -139,6 +140,7 @@ class TestGetVersion:
         assert version.load_time == datetime.min
         assert version.employee_count == 0
         assert version.org_count == 0
+        assert version.checksum == ""
 
     def test_version_after_loading(self, service: Service):
         """Version should be updated after loading data."""
@@ -147,6 +149,11 @@ class TestGetVersion:
         assert version.employee_count == 3
         assert version.org_count == 2
 
+    def test_version_checksum(self, service: Service, test_data_path: Path):
+        """Checksum should be the SHA-256 of the file as read, as in Go."""
+        want = hashlib.sha256(test_data_path.read_bytes()).hexdigest()
+        assert service.get_version().checksum == want
+
 
 class TestInvalidJSONHandling:
     """Tests for handling invalid JSON data."""
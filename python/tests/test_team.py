@@ -173,6 +173,32 @@ class TestIsEmployeeInTeam:
         assert result == expected
 
 
+class TestBulkTeamMembership:
+    """Tests for checking several memberships at once."""
+
+    def test_are_employees_in_team(self, service: Service):
+        """Test that each UID gets its own answer."""
+        result = service.are_employees_in_team(
+            ["jsmith", "bwilson", "nonexistent"], "test-team"
+        )
+        assert result == {"jsmith": True, "bwilson": False, "nonexistent": False}
+        assert service.are_employees_in_team([], "test-team") == {}
+
+    @pytest.mark.parametrize(
+        "uid,teams,expected",
+        [
+            ("bwilson", ["test-team", "platform-team"], True),
+            ("jsmith", ["platform-team", "nonexistent-team"], False),
+            ("jsmith", [], False),
+        ],
+    )
+    def test_is_employee_in_any_team(
+        self, service: Service, uid: str, teams: list[str], expected: bool
+    ):
+        """Test checking membership of any of several teams."""
+        assert service.is_employee_in_any_team(uid, teams) == expected
+
+
 class TestIsSlackUserInTeam:
     """Tests for Slack user team membership checks."""
 
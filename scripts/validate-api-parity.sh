@@ -16,4 +16,4 @@ cd "${REPO_ROOT}"
 
 # Run the parity check orchestrator
 # Use PYTHON env var if set (CI sets python3.12), otherwise default to python3
-${PYTHON:-python3} parity/run_parity_check.py
+${PYTHON:-python3} parity/run_parity_check.py "$@"